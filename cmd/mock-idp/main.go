@@ -0,0 +1,312 @@
+// mock-idp - SSO (OIDC) akışını yerelde denemek için basit kimlik sağlayıcı
+//
+// Sadece geliştirme/test içindir: kullanıcı bilgileri giriş formundan alınır, şifre sorulmaz.
+// Authorization code + PKCE (S256), discovery, JWKS ve RS256 imzalı ID token destekler.
+//
+//	go run ./cmd/mock-idp
+//
+// Ayarlar: MOCK_IDP_ADDR (:9000), MOCK_IDP_ISSUER (http://localhost:9000),
+// MOCK_IDP_CLIENT_ID (hospital-platform), MOCK_IDP_CLIENT_SECRET (mock-secret)
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"html/template"
+	"log"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	keyID          = "mock-idp-1"
+	codeTTL        = time.Minute
+	idTokenTTL     = 5 * time.Minute
+	accessTokenTTL = 5 * time.Minute
+)
+
+// authorizationCode - Giriş formundan sonra üretilen, token endpoint'inde bir kez kullanılabilen kod
+type authorizationCode struct {
+	clientID      string
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+	claims        jwt.MapClaims
+	expiresAt     time.Time
+}
+
+type server struct {
+	issuer       string
+	clientID     string
+	clientSecret string
+	key          *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]*authorizationCode
+}
+
+func main() {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		log.Fatal("RSA anahtarı üretilemedi: ", err)
+	}
+
+	s := &server{
+		issuer:       getEnv("MOCK_IDP_ISSUER", "http://localhost:9000"),
+		clientID:     getEnv("MOCK_IDP_CLIENT_ID", "hospital-platform"),
+		clientSecret: getEnv("MOCK_IDP_CLIENT_SECRET", "mock-secret"),
+		key:          key,
+		codes:        map[string]*authorizationCode{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", s.discovery)
+	mux.HandleFunc("/jwks", s.jwks)
+	mux.HandleFunc("/authorize", s.authorize)
+	mux.HandleFunc("/token", s.token)
+
+	addr := getEnv("MOCK_IDP_ADDR", ":9000")
+	log.Printf("🧪 Mock IdP çalışıyor: issuer=%s client_id=%s addr=%s\n", s.issuer, s.clientID, addr)
+	log.Fatal(http.ListenAndServe(addr, mux))
+}
+
+// discovery OpenID Provider metadata
+func (s *server) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                s.issuer,
+		"authorization_endpoint":                s.issuer + "/authorize",
+		"token_endpoint":                        s.issuer + "/token",
+		"jwks_uri":                              s.issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+		"token_endpoint_auth_methods_supported": []string{"client_secret_basic", "client_secret_post", "none"},
+		"scopes_supported":                      []string{"openid", "email", "profile", "phone", "groups"},
+	})
+}
+
+// jwks ID token imza anahtarının public kısmı
+func (s *server) jwks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": keyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(s.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(s.key.E)).Bytes()),
+		}},
+	})
+}
+
+// authorize GET'te giriş formunu gösterir, POST'ta code üretip redirect_uri'ye döner
+func (s *server) authorize(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "geçersiz istek", http.StatusBadRequest)
+		return
+	}
+
+	params := r.Form
+	if params.Get("response_type") != "code" || params.Get("client_id") != s.clientID || params.Get("redirect_uri") == "" {
+		http.Error(w, "response_type=code, doğru client_id ve redirect_uri gerekli", http.StatusBadRequest)
+		return
+	}
+	if params.Get("code_challenge") == "" || params.Get("code_challenge_method") != "S256" {
+		http.Error(w, "PKCE (code_challenge, S256) gerekli", http.StatusBadRequest)
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		loginPage.Execute(w, params)
+		return
+	}
+
+	redirect, err := url.Parse(params.Get("redirect_uri"))
+	if err != nil {
+		http.Error(w, "redirect_uri geçersiz", http.StatusBadRequest)
+		return
+	}
+	query := redirect.Query()
+	query.Set("state", params.Get("state"))
+
+	if params.Get("action") == "deny" {
+		query.Set("error", "access_denied")
+		redirect.RawQuery = query.Encode()
+		http.Redirect(w, r, redirect.String(), http.StatusFound)
+		return
+	}
+
+	email := strings.TrimSpace(params.Get("email"))
+	claims := jwt.MapClaims{
+		"sub":            subject(email),
+		"email":          email,
+		"email_verified": params.Get("email_verified") != "false",
+		"given_name":     params.Get("given_name"),
+		"family_name":    params.Get("family_name"),
+		"name":           strings.TrimSpace(params.Get("given_name") + " " + params.Get("family_name")),
+	}
+	if tckn := strings.TrimSpace(params.Get("tckn")); tckn != "" {
+		claims["tckn"] = tckn
+	}
+	if phone := strings.TrimSpace(params.Get("phone_number")); phone != "" {
+		claims["phone_number"] = phone
+	}
+	var groups []string
+	for _, group := range strings.Split(params.Get("groups"), ",") {
+		if group = strings.TrimSpace(group); group != "" {
+			groups = append(groups, group)
+		}
+	}
+	claims["groups"] = groups
+
+	code := randomString()
+	s.mu.Lock()
+	s.codes[code] = &authorizationCode{
+		clientID:      params.Get("client_id"),
+		redirectURI:   params.Get("redirect_uri"),
+		nonce:         params.Get("nonce"),
+		codeChallenge: params.Get("code_challenge"),
+		claims:        claims,
+		expiresAt:     time.Now().Add(codeTTL),
+	}
+	s.mu.Unlock()
+
+	query.Set("code", code)
+	redirect.RawQuery = query.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+// token authorization code'u PKCE verifier ile ID token'a çevirir
+func (s *server) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.ParseForm() != nil {
+		tokenError(w, "invalid_request")
+		return
+	}
+
+	clientID, clientSecret, basic := r.BasicAuth()
+	if basic {
+		clientID, _ = url.QueryUnescape(clientID)
+		clientSecret, _ = url.QueryUnescape(clientSecret)
+	} else {
+		clientID, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	// Secret gönderilmediyse public client kabul edilir (sadece PKCE)
+	if clientID != s.clientID || (clientSecret != "" && subtle.ConstantTimeCompare([]byte(clientSecret), []byte(s.clientSecret)) != 1) {
+		tokenError(w, "invalid_client")
+		return
+	}
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		tokenError(w, "unsupported_grant_type")
+		return
+	}
+
+	s.mu.Lock()
+	code, ok := s.codes[r.PostForm.Get("code")]
+	delete(s.codes, r.PostForm.Get("code"))
+	s.mu.Unlock()
+
+	if !ok || time.Now().After(code.expiresAt) || code.clientID != clientID || code.redirectURI != r.PostForm.Get("redirect_uri") {
+		tokenError(w, "invalid_grant")
+		return
+	}
+
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(sum[:]) != code.codeChallenge {
+		tokenError(w, "invalid_grant")
+		return
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{}
+	for name, value := range code.claims {
+		claims[name] = value
+	}
+	claims["iss"] = s.issuer
+	claims["aud"] = clientID
+	claims["iat"] = now.Unix()
+	claims["exp"] = now.Add(idTokenTTL).Unix()
+	claims["nonce"] = code.nonce
+
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	idToken.Header["kid"] = keyID
+	signed, err := idToken.SignedString(s.key)
+	if err != nil {
+		tokenError(w, "server_error")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   int(accessTokenTTL.Seconds()),
+		"id_token":     signed,
+	})
+}
+
+// ==================== HELPER FUNCTIONS ====================
+
+func tokenError(w http.ResponseWriter, code string) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{"error": code})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func randomString() string {
+	buf := make([]byte, 24)
+	rand.Read(buf)
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// subject e-postadan sabit bir sub üretir - aynı kullanıcı her girişte aynı sub'ı alır
+func subject(email string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(email)))
+	return base64.RawURLEncoding.EncodeToString(sum[:16])
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+var loginPage = template.Must(template.New("login").Parse(`<!DOCTYPE html>
+<html lang="tr">
+<head><meta charset="utf-8"><title>Mock IdP - Giriş</title>
+<style>body{font-family:sans-serif;max-width:420px;margin:40px auto}label{display:block;margin-top:10px}input{width:100%;padding:6px}button{margin-top:16px;padding:8px 16px}</style>
+</head>
+<body>
+<h2>🧪 Mock IdP</h2>
+<p>Test kimlik sağlayıcı - şifre sorulmaz, girilen bilgiler ID token'a yazılır.</p>
+<form method="post" action="/authorize">
+{{range $name, $values := .}}{{range $values}}<input type="hidden" name="{{$name}}" value="{{.}}">{{end}}{{end}}
+<label>E-posta <input name="email" type="email" required></label>
+<label>Ad <input name="given_name"></label>
+<label>Soyad <input name="family_name"></label>
+<label>TC Kimlik No (tckn) <input name="tckn"></label>
+<label>Telefon (phone_number) <input name="phone_number" placeholder="+905551234567"></label>
+<label>Gruplar (groups, virgülle) <input name="groups" placeholder="HBYS-Hekim,HBYS-IK"></label>
+<label><input name="email_verified" type="checkbox" value="false" style="width:auto"> E-posta doğrulanmamış</label>
+<button name="action" value="allow">Giriş yap</button>
+<button name="action" value="deny">Reddet</button>
+</form>
+</body>
+</html>
+`))
@@ -0,0 +1,268 @@
+// soft-authenticator - Passkey (WebAuthn) akışını tarayıcısız denemek için yazılım authenticator'ı
+//
+// Sadece geliştirme/test içindir: anahtarlar düz bir JSON dosyasında saklanır, PIN/biyometri sorulmaz
+// (yanıtlar yine de kullanıcı doğrulandı bayrağıyla imzalanır). ES256 anahtar üretir, attestation "none" döner.
+//
+// API'nin döndürdüğü seçenekler stdin'den okunur, POST edilecek gövde stdout'a yazılır:
+//
+//	curl -s -X POST -H "Authorization: Bearer $TOKEN" localhost:8080/me/passkeys/options \
+//	  | go run ./cmd/soft-authenticator register \
+//	  | curl -s -X POST -H "Authorization: Bearer $TOKEN" -H 'Content-Type: application/json' -d @- localhost:8080/me/passkeys
+//
+//	curl -s -X POST localhost:8080/login/passkey/options \
+//	  | go run ./cmd/soft-authenticator login \
+//	  | curl -s -X POST -H 'Content-Type: application/json' -d @- localhost:8080/login/passkey
+//
+// Ayarlar: SOFT_AUTHN_FILE (soft-authenticator.json), SOFT_AUTHN_ORIGIN (http://localhost:3000)
+package main
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hospital-platform/utils"
+	"io"
+	"log"
+	"os"
+	"strings"
+)
+
+// storedCredential - Dosyada saklanan kimlik bilgisi
+type storedCredential struct {
+	ID         string `json:"id"`          // base64url
+	RPID       string `json:"rp_id"`       // Bağlı olduğu alan adı
+	UserHandle string `json:"user_handle"` // base64url
+	UserName   string `json:"user_name"`
+	PrivateKey string `json:"private_key"` // SEC1 DER, base64
+	SignCount  uint32 `json:"sign_count"`
+}
+
+type authenticatorState struct {
+	Credentials []storedCredential `json:"credentials"`
+}
+
+// creationOptions - /me/passkeys/options yanıtının kullanılan alanları
+type creationOptions struct {
+	Challenge string `json:"challenge"`
+	RP        struct {
+		ID string `json:"id"`
+	} `json:"rp"`
+	User struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"user"`
+	PubKeyCredParams []struct {
+		Alg int `json:"alg"`
+	} `json:"pubKeyCredParams"`
+	ExcludeCredentials []credentialDescriptor `json:"excludeCredentials"`
+}
+
+// requestOptions - /login/passkey/options yanıtının kullanılan alanları
+type requestOptions struct {
+	Challenge        string                 `json:"challenge"`
+	RPID             string                 `json:"rpId"`
+	AllowCredentials []credentialDescriptor `json:"allowCredentials"`
+}
+
+type credentialDescriptor struct {
+	ID string `json:"id"`
+}
+
+func main() {
+	log.SetFlags(0)
+	if len(os.Args) != 2 || (os.Args[1] != "register" && os.Args[1] != "login") {
+		log.Fatal("kullanım: soft-authenticator register|login < options.json")
+	}
+
+	statePath := getEnv("SOFT_AUTHN_FILE", "soft-authenticator.json")
+	origin := getEnv("SOFT_AUTHN_ORIGIN", "http://localhost:3000")
+
+	input, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		log.Fatal("stdin okunamadı: ", err)
+	}
+
+	state, err := loadState(statePath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var output interface{}
+	if os.Args[1] == "register" {
+		output, err = register(state, origin, input)
+	} else {
+		output, err = login(state, origin, input)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := saveState(statePath, state); err != nil {
+		log.Fatal(err)
+	}
+	if err := json.NewEncoder(os.Stdout).Encode(output); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// register yeni ES256 anahtar üretir ve navigator.credentials.create() yanıtını taklit eder
+func register(state *authenticatorState, origin string, input []byte) (interface{}, error) {
+	var options creationOptions
+	if err := json.Unmarshal(input, &options); err != nil || options.Challenge == "" {
+		return nil, fmt.Errorf("kayıt seçenekleri okunamadı: %s", strings.TrimSpace(string(input)))
+	}
+
+	supported := false
+	for _, param := range options.PubKeyCredParams {
+		supported = supported || param.Alg == utils.WEBAUTHN_ALG_ES256
+	}
+	if !supported {
+		return nil, errors.New("sunucu ES256 kabul etmiyor")
+	}
+	for _, excluded := range options.ExcludeCredentials {
+		if state.find(excluded.ID) != nil {
+			return nil, errors.New("bu authenticator kullanıcıya zaten kayıtlı (excludeCredentials)")
+		}
+	}
+
+	authenticator := &utils.SoftAuthenticator{Origin: origin}
+	credential, clientDataJSON, attestationObject, err := authenticator.Register(options.RP.ID, options.Challenge)
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalECPrivateKey(credential.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	id := encode(credential.ID)
+	state.Credentials = append(state.Credentials, storedCredential{
+		ID:         id,
+		RPID:       options.RP.ID,
+		UserHandle: options.User.ID,
+		UserName:   options.User.Name,
+		PrivateKey: base64.StdEncoding.EncodeToString(der),
+	})
+
+	return map[string]interface{}{
+		"name": "Soft authenticator",
+		"credential": map[string]interface{}{
+			"id":    id,
+			"rawId": id,
+			"type":  "public-key",
+			"response": map[string]interface{}{
+				"clientDataJSON":    encode(clientDataJSON),
+				"attestationObject": encode(attestationObject),
+				"transports":        []string{"internal"},
+			},
+		},
+	}, nil
+}
+
+// login uygun kimlik bilgisiyle navigator.credentials.get() yanıtını imzalar
+// allowCredentials boşsa alan adına kayıtlı ilk anahtar kullanılır (keşfedilebilir giriş)
+func login(state *authenticatorState, origin string, input []byte) (interface{}, error) {
+	var options requestOptions
+	if err := json.Unmarshal(input, &options); err != nil || options.Challenge == "" {
+		return nil, fmt.Errorf("giriş seçenekleri okunamadı: %s", strings.TrimSpace(string(input)))
+	}
+
+	var stored *storedCredential
+	for i := range state.Credentials {
+		candidate := &state.Credentials[i]
+		if candidate.RPID != options.RPID {
+			continue
+		}
+		if len(options.AllowCredentials) == 0 {
+			stored = candidate
+			break
+		}
+		for _, allowed := range options.AllowCredentials {
+			if allowed.ID == candidate.ID {
+				stored = candidate
+			}
+		}
+		if stored != nil {
+			break
+		}
+	}
+	if stored == nil {
+		return nil, fmt.Errorf("%s için kayıtlı anahtar yok (%s)", options.RPID, "önce register çalıştırın")
+	}
+
+	der, err := base64.StdEncoding.DecodeString(stored.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParseECPrivateKey(der)
+	if err != nil {
+		return nil, err
+	}
+
+	credential := &utils.SoftCredential{RPID: stored.RPID, PrivateKey: key, SignCount: stored.SignCount}
+	authenticator := &utils.SoftAuthenticator{Origin: origin}
+	clientDataJSON, authData, signature, err := authenticator.Assert(credential, options.Challenge)
+	if err != nil {
+		return nil, err
+	}
+	stored.SignCount = credential.SignCount
+
+	return map[string]interface{}{
+		"credential": map[string]interface{}{
+			"id":    stored.ID,
+			"rawId": stored.ID,
+			"type":  "public-key",
+			"response": map[string]interface{}{
+				"clientDataJSON":    encode(clientDataJSON),
+				"authenticatorData": encode(authData),
+				"signature":         encode(signature),
+				"userHandle":        stored.UserHandle,
+			},
+		},
+	}, nil
+}
+
+func (s *authenticatorState) find(id string) *storedCredential {
+	for i := range s.Credentials {
+		if s.Credentials[i].ID == id {
+			return &s.Credentials[i]
+		}
+	}
+	return nil
+}
+
+func loadState(path string) (*authenticatorState, error) {
+	state := &authenticatorState{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("%s okunamadı: %v", path, err)
+	}
+	return state, nil
+}
+
+func saveState(path string, state *authenticatorState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+func encode(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
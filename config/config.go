@@ -3,6 +3,8 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -22,3 +24,41 @@ func GetEnv(key, defaultValue string) string {
 	}
 	return value
 }
+
+// IsDevelopment uygulamanın development ortamında çalışıp çalışmadığını döndürür (APP_ENV)
+// APP_ENV tanımlı değilse production sayılır - değişkeni unutan kurulumda güvenlik kontrolleri kapanmaz
+func IsDevelopment() bool {
+	return GetEnv("APP_ENV", "production") == "development"
+}
+
+// GetEnvDuration süre tipindeki ortam değişkenini okur (örn: "15m", "24h")
+// Değer yoksa veya parse edilemezse varsayılan süre döner
+func GetEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("Warning: %s geçersiz süre değeri (%s), varsayılan kullanılıyor: %s\n", key, value, defaultValue)
+		return defaultValue
+	}
+	return duration
+}
+
+// GetEnvInt tam sayı tipindeki ortam değişkenini okur
+// Değer yoksa veya parse edilemezse varsayılan değer döner
+func GetEnvInt(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Warning: %s geçersiz sayı değeri (%s), varsayılan kullanılıyor: %d\n", key, value, defaultValue)
+		return defaultValue
+	}
+	return number
+}
@@ -0,0 +1,79 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ==================== DENEME SAYACI ANAHTARLARI ====================
+
+const (
+	LOGIN_FAIL_IDENTIFIER_PREFIX = "auth:login_fail:id:"        // Kimlik (email/telefon) bazlı başarısız giriş sayacı
+	LOGIN_FAIL_IP_PREFIX         = "auth:login_fail:ip:"        // IP bazlı başarısız giriş sayacı
+	LOGIN_DELAY_PREFIX           = "auth:login_delay:"          // Artan bekleme süresi (progressive delay)
+	LOGIN_LOCK_IDENTIFIER_PREFIX = "auth:login_lock:id:"        // Kimlik bazlı geçici kilit
+	LOGIN_LOCK_IP_PREFIX         = "auth:login_lock:ip:"        // IP bazlı geçici kilit
+	RESET_REQUEST_COUNT_PREFIX   = "auth:reset_request:"        // Telefon bazlı kod talebi sayacı
+	RESET_FAIL_COUNT_PREFIX      = "auth:reset_fail:"           // Telefon bazlı hatalı kod denemesi sayacı
+	TOTP_USED_PREFIX             = "auth:totp_used:"            // Kullanılmış TOTP kodları (tekrar oynatma koruması)
+	VERIFY_SEND_COUNT_PREFIX     = "auth:verify_send:"          // Konu bazlı doğrulama kodu gönderim sayacı
+	VERIFY_FAIL_COUNT_PREFIX     = "auth:verify_fail:"          // Konu bazlı hatalı doğrulama kodu denemesi sayacı
+	PASSWORD_CHANGE_FAIL_PREFIX  = "auth:password_change_fail:" // Kullanıcı bazlı hatalı mevcut şifre denemesi sayacı (/me/password)
+)
+
+// IncrementAttempt sayacı bir artırır, ilk artışta pencere süresini başlatır
+func IncrementAttempt(key string, window time.Duration) (int64, error) {
+	count, err := RedisClient.Incr(Ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if count == 1 {
+		if err := RedisClient.Expire(Ctx, key, window).Err(); err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
+
+// GetAttemptCount sayacın mevcut değerini getirir (kayıt yoksa 0)
+func GetAttemptCount(key string) (int64, error) {
+	count, err := RedisClient.Get(Ctx, key).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	return count, err
+}
+
+// SetBlock verilen süre boyunca geçerli bir engel (kilit/bekleme) anahtarı yazar
+func SetBlock(key string, ttl time.Duration) error {
+	return RedisClient.Set(Ctx, key, 1, ttl).Err()
+}
+
+// GetBlockTTL engelin kalan süresini döndürür, engel yoksa 0 döner
+func GetBlockTTL(key string) (time.Duration, error) {
+	ttl, err := RedisClient.TTL(Ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+// ClearAttempts sayaç ve engel anahtarlarını siler
+func ClearAttempts(keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return RedisClient.Del(Ctx, keys...).Err()
+}
+
+// MarkTOTPCodeUsed TOTP kodunu kullanılmış olarak işaretler
+// Kod geçerlilik penceresi içinde daha önce kullanıldıysa false döner
+func MarkTOTPCodeUsed(userID uint, code string, ttl time.Duration) (bool, error) {
+	key := fmt.Sprintf("%s%d:%s", TOTP_USED_PREFIX, userID, code)
+	return RedisClient.SetNX(Ctx, key, 1, ttl).Result()
+}
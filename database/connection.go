@@ -1,13 +1,16 @@
 package database
 
 import (
+	"encoding/json"
 	"fmt"
 	"hospital-platform/config"
 	"hospital-platform/model"
 	"log"
+	"time"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var DB *gorm.DB
@@ -32,8 +35,7 @@ func ConnectDB() {
 	fmt.Println("Veritabanı bağlantısı başarılı!")
 
 	// Sadece development ortamında tabloları temizle
-	appEnv := config.GetEnv("APP_ENV", "development")
-	if appEnv == "development" {
+	if config.IsDevelopment() {
 		fmt.Println("Development ortamı - Mevcut tabloları temizleniyor...")
 		dropTables()
 	} else {
@@ -55,9 +57,41 @@ func ConnectDB() {
 
 		// Main business tables
 		&model.Hospital{},
+		&model.HospitalDocument{},
 		&model.User{},
+		&model.UserStatusChange{},
+		&model.HospitalMembership{},
+		&model.Role{},
+		&model.RolePermission{},
 		&model.HospitalPolyclinic{},
 		&model.Staff{},
+		&model.ShiftTemplate{},
+		&model.StaffSchedule{},
+		&model.StaffScheduleEntry{},
+		&model.LeaveType{},
+		&model.LeaveBalance{},
+		&model.LeaveRequest{},
+		&model.DutyRoster{},
+		&model.DutyRosterSlot{},
+		&model.DutyAssignment{},
+
+		// Kimlik doğrulama tabloları
+		&model.MFARecoveryCode{},
+		&model.Passkey{},
+		&model.PasswordPolicy{},
+		&model.PasswordHistory{},
+		&model.Invitation{},
+		&model.JoinCode{},
+		&model.HospitalEmailDomain{},
+		&model.SignupRequest{},
+		&model.APIKey{},
+		&model.APIKeyScope{},
+		&model.HospitalSSOConfig{},
+		&model.SSORoleMapping{},
+
+		// Platform operatörü tabloları
+		&model.PlatformAdmin{},
+		&model.PlatformAuditLog{},
 
 		// Legacy tables (backward compatibility)
 		&model.Polyclinic{},
@@ -72,18 +106,231 @@ func ConnectDB() {
 
 	fmt.Println("Migration tamamlandı!")
 
+	// Eski is_active kolonunu hesap durumuna taşı
+	migrateUserStatus()
+
+	// Eski work_days kolonunu çalışma programlarına taşı
+	migrateWorkDays()
+
 	// Seed master data
 	seedMasterData()
+
+	// Rolleri olmayan (rol sistemi öncesi kaydolmuş) hastanelere varsayılan rolleri ekle
+	seedDefaultRoles()
+
+	// Sonradan eklenen yetkileri mevcut yetkili rollerine ver
+	syncAdminRolePermissions()
+}
+
+// CreateDefaultRoles hastane için varsayılan sistem rollerini (yetkili, çalışan) oluşturur
+// Hastane kaydı transaction'ı içinde de çağrılır
+func CreateDefaultRoles(tx *gorm.DB, hospitalID uint) error {
+	for name, permissions := range model.DefaultRolePermissions {
+		role := model.Role{
+			HospitalID: hospitalID,
+			Name:       name,
+			IsSystem:   true,
+		}
+		for _, permission := range permissions {
+			role.Permissions = append(role.Permissions, model.RolePermission{Permission: permission})
+		}
+		if err := tx.Create(&role).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// seedDefaultRoles hiç rolü olmayan hastaneler için varsayılan rolleri oluşturur
+func seedDefaultRoles() {
+	var hospitalIDs []uint
+	DB.Model(&model.Hospital{}).
+		Where("NOT EXISTS (SELECT 1 FROM roles WHERE roles.hospital_id = hospitals.id)").
+		Pluck("id", &hospitalIDs)
+
+	for _, hospitalID := range hospitalIDs {
+		if err := CreateDefaultRoles(DB, hospitalID); err != nil {
+			fmt.Printf("Varsayılan roller oluşturulamadı (hastane %d): %v\n", hospitalID, err)
+			continue
+		}
+		fmt.Printf("Created default roles for hospital: %d\n", hospitalID)
+	}
+}
+
+// syncAdminRolePermissions yetkili sistem rollerine eksik yetkileri ekler
+// Yetkili rolü düzenlenemediği için yeni tanımlanan yetkiler (ör. leaves:approve) ancak bu şekilde verilebilir
+func syncAdminRolePermissions() {
+	var roleIDs []uint
+	DB.Model(&model.Role{}).Where("is_system = ? AND name = ?", true, model.RoleYetkili).Pluck("id", &roleIDs)
+
+	var permissions []model.RolePermission
+	for _, roleID := range roleIDs {
+		for _, name := range model.AllPermissionNames() {
+			permissions = append(permissions, model.RolePermission{RoleID: roleID, Permission: name})
+		}
+	}
+	if len(permissions) == 0 {
+		return
+	}
+
+	result := DB.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(permissions, 500)
+	if result.Error != nil {
+		fmt.Println("Yetkili rol yetkileri güncellenemedi:", result.Error)
+		return
+	}
+	if result.RowsAffected > 0 {
+		fmt.Printf("Yetkili rollerine %d yeni yetki eklendi\n", result.RowsAffected)
+	}
+}
+
+// migrateUserStatus durum alanı öncesi pasifleştirilmiş kullanıcıları askıya alınmış olarak işaretler ve is_active kolonunu kaldırır
+func migrateUserStatus() {
+	if !DB.Migrator().HasColumn(&model.User{}, "is_active") {
+		return
+	}
+
+	result := DB.Exec("UPDATE users SET status = ?, status_reason = ? WHERE is_active = false",
+		model.UserStatusSuspended, "Durum alanı öncesinde pasifleştirilmiş hesap")
+	if result.Error != nil {
+		fmt.Println("Kullanıcı durumları taşınamadı:", result.Error)
+		return
+	}
+	if err := DB.Migrator().DropColumn(&model.User{}, "is_active"); err != nil {
+		fmt.Println("is_active kolonu kaldırılamadı:", err)
+		return
+	}
+	fmt.Printf("Kullanıcı durumları taşındı (%d askıya alınmış hesap)\n", result.RowsAffected)
+}
+
+// migrateWorkDays personel tablosundaki JSON çalışma günlerini hastanenin varsayılan vardiyasıyla haftalık programlara çevirir ve kolonu kaldırır
+// Program kayıt tarihinden itibaren geçerli olur; iki kez JSON'a çevrilmiş eski kayıtlar ("\"[1,2]\"") da okunur
+func migrateWorkDays() {
+	if !DB.Migrator().HasColumn("staffs", "work_days") {
+		return
+	}
+
+	var rows []struct {
+		ID         uint
+		HospitalID uint
+		WorkDays   *string
+		CreatedAt  time.Time
+	}
+	err := DB.Raw(`SELECT id, hospital_id, work_days::text AS work_days, created_at FROM staffs
+		WHERE NOT EXISTS (SELECT 1 FROM staff_schedules ss WHERE ss.staff_id = staffs.id)`).Scan(&rows).Error
+	if err != nil {
+		fmt.Println("Çalışma günleri okunamadı:", err)
+		return
+	}
+
+	err = DB.Transaction(func(tx *gorm.DB) error {
+		templates := make(map[uint]uint) // hastane → varsayılan vardiya
+		for _, row := range rows {
+			days := parseLegacyWorkDays(row.WorkDays)
+			if len(days) == 0 {
+				continue
+			}
+
+			templateID, ok := templates[row.HospitalID]
+			if !ok {
+				template := model.ShiftTemplate{HospitalID: row.HospitalID, Name: model.DefaultShiftTemplateName}
+				err := tx.Where(template).
+					Attrs(model.ShiftTemplate{StartTime: model.DefaultShiftTemplateStart, EndTime: model.DefaultShiftTemplateEnd}).
+					FirstOrCreate(&template).Error
+				if err != nil {
+					return err
+				}
+				templateID = template.ID
+				templates[row.HospitalID] = templateID
+			}
+
+			year, month, day := row.CreatedAt.Date()
+			schedule := model.StaffSchedule{
+				HospitalID:    row.HospitalID,
+				StaffID:       row.ID,
+				Pattern:       model.SchedulePatternWeekly,
+				CycleDays:     7,
+				EffectiveFrom: time.Date(year, month, day, 0, 0, 0, 0, time.UTC),
+			}
+			for _, weekday := range days {
+				schedule.Entries = append(schedule.Entries, model.StaffScheduleEntry{Day: weekday, ShiftTemplateID: templateID})
+			}
+			if err := tx.Create(&schedule).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Migrator().DropColumn("staffs", "work_days")
+	})
+	if err != nil {
+		fmt.Println("Çalışma günleri programlara taşınamadı:", err)
+		return
+	}
+	fmt.Printf("Çalışma günleri programlara taşındı (%d personel)\n", len(rows))
+}
+
+// parseLegacyWorkDays eski work_days değerini gün numaralarına çevirir (geçersiz ve tekrar eden günler atlanır)
+func parseLegacyWorkDays(value *string) []int {
+	if value == nil {
+		return nil
+	}
+	raw := []byte(*value)
+
+	// İki kez JSON'a çevrilmiş kayıtlar önce metne açılır
+	var nested string
+	if json.Unmarshal(raw, &nested) == nil {
+		raw = []byte(nested)
+	}
+	var days []int
+	if json.Unmarshal(raw, &days) != nil {
+		return nil
+	}
+
+	seen := make(map[int]bool)
+	var result []int
+	for _, day := range days {
+		if day >= 1 && day <= 7 && !seen[day] {
+			seen[day] = true
+			result = append(result, day)
+		}
+	}
+	return result
 }
 
 // dropTables removes problematic tables to allow clean migration
 func dropTables() {
 	// Önce foreign key constraint'leri olan tabloları sil
+	DB.Migrator().DropTable(&model.MFARecoveryCode{})
+	DB.Migrator().DropTable(&model.Passkey{})
+	DB.Migrator().DropTable(&model.UserStatusChange{})
+	DB.Migrator().DropTable(&model.HospitalMembership{})
+	DB.Migrator().DropTable(&model.APIKeyScope{})
+	DB.Migrator().DropTable(&model.APIKey{})
+	DB.Migrator().DropTable(&model.SSORoleMapping{})
+	DB.Migrator().DropTable(&model.HospitalSSOConfig{})
+	DB.Migrator().DropTable(&model.PasswordHistory{})
+	DB.Migrator().DropTable(&model.Invitation{})
+	DB.Migrator().DropTable(&model.SignupRequest{})
+	DB.Migrator().DropTable(&model.JoinCode{})
+	DB.Migrator().DropTable(&model.HospitalEmailDomain{})
+	DB.Migrator().DropTable(&model.PasswordPolicy{})
+	DB.Migrator().DropTable(&model.RolePermission{})
+	DB.Migrator().DropTable(&model.Role{})
 	DB.Migrator().DropTable(&model.User{})
+	DB.Migrator().DropTable(&model.DutyAssignment{})
+	DB.Migrator().DropTable(&model.DutyRosterSlot{})
+	DB.Migrator().DropTable(&model.DutyRoster{})
+	DB.Migrator().DropTable(&model.LeaveRequest{})
+	DB.Migrator().DropTable(&model.LeaveBalance{})
+	DB.Migrator().DropTable(&model.LeaveType{})
+	DB.Migrator().DropTable(&model.StaffScheduleEntry{})
+	DB.Migrator().DropTable(&model.StaffSchedule{})
+	DB.Migrator().DropTable(&model.ShiftTemplate{})
 	DB.Migrator().DropTable(&model.Staff{})
 	DB.Migrator().DropTable(&model.HospitalPolyclinic{})
+	DB.Migrator().DropTable(&model.HospitalDocument{})
 	DB.Migrator().DropTable(&model.Hospital{})
 	DB.Migrator().DropTable(&model.Polyclinic{})
+	DB.Migrator().DropTable(&model.PlatformAuditLog{})
+	DB.Migrator().DropTable(&model.PlatformAdmin{})
 
 	fmt.Println("Eski tablolar temizlendi.")
 }
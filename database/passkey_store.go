@@ -0,0 +1,19 @@
+package database
+
+import "time"
+
+// ==================== PASSKEY (WEBAUTHN) ANAHTARLARI ====================
+
+const (
+	PASSKEY_CEREMONY_PREFIX = "auth:passkey_ceremony:" // Kayıt/giriş töreninin challenge'ı (tür ve kullanıcı)
+)
+
+// SavePasskeyCeremony tarayıcıya gönderilen challenge'ın tören bilgisini saklar
+func SavePasskeyCeremony(challenge string, data []byte, ttl time.Duration) error {
+	return RedisClient.Set(Ctx, PASSKEY_CEREMONY_PREFIX+challenge, data, ttl).Err()
+}
+
+// ConsumePasskeyCeremony tören kaydını getirir ve siler (tek kullanımlık), yoksa redis.Nil döner
+func ConsumePasskeyCeremony(challenge string) ([]byte, error) {
+	return RedisClient.GetDel(Ctx, PASSKEY_CEREMONY_PREFIX+challenge).Bytes()
+}
@@ -0,0 +1,43 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ==================== PLATFORM ANAHTARLARI ====================
+
+const (
+	HOSPITAL_STATUS_PREFIX    = "platform:hospital_status:" // Hastane hesap durumu önbelleği (her istekte DB'ye gitmemek için)
+	PLATFORM_TOTP_USED_PREFIX = "platform:totp_used:"       // Operatörün kullanılmış TOTP kodları (kullanıcı ID'leriyle çakışmasın)
+
+	HOSPITAL_STATUS_TTL = 10 * time.Minute // Önbellek süresi - durum değişikliğinde anında güncellenir
+)
+
+// GetHospitalStatus önbellekteki hastane durumunu döndürür
+// Kayıt yoksa found=false döner, çağıran veritabanından okuyup SetHospitalStatus ile yazmalıdır
+func GetHospitalStatus(hospitalID uint) (status string, found bool, err error) {
+	status, err = RedisClient.Get(Ctx, fmt.Sprintf("%s%d", HOSPITAL_STATUS_PREFIX, hospitalID)).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return status, true, nil
+}
+
+// SetHospitalStatus hastane durumunu önbelleğe yazar
+func SetHospitalStatus(hospitalID uint, status string) error {
+	return RedisClient.Set(Ctx, fmt.Sprintf("%s%d", HOSPITAL_STATUS_PREFIX, hospitalID), status, HOSPITAL_STATUS_TTL).Err()
+}
+
+// MarkPlatformTOTPCodeUsed operatörün TOTP kodunu kullanılmış olarak işaretler
+// Kod geçerlilik penceresi içinde daha önce kullanıldıysa false döner
+func MarkPlatformTOTPCodeUsed(adminID uint, code string, ttl time.Duration) (bool, error) {
+	key := fmt.Sprintf("%s%d:%s", PLATFORM_TOTP_USED_PREFIX, adminID, code)
+	return RedisClient.SetNX(Ctx, key, 1, ttl).Result()
+}
@@ -2,6 +2,8 @@ package database
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"hospital-platform/config"
 	"time"
@@ -34,17 +36,57 @@ func ConnectRedis() {
 	fmt.Printf("✅ Redis bağlantısı başarılı: %s\n", addr)
 }
 
-func SetResetCode(phone string, code string) error {
-	err := RedisClient.Set(Ctx, phone, code, 5*time.Minute).Err() // kod 5 dakika geçerli
-	return err
+// RESET_CODE_TTL şifre sıfırlama kodunun geçerlilik süresi
+const RESET_CODE_TTL = 5 * time.Minute
+
+// RESET_CODE_PREFIX şifre sıfırlama kodlarının namespace'i
+// Anahtar telefonun hash'i ile oluşur, Redis'te telefon numarası ve kodun kendisi düz metin tutulmaz
+const RESET_CODE_PREFIX = "auth:reset_code:"
+
+// resetCodeKey normalize edilmiş telefon numarasından hash'lenmiş Redis anahtarını üretir
+func resetCodeKey(phone string) string {
+	sum := sha256.Sum256([]byte(phone))
+	return RESET_CODE_PREFIX + hex.EncodeToString(sum[:])
+}
+
+// SetResetCode kodun hash'ini RESET_CODE_TTL süresiyle saklar (önceki kodun yerine geçer)
+func SetResetCode(phone string, codeHash string) error {
+	return RedisClient.Set(Ctx, resetCodeKey(phone), codeHash, RESET_CODE_TTL).Err()
 }
 
+// GetResetCode saklanan kod hash'ini getirir (kod yoksa redis.Nil döner)
 func GetResetCode(phone string) (string, error) {
-	code, err := RedisClient.Get(Ctx, phone).Result()
-	return code, err
+	return RedisClient.Get(Ctx, resetCodeKey(phone)).Result()
 }
 
+// DeleteResetCode kodu iptal eder
 func DeleteResetCode(phone string) error {
-	err := RedisClient.Del(Ctx, phone).Err()
-	return err
+	return RedisClient.Del(Ctx, resetCodeKey(phone)).Err()
+}
+
+// ==================== DOĞRULAMA KODLARI ====================
+
+// VERIFY_CODE_PREFIX telefon/e-posta doğrulama kodlarının namespace'i (davet kabulü, iletişim bilgisi değişikliği)
+// Anahtar konunun (ör. "invite:12") hash'i ile oluşur, değer kodun hash'idir
+const VERIFY_CODE_PREFIX = "auth:verify_code:"
+
+// verificationCodeKey doğrulama konusundan hash'lenmiş Redis anahtarını üretir
+func verificationCodeKey(subject string) string {
+	sum := sha256.Sum256([]byte(subject))
+	return VERIFY_CODE_PREFIX + hex.EncodeToString(sum[:])
+}
+
+// SetVerificationCode kodun hash'ini verilen süreyle saklar (önceki kodun yerine geçer)
+func SetVerificationCode(subject string, codeHash string, ttl time.Duration) error {
+	return RedisClient.Set(Ctx, verificationCodeKey(subject), codeHash, ttl).Err()
+}
+
+// GetVerificationCode saklanan kod hash'ini getirir (kod yoksa redis.Nil döner)
+func GetVerificationCode(subject string) (string, error) {
+	return RedisClient.Get(Ctx, verificationCodeKey(subject)).Result()
+}
+
+// DeleteVerificationCode kodu iptal eder
+func DeleteVerificationCode(subject string) error {
+	return RedisClient.Del(Ctx, verificationCodeKey(subject)).Err()
 }
@@ -0,0 +1,106 @@
+package database
+
+import (
+	"fmt"
+	"hospital-platform/model"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ==================== OTURUM ANAHTARLARI ====================
+
+const (
+	SESSION_PREFIX       = "auth:session:"       // Oturum kaydı (token ailesi ID'si ile) - hash
+	SESSION_TOUCH_PREFIX = "auth:session_touch:" // Son görülme güncellemesini seyrekleştiren işaret
+
+	SESSION_TOUCH_INTERVAL = time.Minute // Son görülme bilgisi en fazla bu sıklıkta yazılır
+)
+
+// CreateSession yeni oturum kaydını yazar
+func CreateSession(familyID string, session *model.SessionRecord, ttl time.Duration) error {
+	key := SESSION_PREFIX + familyID
+	pipe := RedisClient.TxPipeline()
+	pipe.HSet(Ctx, key, map[string]interface{}{
+		"user_id":      session.UserID,
+		"ip":           session.IP,
+		"last_ip":      session.LastIP,
+		"user_agent":   session.UserAgent,
+		"mfa":          strconv.FormatBool(session.MFA),
+		"created_at":   session.CreatedAt.Unix(),
+		"last_seen_at": session.LastSeenAt.Unix(),
+	})
+	pipe.Expire(Ctx, key, ttl)
+	_, err := pipe.Exec(Ctx)
+	return err
+}
+
+// GetSession oturum kaydını getirir, yoksa redis.Nil döner
+func GetSession(familyID string) (*model.SessionRecord, error) {
+	fields, err := RedisClient.HGetAll(Ctx, SESSION_PREFIX+familyID).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, redis.Nil
+	}
+
+	userID, _ := strconv.ParseUint(fields["user_id"], 10, 64)
+	mfa, _ := strconv.ParseBool(fields["mfa"])
+	createdAt, _ := strconv.ParseInt(fields["created_at"], 10, 64)
+	lastSeenAt, _ := strconv.ParseInt(fields["last_seen_at"], 10, 64)
+
+	return &model.SessionRecord{
+		UserID:     uint(userID),
+		IP:         fields["ip"],
+		LastIP:     fields["last_ip"],
+		UserAgent:  fields["user_agent"],
+		MFA:        mfa,
+		CreatedAt:  time.Unix(createdAt, 0),
+		LastSeenAt: time.Unix(lastSeenAt, 0),
+	}, nil
+}
+
+// SessionExists oturumun hâlâ aktif olup olmadığını kontrol eder
+func SessionExists(familyID string) (bool, error) {
+	count, err := RedisClient.Exists(Ctx, SESSION_PREFIX+familyID).Result()
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// TouchSession oturumun son görülme zamanını ve IP'sini günceller
+// Her istekte yazmamak için SESSION_TOUCH_INTERVAL içinde sadece ilk çağrı yazar; force ile aralık atlanır
+func TouchSession(familyID, ip string, force bool) error {
+	if !force {
+		first, err := RedisClient.SetNX(Ctx, SESSION_TOUCH_PREFIX+familyID, 1, SESSION_TOUCH_INTERVAL).Result()
+		if err != nil || !first {
+			return err
+		}
+	}
+
+	return touchSessionScript.Run(Ctx, RedisClient, []string{SESSION_PREFIX + familyID}, time.Now().Unix(), ip).Err()
+}
+
+// touchSessionScript sadece var olan oturumu günceller - iptal edilmiş oturumun kaydı yanlışlıkla yeniden oluşmaz
+var touchSessionScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 1 then
+	return redis.call("HSET", KEYS[1], "last_seen_at", ARGV[1], "last_ip", ARGV[2])
+end
+return 0
+`)
+
+// ExtendSession oturumun süresini uzatır (refresh token yenilendiğinde)
+// Kullanıcının aile listesi de aynı süreyle yenilenir; aksi halde login'den refresh TTL kadar sonra
+// liste silinir ve hâlâ aktif oturum, oturum listesinde ve toplu iptallerde görünmez olur
+func ExtendSession(userID uint, familyID string, ttl time.Duration) error {
+	familiesKey := fmt.Sprintf("%s%d", USER_TOKEN_FAMILIES_PREFIX, userID)
+	pipe := RedisClient.TxPipeline()
+	pipe.Expire(Ctx, SESSION_PREFIX+familyID, ttl)
+	pipe.SAdd(Ctx, familiesKey, familyID)
+	pipe.Expire(Ctx, familiesKey, ttl)
+	_, err := pipe.Exec(Ctx)
+	return err
+}
@@ -0,0 +1,19 @@
+package database
+
+import "time"
+
+// ==================== SSO (OIDC) ANAHTARLARI ====================
+
+const (
+	SSO_STATE_PREFIX = "auth:sso_state:" // IdP'ye yönlendirilen girişin state'i (PKCE verifier, nonce, hastane)
+)
+
+// SaveSSOState IdP'ye yönlendirmeden önce giriş denemesinin bilgilerini saklar
+func SaveSSOState(state string, data []byte, ttl time.Duration) error {
+	return RedisClient.Set(Ctx, SSO_STATE_PREFIX+state, data, ttl).Err()
+}
+
+// ConsumeSSOState state kaydını getirir ve siler (tek kullanımlık), yoksa redis.Nil döner
+func ConsumeSSOState(state string) ([]byte, error) {
+	return RedisClient.GetDel(Ctx, SSO_STATE_PREFIX+state).Bytes()
+}
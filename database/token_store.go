@@ -0,0 +1,132 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ==================== REFRESH TOKEN ANAHTARLARI ====================
+
+const (
+	REFRESH_TOKEN_PREFIX        = "auth:refresh:"        // Refresh token kaydı (token hash'i ile)
+	REFRESH_TOKEN_USED_PREFIX   = "auth:refresh_used:"   // Kullanılmış (rotate edilmiş) refresh token işareti
+	TOKEN_FAMILY_TOKENS_PREFIX  = "auth:family_tokens:"  // Bir token ailesine ait refresh token hash'leri
+	TOKEN_FAMILY_REVOKED_PREFIX = "auth:family_revoked:" // İptal edilmiş token aileleri
+	USER_TOKEN_FAMILIES_PREFIX  = "auth:user_families:"  // Kullanıcının aktif token aileleri
+	USER_TOKEN_VERSION_PREFIX   = "auth:user_version:"   // Kullanıcı token versiyonu - artırılınca eski token'lar geçersiz olur
+	USER_STATUS_PREFIX          = "auth:user_status:"    // Kullanıcı hesap durumu önbelleği (her istekte DB'ye gitmemek için)
+
+	USER_STATUS_TTL = 10 * time.Minute // Önbellek süresi - durum değişikliğinde anında güncellenir
+)
+
+// SaveRefreshToken refresh token kaydını Redis'e yazar ve token'ı ailesine bağlar
+func SaveRefreshToken(tokenHash string, familyID string, data []byte, ttl time.Duration) error {
+	pipe := RedisClient.TxPipeline()
+	pipe.Set(Ctx, REFRESH_TOKEN_PREFIX+tokenHash, data, ttl)
+	pipe.SAdd(Ctx, TOKEN_FAMILY_TOKENS_PREFIX+familyID, tokenHash)
+	pipe.Expire(Ctx, TOKEN_FAMILY_TOKENS_PREFIX+familyID, ttl)
+	_, err := pipe.Exec(Ctx)
+	return err
+}
+
+// GetRefreshToken refresh token kaydını getirir, yoksa redis.Nil döner
+func GetRefreshToken(tokenHash string) ([]byte, error) {
+	return RedisClient.Get(Ctx, REFRESH_TOKEN_PREFIX+tokenHash).Bytes()
+}
+
+// MarkRefreshTokenUsed token'ı kullanılmış olarak işaretler
+// Token daha önce kullanılmışsa false döner (yeniden kullanım tespiti)
+func MarkRefreshTokenUsed(tokenHash string, ttl time.Duration) (bool, error) {
+	return RedisClient.SetNX(Ctx, REFRESH_TOKEN_USED_PREFIX+tokenHash, 1, ttl).Result()
+}
+
+// RevokeTokenFamily aileye ait tüm refresh token'ları ve oturum kaydını siler, aileyi iptal edilmiş olarak işaretler
+// ttl, aileden üretilmiş en uzun ömürlü token'ın süresi kadar olmalıdır
+func RevokeTokenFamily(familyID string, ttl time.Duration) error {
+	familyKey := TOKEN_FAMILY_TOKENS_PREFIX + familyID
+
+	tokenHashes, err := RedisClient.SMembers(Ctx, familyKey).Result()
+	if err != nil && err != redis.Nil {
+		return err
+	}
+
+	pipe := RedisClient.TxPipeline()
+	for _, tokenHash := range tokenHashes {
+		pipe.Del(Ctx, REFRESH_TOKEN_PREFIX+tokenHash)
+	}
+	pipe.Del(Ctx, familyKey)
+	pipe.Del(Ctx, SESSION_PREFIX+familyID, SESSION_TOUCH_PREFIX+familyID)
+	pipe.Set(Ctx, TOKEN_FAMILY_REVOKED_PREFIX+familyID, 1, ttl)
+	_, err = pipe.Exec(Ctx)
+	return err
+}
+
+// IsTokenFamilyRevoked token ailesinin iptal edilip edilmediğini kontrol eder
+func IsTokenFamilyRevoked(familyID string) (bool, error) {
+	count, err := RedisClient.Exists(Ctx, TOKEN_FAMILY_REVOKED_PREFIX+familyID).Result()
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// ==================== KULLANICI BAZLI İPTAL ====================
+
+// AddUserTokenFamily yeni token ailesini kullanıcının aile listesine ekler
+func AddUserTokenFamily(userID uint, familyID string, ttl time.Duration) error {
+	key := fmt.Sprintf("%s%d", USER_TOKEN_FAMILIES_PREFIX, userID)
+	pipe := RedisClient.TxPipeline()
+	pipe.SAdd(Ctx, key, familyID)
+	pipe.Expire(Ctx, key, ttl)
+	_, err := pipe.Exec(Ctx)
+	return err
+}
+
+// GetUserTokenFamilies kullanıcının token ailelerini getirir
+func GetUserTokenFamilies(userID uint) ([]string, error) {
+	key := fmt.Sprintf("%s%d", USER_TOKEN_FAMILIES_PREFIX, userID)
+	return RedisClient.SMembers(Ctx, key).Result()
+}
+
+// RemoveUserTokenFamily aileyi kullanıcının aile listesinden çıkarır
+func RemoveUserTokenFamily(userID uint, familyID string) error {
+	key := fmt.Sprintf("%s%d", USER_TOKEN_FAMILIES_PREFIX, userID)
+	return RedisClient.SRem(Ctx, key, familyID).Err()
+}
+
+// GetUserTokenVersion kullanıcının güncel token versiyonunu getirir (kayıt yoksa 0)
+func GetUserTokenVersion(userID uint) (int64, error) {
+	key := fmt.Sprintf("%s%d", USER_TOKEN_VERSION_PREFIX, userID)
+	version, err := RedisClient.Get(Ctx, key).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	return version, err
+}
+
+// IncrementUserTokenVersion kullanıcının token versiyonunu artırır
+// Bu noktadan önce üretilmiş tüm access token'lar bir sonraki istekte reddedilir
+func IncrementUserTokenVersion(userID uint) (int64, error) {
+	key := fmt.Sprintf("%s%d", USER_TOKEN_VERSION_PREFIX, userID)
+	return RedisClient.Incr(Ctx, key).Result()
+}
+
+// GetUserStatus önbellekteki kullanıcı durumunu döndürür
+// Kayıt yoksa found=false döner, çağıran veritabanından okuyup SetUserStatus ile yazmalıdır
+func GetUserStatus(userID uint) (status string, found bool, err error) {
+	status, err = RedisClient.Get(Ctx, fmt.Sprintf("%s%d", USER_STATUS_PREFIX, userID)).Result()
+	if err == redis.Nil {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return status, true, nil
+}
+
+// SetUserStatus kullanıcı durumunu önbelleğe yazar
+func SetUserStatus(userID uint, status string) error {
+	return RedisClient.Set(Ctx, fmt.Sprintf("%s%d", USER_STATUS_PREFIX, userID), status, USER_STATUS_TTL).Err()
+}
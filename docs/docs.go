@@ -15,29 +15,67 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/hospital/polyclinics": {
+        "/.well-known/jwks.json": {
             "get": {
+                "description": "Access token'ları doğrulamak için kullanılan public key'ler. Token header'ındaki kid ile eşleşen anahtar kullanılır",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Token doğrulama anahtarları (JWKS)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JWKSet"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/switch-hospital": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Hastaneye ait poliklinikleri personel sayılarıyla listeler",
+                "description": "Mevcut oturumda, kullanıcının üye olduğu başka bir hastane için yeni access + refresh token üretir. Yeni token'daki hospital_id ve rol seçilen hastanedeki üyelikten gelir; tüm hastane işlemleri bu hastanede yapılır",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Polyclinic"
+                    "Auth"
+                ],
+                "summary": "Hastane değiştir",
+                "parameters": [
+                    {
+                        "description": "Geçilecek hastane",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.SwitchHospitalRequest"
+                        }
+                    }
                 ],
-                "summary": "Hastane polikliniklerini getir",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.HospitalPolyclinicSummary"
-                            }
+                            "$ref": "#/definitions/model.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "401": {
@@ -47,8 +85,43 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/api-keys": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hastanenin anahtarlarını durum, yetki ve son kullanım bilgisiyle listeler. Anahtarların kendisi gösterilmez",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API Keys"
+                ],
+                "summary": "API anahtarları",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.APIKeyResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -62,7 +135,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Seçilen poliklinik türünü hastaneye ekler",
+                "description": "HBYS/İK gibi entegrasyonlar için isimli, yetki kapsamlı, süreli ve isteğe bağlı IP kısıtlı anahtar üretir. Anahtar sadece bu yanıtta gösterilir, veritabanında hash'i tutulur",
                 "consumes": [
                     "application/json"
                 ],
@@ -70,17 +143,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Polyclinic"
+                    "API Keys"
                 ],
-                "summary": "Hastaneye poliklinik ekle",
+                "summary": "API anahtarı oluştur",
                 "parameters": [
                     {
-                        "description": "Poliklinik ekleme verisi",
+                        "description": "Anahtar ayarları",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.AddPolyclinicRequest"
+                            "$ref": "#/definitions/model.CreateAPIKeyRequest"
                         }
                     }
                 ],
@@ -88,7 +161,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/model.HospitalPolyclinic"
+                            "$ref": "#/definitions/model.CreateAPIKeyResponse"
                         }
                     },
                     "400": {
@@ -104,58 +177,47 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 }
             }
         },
-        "/hospital/polyclinics/{id}": {
-            "put": {
+        "/hospital/api-keys/scopes": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Hastane poliklinik bilgilerini günceller",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Anahtarlara verilebilecek yetkiler. Kullanıcı/rol yönetimi ve hastane ayarları yetkileri anahtarlara verilemez",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Polyclinic"
-                ],
-                "summary": "Hastane poliklinik güncelle",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Poliklinik ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Güncelleme verisi",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.UpdatePolyclinicRequest"
-                        }
-                    }
+                    "API Keys"
                 ],
+                "summary": "API anahtarı yetkileri",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.HospitalPolyclinic"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.PermissionInfo"
+                            }
                         }
                     },
                     "401": {
@@ -166,25 +228,27 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/hospital/api-keys/{id}": {
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Hastane poliklinik siler",
+                "description": "Anahtarla yapılan sonraki istekler hemen reddedilir",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Polyclinic"
+                    "API Keys"
                 ],
-                "summary": "Hastane poliklinik sil",
+                "summary": "API anahtarını iptal et",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Poliklinik ID",
+                        "description": "Anahtar ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -211,66 +275,62 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 }
             }
         },
-        "/hospital/register": {
-            "post": {
-                "description": "Yeni hastane ve admin kullanıcı kaydı yapar",
-                "consumes": [
-                    "application/json"
+        "/hospital/application": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Hastane bilgileri, başvuru durumu (pending, rejected, active...), red gerekçesi ve yüklenen belgeler. Onay beklerken de kullanılabilir",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Hospital"
-                ],
-                "summary": "Hastane kaydı",
-                "parameters": [
-                    {
-                        "description": "Hastane kayıt verisi",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.HospitalRegistrationRequest"
-                        }
-                    }
+                    "Hospital Application"
                 ],
+                "summary": "Başvuru durumu",
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.HospitalRegistrationResponse"
+                            "$ref": "#/definitions/model.HospitalApplicationResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            }
-        },
-        "/hospital/staff": {
-            "post": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Hastaneye yeni personel ekler",
+                "description": "Onay beklerken veya red sonrası hastane bilgileri düzeltilir. Onaylanan hastanelerde 409 döner",
                 "consumes": [
                     "application/json"
                 ],
@@ -278,25 +338,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Staff"
+                    "Hospital Application"
                 ],
-                "summary": "Personel ekle",
+                "summary": "Başvuruyu düzenle",
                 "parameters": [
                     {
-                        "description": "Personel ekleme verisi",
+                        "description": "Hastane bilgileri",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CreateStaffRequest"
+                            "$ref": "#/definitions/model.UpdateHospitalApplicationRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Staff"
+                            "$ref": "#/definitions/model.HospitalApplicationResponse"
                         }
                     },
                     "400": {
@@ -313,6 +373,13 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
@@ -323,40 +390,45 @@ const docTemplate = `{
                 }
             }
         },
-        "/hospital/staff/list": {
+        "/hospital/application/documents": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Hastane personellerini sayfalandırılmış ve filtreli olarak getirir",
+                "description": "Faaliyet izin belgesi (license - onay için zorunlu), vergi levhası, yetki belgesi vb. PDF, JPEG veya PNG, en fazla 10 MB",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Staff"
+                    "Hospital Application"
                 ],
-                "summary": "Personel listesi",
+                "summary": "Başvuru belgesi yükle",
                 "parameters": [
                     {
-                        "description": "Listeleme ve filtreleme verisi",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.StaffListRequest"
-                        }
+                        "type": "string",
+                        "description": "Belge türü (license, tax_certificate, authorization, other)",
+                        "name": "type",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Belge dosyası",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/model.StaffListResponse"
+                            "$ref": "#/definitions/model.HospitalDocument"
                         }
                     },
                     "400": {
@@ -372,29 +444,42 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 }
             }
         },
-        "/hospital/staff/{id}": {
+        "/hospital/application/documents/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "ID'ye göre personel detaylarını getirir",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "Staff"
+                    "Hospital Application"
                 ],
-                "summary": "Personel detayları",
+                "summary": "Başvuru belgesini indir",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Personel ID",
+                        "description": "Belge ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -404,7 +489,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Staff"
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -430,46 +515,35 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Personel bilgilerini günceller",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Sadece onay beklerken veya red sonrası silinebilir",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Staff"
+                    "Hospital Application"
                 ],
-                "summary": "Personel güncelle",
+                "summary": "Başvuru belgesini sil",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Personel ID",
+                        "description": "Belge ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Güncelleme verisi",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.UpdateStaffRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Staff"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
@@ -486,55 +560,61 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/hospital/application/resubmit": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Personeli siler",
+                "description": "Red gerekçesine göre bilgiler ve belgeler düzeltildikten sonra başvuru tekrar incelemeye (pending) gönderilir. Faaliyet izin belgesi zorunludur",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Staff"
-                ],
-                "summary": "Personel sil",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Personel ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Hospital Application"
                 ],
+                "summary": "Başvuruyu tekrar gönder",
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.HospitalApplicationResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -543,28 +623,31 @@ const docTemplate = `{
                 }
             }
         },
-        "/hospital/users": {
+        "/hospital/duty-rosters": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
                     }
                 ],
-                "description": "Hastaneye ait alt kullanıcıları listeler",
+                "description": "Hastanenin taslak ve yayınlanmış nöbet listelerini (atamalar olmadan) en yeni dönem başta olacak şekilde listeler",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User Management"
+                    "Duty Roster"
                 ],
-                "summary": "Alt kullanıcıları listele",
+                "summary": "Nöbet listeleri",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.User"
+                                "$ref": "#/definitions/model.DutyRoster"
                             }
                         }
                     },
@@ -583,7 +666,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Yetkili kullanıcı tarafından alt kullanıcı eklenir",
+                "description": "Poliklinik ve/veya meslek grubundaki aktif personelden taslak nöbet listesi üretir. Onaylı izindeki personele nöbet yazılmaz; çakışma, en az dinlenme ve art arda gece nöbeti kurallarına uyulur; hafta içi, hafta sonu ve tatil nöbetleri ile toplam saat eşit dağıtılmaya çalışılır. Aynı seed ve girdilerle her zaman aynı liste üretilir",
                 "consumes": [
                     "application/json"
                 ],
@@ -591,17 +674,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User Management"
+                    "Duty Roster"
                 ],
-                "summary": "Alt kullanıcı ekle",
+                "summary": "Nöbet listesi oluştur",
                 "parameters": [
                     {
-                        "description": "Alt kullanıcı bilgileri",
-                        "name": "user",
+                        "description": "Dönem, nöbetler ve kurallar",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CreateSubUserRequest"
+                            "$ref": "#/definitions/model.CreateDutyRosterRequest"
                         }
                     }
                 ],
@@ -609,7 +692,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/model.User"
+                            "$ref": "#/definitions/model.DutyRosterResponse"
                         }
                     },
                     "400": {
@@ -636,47 +719,38 @@ const docTemplate = `{
                 }
             }
         },
-        "/hospital/users/{id}": {
-            "put": {
+        "/hospital/duty-rosters/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
                     }
                 ],
-                "description": "Yetkili kullanıcı tarafından alt kullanıcı güncellenir",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Atamalar, personel bazında nöbet dağılımı (toplam, gece, hafta sonu, tatil, saat), kişi sayısı tamamlanamayan nöbetler ve güncel kural ihlalleri",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User Management"
+                    "Duty Roster"
                 ],
-                "summary": "Alt kullanıcı güncelle",
+                "summary": "Nöbet listesi detayı",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Kullanıcı ID",
+                        "description": "Nöbet listesi ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Güncelleme verisi",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.UpdateSubUserRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.User"
+                            "$ref": "#/definitions/model.DutyRosterResponse"
                         }
                     },
                     "400": {
@@ -693,8 +767,8 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -708,18 +782,18 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Yetkili kullanıcı tarafından alt kullanıcı silinir",
+                "description": "Yayınlanmış liste silinirse nöbetleri personel takviminden de kalkar",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User Management"
+                    "Duty Roster"
                 ],
-                "summary": "Alt kullanıcı sil",
+                "summary": "Nöbet listesini sil",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Kullanıcı ID",
+                        "description": "Nöbet listesi ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -746,18 +820,25 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 }
             }
         },
-        "/hospital/{id}": {
-            "get": {
+        "/hospital/duty-rosters/{id}/assignments": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "ID'ye göre hastane bilgilerini getirir",
+                "description": "Boş kalan veya kişi sayısı dolmamış nöbete personel ekler. Personel listenin poliklinik/meslek grubunda olmalı ve nöbet izin, çakışma, dinlenme ve art arda gece kurallarına uymalıdır",
                 "consumes": [
                     "application/json"
                 ],
@@ -765,23 +846,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Hospital"
+                    "Duty Roster"
                 ],
-                "summary": "Hastane bilgilerini getir",
+                "summary": "Nöbete personel ekle",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Hastane ID",
+                        "description": "Nöbet listesi ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Slot, tarih ve personel",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.DutyAssignmentRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/model.Hospital"
+                            "$ref": "#/definitions/model.DutyRosterResponse"
                         }
                     },
                     "400": {
@@ -804,32 +894,16 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
-                    }
-                }
-            }
-        },
-        "/job-groups": {
-            "get": {
-                "description": "Tüm meslek gruplarını getirir",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Staff"
-                ],
-                "summary": "Meslek grupları",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.JobGroup"
-                            }
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -838,33 +912,54 @@ const docTemplate = `{
                 }
             }
         },
-        "/job-groups/{job_group_id}/titles": {
-            "get": {
-                "description": "Seçilen meslek grubuna ait unvanları getirir",
+        "/hospital/duty-rosters/{id}/assignments/{assignment_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sadece staff_id kullanılır. Yeni personel listenin poliklinik/meslek grubunda olmalı ve nöbet kurallarına uymalıdır",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Staff"
+                    "Duty Roster"
                 ],
-                "summary": "Meslek grubuna göre unvanlar",
+                "summary": "Nöbeti başka personele ver",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Meslek grubu ID",
-                        "name": "job_group_id",
+                        "description": "Nöbet listesi ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Atama ID",
+                        "name": "assignment_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Yeni personel",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.DutyAssignmentRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.JobTitle"
-                            }
+                            "$ref": "#/definitions/model.DutyRosterResponse"
                         }
                     },
                     "400": {
@@ -874,43 +969,75 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            }
-        },
-        "/login": {
-            "post": {
-                "description": "Email veya telefon numarası ve şifre ile kullanıcı girişi yapılır",
-                "consumes": [
-                    "application/json"
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Nöbet boş kalır ve detaydaki boş nöbetler arasında görünür",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Duty Roster"
                 ],
-                "summary": "Kullanıcı girişi",
+                "summary": "Nöbet atamasını kaldır",
                 "parameters": [
                     {
-                        "description": "Giriş bilgileri",
-                        "name": "credentials",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.LoginRequest"
-                        }
+                        "type": "integer",
+                        "description": "Nöbet listesi ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Atama ID",
+                        "name": "assignment_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.DutyRosterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -922,32 +1049,85 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 }
             }
         },
-        "/polyclinic-types": {
-            "get": {
-                "description": "Master data'dan poliklinik türlerini getirir (dropdown için)",
+        "/hospital/duty-rosters/{id}/publish": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Kural ihlali olan liste yayınlanamaz (ör. liste oluşturulduktan sonra onaylanan izinler). Yayınlanan nöbetler personelin vardiya takviminde görünür ve liste artık değiştirilemez",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Polyclinic"
+                    "Duty Roster"
                 ],
-                "summary": "Poliklinik türleri listesi",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.PolyclinicType"
-                            }
+                "summary": "Nöbet listesini yayınla",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Nöbet listesi ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.DutyRosterResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -956,28 +1136,32 @@ const docTemplate = `{
                 }
             }
         },
-        "/polyclinics": {
+        "/hospital/email-domains": {
             "get": {
-                "description": "Sistemdeki tüm poliklinikleri listeler.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Polyclinic"
+                    "Signup Settings"
                 ],
-                "summary": "Tüm poliklinikleri getir",
+                "summary": "İzinli e-posta alan adları",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.Polyclinic"
+                                "$ref": "#/definitions/model.HospitalEmailDomain"
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -986,7 +1170,12 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Yeni bir poliklinik ekler.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Bu alan adındaki doğrulanmış e-posta adresleri katılım kodu olmadan başvurabilir. Bir alan adı tek hastaneye ait olabilir; herkese açık e-posta servisleri eklenemez",
                 "consumes": [
                     "application/json"
                 ],
@@ -994,17 +1183,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Polyclinic"
+                    "Signup Settings"
                 ],
-                "summary": "Yeni poliklinik oluştur",
+                "summary": "İzinli e-posta alan adı ekle",
                 "parameters": [
                     {
-                        "description": "Poliklinik verisi",
+                        "description": "Alan adı",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Polyclinic"
+                            "$ref": "#/definitions/model.CreateEmailDomainRequest"
                         }
                     }
                 ],
@@ -1012,8 +1201,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.HospitalEmailDomain"
                         }
                     },
                     "400": {
@@ -1022,70 +1210,49 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
-                    }
-                }
-            }
-        },
-        "/polyclinics/{id}": {
-            "put": {
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Polyclinic"
-                ],
-                "summary": "Poliklinik bilgilerini güncelle",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Poliklinik ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
                     },
-                    {
-                        "description": "Yeni poliklinik verisi",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/model.Polyclinic"
+                            "type": "object",
+                            "additionalProperties": true
                         }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            },
+            }
+        },
+        "/hospital/email-domains/{id}": {
             "delete": {
-                "description": "ID'ye göre poliklinik kaydını siler.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Polyclinic"
+                    "Signup Settings"
                 ],
-                "summary": "Poliklinik sil",
+                "summary": "İzinli e-posta alan adını kaldır",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Poliklinik ID",
+                        "description": "Alan adı ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1105,32 +1272,23 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
-                    }
-                }
-            }
-        },
-        "/provinces": {
-            "get": {
-                "description": "Dropdown için tüm illeri listeler",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Location"
-                ],
-                "summary": "Tüm illeri getir",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Province"
-                            }
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1139,23 +1297,27 @@ const docTemplate = `{
                 }
             }
         },
-        "/provinces/{province_id}/districts": {
+        "/hospital/invitations": {
             "get": {
-                "description": "Seçilen ile ait ilçeleri listeler",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hastanenin davetlerini listeler. Varsayılan olarak bekleyen (süresi dolmamış) davetler döner",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Location"
+                    "User Management"
                 ],
-                "summary": "İle göre ilçeleri getir",
+                "summary": "Davetleri listele",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "İl ID",
-                        "name": "province_id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "pending (varsayılan), accepted, revoked, expired veya all",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1164,7 +1326,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.District"
+                                "$ref": "#/definitions/model.InvitationResponse"
                             }
                         }
                     },
@@ -1175,19 +1337,22 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            }
-        },
-        "/register": {
+            },
             "post": {
-                "description": "Yeni kullanıcıyı kaydeder",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Yönetici ad, e-posta, telefon ve rol girer; davetli kişiye imzalı, tek kullanımlık bir link e-postayla gönderilir. Şifreyi davetli kişi belirler",
                 "consumes": [
                     "application/json"
                 ],
@@ -1195,26 +1360,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "User Management"
                 ],
-                "summary": "Yeni kullanıcı kaydı",
+                "summary": "Alt kullanıcı davet et",
                 "parameters": [
                     {
-                        "description": "Kullanıcı bilgileri",
-                        "name": "user",
+                        "description": "Davet bilgileri",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.User"
+                            "$ref": "#/definitions/model.CreateInvitationRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.InvitationResponse"
                         }
                     },
                     "400": {
@@ -1224,8 +1388,22 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1234,28 +1412,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/reset-password/confirm": {
-            "post": {
-                "description": "Kod doğruysa yeni şifre veritabanına yazılır",
-                "consumes": [
-                    "application/json"
+        "/hospital/invitations/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Bekleyen davetin linki artık kullanılamaz",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "User Management"
                 ],
-                "summary": "Kodla şifreyi sıfırla",
+                "summary": "Daveti iptal et",
                 "parameters": [
                     {
-                        "description": "Yeni şifre bilgileri",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.ResetPasswordConfirm"
-                        }
+                        "type": "integer",
+                        "description": "Davet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1272,682 +1450,12326 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
-                    }
-                }
-            }
-        },
-        "/reset-password/request": {
-            "post": {
-                "description": "Telefon numarasına geçici şifre sıfırlama kodu üretir",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Auth"
-                ],
-                "summary": "Şifre sıfırlama kodu gönder",
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/invitations/{id}/resend": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Yeni bir link üretilir ve süre yeniden başlar; önceki link geçersiz olur",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Daveti tekrar gönder",
                 "parameters": [
                     {
-                        "description": "Telefon numarası",
-                        "name": "request",
+                        "type": "integer",
+                        "description": "Davet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.InvitationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/join-codes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Kodların kendisi gösterilmez; son 4 karakter, rol, kullanım ve durum bilgisi döner",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Signup Settings"
+                ],
+                "summary": "Katılım kodları",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.JoinCodeResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Kodla başvuranlara atanacak rol sunucuda saklanır. Kod sadece bu yanıtta gösterilir, veritabanında hash'i tutulur",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Signup Settings"
+                ],
+                "summary": "Katılım kodu oluştur",
+                "parameters": [
+                    {
+                        "description": "Kod ayarları",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.ResetPasswordRequest"
+                            "$ref": "#/definitions/model.CreateJoinCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.JoinCodeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/join-codes/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Kod artık yeni başvurularda kullanılamaz; bekleyen başvurular etkilenmez",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Signup Settings"
+                ],
+                "summary": "Katılım kodunu iptal et",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Katılım kodu ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
+                }
+            }
+        },
+        "/hospital/leave-requests": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Talepler başlangıç tarihine göre sıralanır. from/to verilirse bu aralığa denk gelen talepler döner",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "İzin talepleri",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "pending, approved, rejected, cancelled (boşsa tümü)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Personel ID",
+                        "name": "staff_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Poliklinik ID",
+                        "name": "polyclinic_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Başlangıç günü (YYYY-AA-GG)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bitiş günü, dahil (YYYY-AA-GG)",
+                        "name": "to",
+                        "in": "query"
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.LeaveRequest"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    }
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Talep onay bekler durumda oluşur. İzinden düşen gün sayısı personelin çalışma programında vardiyası olan günlerden hesaplanır; aynı günlere bekleyen/onaylı başka talep olamaz ve takip edilen türlerde kalan hak aşılamaz. Yıl sonunu aşan izinler her yıl için ayrı girilir",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "İzin talebi oluştur",
+                "parameters": [
+                    {
+                        "description": "İzin talebi",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateLeaveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.LeaveRequest"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/leave-requests/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Onaylanan izin vardiya takviminde ve personel listesinde görünür. Takip edilen türlerde yıllık hak aşılıyorsa onaylanmaz",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "İzin talebini onayla",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "İzin talebi ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LeaveRequest"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/leave-requests/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Bekleyen talepler staff:write ile, onaylanmış izinler sadece leaves:approve yetkisiyle iptal edilir",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "İzin talebini iptal et",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "İzin talebi ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LeaveRequest"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/leave-requests/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Talep gerekçesiyle reddedilir",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "İzin talebini reddet",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "İzin talebi ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Red gerekçesi",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RejectLeaveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LeaveRequest"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/leave-types": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Hastanede tanımlı izin türlerini listeler. Hiç tür tanımlanmamışsa varsayılan türler (Yıllık İzin, Hastalık İzni, Kongre / Eğitim, Mazeret İzni) oluşturulur",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "İzin türleri",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.LeaveType"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Yıllık hak 0 ise türün kullanımı bakiyeden düşülmez (ör. hastalık izni)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "İzin türü ekle",
+                "parameters": [
+                    {
+                        "description": "İzin türü",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.LeaveTypeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.LeaveType"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/leave-types/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Yıllık hak değişikliği personele özel hak tanımlanmamış tüm personeli etkiler; girilmiş talepler değişmez",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "İzin türü güncelle",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "İzin türü ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "İzin türü",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.LeaveTypeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LeaveType"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Bekleyen veya onaylanmış talebi olan tür silinemez",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "İzin türü sil",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "İzin türü ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/members": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Birincil hastanesi başka olan ve bu hastanede de çalışan kullanıcılar (ör. konsültan hekimler), bu hastanedeki rolleriyle",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Hastane üyeleri",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.HospitalMemberResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Grubun başka bir hastanesinde hesabı olan kullanıcı, TC kimlik numarası ve hesabındaki e-posta ile bu hastaneye verilen rolle eklenir. Kullanıcı aynı hesapla giriş yapıp /auth/switch-hospital ile bu hastaneye geçer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Üye ekle",
+                "parameters": [
+                    {
+                        "description": "Kullanıcı ve bu hastanedeki rolü",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AddMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.HospitalMemberResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/members/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Üyenin bu hastanedeki rolü, polikliniği ve aktifliği değiştirilir; kullanıcının diğer hastanelerdeki hesabı etkilenmez. Açık token'lar bir sonraki istekte yenilenmelidir",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Üyeliği güncelle",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Üyelik ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rol, poliklinik ve durum",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.HospitalMemberResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Kullanıcının bu hastanedeki erişimi kaldırılır; birincil hastanesindeki hesabı etkilenmez",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Üyeliği kaldır",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Üyelik ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/permissions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rollere atanabilecek tüm yetkileri listeler",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role Management"
+                ],
+                "summary": "Yetki listesi",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.PermissionInfo"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/polyclinics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Hastaneye ait poliklinikleri personel sayılarıyla listeler",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Polyclinic"
+                ],
+                "summary": "Hastane polikliniklerini getir",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.HospitalPolyclinicSummary"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Seçilen poliklinik türünü hastaneye ekler",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Polyclinic"
+                ],
+                "summary": "Hastaneye poliklinik ekle",
+                "parameters": [
+                    {
+                        "description": "Poliklinik ekleme verisi",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AddPolyclinicRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.HospitalPolyclinic"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/polyclinics/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Hastane poliklinik bilgilerini günceller",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Polyclinic"
+                ],
+                "summary": "Hastane poliklinik güncelle",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Poliklinik ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Güncelleme verisi",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdatePolyclinicRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.HospitalPolyclinic"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Hastane poliklinik siler",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Polyclinic"
+                ],
+                "summary": "Hastane poliklinik sil",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Poliklinik ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/polyclinics/{id}/absences": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Poliklinikte izinli personeli gün gün listeler (en fazla 92 gün). Tarihler verilmezse içinde bulunulan ay. include_pending=true ile onay bekleyen talepler de gösterilir",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "Poliklinik devamsızlık takvimi",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Poliklinik ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Başlangıç günü (YYYY-AA-GG)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bitiş günü, dahil (YYYY-AA-GG)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Onay bekleyen talepleri de göster",
+                        "name": "include_pending",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.AbsenceCalendarDay"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/register": {
+            "post": {
+                "description": "Yeni hastane başvurusu ve admin kullanıcı oluşturur. Hastane platform onayına kadar \"pending\" durumundadır; dönen token sadece /hospital/application endpoint'lerinde (başvuruyu görüntüleme, düzenleme, belge yükleme) geçerlidir",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Hospital"
+                ],
+                "summary": "Hastane kaydı",
+                "parameters": [
+                    {
+                        "description": "Hastane kayıt verisi",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.HospitalRegistrationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.HospitalRegistrationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/roles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hastanede tanımlı rolleri yetkileri ve kullanıcı sayılarıyla listeler",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role Management"
+                ],
+                "summary": "Rol listesi",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.RoleResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hastaneye yeni rol ekler. Sadece kendi sahip olduğunuz yetkileri verebilirsiniz",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role Management"
+                ],
+                "summary": "Rol ekle",
+                "parameters": [
+                    {
+                        "description": "Rol bilgileri",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.RoleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/roles/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rolün adını, açıklamasını ve yetkilerini günceller. Yetki değişiklikleri bir sonraki istekte geçerli olur",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role Management"
+                ],
+                "summary": "Rol güncelle",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rol ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rol bilgileri",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.RoleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Kullanıcısı olmayan, sistem rolü olmayan rolü siler",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role Management"
+                ],
+                "summary": "Rol sil",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rol ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/settings/mfa": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hastanedeki yetkili kullanıcılar için MFA zorunluluğunu açar/kapatır",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MFA"
+                ],
+                "summary": "Hastane MFA politikası",
+                "parameters": [
+                    {
+                        "description": "MFA politikası",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateMFAPolicyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/settings/password-policy": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hastanenin şifre kurallarını döndürür (tanımlanmamışsa varsayılan politika). Şifre belirleme ekranlarında kuralları göstermek için kullanılabilir",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Hospital Settings"
+                ],
+                "summary": "Şifre politikası",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PasswordPolicy"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Minimum uzunluk, karakter sınıfları, yaygın şifre kontrolü, şifre geçmişi ve geçerlilik süresini ayarlar. Süresi dolan şifreler bir sonraki girişte değiştirilmelidir",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Hospital Settings"
+                ],
+                "summary": "Şifre politikasını güncelle",
+                "parameters": [
+                    {
+                        "description": "Şifre politikası",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdatePasswordPolicyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PasswordPolicy"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/settings/sso": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hastanenin OIDC ayarları ve IdP'de kayıtlı olması gereken dönüş adresi. Client secret gösterilmez",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Hospital Settings"
+                ],
+                "summary": "SSO ayarları",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SSOConfigResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issuer, client ID/secret ve claim → rol eşlemelerini kaydeder. Açık ayarlarda IdP discovery belgesi doğrulanır; eşlenen roller ayarı yapanın atayabileceği roller olmalıdır",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Hospital Settings"
+                ],
+                "summary": "SSO ayarlarını kaydet",
+                "parameters": [
+                    {
+                        "description": "SSO ayarları",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.SSOConfigRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SSOConfigResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "SSO ile giriş kapatılır, ayarlar ve rol eşlemeleri silinir",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Hospital Settings"
+                ],
+                "summary": "SSO ayarlarını sil",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/shift-templates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Hastanede tanımlı vardiya şablonlarını başlangıç saatine göre listeler",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Vardiya listesi",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ShiftTemplate"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Başlangıç ve bitiş saatiyle vardiya tanımlar. Bitiş başlangıçtan küçük veya eşitse vardiya ertesi gün biter (gece vardiyası, 24 saatlik nöbet)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Vardiya ekle",
+                "parameters": [
+                    {
+                        "description": "Vardiya bilgileri",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ShiftTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.ShiftTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/shift-templates/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Vardiyanın adını ve saatlerini günceller. Yeni saatler vardiyayı kullanan güncel veya gelecek bir programda çakışma ya da dinlenme ihlali doğuruyorsa değişiklik reddedilir",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Vardiya güncelle",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Vardiya ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Vardiya bilgileri",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ShiftTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ShiftTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Hiçbir çalışma programında kullanılmayan vardiyayı siler",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Vardiya sil",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Vardiya ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/signup-requests": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Varsayılan olarak onay bekleyen başvurular döner",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Kayıt başvuruları",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "pending (varsayılan), approved, rejected veya all",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.SignupRequest"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/signup-requests/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Başvuru onaylanır ve kullanıcı oluşturulur. Rol/poliklinik gönderilmezse kayıt yönteminin atadığı değerler kullanılır; kimse kendi yetkilerini aşan bir rol veremez",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Başvuruyu onayla",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Başvuru ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rol/poliklinik değişikliği",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.ApproveSignupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SignupRequest"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/signup-requests/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Başvuru gerekçesiyle reddedilir; başvuran e-postayla bilgilendirilir",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Başvuruyu reddet",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Başvuru ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Red gerekçesi",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RejectSignupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SignupRequest"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/staff": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Hastaneye yeni personel ekler",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Personel ekle",
+                "parameters": [
+                    {
+                        "description": "Personel ekleme verisi",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateStaffRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Staff"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/staff/export": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Listeleme ile aynı filtreleri alır; sayfalama alanları yok sayılır ve eşleşen tüm personel aynı sırayla aktarılır. Dosya satırlar okundukça gönderilir. CSV noktalı virgül ayraçlı ve BOM'ludur; CSV/XLSX başlıkları toplu içe aktarmayla uyumludur. PDF her sayfada hastane başlığı taşır",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Personel dışa aktar (CSV/XLSX/PDF)",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx",
+                            "pdf"
+                        ],
+                        "type": "string",
+                        "description": "Dosya biçimi",
+                        "name": "format",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Filtreleme verisi (page ve page_size yok sayılır)",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.StaffListRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/staff/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "İlk satır başlıktır: ad, soyad, tc, telefon, meslek grubu, unvan, çalışma günleri (zorunlu) ve poliklinik (opsiyonel). Meslek grubu, unvan ve poliklinik adla eşlenir; çalışma günleri \"1-5\", \"1,3,5\" veya \"Pazartesi-Cuma\" yazılabilir. Her satır tekil personel eklemeyle aynı kurallardan geçer. dry_run=true (varsayılan) sadece satır bazında rapor döner; dry_run=false hatasız satırları tek transaction ile ekler, hatalı satırlar atlanır",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Personel içe aktar (CSV/XLSX)",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV veya XLSX dosyası (en fazla 5 MB, 1000 satır)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "true: sadece doğrula (varsayılan), false: hatasız satırları ekle",
+                        "name": "dry_run",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StaffImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/staff/list": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Hastane personellerini sayfalandırılmış ve filtreli olarak getirir",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Personel listesi",
+                "parameters": [
+                    {
+                        "description": "Listeleme ve filtreleme verisi",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.StaffListRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StaffListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/staff/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "ID'ye göre personel detaylarını getirir",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Personel detayları",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Personel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Staff"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Personel bilgilerini günceller",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Personel güncelle",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Personel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Güncelleme verisi",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateStaffRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Staff"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Personeli siler",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Personel sil",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Personel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/staff/{id}/leave-balances": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Her izin türü için yıllık hak, onaylanmış ve bekleyen günler ile kalan hak. Yıl verilmezse içinde bulunulan yıl",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "Personel izin bakiyeleri",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Personel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Yıl",
+                        "name": "year",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.LeaveBalanceSummary"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "İzin türünün varsayılan yıllık hakkı yerine personele özel toplam hak (devreden izinler dahil) tanımlar. Hak 0 verilirse o yıl bu türde izin onaylanamaz",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "Personel izin hakkı tanımla",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Personel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Yıllık hak",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.LeaveBalanceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LeaveBalanceSummary"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/staff/{id}/schedules": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Personelin geçmiş, güncel ve gelecek çalışma programlarını başlangıç tarihine göre listeler",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Personel çalışma programları",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Personel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.StaffSchedule"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Haftalık (weekly, gün 1=Pazartesi..7=Pazar) veya dönüşümlü (rotating, effective_from döngünün 1. günüdür) program ekler. Personelin aynı tarihlere denk gelen başka programı olamaz; çakışan vardiyalar ve iki iş günü arasında asgari dinlenme süresinin (varsayılan 11 saat) altında kalan geçişler reddedilir",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Çalışma programı ekle",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Personel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Program bilgileri",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.StaffScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.StaffSchedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/staff/{id}/schedules/{schedule_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Programın desenini, tarihlerini ve vardiyalarını değiştirir; ekleme ile aynı kontroller uygulanır",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Çalışma programı güncelle",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Personel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Program ID",
+                        "name": "schedule_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Program bilgileri",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.StaffScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StaffSchedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Çalışma programı sil",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Personel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Program ID",
+                        "name": "schedule_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/staff/{id}/shifts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Programlardan hesaplanan somut vardiyaları başlangıç saatine göre döndürür (en fazla 92 gün). Tarihler verilmezse bugünden itibaren 7 gün",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Personel vardiya takvimi",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Personel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Başlangıç günü (YYYY-AA-GG)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bitiş günü, dahil (YYYY-AA-GG)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ShiftOccurrence"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hastaneye ait alt kullanıcıları listeler",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Alt kullanıcıları listele",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.User"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "users:manage yetkisine sahip kullanıcı tarafından alt kullanıcı eklenir. Yöneticinin belirlediği şifre ilk girişte değiştirilmelidir; şifreyi kullanıcının kendisinin belirlemesi için /hospital/invitations tercih edilmelidir",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Alt kullanıcı ekle",
+                "parameters": [
+                    {
+                        "description": "Alt kullanıcı bilgileri",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateSubUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/users/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "users:manage yetkisine sahip kullanıcı tarafından alt kullanıcı güncellenir",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Alt kullanıcı güncelle",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Kullanıcı ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Güncelleme verisi",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateSubUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "users:manage yetkisine sahip kullanıcı tarafından alt kullanıcı silinir",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Alt kullanıcı sil",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Kullanıcı ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/users/{id}/expire-password": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Kullanıcının bir sonraki girişte şifresini değiştirmesini zorunlu kılar",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Şifre değişikliğini zorunlu kıl",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Kullanıcı ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/users/{id}/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Yetkili, hastanedeki bir kullanıcının açık oturumlarını görür",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Kullanıcının oturumları",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Kullanıcı ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.SessionResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Yetkili, kullanıcıyı tüm cihazlardan çıkarır",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Kullanıcının tüm oturumlarını sonlandır",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Kullanıcı ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/users/{id}/sessions/{sessionId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Yetkili, hastanedeki bir kullanıcının seçilen oturumunu kapatır (örn. kaybolan cihaz)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Kullanıcının oturumunu sonlandır",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Kullanıcı ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Oturum ID",
+                        "name": "sessionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/users/{id}/status": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Kullanıcı askıya alınır (suspended), kilitlenir (locked), onaya çekilir (pending) veya tekrar aktif edilir (active). active dışındaki durumlar gerekçe ister ve kullanıcının tüm oturumlarını kapatır; kullanıcı giriş yapamaz. Değişiklik durum geçmişine yazılır",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Kullanıcı durumunu değiştir",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Kullanıcı ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Yeni durum ve gerekçe",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ChangeUserStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserStatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/users/{id}/status-history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Durum değişiklikleri yeniden eskiye; önceki/yeni durum, gerekçe, işlemi yapan (hastane yöneticisi veya platform operatörü) ve IP",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Kullanıcı durum geçmişi",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Kullanıcı ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.UserStatusChange"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/users/{id}/unlock": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Çok fazla başarısız deneme nedeniyle kilitlenen kullanıcının giriş ve şifre sıfırlama kilitlerini kaldırır",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Kullanıcı kilidini aç",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Kullanıcı ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/hospital/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ID'ye göre hastane bilgilerini getirir",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Hospital"
+                ],
+                "summary": "Hastane bilgilerini getir",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hastane ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Hospital"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/invitations/accept": {
+            "post": {
+                "description": "Davetli kişi TC kimlik numarasını girer, şifresini belirler (hastane şifre politikası) ve telefon kodunu doğrular. Başarılı olursa doğrudan giriş yapılır",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Invitations"
+                ],
+                "summary": "Daveti kabul et",
+                "parameters": [
+                    {
+                        "description": "Kabul bilgileri",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AcceptInvitationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/invitations/lookup": {
+            "post": {
+                "description": "Davet linkindeki token doğrulanır; geçerliyse ad, e-posta, maskelenmiş telefon, hastane ve rol döner",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Invitations"
+                ],
+                "summary": "Davet bilgisi",
+                "parameters": [
+                    {
+                        "description": "Davet token'ı",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.InvitationTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.InvitationDetailsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/invitations/phone-code": {
+            "post": {
+                "description": "Davette kayıtlı telefona SMS ile 6 haneli kod gönderilir. Kod daveti kabul ederken girilir",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Invitations"
+                ],
+                "summary": "Davet telefon doğrulama kodu",
+                "parameters": [
+                    {
+                        "description": "Davet token'ı",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.InvitationTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/job-groups": {
+            "get": {
+                "description": "Tüm meslek gruplarını getirir",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Meslek grupları",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.JobGroup"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/job-groups/{job_group_id}/titles": {
+            "get": {
+                "description": "Seçilen meslek grubuna ait unvanları getirir",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Meslek grubuna göre unvanlar",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Meslek grubu ID",
+                        "name": "job_group_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.JobTitle"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "Email veya telefon numarası ve şifre ile kullanıcı girişi yapılır. MFA aktif kullanıcılar için token yerine mfa_token döner; /login/mfa ile ikinci adım tamamlanır. Şifresinin süresi dolmuş kullanıcılar için password_change_token döner; /login/password-change ile yeni şifre belirlenir",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Kullanıcı girişi",
+                "parameters": [
+                    {
+                        "description": "Giriş bilgileri",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TokenResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/login/mfa": {
+            "post": {
+                "description": "Login'de dönen mfa_token ve authenticator kodu (veya kurtarma kodu) ile token çifti alınır",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "MFA ile giriş (ikinci adım)",
+                "parameters": [
+                    {
+                        "description": "MFA token ve kod",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.MFALoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/login/passkey": {
+            "post": {
+                "description": "navigator.credentials.get() yanıtı gönderilir. İmza kayıtlı açık anahtarla doğrulanır; passkey cihaz + PIN/biyometri gerektirdiği için token MFA doğrulanmış sayılır. Şifre kullanılmadığından şifre süresi kontrolü yapılmaz",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Passkey ile giriş",
+                "parameters": [
+                    {
+                        "description": "Tarayıcının döndürdüğü kimlik bilgisi",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.FinishPasskeyLoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/login/passkey/options": {
+            "post": {
+                "description": "navigator.credentials.get() için publicKey seçeneklerini döndürür. email_or_phone verilirse sadece o kullanıcının anahtarları önerilir, verilmezse cihazdaki keşfedilebilir passkey'ler kullanılır. Challenge 5 dakika geçerlidir",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Passkey girişini başlat",
+                "parameters": [
+                    {
+                        "description": "Opsiyonel kullanıcı kimliği",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.BeginPasskeyLoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PasskeyRequestOptions"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/login/password-change": {
+            "post": {
+                "description": "Login'de dönen password_change_token ile hastanenin şifre politikasına uygun yeni şifre belirlenir. Diğer tüm oturumlar sonlandırılır; MFA aktifse mfa_token, değilse token çifti döner",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Süresi dolan şifreyi değiştir (login adımı)",
+                "parameters": [
+                    {
+                        "description": "Şifre değiştirme token'ı ve yeni şifre",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PasswordChangeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mevcut oturumu sonlandırır; access ve refresh token'lar anında geçersiz olur. all_sessions=true ile tüm cihazlardan çıkış yapılır",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Çıkış yap",
+                "parameters": [
+                    {
+                        "description": "Çıkış seçenekleri",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.LogoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ad, soyad, iletişim bilgileri, doğrulama durumları ve aktif hastanedeki rol. TC kimlik numarası maskelenir",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profile"
+                ],
+                "summary": "Profilim",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProfileResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ad ve soyad serbestçe değişir. E-posta veya telefon değişiyorsa önce /me/contact/verify ile yeni adrese kod gönderilir ve kod email_code/phone_code alanında iletilir. Değişiklik eski adrese bildirilir",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profile"
+                ],
+                "summary": "Profili güncelle",
+                "parameters": [
+                    {
+                        "description": "Profil bilgileri",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/me/contact/verify": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Yeni e-posta adresine (channel=email) veya telefon numarasına (channel=sms) doğrulama kodu gönderilir. Adres başka bir hesapta kullanılıyorsa 422 döner",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profile"
+                ],
+                "summary": "İletişim bilgisi doğrulama kodu gönder",
+                "parameters": [
+                    {
+                        "description": "Kanal ve yeni adres",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ContactVerificationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/me/memberships": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Kullanıcının birincil hastanesi ve üye olduğu diğer hastaneler, her birindeki rolüyle. Token'ın geçerli olduğu hastane current=true ile işaretlenir",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Hastanelerim",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.MembershipResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/me/mfa/disable": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Şifre ve authenticator kodu ile MFA kapatılır; tüm oturumlar sonlandırılır. Hastane MFA'yı zorunlu kılmışsa yetkili kullanıcılar kapatamaz",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MFA"
+                ],
+                "summary": "İki adımlı doğrulamayı kapat",
+                "parameters": [
+                    {
+                        "description": "Şifre ve kod",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.DisableMFARequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/me/mfa/recovery-codes": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Güncel authenticator kodu ile doğrulayıp eski kurtarma kodlarını geçersiz kılar ve yenilerini üretir",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MFA"
+                ],
+                "summary": "Kurtarma kodlarını yenile",
+                "parameters": [
+                    {
+                        "description": "Authenticator kodu",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.MFACodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/me/mfa/totp/enroll": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Authenticator uygulaması için secret ve QR kod üretir. Kayıt, /me/mfa/totp/verify ile kod doğrulanınca aktif olur",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MFA"
+                ],
+                "summary": "TOTP kaydını başlat",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.MFAEnrollResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/me/mfa/totp/verify": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "İlk authenticator kodunu doğrular, MFA'yı aktif eder ve tek kullanımlık kurtarma kodlarını döner (sadece bir kez gösterilir)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MFA"
+                ],
+                "summary": "TOTP kaydını doğrula",
+                "parameters": [
+                    {
+                        "description": "Authenticator kodu",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.MFACodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.MFAActivationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/me/passkeys": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profile"
+                ],
+                "summary": "Passkey'lerim",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.PasskeyResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "navigator.credentials.create() yanıtı ve opsiyonel bir ad gönderilir. Kullanıcı doğrulaması (PIN/biyometri) yapılmamış yanıtlar reddedilir",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profile"
+                ],
+                "summary": "Passkey kaydını tamamla",
+                "parameters": [
+                    {
+                        "description": "Ad ve tarayıcının döndürdüğü kimlik bilgisi",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.FinishPasskeyRegistrationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.PasskeyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/me/passkeys/options": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "navigator.credentials.create() için publicKey seçeneklerini döndürür. Kullanıcının kayıtlı anahtarları excludeCredentials ile hariç tutulur. Kullanıcı başına en fazla 10 passkey",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profile"
+                ],
+                "summary": "Passkey kaydını başlat",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PasskeyCreationOptions"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/me/passkeys/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Silinen passkey ile artık giriş yapılamaz. Açık oturumlar /me/sessions üzerinden ayrıca sonlandırılabilir",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profile"
+                ],
+                "summary": "Passkey sil",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Passkey ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/me/password": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mevcut şifre doğrulanır, yeni şifre hastanenin şifre politikasına göre kontrol edilir. İsteği yapan oturum dışındaki tüm oturumlar sonlandırılır. 15 dakikada 5 hatalı mevcut şifre denemesinden sonra 429 döner",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profile"
+                ],
+                "summary": "Şifre değiştir",
+                "parameters": [
+                    {
+                        "description": "Mevcut ve yeni şifre",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ChangePasswordResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/me/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Kullanıcının açık oturumlarını cihaz, IP, giriş ve son görülme zamanıyla listeler. İsteği yapan oturum current=true ile işaretlenir",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sessions"
+                ],
+                "summary": "Aktif oturumlarım",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.SessionResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "İsteği yapan oturum açık kalır, diğer tüm cihazların oturumu kapatılır",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sessions"
+                ],
+                "summary": "Diğer cihazlardan çıkış",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/me/sessions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Seçilen cihazın oturumu kapatılır; o cihazdaki access ve refresh token'lar hemen geçersiz olur",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sessions"
+                ],
+                "summary": "Oturumu sonlandır",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Oturum ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/audit-logs/list": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Operatör işlemleri (giriş, hastane görüntüleme/askıya alma, kullanıcı desteği, master data değişiklikleri) yeniden eskiye. Operatör, işlem, hedef, hastane ve tarih aralığı ile filtrelenir",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Platform denetim kayıtları",
+                "parameters": [
+                    {
+                        "description": "Filtreler ve sayfalama",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PlatformAuditLogListRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PlatformAuditLogListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/hospitals/list": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Tüm hastaneler; ad, vergi no, e-posta veya telefonda arama, durum ve il filtresi. Kullanıcı ve personel sayılarıyla sayfalı döner",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Hastane listesi",
+                "parameters": [
+                    {
+                        "description": "Filtreler ve sayfalama",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PlatformHospitalListRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PlatformHospitalListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/hospitals/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hastane bilgileri, durumu, kullanıcı/üye/personel/poliklinik sayıları ve SSO durumu",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Hastane detayı",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hastane ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PlatformHospitalDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/hospitals/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Onay bekleyen (pending) hastane aktif olur; kullanıcıların token'ları bir sonraki istekte tüm yetkileriyle çalışır. Faaliyet izin belgesi yüklenmemişse 422. Not isteğe bağlıdır, sonuç hastanenin e-postasına bildirilir",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Hastane başvurusunu onayla",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hastane ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "İnceleme notu",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.PlatformActionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/hospitals/{id}/documents/{documentId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Belge listesi hastane detayında döner. Her indirme denetim kaydına yazılır",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Başvuru belgesini indir",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hastane ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Belge ID",
+                        "name": "documentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/hospitals/{id}/reactivate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Askıya alınmış hastanenin kullanıcıları tekrar giriş yapabilir. Gerekçe zorunludur",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Hastaneyi tekrar aktif et",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hastane ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Gerekçe",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PlatformActionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/hospitals/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Onay bekleyen başvuru gerekçesiyle reddedilir. Başvuran gerekçeyi görür, bilgileri/belgeleri düzeltip tekrar gönderebilir. Sonuç hastanenin e-postasına bildirilir",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Hastane başvurusunu reddet",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hastane ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Red gerekçesi",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PlatformActionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/hospitals/{id}/suspend": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hastanenin kullanıcıları giriş yapamaz, açık token'ları ve API anahtarları bir sonraki istekte 403 alır. Veriler silinmez. Gerekçe zorunludur ve denetim kaydına yazılır",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Hastaneyi askıya al",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hastane ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Gerekçe",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PlatformActionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/login": {
+            "post": {
+                "description": "E-posta, şifre ve (TOTP kaydı tamamlanmışsa) authenticator kodu ile giriş. Kayıt tamamlanmamışsa dönen token sadece /platform/me/mfa endpoint'lerinde geçerlidir. Token yenilenemez, süresi dolunca tekrar giriş yapılır",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Platform operatörü girişi",
+                "parameters": [
+                    {
+                        "description": "Giriş bilgileri",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PlatformLoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PlatformTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/master-data/districts": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform Master Data"
+                ],
+                "summary": "İlçe ekle",
+                "parameters": [
+                    {
+                        "description": "İlçe",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.DistrictRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.District"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/master-data/districts/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform Master Data"
+                ],
+                "summary": "İlçe güncelle",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "İlçe ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "İlçe",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.DistrictRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.District"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hastanesi olan ilçe silinemez",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform Master Data"
+                ],
+                "summary": "İlçe sil",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "İlçe ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/master-data/job-groups": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform Master Data"
+                ],
+                "summary": "Meslek grubu ekle",
+                "parameters": [
+                    {
+                        "description": "Meslek grubu",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.JobGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.JobGroup"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/master-data/job-groups/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform Master Data"
+                ],
+                "summary": "Meslek grubu güncelle",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Meslek grubu ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Meslek grubu",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.JobGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.JobGroup"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Unvanı veya personeli olan grup silinemez",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform Master Data"
+                ],
+                "summary": "Meslek grubu sil",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Meslek grubu ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/master-data/job-titles": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform Master Data"
+                ],
+                "summary": "Unvan ekle",
+                "parameters": [
+                    {
+                        "description": "Unvan",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.JobTitleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.JobTitle"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/master-data/job-titles/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform Master Data"
+                ],
+                "summary": "Unvan güncelle",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unvan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Unvan",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.JobTitleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.JobTitle"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Personeli olan unvan silinemez",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform Master Data"
+                ],
+                "summary": "Unvan sil",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unvan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/master-data/polyclinic-types": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform Master Data"
+                ],
+                "summary": "Poliklinik türü ekle",
+                "parameters": [
+                    {
+                        "description": "Poliklinik türü",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PolyclinicTypeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.PolyclinicType"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/master-data/polyclinic-types/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform Master Data"
+                ],
+                "summary": "Poliklinik türü güncelle",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Poliklinik türü ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Poliklinik türü",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PolyclinicTypeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PolyclinicType"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Herhangi bir hastanede açılmış poliklinik türü silinemez",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform Master Data"
+                ],
+                "summary": "Poliklinik türü sil",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Poliklinik türü ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/master-data/provinces": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform Master Data"
+                ],
+                "summary": "İl ekle",
+                "parameters": [
+                    {
+                        "description": "İl",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ProvinceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Province"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/master-data/provinces/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform Master Data"
+                ],
+                "summary": "İl güncelle",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "İl ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "İl",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ProvinceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Province"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "İlçesi veya hastanesi olan il silinemez",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform Master Data"
+                ],
+                "summary": "İl sil",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "İl ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/me/mfa/enroll": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Authenticator uygulamasına eklenecek secret ve QR kod üretilir. Kod /platform/me/mfa/verify ile doğrulanana kadar aktif olmaz",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Platform TOTP kaydını başlat",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.MFAEnrollResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/me/mfa/verify": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "İlk kod doğrulanır, TOTP aktif olur ve tüm platform endpoint'lerinde geçerli yeni token döner. Bundan sonra her girişte kod istenir",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Platform TOTP kaydını tamamla",
+                "parameters": [
+                    {
+                        "description": "Authenticator kodu",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.MFACodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PlatformTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/users/search": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "E-posta, telefon, TC veya ad soyad ile tüm hastanelerde arama (en az 3 karakter). Arama denetim kaydına yazılır",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Kullanıcı ara",
+                "parameters": [
+                    {
+                        "description": "Arama ve sayfalama",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PlatformUserSearchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PlatformUserListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/users/{id}/expire-password": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Kullanıcı bir sonraki girişte şifresini değiştirmeden token alamaz; mevcut oturumlar etkilenmez",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Şifre değişikliği iste",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Kullanıcı ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Gerekçe",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PlatformActionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/users/{id}/reset-mfa": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "TOTP kaydı ve kurtarma kodları silinir, tüm oturumlar kapatılır. Kullanıcı bir sonraki girişte yeniden kayıt yapar. Kimlik doğrulaması destek sürecinde yapılmış olmalıdır",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Kullanıcı MFA sıfırla",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Kullanıcı ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Gerekçe",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PlatformActionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/users/{id}/revoke-sessions": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Tüm cihazlardaki access ve refresh token'lar anında geçersiz olur",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Kullanıcı oturumlarını sonlandır",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Kullanıcı ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Gerekçe",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PlatformActionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/users/{id}/status": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Kullanıcı askıya alınır (suspended), kilitlenir (locked), onaya çekilir (pending) veya tekrar aktif edilir (active). active dışındaki durumlarda tüm oturumlar kapatılır. Gerekçe her geçişte zorunludur",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Kullanıcı durumunu değiştir",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Kullanıcı ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Yeni durum ve gerekçe",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ChangeUserStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserStatusResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/users/{id}/unlock": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hatalı denemeler nedeniyle oluşan giriş ve şifre sıfırlama kilitleri kaldırılır",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Kullanıcı kilidini aç",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Kullanıcı ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Gerekçe (ör. destek talebi no)",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PlatformActionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/polyclinic-types": {
+            "get": {
+                "description": "Master data'dan poliklinik türlerini getirir (dropdown için)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Polyclinic"
+                ],
+                "summary": "Poliklinik türleri listesi",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.PolyclinicType"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/polyclinics": {
+            "get": {
+                "description": "Sistemdeki tüm poliklinikleri listeler.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Polyclinic"
+                ],
+                "summary": "Tüm poliklinikleri getir",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Polyclinic"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Yeni bir poliklinik ekler.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Polyclinic"
+                ],
+                "summary": "Yeni poliklinik oluştur",
+                "parameters": [
+                    {
+                        "description": "Poliklinik verisi",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Polyclinic"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/polyclinics/{id}": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Polyclinic"
+                ],
+                "summary": "Poliklinik bilgilerini güncelle",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Poliklinik ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Yeni poliklinik verisi",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Polyclinic"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "ID'ye göre poliklinik kaydını siler.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Polyclinic"
+                ],
+                "summary": "Poliklinik sil",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Poliklinik ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/provinces": {
+            "get": {
+                "description": "Dropdown için tüm illeri listeler",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Location"
+                ],
+                "summary": "Tüm illeri getir",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Province"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/provinces/{province_id}/districts": {
+            "get": {
+                "description": "Seçilen ile ait ilçeleri listeler",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Location"
+                ],
+                "summary": "İle göre ilçeleri getir",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "İl ID",
+                        "name": "province_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.District"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/reset-password/confirm": {
+            "post": {
+                "description": "Kod doğruysa yeni şifre veritabanına yazılır",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Kodla şifreyi sıfırla",
+                "parameters": [
+                    {
+                        "description": "Yeni şifre bilgileri",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ResetPasswordConfirm"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/reset-password/request": {
+            "post": {
+                "description": "Telefon numarasına kayıtlı kullanıcıya SMS (varsayılan) veya e-posta ile tek kullanımlık kod gönderir. Kod yanıtta dönmez; numara kayıtlı olmasa da aynı yanıt döner",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Şifre sıfırlama kodu gönder",
+                "parameters": [
+                    {
+                        "description": "Telefon numarası, kanal ve dil",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/signup": {
+            "post": {
+                "description": "Hastane ve rol, katılım kodundan veya e-posta alan adından sunucuda belirlenir. Başvuru hastane yetkilisi onaylayana kadar beklemede kalır; sonuç e-postayla bildirilir",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Signup"
+                ],
+                "summary": "Kayıt başvurusu",
+                "parameters": [
+                    {
+                        "description": "Başvuru bilgileri",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.SelfSignupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/model.SignupSubmittedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/signup/email-code": {
+            "post": {
+                "description": "Başvurudan önce e-posta adresine 6 haneli doğrulama kodu gönderilir",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Signup"
+                ],
+                "summary": "Kayıt e-posta doğrulama kodu",
+                "parameters": [
+                    {
+                        "description": "E-posta",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.SignupEmailCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/sso/callback": {
+            "post": {
+                "description": "IdP'nin dönüş adresine eklediği code ve state ile girişi tamamlar. Normal login gibi access + refresh token döner; kullanıcının MFA'sı açıksa ikinci adım istenir. Frontend dönüş adresindeki parametreleri POST edebilir veya dönüş adresi doğrudan bu endpoint olabilir (GET)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "SSO dönüşü",
+                "parameters": [
+                    {
+                        "description": "IdP dönüş parametreleri",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.SSOCallbackRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/sso/start": {
+            "post": {
+                "description": "Hastanenin kimlik sağlayıcısına (OIDC) yönlendirme adresini döndürür. Hastane ID'si veya hastanenin izinli alan adındaki e-posta ile başlatılır. Authorization code + PKCE kullanılır",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "SSO ile giriş başlat",
+                "parameters": [
+                    {
+                        "description": "Hastane ID veya e-posta",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.SSOStartRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SSOStartResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/token/refresh": {
+            "post": {
+                "description": "Refresh token ile yeni access + refresh token çifti alınır. Her refresh token tek kullanımlıktır; yeniden kullanılırsa tüm oturum iptal edilir",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Token yenile",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "model.APIKeyResponse": {
+            "description": "API anahtarı bilgisi",
+            "type": "object",
+            "properties": {
+                "allowed_ips": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "10.0.4.12"
+                    ]
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "key_hint": {
+                    "type": "string",
+                    "example": "hpk_Xq3v"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "last_used_ip": {
+                    "type": "string",
+                    "example": "10.0.4.12"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "HBYS entegrasyonu"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "staff:read"
+                    ]
+                },
+                "status": {
+                    "description": "active, expired, revoked",
+                    "type": "string",
+                    "example": "active"
+                }
+            }
+        },
+        "model.AbsenceCalendarDay": {
+            "description": "Devamsızlık takvimi günü",
+            "type": "object",
+            "properties": {
+                "absences": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AbsenceEntry"
+                    }
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-07-07"
+                }
+            }
+        },
+        "model.AbsenceEntry": {
+            "description": "İzinli personel",
+            "type": "object",
+            "properties": {
+                "leave_request_id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "leave_type_name": {
+                    "type": "string",
+                    "example": "Yıllık İzin"
+                },
+                "staff_id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "staff_name": {
+                    "type": "string",
+                    "example": "Dr. Mehmet Özkan"
+                },
+                "status": {
+                    "description": "approved veya (include_pending ile) pending",
+                    "type": "string",
+                    "example": "approved"
+                }
+            }
+        },
+        "model.AcceptInvitationRequest": {
+            "description": "Daveti kabul et - şifre belirle, telefonu doğrula",
+            "type": "object",
+            "required": [
+                "confirm_password",
+                "password",
+                "phone_code",
+                "tc",
+                "token"
+            ],
+            "properties": {
+                "confirm_password": {
+                    "description": "Şifre tekrarı",
+                    "type": "string",
+                    "example": "GüçlüŞifre123"
+                },
+                "password": {
+                    "description": "Yeni şifre (hastane şifre politikasına uygun)",
+                    "type": "string",
+                    "example": "GüçlüŞifre123"
+                },
+                "phone_code": {
+                    "description": "Telefona gönderilen doğrulama kodu",
+                    "type": "string",
+                    "example": "123456"
+                },
+                "tc": {
+                    "description": "TC Kimlik No",
+                    "type": "string",
+                    "example": "12345678901"
+                },
+                "token": {
+                    "description": "Davet linkindeki token",
+                    "type": "string",
+                    "example": "Zm9vYmFy.c2lnbmF0dXJl"
+                }
+            }
+        },
+        "model.AddMemberRequest": {
+            "description": "Başka hastanedeki mevcut bir kullanıcıyı bu hastaneye üye ekleme",
+            "type": "object",
+            "required": [
+                "email",
+                "role",
+                "tc"
+            ],
+            "properties": {
+                "email": {
+                    "description": "Kullanıcının hesabındaki e-posta (TC ile birlikte eşleşmeli)",
+                    "type": "string",
+                    "example": "ahmet.yilmaz@example.com"
+                },
+                "polyclinic_id": {
+                    "description": "Bu hastanedeki poliklinik (opsiyonel)",
+                    "type": "integer",
+                    "example": 3
+                },
+                "role": {
+                    "description": "Bu hastanedeki rol",
+                    "type": "string",
+                    "example": "çalışan"
+                },
+                "tc": {
+                    "description": "Kullanıcının TC kimlik numarası",
+                    "type": "string",
+                    "example": "12345678901"
+                }
+            }
+        },
+        "model.AddPolyclinicRequest": {
+            "description": "Hastaneye poliklinik ekleme verisi",
+            "type": "object",
+            "required": [
+                "floor",
+                "polyclinic_type_id",
+                "room_number"
+            ],
+            "properties": {
+                "floor": {
+                    "description": "Kat numarası",
+                    "type": "integer",
+                    "example": 2
+                },
+                "polyclinic_type_id": {
+                    "description": "Master data'dan seçilen tür",
+                    "type": "integer",
+                    "example": 1
+                },
+                "room_number": {
+                    "description": "Oda numarası",
+                    "type": "integer",
+                    "example": 205
+                }
+            }
+        },
+        "model.ApproveSignupRequest": {
+            "description": "Başvuruyu onayla - rol ve poliklinik boşsa başvurudaki değerler kullanılır",
+            "type": "object",
+            "properties": {
+                "polyclinic_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "role": {
+                    "type": "string",
+                    "example": "hemşire"
+                }
+            }
+        },
+        "model.BeginPasskeyLoginRequest": {
+            "description": "Passkey ile giriş başlatma - kimlik verilirse sadece o kullanıcının anahtarları önerilir",
+            "type": "object",
+            "properties": {
+                "email_or_phone": {
+                    "description": "Opsiyonel",
+                    "type": "string",
+                    "example": "ahmet@example.com"
+                }
+            }
+        },
+        "model.ChangePasswordRequest": {
+            "description": "Mevcut şifreyle şifre değiştirme",
+            "type": "object",
+            "required": [
+                "confirm_password",
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "confirm_password": {
+                    "type": "string",
+                    "example": "YeniSifre!2025"
+                },
+                "current_password": {
+                    "type": "string",
+                    "example": "EskiSifre!2024"
+                },
+                "new_password": {
+                    "type": "string",
+                    "example": "YeniSifre!2025"
+                }
+            }
+        },
+        "model.ChangePasswordResponse": {
+            "description": "Şifre değiştirme sonucu",
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Şifreniz değiştirildi"
+                },
+                "revoked_sessions": {
+                    "description": "Sonlandırılan diğer oturum sayısı",
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "model.ChangeUserStatusRequest": {
+            "description": "Kullanıcı durumu değiştirme - active dışındaki durumlar için gerekçe zorunludur",
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "İşten ayrıldı"
+                },
+                "status": {
+                    "description": "active, suspended, locked, pending",
+                    "type": "string",
+                    "example": "suspended"
+                }
+            }
+        },
+        "model.ContactVerificationRequest": {
+            "description": "Yeni e-posta veya telefon için doğrulama kodu isteği",
+            "type": "object",
+            "required": [
+                "channel",
+                "value"
+            ],
+            "properties": {
+                "channel": {
+                    "description": "email veya sms",
+                    "type": "string",
+                    "example": "email"
+                },
+                "lang": {
+                    "type": "string",
+                    "example": "tr"
+                },
+                "value": {
+                    "description": "Yeni e-posta adresi veya telefon numarası",
+                    "type": "string",
+                    "example": "ahmet.yeni@example.com"
+                }
+            }
+        },
+        "model.CreateAPIKeyRequest": {
+            "description": "API anahtarı oluşturma isteği",
+            "type": "object",
+            "required": [
+                "expires_in_days",
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "allowed_ips": {
+                    "description": "İzin verilen IP/CIDR'lar (boşsa her IP)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "10.0.4.12",
+                        "10.0.5.0/24"
+                    ]
+                },
+                "expires_in_days": {
+                    "description": "Geçerlilik süresi (gün)",
+                    "type": "integer",
+                    "example": 180
+                },
+                "name": {
+                    "description": "Anahtarın adı",
+                    "type": "string",
+                    "example": "HBYS entegrasyonu"
+                },
+                "scopes": {
+                    "description": "Yetkiler (GET /hospital/api-keys/scopes)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "staff:read"
+                    ]
+                }
+            }
+        },
+        "model.CreateAPIKeyResponse": {
+            "description": "Yeni oluşturulan API anahtarı - anahtar sadece bu cevapta gösterilir",
+            "type": "object",
+            "properties": {
+                "allowed_ips": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "10.0.4.12"
+                    ]
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "key": {
+                    "description": "İsteklerde X-API-Key header'ında gönderilir",
+                    "type": "string",
+                    "example": "hpk_Xq3vR8mZ0c2Lk9PwYt4sNa7bUe1fHj6dGi5oKl3QrVw"
+                },
+                "key_hint": {
+                    "type": "string",
+                    "example": "hpk_Xq3v"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "last_used_ip": {
+                    "type": "string",
+                    "example": "10.0.4.12"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "HBYS entegrasyonu"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "staff:read"
+                    ]
+                },
+                "status": {
+                    "description": "active, expired, revoked",
+                    "type": "string",
+                    "example": "active"
+                }
+            }
+        },
+        "model.CreateDutyRosterRequest": {
+            "description": "Nöbet listesi oluşturma verisi - polyclinic_id ve/veya job_group_id zorunlu",
+            "type": "object",
+            "required": [
+                "end_date",
+                "name",
+                "slots",
+                "start_date"
+            ],
+            "properties": {
+                "end_date": {
+                    "description": "YYYY-AA-GG, dahil",
+                    "type": "string",
+                    "example": "2025-11-30"
+                },
+                "holidays": {
+                    "description": "Dönem içindeki resmi tatiller",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "2025-10-29"
+                    ]
+                },
+                "job_group_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "max_consecutive_nights": {
+                    "description": "Varsayılan 1",
+                    "type": "integer",
+                    "example": 1
+                },
+                "min_rest_hours": {
+                    "description": "Varsayılan SCHEDULE_MIN_REST",
+                    "type": "integer",
+                    "example": 24
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Kardiyoloji Kasım 2025 nöbetleri"
+                },
+                "polyclinic_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "seed": {
+                    "description": "Verilmezse rastgele üretilir ve listede saklanır",
+                    "type": "integer",
+                    "example": 42
+                },
+                "slots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.DutyRosterSlotRequest"
+                    }
+                },
+                "start_date": {
+                    "description": "YYYY-AA-GG",
+                    "type": "string",
+                    "example": "2025-11-01"
+                }
+            }
+        },
+        "model.CreateEmailDomainRequest": {
+            "description": "Kayıt için izinli e-posta alan adı ekle",
+            "type": "object",
+            "required": [
+                "domain"
+            ],
+            "properties": {
+                "domain": {
+                    "type": "string",
+                    "example": "ankarasehir.gov.tr"
+                },
+                "polyclinic_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "role": {
+                    "description": "Boşsa çalışan",
+                    "type": "string",
+                    "example": "çalışan"
+                }
+            }
+        },
+        "model.CreateInvitationRequest": {
+            "description": "Alt kullanıcı daveti - şifreyi davetli kişi belirler",
+            "type": "object",
+            "required": [
+                "email",
+                "first_name",
+                "last_name",
+                "phone",
+                "role"
+            ],
+            "properties": {
+                "email": {
+                    "description": "Davet linkinin gönderileceği e-posta",
+                    "type": "string",
+                    "example": "mehmet.yilmaz@example.com"
+                },
+                "first_name": {
+                    "description": "Ad",
+                    "type": "string",
+                    "example": "Mehmet"
+                },
+                "lang": {
+                    "description": "Davet mesajı dili: tr (varsayılan) veya en",
+                    "type": "string",
+                    "example": "tr"
+                },
+                "last_name": {
+                    "description": "Soyad",
+                    "type": "string",
+                    "example": "Yılmaz"
+                },
+                "phone": {
+                    "description": "Kabulde SMS ile doğrulanacak telefon",
+                    "type": "string",
+                    "example": "05551234567"
+                },
+                "polyclinic_id": {
+                    "description": "Bağlı olduğu poliklinik (opsiyonel)",
+                    "type": "integer",
+                    "example": 3
+                },
+                "role": {
+                    "description": "Rol adı (hastanede tanımlı)",
+                    "type": "string",
+                    "example": "çalışan"
+                }
+            }
+        },
+        "model.CreateJoinCodeRequest": {
+            "description": "Katılım kodu oluştur - kod sadece bu yanıtta gösterilir",
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "expires_in_days": {
+                    "description": "Varsayılan 30, en fazla 365",
+                    "type": "integer",
+                    "example": 30
+                },
+                "max_uses": {
+                    "description": "0 = sınırsız",
+                    "type": "integer",
+                    "example": 20
+                },
+                "polyclinic_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "role": {
+                    "description": "Kodla başvuranlara atanacak rol",
+                    "type": "string",
+                    "example": "çalışan"
+                }
+            }
+        },
+        "model.CreateLeaveRequest": {
+            "description": "İzin talebi verisi",
+            "type": "object",
+            "required": [
+                "end_date",
+                "leave_type_id",
+                "staff_id",
+                "start_date"
+            ],
+            "properties": {
+                "end_date": {
+                    "description": "Son izin günü, dahil (YYYY-AA-GG)",
+                    "type": "string",
+                    "example": "2025-07-18"
+                },
+                "leave_type_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Yaz tatili"
+                },
+                "staff_id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "start_date": {
+                    "description": "İlk izin günü (YYYY-AA-GG)",
+                    "type": "string",
+                    "example": "2025-07-07"
+                }
+            }
+        },
+        "model.CreateStaffRequest": {
+            "description": "Hastaneye yeni personel eklerken gönderilecek veriler",
+            "type": "object",
+            "required": [
+                "first_name",
+                "job_group_id",
+                "job_title_id",
+                "last_name",
+                "phone",
+                "tc",
+                "work_days"
+            ],
+            "properties": {
+                "first_name": {
+                    "description": "Personelin adı (zorunlu alan)",
+                    "type": "string",
+                    "example": "Dr. Mehmet"
+                },
+                "job_group_id": {
+                    "description": "Hangi meslek grubuna ait (Doktor, Hemşire vb.)",
+                    "type": "integer",
+                    "example": 1
+                },
+                "job_title_id": {
+                    "description": "Unvanı (Başhekim, Uzman Doktor vb.) - bazıları unique",
+                    "type": "integer",
+                    "example": 1
+                },
+                "last_name": {
+                    "description": "Personelin soyadı (zorunlu alan)",
+                    "type": "string",
+                    "example": "Özkan"
+                },
+                "phone": {
+                    "description": "Telefon numarası - sistemde benzersiz olmalı",
+                    "type": "string",
+                    "example": "05559876543"
+                },
+                "polyclinic_id": {
+                    "description": "Hangi poliklinikte çalışacak (opsiyonel)",
+                    "type": "integer",
+                    "example": 1
+                },
+                "shift_template_id": {
+                    "description": "Bu günlerde çalışılacak vardiya (boşsa hastanenin \"Mesai\" vardiyası)",
+                    "type": "integer",
+                    "example": 2
+                },
+                "tc": {
+                    "description": "TC Kimlik numarası - sistemde benzersiz olmalı",
+                    "type": "string",
+                    "example": "98765432101"
+                },
+                "work_days": {
+                    "description": "Hangi günler çalışacak (1:Pzt, 7:Paz) - bugünden başlayan haftalık program oluşturulur",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2,
+                        3,
+                        4,
+                        5
+                    ]
+                }
+            }
+        },
+        "model.CreateSubUserRequest": {
+            "description": "Alt kullanıcı ekleme verisi",
+            "type": "object",
+            "required": [
+                "email",
+                "first_name",
+                "last_name",
+                "password",
+                "phone",
+                "role",
+                "tc"
+            ],
+            "properties": {
+                "email": {
+                    "description": "E-posta",
+                    "type": "string",
+                    "example": "mehmet.yilmaz@example.com"
+                },
+                "first_name": {
+                    "description": "Ad",
+                    "type": "string",
+                    "example": "Mehmet"
+                },
+                "last_name": {
+                    "description": "Soyad",
+                    "type": "string",
+                    "example": "Yılmaz"
+                },
+                "password": {
+                    "description": "Şifre",
+                    "type": "string",
+                    "minLength": 6,
+                    "example": "123456"
+                },
+                "phone": {
+                    "description": "Telefon",
+                    "type": "string",
+                    "example": "05551234567"
+                },
+                "polyclinic_id": {
+                    "description": "Bağlı olduğu poliklinik (opsiyonel)",
+                    "type": "integer",
+                    "example": 3
+                },
+                "role": {
+                    "description": "Rol adı (hastanede tanımlı)",
+                    "type": "string",
+                    "example": "çalışan"
+                },
+                "tc": {
+                    "description": "TC Kimlik No",
+                    "type": "string",
+                    "example": "12345678901"
+                }
+            }
+        },
+        "model.DisableMFARequest": {
+            "description": "MFA kapatma isteği",
+            "type": "object",
+            "required": [
+                "code",
+                "password"
+            ],
+            "properties": {
+                "code": {
+                    "description": "Güncel TOTP kodu",
+                    "type": "string",
+                    "example": "123456"
+                },
+                "password": {
+                    "description": "Mevcut şifre",
+                    "type": "string",
+                    "example": "123456"
+                }
+            }
+        },
+        "model.District": {
+            "description": "İlçe bilgileri",
+            "type": "object",
+            "required": [
+                "name",
+                "province_id"
+            ],
+            "properties": {
+                "name": {
+                    "description": "İlçe adı",
+                    "type": "string",
+                    "example": "Beşiktaş"
+                },
+                "province": {
+                    "description": "İl bilgisi",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Province"
+                        }
+                    ]
+                },
+                "province_id": {
+                    "description": "Bağlı olduğu il",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "model.DistrictRequest": {
+            "description": "İlçe ekleme/güncelleme",
+            "type": "object",
+            "required": [
+                "name",
+                "province_id"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Beşiktaş"
+                },
+                "province_id": {
+                    "type": "integer",
+                    "example": 34
+                }
+            }
+        },
+        "model.DutyAssignment": {
+            "description": "Nöbet ataması",
+            "type": "object",
+            "properties": {
+                "date": {
+                    "description": "Nöbetin başladığı gün",
+                    "type": "string",
+                    "example": "2025-11-01T00:00:00Z"
+                },
+                "ends_at": {
+                    "type": "string",
+                    "example": "2025-11-02T08:00:00+03:00"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "manual": {
+                    "description": "Yönetici tarafından elle atandı/değiştirildi",
+                    "type": "boolean",
+                    "example": false
+                },
+                "night": {
+                    "description": "Gece yarısını geçen nöbet",
+                    "type": "boolean",
+                    "example": true
+                },
+                "roster_id": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "shift_name": {
+                    "type": "string",
+                    "example": "Gece"
+                },
+                "shift_template_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "slot_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "staff_id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "staff_name": {
+                    "description": "Listelerde gösterim için",
+                    "type": "string",
+                    "example": "Dr. Mehmet Özkan"
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2025-11-01T08:00:00+03:00"
+                }
+            }
+        },
+        "model.DutyAssignmentRequest": {
+            "description": "Elle nöbet ataması - slot_id ve date sadece yeni atamada kullanılır",
+            "type": "object",
+            "required": [
+                "staff_id"
+            ],
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2025-11-01"
+                },
+                "slot_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "staff_id": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "model.DutyRoster": {
+            "description": "Nöbet listesi",
+            "type": "object",
+            "properties": {
+                "assignments": {
+                    "description": "Atanan nöbetler",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.DutyAssignment"
+                    }
+                },
+                "created_by": {
+                    "description": "Oluşturan kullanıcı",
+                    "type": "integer",
+                    "example": 1
+                },
+                "end_date": {
+                    "description": "Son nöbet günü (dahil)",
+                    "type": "string",
+                    "example": "2025-11-30T00:00:00Z"
+                },
+                "holidays": {
+                    "description": "Hafta sonu gibi sayılan resmi tatiller (YYYY-AA-GG)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "2025-10-29"
+                    ]
+                },
+                "hospital_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "job_group_id": {
+                    "description": "Aday personelin meslek grubu",
+                    "type": "integer",
+                    "example": 1
+                },
+                "max_consecutive_nights": {
+                    "description": "Art arda en fazla gece nöbeti",
+                    "type": "integer",
+                    "example": 1
+                },
+                "min_rest_hours": {
+                    "description": "İki nöbet arası en az dinlenme (saat)",
+                    "type": "integer",
+                    "example": 24
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Kardiyoloji Kasım 2025 nöbetleri"
+                },
+                "polyclinic_id": {
+                    "description": "Aday personelin polikliniği",
+                    "type": "integer",
+                    "example": 3
+                },
+                "published_at": {
+                    "description": "Yayın zamanı",
+                    "type": "string"
+                },
+                "published_by": {
+                    "description": "Yayınlayan kullanıcı",
+                    "type": "integer",
+                    "example": 1
+                },
+                "seed": {
+                    "description": "Üreticinin eşitlik bozma tohumu",
+                    "type": "integer",
+                    "example": 20251101
+                },
+                "slots": {
+                    "description": "Günlük nöbet ihtiyacı",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.DutyRosterSlot"
+                    }
+                },
+                "start_date": {
+                    "description": "İlk nöbet günü",
+                    "type": "string",
+                    "example": "2025-11-01T00:00:00Z"
+                },
+                "status": {
+                    "description": "draft, published",
+                    "type": "string",
+                    "example": "draft"
+                }
+            }
+        },
+        "model.DutyRosterGap": {
+            "description": "Boş nöbet",
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2025-11-01"
+                },
+                "missing": {
+                    "description": "Eksik kişi sayısı",
+                    "type": "integer",
+                    "example": 1
+                },
+                "shift_name": {
+                    "type": "string",
+                    "example": "Gece"
+                },
+                "slot_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "model.DutyRosterResponse": {
+            "description": "Nöbet listesi detayı",
+            "type": "object",
+            "properties": {
+                "gaps": {
+                    "description": "Kişi sayısı tamamlanamayan nöbetler",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.DutyRosterGap"
+                    }
+                },
+                "roster": {
+                    "$ref": "#/definitions/model.DutyRoster"
+                },
+                "stats": {
+                    "description": "Aday personelin nöbet dağılımı",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.DutyStaffStats"
+                    }
+                },
+                "violations": {
+                    "description": "Kural ihlalleri (ör. sonradan onaylanan izinler) - varken yayınlanamaz",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ValidationError"
+                    }
+                }
+            }
+        },
+        "model.DutyRosterSlot": {
+            "type": "object",
+            "properties": {
+                "day_type": {
+                    "description": "all, weekday, weekend",
+                    "type": "string",
+                    "example": "weekday"
+                },
+                "headcount": {
+                    "description": "Aynı anda nöbette olacak kişi sayısı",
+                    "type": "integer",
+                    "example": 2
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "shift_template": {
+                    "$ref": "#/definitions/model.ShiftTemplate"
+                },
+                "shift_template_id": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "model.DutyRosterSlotRequest": {
+            "description": "Nöbet slotu",
+            "type": "object",
+            "required": [
+                "headcount",
+                "shift_template_id"
+            ],
+            "properties": {
+                "day_type": {
+                    "description": "all (varsayılan), weekday, weekend",
+                    "type": "string",
+                    "example": "weekend"
+                },
+                "headcount": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "shift_template_id": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "model.DutyStaffStats": {
+            "description": "Personel nöbet dağılımı",
+            "type": "object",
+            "properties": {
+                "holidays": {
+                    "description": "Resmi tatiller",
+                    "type": "integer",
+                    "example": 1
+                },
+                "hours": {
+                    "type": "number",
+                    "example": 112
+                },
+                "nights": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "staff_id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "staff_name": {
+                    "type": "string",
+                    "example": "Dr. Mehmet Özkan"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "weekends": {
+                    "description": "Cumartesi/Pazar (tatiller hariç)",
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "model.FinishPasskeyLoginRequest": {
+            "description": "Passkey ile giriş tamamlama isteği",
+            "type": "object",
+            "required": [
+                "credential"
+            ],
+            "properties": {
+                "credential": {
+                    "$ref": "#/definitions/model.PasskeyCredential"
+                }
+            }
+        },
+        "model.FinishPasskeyRegistrationRequest": {
+            "description": "Passkey kaydını tamamlama isteği",
+            "type": "object",
+            "required": [
+                "credential"
+            ],
+            "properties": {
+                "credential": {
+                    "$ref": "#/definitions/model.PasskeyCredential"
+                },
+                "name": {
+                    "description": "Boşsa \"Passkey\" adı verilir",
+                    "type": "string",
+                    "example": "İş bilgisayarı"
+                }
+            }
+        },
+        "model.Hospital": {
+            "description": "Hastane bilgileri",
+            "type": "object",
+            "required": [
+                "address_detail",
+                "district_id",
+                "email",
+                "name",
+                "phone",
+                "province_id",
+                "tax_id"
+            ],
+            "properties": {
+                "address_detail": {
+                    "description": "Açık adres",
+                    "type": "string",
+                    "example": "Beşiktaş Caddesi No:123"
+                },
+                "district": {
+                    "description": "İlçe bilgisi",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.District"
+                        }
+                    ]
+                },
+                "district_id": {
+                    "description": "İlçe ID",
+                    "type": "integer",
+                    "example": 1
+                },
+                "email": {
+                    "description": "E-posta adresi",
+                    "type": "string",
+                    "example": "info@acibadem.com"
+                },
+                "name": {
+                    "description": "Hastane adı",
+                    "type": "string",
+                    "example": "Acıbadem Hastanesi"
+                },
+                "phone": {
+                    "description": "Telefon numarası",
+                    "type": "string",
+                    "example": "02121234567"
+                },
+                "province": {
+                    "description": "İlişkiler",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Province"
+                        }
+                    ]
+                },
+                "province_id": {
+                    "description": "İl ID",
+                    "type": "integer",
+                    "example": 1
+                },
+                "require_mfa": {
+                    "description": "Yetkili kullanıcılar için MFA zorunlu mu?",
+                    "type": "boolean",
+                    "example": false
+                },
+                "status": {
+                    "description": "Hesap durumu - platform operatörü tarafından yönetilir",
+                    "type": "string",
+                    "example": "active"
+                },
+                "status_changed_at": {
+                    "description": "Son durum değişikliği zamanı",
+                    "type": "string"
+                },
+                "status_changed_by": {
+                    "description": "Değişikliği yapan platform operatörü",
+                    "type": "integer",
+                    "example": 1
+                },
+                "status_reason": {
+                    "description": "Son durum değişikliğinin gerekçesi",
+                    "type": "string",
+                    "example": "Ödeme yapılmadı"
+                },
+                "submitted_at": {
+                    "description": "Başvurunun (son) incelemeye gönderilme zamanı",
+                    "type": "string"
+                },
+                "tax_id": {
+                    "description": "Vergi kimlik numarası",
+                    "type": "string",
+                    "example": "1234567890"
+                },
+                "users": {
+                    "description": "Hastane kullanıcıları",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.User"
+                    }
+                }
+            }
+        },
+        "model.HospitalApplicationResponse": {
+            "description": "Hastane kayıt başvurusu ve durumu",
+            "type": "object",
+            "properties": {
+                "can_resubmit": {
+                    "description": "Reddedilen başvuru tekrar gönderilebilir mi?",
+                    "type": "boolean",
+                    "example": false
+                },
+                "documents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.HospitalDocument"
+                    }
+                },
+                "editable": {
+                    "description": "Bilgiler ve belgeler değiştirilebilir mi? (pending, rejected)",
+                    "type": "boolean",
+                    "example": true
+                },
+                "hospital": {
+                    "$ref": "#/definitions/model.Hospital"
+                }
+            }
+        },
+        "model.HospitalDocument": {
+            "description": "Başvuru belgesi (dosya içeriği ayrı endpoint'ten indirilir)",
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "description": "İçerikten tespit edilen tür",
+                    "type": "string",
+                    "example": "application/pdf"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "description": "Yüklenen dosyanın adı",
+                    "type": "string",
+                    "example": "ruhsat.pdf"
+                },
+                "hospital_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "sha256": {
+                    "description": "Bütünlük kontrolü için içerik özeti",
+                    "type": "string"
+                },
+                "size": {
+                    "description": "Bayt",
+                    "type": "integer",
+                    "example": 245760
+                },
+                "type": {
+                    "description": "license, tax_certificate, authorization, other",
+                    "type": "string",
+                    "example": "license"
+                },
+                "uploaded_by": {
+                    "description": "Yükleyen kullanıcı",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "model.HospitalEmailDomain": {
+            "description": "Bu alan adındaki e-posta adresleri hastaneye katılım kodu olmadan başvurabilir",
+            "type": "object",
+            "properties": {
+                "created_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "domain": {
+                    "description": "Bir alan adı tek hastaneye ait olabilir",
+                    "type": "string",
+                    "example": "ankarasehir.gov.tr"
+                },
+                "hospital_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "polyclinic_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "role": {
+                    "description": "Başvurulara atanacak rol",
+                    "type": "string",
+                    "example": "çalışan"
+                }
+            }
+        },
+        "model.HospitalMemberResponse": {
+            "description": "Hastanenin diğer hastanelerden üye kullanıcısı",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "email": {
+                    "type": "string",
+                    "example": "ahmet.yilmaz@example.com"
+                },
+                "first_name": {
+                    "type": "string",
+                    "example": "Ahmet"
+                },
+                "id": {
+                    "description": "Üyelik ID",
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "last_name": {
+                    "type": "string",
+                    "example": "Yılmaz"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "05551234567"
+                },
+                "polyclinic_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "primary_hospital_id": {
+                    "description": "Hesabın ait olduğu hastane",
+                    "type": "integer",
+                    "example": 2
+                },
+                "role": {
+                    "type": "string",
+                    "example": "çalışan"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "model.HospitalPolyclinic": {
+            "description": "Hastane poliklinik bilgileri",
+            "type": "object",
+            "required": [
+                "floor",
+                "hospital_id",
+                "polyclinic_type_id",
+                "room_number"
+            ],
+            "properties": {
+                "floor": {
+                    "description": "Kat numarası",
+                    "type": "integer",
+                    "example": 2
+                },
+                "hospital": {
+                    "description": "İlişkiler",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Hospital"
+                        }
+                    ]
+                },
+                "hospital_id": {
+                    "description": "Hangi hastane",
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_active": {
+                    "description": "Aktif mi?",
+                    "type": "boolean",
+                    "example": true
+                },
+                "polyclinic_type": {
+                    "$ref": "#/definitions/model.PolyclinicType"
+                },
+                "polyclinic_type_id": {
+                    "description": "Poliklinik türü",
+                    "type": "integer",
+                    "example": 1
+                },
+                "room_number": {
+                    "description": "Oda numarası",
+                    "type": "integer",
+                    "example": 205
+                },
+                "staff": {
+                    "description": "Bu poliklinikte çalışan personel",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Staff"
+                    }
+                }
+            }
+        },
+        "model.HospitalPolyclinicSummary": {
+            "description": "Hastane poliklinik özet bilgileri",
+            "type": "object",
+            "properties": {
+                "floor": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "polyclinic_type_name": {
+                    "type": "string",
+                    "example": "Kardiyoloji"
+                },
+                "room_number": {
+                    "type": "integer",
+                    "example": 205
+                },
+                "staff_by_job_group": {
+                    "description": "Meslek grubuna göre personel sayıları",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StaffCountByGroup"
+                    }
+                },
+                "total_staff_count": {
+                    "description": "Toplam personel sayısı",
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "model.HospitalRegistrationRequest": {
+            "description": "Hastane kayıt verisi",
+            "type": "object",
+            "required": [
+                "address_detail",
+                "admin_email",
+                "admin_first_name",
+                "admin_last_name",
+                "admin_password",
+                "admin_phone",
+                "admin_tc",
+                "district_id",
+                "hospital_email",
+                "hospital_name",
+                "hospital_phone",
+                "province_id",
+                "tax_id"
+            ],
+            "properties": {
+                "address_detail": {
+                    "type": "string",
+                    "example": "Beşiktaş Caddesi No:123"
+                },
+                "admin_email": {
+                    "type": "string",
+                    "example": "ahmet.yilmaz@acibadem.com"
+                },
+                "admin_first_name": {
+                    "description": "Yetkili Bilgileri",
+                    "type": "string",
+                    "example": "Ahmet"
+                },
+                "admin_last_name": {
+                    "type": "string",
+                    "example": "Yılmaz"
+                },
+                "admin_password": {
+                    "type": "string",
+                    "minLength": 6,
+                    "example": "123456"
+                },
+                "admin_phone": {
+                    "type": "string",
+                    "example": "05551234567"
+                },
+                "admin_tc": {
+                    "type": "string",
+                    "example": "12345678901"
+                },
+                "district_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "hospital_email": {
+                    "type": "string",
+                    "example": "info@acibadem.com"
+                },
+                "hospital_name": {
+                    "description": "Hastane Bilgileri",
+                    "type": "string",
+                    "example": "Acıbadem Hastanesi"
+                },
+                "hospital_phone": {
+                    "type": "string",
+                    "example": "02121234567"
+                },
+                "province_id": {
+                    "description": "Adres Bilgileri",
+                    "type": "integer",
+                    "example": 1
+                },
+                "tax_id": {
+                    "type": "string",
+                    "example": "1234567890"
+                }
+            }
+        },
+        "model.HospitalRegistrationResponse": {
+            "description": "Hastane kayıt yanıtı",
+            "type": "object",
+            "properties": {
+                "admin_user": {
+                    "$ref": "#/definitions/model.User"
+                },
+                "expires_in": {
+                    "type": "integer",
+                    "example": 900
+                },
+                "hospital": {
+                    "$ref": "#/definitions/model.Hospital"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Hastane başarıyla kaydedildi"
+                },
+                "refresh_token": {
+                    "type": "string",
+                    "example": "q1w2e3r4t5y6..."
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "model.InvitationDetailsResponse": {
+            "description": "Davet kabul ekranı için bilgiler",
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "mehmet.yilmaz@example.com"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string",
+                    "example": "Mehmet"
+                },
+                "hospital_name": {
+                    "type": "string",
+                    "example": "Ankara Şehir Hastanesi"
+                },
+                "last_name": {
+                    "type": "string",
+                    "example": "Yılmaz"
+                },
+                "phone": {
+                    "description": "Maskelenmiş telefon",
+                    "type": "string",
+                    "example": "*******4567"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "çalışan"
+                }
+            }
+        },
+        "model.InvitationResponse": {
+            "description": "Davet bilgisi",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "mehmet.yilmaz@example.com"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string",
+                    "example": "Mehmet"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "invited_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "last_name": {
+                    "type": "string",
+                    "example": "Yılmaz"
+                },
+                "last_sent_at": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "05551234567"
+                },
+                "polyclinic_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "role": {
+                    "type": "string",
+                    "example": "çalışan"
+                },
+                "send_count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "status": {
+                    "description": "pending, accepted, revoked, expired",
+                    "type": "string",
+                    "example": "pending"
+                }
+            }
+        },
+        "model.InvitationTokenRequest": {
+            "description": "Davet linkindeki token",
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "lang": {
+                    "description": "SMS dili (boşsa davet dili)",
+                    "type": "string",
+                    "example": "tr"
+                },
+                "token": {
+                    "description": "Davet linkindeki token",
+                    "type": "string",
+                    "example": "Zm9vYmFy.c2lnbmF0dXJl"
+                }
+            }
+        },
+        "model.JobGroup": {
+            "description": "Meslek grubu bilgileri",
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "job_titles": {
+                    "description": "Bu gruba ait unvanlar",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.JobTitle"
+                    }
+                },
+                "name": {
+                    "description": "Meslek grubu adı",
+                    "type": "string",
+                    "example": "Doktor"
+                }
+            }
+        },
+        "model.JobGroupRequest": {
+            "description": "Meslek grubu ekleme/güncelleme",
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Doktor"
+                }
+            }
+        },
+        "model.JobTitle": {
+            "description": "Unvan bilgileri",
+            "type": "object",
+            "required": [
+                "job_group_id",
+                "name"
+            ],
+            "properties": {
+                "is_unique": {
+                    "description": "Bu unvandan hastanede sadece 1 tane olabilir mi? (örn: Başhekim)",
+                    "type": "boolean",
+                    "example": false
+                },
+                "job_group": {
+                    "description": "Meslek grubu bilgisi",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.JobGroup"
+                        }
+                    ]
+                },
+                "job_group_id": {
+                    "description": "Bağlı olduğu meslek grubu",
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "description": "Unvan adı",
+                    "type": "string",
+                    "example": "Uzman Doktor"
+                }
+            }
+        },
+        "model.JobTitleRequest": {
+            "description": "Unvan ekleme/güncelleme",
+            "type": "object",
+            "required": [
+                "job_group_id",
+                "name"
+            ],
+            "properties": {
+                "is_unique": {
+                    "description": "Hastanede bu unvandan sadece bir kişi olabilir mi?",
+                    "type": "boolean",
+                    "example": false
+                },
+                "job_group_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Uzman Doktor"
+                }
+            }
+        },
+        "model.JoinCodeResponse": {
+            "description": "Katılım kodu bilgisi - code alanı sadece oluşturulurken döner",
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "ANK7-QX2M-9PDR"
+                },
+                "code_hint": {
+                    "description": "Listede tanımak için kodun son 4 karakteri",
+                    "type": "string",
+                    "example": "M9PD"
+                },
+                "created_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "hospital_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "max_uses": {
+                    "description": "0 = sınırsız",
+                    "type": "integer",
+                    "example": 20
+                },
+                "polyclinic_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "çalışan"
+                },
+                "status": {
+                    "description": "active, expired, exhausted, revoked",
+                    "type": "string",
+                    "example": "active"
+                },
+                "use_count": {
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "model.LeaveBalanceRequest": {
+            "description": "Yıllık izin hakkı güncelleme verisi",
+            "type": "object",
+            "required": [
+                "leave_type_id",
+                "year"
+            ],
+            "properties": {
+                "days": {
+                    "description": "Devreden izinler dahil toplam hak",
+                    "type": "integer",
+                    "example": 24
+                },
+                "leave_type_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "year": {
+                    "type": "integer",
+                    "example": 2025
+                }
+            }
+        },
+        "model.LeaveBalanceSummary": {
+            "description": "İzin bakiyesi",
+            "type": "object",
+            "properties": {
+                "entitled": {
+                    "description": "Yıllık hak",
+                    "type": "integer",
+                    "example": 20
+                },
+                "is_overridden": {
+                    "description": "Hak personele özel tanımlanmış mı?",
+                    "type": "boolean",
+                    "example": false
+                },
+                "leave_type_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "leave_type_name": {
+                    "type": "string",
+                    "example": "Yıllık İzin"
+                },
+                "pending": {
+                    "description": "Onay bekleyen izin günleri",
+                    "type": "integer",
+                    "example": 3
+                },
+                "remaining": {
+                    "description": "Hak - kullanılan - bekleyen",
+                    "type": "integer",
+                    "example": 12
+                },
+                "tracked": {
+                    "description": "false ise hak sınırı yok, sadece kullanım gösterilir",
+                    "type": "boolean",
+                    "example": true
+                },
+                "used": {
+                    "description": "Onaylanmış izin günleri",
+                    "type": "integer",
+                    "example": 5
+                },
+                "year": {
+                    "type": "integer",
+                    "example": 2025
+                }
+            }
+        },
+        "model.LeaveRequest": {
+            "description": "İzin talebi",
+            "type": "object",
+            "properties": {
+                "cancelled_at": {
+                    "description": "İptal zamanı",
+                    "type": "string"
+                },
+                "cancelled_by": {
+                    "description": "İptal eden kullanıcı",
+                    "type": "integer",
+                    "example": 3
+                },
+                "days": {
+                    "description": "İzinden düşen çalışma günü sayısı",
+                    "type": "integer",
+                    "example": 10
+                },
+                "end_date": {
+                    "description": "Son izin günü (dahil)",
+                    "type": "string",
+                    "example": "2025-07-18T00:00:00Z"
+                },
+                "hospital_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "leave_type": {
+                    "$ref": "#/definitions/model.LeaveType"
+                },
+                "leave_type_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "note": {
+                    "description": "Talep açıklaması",
+                    "type": "string",
+                    "example": "Yaz tatili"
+                },
+                "reject_reason": {
+                    "type": "string",
+                    "example": "Aynı tarihlerde poliklinikte yeterli hekim yok"
+                },
+                "requested_by": {
+                    "description": "Talebi giren kullanıcı (API anahtarıyla girildiyse boş)",
+                    "type": "integer",
+                    "example": 3
+                },
+                "reviewed_at": {
+                    "description": "İnceleme zamanı",
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "description": "Onaylayan/reddeden yetkili",
+                    "type": "integer",
+                    "example": 1
+                },
+                "staff_id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "staff_name": {
+                    "description": "Listelerde gösterim için",
+                    "type": "string",
+                    "example": "Dr. Mehmet Özkan"
+                },
+                "start_date": {
+                    "description": "İlk izin günü",
+                    "type": "string",
+                    "example": "2025-07-07T00:00:00Z"
+                },
+                "status": {
+                    "description": "pending, approved, rejected, cancelled",
+                    "type": "string",
+                    "example": "pending"
+                }
+            }
+        },
+        "model.LeaveType": {
+            "description": "İzin türü",
+            "type": "object",
+            "properties": {
+                "annual_days": {
+                    "description": "Yıllık hak (gün) - 0 ise bakiye takip edilmez",
+                    "type": "integer",
+                    "example": 20
+                },
+                "hospital_id": {
+                    "description": "Hangi hastaneye ait",
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "description": "İzin türü adı",
+                    "type": "string",
+                    "example": "Yıllık İzin"
+                }
+            }
+        },
+        "model.LeaveTypeRequest": {
+            "description": "İzin türü ekleme/güncelleme verisi",
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "annual_days": {
+                    "description": "Yıllık hak (gün) - 0 ise bakiye takip edilmez",
+                    "type": "integer",
+                    "example": 20
+                },
+                "name": {
+                    "description": "İzin türü adı (hastanede benzersiz)",
+                    "type": "string",
+                    "example": "Yıllık İzin"
+                }
+            }
+        },
+        "model.LoginRequest": {
+            "description": "Kullanıcı giriş bilgileri (email veya telefon ile)",
+            "type": "object",
+            "required": [
+                "email_or_phone",
+                "password"
+            ],
+            "properties": {
+                "email_or_phone": {
+                    "description": "E-posta adresi veya telefon numarası",
+                    "type": "string",
+                    "example": "ahmet.yilmaz@example.com"
+                },
+                "password": {
+                    "description": "Şifre",
+                    "type": "string",
+                    "minLength": 6,
+                    "example": "123456"
+                }
+            }
+        },
+        "model.LogoutRequest": {
+            "description": "Çıkış isteği",
+            "type": "object",
+            "properties": {
+                "all_sessions": {
+                    "description": "true ise tüm cihazlardaki oturumlar sonlandırılır",
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "model.MFAActivationResponse": {
+            "description": "TOTP kaydı tamamlandı - kurtarma kodları sadece bir kez gösterilir",
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "description": "Tek kullanımlık kurtarma kodları",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "abcd-efgh",
+                        "ijkl-mnop"
+                    ]
+                },
+                "tokens": {
+                    "description": "MFA doğrulanmış yeni token çifti",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.TokenResponse"
+                        }
+                    ]
+                }
+            }
+        },
+        "model.MFACodeRequest": {
+            "description": "TOTP kodu doğrulama isteği",
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "description": "Authenticator uygulamasındaki 6 haneli kod",
+                    "type": "string",
+                    "example": "123456"
+                }
+            }
+        },
+        "model.MFAEnrollResponse": {
+            "description": "TOTP kayıt başlatma yanıtı - authenticator uygulamasına eklenecek bilgiler",
+            "type": "object",
+            "properties": {
+                "otpauth_url": {
+                    "description": "Provisioning URI",
+                    "type": "string",
+                    "example": "otpauth://totp/Hastane%20Takip:ahmet@example.com?secret=..."
+                },
+                "qr_code": {
+                    "description": "QR kod (PNG, data URI)",
+                    "type": "string",
+                    "example": "data:image/png;base64,iVBORw0KGgo..."
+                },
+                "secret": {
+                    "description": "Manuel giriş için base32 secret",
+                    "type": "string",
+                    "example": "JBSWY3DPEHPK3PXP"
+                }
+            }
+        },
+        "model.MFALoginRequest": {
+            "description": "Login ikinci adımı - TOTP kodu veya kurtarma kodu",
+            "type": "object",
+            "required": [
+                "mfa_token"
+            ],
+            "properties": {
+                "code": {
+                    "description": "TOTP kodu",
+                    "type": "string",
+                    "example": "123456"
+                },
+                "mfa_token": {
+                    "description": "Login'de dönen mfa_token",
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "recovery_code": {
+                    "description": "veya kurtarma kodu",
+                    "type": "string",
+                    "example": "abcd-efgh"
+                }
+            }
+        },
+        "model.MembershipResponse": {
+            "description": "Kullanıcının giriş yapabildiği hastane",
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "Token'ın geçerli olduğu aktif hastane mi?",
+                    "type": "boolean",
+                    "example": false
+                },
+                "hospital_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "hospital_name": {
+                    "type": "string",
+                    "example": "Acıbadem Hastanesi"
+                },
+                "polyclinic_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "primary": {
+                    "description": "Hesabın ait olduğu (birincil) hastane mi?",
+                    "type": "boolean",
+                    "example": true
+                },
+                "role": {
+                    "type": "string",
+                    "example": "çalışan"
+                }
+            }
+        },
+        "model.PaginationInfo": {
+            "description": "Sayfalama bilgileri",
+            "type": "object",
+            "properties": {
+                "current_page": {
+                    "description": "Mevcut sayfa",
+                    "type": "integer",
+                    "example": 1
+                },
+                "has_next": {
+                    "description": "Sonraki sayfa var mı?",
+                    "type": "boolean",
+                    "example": true
+                },
+                "has_prev": {
+                    "description": "Önceki sayfa var mı?",
+                    "type": "boolean",
+                    "example": false
+                },
+                "page_size": {
+                    "description": "Sayfa başına kayıt",
+                    "type": "integer",
+                    "example": 10
+                },
+                "total_pages": {
+                    "description": "Toplam sayfa sayısı",
+                    "type": "integer",
+                    "example": 5
+                },
+                "total_records": {
+                    "description": "Toplam kayıt sayısı",
+                    "type": "integer",
+                    "example": 45
+                }
+            }
+        },
+        "model.PasskeyAuthenticatorSelection": {
+            "description": "Authenticator seçim kriterleri",
+            "type": "object",
+            "properties": {
+                "residentKey": {
+                    "type": "string",
+                    "example": "preferred"
+                },
+                "userVerification": {
+                    "type": "string",
+                    "example": "required"
+                }
+            }
+        },
+        "model.PasskeyCreationOptions": {
+            "description": "navigator.credentials.create() için publicKey seçenekleri",
+            "type": "object",
+            "properties": {
+                "attestation": {
+                    "type": "string",
+                    "example": "none"
+                },
+                "authenticatorSelection": {
+                    "$ref": "#/definitions/model.PasskeyAuthenticatorSelection"
+                },
+                "challenge": {
+                    "type": "string"
+                },
+                "excludeCredentials": {
+                    "description": "Zaten kayıtlı anahtarlar",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PasskeyCredentialDescriptor"
+                    }
+                },
+                "pubKeyCredParams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PasskeyCredentialParam"
+                    }
+                },
+                "rp": {
+                    "$ref": "#/definitions/model.PasskeyRelyingParty"
+                },
+                "timeout": {
+                    "description": "Milisaniye",
+                    "type": "integer",
+                    "example": 300000
+                },
+                "user": {
+                    "$ref": "#/definitions/model.PasskeyUserEntity"
+                }
+            }
+        },
+        "model.PasskeyCredential": {
+            "description": "Tarayıcının döndürdüğü PublicKeyCredential (byte alanları base64url)",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "rawId": {
+                    "type": "string"
+                },
+                "response": {
+                    "$ref": "#/definitions/model.PasskeyCredentialResponse"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "public-key"
+                }
+            }
+        },
+        "model.PasskeyCredentialDescriptor": {
+            "description": "Kimlik bilgisi tanımlayıcısı (hariç tutulan veya izin verilen)",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "base64url",
+                    "type": "string"
+                },
+                "transports": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "internal",
+                        "hybrid"
+                    ]
+                },
+                "type": {
+                    "type": "string",
+                    "example": "public-key"
+                }
+            }
+        },
+        "model.PasskeyCredentialParam": {
+            "description": "Kabul edilen açık anahtar algoritması",
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "integer",
+                    "example": -7
+                },
+                "type": {
+                    "type": "string",
+                    "example": "public-key"
+                }
+            }
+        },
+        "model.PasskeyCredentialResponse": {
+            "description": "Authenticator yanıtı - kayıtta attestationObject, girişte authenticatorData + signature dolu",
+            "type": "object",
+            "properties": {
+                "attestationObject": {
+                    "type": "string"
+                },
+                "authenticatorData": {
+                    "type": "string"
+                },
+                "clientDataJSON": {
+                    "type": "string"
+                },
+                "signature": {
+                    "type": "string"
+                },
+                "transports": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "userHandle": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PasskeyRelyingParty": {
+            "description": "Relying party (bu uygulama)",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "hbys.hastane.com"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Hastane Yönetim Platformu"
+                }
+            }
+        },
+        "model.PasskeyRequestOptions": {
+            "description": "navigator.credentials.get() için publicKey seçenekleri",
+            "type": "object",
+            "properties": {
+                "allowCredentials": {
+                    "description": "Boşsa cihazdaki keşfedilebilir passkey'ler önerilir",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PasskeyCredentialDescriptor"
+                    }
+                },
+                "challenge": {
+                    "type": "string"
+                },
+                "rpId": {
+                    "type": "string",
+                    "example": "hbys.hastane.com"
+                },
+                "timeout": {
+                    "description": "Milisaniye",
+                    "type": "integer",
+                    "example": 300000
+                },
+                "userVerification": {
+                    "type": "string",
+                    "example": "required"
+                }
+            }
+        },
+        "model.PasskeyResponse": {
+            "description": "Kayıtlı passkey bilgisi",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "İş bilgisayarı"
+                },
+                "synced": {
+                    "description": "Bulut ile senkronize edilen passkey mi?",
+                    "type": "boolean",
+                    "example": true
+                },
+                "transports": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "internal",
+                        "hybrid"
+                    ]
+                }
+            }
+        },
+        "model.PasskeyUserEntity": {
+            "description": "Passkey'in bağlanacağı kullanıcı",
+            "type": "object",
+            "properties": {
+                "displayName": {
+                    "type": "string",
+                    "example": "Ahmet Yılmaz"
+                },
+                "id": {
+                    "description": "Kullanıcı handle'ı (base64url)",
+                    "type": "string",
+                    "example": "AAAAAAAAAAE"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "ahmet@example.com"
+                }
+            }
+        },
+        "model.PasswordChangeRequest": {
+            "description": "Süresi dolan şifrenin login sırasında değiştirilmesi",
+            "type": "object",
+            "required": [
+                "confirm_password",
+                "new_password",
+                "password_change_token"
+            ],
+            "properties": {
+                "confirm_password": {
+                    "description": "Şifre tekrarı",
+                    "type": "string",
+                    "example": "YeniSifre123"
+                },
+                "new_password": {
+                    "description": "Yeni şifre",
+                    "type": "string",
+                    "example": "YeniSifre123"
+                },
+                "password_change_token": {
+                    "description": "Login'de dönen password_change_token",
+                    "type": "string",
+                    "example": "eyJhbGciOiJSUzI1NiIs..."
+                }
+            }
+        },
+        "model.PasswordPolicy": {
+            "description": "Hastane şifre politikası",
+            "type": "object",
+            "properties": {
+                "check_deny_list": {
+                    "description": "Yaygın/sızdırılmış şifre listesinde olmamalı",
+                    "type": "boolean",
+                    "example": true
+                },
+                "history_count": {
+                    "description": "Son N şifre tekrar kullanılamaz (0: kontrol yok)",
+                    "type": "integer",
+                    "example": 5
+                },
+                "hospital_id": {
+                    "description": "Hangi hastaneye ait",
+                    "type": "integer",
+                    "example": 1
+                },
+                "max_age_days": {
+                    "description": "Şifre geçerlilik süresi (gün, 0: süresiz)",
+                    "type": "integer",
+                    "example": 90
+                },
+                "min_length": {
+                    "description": "Minimum uzunluk",
+                    "type": "integer",
+                    "example": 8
+                },
+                "require_digit": {
+                    "description": "En az bir rakam",
+                    "type": "boolean",
+                    "example": true
+                },
+                "require_lower": {
+                    "description": "En az bir küçük harf",
+                    "type": "boolean",
+                    "example": true
+                },
+                "require_symbol": {
+                    "description": "En az bir özel karakter",
+                    "type": "boolean",
+                    "example": false
+                },
+                "require_upper": {
+                    "description": "En az bir büyük harf",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "model.PermissionInfo": {
+            "description": "Yetki bilgisi",
+            "type": "object",
+            "properties": {
+                "description": {
+                    "description": "Açıklama",
+                    "type": "string",
+                    "example": "Personel ekleme/güncelleme"
+                },
+                "name": {
+                    "description": "Yetki adı",
+                    "type": "string",
+                    "example": "staff:write"
+                }
+            }
+        },
+        "model.PlatformActionRequest": {
+            "description": "Gerekçe zorunlu platform işlemi (askıya alma, destek işlemleri)",
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "description": "Denetim kaydına yazılır",
+                    "type": "string",
+                    "example": "Destek talebi #4521"
+                }
+            }
+        },
+        "model.PlatformAuditLog": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "İşlem kodu (bkz. PlatformAction*)",
+                    "type": "string",
+                    "example": "hospital.suspend"
+                },
+                "admin_email": {
+                    "description": "Operatör silinse de kayıt okunabilsin",
+                    "type": "string",
+                    "example": "operator@platform.com"
+                },
+                "admin_id": {
+                    "description": "İşlemi yapan operatör",
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "details": {
+                    "description": "İşleme özel ek bilgiler (JSON)",
+                    "type": "string",
+                    "example": "{\"name\":\"Doktor\"}"
+                },
+                "hospital_id": {
+                    "description": "İşlemin ilgili olduğu hastane (varsa)",
+                    "type": "integer",
+                    "example": 12
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "ip": {
+                    "type": "string",
+                    "example": "10.0.0.5"
+                },
+                "reason": {
+                    "description": "Operatörün girdiği gerekçe",
+                    "type": "string",
+                    "example": "Destek talebi #4521"
+                },
+                "target_id": {
+                    "description": "Etkilenen kayıt ID",
+                    "type": "integer",
+                    "example": 12
+                },
+                "target_type": {
+                    "description": "Etkilenen kayıt türü",
+                    "type": "string",
+                    "example": "hospital"
+                },
+                "user_agent": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PlatformAuditLogListRequest": {
+            "description": "Denetim kaydı filtreleri",
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "hospital.suspend"
+                },
+                "admin_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "hospital_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "page": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "page_size": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1,
+                    "example": 50
+                },
+                "target_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "target_type": {
+                    "type": "string",
+                    "example": "hospital"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2024-12-31T23:59:59Z"
+                }
+            }
+        },
+        "model.PlatformAuditLogListResponse": {
+            "description": "Sayfalandırılmış denetim kayıtları",
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PlatformAuditLog"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/model.PaginationInfo"
+                }
+            }
+        },
+        "model.PlatformHospitalDetail": {
+            "description": "Platform hastane detayı",
+            "type": "object",
+            "properties": {
+                "address_detail": {
+                    "type": "string",
+                    "example": "Beşiktaş Caddesi No:123"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "district_name": {
+                    "type": "string",
+                    "example": "Beşiktaş"
+                },
+                "documents": {
+                    "description": "Başvuru belgeleri (içerik ayrı endpoint'ten indirilir)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.HospitalDocument"
+                    }
+                },
+                "email": {
+                    "type": "string",
+                    "example": "info@acibadem.com"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "member_count": {
+                    "description": "Başka hastaneden üye kullanıcılar",
+                    "type": "integer",
+                    "example": 3
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Acıbadem Hastanesi"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "02121234567"
+                },
+                "polyclinic_count": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "province_name": {
+                    "type": "string",
+                    "example": "İstanbul"
+                },
+                "require_mfa": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "sso_enabled": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "staff_count": {
+                    "type": "integer",
+                    "example": 140
+                },
+                "status": {
+                    "type": "string",
+                    "example": "active"
+                },
+                "status_changed_at": {
+                    "type": "string"
+                },
+                "status_reason": {
+                    "type": "string",
+                    "example": ""
+                },
+                "submitted_at": {
+                    "type": "string"
+                },
+                "tax_id": {
+                    "type": "string",
+                    "example": "1234567890"
+                },
+                "user_count": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "model.PlatformHospitalListRequest": {
+            "description": "Platform hastane listesi filtreleri",
+            "type": "object",
+            "properties": {
+                "page": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "page_size": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1,
+                    "example": 20
+                },
+                "province_id": {
+                    "type": "integer",
+                    "example": 34
+                },
+                "query": {
+                    "description": "Ad, vergi no, e-posta veya telefonda arar",
+                    "type": "string",
+                    "example": "acıbadem"
+                },
+                "status": {
+                    "description": "active, suspended",
+                    "type": "string",
+                    "example": "active"
+                }
+            }
+        },
+        "model.PlatformHospitalListResponse": {
+            "description": "Sayfalandırılmış platform hastane listesi",
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PlatformHospitalSummary"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/model.PaginationInfo"
+                }
+            }
+        },
+        "model.PlatformHospitalSummary": {
+            "description": "Platform hastane listesi satırı",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "district_name": {
+                    "type": "string",
+                    "example": "Beşiktaş"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "info@acibadem.com"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Acıbadem Hastanesi"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "02121234567"
+                },
+                "province_name": {
+                    "type": "string",
+                    "example": "İstanbul"
+                },
+                "staff_count": {
+                    "type": "integer",
+                    "example": 140
+                },
+                "status": {
+                    "type": "string",
+                    "example": "active"
+                },
+                "status_changed_at": {
+                    "type": "string"
+                },
+                "status_reason": {
+                    "type": "string",
+                    "example": ""
+                },
+                "submitted_at": {
+                    "type": "string"
+                },
+                "tax_id": {
+                    "type": "string",
+                    "example": "1234567890"
+                },
+                "user_count": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "model.PlatformLoginRequest": {
+            "description": "Platform operatörü girişi - TOTP kaydı tamamlanmışsa kod zorunludur",
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "code": {
+                    "description": "Authenticator uygulamasındaki 6 haneli kod",
+                    "type": "string",
+                    "example": "123456"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "operator@platform.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "GüçlüŞifre123!"
+                }
+            }
+        },
+        "model.PlatformTokenResponse": {
+            "description": "Platform operatörü token'ı - hastane endpoint'lerinde geçersizdir",
+            "type": "object",
+            "properties": {
+                "expires_in": {
+                    "description": "Saniye",
+                    "type": "integer",
+                    "example": 3600
+                },
+                "mfa": {
+                    "description": "Token TOTP ile doğrulandı mı?",
+                    "type": "boolean",
+                    "example": true
+                },
+                "mfa_enrollment_required": {
+                    "description": "true ise önce /platform/me/mfa ile TOTP kaydı yapılmalı",
+                    "type": "boolean",
+                    "example": false
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJSUzI1NiIs..."
+                },
+                "token_type": {
+                    "type": "string",
+                    "example": "Bearer"
+                }
+            }
+        },
+        "model.PlatformUserListResponse": {
+            "description": "Sayfalandırılmış platform kullanıcı listesi",
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PlatformUserSummary"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/model.PaginationInfo"
+                }
+            }
+        },
+        "model.PlatformUserSearchRequest": {
+            "description": "Hastaneler arası kullanıcı arama",
+            "type": "object",
+            "required": [
+                "query"
+            ],
+            "properties": {
+                "hospital_id": {
+                    "description": "Sadece bu hastanenin kullanıcıları",
+                    "type": "integer",
+                    "example": 1
+                },
+                "page": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "page_size": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1,
+                    "example": 20
+                },
+                "query": {
+                    "description": "E-posta, telefon, TC veya ad soyad",
+                    "type": "string",
+                    "example": "ahmet.yilmaz@example.com"
+                }
+            }
+        },
+        "model.PlatformUserSummary": {
+            "description": "Platform kullanıcı arama satırı",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "ahmet.yilmaz@example.com"
+                },
+                "first_name": {
+                    "type": "string",
+                    "example": "Ahmet"
+                },
+                "hospital_id": {
+                    "description": "Birincil hastane",
+                    "type": "integer",
+                    "example": 1
+                },
+                "hospital_name": {
+                    "type": "string",
+                    "example": "Acıbadem Hastanesi"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "last_name": {
+                    "type": "string",
+                    "example": "Yılmaz"
+                },
+                "mfa_enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "05551234567"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "yetkili"
+                },
+                "status": {
+                    "description": "active, suspended, locked, pending",
+                    "type": "string",
+                    "example": "active"
+                }
+            }
+        },
+        "model.Polyclinic": {
+            "description": "Hastane poliklinik bilgileri (eski model)",
+            "type": "object",
+            "required": [
+                "floor",
+                "name",
+                "room_number"
+            ],
+            "properties": {
+                "floor": {
+                    "description": "Kat numarası",
+                    "type": "integer",
+                    "example": 2
+                },
+                "name": {
+                    "description": "Poliklinik adı",
+                    "type": "string",
+                    "example": "Kardiyoloji"
+                },
+                "room_number": {
+                    "description": "Oda numarası",
+                    "type": "integer",
+                    "example": 205
+                }
+            }
+        },
+        "model.PolyclinicType": {
+            "description": "Poliklinik türü bilgileri (master data)",
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "description": "Açıklama",
+                    "type": "string",
+                    "example": "Kalp ve damar hastalıkları"
+                },
+                "name": {
+                    "description": "Poliklinik türü adı",
+                    "type": "string",
+                    "example": "Kardiyoloji"
+                }
+            }
+        },
+        "model.PolyclinicTypeRequest": {
+            "description": "Poliklinik türü ekleme/güncelleme",
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Kalp ve damar hastalıkları"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Kardiyoloji"
+                }
+            }
+        },
+        "model.ProfileResponse": {
+            "description": "Giriş yapan kullanıcının kendi profili",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "ahmet.yilmaz@example.com"
+                },
+                "email_verified": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "first_name": {
+                    "type": "string",
+                    "example": "Ahmet"
+                },
+                "has_password": {
+                    "description": "SSO ile açılan hesaplarda yerel şifre yoktur",
+                    "type": "boolean",
+                    "example": true
+                },
+                "hospital_id": {
+                    "description": "Token'daki aktif hastane",
+                    "type": "integer",
+                    "example": 1
+                },
+                "hospital_name": {
+                    "type": "string",
+                    "example": "Acıbadem Hastanesi"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "last_name": {
+                    "type": "string",
+                    "example": "Yılmaz"
+                },
+                "mfa_enabled": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "password_changed_at": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "05551234567"
+                },
+                "phone_verified": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "role": {
+                    "description": "Aktif hastanedeki rol",
+                    "type": "string",
+                    "example": "yetkili"
+                },
+                "tc": {
+                    "description": "Maskelenmiş - değiştirilemez",
+                    "type": "string",
+                    "example": "123******01"
+                }
+            }
+        },
+        "model.Province": {
+            "description": "İl bilgileri",
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "districts": {
+                    "description": "İlçeler",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.District"
+                    }
+                },
+                "name": {
+                    "description": "İl adı",
+                    "type": "string",
+                    "example": "İstanbul"
+                }
+            }
+        },
+        "model.ProvinceRequest": {
+            "description": "İl ekleme/güncelleme",
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "İstanbul"
+                }
+            }
+        },
+        "model.RefreshTokenRequest": {
+            "description": "Refresh token ile yeni token çifti alma isteği",
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "description": "Login'de dönen refresh token",
+                    "type": "string",
+                    "example": "q1w2e3r4t5y6..."
+                }
+            }
+        },
+        "model.RejectLeaveRequest": {
+            "description": "İzin talebini reddet",
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "Aynı tarihlerde poliklinikte yeterli hekim yok"
+                }
+            }
+        },
+        "model.RejectSignupRequest": {
+            "description": "Başvuruyu reddet",
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "Kadromuzda bulunmuyor"
+                }
+            }
+        },
+        "model.ResetPasswordConfirm": {
+            "description": "Şifre sıfırlama onayı",
+            "type": "object",
+            "required": [
+                "code",
+                "confirm_password",
+                "new_password",
+                "phone"
+            ],
+            "properties": {
+                "code": {
+                    "description": "Doğrulama kodu",
+                    "type": "string",
+                    "example": "123456"
+                },
+                "confirm_password": {
+                    "description": "Şifre tekrarı",
+                    "type": "string",
+                    "example": "yeni123456"
+                },
+                "new_password": {
+                    "description": "Yeni şifre",
+                    "type": "string",
+                    "minLength": 6,
+                    "example": "yeni123456"
+                },
+                "phone": {
+                    "description": "Telefon numarası",
+                    "type": "string",
+                    "example": "05551234567"
+                }
+            }
+        },
+        "model.ResetPasswordRequest": {
+            "description": "Şifre sıfırlama talebi",
+            "type": "object",
+            "required": [
+                "phone"
+            ],
+            "properties": {
+                "channel": {
+                    "description": "Kodun gönderileceği kanal: sms (varsayılan) veya email (kayıtlı e-posta adresine)",
+                    "type": "string",
+                    "example": "sms"
+                },
+                "lang": {
+                    "description": "Mesaj dili: tr veya en (boşsa Accept-Language)",
+                    "type": "string",
+                    "example": "tr"
+                },
+                "phone": {
+                    "description": "Telefon numarası",
+                    "type": "string",
+                    "example": "05551234567"
+                }
+            }
+        },
+        "model.RoleRequest": {
+            "description": "Rol ekleme/güncelleme verisi",
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "description": "Açıklama",
+                    "type": "string",
+                    "example": "Personel yönetimi yapabilir"
+                },
+                "name": {
+                    "description": "Rol adı",
+                    "type": "string",
+                    "example": "İK Sorumlusu"
+                },
+                "permissions": {
+                    "description": "Yetki listesi",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "staff:read",
+                        "staff:write"
+                    ]
                 }
             }
-        }
-    },
-    "definitions": {
-        "model.AddPolyclinicRequest": {
-            "description": "Hastaneye poliklinik ekleme verisi",
+        },
+        "model.RoleResponse": {
+            "description": "Rol bilgisi",
+            "type": "object",
+            "properties": {
+                "description": {
+                    "description": "Açıklama",
+                    "type": "string",
+                    "example": "Personel yönetimi"
+                },
+                "id": {
+                    "description": "Rol ID",
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_system": {
+                    "description": "Sistem rolü mü?",
+                    "type": "boolean",
+                    "example": false
+                },
+                "name": {
+                    "description": "Rol adı",
+                    "type": "string",
+                    "example": "İK Sorumlusu"
+                },
+                "permissions": {
+                    "description": "Yetkiler",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "staff:read",
+                        "staff:write"
+                    ]
+                },
+                "user_count": {
+                    "description": "Bu role sahip kullanıcı sayısı",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "model.SSOCallbackRequest": {
+            "description": "IdP'nin dönüş adresine eklediği code ve state",
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "SplxlOBeZQQYbYS6WxSbIA"
+                },
+                "error": {
+                    "description": "IdP hata döndürdüyse",
+                    "type": "string",
+                    "example": "access_denied"
+                },
+                "state": {
+                    "type": "string",
+                    "example": "af0ifjsldkj"
+                }
+            }
+        },
+        "model.SSOConfigRequest": {
+            "description": "SSO ayarlarını kaydetme isteği",
             "type": "object",
             "required": [
-                "floor",
-                "polyclinic_type_id",
-                "room_number"
+                "client_id",
+                "issuer"
             ],
             "properties": {
-                "floor": {
-                    "description": "Kat numarası",
+                "client_id": {
+                    "type": "string",
+                    "example": "hospital-platform"
+                },
+                "client_secret": {
+                    "description": "Boş bırakılırsa mevcut secret korunur",
+                    "type": "string",
+                    "example": "s3cr3t"
+                },
+                "default_role": {
+                    "type": "string",
+                    "example": "çalışan"
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "issuer": {
+                    "type": "string",
+                    "example": "https://idp.hastanegrubu.com.tr"
+                },
+                "jit_provisioning": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "role_claim": {
+                    "type": "string",
+                    "example": "groups"
+                },
+                "role_mappings": {
+                    "description": "Sıra öncelik belirtir",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SSORoleMappingRequest"
+                    }
+                },
+                "scopes": {
+                    "description": "Varsayılan: openid email profile",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "openid",
+                        "email",
+                        "profile"
+                    ]
+                },
+                "sync_role": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "tckn_claim": {
+                    "description": "Varsayılan: tckn",
+                    "type": "string",
+                    "example": "tckn"
+                }
+            }
+        },
+        "model.SSOConfigResponse": {
+            "description": "Hastanenin SSO ayarları - client secret gösterilmez",
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "string",
+                    "example": "hospital-platform"
+                },
+                "default_role": {
+                    "description": "Hiçbir eşleme tutmazsa JIT'te atanacak rol (boşsa giriş reddedilir)",
+                    "type": "string",
+                    "example": "çalışan"
+                },
+                "enabled": {
+                    "description": "SSO ile giriş açık mı?",
+                    "type": "boolean",
+                    "example": true
+                },
+                "has_client_secret": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "hospital_id": {
                     "type": "integer",
-                    "example": 2
+                    "example": 1
                 },
-                "polyclinic_type_id": {
-                    "description": "Master data'dan seçilen tür",
+                "issuer": {
+                    "description": "IdP issuer (discovery bu adresten okunur)",
+                    "type": "string",
+                    "example": "https://idp.hastanegrubu.com.tr"
+                },
+                "jit_provisioning": {
+                    "description": "E-postayla eşleşen kullanıcı yoksa oluşturulsun mu?",
+                    "type": "boolean",
+                    "example": true
+                },
+                "redirect_uri": {
+                    "description": "IdP'de kayıtlı olması gereken dönüş adresi",
+                    "type": "string",
+                    "example": "http://localhost:3000/sso/callback"
+                },
+                "role_claim": {
+                    "description": "Rol eşlemesinde bakılan claim (string veya liste)",
+                    "type": "string",
+                    "example": "groups"
+                },
+                "role_mappings": {
+                    "description": "Claim değeri → rol (öncelik sırasıyla)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SSORoleMapping"
+                    }
+                },
+                "scopes": {
+                    "description": "Boşlukla ayrılmış scope'lar",
+                    "type": "string",
+                    "example": "openid email profile groups"
+                },
+                "sync_role": {
+                    "description": "Her girişte rol claim'den güncellensin mi?",
+                    "type": "boolean",
+                    "example": false
+                },
+                "tckn_claim": {
+                    "description": "JIT'te TC kimlik numarasının okunduğu claim",
+                    "type": "string",
+                    "example": "tckn"
+                },
+                "updated_by": {
                     "type": "integer",
                     "example": 1
+                }
+            }
+        },
+        "model.SSORoleMapping": {
+            "description": "IdP claim değerinden platform rolüne eşleme",
+            "type": "object",
+            "properties": {
+                "claim_value": {
+                    "description": "Role claim'inde aranan değer",
+                    "type": "string",
+                    "example": "HBYS-Hekim"
                 },
-                "room_number": {
-                    "description": "Oda numarası",
+                "role": {
+                    "type": "string",
+                    "example": "Poliklinik Sorumlusu"
+                }
+            }
+        },
+        "model.SSORoleMappingRequest": {
+            "description": "Claim → rol eşlemesi",
+            "type": "object",
+            "required": [
+                "claim_value",
+                "role"
+            ],
+            "properties": {
+                "claim_value": {
+                    "type": "string",
+                    "example": "HBYS-Hekim"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "Poliklinik Sorumlusu"
+                }
+            }
+        },
+        "model.SSOStartRequest": {
+            "description": "SSO girişini başlatma - hastane ID'si veya hastanenin izinli alan adındaki e-posta",
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "ahmet.yilmaz@hastane.gov.tr"
+                },
+                "hospital_id": {
                     "type": "integer",
-                    "example": 205
+                    "example": 1
                 }
             }
         },
-        "model.CreateStaffRequest": {
-            "type": "object"
+        "model.SSOStartResponse": {
+            "description": "Kullanıcının yönlendirileceği IdP giriş adresi",
+            "type": "object",
+            "properties": {
+                "authorization_url": {
+                    "type": "string",
+                    "example": "https://idp.hastanegrubu.com.tr/authorize?response_type=code\u0026client_id=..."
+                }
+            }
         },
-        "model.CreateSubUserRequest": {
-            "description": "Alt kullanıcı ekleme verisi",
+        "model.SelfSignupRequest": {
+            "description": "Kendi kendine kayıt başvurusu - hastane ve rol katılım kodundan veya e-posta alan adından sunucuda belirlenir",
             "type": "object",
             "required": [
+                "confirm_password",
                 "email",
+                "email_code",
                 "first_name",
                 "last_name",
                 "password",
                 "phone",
-                "role",
                 "tc"
             ],
             "properties": {
+                "confirm_password": {
+                    "type": "string",
+                    "example": "GüçlüŞifre123"
+                },
                 "email": {
-                    "description": "E-posta",
                     "type": "string",
-                    "example": "mehmet.yilmaz@example.com"
+                    "example": "zeynep.kaya@hastane.gov.tr"
+                },
+                "email_code": {
+                    "description": "E-postaya gönderilen doğrulama kodu",
+                    "type": "string",
+                    "example": "123456"
+                },
+                "first_name": {
+                    "type": "string",
+                    "example": "Zeynep"
+                },
+                "join_code": {
+                    "description": "Boşsa e-posta alan adıyla eşleştirilir",
+                    "type": "string",
+                    "example": "ANK7-QX2M-9PDR"
+                },
+                "lang": {
+                    "description": "Onay/red bildiriminin dili",
+                    "type": "string",
+                    "example": "tr"
+                },
+                "last_name": {
+                    "type": "string",
+                    "example": "Kaya"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "GüçlüŞifre123"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "05551234567"
+                },
+                "tc": {
+                    "type": "string",
+                    "example": "12345678901"
+                }
+            }
+        },
+        "model.SessionResponse": {
+            "description": "Aktif oturum (cihaz)",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Giriş zamanı",
+                    "type": "string"
+                },
+                "current": {
+                    "description": "İsteği yapan oturum mu?",
+                    "type": "boolean",
+                    "example": false
+                },
+                "device": {
+                    "description": "User-Agent'tan çıkarılan cihaz özeti",
+                    "type": "string",
+                    "example": "Chrome / Windows"
+                },
+                "id": {
+                    "description": "Oturum ID (iptal için)",
+                    "type": "string",
+                    "example": "m3Jr0c8yQ2a1Zb9xW4vTnA"
+                },
+                "ip": {
+                    "description": "Girişin yapıldığı IP",
+                    "type": "string",
+                    "example": "85.105.12.34"
+                },
+                "last_ip": {
+                    "description": "Son görülen IP",
+                    "type": "string",
+                    "example": "85.105.12.34"
+                },
+                "last_seen_at": {
+                    "description": "Son istek zamanı (yaklaşık, dakika hassasiyetinde)",
+                    "type": "string"
+                },
+                "mfa": {
+                    "description": "İki adımlı doğrulamayla açıldı mı?",
+                    "type": "boolean",
+                    "example": true
+                },
+                "user_agent": {
+                    "description": "Ham User-Agent",
+                    "type": "string",
+                    "example": "Mozilla/5.0 (Windows NT 10.0; Win64; x64)"
+                }
+            }
+        },
+        "model.ShiftOccurrence": {
+            "description": "Takvimdeki vardiya",
+            "type": "object",
+            "properties": {
+                "date": {
+                    "description": "Vardiyanın başladığı gün",
+                    "type": "string",
+                    "example": "2025-01-06"
+                },
+                "duty_roster_id": {
+                    "description": "Yayınlanmış nöbet listesinden geliyorsa liste",
+                    "type": "integer",
+                    "example": 4
                 },
-                "first_name": {
-                    "description": "Ad",
+                "end": {
                     "type": "string",
-                    "example": "Mehmet"
+                    "example": "2025-01-07T08:00:00+03:00"
                 },
-                "last_name": {
-                    "description": "Soyad",
+                "leave_request_id": {
+                    "description": "Vardiya günü onaylı izne denk geliyorsa izin talebi",
+                    "type": "integer",
+                    "example": 7
+                },
+                "leave_type_name": {
+                    "description": "İzin türü - personel bu vardiyada müsait değil",
                     "type": "string",
-                    "example": "Yılmaz"
+                    "example": "Yıllık İzin"
                 },
-                "password": {
-                    "description": "Şifre",
+                "schedule_id": {
+                    "description": "Vardiyanın geldiği çalışma programı",
+                    "type": "integer",
+                    "example": 3
+                },
+                "shift_name": {
                     "type": "string",
-                    "minLength": 6,
-                    "example": "123456"
+                    "example": "Gece"
                 },
-                "phone": {
-                    "description": "Telefon",
+                "shift_template_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "start": {
                     "type": "string",
-                    "example": "05551234567"
+                    "example": "2025-01-06T20:00:00+03:00"
+                }
+            }
+        },
+        "model.ShiftTemplate": {
+            "description": "Vardiya şablonu",
+            "type": "object",
+            "properties": {
+                "end_time": {
+                    "description": "Bitiş (SS:DD)",
+                    "type": "string",
+                    "example": "08:00"
                 },
-                "role": {
-                    "description": "Rol",
+                "hospital_id": {
+                    "description": "Hangi hastaneye ait",
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "description": "Vardiya adı",
                     "type": "string",
-                    "enum": [
-                        "yetkili",
-                        "çalışan"
-                    ],
-                    "example": "çalışan"
+                    "example": "Gece"
                 },
-                "tc": {
-                    "description": "TC Kimlik No",
+                "start_time": {
+                    "description": "Başlangıç (SS:DD)",
                     "type": "string",
-                    "example": "12345678901"
+                    "example": "20:00"
                 }
             }
         },
-        "model.District": {
-            "description": "İlçe bilgileri",
+        "model.ShiftTemplateRequest": {
+            "description": "Vardiya şablonu ekleme/güncelleme verisi",
             "type": "object",
             "required": [
+                "end_time",
                 "name",
-                "province_id"
+                "start_time"
             ],
             "properties": {
-                "name": {
-                    "description": "İlçe adı",
+                "end_time": {
+                    "description": "Bitiş (SS:DD) - başlangıçtan küçükse ertesi gün biter",
                     "type": "string",
-                    "example": "Beşiktaş"
+                    "example": "08:00"
                 },
-                "province": {
-                    "description": "İl bilgisi",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/model.Province"
-                        }
-                    ]
+                "name": {
+                    "description": "Vardiya adı (hastanede benzersiz)",
+                    "type": "string",
+                    "example": "Gece"
                 },
-                "province_id": {
-                    "description": "Bağlı olduğu il",
-                    "type": "integer",
-                    "example": 1
+                "start_time": {
+                    "description": "Başlangıç (SS:DD)",
+                    "type": "string",
+                    "example": "20:00"
                 }
             }
         },
-        "model.Hospital": {
-            "description": "Hastane bilgileri",
+        "model.SignupEmailCodeRequest": {
+            "description": "Kayıt için e-posta doğrulama kodu iste",
             "type": "object",
             "required": [
-                "address_detail",
-                "district_id",
-                "email",
-                "name",
-                "phone",
-                "province_id",
-                "tax_id"
+                "email"
             ],
             "properties": {
-                "address_detail": {
-                    "description": "Açık adres",
+                "email": {
                     "type": "string",
-                    "example": "Beşiktaş Caddesi No:123"
+                    "example": "zeynep.kaya@hastane.gov.tr"
                 },
-                "district": {
-                    "description": "İlçe bilgisi",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/model.District"
-                        }
-                    ]
+                "lang": {
+                    "description": "Mesaj dili: tr (varsayılan) veya en",
+                    "type": "string",
+                    "example": "tr"
+                }
+            }
+        },
+        "model.SignupRequest": {
+            "description": "Hastaneye kendi kendine kayıt başvurusu - onaylanana kadar kullanıcı oluşturulmaz",
+            "type": "object",
+            "properties": {
+                "email": {
+                    "description": "E-posta (başvuruda kodla doğrulandı)",
+                    "type": "string",
+                    "example": "zeynep.kaya@hastane.gov.tr"
                 },
-                "district_id": {
-                    "description": "İlçe ID",
+                "first_name": {
+                    "description": "Ad",
+                    "type": "string",
+                    "example": "Zeynep"
+                },
+                "hospital_id": {
+                    "description": "Başvurulan hastane (katılım kodu veya e-posta alan adından belirlenir)",
                     "type": "integer",
                     "example": 1
                 },
-                "email": {
-                    "description": "E-posta adresi",
-                    "type": "string",
-                    "example": "info@acibadem.com"
+                "join_code_id": {
+                    "description": "Kullanılan katılım kodu",
+                    "type": "integer",
+                    "example": 2
                 },
-                "name": {
-                    "description": "Hastane adı",
+                "last_name": {
+                    "description": "Soyad",
                     "type": "string",
-                    "example": "Acıbadem Hastanesi"
+                    "example": "Kaya"
                 },
                 "phone": {
-                    "description": "Telefon numarası",
+                    "description": "Telefon",
                     "type": "string",
-                    "example": "02121234567"
-                },
-                "province": {
-                    "description": "İlişkiler",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/model.Province"
-                        }
-                    ]
+                    "example": "05551234567"
                 },
-                "province_id": {
-                    "description": "İl ID",
+                "polyclinic_id": {
+                    "description": "Bağlı olacağı poliklinik",
                     "type": "integer",
-                    "example": 1
+                    "example": 3
                 },
-                "tax_id": {
-                    "description": "Vergi kimlik numarası",
+                "reject_reason": {
+                    "description": "Red gerekçesi",
                     "type": "string",
-                    "example": "1234567890"
-                },
-                "users": {
-                    "description": "Hastane kullanıcıları",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.User"
-                    }
-                }
-            }
-        },
-        "model.HospitalPolyclinic": {
-            "description": "Hastane poliklinik bilgileri",
-            "type": "object",
-            "required": [
-                "floor",
-                "hospital_id",
-                "polyclinic_type_id",
-                "room_number"
-            ],
-            "properties": {
-                "floor": {
-                    "description": "Kat numarası",
-                    "type": "integer",
-                    "example": 2
+                    "example": "Kadromuzda bulunmuyor"
                 },
-                "hospital": {
-                    "description": "İlişkiler",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/model.Hospital"
-                        }
-                    ]
+                "reviewed_at": {
+                    "description": "İnceleme zamanı",
+                    "type": "string"
                 },
-                "hospital_id": {
-                    "description": "Hangi hastane",
+                "reviewed_by": {
+                    "description": "Onaylayan/reddeden yetkili",
                     "type": "integer",
                     "example": 1
                 },
-                "is_active": {
-                    "description": "Aktif mi?",
-                    "type": "boolean",
-                    "example": true
+                "role": {
+                    "description": "Sunucunun atadığı rol (kod/alan adından, onaylayan değiştirebilir)",
+                    "type": "string",
+                    "example": "çalışan"
                 },
-                "polyclinic_type": {
-                    "$ref": "#/definitions/model.PolyclinicType"
+                "source": {
+                    "description": "join_code veya email_domain",
+                    "type": "string",
+                    "example": "join_code"
                 },
-                "polyclinic_type_id": {
-                    "description": "Poliklinik türü",
-                    "type": "integer",
-                    "example": 1
+                "status": {
+                    "description": "pending, approved, rejected",
+                    "type": "string",
+                    "example": "pending"
                 },
-                "room_number": {
-                    "description": "Oda numarası",
-                    "type": "integer",
-                    "example": 205
+                "tc": {
+                    "description": "TC Kimlik No",
+                    "type": "string",
+                    "example": "12345678901"
                 },
-                "staff": {
-                    "description": "Bu poliklinikte çalışan personel",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.Staff"
-                    }
+                "user_id": {
+                    "description": "Onayda oluşturulan kullanıcı",
+                    "type": "integer",
+                    "example": 15
                 }
             }
         },
-        "model.HospitalPolyclinicSummary": {
-            "description": "Hastane poliklinik özet bilgileri",
+        "model.SignupSubmittedResponse": {
+            "description": "Başvuru alındı - yetkili onayı bekleniyor",
             "type": "object",
             "properties": {
-                "floor": {
-                    "type": "integer",
-                    "example": 2
+                "hospital_name": {
+                    "type": "string",
+                    "example": "Ankara Şehir Hastanesi"
                 },
                 "id": {
                     "type": "integer",
-                    "example": 1
-                },
-                "is_active": {
-                    "type": "boolean",
-                    "example": true
+                    "example": 7
                 },
-                "polyclinic_type_name": {
+                "status": {
                     "type": "string",
-                    "example": "Kardiyoloji"
-                },
-                "room_number": {
-                    "type": "integer",
-                    "example": 205
-                },
-                "staff_by_job_group": {
-                    "description": "Meslek grubuna göre personel sayıları",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.StaffCountByGroup"
-                    }
-                },
-                "total_staff_count": {
-                    "description": "Toplam personel sayısı",
-                    "type": "integer",
-                    "example": 10
+                    "example": "pending"
                 }
             }
         },
-        "model.HospitalRegistrationRequest": {
-            "description": "Hastane kayıt verisi",
+        "model.Staff": {
+            "description": "Hastane personel bilgileri",
             "type": "object",
             "required": [
-                "address_detail",
-                "admin_email",
-                "admin_first_name",
-                "admin_last_name",
-                "admin_password",
-                "admin_phone",
-                "admin_tc",
-                "district_id",
-                "hospital_email",
-                "hospital_name",
-                "hospital_phone",
-                "province_id",
-                "tax_id"
+                "first_name",
+                "hospital_id",
+                "job_group_id",
+                "job_title_id",
+                "last_name",
+                "phone",
+                "tc"
             ],
             "properties": {
-                "address_detail": {
+                "first_name": {
+                    "description": "Ad",
                     "type": "string",
-                    "example": "Beşiktaş Caddesi No:123"
+                    "example": "Dr. Mehmet"
                 },
-                "admin_email": {
-                    "type": "string",
-                    "example": "ahmet.yilmaz@acibadem.com"
+                "hospital": {
+                    "description": "İlişkiler",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Hospital"
+                        }
+                    ]
                 },
-                "admin_first_name": {
-                    "description": "Yetkili Bilgileri",
-                    "type": "string",
-                    "example": "Ahmet"
+                "hospital_id": {
+                    "description": "Hangi hastane",
+                    "type": "integer",
+                    "example": 1
                 },
-                "admin_last_name": {
-                    "type": "string",
-                    "example": "Yılmaz"
+                "is_active": {
+                    "description": "Aktif mi?",
+                    "type": "boolean",
+                    "example": true
                 },
-                "admin_password": {
-                    "type": "string",
-                    "minLength": 6,
-                    "example": "123456"
+                "job_group": {
+                    "$ref": "#/definitions/model.JobGroup"
                 },
-                "admin_phone": {
-                    "type": "string",
-                    "example": "05551234567"
+                "job_group_id": {
+                    "description": "Meslek grubu",
+                    "type": "integer",
+                    "example": 1
                 },
-                "admin_tc": {
-                    "type": "string",
-                    "example": "12345678901"
+                "job_title": {
+                    "$ref": "#/definitions/model.JobTitle"
                 },
-                "district_id": {
+                "job_title_id": {
+                    "description": "Unvan",
                     "type": "integer",
                     "example": 1
                 },
-                "hospital_email": {
+                "last_name": {
+                    "description": "Soyad",
                     "type": "string",
-                    "example": "info@acibadem.com"
+                    "example": "Özkan"
                 },
-                "hospital_name": {
-                    "description": "Hastane Bilgileri",
+                "phone": {
+                    "description": "Telefon",
                     "type": "string",
-                    "example": "Acıbadem Hastanesi"
+                    "example": "05559876543"
                 },
-                "hospital_phone": {
-                    "type": "string",
-                    "example": "02121234567"
+                "polyclinic": {
+                    "$ref": "#/definitions/model.HospitalPolyclinic"
                 },
-                "province_id": {
-                    "description": "Adres Bilgileri",
+                "polyclinic_id": {
+                    "description": "Bağlı olduğu poliklinik (nullable - güvenlik gibi genel personel için)",
                     "type": "integer",
                     "example": 1
                 },
-                "tax_id": {
+                "schedules": {
+                    "description": "Çalışma programları (vardiyalar ve geçerlilik tarihleri)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StaffSchedule"
+                    }
+                },
+                "tc": {
+                    "description": "TC Kimlik No",
                     "type": "string",
-                    "example": "1234567890"
+                    "example": "98765432101"
                 }
             }
         },
-        "model.HospitalRegistrationResponse": {
-            "description": "Hastane kayıt yanıtı",
+        "model.StaffCountByGroup": {
+            "description": "Meslek grubuna göre personel sayısı",
             "type": "object",
             "properties": {
-                "admin_user": {
-                    "$ref": "#/definitions/model.User"
-                },
-                "hospital": {
-                    "$ref": "#/definitions/model.Hospital"
-                },
-                "message": {
-                    "type": "string",
-                    "example": "Hastane başarıyla kaydedildi"
+                "count": {
+                    "type": "integer",
+                    "example": 7
                 },
-                "token": {
+                "job_group_name": {
                     "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                    "example": "Doktor"
                 }
             }
         },
-        "model.JobGroup": {
-            "description": "Meslek grubu bilgileri",
+        "model.StaffImportResponse": {
+            "description": "Toplu personel içe aktarma raporu",
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "job_titles": {
-                    "description": "Bu gruba ait unvanlar",
+                "created_count": {
+                    "description": "Eklenen personel sayısı (commit)",
+                    "type": "integer",
+                    "example": 0
+                },
+                "dry_run": {
+                    "description": "true ise hiçbir kayıt eklenmedi",
+                    "type": "boolean",
+                    "example": true
+                },
+                "invalid_rows": {
+                    "description": "Hatalı satırlar",
+                    "type": "integer",
+                    "example": 3
+                },
+                "rows": {
+                    "description": "Satır bazında sonuçlar",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.JobTitle"
+                        "$ref": "#/definitions/model.StaffImportRow"
                     }
                 },
-                "name": {
-                    "description": "Meslek grubu adı",
-                    "type": "string",
-                    "example": "Doktor"
+                "total_rows": {
+                    "description": "Boş olmayan veri satırı sayısı",
+                    "type": "integer",
+                    "example": 120
+                },
+                "valid_rows": {
+                    "description": "Hatasız satırlar",
+                    "type": "integer",
+                    "example": 117
                 }
             }
         },
-        "model.JobTitle": {
-            "description": "Unvan bilgileri",
+        "model.StaffImportRow": {
+            "description": "İçe aktarılan dosyanın tek bir satırının sonucu",
             "type": "object",
-            "required": [
-                "job_group_id",
-                "name"
-            ],
             "properties": {
-                "is_unique": {
-                    "description": "Bu unvandan hastanede sadece 1 tane olabilir mi? (örn: Başhekim)",
-                    "type": "boolean",
-                    "example": false
+                "first_name": {
+                    "description": "Satırdaki ad (tanıma kolaylığı için)",
+                    "type": "string",
+                    "example": "Mehmet"
                 },
-                "job_group": {
-                    "description": "Meslek grubu bilgisi",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/model.JobGroup"
-                        }
-                    ]
+                "last_name": {
+                    "description": "Satırdaki soyad",
+                    "type": "string",
+                    "example": "Özkan"
                 },
-                "job_group_id": {
-                    "description": "Bağlı olduğu meslek grubu",
+                "row": {
+                    "description": "Dosyadaki satır numarası (başlık 1. satır)",
                     "type": "integer",
-                    "example": 1
+                    "example": 2
                 },
-                "name": {
-                    "description": "Unvan adı",
+                "staff_id": {
+                    "description": "Eklenen personelin ID'si (commit)",
+                    "type": "integer",
+                    "example": 42
+                },
+                "status": {
+                    "description": "valid, invalid, created",
                     "type": "string",
-                    "example": "Uzman Doktor"
+                    "example": "invalid"
+                },
+                "validation_errors": {
+                    "description": "Satırın hataları",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ValidationError"
+                    }
                 }
             }
         },
-        "model.LoginRequest": {
-            "description": "Kullanıcı giriş bilgileri (email veya telefon ile)",
+        "model.StaffListRequest": {
+            "description": "Personel listeleme ve filtreleme verisi",
             "type": "object",
-            "required": [
-                "email_or_phone",
-                "password"
-            ],
             "properties": {
-                "email_or_phone": {
-                    "description": "E-posta adresi veya telefon numarası",
+                "first_name": {
+                    "description": "Filtering (Optional)",
                     "type": "string",
-                    "example": "ahmet.yilmaz@example.com"
+                    "example": "Mehmet"
                 },
-                "password": {
-                    "description": "Şifre",
-                    "type": "string",
-                    "minLength": 6,
-                    "example": "123456"
-                }
-            }
-        },
-        "model.PaginationInfo": {
-            "description": "Sayfalama bilgileri",
-            "type": "object",
-            "properties": {
-                "current_page": {
-                    "description": "Mevcut sayfa",
+                "is_active": {
+                    "description": "Aktiflik durumu ile filtreleme",
+                    "type": "boolean",
+                    "example": true
+                },
+                "job_group_id": {
+                    "description": "Meslek grubu ile filtreleme",
                     "type": "integer",
                     "example": 1
                 },
-                "has_next": {
-                    "description": "Sonraki sayfa var mı?",
-                    "type": "boolean",
-                    "example": true
+                "job_title_id": {
+                    "description": "Unvan ile filtreleme",
+                    "type": "integer",
+                    "example": 2
                 },
-                "has_prev": {
-                    "description": "Önceki sayfa var mı?",
-                    "type": "boolean",
-                    "example": false
+                "last_name": {
+                    "description": "Soyad ile filtreleme",
+                    "type": "string",
+                    "example": "Özkan"
+                },
+                "page": {
+                    "description": "Pagination",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
                 },
                 "page_size": {
-                    "description": "Sayfa başına kayıt",
+                    "description": "Sayfa başına kayıt (1-100 arası)",
                     "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1,
                     "example": 10
                 },
-                "total_pages": {
-                    "description": "Toplam sayfa sayısı",
+                "polyclinic_id": {
+                    "description": "Poliklinik ile filtreleme",
                     "type": "integer",
-                    "example": 5
+                    "example": 1
                 },
-                "total_records": {
-                    "description": "Toplam kayıt sayısı",
-                    "type": "integer",
-                    "example": 45
+                "tc": {
+                    "description": "TC ile filtreleme",
+                    "type": "string",
+                    "example": "98765432101"
                 }
             }
         },
-        "model.Polyclinic": {
-            "description": "Hastane poliklinik bilgileri (eski model)",
+        "model.StaffListResponse": {
+            "description": "Sayfalandırılmış personel listesi yanıtı",
             "type": "object",
-            "required": [
-                "floor",
-                "name",
-                "room_number"
-            ],
             "properties": {
-                "floor": {
-                    "description": "Kat numarası",
+                "data": {
+                    "description": "Personel listesi",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StaffSummary"
+                    }
+                },
+                "pagination": {
+                    "description": "Sayfalama bilgileri",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.PaginationInfo"
+                        }
+                    ]
+                }
+            }
+        },
+        "model.StaffSchedule": {
+            "description": "Personel çalışma programı",
+            "type": "object",
+            "properties": {
+                "cycle_days": {
+                    "description": "Döngü uzunluğu (haftalıkta 7)",
+                    "type": "integer",
+                    "example": 7
+                },
+                "effective_from": {
+                    "description": "Geçerlilik başlangıcı (dönüşümlü döngünün 1. günü)",
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "effective_to": {
+                    "description": "Geçerlilik bitişi (dahil, boşsa süresiz)",
+                    "type": "string",
+                    "example": "2025-12-31T00:00:00Z"
+                },
+                "entries": {
+                    "description": "Döngü günlerindeki vardiyalar",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StaffScheduleEntry"
+                    }
+                },
+                "hospital_id": {
                     "type": "integer",
-                    "example": 2
+                    "example": 1
                 },
-                "name": {
-                    "description": "Poliklinik adı",
+                "pattern": {
+                    "description": "weekly, rotating",
                     "type": "string",
-                    "example": "Kardiyoloji"
+                    "example": "weekly"
                 },
-                "room_number": {
-                    "description": "Oda numarası",
+                "staff_id": {
                     "type": "integer",
-                    "example": 205
+                    "example": 42
                 }
             }
         },
-        "model.PolyclinicType": {
-            "description": "Poliklinik türü bilgileri (master data)",
+        "model.StaffScheduleEntry": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "description": {
-                    "description": "Açıklama",
-                    "type": "string",
-                    "example": "Kalp ve damar hastalıkları"
+                "day": {
+                    "description": "Haftalıkta 1-7, dönüşümlüde 1..cycle_days",
+                    "type": "integer",
+                    "example": 1
                 },
-                "name": {
-                    "description": "Poliklinik türü adı",
-                    "type": "string",
-                    "example": "Kardiyoloji"
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "shift_template": {
+                    "$ref": "#/definitions/model.ShiftTemplate"
+                },
+                "shift_template_id": {
+                    "type": "integer",
+                    "example": 2
                 }
             }
         },
-        "model.Province": {
-            "description": "İl bilgileri",
+        "model.StaffScheduleEntryRequest": {
+            "description": "Program günü",
             "type": "object",
             "required": [
-                "name"
+                "day",
+                "shift_template_id"
             ],
             "properties": {
-                "districts": {
-                    "description": "İlçeler",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.District"
-                    }
+                "day": {
+                    "description": "Haftalıkta 1=Pazartesi..7=Pazar, dönüşümlüde döngünün günü",
+                    "type": "integer",
+                    "example": 1
                 },
-                "name": {
-                    "description": "İl adı",
-                    "type": "string",
-                    "example": "İstanbul"
+                "shift_template_id": {
+                    "description": "Vardiya şablonu",
+                    "type": "integer",
+                    "example": 2
                 }
             }
         },
-        "model.ResetPasswordConfirm": {
-            "description": "Şifre sıfırlama onayı",
+        "model.StaffScheduleRequest": {
+            "description": "Personel çalışma programı verisi",
             "type": "object",
             "required": [
-                "code",
-                "confirm_password",
-                "new_password",
-                "phone"
+                "effective_from",
+                "entries",
+                "pattern"
             ],
             "properties": {
-                "code": {
-                    "description": "Doğrulama kodu",
-                    "type": "string",
-                    "example": "123456"
+                "cycle_days": {
+                    "description": "Dönüşümlü döngü uzunluğu (2-56 gün)",
+                    "type": "integer",
+                    "example": 4
                 },
-                "confirm_password": {
-                    "description": "Şifre tekrarı",
+                "effective_from": {
+                    "description": "Başlangıç tarihi (YYYY-AA-GG)",
                     "type": "string",
-                    "example": "yeni123456"
+                    "example": "2025-01-06"
                 },
-                "new_password": {
-                    "description": "Yeni şifre",
+                "effective_to": {
+                    "description": "Bitiş tarihi (dahil, boşsa süresiz)",
                     "type": "string",
-                    "minLength": 6,
-                    "example": "yeni123456"
+                    "example": "2025-06-30"
                 },
-                "phone": {
-                    "description": "Telefon numarası",
-                    "type": "string",
-                    "example": "05551234567"
-                }
-            }
-        },
-        "model.ResetPasswordRequest": {
-            "description": "Şifre sıfırlama talebi",
-            "type": "object",
-            "required": [
-                "phone"
-            ],
-            "properties": {
-                "phone": {
-                    "description": "Telefon numarası",
+                "entries": {
+                    "description": "Çalışılan günler ve vardiyaları",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StaffScheduleEntryRequest"
+                    }
+                },
+                "pattern": {
+                    "description": "weekly veya rotating",
                     "type": "string",
-                    "example": "05551234567"
+                    "example": "rotating"
                 }
             }
         },
-        "model.Staff": {
-            "description": "Hastane personel bilgileri",
+        "model.StaffSummary": {
+            "description": "Personel özet bilgileri",
             "type": "object",
-            "required": [
-                "first_name",
-                "hospital_id",
-                "job_group_id",
-                "job_title_id",
-                "last_name",
-                "phone",
-                "tc",
-                "work_days"
-            ],
             "properties": {
                 "first_name": {
                     "description": "Ad",
                     "type": "string",
                     "example": "Dr. Mehmet"
                 },
-                "hospital": {
-                    "description": "İlişkiler",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/model.Hospital"
-                        }
-                    ]
-                },
-                "hospital_id": {
-                    "description": "Hangi hastane",
+                "id": {
+                    "description": "Personel ID",
                     "type": "integer",
                     "example": 1
                 },
@@ -1956,225 +13778,342 @@ const docTemplate = `{
                     "type": "boolean",
                     "example": true
                 },
-                "job_group": {
-                    "$ref": "#/definitions/model.JobGroup"
-                },
-                "job_group_id": {
-                    "description": "Meslek grubu",
-                    "type": "integer",
-                    "example": 1
-                },
-                "job_title": {
-                    "$ref": "#/definitions/model.JobTitle"
+                "job_group_name": {
+                    "description": "Meslek grubu adı",
+                    "type": "string",
+                    "example": "Doktor"
                 },
-                "job_title_id": {
-                    "description": "Unvan",
-                    "type": "integer",
-                    "example": 1
+                "job_title_name": {
+                    "description": "Unvan adı",
+                    "type": "string",
+                    "example": "Uzman Doktor"
                 },
                 "last_name": {
                     "description": "Soyad",
                     "type": "string",
                     "example": "Özkan"
                 },
+                "leave_type_name": {
+                    "description": "Bugün onaylı izindeyse izin türü",
+                    "type": "string",
+                    "example": "Yıllık İzin"
+                },
                 "phone": {
                     "description": "Telefon",
                     "type": "string",
                     "example": "05559876543"
                 },
-                "polyclinic": {
-                    "$ref": "#/definitions/model.HospitalPolyclinic"
-                },
-                "polyclinic_id": {
-                    "description": "Bağlı olduğu poliklinik (nullable - güvenlik gibi genel personel için)",
-                    "type": "integer",
-                    "example": 1
+                "polyclinic_type_name": {
+                    "description": "Poliklinik adı (nullable)",
+                    "type": "string",
+                    "example": "Kardiyoloji"
                 },
                 "tc": {
                     "description": "TC Kimlik No",
                     "type": "string",
                     "example": "98765432101"
                 },
-                "work_days": {
-                    "description": "Çalışma günleri (JSON array: 1=Pazartesi, 7=Pazar)",
+                "work_days_text": {
+                    "description": "Bugün geçerli çalışma programının metni",
                     "type": "string",
-                    "example": "[1,2,3,4,5]"
+                    "example": "Pazartesi-Cuma 08:00-17:00"
                 }
             }
         },
-        "model.StaffCountByGroup": {
-            "description": "Meslek grubuna göre personel sayısı",
+        "model.SwitchHospitalRequest": {
+            "description": "Aktif hastaneyi değiştirme isteği",
+            "type": "object",
+            "required": [
+                "hospital_id"
+            ],
+            "properties": {
+                "hospital_id": {
+                    "description": "Üye olunan hastane",
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "model.TokenResponse": {
+            "description": "Access + refresh token çifti",
             "type": "object",
             "properties": {
-                "count": {
+                "expires_in": {
+                    "description": "Access token ömrü (saniye)",
                     "type": "integer",
-                    "example": 7
+                    "example": 900
                 },
-                "job_group_name": {
+                "hospital_id": {
+                    "description": "Token'ın geçerli olduğu aktif hastane",
+                    "type": "integer",
+                    "example": 1
+                },
+                "memberships": {
+                    "description": "Kullanıcının giriş yapabildiği hastaneler (/auth/switch-hospital ile geçilir)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.MembershipResponse"
+                    }
+                },
+                "mfa_enrollment_required": {
+                    "description": "Hastane MFA zorunlu kılmış ama kullanıcı henüz kayıt olmamış",
+                    "type": "boolean",
+                    "example": false
+                },
+                "refresh_token": {
+                    "description": "Tek kullanımlık refresh token",
                     "type": "string",
-                    "example": "Doktor"
+                    "example": "q1w2e3r4t5y6..."
+                },
+                "token": {
+                    "description": "Access token (geriye uyumluluk için \"token\" adıyla)",
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "token_type": {
+                    "description": "Token tipi",
+                    "type": "string",
+                    "example": "Bearer"
                 }
             }
         },
-        "model.StaffListRequest": {
-            "description": "Personel listeleme ve filtreleme verisi",
+        "model.UpdateHospitalApplicationRequest": {
+            "description": "Başvuru bilgilerini güncelleme (onay beklerken veya red sonrası)",
             "type": "object",
+            "required": [
+                "address_detail",
+                "district_id",
+                "hospital_email",
+                "hospital_name",
+                "hospital_phone",
+                "province_id",
+                "tax_id"
+            ],
             "properties": {
-                "first_name": {
-                    "description": "Filtering (Optional)",
+                "address_detail": {
                     "type": "string",
-                    "example": "Mehmet"
-                },
-                "is_active": {
-                    "description": "Aktiflik durumu ile filtreleme",
-                    "type": "boolean",
-                    "example": true
+                    "example": "Beşiktaş Caddesi No:123"
                 },
-                "job_group_id": {
-                    "description": "Meslek grubu ile filtreleme",
+                "district_id": {
                     "type": "integer",
                     "example": 1
                 },
-                "job_title_id": {
-                    "description": "Unvan ile filtreleme",
-                    "type": "integer",
-                    "example": 2
+                "hospital_email": {
+                    "type": "string",
+                    "example": "info@acibadem.com"
                 },
-                "last_name": {
-                    "description": "Soyad ile filtreleme",
+                "hospital_name": {
                     "type": "string",
-                    "example": "Özkan"
+                    "example": "Acıbadem Hastanesi"
                 },
-                "page": {
-                    "description": "Pagination",
+                "hospital_phone": {
+                    "type": "string",
+                    "example": "02121234567"
+                },
+                "province_id": {
                     "type": "integer",
-                    "minimum": 1,
                     "example": 1
                 },
-                "page_size": {
-                    "description": "Sayfa başına kayıt (1-100 arası)",
-                    "type": "integer",
-                    "maximum": 100,
-                    "minimum": 1,
-                    "example": 10
+                "tax_id": {
+                    "type": "string",
+                    "example": "1234567890"
+                }
+            }
+        },
+        "model.UpdateMFAPolicyRequest": {
+            "description": "Hastane MFA politikası güncelleme isteği",
+            "type": "object",
+            "properties": {
+                "require_mfa": {
+                    "description": "true: yetkili kullanıcılar için MFA zorunlu",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "model.UpdateMemberRequest": {
+            "description": "Üyelik güncelleme - rol, poliklinik ve durum",
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
                 },
                 "polyclinic_id": {
-                    "description": "Poliklinik ile filtreleme",
                     "type": "integer",
-                    "example": 1
+                    "example": 3
                 },
-                "tc": {
-                    "description": "TC ile filtreleme",
+                "role": {
                     "type": "string",
-                    "example": "98765432101"
+                    "example": "çalışan"
                 }
             }
         },
-        "model.StaffListResponse": {
-            "description": "Sayfalandırılmış personel listesi yanıtı",
+        "model.UpdatePasswordPolicyRequest": {
+            "description": "Şifre politikası güncelleme verisi",
             "type": "object",
             "properties": {
-                "data": {
-                    "description": "Personel listesi",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.StaffSummary"
-                    }
+                "check_deny_list": {
+                    "description": "Yaygın şifre listesi kontrolü",
+                    "type": "boolean",
+                    "example": true
                 },
-                "pagination": {
-                    "description": "Sayfalama bilgileri",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/model.PaginationInfo"
-                        }
-                    ]
+                "history_count": {
+                    "description": "Son N şifre tekrar kullanılamaz (0-24)",
+                    "type": "integer",
+                    "example": 5
+                },
+                "max_age_days": {
+                    "description": "Şifre geçerlilik süresi (gün, 0: süresiz)",
+                    "type": "integer",
+                    "example": 90
+                },
+                "min_length": {
+                    "description": "Minimum uzunluk (8-128)",
+                    "type": "integer",
+                    "example": 10
+                },
+                "require_digit": {
+                    "description": "En az bir rakam",
+                    "type": "boolean",
+                    "example": true
+                },
+                "require_lower": {
+                    "description": "En az bir küçük harf",
+                    "type": "boolean",
+                    "example": true
+                },
+                "require_symbol": {
+                    "description": "En az bir özel karakter",
+                    "type": "boolean",
+                    "example": true
+                },
+                "require_upper": {
+                    "description": "En az bir büyük harf",
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "model.StaffSummary": {
-            "description": "Personel özet bilgileri",
+        "model.UpdatePolyclinicRequest": {
+            "description": "Hastane poliklinik güncelleme verisi",
             "type": "object",
+            "required": [
+                "floor",
+                "room_number"
+            ],
             "properties": {
-                "first_name": {
-                    "description": "Ad",
-                    "type": "string",
-                    "example": "Dr. Mehmet"
-                },
-                "id": {
-                    "description": "Personel ID",
+                "floor": {
+                    "description": "Kat numarası",
                     "type": "integer",
-                    "example": 1
+                    "example": 3
                 },
                 "is_active": {
                     "description": "Aktif mi?",
                     "type": "boolean",
                     "example": true
                 },
-                "job_group_name": {
-                    "description": "Meslek grubu adı",
+                "room_number": {
+                    "description": "Oda numarası",
+                    "type": "integer",
+                    "example": 301
+                }
+            }
+        },
+        "model.UpdateProfileRequest": {
+            "description": "Profil güncelleme - e-posta veya telefon değişiyorsa yeni adrese gönderilen kod gerekir",
+            "type": "object",
+            "required": [
+                "email",
+                "first_name",
+                "last_name",
+                "phone"
+            ],
+            "properties": {
+                "email": {
                     "type": "string",
-                    "example": "Doktor"
+                    "example": "ahmet.yilmaz@example.com"
                 },
-                "job_title_name": {
-                    "description": "Unvan adı",
+                "email_code": {
+                    "description": "E-posta değişiyorsa /me/contact/verify ile yeni adrese gönderilen kod",
                     "type": "string",
-                    "example": "Uzman Doktor"
+                    "example": "482913"
                 },
-                "last_name": {
-                    "description": "Soyad",
+                "first_name": {
                     "type": "string",
-                    "example": "Özkan"
+                    "example": "Ahmet"
                 },
-                "phone": {
-                    "description": "Telefon",
+                "lang": {
+                    "description": "Eski adrese gidecek değişiklik bildiriminin dili",
                     "type": "string",
-                    "example": "05559876543"
+                    "example": "tr"
                 },
-                "polyclinic_type_name": {
-                    "description": "Poliklinik adı (nullable)",
+                "last_name": {
                     "type": "string",
-                    "example": "Kardiyoloji"
+                    "example": "Yılmaz"
                 },
-                "tc": {
-                    "description": "TC Kimlik No",
+                "phone": {
                     "type": "string",
-                    "example": "98765432101"
+                    "example": "05551234567"
                 },
-                "work_days_text": {
-                    "description": "Çalışma günleri metni",
+                "phone_code": {
+                    "description": "Telefon değişiyorsa yeni numaraya gönderilen kod",
                     "type": "string",
-                    "example": "Pazartesi-Cuma"
+                    "example": "205718"
                 }
             }
         },
-        "model.UpdatePolyclinicRequest": {
-            "description": "Hastane poliklinik güncelleme verisi",
+        "model.UpdateStaffRequest": {
+            "description": "Personel güncelleme verisi",
             "type": "object",
             "required": [
-                "floor",
-                "room_number"
+                "first_name",
+                "job_group_id",
+                "job_title_id",
+                "last_name",
+                "phone"
             ],
             "properties": {
-                "floor": {
-                    "description": "Kat numarası",
-                    "type": "integer",
-                    "example": 3
+                "first_name": {
+                    "description": "Ad",
+                    "type": "string",
+                    "example": "Dr. Ahmet"
                 },
                 "is_active": {
                     "description": "Aktif mi?",
                     "type": "boolean",
                     "example": true
                 },
-                "room_number": {
-                    "description": "Oda numarası",
+                "job_group_id": {
+                    "description": "Meslek grubu ID",
                     "type": "integer",
-                    "example": 301
+                    "example": 2
+                },
+                "job_title_id": {
+                    "description": "Unvan ID",
+                    "type": "integer",
+                    "example": 3
+                },
+                "last_name": {
+                    "description": "Soyad",
+                    "type": "string",
+                    "example": "Yılmaz"
+                },
+                "phone": {
+                    "description": "Telefon",
+                    "type": "string",
+                    "example": "05551234567"
+                },
+                "polyclinic_id": {
+                    "description": "Poliklinik ID (nullable)",
+                    "type": "integer",
+                    "example": 2
                 }
             }
         },
-        "model.UpdateStaffRequest": {
-            "type": "object"
-        },
         "model.UpdateSubUserRequest": {
             "description": "Alt kullanıcı güncelleme verisi",
             "type": "object",
@@ -2196,11 +14135,6 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Ahmet"
                 },
-                "is_active": {
-                    "description": "Aktif mi?",
-                    "type": "boolean",
-                    "example": true
-                },
                 "last_name": {
                     "description": "Soyad",
                     "type": "string",
@@ -2211,13 +14145,14 @@ const docTemplate = `{
                     "type": "string",
                     "example": "05559876543"
                 },
+                "polyclinic_id": {
+                    "description": "Bağlı olduğu poliklinik (opsiyonel)",
+                    "type": "integer",
+                    "example": 3
+                },
                 "role": {
-                    "description": "Rol",
+                    "description": "Rol adı (hastanede tanımlı)",
                     "type": "string",
-                    "enum": [
-                        "yetkili",
-                        "çalışan"
-                    ],
                     "example": "yetkili"
                 }
             }
@@ -2272,16 +14207,16 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 1
                 },
-                "is_active": {
-                    "description": "Aktif mi?",
-                    "type": "boolean",
-                    "example": true
-                },
                 "last_name": {
                     "description": "Soyad",
                     "type": "string",
                     "example": "Yılmaz"
                 },
+                "mfa_enabled": {
+                    "description": "TOTP iki adımlı doğrulama aktif mi?",
+                    "type": "boolean",
+                    "example": false
+                },
                 "password": {
                     "description": "Şifre (JSON'dan okuyabilir ama response'da göstermez)",
                     "type": "string",
@@ -2293,26 +14228,187 @@ const docTemplate = `{
                     "type": "string",
                     "example": "05551234567"
                 },
+                "polyclinic_id": {
+                    "description": "Bağlı olduğu poliklinik (polyclinics:write:own için)",
+                    "type": "integer",
+                    "example": 3
+                },
                 "role": {
-                    "description": "Rol: \"yetkili\" veya \"çalışan\"",
+                    "description": "Rol adı (hastanede tanımlı rollerden biri)",
                     "type": "string",
-                    "enum": [
-                        "yetkili",
-                        "çalışan"
-                    ],
                     "example": "çalışan"
                 },
+                "status": {
+                    "description": "active, suspended, locked, pending",
+                    "type": "string",
+                    "example": "active"
+                },
+                "status_changed_at": {
+                    "description": "Son durum değişikliği zamanı",
+                    "type": "string"
+                },
+                "status_changed_by": {
+                    "description": "Değişikliği yapan hastane kullanıcısı (platform operatörü ise boş)",
+                    "type": "integer",
+                    "example": 1
+                },
+                "status_reason": {
+                    "description": "Son durum değişikliğinin gerekçesi",
+                    "type": "string",
+                    "example": "İşten ayrıldı"
+                },
                 "tc": {
                     "description": "Türkiye Cumhuriyeti Kimlik Numarası",
                     "type": "string",
                     "example": "12345678901"
                 }
             }
+        },
+        "model.UserStatusChange": {
+            "description": "Kullanıcı durum değişikliği denetim kaydı",
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "description": "Hastane kullanıcısı veya platform operatörü ID",
+                    "type": "integer",
+                    "example": 1
+                },
+                "actor_type": {
+                    "description": "user veya platform",
+                    "type": "string",
+                    "example": "user"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "from_status": {
+                    "type": "string",
+                    "example": "active"
+                },
+                "hospital_id": {
+                    "description": "Kullanıcının birincil hastanesi",
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "ip": {
+                    "type": "string",
+                    "example": "10.0.0.5"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "İşten ayrıldı"
+                },
+                "to_status": {
+                    "type": "string",
+                    "example": "suspended"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "model.UserStatusResponse": {
+            "description": "Kullanıcının güncel durumu",
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "suspended"
+                },
+                "status_changed_at": {
+                    "type": "string"
+                },
+                "status_changed_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "status_reason": {
+                    "type": "string",
+                    "example": "İşten ayrıldı"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "model.ValidationError": {
+            "description": "Validation hatası detayları",
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "tax_id"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Bu vergi kimlik numarası zaten kullanılıyor"
+                }
+            }
+        },
+        "utils.JWK": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string"
+                },
+                "crv": {
+                    "description": "OKP/EC eğrisi (Ed25519, P-256)",
+                    "type": "string"
+                },
+                "e": {
+                    "description": "RSA exponent",
+                    "type": "string"
+                },
+                "kid": {
+                    "type": "string"
+                },
+                "kty": {
+                    "type": "string"
+                },
+                "n": {
+                    "description": "RSA modulus",
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string"
+                },
+                "x": {
+                    "description": "OKP public key / EC x koordinatı",
+                    "type": "string"
+                },
+                "y": {
+                    "description": "EC y koordinatı (harici IdP anahtarları için)",
+                    "type": "string"
+                }
+            }
+        },
+        "utils.JWKSet": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/utils.JWK"
+                    }
+                }
+            }
         }
     },
     "securityDefinitions": {
+        "ApiKeyAuth": {
+            "description": "Entegrasyonlar için hastane API anahtarı (hpk_...). Sadece anahtara verilen yetkilerin endpoint'lerinde geçerlidir",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
         "BearerAuth": {
-            "description": "JWT token için \"Bearer \" prefix'i ile birlikte token'ı girin. Örnek: Bearer eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9...",
+            "description": "JWT token için \"Bearer \" prefix'i ile birlikte token'ı girin. Örnek: Bearer eyJhbGciOiJSUzI1NiIsImtpZCI6Ii4uLiJ9...",
             "type": "apiKey",
             "name": "Authorization",
             "in": "header"
@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"hospital-platform/model"
+	"hospital-platform/service"
+	"hospital-platform/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// APIKeyHandler entegrasyon API anahtarları HTTP isteklerini yönetir
+type APIKeyHandler struct {
+	apiKeyService *service.APIKeyService
+}
+
+// NewAPIKeyHandler yeni bir API anahtarı handler'ı oluşturur
+func NewAPIKeyHandler() *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: service.NewAPIKeyService(),
+	}
+}
+
+// GetScopes API anahtarlarına verilebilecek yetkileri listeler
+// @Summary API anahtarı yetkileri
+// @Description Anahtarlara verilebilecek yetkiler. Kullanıcı/rol yönetimi ve hastane ayarları yetkileri anahtarlara verilemez
+// @Tags API Keys
+// @Produce json
+// @Success 200 {array} model.PermissionInfo
+// @Failure 401 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/api-keys/scopes [get]
+func (h *APIKeyHandler) GetScopes(c echo.Context) error {
+	return c.JSON(http.StatusOK, echo.Map{
+		"data": h.apiKeyService.GetScopes(),
+	})
+}
+
+// CreateAPIKey yeni API anahtarı oluşturur
+// @Summary API anahtarı oluştur
+// @Description HBYS/İK gibi entegrasyonlar için isimli, yetki kapsamlı, süreli ve isteğe bağlı IP kısıtlı anahtar üretir. Anahtar sadece bu yanıtta gösterilir, veritabanında hash'i tutulur
+// @Tags API Keys
+// @Accept json
+// @Produce json
+// @Param body body model.CreateAPIKeyRequest true "Anahtar ayarları"
+// @Success 201 {object} model.CreateAPIKeyResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(c echo.Context) error {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	var req model.CreateAPIKeyRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Geçersiz istek formatı",
+			"details": err.Error(),
+		})
+	}
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Veri doğrulama hatası",
+			"details": err.Error(),
+		})
+	}
+
+	apiKey, validationErrors, err := h.apiKeyService.CreateAPIKey(&req, hospitalID, userID)
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Veri doğrulama hataları",
+			"validation_errors": validationErrors,
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, echo.Map{
+		"message": "API anahtarı oluşturuldu. Anahtar tekrar gösterilmeyecek, güvenli bir şekilde saklayın",
+		"data":    apiKey,
+	})
+}
+
+// ListAPIKeys hastanenin API anahtarlarını listeler
+// @Summary API anahtarları
+// @Description Hastanenin anahtarlarını durum, yetki ve son kullanım bilgisiyle listeler. Anahtarların kendisi gösterilmez
+// @Tags API Keys
+// @Produce json
+// @Success 200 {array} model.APIKeyResponse
+// @Failure 401 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/api-keys [get]
+func (h *APIKeyHandler) ListAPIKeys(c echo.Context) error {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	apiKeys, err := h.apiKeyService.ListAPIKeys(hospitalID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data": apiKeys,
+	})
+}
+
+// RevokeAPIKey API anahtarını iptal eder
+// @Summary API anahtarını iptal et
+// @Description Anahtarla yapılan sonraki istekler hemen reddedilir
+// @Tags API Keys
+// @Produce json
+// @Param id path int true "Anahtar ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c echo.Context) error {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+	}
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Geçersiz anahtar ID"})
+	}
+
+	if err := h.apiKeyService.RevokeAPIKey(hospitalID, uint(id), userID); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "API anahtarı iptal edildi",
+	})
+}
+
+// handleError servis hatalarını uygun HTTP durum kodlarına çevirir
+func (h *APIKeyHandler) handleError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrAPIKeyNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrPermissionEscalation):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+	default:
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+}
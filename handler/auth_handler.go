@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"strconv"
 
@@ -12,66 +14,195 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
-// Register godoc
-// @Summary Yeni kullanıcı kaydı
-// @Description Yeni kullanıcıyı kaydeder
+// Login godoc
+// @Summary Kullanıcı girişi
+// @Description Email veya telefon numarası ve şifre ile kullanıcı girişi yapılır. MFA aktif kullanıcılar için token yerine mfa_token döner; /login/mfa ile ikinci adım tamamlanır. Şifresinin süresi dolmuş kullanıcılar için password_change_token döner; /login/password-change ile yeni şifre belirlenir
 // @Tags Auth
 // @Accept  json
 // @Produce  json
-// @Param user body model.User true "Kullanıcı bilgileri"
-// @Success 200 {object} map[string]interface{}
-// @Failure 400 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
-// @Router /register [post]
-func Register(c echo.Context) error {
-	var user model.User
+// @Param credentials body model.LoginRequest true "Giriş bilgileri"
+// @Success 200 {object} model.TokenResponse
+// @Failure 401 {object} map[string]interface{}
+// @Failure 429 {object} map[string]interface{}
+// @Failure 503 {object} map[string]interface{}
+// @Router /login [post]
+func Login(c echo.Context) error {
+	var credentials model.LoginRequest
+	if err := c.Bind(&credentials); err != nil {
+		fmt.Println("Bind hatası:", err)
+		return c.JSON(http.StatusBadRequest, echo.Map{"message": "Geçersiz veri"})
+	}
+
+	tokens, challenge, err := service.Login(credentials.EmailOrPhone, credentials.Password, utils.GetClientInfo(c))
+	if err != nil {
+		fmt.Println("Login hatası:", err)
+
+		var blocked *service.LoginBlockedError
+		if errors.As(err, &blocked) {
+			c.Response().Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(blocked.RetryAfter.Seconds()))))
+			return c.JSON(http.StatusTooManyRequests, echo.Map{"message": blocked.Error()})
+		}
+		if errors.Is(err, service.ErrInvalidCredentials) {
+			return c.JSON(http.StatusUnauthorized, echo.Map{"message": err.Error()})
+		}
+		if errors.Is(err, service.ErrHospitalSuspended) || errors.Is(err, service.ErrUserInactive) {
+			return c.JSON(http.StatusForbidden, echo.Map{"message": err.Error()})
+		}
+		if errors.Is(err, utils.ErrHasherBusy) {
+			c.Response().Header().Set("Retry-After", "1")
+			return c.JSON(http.StatusServiceUnavailable, echo.Map{"message": "Sunucu yoğun, lütfen tekrar deneyin"})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Giriş yapılamadı"})
+	}
+
+	// MFA veya şifre değişikliği bekleniyor
+	if challenge != nil {
+		return c.JSON(http.StatusOK, challenge)
+	}
 
-	if err := c.Bind(&user); err != nil {
+	return c.JSON(http.StatusOK, tokens)
+}
+
+// LoginPasswordChange godoc
+// @Summary Süresi dolan şifreyi değiştir (login adımı)
+// @Description Login'de dönen password_change_token ile hastanenin şifre politikasına uygun yeni şifre belirlenir. Diğer tüm oturumlar sonlandırılır; MFA aktifse mfa_token, değilse token çifti döner
+// @Tags Auth
+// @Accept  json
+// @Produce  json
+// @Param request body model.PasswordChangeRequest true "Şifre değiştirme token'ı ve yeni şifre"
+// @Success 200 {object} model.TokenResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Failure 503 {object} map[string]interface{}
+// @Router /login/password-change [post]
+func LoginPasswordChange(c echo.Context) error {
+	var req model.PasswordChangeRequest
+	if err := c.Bind(&req); err != nil || req.ChangeToken == "" || req.NewPassword == "" {
 		return c.JSON(http.StatusBadRequest, echo.Map{"message": "Geçersiz veri"})
 	}
 
-	if err := service.RegisterUser(&user); err != nil {
-		return c.JSON(http.StatusInternalServerError, echo.Map{
-			"message": "Kayıt başarısız",
-			"error":   err.Error(),
+	tokens, challenge, validationErrors, err := service.ChangeExpiredPassword(&req, utils.GetClientInfo(c))
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"message":           "Şifre politikaya uymuyor",
+			"validation_errors": validationErrors,
 		})
 	}
+	if err != nil {
+		fmt.Println("Şifre değiştirme hatası:", err)
+
+		if errors.Is(err, service.ErrInvalidPasswordChangeToken) {
+			return c.JSON(http.StatusUnauthorized, echo.Map{"message": err.Error()})
+		}
+		if errors.Is(err, service.ErrHospitalSuspended) || errors.Is(err, service.ErrUserInactive) {
+			return c.JSON(http.StatusForbidden, echo.Map{"message": err.Error()})
+		}
+		if errors.Is(err, utils.ErrHasherBusy) {
+			c.Response().Header().Set("Retry-After", "1")
+			return c.JSON(http.StatusServiceUnavailable, echo.Map{"message": "Sunucu yoğun, lütfen tekrar deneyin"})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Şifre değiştirilemedi"})
+	}
 
-	return c.JSON(http.StatusOK, echo.Map{"message": "Kayıt başarılı"})
+	if challenge != nil {
+		return c.JSON(http.StatusOK, challenge)
+	}
+
+	return c.JSON(http.StatusOK, tokens)
 }
 
-// Login godoc
-// @Summary Kullanıcı girişi
-// @Description Email veya telefon numarası ve şifre ile kullanıcı girişi yapılır
+// RefreshToken godoc
+// @Summary Token yenile
+// @Description Refresh token ile yeni access + refresh token çifti alınır. Her refresh token tek kullanımlıktır; yeniden kullanılırsa tüm oturum iptal edilir
 // @Tags Auth
 // @Accept  json
 // @Produce  json
-// @Param credentials body model.LoginRequest true "Giriş bilgileri"
-// @Success 200 {object} map[string]interface{}
+// @Param request body model.RefreshTokenRequest true "Refresh token"
+// @Success 200 {object} model.TokenResponse
+// @Failure 400 {object} map[string]interface{}
 // @Failure 401 {object} map[string]interface{}
-// @Router /login [post]
-func Login(c echo.Context) error {
-	var credentials model.LoginRequest
-	if err := c.Bind(&credentials); err != nil {
-		fmt.Println("Bind hatası:", err)
+// @Router /token/refresh [post]
+func RefreshToken(c echo.Context) error {
+	var req model.RefreshTokenRequest
+	if err := c.Bind(&req); err != nil || req.RefreshToken == "" {
 		return c.JSON(http.StatusBadRequest, echo.Map{"message": "Geçersiz veri"})
 	}
-	fmt.Printf("Gelen credentials: %+v\n", credentials)
 
-	token, err := service.Login(credentials.EmailOrPhone, credentials.Password)
+	tokens, err := service.NewTokenService().Refresh(req.RefreshToken, utils.GetClientInfo(c))
 	if err != nil {
-		fmt.Println("Login hatası:", err)
-		return c.JSON(http.StatusUnauthorized, echo.Map{"message": err.Error()})
+		fmt.Println("Token yenileme hatası:", err)
+		if errors.Is(err, service.ErrInvalidRefreshToken) || errors.Is(err, service.ErrRefreshTokenReused) {
+			return c.JSON(http.StatusUnauthorized, echo.Map{"message": err.Error()})
+		}
+		if errors.Is(err, service.ErrHospitalSuspended) || errors.Is(err, service.ErrUserInactive) {
+			return c.JSON(http.StatusForbidden, echo.Map{"message": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Token yenilenemedi"})
+	}
+
+	return c.JSON(http.StatusOK, tokens)
+}
+
+// JWKS godoc
+// @Summary Token doğrulama anahtarları (JWKS)
+// @Description Access token'ları doğrulamak için kullanılan public key'ler. Token header'ındaki kid ile eşleşen anahtar kullanılır
+// @Tags Auth
+// @Produce  json
+// @Success 200 {object} utils.JWKSet
+// @Router /.well-known/jwks.json [get]
+func JWKS(c echo.Context) error {
+	// Rotasyonda yeni anahtarın kısa sürede görülebilmesi için kısa cache
+	c.Response().Header().Set("Cache-Control", "public, max-age=300")
+	return c.JSON(http.StatusOK, utils.GetJWKS())
+}
+
+// Logout godoc
+// @Summary Çıkış yap
+// @Description Mevcut oturumu sonlandırır; access ve refresh token'lar anında geçersiz olur. all_sessions=true ile tüm cihazlardan çıkış yapılır
+// @Tags Auth
+// @Accept  json
+// @Produce  json
+// @Param request body model.LogoutRequest false "Çıkış seçenekleri"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /logout [post]
+func Logout(c echo.Context) error {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	var req model.LogoutRequest
+	_ = c.Bind(&req) // Body opsiyonel
+
+	familyID, hasFamily := utils.GetTokenFamilyFromContext(c)
+	if !hasFamily && !req.AllSessions {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Bu token için oturum bilgisi bulunamadı",
+		})
+	}
+
+	if err := service.Logout(userID, familyID, req.AllSessions); err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"error": err.Error(),
+		})
 	}
 
-	return c.JSON(http.StatusOK, echo.Map{"token": token})
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Çıkış başarılı",
+	})
 }
 
 // ==================== ALT KULLANICI YÖNETİMİ ====================
 
 // CreateSubUser godoc
 // @Summary Alt kullanıcı ekle
-// @Description Yetkili kullanıcı tarafından alt kullanıcı eklenir
+// @Description users:manage yetkisine sahip kullanıcı tarafından alt kullanıcı eklenir. Yöneticinin belirlediği şifre ilk girişte değiştirilmelidir; şifreyi kullanıcının kendisinin belirlemesi için /hospital/invitations tercih edilmelidir
 // @Tags User Management
 // @Accept json
 // @Produce json
@@ -90,18 +221,10 @@ func CreateSubUser(c echo.Context) error {
 			"error": "Geçersiz token",
 		})
 	}
-
-	role, ok := utils.GetRoleFromContext(c)
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
 	if !ok {
 		return c.JSON(http.StatusUnauthorized, echo.Map{
-			"error": "Rol bilgisi bulunamadı",
-		})
-	}
-
-	// Sadece yetkili kullanıcılar alt kullanıcı ekleyebilir
-	if role != "yetkili" {
-		return c.JSON(http.StatusForbidden, echo.Map{
-			"error": "Bu işlem için yetkili rolü gerekli",
+			"error": "Geçersiz token",
 		})
 	}
 
@@ -120,7 +243,7 @@ func CreateSubUser(c echo.Context) error {
 		})
 	}
 
-	user, validationErrors, err := service.CreateSubUser(&req, userID)
+	user, validationErrors, err := service.CreateSubUser(&req, hospitalID, userID)
 
 	// Validation hataları
 	if len(validationErrors) > 0 {
@@ -131,6 +254,11 @@ func CreateSubUser(c echo.Context) error {
 	}
 
 	// Service hataları
+	if errors.Is(err, service.ErrPermissionEscalation) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"error": err.Error(),
+		})
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, echo.Map{
 			"error": err.Error(),
@@ -174,7 +302,7 @@ func GetSubUsers(c echo.Context) error {
 
 // UpdateSubUser godoc
 // @Summary Alt kullanıcı güncelle
-// @Description Yetkili kullanıcı tarafından alt kullanıcı güncellenir
+// @Description users:manage yetkisine sahip kullanıcı tarafından alt kullanıcı güncellenir
 // @Tags User Management
 // @Accept json
 // @Produce json
@@ -194,18 +322,10 @@ func UpdateSubUser(c echo.Context) error {
 			"error": "Geçersiz token",
 		})
 	}
-
-	role, ok := utils.GetRoleFromContext(c)
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
 	if !ok {
 		return c.JSON(http.StatusUnauthorized, echo.Map{
-			"error": "Rol bilgisi bulunamadı",
-		})
-	}
-
-	// Sadece yetkili kullanıcılar alt kullanıcı güncelleyebilir
-	if role != "yetkili" {
-		return c.JSON(http.StatusForbidden, echo.Map{
-			"error": "Bu işlem için yetkili rolü gerekli",
+			"error": "Geçersiz token",
 		})
 	}
 
@@ -226,7 +346,7 @@ func UpdateSubUser(c echo.Context) error {
 		})
 	}
 
-	user, validationErrors, err := service.UpdateSubUser(uint(id), &req, userID)
+	user, validationErrors, err := service.UpdateSubUser(uint(id), &req, hospitalID, userID)
 
 	// Validation hataları
 	if len(validationErrors) > 0 {
@@ -237,6 +357,11 @@ func UpdateSubUser(c echo.Context) error {
 	}
 
 	// Service hataları
+	if errors.Is(err, service.ErrPermissionEscalation) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"error": err.Error(),
+		})
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, echo.Map{
 			"error": err.Error(),
@@ -251,7 +376,7 @@ func UpdateSubUser(c echo.Context) error {
 
 // DeleteSubUser godoc
 // @Summary Alt kullanıcı sil
-// @Description Yetkili kullanıcı tarafından alt kullanıcı silinir
+// @Description users:manage yetkisine sahip kullanıcı tarafından alt kullanıcı silinir
 // @Tags User Management
 // @Produce json
 // @Param id path int true "Kullanıcı ID"
@@ -268,18 +393,62 @@ func DeleteSubUser(c echo.Context) error {
 			"error": "Geçersiz token",
 		})
 	}
-
-	role, ok := utils.GetRoleFromContext(c)
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
 	if !ok {
 		return c.JSON(http.StatusUnauthorized, echo.Map{
-			"error": "Rol bilgisi bulunamadı",
+			"error": "Geçersiz token",
 		})
 	}
 
-	// Sadece yetkili kullanıcılar alt kullanıcı silebilir
-	if role != "yetkili" {
+	// Path parametresi
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Geçersiz kullanıcı ID",
+		})
+	}
+
+	err = service.DeleteSubUser(uint(id), hospitalID, userID)
+	if errors.Is(err, service.ErrPermissionEscalation) {
 		return c.JSON(http.StatusForbidden, echo.Map{
-			"error": "Bu işlem için yetkili rolü gerekli",
+			"error": err.Error(),
+		})
+	}
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Alt kullanıcı başarıyla silindi",
+	})
+}
+
+// UnlockSubUser godoc
+// @Summary Kullanıcı kilidini aç
+// @Description Çok fazla başarısız deneme nedeniyle kilitlenen kullanıcının giriş ve şifre sıfırlama kilitlerini kaldırır
+// @Tags User Management
+// @Produce json
+// @Param id path int true "Kullanıcı ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/users/{id}/unlock [post]
+func UnlockSubUser(c echo.Context) error {
+	// JWT token'dan kullanıcı bilgilerini al
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
 		})
 	}
 
@@ -292,14 +461,65 @@ func DeleteSubUser(c echo.Context) error {
 		})
 	}
 
-	err = service.DeleteSubUser(uint(id), userID)
+	if err := service.UnlockSubUser(uint(id), hospitalID, userID); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Kullanıcı kilidi kaldırıldı",
+	})
+}
+
+// ExpireSubUserPassword godoc
+// @Summary Şifre değişikliğini zorunlu kıl
+// @Description Kullanıcının bir sonraki girişte şifresini değiştirmesini zorunlu kılar
+// @Tags User Management
+// @Produce json
+// @Param id path int true "Kullanıcı ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/users/{id}/expire-password [post]
+func ExpireSubUserPassword(c echo.Context) error {
+	// JWT token'dan kullanıcı bilgilerini al
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	// Path parametresi
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Geçersiz kullanıcı ID",
+		})
+	}
+
+	if err := service.ExpireSubUserPassword(uint(id), hospitalID, userID); err != nil {
+		if errors.Is(err, service.ErrPermissionEscalation) {
+			return c.JSON(http.StatusForbidden, echo.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.JSON(http.StatusBadRequest, echo.Map{
 			"error": err.Error(),
 		})
 	}
 
 	return c.JSON(http.StatusOK, echo.Map{
-		"message": "Alt kullanıcı başarıyla silindi",
+		"message": "Kullanıcı bir sonraki girişte şifresini değiştirmek zorunda",
 	})
 }
@@ -0,0 +1,377 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"hospital-platform/model"
+	"hospital-platform/service"
+	"hospital-platform/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// DutyRosterHandler nöbet listesi üretme, düzenleme ve yayınlama HTTP isteklerini yönetir
+type DutyRosterHandler struct {
+	rosterService *service.DutyRosterService
+}
+
+// NewDutyRosterHandler yeni bir nöbet listesi handler'ı oluşturur
+func NewDutyRosterHandler() *DutyRosterHandler {
+	return &DutyRosterHandler{
+		rosterService: service.NewDutyRosterService(),
+	}
+}
+
+// ListDutyRosters hastanenin nöbet listelerini döndürür
+// @Summary Nöbet listeleri
+// @Description Hastanenin taslak ve yayınlanmış nöbet listelerini (atamalar olmadan) en yeni dönem başta olacak şekilde listeler
+// @Tags Duty Roster
+// @Produce json
+// @Success 200 {array} model.DutyRoster
+// @Failure 401 {object} map[string]interface{}
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /hospital/duty-rosters [get]
+func (h *DutyRosterHandler) ListDutyRosters(c echo.Context) error {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	rosters, err := h.rosterService.List(hospitalID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data": rosters,
+	})
+}
+
+// GetDutyRoster nöbet listesini detaylarıyla döndürür
+// @Summary Nöbet listesi detayı
+// @Description Atamalar, personel bazında nöbet dağılımı (toplam, gece, hafta sonu, tatil, saat), kişi sayısı tamamlanamayan nöbetler ve güncel kural ihlalleri
+// @Tags Duty Roster
+// @Produce json
+// @Param id path int true "Nöbet listesi ID"
+// @Success 200 {object} model.DutyRosterResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /hospital/duty-rosters/{id} [get]
+func (h *DutyRosterHandler) GetDutyRoster(c echo.Context) error {
+	hospitalID, id, ok := h.getTarget(c)
+	if !ok {
+		return nil
+	}
+
+	response, err := h.rosterService.Get(hospitalID, id)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data": response,
+	})
+}
+
+// CreateDutyRoster nöbet listesi üretir
+// @Summary Nöbet listesi oluştur
+// @Description Poliklinik ve/veya meslek grubundaki aktif personelden taslak nöbet listesi üretir. Onaylı izindeki personele nöbet yazılmaz; çakışma, en az dinlenme ve art arda gece nöbeti kurallarına uyulur; hafta içi, hafta sonu ve tatil nöbetleri ile toplam saat eşit dağıtılmaya çalışılır. Aynı seed ve girdilerle her zaman aynı liste üretilir
+// @Tags Duty Roster
+// @Accept json
+// @Produce json
+// @Param body body model.CreateDutyRosterRequest true "Dönem, nöbetler ve kurallar"
+// @Success 201 {object} model.DutyRosterResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/duty-rosters [post]
+func (h *DutyRosterHandler) CreateDutyRoster(c echo.Context) error {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	var req model.CreateDutyRosterRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Geçersiz istek formatı",
+			"details": err.Error(),
+		})
+	}
+
+	response, validationErrors, err := h.rosterService.Create(hospitalID, h.getActor(c), &req)
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Veri doğrulama hataları",
+			"validation_errors": validationErrors,
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, echo.Map{
+		"message": "Nöbet listesi taslak olarak oluşturuldu",
+		"data":    response,
+	})
+}
+
+// PublishDutyRoster taslak nöbet listesini yayınlar
+// @Summary Nöbet listesini yayınla
+// @Description Kural ihlali olan liste yayınlanamaz (ör. liste oluşturulduktan sonra onaylanan izinler). Yayınlanan nöbetler personelin vardiya takviminde görünür ve liste artık değiştirilemez
+// @Tags Duty Roster
+// @Produce json
+// @Param id path int true "Nöbet listesi ID"
+// @Success 200 {object} model.DutyRosterResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/duty-rosters/{id}/publish [post]
+func (h *DutyRosterHandler) PublishDutyRoster(c echo.Context) error {
+	hospitalID, id, ok := h.getTarget(c)
+	if !ok {
+		return nil
+	}
+
+	response, validationErrors, err := h.rosterService.Publish(hospitalID, id, h.getActor(c))
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Nöbet listesinde kural ihlalleri var",
+			"validation_errors": validationErrors,
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Nöbet listesi yayınlandı",
+		"data":    response,
+	})
+}
+
+// DeleteDutyRoster nöbet listesini siler
+// @Summary Nöbet listesini sil
+// @Description Yayınlanmış liste silinirse nöbetleri personel takviminden de kalkar
+// @Tags Duty Roster
+// @Produce json
+// @Param id path int true "Nöbet listesi ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/duty-rosters/{id} [delete]
+func (h *DutyRosterHandler) DeleteDutyRoster(c echo.Context) error {
+	hospitalID, id, ok := h.getTarget(c)
+	if !ok {
+		return nil
+	}
+
+	if err := h.rosterService.Delete(hospitalID, id); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Nöbet listesi silindi",
+	})
+}
+
+// ==================== ELLE DÜZENLEME ====================
+
+// AddDutyAssignment taslak listede bir nöbete personel ekler
+// @Summary Nöbete personel ekle
+// @Description Boş kalan veya kişi sayısı dolmamış nöbete personel ekler. Personel listenin poliklinik/meslek grubunda olmalı ve nöbet izin, çakışma, dinlenme ve art arda gece kurallarına uymalıdır
+// @Tags Duty Roster
+// @Accept json
+// @Produce json
+// @Param id path int true "Nöbet listesi ID"
+// @Param body body model.DutyAssignmentRequest true "Slot, tarih ve personel"
+// @Success 201 {object} model.DutyRosterResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/duty-rosters/{id}/assignments [post]
+func (h *DutyRosterHandler) AddDutyAssignment(c echo.Context) error {
+	hospitalID, id, ok := h.getTarget(c)
+	if !ok {
+		return nil
+	}
+
+	var req model.DutyAssignmentRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Geçersiz istek formatı",
+			"details": err.Error(),
+		})
+	}
+
+	response, validationErrors, err := h.rosterService.AddAssignment(hospitalID, id, &req)
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Veri doğrulama hataları",
+			"validation_errors": validationErrors,
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, echo.Map{
+		"message": "Nöbet ataması eklendi",
+		"data":    response,
+	})
+}
+
+// UpdateDutyAssignment taslak listedeki nöbeti başka personele verir
+// @Summary Nöbeti başka personele ver
+// @Description Sadece staff_id kullanılır. Yeni personel listenin poliklinik/meslek grubunda olmalı ve nöbet kurallarına uymalıdır
+// @Tags Duty Roster
+// @Accept json
+// @Produce json
+// @Param id path int true "Nöbet listesi ID"
+// @Param assignment_id path int true "Atama ID"
+// @Param body body model.DutyAssignmentRequest true "Yeni personel"
+// @Success 200 {object} model.DutyRosterResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/duty-rosters/{id}/assignments/{assignment_id} [put]
+func (h *DutyRosterHandler) UpdateDutyAssignment(c echo.Context) error {
+	hospitalID, id, ok := h.getTarget(c)
+	if !ok {
+		return nil
+	}
+	assignmentID, ok := h.getAssignmentID(c)
+	if !ok {
+		return nil
+	}
+
+	var req model.DutyAssignmentRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Geçersiz istek formatı",
+			"details": err.Error(),
+		})
+	}
+
+	response, validationErrors, err := h.rosterService.UpdateAssignment(hospitalID, id, assignmentID, &req)
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Veri doğrulama hataları",
+			"validation_errors": validationErrors,
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Nöbet ataması güncellendi",
+		"data":    response,
+	})
+}
+
+// DeleteDutyAssignment taslak listeden nöbeti kaldırır
+// @Summary Nöbet atamasını kaldır
+// @Description Nöbet boş kalır ve detaydaki boş nöbetler arasında görünür
+// @Tags Duty Roster
+// @Produce json
+// @Param id path int true "Nöbet listesi ID"
+// @Param assignment_id path int true "Atama ID"
+// @Success 200 {object} model.DutyRosterResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/duty-rosters/{id}/assignments/{assignment_id} [delete]
+func (h *DutyRosterHandler) DeleteDutyAssignment(c echo.Context) error {
+	hospitalID, id, ok := h.getTarget(c)
+	if !ok {
+		return nil
+	}
+	assignmentID, ok := h.getAssignmentID(c)
+	if !ok {
+		return nil
+	}
+
+	response, err := h.rosterService.DeleteAssignment(hospitalID, id, assignmentID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Nöbet ataması kaldırıldı",
+		"data":    response,
+	})
+}
+
+// ==================== HELPER METHODS ====================
+
+// getTarget hastane ID'sini ve path'teki liste ID'sini okur; hatalıysa cevabı kendisi yazar ve false döner
+func (h *DutyRosterHandler) getTarget(c echo.Context) (uint, uint, bool) {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+		return 0, 0, false
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, echo.Map{"error": "Geçersiz nöbet listesi ID"})
+		return 0, 0, false
+	}
+	return hospitalID, uint(id), true
+}
+
+// getAssignmentID path'teki atama ID'sini okur; hatalıysa cevabı kendisi yazar ve false döner
+func (h *DutyRosterHandler) getAssignmentID(c echo.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("assignment_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, echo.Map{"error": "Geçersiz atama ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// getActor işlemi yapan kullanıcıyı döndürür (API anahtarıyla yapılan isteklerde nil)
+func (h *DutyRosterHandler) getActor(c echo.Context) *uint {
+	if userID, ok := utils.GetUserIDFromContext(c); ok {
+		return &userID
+	}
+	return nil
+}
+
+// handleError servis hatalarını HTTP durum kodlarına çevirir
+func (h *DutyRosterHandler) handleError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrDutyRosterNotFound),
+		errors.Is(err, service.ErrDutyAssignmentNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrDutyRosterPublished):
+		return c.JSON(http.StatusConflict, echo.Map{"error": err.Error()})
+	default:
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+}
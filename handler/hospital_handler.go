@@ -1,11 +1,15 @@
 package handler
 
 import (
+	"errors"
+	"io"
+	"mime"
 	"net/http"
 	"strconv"
 
 	"hospital-platform/model"
 	"hospital-platform/service"
+	"hospital-platform/utils"
 
 	"github.com/labstack/echo/v4"
 )
@@ -24,7 +28,7 @@ func NewHospitalHandler() *HospitalHandler {
 
 // RegisterHospital yeni hastane ve admin kullanıcı kaydı yapar
 // @Summary Hastane kaydı
-// @Description Yeni hastane ve admin kullanıcı kaydı yapar
+// @Description Yeni hastane başvurusu ve admin kullanıcı oluşturur. Hastane platform onayına kadar "pending" durumundadır; dönen token sadece /hospital/application endpoint'lerinde (başvuruyu görüntüleme, düzenleme, belge yükleme) geçerlidir
 // @Tags Hospital
 // @Accept json
 // @Produce json
@@ -53,7 +57,7 @@ func (h *HospitalHandler) RegisterHospital(c echo.Context) error {
 	}
 
 	// Hastane kaydını yap
-	response, validationErrors, err := h.hospitalService.RegisterHospital(&req)
+	response, validationErrors, err := h.hospitalService.RegisterHospital(&req, utils.GetClientInfo(c))
 
 	// Doğrulama hatalarını işle
 	if len(validationErrors) > 0 {
@@ -106,3 +110,268 @@ func (h *HospitalHandler) GetHospitalByID(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, hospital)
 }
+
+// ==================== KAYIT BAŞVURUSU ====================
+
+// GetApplication hastanenin kayıt başvurusunu getirir
+// @Summary Başvuru durumu
+// @Description Hastane bilgileri, başvuru durumu (pending, rejected, active...), red gerekçesi ve yüklenen belgeler. Onay beklerken de kullanılabilir
+// @Tags Hospital Application
+// @Produce json
+// @Success 200 {object} model.HospitalApplicationResponse
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/application [get]
+func (h *HospitalHandler) GetApplication(c echo.Context) error {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	application, err := h.hospitalService.GetApplication(hospitalID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, application)
+}
+
+// UpdateApplication başvurudaki hastane bilgilerini günceller
+// @Summary Başvuruyu düzenle
+// @Description Onay beklerken veya red sonrası hastane bilgileri düzeltilir. Onaylanan hastanelerde 409 döner
+// @Tags Hospital Application
+// @Accept json
+// @Produce json
+// @Param body body model.UpdateHospitalApplicationRequest true "Hastane bilgileri"
+// @Success 200 {object} model.HospitalApplicationResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/application [put]
+func (h *HospitalHandler) UpdateApplication(c echo.Context) error {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	var req model.UpdateHospitalApplicationRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Geçersiz istek formatı",
+			"details": err.Error(),
+		})
+	}
+
+	application, validationErrors, err := h.hospitalService.UpdateApplication(hospitalID, &req)
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Veri doğrulama hataları",
+			"validation_errors": validationErrors,
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, application)
+}
+
+// UploadDocument başvuruya belge yükler
+// @Summary Başvuru belgesi yükle
+// @Description Faaliyet izin belgesi (license - onay için zorunlu), vergi levhası, yetki belgesi vb. PDF, JPEG veya PNG, en fazla 10 MB
+// @Tags Hospital Application
+// @Accept multipart/form-data
+// @Produce json
+// @Param type formData string true "Belge türü (license, tax_certificate, authorization, other)"
+// @Param file formData file true "Belge dosyası"
+// @Success 201 {object} model.HospitalDocument
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/application/documents [post]
+func (h *HospitalHandler) UploadDocument(c echo.Context) error {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Dosya gerekli (multipart alan adı: file)",
+		})
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Dosya okunamadı",
+		})
+	}
+	defer file.Close()
+
+	// Sınırın bir bayt fazlası okunur - büyük dosyalar belleğe tamamen alınmadan servis tarafından reddedilir
+	data, err := io.ReadAll(io.LimitReader(file, service.MAX_DOCUMENT_SIZE+1))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Dosya okunamadı",
+		})
+	}
+
+	document, validationErrors, err := h.hospitalService.UploadDocument(hospitalID, userID, c.FormValue("type"), fileHeader.Filename, data)
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Veri doğrulama hataları",
+			"validation_errors": validationErrors,
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, echo.Map{
+		"message": "Belge yüklendi",
+		"data":    document,
+	})
+}
+
+// DownloadDocument başvuru belgesini indirir
+// @Summary Başvuru belgesini indir
+// @Tags Hospital Application
+// @Produce application/octet-stream
+// @Param id path int true "Belge ID"
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/application/documents/{id} [get]
+func (h *HospitalHandler) DownloadDocument(c echo.Context) error {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+	documentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Geçersiz belge ID",
+		})
+	}
+
+	document, err := h.hospitalService.GetDocument(hospitalID, uint(documentID))
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return sendDocument(c, document)
+}
+
+// DeleteDocument başvuru belgesini siler
+// @Summary Başvuru belgesini sil
+// @Description Sadece onay beklerken veya red sonrası silinebilir
+// @Tags Hospital Application
+// @Produce json
+// @Param id path int true "Belge ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/application/documents/{id} [delete]
+func (h *HospitalHandler) DeleteDocument(c echo.Context) error {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+	documentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Geçersiz belge ID",
+		})
+	}
+
+	if err := h.hospitalService.DeleteDocument(hospitalID, uint(documentID)); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Belge silindi",
+	})
+}
+
+// ResubmitApplication reddedilen başvuruyu tekrar gönderir
+// @Summary Başvuruyu tekrar gönder
+// @Description Red gerekçesine göre bilgiler ve belgeler düzeltildikten sonra başvuru tekrar incelemeye (pending) gönderilir. Faaliyet izin belgesi zorunludur
+// @Tags Hospital Application
+// @Produce json
+// @Success 200 {object} model.HospitalApplicationResponse
+// @Failure 401 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/application/resubmit [post]
+func (h *HospitalHandler) ResubmitApplication(c echo.Context) error {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	application, validationErrors, err := h.hospitalService.ResubmitApplication(hospitalID)
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Veri doğrulama hataları",
+			"validation_errors": validationErrors,
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Başvuru tekrar incelemeye gönderildi",
+		"data":    application,
+	})
+}
+
+// handleError servis hatalarını uygun HTTP durum kodlarına çevirir
+func (h *HospitalHandler) handleError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrHospitalNotFound), errors.Is(err, service.ErrDocumentNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrApplicationNotEditable), errors.Is(err, service.ErrApplicationNotRejected):
+		return c.JSON(http.StatusConflict, echo.Map{"error": err.Error()})
+	default:
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+}
+
+// sendDocument belgeyi indirilecek dosya olarak yazar
+// İçerik türü yüklemede içerikten tespit edilmiştir; tarayıcının tahmin yürütmesi engellenir
+func sendDocument(c echo.Context, document *model.HospitalDocument) error {
+	c.Response().Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": document.FileName}))
+	c.Response().Header().Set("X-Content-Type-Options", "nosniff")
+	return c.Blob(http.StatusOK, document.ContentType, document.Data)
+}
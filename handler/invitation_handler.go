@@ -0,0 +1,345 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"hospital-platform/model"
+	"hospital-platform/service"
+	"hospital-platform/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// InvitationHandler alt kullanıcı davetleri HTTP isteklerini yönetir
+type InvitationHandler struct {
+	invitationService *service.InvitationService
+}
+
+// NewInvitationHandler yeni bir davet handler'ı oluşturur
+func NewInvitationHandler() *InvitationHandler {
+	return &InvitationHandler{
+		invitationService: service.NewInvitationService(),
+	}
+}
+
+// ==================== YÖNETİCİ ENDPOINT'LERİ ====================
+
+// CreateInvitation alt kullanıcı daveti oluşturur
+// @Summary Alt kullanıcı davet et
+// @Description Yönetici ad, e-posta, telefon ve rol girer; davetli kişiye imzalı, tek kullanımlık bir link e-postayla gönderilir. Şifreyi davetli kişi belirler
+// @Tags User Management
+// @Accept json
+// @Produce json
+// @Param body body model.CreateInvitationRequest true "Davet bilgileri"
+// @Success 201 {object} model.InvitationResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/invitations [post]
+func (h *InvitationHandler) CreateInvitation(c echo.Context) error {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	var req model.CreateInvitationRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Geçersiz istek formatı",
+			"details": err.Error(),
+		})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Veri doğrulama hatası",
+			"details": err.Error(),
+		})
+	}
+
+	invitation, validationErrors, err := h.invitationService.CreateInvitation(&req, hospitalID, userID)
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Veri doğrulama hataları",
+			"validation_errors": validationErrors,
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, echo.Map{
+		"message": "Davet gönderildi",
+		"data":    invitation,
+	})
+}
+
+// ListInvitations hastanenin davetlerini listeler
+// @Summary Davetleri listele
+// @Description Hastanenin davetlerini listeler. Varsayılan olarak bekleyen (süresi dolmamış) davetler döner
+// @Tags User Management
+// @Produce json
+// @Param status query string false "pending (varsayılan), accepted, revoked, expired veya all"
+// @Success 200 {array} model.InvitationResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/invitations [get]
+func (h *InvitationHandler) ListInvitations(c echo.Context) error {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	status := c.QueryParam("status")
+	switch status {
+	case "":
+		status = model.InvitationPending
+	case "all":
+		status = ""
+	case model.InvitationPending, model.InvitationAccepted, model.InvitationRevoked, model.InvitationExpired:
+	default:
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Geçersiz durum filtresi",
+		})
+	}
+
+	invitations, err := h.invitationService.ListInvitations(hospitalID, status)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data": invitations,
+	})
+}
+
+// ResendInvitation daveti yeni linkle tekrar gönderir
+// @Summary Daveti tekrar gönder
+// @Description Yeni bir link üretilir ve süre yeniden başlar; önceki link geçersiz olur
+// @Tags User Management
+// @Produce json
+// @Param id path int true "Davet ID"
+// @Success 200 {object} model.InvitationResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/invitations/{id}/resend [post]
+func (h *InvitationHandler) ResendInvitation(c echo.Context) error {
+	hospitalID, userID, id, ok := h.getTarget(c)
+	if !ok {
+		return nil
+	}
+
+	invitation, err := h.invitationService.ResendInvitation(hospitalID, id, userID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Davet tekrar gönderildi",
+		"data":    invitation,
+	})
+}
+
+// RevokeInvitation bekleyen daveti iptal eder
+// @Summary Daveti iptal et
+// @Description Bekleyen davetin linki artık kullanılamaz
+// @Tags User Management
+// @Produce json
+// @Param id path int true "Davet ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/invitations/{id} [delete]
+func (h *InvitationHandler) RevokeInvitation(c echo.Context) error {
+	hospitalID, userID, id, ok := h.getTarget(c)
+	if !ok {
+		return nil
+	}
+
+	if err := h.invitationService.RevokeInvitation(hospitalID, id, userID); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Davet iptal edildi",
+	})
+}
+
+// ==================== DAVETLİ ENDPOINT'LERİ ====================
+
+// LookupInvitation davet linkini doğrular ve kabul ekranı bilgilerini döndürür
+// @Summary Davet bilgisi
+// @Description Davet linkindeki token doğrulanır; geçerliyse ad, e-posta, maskelenmiş telefon, hastane ve rol döner
+// @Tags Invitations
+// @Accept json
+// @Produce json
+// @Param body body model.InvitationTokenRequest true "Davet token'ı"
+// @Success 200 {object} model.InvitationDetailsResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 410 {object} map[string]interface{}
+// @Router /invitations/lookup [post]
+func (h *InvitationHandler) LookupInvitation(c echo.Context) error {
+	var req model.InvitationTokenRequest
+	if err := c.Bind(&req); err != nil || req.Token == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Geçersiz veri"})
+	}
+
+	details, err := h.invitationService.GetDetails(req.Token)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data": details,
+	})
+}
+
+// SendInvitationPhoneCode davetteki telefona doğrulama kodu gönderir
+// @Summary Davet telefon doğrulama kodu
+// @Description Davette kayıtlı telefona SMS ile 6 haneli kod gönderilir. Kod daveti kabul ederken girilir
+// @Tags Invitations
+// @Accept json
+// @Produce json
+// @Param body body model.InvitationTokenRequest true "Davet token'ı"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 410 {object} map[string]interface{}
+// @Failure 429 {object} map[string]interface{}
+// @Router /invitations/phone-code [post]
+func (h *InvitationHandler) SendInvitationPhoneCode(c echo.Context) error {
+	var req model.InvitationTokenRequest
+	if err := c.Bind(&req); err != nil || req.Token == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Geçersiz veri"})
+	}
+
+	lang := ""
+	if req.Lang != "" {
+		lang = service.ResolveLanguage(req.Lang, "")
+	}
+
+	if err := h.invitationService.SendPhoneCode(req.Token, lang); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Doğrulama kodu gönderildi",
+	})
+}
+
+// AcceptInvitation daveti kabul eder ve kullanıcı hesabını oluşturur
+// @Summary Daveti kabul et
+// @Description Davetli kişi TC kimlik numarasını girer, şifresini belirler (hastane şifre politikası) ve telefon kodunu doğrular. Başarılı olursa doğrudan giriş yapılır
+// @Tags Invitations
+// @Accept json
+// @Produce json
+// @Param body body model.AcceptInvitationRequest true "Kabul bilgileri"
+// @Success 201 {object} model.TokenResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 410 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Router /invitations/accept [post]
+func (h *InvitationHandler) AcceptInvitation(c echo.Context) error {
+	var req model.AcceptInvitationRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Geçersiz istek formatı",
+			"details": err.Error(),
+		})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Veri doğrulama hatası",
+			"details": err.Error(),
+		})
+	}
+
+	tokens, challenge, validationErrors, err := h.invitationService.AcceptInvitation(&req, utils.GetClientInfo(c))
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Veri doğrulama hataları",
+			"validation_errors": validationErrors,
+		})
+	}
+	if err != nil {
+		fmt.Println("Davet kabul hatası:", err)
+		return h.handleError(c, err)
+	}
+
+	if challenge != nil {
+		return c.JSON(http.StatusCreated, challenge)
+	}
+	return c.JSON(http.StatusCreated, tokens)
+}
+
+// ==================== HELPER METHODS ====================
+
+// getTarget token'dan hastane ve kullanıcı ID'sini, path'ten davet ID'sini alır
+// Hata durumunda cevabı kendisi yazar ve ok=false döner
+func (h *InvitationHandler) getTarget(c echo.Context) (uint, uint, uint, bool) {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+		return 0, 0, 0, false
+	}
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+		return 0, 0, 0, false
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, echo.Map{"error": "Geçersiz davet ID"})
+		return 0, 0, 0, false
+	}
+	return hospitalID, userID, uint(id), true
+}
+
+// handleError servis hatalarını uygun HTTP durum kodlarına çevirir
+func (h *InvitationHandler) handleError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvitationNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrPermissionEscalation):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrInvitationNotPending):
+		return c.JSON(http.StatusConflict, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidInvitation), errors.Is(err, service.ErrInvitationRoleGone):
+		return c.JSON(http.StatusGone, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrVerificationCodeInvalid), errors.Is(err, service.ErrVerificationCodeExhausted):
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Veri doğrulama hataları",
+			"validation_errors": []model.ValidationError{{Field: "phone_code", Message: err.Error()}},
+		})
+	case errors.Is(err, service.ErrTooManyVerificationCodes):
+		return c.JSON(http.StatusTooManyRequests, echo.Map{"error": err.Error()})
+	case errors.Is(err, utils.ErrHasherBusy):
+		c.Response().Header().Set("Retry-After", "1")
+		return c.JSON(http.StatusServiceUnavailable, echo.Map{"error": "Sunucu yoğun, lütfen tekrar deneyin"})
+	default:
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+}
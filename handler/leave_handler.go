@@ -0,0 +1,619 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"hospital-platform/model"
+	"hospital-platform/service"
+	"hospital-platform/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// LeaveHandler izin türleri, izin talepleri, yıllık haklar ve devamsızlık takvimi HTTP isteklerini yönetir
+type LeaveHandler struct {
+	leaveService *service.LeaveService
+}
+
+// NewLeaveHandler yeni bir izin handler'ı oluşturur
+func NewLeaveHandler() *LeaveHandler {
+	return &LeaveHandler{
+		leaveService: service.NewLeaveService(),
+	}
+}
+
+// ==================== İZİN TÜRLERİ ====================
+
+// GetLeaveTypes hastanenin izin türlerini listeler
+// @Summary İzin türleri
+// @Description Hastanede tanımlı izin türlerini listeler. Hiç tür tanımlanmamışsa varsayılan türler (Yıllık İzin, Hastalık İzni, Kongre / Eğitim, Mazeret İzni) oluşturulur
+// @Tags Leave
+// @Produce json
+// @Success 200 {array} model.LeaveType
+// @Failure 401 {object} map[string]interface{}
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /hospital/leave-types [get]
+func (h *LeaveHandler) GetLeaveTypes(c echo.Context) error {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	types, err := h.leaveService.GetTypes(hospitalID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data": types,
+	})
+}
+
+// CreateLeaveType izin türü ekler
+// @Summary İzin türü ekle
+// @Description Yıllık hak 0 ise türün kullanımı bakiyeden düşülmez (ör. hastalık izni)
+// @Tags Leave
+// @Accept json
+// @Produce json
+// @Param body body model.LeaveTypeRequest true "İzin türü"
+// @Success 201 {object} model.LeaveType
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/leave-types [post]
+func (h *LeaveHandler) CreateLeaveType(c echo.Context) error {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	var req model.LeaveTypeRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Geçersiz istek formatı",
+			"details": err.Error(),
+		})
+	}
+
+	leaveType, validationErrors, err := h.leaveService.CreateType(hospitalID, &req)
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Veri doğrulama hataları",
+			"validation_errors": validationErrors,
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, echo.Map{
+		"message": "İzin türü başarıyla eklendi",
+		"data":    leaveType,
+	})
+}
+
+// UpdateLeaveType izin türünü günceller
+// @Summary İzin türü güncelle
+// @Description Yıllık hak değişikliği personele özel hak tanımlanmamış tüm personeli etkiler; girilmiş talepler değişmez
+// @Tags Leave
+// @Accept json
+// @Produce json
+// @Param id path int true "İzin türü ID"
+// @Param body body model.LeaveTypeRequest true "İzin türü"
+// @Success 200 {object} model.LeaveType
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/leave-types/{id} [put]
+func (h *LeaveHandler) UpdateLeaveType(c echo.Context) error {
+	hospitalID, id, ok := h.getTarget(c, "Geçersiz izin türü ID")
+	if !ok {
+		return nil
+	}
+
+	var req model.LeaveTypeRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Geçersiz istek formatı",
+			"details": err.Error(),
+		})
+	}
+
+	leaveType, validationErrors, err := h.leaveService.UpdateType(hospitalID, id, &req)
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Veri doğrulama hataları",
+			"validation_errors": validationErrors,
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "İzin türü başarıyla güncellendi",
+		"data":    leaveType,
+	})
+}
+
+// DeleteLeaveType izin türünü siler
+// @Summary İzin türü sil
+// @Description Bekleyen veya onaylanmış talebi olan tür silinemez
+// @Tags Leave
+// @Produce json
+// @Param id path int true "İzin türü ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/leave-types/{id} [delete]
+func (h *LeaveHandler) DeleteLeaveType(c echo.Context) error {
+	hospitalID, id, ok := h.getTarget(c, "Geçersiz izin türü ID")
+	if !ok {
+		return nil
+	}
+
+	if err := h.leaveService.DeleteType(hospitalID, id); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "İzin türü başarıyla silindi",
+	})
+}
+
+// ==================== İZİN TALEPLERİ ====================
+
+// ListLeaveRequests hastanenin izin taleplerini listeler
+// @Summary İzin talepleri
+// @Description Talepler başlangıç tarihine göre sıralanır. from/to verilirse bu aralığa denk gelen talepler döner
+// @Tags Leave
+// @Produce json
+// @Param status query string false "pending, approved, rejected, cancelled (boşsa tümü)"
+// @Param staff_id query int false "Personel ID"
+// @Param polyclinic_id query int false "Poliklinik ID"
+// @Param from query string false "Başlangıç günü (YYYY-AA-GG)"
+// @Param to query string false "Bitiş günü, dahil (YYYY-AA-GG)"
+// @Success 200 {array} model.LeaveRequest
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /hospital/leave-requests [get]
+func (h *LeaveHandler) ListLeaveRequests(c echo.Context) error {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	filter := model.LeaveRequestFilter{Status: c.QueryParam("status")}
+	switch filter.Status {
+	case "", model.LeavePending, model.LeaveApproved, model.LeaveRejected, model.LeaveCancelled:
+	default:
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Geçersiz durum filtresi",
+		})
+	}
+	if !h.parseIDQuery(c, "staff_id", &filter.StaffID) ||
+		!h.parseIDQuery(c, "polyclinic_id", &filter.PolyclinicID) ||
+		!h.parseDateQuery(c, "from", &filter.From) ||
+		!h.parseDateQuery(c, "to", &filter.To) {
+		return nil
+	}
+
+	requests, err := h.leaveService.ListRequests(hospitalID, &filter)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data": requests,
+	})
+}
+
+// CreateLeaveRequest personel adına izin talebi oluşturur
+// @Summary İzin talebi oluştur
+// @Description Talep onay bekler durumda oluşur. İzinden düşen gün sayısı personelin çalışma programında vardiyası olan günlerden hesaplanır; aynı günlere bekleyen/onaylı başka talep olamaz ve takip edilen türlerde kalan hak aşılamaz. Yıl sonunu aşan izinler her yıl için ayrı girilir
+// @Tags Leave
+// @Accept json
+// @Produce json
+// @Param body body model.CreateLeaveRequest true "İzin talebi"
+// @Success 201 {object} model.LeaveRequest
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /hospital/leave-requests [post]
+func (h *LeaveHandler) CreateLeaveRequest(c echo.Context) error {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	var req model.CreateLeaveRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Geçersiz istek formatı",
+			"details": err.Error(),
+		})
+	}
+
+	request, validationErrors, err := h.leaveService.CreateRequest(hospitalID, h.getActor(c), &req)
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Veri doğrulama hataları",
+			"validation_errors": validationErrors,
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, echo.Map{
+		"message": "İzin talebi oluşturuldu, yetkili onayı bekleniyor",
+		"data":    request,
+	})
+}
+
+// ApproveLeaveRequest bekleyen izin talebini onaylar
+// @Summary İzin talebini onayla
+// @Description Onaylanan izin vardiya takviminde ve personel listesinde görünür. Takip edilen türlerde yıllık hak aşılıyorsa onaylanmaz
+// @Tags Leave
+// @Produce json
+// @Param id path int true "İzin talebi ID"
+// @Success 200 {object} model.LeaveRequest
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/leave-requests/{id}/approve [post]
+func (h *LeaveHandler) ApproveLeaveRequest(c echo.Context) error {
+	hospitalID, id, ok := h.getTarget(c, "Geçersiz izin talebi ID")
+	if !ok {
+		return nil
+	}
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	request, validationErrors, err := h.leaveService.Approve(hospitalID, id, userID)
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Veri doğrulama hataları",
+			"validation_errors": validationErrors,
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "İzin talebi onaylandı",
+		"data":    request,
+	})
+}
+
+// RejectLeaveRequest bekleyen izin talebini reddeder
+// @Summary İzin talebini reddet
+// @Description Talep gerekçesiyle reddedilir
+// @Tags Leave
+// @Accept json
+// @Produce json
+// @Param id path int true "İzin talebi ID"
+// @Param body body model.RejectLeaveRequest true "Red gerekçesi"
+// @Success 200 {object} model.LeaveRequest
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/leave-requests/{id}/reject [post]
+func (h *LeaveHandler) RejectLeaveRequest(c echo.Context) error {
+	hospitalID, id, ok := h.getTarget(c, "Geçersiz izin talebi ID")
+	if !ok {
+		return nil
+	}
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	var req model.RejectLeaveRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Geçersiz istek formatı",
+			"details": err.Error(),
+		})
+	}
+
+	request, validationErrors, err := h.leaveService.Reject(hospitalID, id, userID, req.Reason)
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Veri doğrulama hataları",
+			"validation_errors": validationErrors,
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "İzin talebi reddedildi",
+		"data":    request,
+	})
+}
+
+// CancelLeaveRequest izin talebini iptal eder
+// @Summary İzin talebini iptal et
+// @Description Bekleyen talepler staff:write ile, onaylanmış izinler sadece leaves:approve yetkisiyle iptal edilir
+// @Tags Leave
+// @Produce json
+// @Param id path int true "İzin talebi ID"
+// @Success 200 {object} model.LeaveRequest
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /hospital/leave-requests/{id}/cancel [post]
+func (h *LeaveHandler) CancelLeaveRequest(c echo.Context) error {
+	hospitalID, id, ok := h.getTarget(c, "Geçersiz izin talebi ID")
+	if !ok {
+		return nil
+	}
+
+	request, err := h.leaveService.Cancel(hospitalID, id, h.getActor(c), utils.HasPermission(c, model.PermLeavesApprove))
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "İzin talebi iptal edildi",
+		"data":    request,
+	})
+}
+
+// ==================== YILLIK HAKLAR ====================
+
+// GetLeaveBalances personelin yıllık izin bakiyelerini döndürür
+// @Summary Personel izin bakiyeleri
+// @Description Her izin türü için yıllık hak, onaylanmış ve bekleyen günler ile kalan hak. Yıl verilmezse içinde bulunulan yıl
+// @Tags Leave
+// @Produce json
+// @Param id path int true "Personel ID"
+// @Param year query int false "Yıl"
+// @Success 200 {array} model.LeaveBalanceSummary
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /hospital/staff/{id}/leave-balances [get]
+func (h *LeaveHandler) GetLeaveBalances(c echo.Context) error {
+	hospitalID, staffID, ok := h.getTarget(c, "Geçersiz personel ID")
+	if !ok {
+		return nil
+	}
+
+	year := time.Now().Year()
+	if value := c.QueryParam("year"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, echo.Map{
+				"error": "Geçersiz yıl",
+			})
+		}
+		year = parsed
+	}
+
+	balances, err := h.leaveService.GetBalances(hospitalID, staffID, year)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data": balances,
+	})
+}
+
+// SetLeaveBalance personelin yıllık izin hakkını tanımlar
+// @Summary Personel izin hakkı tanımla
+// @Description İzin türünün varsayılan yıllık hakkı yerine personele özel toplam hak (devreden izinler dahil) tanımlar. Hak 0 verilirse o yıl bu türde izin onaylanamaz
+// @Tags Leave
+// @Accept json
+// @Produce json
+// @Param id path int true "Personel ID"
+// @Param body body model.LeaveBalanceRequest true "Yıllık hak"
+// @Success 200 {object} model.LeaveBalanceSummary
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/staff/{id}/leave-balances [put]
+func (h *LeaveHandler) SetLeaveBalance(c echo.Context) error {
+	hospitalID, staffID, ok := h.getTarget(c, "Geçersiz personel ID")
+	if !ok {
+		return nil
+	}
+
+	var req model.LeaveBalanceRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Geçersiz istek formatı",
+			"details": err.Error(),
+		})
+	}
+
+	balance, validationErrors, err := h.leaveService.SetBalance(hospitalID, staffID, &req)
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Veri doğrulama hataları",
+			"validation_errors": validationErrors,
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "İzin hakkı kaydedildi",
+		"data":    balance,
+	})
+}
+
+// ==================== DEVAMSIZLIK TAKVİMİ ====================
+
+// GetAbsenceCalendar polikliniğin devamsızlık takvimini döndürür
+// @Summary Poliklinik devamsızlık takvimi
+// @Description Poliklinikte izinli personeli gün gün listeler (en fazla 92 gün). Tarihler verilmezse içinde bulunulan ay. include_pending=true ile onay bekleyen talepler de gösterilir
+// @Tags Leave
+// @Produce json
+// @Param id path int true "Poliklinik ID"
+// @Param from query string false "Başlangıç günü (YYYY-AA-GG)"
+// @Param to query string false "Bitiş günü, dahil (YYYY-AA-GG)"
+// @Param include_pending query bool false "Onay bekleyen talepleri de göster"
+// @Success 200 {array} model.AbsenceCalendarDay
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /hospital/polyclinics/{id}/absences [get]
+func (h *LeaveHandler) GetAbsenceCalendar(c echo.Context) error {
+	hospitalID, polyclinicID, ok := h.getTarget(c, "Geçersiz poliklinik ID")
+	if !ok {
+		return nil
+	}
+
+	now := time.Now()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	var from, to *time.Time
+	if !h.parseDateQuery(c, "from", &from) || !h.parseDateQuery(c, "to", &to) {
+		return nil
+	}
+	if from == nil {
+		from = &monthStart
+	}
+	if to == nil {
+		end := from.AddDate(0, 1, -1)
+		to = &end
+	}
+	includePending := c.QueryParam("include_pending") == "true"
+
+	days, err := h.leaveService.GetAbsenceCalendar(hospitalID, polyclinicID, *from, *to, includePending)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data": days,
+	})
+}
+
+// ==================== HELPER METHODS ====================
+
+// getTarget token'dan hastane ID'sini ve path'ten hedef ID'yi alır
+// Hata durumunda cevabı kendisi yazar ve ok=false döner
+func (h *LeaveHandler) getTarget(c echo.Context, invalidIDMessage string) (uint, uint, bool) {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+		return 0, 0, false
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, echo.Map{"error": invalidIDMessage})
+		return 0, 0, false
+	}
+	return hospitalID, uint(id), true
+}
+
+// getActor işlemi yapan kullanıcıyı döndürür (API anahtarıyla yapılan isteklerde nil)
+func (h *LeaveHandler) getActor(c echo.Context) *uint {
+	if userID, ok := utils.GetUserIDFromContext(c); ok {
+		return &userID
+	}
+	return nil
+}
+
+// parseIDQuery isteğe bağlı ID query parametresini okur; hatalıysa cevabı kendisi yazar ve false döner
+func (h *LeaveHandler) parseIDQuery(c echo.Context, name string, target **uint) bool {
+	value := c.QueryParam(name)
+	if value == "" {
+		return true
+	}
+	parsed, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, echo.Map{"error": "Geçersiz " + name})
+		return false
+	}
+	id := uint(parsed)
+	*target = &id
+	return true
+}
+
+// parseDateQuery isteğe bağlı YYYY-AA-GG query parametresini okur; hatalıysa cevabı kendisi yazar ve false döner
+func (h *LeaveHandler) parseDateQuery(c echo.Context, name string, target **time.Time) bool {
+	value := c.QueryParam(name)
+	if value == "" {
+		return true
+	}
+	parsed, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, echo.Map{"error": name + " YYYY-AA-GG biçiminde olmalı"})
+		return false
+	}
+	*target = &parsed
+	return true
+}
+
+// handleError servis hatalarını uygun HTTP durum kodlarına çevirir
+func (h *LeaveHandler) handleError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrLeaveTypeNotFound),
+		errors.Is(err, service.ErrLeaveRequestNotFound),
+		errors.Is(err, service.ErrLeaveStaffNotFound),
+		errors.Is(err, service.ErrLeavePolyclinicNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrLeaveCancelForbidden):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrLeaveTypeInUse),
+		errors.Is(err, service.ErrLeaveNotPending),
+		errors.Is(err, service.ErrLeaveNotCancellable):
+		return c.JSON(http.StatusConflict, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrLeaveRangeInvalid):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	default:
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+}
@@ -0,0 +1,292 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"hospital-platform/model"
+	"hospital-platform/service"
+	"hospital-platform/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// MembershipHandler çoklu hastane üyelikleri ve aktif hastane değişimi HTTP isteklerini yönetir
+type MembershipHandler struct {
+	membershipService *service.MembershipService
+}
+
+// NewMembershipHandler yeni bir üyelik handler'ı oluşturur
+func NewMembershipHandler() *MembershipHandler {
+	return &MembershipHandler{
+		membershipService: service.NewMembershipService(),
+	}
+}
+
+// ==================== KENDİ ÜYELİKLERİ ====================
+
+// ListMyMemberships kullanıcının giriş yapabildiği hastaneleri listeler
+// @Summary Hastanelerim
+// @Description Kullanıcının birincil hastanesi ve üye olduğu diğer hastaneler, her birindeki rolüyle. Token'ın geçerli olduğu hastane current=true ile işaretlenir
+// @Tags Auth
+// @Produce json
+// @Success 200 {array} model.MembershipResponse
+// @Failure 401 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /me/memberships [get]
+func (h *MembershipHandler) ListMyMemberships(c echo.Context) error {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+	}
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+	}
+
+	memberships, err := h.membershipService.ListMemberships(userID, hospitalID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data": memberships,
+	})
+}
+
+// SwitchHospital aktif hastaneyi değiştirir
+// @Summary Hastane değiştir
+// @Description Mevcut oturumda, kullanıcının üye olduğu başka bir hastane için yeni access + refresh token üretir. Yeni token'daki hospital_id ve rol seçilen hastanedeki üyelikten gelir; tüm hastane işlemleri bu hastanede yapılır
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body model.SwitchHospitalRequest true "Geçilecek hastane"
+// @Success 200 {object} model.TokenResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /auth/switch-hospital [post]
+func (h *MembershipHandler) SwitchHospital(c echo.Context) error {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+	}
+	familyID, ok := utils.GetTokenFamilyFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+	}
+
+	var req model.SwitchHospitalRequest
+	if err := c.Bind(&req); err != nil || req.HospitalID == 0 {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Hastane ID gerekli"})
+	}
+
+	tokens, err := h.membershipService.SwitchHospital(userID, familyID, req.HospitalID)
+	if err != nil {
+		if errors.Is(err, service.ErrSessionNotFound) {
+			return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Oturum sonlandırılmış"})
+		}
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, tokens)
+}
+
+// ==================== HASTANE ÜYELERİ ====================
+
+// ListMembers hastanenin diğer hastanelerden üyelerini listeler
+// @Summary Hastane üyeleri
+// @Description Birincil hastanesi başka olan ve bu hastanede de çalışan kullanıcılar (ör. konsültan hekimler), bu hastanedeki rolleriyle
+// @Tags User Management
+// @Produce json
+// @Success 200 {array} model.HospitalMemberResponse
+// @Failure 401 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/members [get]
+func (h *MembershipHandler) ListMembers(c echo.Context) error {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+	}
+
+	members, err := h.membershipService.ListMembers(hospitalID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data": members,
+	})
+}
+
+// AddMember başka hastanedeki mevcut bir kullanıcıyı bu hastaneye ekler
+// @Summary Üye ekle
+// @Description Grubun başka bir hastanesinde hesabı olan kullanıcı, TC kimlik numarası ve hesabındaki e-posta ile bu hastaneye verilen rolle eklenir. Kullanıcı aynı hesapla giriş yapıp /auth/switch-hospital ile bu hastaneye geçer
+// @Tags User Management
+// @Accept json
+// @Produce json
+// @Param body body model.AddMemberRequest true "Kullanıcı ve bu hastanedeki rolü"
+// @Success 201 {object} model.HospitalMemberResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/members [post]
+func (h *MembershipHandler) AddMember(c echo.Context) error {
+	hospitalID, actorID, ok := h.getActor(c)
+	if !ok {
+		return nil
+	}
+
+	var req model.AddMemberRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Geçersiz istek formatı",
+			"details": err.Error(),
+		})
+	}
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Veri doğrulama hatası",
+			"details": err.Error(),
+		})
+	}
+
+	member, validationErrors, err := h.membershipService.AddMember(hospitalID, actorID, &req)
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Veri doğrulama hataları",
+			"validation_errors": validationErrors,
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, echo.Map{
+		"message": "Kullanıcı hastaneye üye eklendi",
+		"data":    member,
+	})
+}
+
+// UpdateMember üyenin bu hastanedeki rolünü ve durumunu günceller
+// @Summary Üyeliği güncelle
+// @Description Üyenin bu hastanedeki rolü, polikliniği ve aktifliği değiştirilir; kullanıcının diğer hastanelerdeki hesabı etkilenmez. Açık token'lar bir sonraki istekte yenilenmelidir
+// @Tags User Management
+// @Accept json
+// @Produce json
+// @Param id path int true "Üyelik ID"
+// @Param body body model.UpdateMemberRequest true "Rol, poliklinik ve durum"
+// @Success 200 {object} model.HospitalMemberResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/members/{id} [put]
+func (h *MembershipHandler) UpdateMember(c echo.Context) error {
+	hospitalID, actorID, ok := h.getActor(c)
+	if !ok {
+		return nil
+	}
+	membershipID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Geçersiz üyelik ID"})
+	}
+
+	var req model.UpdateMemberRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Geçersiz istek formatı",
+			"details": err.Error(),
+		})
+	}
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Veri doğrulama hatası",
+			"details": err.Error(),
+		})
+	}
+
+	member, validationErrors, err := h.membershipService.UpdateMember(hospitalID, uint(membershipID), actorID, &req)
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Veri doğrulama hataları",
+			"validation_errors": validationErrors,
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Üyelik güncellendi",
+		"data":    member,
+	})
+}
+
+// RemoveMember üyeliği kaldırır
+// @Summary Üyeliği kaldır
+// @Description Kullanıcının bu hastanedeki erişimi kaldırılır; birincil hastanesindeki hesabı etkilenmez
+// @Tags User Management
+// @Produce json
+// @Param id path int true "Üyelik ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/members/{id} [delete]
+func (h *MembershipHandler) RemoveMember(c echo.Context) error {
+	hospitalID, actorID, ok := h.getActor(c)
+	if !ok {
+		return nil
+	}
+	membershipID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Geçersiz üyelik ID"})
+	}
+
+	if err := h.membershipService.RemoveMember(hospitalID, uint(membershipID), actorID); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Üyelik kaldırıldı",
+	})
+}
+
+// getActor token'dan hastane ve işlemi yapan kullanıcı ID'sini alır
+// Hata durumunda cevabı kendisi yazar ve ok=false döner
+func (h *MembershipHandler) getActor(c echo.Context) (uint, uint, bool) {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+		return 0, 0, false
+	}
+	actorID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+		return 0, 0, false
+	}
+	return hospitalID, actorID, true
+}
+
+// handleError servis hatalarını uygun HTTP durum kodlarına çevirir
+func (h *MembershipHandler) handleError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrMemberNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrMembershipNotFound), errors.Is(err, service.ErrPermissionEscalation), errors.Is(err, service.ErrHospitalSuspended), errors.Is(err, service.ErrUserInactive):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrMemberSelf):
+		return c.JSON(http.StatusConflict, echo.Map{"error": err.Error()})
+	default:
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+}
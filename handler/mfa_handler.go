@@ -0,0 +1,257 @@
+package handler
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"hospital-platform/model"
+	"hospital-platform/service"
+	"hospital-platform/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// MFAHandler iki adımlı doğrulama HTTP isteklerini yönetir
+type MFAHandler struct {
+	mfaService *service.MFAService
+}
+
+// NewMFAHandler yeni bir MFA handler'ı oluşturur
+func NewMFAHandler() *MFAHandler {
+	return &MFAHandler{
+		mfaService: service.NewMFAService(),
+	}
+}
+
+// VerifyLogin login ikinci adımını tamamlar
+// @Summary MFA ile giriş (ikinci adım)
+// @Description Login'de dönen mfa_token ve authenticator kodu (veya kurtarma kodu) ile token çifti alınır
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body model.MFALoginRequest true "MFA token ve kod"
+// @Success 200 {object} model.TokenResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 429 {object} map[string]interface{}
+// @Router /login/mfa [post]
+func (h *MFAHandler) VerifyLogin(c echo.Context) error {
+	var req model.MFALoginRequest
+	if err := c.Bind(&req); err != nil || req.MFAToken == "" || (req.Code == "" && req.RecoveryCode == "") {
+		return c.JSON(http.StatusBadRequest, echo.Map{"message": "Geçersiz veri"})
+	}
+
+	tokens, err := h.mfaService.VerifyLogin(&req, utils.GetClientInfo(c))
+	if err != nil {
+		var blocked *service.LoginBlockedError
+		if errors.As(err, &blocked) {
+			c.Response().Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(blocked.RetryAfter.Seconds()))))
+			return c.JSON(http.StatusTooManyRequests, echo.Map{"message": blocked.Error()})
+		}
+		if errors.Is(err, service.ErrInvalidMFAToken) || errors.Is(err, service.ErrInvalidMFACode) {
+			return c.JSON(http.StatusUnauthorized, echo.Map{"message": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Giriş yapılamadı"})
+	}
+
+	return c.JSON(http.StatusOK, tokens)
+}
+
+// StartEnrollment TOTP kaydını başlatır
+// @Summary TOTP kaydını başlat
+// @Description Authenticator uygulaması için secret ve QR kod üretir. Kayıt, /me/mfa/totp/verify ile kod doğrulanınca aktif olur
+// @Tags MFA
+// @Produce json
+// @Success 200 {object} model.MFAEnrollResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /me/mfa/totp/enroll [post]
+func (h *MFAHandler) StartEnrollment(c echo.Context) error {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	enrollment, err := h.mfaService.StartEnrollment(userID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data": enrollment,
+	})
+}
+
+// ConfirmEnrollment TOTP kaydını doğrular ve aktif eder
+// @Summary TOTP kaydını doğrula
+// @Description İlk authenticator kodunu doğrular, MFA'yı aktif eder ve tek kullanımlık kurtarma kodlarını döner (sadece bir kez gösterilir)
+// @Tags MFA
+// @Accept json
+// @Produce json
+// @Param body body model.MFACodeRequest true "Authenticator kodu"
+// @Success 200 {object} model.MFAActivationResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /me/mfa/totp/verify [post]
+func (h *MFAHandler) ConfirmEnrollment(c echo.Context) error {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	var req model.MFACodeRequest
+	if err := c.Bind(&req); err != nil || req.Code == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Geçersiz istek formatı",
+		})
+	}
+
+	familyID, _ := utils.GetTokenFamilyFromContext(c)
+	activation, err := h.mfaService.ConfirmEnrollment(userID, req.Code, familyID, utils.GetClientInfo(c))
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "İki adımlı doğrulama aktif edildi",
+		"data":    activation,
+	})
+}
+
+// RegenerateRecoveryCodes yeni kurtarma kodları üretir
+// @Summary Kurtarma kodlarını yenile
+// @Description Güncel authenticator kodu ile doğrulayıp eski kurtarma kodlarını geçersiz kılar ve yenilerini üretir
+// @Tags MFA
+// @Accept json
+// @Produce json
+// @Param body body model.MFACodeRequest true "Authenticator kodu"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /me/mfa/recovery-codes [post]
+func (h *MFAHandler) RegenerateRecoveryCodes(c echo.Context) error {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	var req model.MFACodeRequest
+	if err := c.Bind(&req); err != nil || req.Code == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Geçersiz istek formatı",
+		})
+	}
+
+	codes, err := h.mfaService.RegenerateRecoveryCodes(userID, req.Code)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data": echo.Map{"recovery_codes": codes},
+	})
+}
+
+// Disable MFA'yı kapatır
+// @Summary İki adımlı doğrulamayı kapat
+// @Description Şifre ve authenticator kodu ile MFA kapatılır; tüm oturumlar sonlandırılır. Hastane MFA'yı zorunlu kılmışsa yetkili kullanıcılar kapatamaz
+// @Tags MFA
+// @Accept json
+// @Produce json
+// @Param body body model.DisableMFARequest true "Şifre ve kod"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /me/mfa/disable [post]
+func (h *MFAHandler) Disable(c echo.Context) error {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	var req model.DisableMFARequest
+	if err := c.Bind(&req); err != nil || req.Password == "" || req.Code == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Geçersiz istek formatı",
+		})
+	}
+
+	if err := h.mfaService.Disable(userID, req.Password, req.Code); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "İki adımlı doğrulama kapatıldı",
+	})
+}
+
+// UpdateHospitalPolicy hastane MFA politikasını günceller
+// @Summary Hastane MFA politikası
+// @Description Hastanedeki yetkili kullanıcılar için MFA zorunluluğunu açar/kapatır
+// @Tags MFA
+// @Accept json
+// @Produce json
+// @Param body body model.UpdateMFAPolicyRequest true "MFA politikası"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/settings/mfa [put]
+func (h *MFAHandler) UpdateHospitalPolicy(c echo.Context) error {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	var req model.UpdateMFAPolicyRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Geçersiz istek formatı",
+			"details": err.Error(),
+		})
+	}
+
+	if err := h.mfaService.SetHospitalPolicy(hospitalID, req.RequireMFA); err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "MFA politikası güncellendi",
+		"data":    req,
+	})
+}
+
+// ==================== HELPER METHODS ====================
+
+// handleError servis hatalarını uygun HTTP durum kodlarına çevirir
+func (h *MFAHandler) handleError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidMFACode), errors.Is(err, service.ErrInvalidCredentials):
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrMFARequiredByHospital), errors.Is(err, service.ErrHospitalSuspended), errors.Is(err, service.ErrUserInactive):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrMFAAlreadyEnabled), errors.Is(err, service.ErrMFANotEnabled), errors.Is(err, service.ErrMFANotEnrolled):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	default:
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+}
@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"hospital-platform/model"
+	"hospital-platform/service"
+	"hospital-platform/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// PasskeyHandler WebAuthn passkey kayıt, listeleme ve giriş isteklerini yönetir
+type PasskeyHandler struct {
+	passkeyService *service.PasskeyService
+}
+
+// NewPasskeyHandler yeni bir passkey handler'ı oluşturur
+func NewPasskeyHandler() *PasskeyHandler {
+	return &PasskeyHandler{
+		passkeyService: service.NewPasskeyService(),
+	}
+}
+
+// ==================== GİRİŞ ====================
+
+// BeginLogin passkey ile giriş seçeneklerini döndürür
+// @Summary Passkey girişini başlat
+// @Description navigator.credentials.get() için publicKey seçeneklerini döndürür. email_or_phone verilirse sadece o kullanıcının anahtarları önerilir, verilmezse cihazdaki keşfedilebilir passkey'ler kullanılır. Challenge 5 dakika geçerlidir
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body model.BeginPasskeyLoginRequest false "Opsiyonel kullanıcı kimliği"
+// @Success 200 {object} model.PasskeyRequestOptions
+// @Failure 500 {object} map[string]interface{}
+// @Router /login/passkey/options [post]
+func (h *PasskeyHandler) BeginLogin(c echo.Context) error {
+	var req model.BeginPasskeyLoginRequest
+	// Gövde opsiyonel - boş istek keşfedilebilir giriş başlatır
+	_ = c.Bind(&req)
+
+	options, err := h.passkeyService.BeginLogin(&req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Passkey girişi başlatılamadı"})
+	}
+
+	return c.JSON(http.StatusOK, options)
+}
+
+// FinishLogin passkey imzasını doğrulayıp token çifti döndürür
+// @Summary Passkey ile giriş
+// @Description navigator.credentials.get() yanıtı gönderilir. İmza kayıtlı açık anahtarla doğrulanır; passkey cihaz + PIN/biyometri gerektirdiği için token MFA doğrulanmış sayılır. Şifre kullanılmadığından şifre süresi kontrolü yapılmaz
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body model.FinishPasskeyLoginRequest true "Tarayıcının döndürdüğü kimlik bilgisi"
+// @Success 200 {object} model.TokenResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 429 {object} map[string]interface{}
+// @Router /login/passkey [post]
+func (h *PasskeyHandler) FinishLogin(c echo.Context) error {
+	var req model.FinishPasskeyLoginRequest
+	if err := c.Bind(&req); err != nil || req.Credential.RawID == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"message": "Geçersiz veri"})
+	}
+
+	tokens, err := h.passkeyService.FinishLogin(&req, utils.GetClientInfo(c))
+	if err != nil {
+		var blocked *service.LoginBlockedError
+		if errors.As(err, &blocked) {
+			c.Response().Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(blocked.RetryAfter.Seconds()))))
+			return c.JSON(http.StatusTooManyRequests, echo.Map{"message": blocked.Error()})
+		}
+		switch {
+		case errors.Is(err, service.ErrPasskeyNotFound),
+			errors.Is(err, service.ErrPasskeyInvalid),
+			errors.Is(err, service.ErrPasskeyCloned),
+			errors.Is(err, service.ErrPasskeyCeremonyExpired):
+			return c.JSON(http.StatusUnauthorized, echo.Map{"message": err.Error()})
+		case errors.Is(err, service.ErrHospitalSuspended), errors.Is(err, service.ErrUserInactive):
+			return c.JSON(http.StatusForbidden, echo.Map{"message": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Giriş yapılamadı"})
+	}
+
+	return c.JSON(http.StatusOK, tokens)
+}
+
+// ==================== PROFİL ====================
+
+// BeginRegistration passkey kayıt seçeneklerini döndürür
+// @Summary Passkey kaydını başlat
+// @Description navigator.credentials.create() için publicKey seçeneklerini döndürür. Kullanıcının kayıtlı anahtarları excludeCredentials ile hariç tutulur. Kullanıcı başına en fazla 10 passkey
+// @Tags Profile
+// @Produce json
+// @Success 200 {object} model.PasskeyCreationOptions
+// @Failure 401 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /me/passkeys/options [post]
+func (h *PasskeyHandler) BeginRegistration(c echo.Context) error {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+	}
+
+	options, err := h.passkeyService.BeginRegistration(userID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, options)
+}
+
+// FinishRegistration authenticator yanıtını doğrulayıp passkey'i kaydeder
+// @Summary Passkey kaydını tamamla
+// @Description navigator.credentials.create() yanıtı ve opsiyonel bir ad gönderilir. Kullanıcı doğrulaması (PIN/biyometri) yapılmamış yanıtlar reddedilir
+// @Tags Profile
+// @Accept json
+// @Produce json
+// @Param request body model.FinishPasskeyRegistrationRequest true "Ad ve tarayıcının döndürdüğü kimlik bilgisi"
+// @Success 201 {object} model.PasskeyResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /me/passkeys [post]
+func (h *PasskeyHandler) FinishRegistration(c echo.Context) error {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+	}
+
+	var req model.FinishPasskeyRegistrationRequest
+	if !bindRequest(c, &req) {
+		return nil
+	}
+
+	passkey, validationErrors, err := h.passkeyService.FinishRegistration(userID, &req)
+	if len(validationErrors) > 0 {
+		return validationFailed(c, validationErrors)
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, passkey)
+}
+
+// ListPasskeys kullanıcının kayıtlı passkey'lerini listeler
+// @Summary Passkey'lerim
+// @Tags Profile
+// @Produce json
+// @Success 200 {array} model.PasskeyResponse
+// @Failure 401 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /me/passkeys [get]
+func (h *PasskeyHandler) ListPasskeys(c echo.Context) error {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+	}
+
+	passkeys, err := h.passkeyService.ListPasskeys(userID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, passkeys)
+}
+
+// RevokePasskey kullanıcının passkey'ini siler
+// @Summary Passkey sil
+// @Description Silinen passkey ile artık giriş yapılamaz. Açık oturumlar /me/sessions üzerinden ayrıca sonlandırılabilir
+// @Tags Profile
+// @Produce json
+// @Param id path int true "Passkey ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /me/passkeys/{id} [delete]
+func (h *PasskeyHandler) RevokePasskey(c echo.Context) error {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Geçersiz passkey ID"})
+	}
+
+	if err := h.passkeyService.RevokePasskey(userID, uint(id)); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Passkey silindi",
+	})
+}
+
+// handleError servis hatalarını uygun HTTP durum kodlarına çevirir
+func (h *PasskeyHandler) handleError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrPasskeyNotFound), errors.Is(err, service.ErrProfileNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrPasskeyAlreadyRegistered), errors.Is(err, service.ErrPasskeyLimitReached):
+		return c.JSON(http.StatusConflict, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrPasskeyInvalid), errors.Is(err, service.ErrPasskeyCeremonyExpired):
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{"error": err.Error()})
+	default:
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+}
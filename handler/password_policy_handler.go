@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"net/http"
+
+	"hospital-platform/model"
+	"hospital-platform/service"
+	"hospital-platform/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// PasswordPolicyHandler hastane şifre politikası HTTP isteklerini yönetir
+type PasswordPolicyHandler struct {
+	policyService *service.PasswordPolicyService
+}
+
+// NewPasswordPolicyHandler yeni bir şifre politikası handler'ı oluşturur
+func NewPasswordPolicyHandler() *PasswordPolicyHandler {
+	return &PasswordPolicyHandler{
+		policyService: service.NewPasswordPolicyService(),
+	}
+}
+
+// GetPolicy hastanenin şifre politikasını getirir
+// @Summary Şifre politikası
+// @Description Hastanenin şifre kurallarını döndürür (tanımlanmamışsa varsayılan politika). Şifre belirleme ekranlarında kuralları göstermek için kullanılabilir
+// @Tags Hospital Settings
+// @Produce json
+// @Success 200 {object} model.PasswordPolicy
+// @Failure 401 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/settings/password-policy [get]
+func (h *PasswordPolicyHandler) GetPolicy(c echo.Context) error {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	policy, err := h.policyService.GetPolicy(hospitalID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data": policy,
+	})
+}
+
+// UpdatePolicy hastanenin şifre politikasını günceller
+// @Summary Şifre politikasını güncelle
+// @Description Minimum uzunluk, karakter sınıfları, yaygın şifre kontrolü, şifre geçmişi ve geçerlilik süresini ayarlar. Süresi dolan şifreler bir sonraki girişte değiştirilmelidir
+// @Tags Hospital Settings
+// @Accept json
+// @Produce json
+// @Param body body model.UpdatePasswordPolicyRequest true "Şifre politikası"
+// @Success 200 {object} model.PasswordPolicy
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/settings/password-policy [put]
+func (h *PasswordPolicyHandler) UpdatePolicy(c echo.Context) error {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	var req model.UpdatePasswordPolicyRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Geçersiz istek formatı",
+			"details": err.Error(),
+		})
+	}
+
+	policy, validationErrors, err := h.policyService.UpdatePolicy(hospitalID, &req)
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Veri doğrulama hataları",
+			"validation_errors": validationErrors,
+		})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Şifre politikası güncellendi",
+		"data":    policy,
+	})
+}
@@ -0,0 +1,990 @@
+package handler
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"hospital-platform/model"
+	"hospital-platform/service"
+	"hospital-platform/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// PlatformHandler platform operatörünün (süper admin) HTTP isteklerini yönetir
+// Bu endpoint'ler hastane token'larıyla çağrılamaz, sadece /platform/login'den alınan token geçerlidir
+type PlatformHandler struct {
+	platformService *service.PlatformService
+}
+
+// NewPlatformHandler yeni bir platform handler'ı oluşturur
+func NewPlatformHandler() *PlatformHandler {
+	return &PlatformHandler{
+		platformService: service.NewPlatformService(),
+	}
+}
+
+// ==================== GİRİŞ VE TOTP ====================
+
+// Login platform operatörü girişi
+// @Summary Platform operatörü girişi
+// @Description E-posta, şifre ve (TOTP kaydı tamamlanmışsa) authenticator kodu ile giriş. Kayıt tamamlanmamışsa dönen token sadece /platform/me/mfa endpoint'lerinde geçerlidir. Token yenilenemez, süresi dolunca tekrar giriş yapılır
+// @Tags Platform
+// @Accept json
+// @Produce json
+// @Param body body model.PlatformLoginRequest true "Giriş bilgileri"
+// @Success 200 {object} model.PlatformTokenResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 429 {object} map[string]interface{}
+// @Router /platform/login [post]
+func (h *PlatformHandler) Login(c echo.Context) error {
+	var req model.PlatformLoginRequest
+	if err := c.Bind(&req); err != nil || req.Email == "" || req.Password == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "E-posta ve şifre gerekli"})
+	}
+
+	tokens, err := h.platformService.Login(&req, utils.GetClientInfo(c))
+	if err != nil {
+		var blocked *service.LoginBlockedError
+		if errors.As(err, &blocked) {
+			c.Response().Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(blocked.RetryAfter.Seconds()))))
+			return c.JSON(http.StatusTooManyRequests, echo.Map{"error": blocked.Error()})
+		}
+		if errors.Is(err, service.ErrPlatformMFACodeRequired) {
+			return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error(), "mfa_required": true})
+		}
+		if errors.Is(err, utils.ErrHasherBusy) {
+			c.Response().Header().Set("Retry-After", "1")
+			return c.JSON(http.StatusServiceUnavailable, echo.Map{"error": "Sunucu yoğun, lütfen tekrar deneyin"})
+		}
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, tokens)
+}
+
+// StartMFAEnrollment operatör TOTP kaydını başlatır
+// @Summary Platform TOTP kaydını başlat
+// @Description Authenticator uygulamasına eklenecek secret ve QR kod üretilir. Kod /platform/me/mfa/verify ile doğrulanana kadar aktif olmaz
+// @Tags Platform
+// @Produce json
+// @Success 200 {object} model.MFAEnrollResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /platform/me/mfa/enroll [post]
+func (h *PlatformHandler) StartMFAEnrollment(c echo.Context) error {
+	actor, ok := h.getActor(c)
+	if !ok {
+		return nil
+	}
+
+	enrollment, err := h.platformService.StartMFAEnrollment(actor.AdminID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, enrollment)
+}
+
+// ConfirmMFAEnrollment operatör TOTP kaydını tamamlar
+// @Summary Platform TOTP kaydını tamamla
+// @Description İlk kod doğrulanır, TOTP aktif olur ve tüm platform endpoint'lerinde geçerli yeni token döner. Bundan sonra her girişte kod istenir
+// @Tags Platform
+// @Accept json
+// @Produce json
+// @Param body body model.MFACodeRequest true "Authenticator kodu"
+// @Success 200 {object} model.PlatformTokenResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /platform/me/mfa/verify [post]
+func (h *PlatformHandler) ConfirmMFAEnrollment(c echo.Context) error {
+	actor, ok := h.getActor(c)
+	if !ok {
+		return nil
+	}
+
+	var req model.MFACodeRequest
+	if err := c.Bind(&req); err != nil || req.Code == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Doğrulama kodu gerekli"})
+	}
+
+	tokens, err := h.platformService.ConfirmMFAEnrollment(actor, req.Code)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, tokens)
+}
+
+// ==================== HASTANELER ====================
+
+// ListHospitals hastaneleri listeler ve arar
+// @Summary Hastane listesi
+// @Description Tüm hastaneler; ad, vergi no, e-posta veya telefonda arama, durum ve il filtresi. Kullanıcı ve personel sayılarıyla sayfalı döner
+// @Tags Platform
+// @Accept json
+// @Produce json
+// @Param body body model.PlatformHospitalListRequest true "Filtreler ve sayfalama"
+// @Success 200 {object} model.PlatformHospitalListResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /platform/hospitals/list [post]
+func (h *PlatformHandler) ListHospitals(c echo.Context) error {
+	actor, ok := h.getActor(c)
+	if !ok {
+		return nil
+	}
+
+	var req model.PlatformHospitalListRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Geçersiz istek formatı",
+			"details": err.Error(),
+		})
+	}
+
+	response, validationErrors, err := h.platformService.ListHospitals(actor, &req)
+	if len(validationErrors) > 0 {
+		return validationFailed(c, validationErrors)
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetHospital hastane detayını getirir
+// @Summary Hastane detayı
+// @Description Hastane bilgileri, durumu, kullanıcı/üye/personel/poliklinik sayıları ve SSO durumu
+// @Tags Platform
+// @Produce json
+// @Param id path int true "Hastane ID"
+// @Success 200 {object} model.PlatformHospitalDetail
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /platform/hospitals/{id} [get]
+func (h *PlatformHandler) GetHospital(c echo.Context) error {
+	actor, ok := h.getActor(c)
+	if !ok {
+		return nil
+	}
+	hospitalID, ok := h.getIDParam(c, "id")
+	if !ok {
+		return nil
+	}
+
+	detail, err := h.platformService.GetHospital(actor, hospitalID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data": detail,
+	})
+}
+
+// SuspendHospital hastaneyi askıya alır
+// @Summary Hastaneyi askıya al
+// @Description Hastanenin kullanıcıları giriş yapamaz, açık token'ları ve API anahtarları bir sonraki istekte 403 alır. Veriler silinmez. Gerekçe zorunludur ve denetim kaydına yazılır
+// @Tags Platform
+// @Accept json
+// @Produce json
+// @Param id path int true "Hastane ID"
+// @Param body body model.PlatformActionRequest true "Gerekçe"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /platform/hospitals/{id}/suspend [post]
+func (h *PlatformHandler) SuspendHospital(c echo.Context) error {
+	return h.targetAction(c, h.platformService.SuspendHospital, "Hastane askıya alındı")
+}
+
+// ReactivateHospital askıdaki hastaneyi aktif eder
+// @Summary Hastaneyi tekrar aktif et
+// @Description Askıya alınmış hastanenin kullanıcıları tekrar giriş yapabilir. Gerekçe zorunludur
+// @Tags Platform
+// @Accept json
+// @Produce json
+// @Param id path int true "Hastane ID"
+// @Param body body model.PlatformActionRequest true "Gerekçe"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /platform/hospitals/{id}/reactivate [post]
+func (h *PlatformHandler) ReactivateHospital(c echo.Context) error {
+	return h.targetAction(c, h.platformService.ReactivateHospital, "Hastane tekrar aktif edildi")
+}
+
+// ApproveHospital kayıt başvurusunu onaylar
+// @Summary Hastane başvurusunu onayla
+// @Description Onay bekleyen (pending) hastane aktif olur; kullanıcıların token'ları bir sonraki istekte tüm yetkileriyle çalışır. Faaliyet izin belgesi yüklenmemişse 422. Not isteğe bağlıdır, sonuç hastanenin e-postasına bildirilir
+// @Tags Platform
+// @Accept json
+// @Produce json
+// @Param id path int true "Hastane ID"
+// @Param body body model.PlatformActionRequest false "İnceleme notu"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /platform/hospitals/{id}/approve [post]
+func (h *PlatformHandler) ApproveHospital(c echo.Context) error {
+	return h.targetAction(c, h.platformService.ApproveHospital, "Hastane başvurusu onaylandı")
+}
+
+// RejectHospital kayıt başvurusunu reddeder
+// @Summary Hastane başvurusunu reddet
+// @Description Onay bekleyen başvuru gerekçesiyle reddedilir. Başvuran gerekçeyi görür, bilgileri/belgeleri düzeltip tekrar gönderebilir. Sonuç hastanenin e-postasına bildirilir
+// @Tags Platform
+// @Accept json
+// @Produce json
+// @Param id path int true "Hastane ID"
+// @Param body body model.PlatformActionRequest true "Red gerekçesi"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /platform/hospitals/{id}/reject [post]
+func (h *PlatformHandler) RejectHospital(c echo.Context) error {
+	return h.targetAction(c, h.platformService.RejectHospital, "Hastane başvurusu reddedildi")
+}
+
+// DownloadHospitalDocument başvuru belgesini indirir
+// @Summary Başvuru belgesini indir
+// @Description Belge listesi hastane detayında döner. Her indirme denetim kaydına yazılır
+// @Tags Platform
+// @Produce application/octet-stream
+// @Param id path int true "Hastane ID"
+// @Param documentId path int true "Belge ID"
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /platform/hospitals/{id}/documents/{documentId} [get]
+func (h *PlatformHandler) DownloadHospitalDocument(c echo.Context) error {
+	actor, ok := h.getActor(c)
+	if !ok {
+		return nil
+	}
+	hospitalID, ok := h.getIDParam(c, "id")
+	if !ok {
+		return nil
+	}
+	documentID, ok := h.getIDParam(c, "documentId")
+	if !ok {
+		return nil
+	}
+
+	document, err := h.platformService.GetHospitalDocument(actor, hospitalID, documentID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return sendDocument(c, document)
+}
+
+// ==================== KULLANICI DESTEĞİ ====================
+
+// SearchUsers tüm hastanelerde kullanıcı arar
+// @Summary Kullanıcı ara
+// @Description E-posta, telefon, TC veya ad soyad ile tüm hastanelerde arama (en az 3 karakter). Arama denetim kaydına yazılır
+// @Tags Platform
+// @Accept json
+// @Produce json
+// @Param body body model.PlatformUserSearchRequest true "Arama ve sayfalama"
+// @Success 200 {object} model.PlatformUserListResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /platform/users/search [post]
+func (h *PlatformHandler) SearchUsers(c echo.Context) error {
+	actor, ok := h.getActor(c)
+	if !ok {
+		return nil
+	}
+
+	var req model.PlatformUserSearchRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Geçersiz istek formatı",
+			"details": err.Error(),
+		})
+	}
+
+	response, validationErrors, err := h.platformService.SearchUsers(actor, &req)
+	if len(validationErrors) > 0 {
+		return validationFailed(c, validationErrors)
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// UnlockUser kullanıcının giriş kilidini kaldırır
+// @Summary Kullanıcı kilidini aç
+// @Description Hatalı denemeler nedeniyle oluşan giriş ve şifre sıfırlama kilitleri kaldırılır
+// @Tags Platform
+// @Accept json
+// @Produce json
+// @Param id path int true "Kullanıcı ID"
+// @Param body body model.PlatformActionRequest true "Gerekçe (ör. destek talebi no)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /platform/users/{id}/unlock [post]
+func (h *PlatformHandler) UnlockUser(c echo.Context) error {
+	return h.targetAction(c, h.platformService.UnlockUser, "Kullanıcı kilidi kaldırıldı")
+}
+
+// ChangeUserStatus kullanıcının hesap durumunu değiştirir
+// @Summary Kullanıcı durumunu değiştir
+// @Description Kullanıcı askıya alınır (suspended), kilitlenir (locked), onaya çekilir (pending) veya tekrar aktif edilir (active). active dışındaki durumlarda tüm oturumlar kapatılır. Gerekçe her geçişte zorunludur
+// @Tags Platform
+// @Accept json
+// @Produce json
+// @Param id path int true "Kullanıcı ID"
+// @Param body body model.ChangeUserStatusRequest true "Yeni durum ve gerekçe"
+// @Success 200 {object} model.UserStatusResponse
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /platform/users/{id}/status [put]
+func (h *PlatformHandler) ChangeUserStatus(c echo.Context) error {
+	actor, ok := h.getActor(c)
+	if !ok {
+		return nil
+	}
+	id, ok := h.getIDParam(c, "id")
+	if !ok {
+		return nil
+	}
+
+	var req model.ChangeUserStatusRequest
+	if !bindRequest(c, &req) {
+		return nil
+	}
+
+	response, validationErrors, err := h.platformService.ChangeUserStatus(actor, id, &req)
+	if len(validationErrors) > 0 {
+		return validationFailed(c, validationErrors)
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// ResetUserMFA kullanıcının iki adımlı doğrulamasını sıfırlar
+// @Summary Kullanıcı MFA sıfırla
+// @Description TOTP kaydı ve kurtarma kodları silinir, tüm oturumlar kapatılır. Kullanıcı bir sonraki girişte yeniden kayıt yapar. Kimlik doğrulaması destek sürecinde yapılmış olmalıdır
+// @Tags Platform
+// @Accept json
+// @Produce json
+// @Param id path int true "Kullanıcı ID"
+// @Param body body model.PlatformActionRequest true "Gerekçe"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /platform/users/{id}/reset-mfa [post]
+func (h *PlatformHandler) ResetUserMFA(c echo.Context) error {
+	return h.targetAction(c, h.platformService.ResetUserMFA, "İki adımlı doğrulama sıfırlandı")
+}
+
+// RevokeUserSessions kullanıcının tüm oturumlarını sonlandırır
+// @Summary Kullanıcı oturumlarını sonlandır
+// @Description Tüm cihazlardaki access ve refresh token'lar anında geçersiz olur
+// @Tags Platform
+// @Accept json
+// @Produce json
+// @Param id path int true "Kullanıcı ID"
+// @Param body body model.PlatformActionRequest true "Gerekçe"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /platform/users/{id}/revoke-sessions [post]
+func (h *PlatformHandler) RevokeUserSessions(c echo.Context) error {
+	return h.targetAction(c, h.platformService.RevokeUserSessions, "Kullanıcının tüm oturumları sonlandırıldı")
+}
+
+// ExpireUserPassword kullanıcıdan bir sonraki girişte şifre değişikliği ister
+// @Summary Şifre değişikliği iste
+// @Description Kullanıcı bir sonraki girişte şifresini değiştirmeden token alamaz; mevcut oturumlar etkilenmez
+// @Tags Platform
+// @Accept json
+// @Produce json
+// @Param id path int true "Kullanıcı ID"
+// @Param body body model.PlatformActionRequest true "Gerekçe"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /platform/users/{id}/expire-password [post]
+func (h *PlatformHandler) ExpireUserPassword(c echo.Context) error {
+	return h.targetAction(c, h.platformService.ExpireUserPassword, "Kullanıcıdan şifre değişikliği istendi")
+}
+
+// ==================== MASTER DATA ====================
+
+// CreateProvince il ekler
+// @Summary İl ekle
+// @Tags Platform Master Data
+// @Accept json
+// @Produce json
+// @Param body body model.ProvinceRequest true "İl"
+// @Success 201 {object} model.Province
+// @Failure 401 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /platform/master-data/provinces [post]
+func (h *PlatformHandler) CreateProvince(c echo.Context) error {
+	actor, ok := h.getActor(c)
+	if !ok {
+		return nil
+	}
+	var req model.ProvinceRequest
+	if !bindRequest(c, &req) {
+		return nil
+	}
+	province, validationErrors, err := h.platformService.CreateProvince(actor, &req)
+	return h.masterDataResult(c, http.StatusCreated, province, validationErrors, err)
+}
+
+// UpdateProvince il adını günceller
+// @Summary İl güncelle
+// @Tags Platform Master Data
+// @Accept json
+// @Produce json
+// @Param id path int true "İl ID"
+// @Param body body model.ProvinceRequest true "İl"
+// @Success 200 {object} model.Province
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /platform/master-data/provinces/{id} [put]
+func (h *PlatformHandler) UpdateProvince(c echo.Context) error {
+	actor, ok := h.getActor(c)
+	if !ok {
+		return nil
+	}
+	id, ok := h.getIDParam(c, "id")
+	if !ok {
+		return nil
+	}
+	var req model.ProvinceRequest
+	if !bindRequest(c, &req) {
+		return nil
+	}
+	province, validationErrors, err := h.platformService.UpdateProvince(actor, id, &req)
+	return h.masterDataResult(c, http.StatusOK, province, validationErrors, err)
+}
+
+// DeleteProvince kullanılmayan ili siler
+// @Summary İl sil
+// @Description İlçesi veya hastanesi olan il silinemez
+// @Tags Platform Master Data
+// @Produce json
+// @Param id path int true "İl ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /platform/master-data/provinces/{id} [delete]
+func (h *PlatformHandler) DeleteProvince(c echo.Context) error {
+	return h.deleteMasterData(c, model.PlatformTargetProvince)
+}
+
+// CreateDistrict ilçe ekler
+// @Summary İlçe ekle
+// @Tags Platform Master Data
+// @Accept json
+// @Produce json
+// @Param body body model.DistrictRequest true "İlçe"
+// @Success 201 {object} model.District
+// @Failure 401 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /platform/master-data/districts [post]
+func (h *PlatformHandler) CreateDistrict(c echo.Context) error {
+	actor, ok := h.getActor(c)
+	if !ok {
+		return nil
+	}
+	var req model.DistrictRequest
+	if !bindRequest(c, &req) {
+		return nil
+	}
+	district, validationErrors, err := h.platformService.CreateDistrict(actor, &req)
+	return h.masterDataResult(c, http.StatusCreated, district, validationErrors, err)
+}
+
+// UpdateDistrict ilçeyi günceller
+// @Summary İlçe güncelle
+// @Tags Platform Master Data
+// @Accept json
+// @Produce json
+// @Param id path int true "İlçe ID"
+// @Param body body model.DistrictRequest true "İlçe"
+// @Success 200 {object} model.District
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /platform/master-data/districts/{id} [put]
+func (h *PlatformHandler) UpdateDistrict(c echo.Context) error {
+	actor, ok := h.getActor(c)
+	if !ok {
+		return nil
+	}
+	id, ok := h.getIDParam(c, "id")
+	if !ok {
+		return nil
+	}
+	var req model.DistrictRequest
+	if !bindRequest(c, &req) {
+		return nil
+	}
+	district, validationErrors, err := h.platformService.UpdateDistrict(actor, id, &req)
+	return h.masterDataResult(c, http.StatusOK, district, validationErrors, err)
+}
+
+// DeleteDistrict kullanılmayan ilçeyi siler
+// @Summary İlçe sil
+// @Description Hastanesi olan ilçe silinemez
+// @Tags Platform Master Data
+// @Produce json
+// @Param id path int true "İlçe ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /platform/master-data/districts/{id} [delete]
+func (h *PlatformHandler) DeleteDistrict(c echo.Context) error {
+	return h.deleteMasterData(c, model.PlatformTargetDistrict)
+}
+
+// CreateJobGroup meslek grubu ekler
+// @Summary Meslek grubu ekle
+// @Tags Platform Master Data
+// @Accept json
+// @Produce json
+// @Param body body model.JobGroupRequest true "Meslek grubu"
+// @Success 201 {object} model.JobGroup
+// @Failure 401 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /platform/master-data/job-groups [post]
+func (h *PlatformHandler) CreateJobGroup(c echo.Context) error {
+	actor, ok := h.getActor(c)
+	if !ok {
+		return nil
+	}
+	var req model.JobGroupRequest
+	if !bindRequest(c, &req) {
+		return nil
+	}
+	jobGroup, validationErrors, err := h.platformService.CreateJobGroup(actor, &req)
+	return h.masterDataResult(c, http.StatusCreated, jobGroup, validationErrors, err)
+}
+
+// UpdateJobGroup meslek grubunu günceller
+// @Summary Meslek grubu güncelle
+// @Tags Platform Master Data
+// @Accept json
+// @Produce json
+// @Param id path int true "Meslek grubu ID"
+// @Param body body model.JobGroupRequest true "Meslek grubu"
+// @Success 200 {object} model.JobGroup
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /platform/master-data/job-groups/{id} [put]
+func (h *PlatformHandler) UpdateJobGroup(c echo.Context) error {
+	actor, ok := h.getActor(c)
+	if !ok {
+		return nil
+	}
+	id, ok := h.getIDParam(c, "id")
+	if !ok {
+		return nil
+	}
+	var req model.JobGroupRequest
+	if !bindRequest(c, &req) {
+		return nil
+	}
+	jobGroup, validationErrors, err := h.platformService.UpdateJobGroup(actor, id, &req)
+	return h.masterDataResult(c, http.StatusOK, jobGroup, validationErrors, err)
+}
+
+// DeleteJobGroup kullanılmayan meslek grubunu siler
+// @Summary Meslek grubu sil
+// @Description Unvanı veya personeli olan grup silinemez
+// @Tags Platform Master Data
+// @Produce json
+// @Param id path int true "Meslek grubu ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /platform/master-data/job-groups/{id} [delete]
+func (h *PlatformHandler) DeleteJobGroup(c echo.Context) error {
+	return h.deleteMasterData(c, model.PlatformTargetJobGroup)
+}
+
+// CreateJobTitle unvan ekler
+// @Summary Unvan ekle
+// @Tags Platform Master Data
+// @Accept json
+// @Produce json
+// @Param body body model.JobTitleRequest true "Unvan"
+// @Success 201 {object} model.JobTitle
+// @Failure 401 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /platform/master-data/job-titles [post]
+func (h *PlatformHandler) CreateJobTitle(c echo.Context) error {
+	actor, ok := h.getActor(c)
+	if !ok {
+		return nil
+	}
+	var req model.JobTitleRequest
+	if !bindRequest(c, &req) {
+		return nil
+	}
+	jobTitle, validationErrors, err := h.platformService.CreateJobTitle(actor, &req)
+	return h.masterDataResult(c, http.StatusCreated, jobTitle, validationErrors, err)
+}
+
+// UpdateJobTitle unvanı günceller
+// @Summary Unvan güncelle
+// @Tags Platform Master Data
+// @Accept json
+// @Produce json
+// @Param id path int true "Unvan ID"
+// @Param body body model.JobTitleRequest true "Unvan"
+// @Success 200 {object} model.JobTitle
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /platform/master-data/job-titles/{id} [put]
+func (h *PlatformHandler) UpdateJobTitle(c echo.Context) error {
+	actor, ok := h.getActor(c)
+	if !ok {
+		return nil
+	}
+	id, ok := h.getIDParam(c, "id")
+	if !ok {
+		return nil
+	}
+	var req model.JobTitleRequest
+	if !bindRequest(c, &req) {
+		return nil
+	}
+	jobTitle, validationErrors, err := h.platformService.UpdateJobTitle(actor, id, &req)
+	return h.masterDataResult(c, http.StatusOK, jobTitle, validationErrors, err)
+}
+
+// DeleteJobTitle kullanılmayan unvanı siler
+// @Summary Unvan sil
+// @Description Personeli olan unvan silinemez
+// @Tags Platform Master Data
+// @Produce json
+// @Param id path int true "Unvan ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /platform/master-data/job-titles/{id} [delete]
+func (h *PlatformHandler) DeleteJobTitle(c echo.Context) error {
+	return h.deleteMasterData(c, model.PlatformTargetJobTitle)
+}
+
+// CreatePolyclinicType poliklinik türü ekler
+// @Summary Poliklinik türü ekle
+// @Tags Platform Master Data
+// @Accept json
+// @Produce json
+// @Param body body model.PolyclinicTypeRequest true "Poliklinik türü"
+// @Success 201 {object} model.PolyclinicType
+// @Failure 401 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /platform/master-data/polyclinic-types [post]
+func (h *PlatformHandler) CreatePolyclinicType(c echo.Context) error {
+	actor, ok := h.getActor(c)
+	if !ok {
+		return nil
+	}
+	var req model.PolyclinicTypeRequest
+	if !bindRequest(c, &req) {
+		return nil
+	}
+	polyclinicType, validationErrors, err := h.platformService.CreatePolyclinicType(actor, &req)
+	return h.masterDataResult(c, http.StatusCreated, polyclinicType, validationErrors, err)
+}
+
+// UpdatePolyclinicType poliklinik türünü günceller
+// @Summary Poliklinik türü güncelle
+// @Tags Platform Master Data
+// @Accept json
+// @Produce json
+// @Param id path int true "Poliklinik türü ID"
+// @Param body body model.PolyclinicTypeRequest true "Poliklinik türü"
+// @Success 200 {object} model.PolyclinicType
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /platform/master-data/polyclinic-types/{id} [put]
+func (h *PlatformHandler) UpdatePolyclinicType(c echo.Context) error {
+	actor, ok := h.getActor(c)
+	if !ok {
+		return nil
+	}
+	id, ok := h.getIDParam(c, "id")
+	if !ok {
+		return nil
+	}
+	var req model.PolyclinicTypeRequest
+	if !bindRequest(c, &req) {
+		return nil
+	}
+	polyclinicType, validationErrors, err := h.platformService.UpdatePolyclinicType(actor, id, &req)
+	return h.masterDataResult(c, http.StatusOK, polyclinicType, validationErrors, err)
+}
+
+// DeletePolyclinicType kullanılmayan poliklinik türünü siler
+// @Summary Poliklinik türü sil
+// @Description Herhangi bir hastanede açılmış poliklinik türü silinemez
+// @Tags Platform Master Data
+// @Produce json
+// @Param id path int true "Poliklinik türü ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /platform/master-data/polyclinic-types/{id} [delete]
+func (h *PlatformHandler) DeletePolyclinicType(c echo.Context) error {
+	return h.deleteMasterData(c, model.PlatformTargetPolyclinicType)
+}
+
+// ==================== DENETİM KAYDI ====================
+
+// ListAuditLogs platform denetim kayıtlarını listeler
+// @Summary Platform denetim kayıtları
+// @Description Operatör işlemleri (giriş, hastane görüntüleme/askıya alma, kullanıcı desteği, master data değişiklikleri) yeniden eskiye. Operatör, işlem, hedef, hastane ve tarih aralığı ile filtrelenir
+// @Tags Platform
+// @Accept json
+// @Produce json
+// @Param body body model.PlatformAuditLogListRequest true "Filtreler ve sayfalama"
+// @Success 200 {object} model.PlatformAuditLogListResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /platform/audit-logs/list [post]
+func (h *PlatformHandler) ListAuditLogs(c echo.Context) error {
+	if _, ok := h.getActor(c); !ok {
+		return nil
+	}
+
+	var req model.PlatformAuditLogListRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Geçersiz istek formatı",
+			"details": err.Error(),
+		})
+	}
+
+	response, validationErrors, err := h.platformService.ListAuditLogs(&req)
+	if len(validationErrors) > 0 {
+		return validationFailed(c, validationErrors)
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// ==================== HELPER METHODS ====================
+
+// targetAction path'teki ID ve gövdedeki gerekçe ile işlemi çalıştırır
+func (h *PlatformHandler) targetAction(c echo.Context, action func(*service.PlatformActor, uint, string) ([]model.ValidationError, error), message string) error {
+	actor, ok := h.getActor(c)
+	if !ok {
+		return nil
+	}
+	id, ok := h.getIDParam(c, "id")
+	if !ok {
+		return nil
+	}
+
+	var req model.PlatformActionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Geçersiz istek formatı",
+			"details": err.Error(),
+		})
+	}
+
+	validationErrors, err := action(actor, id, req.Reason)
+	if len(validationErrors) > 0 {
+		return validationFailed(c, validationErrors)
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": message,
+	})
+}
+
+// deleteMasterData path'teki ID ile master data kaydını siler
+func (h *PlatformHandler) deleteMasterData(c echo.Context, targetType string) error {
+	actor, ok := h.getActor(c)
+	if !ok {
+		return nil
+	}
+	id, ok := h.getIDParam(c, "id")
+	if !ok {
+		return nil
+	}
+
+	if err := h.platformService.DeleteMasterData(actor, targetType, id); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Kayıt silindi",
+	})
+}
+
+// masterDataResult master data oluşturma/güncelleme sonucunu cevaba çevirir
+func (h *PlatformHandler) masterDataResult(c echo.Context, status int, record interface{}, validationErrors []model.ValidationError, err error) error {
+	if len(validationErrors) > 0 {
+		return validationFailed(c, validationErrors)
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(status, echo.Map{
+		"message": "Kayıt kaydedildi",
+		"data":    record,
+	})
+}
+
+// getActor context'teki operatörü ve istemci bilgisini döndürür
+// Hata durumunda cevabı kendisi yazar ve ok=false döner
+func (h *PlatformHandler) getActor(c echo.Context) (*service.PlatformActor, bool) {
+	adminID, email, ok := utils.GetPlatformAdminFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+		return nil, false
+	}
+	return &service.PlatformActor{
+		AdminID: adminID,
+		Email:   email,
+		Client:  utils.GetClientInfo(c),
+	}, true
+}
+
+// getIDParam path parametresini ID olarak okur
+// Hata durumunda cevabı kendisi yazar ve ok=false döner
+func (h *PlatformHandler) getIDParam(c echo.Context, name string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(name), 10, 32)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, echo.Map{"error": "Geçersiz ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// handleError servis hatalarını uygun HTTP durum kodlarına çevirir
+func (h *PlatformHandler) handleError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrPlatformInvalidCredentials), errors.Is(err, service.ErrInvalidMFACode):
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrPlatformHospitalNotFound),
+		errors.Is(err, service.ErrPlatformUserNotFound),
+		errors.Is(err, service.ErrMasterDataNotFound),
+		errors.Is(err, service.ErrDocumentNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrHospitalStatusUnchanged),
+		errors.Is(err, service.ErrHospitalStatusTransition),
+		errors.Is(err, service.ErrMasterDataInUse),
+		errors.Is(err, service.ErrUserStatusConflict):
+		return c.JSON(http.StatusConflict, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrMFAAlreadyEnabled), errors.Is(err, service.ErrMFANotEnrolled):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	default:
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+}
+
+// bindRequest istek gövdesini okur, hata durumunda cevabı kendisi yazar
+func bindRequest(c echo.Context, req interface{}) bool {
+	if err := c.Bind(req); err != nil {
+		c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Geçersiz istek formatı",
+			"details": err.Error(),
+		})
+		return false
+	}
+	return true
+}
+
+// validationFailed servis doğrulama hatalarını 422 olarak döndürür
+func validationFailed(c echo.Context, validationErrors []model.ValidationError) error {
+	return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+		"error":             "Veri doğrulama hataları",
+		"validation_errors": validationErrors,
+	})
+}
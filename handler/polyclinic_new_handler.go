@@ -55,6 +55,7 @@ func (h *PolyclinicNewHandler) GetPolyclinicTypes(c echo.Context) error {
 // @Failure 400 {object} map[string]interface{}
 // @Failure 401 {object} map[string]interface{}
 // @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /hospital/polyclinics [post]
 func (h *PolyclinicNewHandler) AddPolyclinicToHospital(c echo.Context) error {
 	// JWT token'dan hospital ID al
@@ -102,6 +103,7 @@ func (h *PolyclinicNewHandler) AddPolyclinicToHospital(c echo.Context) error {
 // @Failure 401 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /hospital/polyclinics [get]
 func (h *PolyclinicNewHandler) GetHospitalPolyclinics(c echo.Context) error {
 	// JWT token'dan hospital ID al
@@ -135,7 +137,9 @@ func (h *PolyclinicNewHandler) GetHospitalPolyclinics(c echo.Context) error {
 // @Success 200 {object} model.HospitalPolyclinic
 // @Failure 400 {object} map[string]interface{}
 // @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
 // @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /hospital/polyclinics/{id} [put]
 func (h *PolyclinicNewHandler) UpdateHospitalPolyclinic(c echo.Context) error {
 	// JWT token'dan hospital ID al
@@ -155,6 +159,16 @@ func (h *PolyclinicNewHandler) UpdateHospitalPolyclinic(c echo.Context) error {
 		})
 	}
 
+	// polyclinics:write yoksa (polyclinics:write:own) sadece kullanıcının bağlı olduğu poliklinik güncellenebilir
+	if !utils.HasPermission(c, model.PermPolyclinicsWrite) {
+		userID, _ := utils.GetUserIDFromContext(c)
+		if !h.polyclinicService.IsUserPolyclinic(userID, hospitalID, uint(id)) {
+			return c.JSON(http.StatusForbidden, echo.Map{
+				"error": "Sadece bağlı olduğunuz polikliniği güncelleyebilirsiniz",
+			})
+		}
+	}
+
 	var req model.UpdatePolyclinicRequest
 	if err := c.Bind(&req); err != nil {
 		return c.JSON(http.StatusBadRequest, echo.Map{
@@ -186,6 +200,7 @@ func (h *PolyclinicNewHandler) UpdateHospitalPolyclinic(c echo.Context) error {
 // @Failure 400 {object} map[string]interface{}
 // @Failure 401 {object} map[string]interface{}
 // @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /hospital/polyclinics/{id} [delete]
 func (h *PolyclinicNewHandler) DeleteHospitalPolyclinic(c echo.Context) error {
 	// JWT token'dan hospital ID al
@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"hospital-platform/model"
+	"hospital-platform/service"
+	"hospital-platform/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ProfileHandler giriş yapmış kullanıcının kendi profil ve şifre isteklerini yönetir
+type ProfileHandler struct {
+	profileService *service.ProfileService
+}
+
+// NewProfileHandler yeni bir profil handler'ı oluşturur
+func NewProfileHandler() *ProfileHandler {
+	return &ProfileHandler{
+		profileService: service.NewProfileService(),
+	}
+}
+
+// GetProfile giriş yapan kullanıcının profilini döndürür
+// @Summary Profilim
+// @Description Ad, soyad, iletişim bilgileri, doğrulama durumları ve aktif hastanedeki rol. TC kimlik numarası maskelenir
+// @Tags Profile
+// @Produce json
+// @Success 200 {object} model.ProfileResponse
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /me [get]
+func (h *ProfileHandler) GetProfile(c echo.Context) error {
+	userID, hospitalID, ok := h.getCurrent(c)
+	if !ok {
+		return nil
+	}
+
+	profile, err := h.profileService.GetProfile(userID, hospitalID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, profile)
+}
+
+// UpdateProfile ad, soyad, e-posta ve telefonu günceller
+// @Summary Profili güncelle
+// @Description Ad ve soyad serbestçe değişir. E-posta veya telefon değişiyorsa önce /me/contact/verify ile yeni adrese kod gönderilir ve kod email_code/phone_code alanında iletilir. Değişiklik eski adrese bildirilir
+// @Tags Profile
+// @Accept json
+// @Produce json
+// @Param request body model.UpdateProfileRequest true "Profil bilgileri"
+// @Success 200 {object} model.ProfileResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /me [put]
+func (h *ProfileHandler) UpdateProfile(c echo.Context) error {
+	userID, hospitalID, ok := h.getCurrent(c)
+	if !ok {
+		return nil
+	}
+
+	var req model.UpdateProfileRequest
+	if !bindRequest(c, &req) {
+		return nil
+	}
+	req.Lang = service.ResolveLanguage(req.Lang, c.Request().Header.Get("Accept-Language"))
+
+	profile, validationErrors, err := h.profileService.UpdateProfile(userID, hospitalID, &req)
+	if len(validationErrors) > 0 {
+		return validationFailed(c, validationErrors)
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, profile)
+}
+
+// SendContactCode yeni e-posta veya telefona doğrulama kodu gönderir
+// @Summary İletişim bilgisi doğrulama kodu gönder
+// @Description Yeni e-posta adresine (channel=email) veya telefon numarasına (channel=sms) doğrulama kodu gönderilir. Adres başka bir hesapta kullanılıyorsa 422 döner
+// @Tags Profile
+// @Accept json
+// @Produce json
+// @Param request body model.ContactVerificationRequest true "Kanal ve yeni adres"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Failure 429 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /me/contact/verify [post]
+func (h *ProfileHandler) SendContactCode(c echo.Context) error {
+	userID, _, ok := h.getCurrent(c)
+	if !ok {
+		return nil
+	}
+
+	var req model.ContactVerificationRequest
+	if !bindRequest(c, &req) {
+		return nil
+	}
+	lang := service.ResolveLanguage(req.Lang, c.Request().Header.Get("Accept-Language"))
+
+	validationErrors, err := h.profileService.SendContactCode(userID, &req, lang)
+	if len(validationErrors) > 0 {
+		return validationFailed(c, validationErrors)
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Doğrulama kodu gönderildi",
+	})
+}
+
+// ChangePassword mevcut şifreyle yeni şifre belirler
+// @Summary Şifre değiştir
+// @Description Mevcut şifre doğrulanır, yeni şifre hastanenin şifre politikasına göre kontrol edilir. İsteği yapan oturum dışındaki tüm oturumlar sonlandırılır. 15 dakikada 5 hatalı mevcut şifre denemesinden sonra 429 döner
+// @Tags Profile
+// @Accept json
+// @Produce json
+// @Param request body model.ChangePasswordRequest true "Mevcut ve yeni şifre"
+// @Success 200 {object} model.ChangePasswordResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Failure 429 {object} map[string]interface{}
+// @Failure 503 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /me/password [post]
+func (h *ProfileHandler) ChangePassword(c echo.Context) error {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+	}
+	familyID, ok := utils.GetTokenFamilyFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+	}
+
+	var req model.ChangePasswordRequest
+	if !bindRequest(c, &req) {
+		return nil
+	}
+	if req.CurrentPassword == "" || req.NewPassword == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Mevcut ve yeni şifre zorunludur"})
+	}
+
+	revoked, validationErrors, err := h.profileService.ChangePassword(userID, familyID, &req)
+	if len(validationErrors) > 0 {
+		return validationFailed(c, validationErrors)
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, model.ChangePasswordResponse{
+		Message:         "Şifreniz değiştirildi",
+		RevokedSessions: revoked,
+	})
+}
+
+// ==================== HELPER METHODS ====================
+
+// getCurrent token'dan kullanıcı ve aktif hastane ID'sini alır
+// Hata durumunda cevabı kendisi yazar ve ok=false döner
+func (h *ProfileHandler) getCurrent(c echo.Context) (uint, uint, bool) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+		return 0, 0, false
+	}
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+		return 0, 0, false
+	}
+	return userID, hospitalID, true
+}
+
+// handleError servis hatalarını uygun HTTP durum kodlarına çevirir
+func (h *ProfileHandler) handleError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrProfileNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrCurrentPasswordInvalid):
+		return validationFailed(c, []model.ValidationError{{Field: "current_password", Message: err.Error()}})
+	case errors.Is(err, service.ErrNoLocalPassword):
+		return c.JSON(http.StatusConflict, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrVerificationCodeInvalid), errors.Is(err, service.ErrVerificationCodeExhausted):
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrTooManyVerificationCodes), errors.Is(err, service.ErrTooManyPasswordAttempts):
+		return c.JSON(http.StatusTooManyRequests, echo.Map{"error": err.Error()})
+	case errors.Is(err, utils.ErrHasherBusy):
+		c.Response().Header().Set("Retry-After", "1")
+		return c.JSON(http.StatusServiceUnavailable, echo.Map{"error": "Sunucu yoğun, lütfen tekrar deneyin"})
+	default:
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+}
@@ -1,13 +1,13 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
-	"math/rand"
 	"net/http"
 
 	"hospital-platform/database"
 	"hospital-platform/model"
-	"hospital-platform/repository"
+	"hospital-platform/service"
 	"hospital-platform/utils"
 
 	"github.com/labstack/echo/v4"
@@ -15,51 +15,41 @@ import (
 
 // ResetPasswordRequest godoc
 // @Summary Şifre sıfırlama kodu gönder
-// @Description Telefon numarasına geçici şifre sıfırlama kodu üretir
+// @Description Telefon numarasına kayıtlı kullanıcıya SMS (varsayılan) veya e-posta ile tek kullanımlık kod gönderir. Kod yanıtta dönmez; numara kayıtlı olmasa da aynı yanıt döner
 // @Tags Auth
 // @Accept json
 // @Produce json
-// @Param request body model.ResetPasswordRequest true "Telefon numarası"
+// @Param request body model.ResetPasswordRequest true "Telefon numarası, kanal ve dil"
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]interface{}
+// @Failure 429 {object} map[string]interface{}
 // @Router /reset-password/request [post]
-// Şifre sıfırlama isteği: Telefon numarasına geçici kod üret
+// Şifre sıfırlama isteği: Telefon numarasına kayıtlı kullanıcıya geçici kod gönder
 func ResetPasswordRequestHandler(c echo.Context) error {
-	var request struct {
-		Phone string `json:"phone"`
-	}
-	if err := c.Bind(&request); err != nil {
+	var request model.ResetPasswordRequest
+	if err := c.Bind(&request); err != nil || request.Phone == "" {
 		fmt.Println("Bind hatası:", err)
 		return c.JSON(http.StatusBadRequest, echo.Map{"message": "Geçersiz istek"})
 	}
 
-	// Kullanıcı veritabanında var mı?
-	user, err := repository.NewUserRepository().GetByPhone(request.Phone)
-	if err != nil {
-		fmt.Println("Kullanıcı sorgusu hatası:", err)
-		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Sunucu hatası"})
-	}
-	if user == nil {
-		fmt.Println("Telefon numarası sistemde kayıtlı değil:", request.Phone)
-		return c.JSON(http.StatusBadRequest, echo.Map{"message": "Telefon numarası bulunamadı"})
+	if request.Channel == "" {
+		request.Channel = utils.CHANNEL_SMS
 	}
-
-	// 6 haneli kod üret
-	code := fmt.Sprintf("%06d", rand.Intn(1000000))
-
-	// Redis'e kaydet (key: phone, value: code)
-	err = database.SetResetCode(request.Phone, code)
-	if err != nil {
-		fmt.Println("Redis'e kod yazılamadı:", err)
-		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Kod üretilemedi"})
+	lang := service.ResolveLanguage(request.Lang, c.Request().Header.Get("Accept-Language"))
+
+	if err := service.NewPasswordResetService().SendCode(request.Phone, request.Channel, lang); err != nil {
+		fmt.Println("Kod gönderilemedi:", err)
+		if errors.Is(err, service.ErrTooManyResetCodes) {
+			return c.JSON(http.StatusTooManyRequests, echo.Map{"message": err.Error()})
+		}
+		if errors.Is(err, utils.ErrUnknownChannel) {
+			return c.JSON(http.StatusBadRequest, echo.Map{"message": "Geçersiz kanal, sms veya email olmalı"})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Kod gönderilemedi"})
 	}
 
-	fmt.Printf("Şifre sıfırlama kodu gönderildi: %s -> %s\n", request.Phone, code)
-
-	// Kullanıcıya kod üretildi mesajı
 	return c.JSON(http.StatusOK, echo.Map{
-		"message": "Kod üretildi",
-		"code":    code, // Gerçekte SMS gönderilir ama test için döndük
+		"message": "Numara kayıtlıysa doğrulama kodu gönderildi",
 	})
 }
 
@@ -72,6 +62,8 @@ func ResetPasswordRequestHandler(c echo.Context) error {
 // @Param request body model.ResetPasswordConfirm true "Yeni şifre bilgileri"
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]interface{}
+// @Failure 429 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
 // @Router /reset-password/confirm [post]
 func ResetPasswordConfirm(c echo.Context) error {
 	var request model.ResetPasswordConfirm
@@ -80,17 +72,18 @@ func ResetPasswordConfirm(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, echo.Map{"message": "Geçersiz veri"})
 	}
 
-	// Redis’ten kodu al
-	storedCode, err := database.RedisClient.Get(database.Ctx, request.Phone).Result()
-	if err != nil {
-		return c.JSON(http.StatusBadRequest, echo.Map{"message": "Kod süresi dolmuş veya bulunamadı"})
-	}
-
-	fmt.Println("✔️ Beklenen kod:", storedCode)
-	fmt.Println("📲 Girilen kod:", request.Code)
-
-	if request.Code != storedCode {
-		return c.JSON(http.StatusBadRequest, echo.Map{"message": "Kod eşleşmiyor"})
+	resetService := service.NewPasswordResetService()
+
+	// Kodu doğrula - hatalı denemeler sayılır, limit dolunca kod iptal edilir
+	if err := resetService.VerifyCode(request.Phone, request.Code); err != nil {
+		switch {
+		case errors.Is(err, service.ErrResetCodeExhausted):
+			return c.JSON(http.StatusTooManyRequests, echo.Map{"message": err.Error()})
+		case errors.Is(err, service.ErrResetCodeNotFound), errors.Is(err, service.ErrResetCodeMismatch):
+			return c.JSON(http.StatusBadRequest, echo.Map{"message": err.Error()})
+		default:
+			return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Sunucu hatası"})
+		}
 	}
 
 	if request.NewPassword != request.ConfirmPassword {
@@ -103,19 +96,32 @@ func ResetPasswordConfirm(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, echo.Map{"message": "Kullanıcı bulunamadı"})
 	}
 
-	// Yeni şifreyi hashle
-	hashed, err := utils.HashPassword(request.NewPassword)
+	// Yeni şifre hastanenin politikasına uymalı (uzunluk, karakterler, yaygın şifreler, geçmiş)
+	policyService := service.NewPasswordPolicyService()
+	validationErrors, err := policyService.ValidatePassword(user.HospitalID, &user, "new_password", request.NewPassword)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Hashleme hatası"})
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Şifre doğrulanamadı"})
+	}
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"message":           "Şifre politikaya uymuyor",
+			"validation_errors": validationErrors,
+		})
 	}
 
-	user.Password = hashed
-	if err := database.DB.Save(&user).Error; err != nil {
+	// Yeni şifreyi hashleyip kaydet (eski şifre geçmişe eklenir)
+	if err := policyService.SetPassword(&user, request.NewPassword); err != nil {
 		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Şifre güncellenemedi"})
 	}
 
-	// Kod Redis’ten silinir
-	database.RedisClient.Del(database.Ctx, request.Phone)
+	// Şifreyi ele geçirip sıfırlamaya sebep olan oturumlar dahil tüm cihazlardan çıkış yapılır
+	if err := service.NewTokenService().RevokeAllForUser(user.ID); err != nil {
+		fmt.Println("Oturumlar sonlandırılamadı:", err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Şifre güncellendi ancak açık oturumlar sonlandırılamadı, tekrar deneyin"})
+	}
+
+	// Kod tek kullanımlık - silinir
+	resetService.ConsumeCode(request.Phone)
 
 	fmt.Println("🔐 Şifre başarıyla güncellendi")
 	return c.JSON(http.StatusOK, echo.Map{"message": "Şifre başarıyla güncellendi"})
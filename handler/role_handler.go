@@ -0,0 +1,240 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"hospital-platform/model"
+	"hospital-platform/service"
+	"hospital-platform/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RoleHandler rol ve yetki yönetimi HTTP isteklerini yönetir
+type RoleHandler struct {
+	roleService *service.RoleService
+}
+
+// NewRoleHandler yeni bir rol handler'ı oluşturur
+func NewRoleHandler() *RoleHandler {
+	return &RoleHandler{
+		roleService: service.NewRoleService(),
+	}
+}
+
+// GetPermissions tanımlı yetkileri listeler
+// @Summary Yetki listesi
+// @Description Rollere atanabilecek tüm yetkileri listeler
+// @Tags Role Management
+// @Produce json
+// @Success 200 {array} model.PermissionInfo
+// @Failure 401 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/permissions [get]
+func (h *RoleHandler) GetPermissions(c echo.Context) error {
+	return c.JSON(http.StatusOK, echo.Map{
+		"data": h.roleService.GetPermissions(),
+	})
+}
+
+// GetRoles hastanenin rollerini listeler
+// @Summary Rol listesi
+// @Description Hastanede tanımlı rolleri yetkileri ve kullanıcı sayılarıyla listeler
+// @Tags Role Management
+// @Produce json
+// @Success 200 {array} model.RoleResponse
+// @Failure 401 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/roles [get]
+func (h *RoleHandler) GetRoles(c echo.Context) error {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	roles, err := h.roleService.GetRoles(hospitalID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data": roles,
+	})
+}
+
+// CreateRole yeni rol oluşturur
+// @Summary Rol ekle
+// @Description Hastaneye yeni rol ekler. Sadece kendi sahip olduğunuz yetkileri verebilirsiniz
+// @Tags Role Management
+// @Accept json
+// @Produce json
+// @Param body body model.RoleRequest true "Rol bilgileri"
+// @Success 201 {object} model.RoleResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/roles [post]
+func (h *RoleHandler) CreateRole(c echo.Context) error {
+	hospitalID, role, ok := h.getActor(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	var req model.RoleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Geçersiz istek formatı",
+			"details": err.Error(),
+		})
+	}
+
+	response, validationErrors, err := h.roleService.CreateRole(hospitalID, role, &req)
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Veri doğrulama hataları",
+			"validation_errors": validationErrors,
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, echo.Map{
+		"message": "Rol başarıyla eklendi",
+		"data":    response,
+	})
+}
+
+// UpdateRole rolü günceller
+// @Summary Rol güncelle
+// @Description Rolün adını, açıklamasını ve yetkilerini günceller. Yetki değişiklikleri bir sonraki istekte geçerli olur
+// @Tags Role Management
+// @Accept json
+// @Produce json
+// @Param id path int true "Rol ID"
+// @Param body body model.RoleRequest true "Rol bilgileri"
+// @Success 200 {object} model.RoleResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/roles/{id} [put]
+func (h *RoleHandler) UpdateRole(c echo.Context) error {
+	hospitalID, role, ok := h.getActor(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Geçersiz rol ID",
+		})
+	}
+
+	var req model.RoleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Geçersiz istek formatı",
+			"details": err.Error(),
+		})
+	}
+
+	response, validationErrors, err := h.roleService.UpdateRole(hospitalID, uint(id), role, &req)
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Veri doğrulama hataları",
+			"validation_errors": validationErrors,
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Rol başarıyla güncellendi",
+		"data":    response,
+	})
+}
+
+// DeleteRole rolü siler
+// @Summary Rol sil
+// @Description Kullanıcısı olmayan, sistem rolü olmayan rolü siler
+// @Tags Role Management
+// @Produce json
+// @Param id path int true "Rol ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/roles/{id} [delete]
+func (h *RoleHandler) DeleteRole(c echo.Context) error {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Geçersiz rol ID",
+		})
+	}
+
+	if err := h.roleService.DeleteRole(hospitalID, uint(id)); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Rol başarıyla silindi",
+	})
+}
+
+// ==================== HELPER METHODS ====================
+
+// getActor token'dan hastane ID ve rol bilgisini alır
+func (h *RoleHandler) getActor(c echo.Context) (uint, string, bool) {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return 0, "", false
+	}
+	role, ok := utils.GetRoleFromContext(c)
+	if !ok {
+		return 0, "", false
+	}
+	return hospitalID, role, true
+}
+
+// handleError servis hatalarını uygun HTTP durum kodlarına çevirir
+func (h *RoleHandler) handleError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrRoleNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrPermissionEscalation):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrRoleInUse):
+		return c.JSON(http.StatusConflict, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrSystemRoleLocked), errors.Is(err, service.ErrAdminRoleLocked):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	default:
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+}
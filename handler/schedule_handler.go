@@ -0,0 +1,452 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"hospital-platform/model"
+	"hospital-platform/service"
+	"hospital-platform/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ScheduleHandler vardiya şablonları ve personel çalışma programları HTTP isteklerini yönetir
+type ScheduleHandler struct {
+	scheduleService *service.ScheduleService
+}
+
+// NewScheduleHandler yeni bir program handler'ı oluşturur
+func NewScheduleHandler() *ScheduleHandler {
+	return &ScheduleHandler{
+		scheduleService: service.NewScheduleService(),
+	}
+}
+
+// ==================== VARDİYA ŞABLONLARI ====================
+
+// GetShiftTemplates hastanenin vardiyalarını listeler
+// @Summary Vardiya listesi
+// @Description Hastanede tanımlı vardiya şablonlarını başlangıç saatine göre listeler
+// @Tags Schedule
+// @Produce json
+// @Success 200 {array} model.ShiftTemplate
+// @Failure 401 {object} map[string]interface{}
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /hospital/shift-templates [get]
+func (h *ScheduleHandler) GetShiftTemplates(c echo.Context) error {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	templates, err := h.scheduleService.GetTemplates(hospitalID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data": templates,
+	})
+}
+
+// CreateShiftTemplate vardiya şablonu ekler
+// @Summary Vardiya ekle
+// @Description Başlangıç ve bitiş saatiyle vardiya tanımlar. Bitiş başlangıçtan küçük veya eşitse vardiya ertesi gün biter (gece vardiyası, 24 saatlik nöbet)
+// @Tags Schedule
+// @Accept json
+// @Produce json
+// @Param body body model.ShiftTemplateRequest true "Vardiya bilgileri"
+// @Success 201 {object} model.ShiftTemplate
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /hospital/shift-templates [post]
+func (h *ScheduleHandler) CreateShiftTemplate(c echo.Context) error {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	var req model.ShiftTemplateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Geçersiz istek formatı",
+			"details": err.Error(),
+		})
+	}
+
+	template, validationErrors, err := h.scheduleService.CreateTemplate(hospitalID, &req)
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Veri doğrulama hataları",
+			"validation_errors": validationErrors,
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, echo.Map{
+		"message": "Vardiya başarıyla eklendi",
+		"data":    template,
+	})
+}
+
+// UpdateShiftTemplate vardiya şablonunu günceller
+// @Summary Vardiya güncelle
+// @Description Vardiyanın adını ve saatlerini günceller. Yeni saatler vardiyayı kullanan güncel veya gelecek bir programda çakışma ya da dinlenme ihlali doğuruyorsa değişiklik reddedilir
+// @Tags Schedule
+// @Accept json
+// @Produce json
+// @Param id path int true "Vardiya ID"
+// @Param body body model.ShiftTemplateRequest true "Vardiya bilgileri"
+// @Success 200 {object} model.ShiftTemplate
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /hospital/shift-templates/{id} [put]
+func (h *ScheduleHandler) UpdateShiftTemplate(c echo.Context) error {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Geçersiz vardiya ID",
+		})
+	}
+
+	var req model.ShiftTemplateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Geçersiz istek formatı",
+			"details": err.Error(),
+		})
+	}
+
+	template, validationErrors, err := h.scheduleService.UpdateTemplate(hospitalID, uint(id), &req)
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Veri doğrulama hataları",
+			"validation_errors": validationErrors,
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Vardiya başarıyla güncellendi",
+		"data":    template,
+	})
+}
+
+// DeleteShiftTemplate kullanılmayan vardiya şablonunu siler
+// @Summary Vardiya sil
+// @Description Hiçbir çalışma programında kullanılmayan vardiyayı siler
+// @Tags Schedule
+// @Produce json
+// @Param id path int true "Vardiya ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /hospital/shift-templates/{id} [delete]
+func (h *ScheduleHandler) DeleteShiftTemplate(c echo.Context) error {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Geçersiz vardiya ID",
+		})
+	}
+
+	if err := h.scheduleService.DeleteTemplate(hospitalID, uint(id)); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Vardiya başarıyla silindi",
+	})
+}
+
+// ==================== PERSONEL PROGRAMLARI ====================
+
+// GetStaffSchedules personelin çalışma programlarını listeler
+// @Summary Personel çalışma programları
+// @Description Personelin geçmiş, güncel ve gelecek çalışma programlarını başlangıç tarihine göre listeler
+// @Tags Schedule
+// @Produce json
+// @Param id path int true "Personel ID"
+// @Success 200 {array} model.StaffSchedule
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /hospital/staff/{id}/schedules [get]
+func (h *ScheduleHandler) GetStaffSchedules(c echo.Context) error {
+	hospitalID, staffID, ok := h.getStaffParams(c)
+	if !ok {
+		return nil
+	}
+
+	schedules, err := h.scheduleService.GetSchedules(hospitalID, staffID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data": schedules,
+	})
+}
+
+// CreateStaffSchedule personele çalışma programı ekler
+// @Summary Çalışma programı ekle
+// @Description Haftalık (weekly, gün 1=Pazartesi..7=Pazar) veya dönüşümlü (rotating, effective_from döngünün 1. günüdür) program ekler. Personelin aynı tarihlere denk gelen başka programı olamaz; çakışan vardiyalar ve iki iş günü arasında asgari dinlenme süresinin (varsayılan 11 saat) altında kalan geçişler reddedilir
+// @Tags Schedule
+// @Accept json
+// @Produce json
+// @Param id path int true "Personel ID"
+// @Param body body model.StaffScheduleRequest true "Program bilgileri"
+// @Success 201 {object} model.StaffSchedule
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /hospital/staff/{id}/schedules [post]
+func (h *ScheduleHandler) CreateStaffSchedule(c echo.Context) error {
+	hospitalID, staffID, ok := h.getStaffParams(c)
+	if !ok {
+		return nil
+	}
+
+	var req model.StaffScheduleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Geçersiz istek formatı",
+			"details": err.Error(),
+		})
+	}
+
+	schedule, validationErrors, err := h.scheduleService.CreateSchedule(hospitalID, staffID, &req)
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Veri doğrulama hataları",
+			"validation_errors": validationErrors,
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, echo.Map{
+		"message": "Çalışma programı başarıyla eklendi",
+		"data":    schedule,
+	})
+}
+
+// UpdateStaffSchedule personelin çalışma programını değiştirir
+// @Summary Çalışma programı güncelle
+// @Description Programın desenini, tarihlerini ve vardiyalarını değiştirir; ekleme ile aynı kontroller uygulanır
+// @Tags Schedule
+// @Accept json
+// @Produce json
+// @Param id path int true "Personel ID"
+// @Param schedule_id path int true "Program ID"
+// @Param body body model.StaffScheduleRequest true "Program bilgileri"
+// @Success 200 {object} model.StaffSchedule
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /hospital/staff/{id}/schedules/{schedule_id} [put]
+func (h *ScheduleHandler) UpdateStaffSchedule(c echo.Context) error {
+	hospitalID, staffID, ok := h.getStaffParams(c)
+	if !ok {
+		return nil
+	}
+
+	scheduleID, err := strconv.ParseUint(c.Param("schedule_id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Geçersiz program ID",
+		})
+	}
+
+	var req model.StaffScheduleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Geçersiz istek formatı",
+			"details": err.Error(),
+		})
+	}
+
+	schedule, validationErrors, err := h.scheduleService.UpdateSchedule(hospitalID, staffID, uint(scheduleID), &req)
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Veri doğrulama hataları",
+			"validation_errors": validationErrors,
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Çalışma programı başarıyla güncellendi",
+		"data":    schedule,
+	})
+}
+
+// DeleteStaffSchedule personelin çalışma programını siler
+// @Summary Çalışma programı sil
+// @Tags Schedule
+// @Produce json
+// @Param id path int true "Personel ID"
+// @Param schedule_id path int true "Program ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /hospital/staff/{id}/schedules/{schedule_id} [delete]
+func (h *ScheduleHandler) DeleteStaffSchedule(c echo.Context) error {
+	hospitalID, staffID, ok := h.getStaffParams(c)
+	if !ok {
+		return nil
+	}
+
+	scheduleID, err := strconv.ParseUint(c.Param("schedule_id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Geçersiz program ID",
+		})
+	}
+
+	if err := h.scheduleService.DeleteSchedule(hospitalID, staffID, uint(scheduleID)); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Çalışma programı başarıyla silindi",
+	})
+}
+
+// GetStaffShifts personelin tarih aralığındaki vardiyalarını hesaplar
+// @Summary Personel vardiya takvimi
+// @Description Programlardan hesaplanan somut vardiyaları başlangıç saatine göre döndürür (en fazla 92 gün). Tarihler verilmezse bugünden itibaren 7 gün
+// @Tags Schedule
+// @Produce json
+// @Param id path int true "Personel ID"
+// @Param from query string false "Başlangıç günü (YYYY-AA-GG)"
+// @Param to query string false "Bitiş günü, dahil (YYYY-AA-GG)"
+// @Success 200 {array} model.ShiftOccurrence
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /hospital/staff/{id}/shifts [get]
+func (h *ScheduleHandler) GetStaffShifts(c echo.Context) error {
+	hospitalID, staffID, ok := h.getStaffParams(c)
+	if !ok {
+		return nil
+	}
+
+	from := time.Now()
+	if value := c.QueryParam("from"); value != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, echo.Map{
+				"error": "from YYYY-AA-GG biçiminde olmalı",
+			})
+		}
+		from = parsed
+	}
+	to := from.AddDate(0, 0, 6)
+	if value := c.QueryParam("to"); value != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, echo.Map{
+				"error": "to YYYY-AA-GG biçiminde olmalı",
+			})
+		}
+		to = parsed
+	}
+
+	shifts, err := h.scheduleService.GetShifts(hospitalID, staffID, from, to)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data": shifts,
+	})
+}
+
+// ==================== HELPER METHODS ====================
+
+// getStaffParams token'dan hastane ID'sini ve path'ten personel ID'sini alır; hata yanıtını kendisi yazar
+func (h *ScheduleHandler) getStaffParams(c echo.Context) (uint, uint, bool) {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+		return 0, 0, false
+	}
+
+	staffID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Geçersiz personel ID",
+		})
+		return 0, 0, false
+	}
+	return hospitalID, uint(staffID), true
+}
+
+// handleError servis hatalarını uygun HTTP durum kodlarına çevirir
+func (h *ScheduleHandler) handleError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrShiftTemplateNotFound),
+		errors.Is(err, service.ErrScheduleNotFound),
+		errors.Is(err, service.ErrScheduleStaffNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrShiftTemplateInUse):
+		return c.JSON(http.StatusConflict, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrShiftRangeInvalid):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	default:
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+}
@@ -0,0 +1,246 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"hospital-platform/service"
+	"hospital-platform/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// SessionHandler oturum (cihaz) yönetimi HTTP isteklerini yönetir
+type SessionHandler struct {
+	sessionService *service.SessionService
+}
+
+// NewSessionHandler yeni bir oturum handler'ı oluşturur
+func NewSessionHandler() *SessionHandler {
+	return &SessionHandler{
+		sessionService: service.NewSessionService(),
+	}
+}
+
+// ==================== KENDİ OTURUMLARI ====================
+
+// ListMySessions giriş yapılan cihazları listeler
+// @Summary Aktif oturumlarım
+// @Description Kullanıcının açık oturumlarını cihaz, IP, giriş ve son görülme zamanıyla listeler. İsteği yapan oturum current=true ile işaretlenir
+// @Tags Sessions
+// @Produce json
+// @Success 200 {array} model.SessionResponse
+// @Failure 401 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /me/sessions [get]
+func (h *SessionHandler) ListMySessions(c echo.Context) error {
+	userID, familyID, ok := h.getCurrent(c)
+	if !ok {
+		return nil
+	}
+
+	sessions, err := h.sessionService.ListSessions(userID, familyID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data": sessions,
+	})
+}
+
+// RevokeMySession kendi oturumlarından birini sonlandırır
+// @Summary Oturumu sonlandır
+// @Description Seçilen cihazın oturumu kapatılır; o cihazdaki access ve refresh token'lar hemen geçersiz olur
+// @Tags Sessions
+// @Produce json
+// @Param id path string true "Oturum ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /me/sessions/{id} [delete]
+func (h *SessionHandler) RevokeMySession(c echo.Context) error {
+	userID, _, ok := h.getCurrent(c)
+	if !ok {
+		return nil
+	}
+
+	if err := h.sessionService.RevokeSession(userID, c.Param("id")); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Oturum sonlandırıldı",
+	})
+}
+
+// RevokeMyOtherSessions mevcut oturum dışındaki tüm oturumları sonlandırır
+// @Summary Diğer cihazlardan çıkış
+// @Description İsteği yapan oturum açık kalır, diğer tüm cihazların oturumu kapatılır
+// @Tags Sessions
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /me/sessions [delete]
+func (h *SessionHandler) RevokeMyOtherSessions(c echo.Context) error {
+	userID, familyID, ok := h.getCurrent(c)
+	if !ok {
+		return nil
+	}
+
+	revoked, err := h.sessionService.RevokeOtherSessions(userID, familyID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Diğer oturumlar sonlandırıldı",
+		"revoked": revoked,
+	})
+}
+
+// ==================== YÖNETİCİ ENDPOINT'LERİ ====================
+
+// ListUserSessions hastanedeki bir kullanıcının oturumlarını listeler
+// @Summary Kullanıcının oturumları
+// @Description Yetkili, hastanedeki bir kullanıcının açık oturumlarını görür
+// @Tags User Management
+// @Produce json
+// @Param id path int true "Kullanıcı ID"
+// @Success 200 {array} model.SessionResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/users/{id}/sessions [get]
+func (h *SessionHandler) ListUserSessions(c echo.Context) error {
+	hospitalID, actorID, targetID, ok := h.getTarget(c)
+	if !ok {
+		return nil
+	}
+
+	sessions, err := h.sessionService.ListUserSessions(hospitalID, actorID, targetID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data": sessions,
+	})
+}
+
+// RevokeUserSession hastanedeki bir kullanıcının oturumunu sonlandırır
+// @Summary Kullanıcının oturumunu sonlandır
+// @Description Yetkili, hastanedeki bir kullanıcının seçilen oturumunu kapatır (örn. kaybolan cihaz)
+// @Tags User Management
+// @Produce json
+// @Param id path int true "Kullanıcı ID"
+// @Param sessionId path string true "Oturum ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/users/{id}/sessions/{sessionId} [delete]
+func (h *SessionHandler) RevokeUserSession(c echo.Context) error {
+	hospitalID, actorID, targetID, ok := h.getTarget(c)
+	if !ok {
+		return nil
+	}
+	familyID, _ := utils.GetTokenFamilyFromContext(c)
+
+	if err := h.sessionService.RevokeUserSession(hospitalID, actorID, targetID, c.Param("sessionId"), familyID); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Oturum sonlandırıldı",
+	})
+}
+
+// RevokeAllUserSessions hastanedeki bir kullanıcının tüm oturumlarını sonlandırır
+// @Summary Kullanıcının tüm oturumlarını sonlandır
+// @Description Yetkili, kullanıcıyı tüm cihazlardan çıkarır
+// @Tags User Management
+// @Produce json
+// @Param id path int true "Kullanıcı ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/users/{id}/sessions [delete]
+func (h *SessionHandler) RevokeAllUserSessions(c echo.Context) error {
+	hospitalID, actorID, targetID, ok := h.getTarget(c)
+	if !ok {
+		return nil
+	}
+
+	if err := h.sessionService.RevokeAllUserSessions(hospitalID, actorID, targetID); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Kullanıcının tüm oturumları sonlandırıldı",
+	})
+}
+
+// ==================== HELPER METHODS ====================
+
+// getCurrent token'dan kullanıcı ID'sini ve mevcut oturum ID'sini alır
+// Hata durumunda cevabı kendisi yazar ve ok=false döner
+func (h *SessionHandler) getCurrent(c echo.Context) (uint, string, bool) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+		return 0, "", false
+	}
+	familyID, ok := utils.GetTokenFamilyFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+		return 0, "", false
+	}
+	return userID, familyID, true
+}
+
+// getTarget token'dan hastane ve yönetici ID'sini, path'ten kullanıcı ID'sini alır
+// Hata durumunda cevabı kendisi yazar ve ok=false döner
+func (h *SessionHandler) getTarget(c echo.Context) (uint, uint, uint, bool) {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+		return 0, 0, 0, false
+	}
+	actorID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+		return 0, 0, 0, false
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, echo.Map{"error": "Geçersiz kullanıcı ID"})
+		return 0, 0, 0, false
+	}
+	return hospitalID, actorID, uint(id), true
+}
+
+// handleError servis hatalarını uygun HTTP durum kodlarına çevirir
+func (h *SessionHandler) handleError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrSessionNotFound), errors.Is(err, service.ErrSessionUserNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrPermissionEscalation):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrCurrentSessionRevoke):
+		return c.JSON(http.StatusConflict, echo.Map{"error": err.Error()})
+	default:
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+}
@@ -0,0 +1,531 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"hospital-platform/model"
+	"hospital-platform/service"
+	"hospital-platform/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// SignupHandler kendi kendine kayıt, başvuru onayı ve kayıt yöntemleri (katılım kodu, e-posta alan adı) HTTP isteklerini yönetir
+type SignupHandler struct {
+	signupService   *service.SignupService
+	joinCodeService *service.JoinCodeService
+}
+
+// NewSignupHandler yeni bir kayıt handler'ı oluşturur
+func NewSignupHandler() *SignupHandler {
+	return &SignupHandler{
+		signupService:   service.NewSignupService(),
+		joinCodeService: service.NewJoinCodeService(),
+	}
+}
+
+// ==================== BAŞVURAN ENDPOINT'LERİ ====================
+
+// SendEmailCode kayıt için e-posta doğrulama kodu gönderir
+// @Summary Kayıt e-posta doğrulama kodu
+// @Description Başvurudan önce e-posta adresine 6 haneli doğrulama kodu gönderilir
+// @Tags Signup
+// @Accept json
+// @Produce json
+// @Param body body model.SignupEmailCodeRequest true "E-posta"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 429 {object} map[string]interface{}
+// @Router /signup/email-code [post]
+func (h *SignupHandler) SendEmailCode(c echo.Context) error {
+	var req model.SignupEmailCodeRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Geçersiz veri"})
+	}
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Veri doğrulama hatası",
+			"details": err.Error(),
+		})
+	}
+
+	lang := service.ResolveLanguage(req.Lang, c.Request().Header.Get("Accept-Language"))
+	if err := h.signupService.SendEmailCode(req.Email, lang); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Doğrulama kodu gönderildi",
+	})
+}
+
+// Signup kayıt başvurusu oluşturur
+// @Summary Kayıt başvurusu
+// @Description Hastane ve rol, katılım kodundan veya e-posta alan adından sunucuda belirlenir. Başvuru hastane yetkilisi onaylayana kadar beklemede kalır; sonuç e-postayla bildirilir
+// @Tags Signup
+// @Accept json
+// @Produce json
+// @Param body body model.SelfSignupRequest true "Başvuru bilgileri"
+// @Success 202 {object} model.SignupSubmittedResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Router /signup [post]
+func (h *SignupHandler) Signup(c echo.Context) error {
+	var req model.SelfSignupRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Geçersiz istek formatı",
+			"details": err.Error(),
+		})
+	}
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Veri doğrulama hatası",
+			"details": err.Error(),
+		})
+	}
+	if req.Lang == "" {
+		req.Lang = service.ResolveLanguage("", c.Request().Header.Get("Accept-Language"))
+	}
+
+	result, validationErrors, err := h.signupService.Submit(&req)
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Veri doğrulama hataları",
+			"validation_errors": validationErrors,
+		})
+	}
+	if err != nil {
+		fmt.Println("Kayıt başvurusu hatası:", err)
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusAccepted, echo.Map{
+		"message": "Başvurunuz alındı, hastane yetkilisinin onayı bekleniyor",
+		"data":    result,
+	})
+}
+
+// ==================== BAŞVURU İNCELEME ====================
+
+// ListSignupRequests hastanenin kayıt başvurularını listeler
+// @Summary Kayıt başvuruları
+// @Description Varsayılan olarak onay bekleyen başvurular döner
+// @Tags User Management
+// @Produce json
+// @Param status query string false "pending (varsayılan), approved, rejected veya all"
+// @Success 200 {array} model.SignupRequest
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/signup-requests [get]
+func (h *SignupHandler) ListSignupRequests(c echo.Context) error {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	status := c.QueryParam("status")
+	switch status {
+	case "":
+		status = model.SignupPending
+	case "all":
+		status = ""
+	case model.SignupPending, model.SignupApproved, model.SignupRejected:
+	default:
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Geçersiz durum filtresi",
+		})
+	}
+
+	requests, err := h.signupService.ListRequests(hospitalID, status)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data": requests,
+	})
+}
+
+// ApproveSignupRequest başvuruyu onaylar ve kullanıcıyı oluşturur
+// @Summary Başvuruyu onayla
+// @Description Başvuru onaylanır ve kullanıcı oluşturulur. Rol/poliklinik gönderilmezse kayıt yönteminin atadığı değerler kullanılır; kimse kendi yetkilerini aşan bir rol veremez
+// @Tags User Management
+// @Accept json
+// @Produce json
+// @Param id path int true "Başvuru ID"
+// @Param body body model.ApproveSignupRequest false "Rol/poliklinik değişikliği"
+// @Success 200 {object} model.SignupRequest
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/signup-requests/{id}/approve [post]
+func (h *SignupHandler) ApproveSignupRequest(c echo.Context) error {
+	hospitalID, userID, id, ok := h.getTarget(c, "Geçersiz başvuru ID")
+	if !ok {
+		return nil
+	}
+
+	var req model.ApproveSignupRequest
+	if c.Request().ContentLength != 0 {
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, echo.Map{
+				"error":   "Geçersiz istek formatı",
+				"details": err.Error(),
+			})
+		}
+	}
+
+	request, validationErrors, err := h.signupService.Approve(hospitalID, id, userID, &req)
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Veri doğrulama hataları",
+			"validation_errors": validationErrors,
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Başvuru onaylandı, kullanıcı oluşturuldu",
+		"data":    request,
+	})
+}
+
+// RejectSignupRequest başvuruyu reddeder
+// @Summary Başvuruyu reddet
+// @Description Başvuru gerekçesiyle reddedilir; başvuran e-postayla bilgilendirilir
+// @Tags User Management
+// @Accept json
+// @Produce json
+// @Param id path int true "Başvuru ID"
+// @Param body body model.RejectSignupRequest true "Red gerekçesi"
+// @Success 200 {object} model.SignupRequest
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/signup-requests/{id}/reject [post]
+func (h *SignupHandler) RejectSignupRequest(c echo.Context) error {
+	hospitalID, userID, id, ok := h.getTarget(c, "Geçersiz başvuru ID")
+	if !ok {
+		return nil
+	}
+
+	var req model.RejectSignupRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Geçersiz istek formatı",
+			"details": err.Error(),
+		})
+	}
+
+	request, validationErrors, err := h.signupService.Reject(hospitalID, id, userID, req.Reason)
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Veri doğrulama hataları",
+			"validation_errors": validationErrors,
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Başvuru reddedildi",
+		"data":    request,
+	})
+}
+
+// ==================== KATILIM KODLARI ====================
+
+// CreateJoinCode katılım kodu oluşturur
+// @Summary Katılım kodu oluştur
+// @Description Kodla başvuranlara atanacak rol sunucuda saklanır. Kod sadece bu yanıtta gösterilir, veritabanında hash'i tutulur
+// @Tags Signup Settings
+// @Accept json
+// @Produce json
+// @Param body body model.CreateJoinCodeRequest true "Kod ayarları"
+// @Success 201 {object} model.JoinCodeResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/join-codes [post]
+func (h *SignupHandler) CreateJoinCode(c echo.Context) error {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	var req model.CreateJoinCodeRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Geçersiz istek formatı",
+			"details": err.Error(),
+		})
+	}
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Veri doğrulama hatası",
+			"details": err.Error(),
+		})
+	}
+
+	code, validationErrors, err := h.joinCodeService.CreateJoinCode(&req, hospitalID, userID)
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Veri doğrulama hataları",
+			"validation_errors": validationErrors,
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, echo.Map{
+		"message": "Katılım kodu oluşturuldu. Kod tekrar gösterilmeyecek, güvenli bir şekilde paylaşın",
+		"data":    code,
+	})
+}
+
+// ListJoinCodes hastanenin katılım kodlarını listeler
+// @Summary Katılım kodları
+// @Description Kodların kendisi gösterilmez; son 4 karakter, rol, kullanım ve durum bilgisi döner
+// @Tags Signup Settings
+// @Produce json
+// @Success 200 {array} model.JoinCodeResponse
+// @Failure 401 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/join-codes [get]
+func (h *SignupHandler) ListJoinCodes(c echo.Context) error {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	codes, err := h.joinCodeService.ListJoinCodes(hospitalID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data": codes,
+	})
+}
+
+// RevokeJoinCode katılım kodunu iptal eder
+// @Summary Katılım kodunu iptal et
+// @Description Kod artık yeni başvurularda kullanılamaz; bekleyen başvurular etkilenmez
+// @Tags Signup Settings
+// @Produce json
+// @Param id path int true "Katılım kodu ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/join-codes/{id} [delete]
+func (h *SignupHandler) RevokeJoinCode(c echo.Context) error {
+	hospitalID, userID, id, ok := h.getTarget(c, "Geçersiz katılım kodu ID")
+	if !ok {
+		return nil
+	}
+
+	if err := h.joinCodeService.RevokeJoinCode(hospitalID, id, userID); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Katılım kodu iptal edildi",
+	})
+}
+
+// ==================== İZİNLİ E-POSTA ALAN ADLARI ====================
+
+// AddEmailDomain izinli e-posta alan adı ekler
+// @Summary İzinli e-posta alan adı ekle
+// @Description Bu alan adındaki doğrulanmış e-posta adresleri katılım kodu olmadan başvurabilir. Bir alan adı tek hastaneye ait olabilir; herkese açık e-posta servisleri eklenemez
+// @Tags Signup Settings
+// @Accept json
+// @Produce json
+// @Param body body model.CreateEmailDomainRequest true "Alan adı"
+// @Success 201 {object} model.HospitalEmailDomain
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/email-domains [post]
+func (h *SignupHandler) AddEmailDomain(c echo.Context) error {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	var req model.CreateEmailDomainRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Geçersiz istek formatı",
+			"details": err.Error(),
+		})
+	}
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Veri doğrulama hatası",
+			"details": err.Error(),
+		})
+	}
+
+	domain, validationErrors, err := h.joinCodeService.AddEmailDomain(&req, hospitalID, userID)
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Veri doğrulama hataları",
+			"validation_errors": validationErrors,
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, echo.Map{
+		"message": "Alan adı eklendi",
+		"data":    domain,
+	})
+}
+
+// ListEmailDomains hastanenin izinli e-posta alan adlarını listeler
+// @Summary İzinli e-posta alan adları
+// @Tags Signup Settings
+// @Produce json
+// @Success 200 {array} model.HospitalEmailDomain
+// @Failure 401 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/email-domains [get]
+func (h *SignupHandler) ListEmailDomains(c echo.Context) error {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	domains, err := h.joinCodeService.ListEmailDomains(hospitalID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data": domains,
+	})
+}
+
+// RemoveEmailDomain izinli e-posta alan adını kaldırır
+// @Summary İzinli e-posta alan adını kaldır
+// @Tags Signup Settings
+// @Produce json
+// @Param id path int true "Alan adı ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/email-domains/{id} [delete]
+func (h *SignupHandler) RemoveEmailDomain(c echo.Context) error {
+	hospitalID, userID, id, ok := h.getTarget(c, "Geçersiz alan adı ID")
+	if !ok {
+		return nil
+	}
+
+	if err := h.joinCodeService.RemoveEmailDomain(hospitalID, id, userID); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Alan adı kaldırıldı",
+	})
+}
+
+// ==================== HELPER METHODS ====================
+
+// getTarget token'dan hastane ve kullanıcı ID'sini, path'ten kayıt ID'sini alır
+// Hata durumunda cevabı kendisi yazar ve ok=false döner
+func (h *SignupHandler) getTarget(c echo.Context, invalidIDMessage string) (uint, uint, uint, bool) {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+		return 0, 0, 0, false
+	}
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+		return 0, 0, 0, false
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, echo.Map{"error": invalidIDMessage})
+		return 0, 0, 0, false
+	}
+	return hospitalID, userID, uint(id), true
+}
+
+// handleError servis hatalarını uygun HTTP durum kodlarına çevirir
+func (h *SignupHandler) handleError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrSignupNotFound),
+		errors.Is(err, service.ErrJoinCodeNotFound),
+		errors.Is(err, service.ErrEmailDomainNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrPermissionEscalation):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrSignupNotPending):
+		return c.JSON(http.StatusConflict, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrVerificationCodeInvalid), errors.Is(err, service.ErrVerificationCodeExhausted):
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Veri doğrulama hataları",
+			"validation_errors": []model.ValidationError{{Field: "email_code", Message: err.Error()}},
+		})
+	case errors.Is(err, service.ErrTooManyVerificationCodes):
+		return c.JSON(http.StatusTooManyRequests, echo.Map{"error": err.Error()})
+	case errors.Is(err, utils.ErrHasherBusy):
+		c.Response().Header().Set("Retry-After", "1")
+		return c.JSON(http.StatusServiceUnavailable, echo.Map{"error": "Sunucu yoğun, lütfen tekrar deneyin"})
+	default:
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+}
@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"hospital-platform/model"
+	"hospital-platform/service"
+	"hospital-platform/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// SSOHandler OpenID Connect tek oturum açma HTTP isteklerini yönetir
+type SSOHandler struct {
+	ssoService *service.SSOService
+}
+
+// NewSSOHandler yeni bir SSO handler'ı oluşturur
+func NewSSOHandler() *SSOHandler {
+	return &SSOHandler{
+		ssoService: service.NewSSOService(),
+	}
+}
+
+// ==================== GİRİŞ ====================
+
+// Start SSO girişini başlatır
+// @Summary SSO ile giriş başlat
+// @Description Hastanenin kimlik sağlayıcısına (OIDC) yönlendirme adresini döndürür. Hastane ID'si veya hastanenin izinli alan adındaki e-posta ile başlatılır. Authorization code + PKCE kullanılır
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param body body model.SSOStartRequest true "Hastane ID veya e-posta"
+// @Success 200 {object} model.SSOStartResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 502 {object} map[string]interface{}
+// @Router /sso/start [post]
+func (h *SSOHandler) Start(c echo.Context) error {
+	var req model.SSOStartRequest
+	if err := c.Bind(&req); err != nil || (req.HospitalID == 0 && req.Email == "") {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Hastane ID veya e-posta gerekli"})
+	}
+
+	response, err := h.ssoService.Start(&req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// Callback IdP dönüşünü tamamlar
+// @Summary SSO dönüşü
+// @Description IdP'nin dönüş adresine eklediği code ve state ile girişi tamamlar. Normal login gibi access + refresh token döner; kullanıcının MFA'sı açıksa ikinci adım istenir. Frontend dönüş adresindeki parametreleri POST edebilir veya dönüş adresi doğrudan bu endpoint olabilir (GET)
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param body body model.SSOCallbackRequest true "IdP dönüş parametreleri"
+// @Success 200 {object} model.TokenResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 502 {object} map[string]interface{}
+// @Router /sso/callback [post]
+func (h *SSOHandler) Callback(c echo.Context) error {
+	var req model.SSOCallbackRequest
+	if err := c.Bind(&req); err != nil || req.State == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Geçersiz SSO dönüşü"})
+	}
+
+	tokens, challenge, err := h.ssoService.Callback(&req, utils.GetClientInfo(c))
+	if err != nil {
+		fmt.Println("SSO giriş hatası:", err)
+		return h.handleError(c, err)
+	}
+
+	// MFA bekleniyor
+	if challenge != nil {
+		return c.JSON(http.StatusOK, challenge)
+	}
+	return c.JSON(http.StatusOK, tokens)
+}
+
+// ==================== AYARLAR ====================
+
+// GetConfig hastanenin SSO ayarlarını getirir
+// @Summary SSO ayarları
+// @Description Hastanenin OIDC ayarları ve IdP'de kayıtlı olması gereken dönüş adresi. Client secret gösterilmez
+// @Tags Hospital Settings
+// @Produce json
+// @Success 200 {object} model.SSOConfigResponse
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/settings/sso [get]
+func (h *SSOHandler) GetConfig(c echo.Context) error {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+	}
+
+	ssoConfig, err := h.ssoService.GetConfig(hospitalID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data": ssoConfig,
+	})
+}
+
+// UpdateConfig hastanenin SSO ayarlarını kaydeder
+// @Summary SSO ayarlarını kaydet
+// @Description Issuer, client ID/secret ve claim → rol eşlemelerini kaydeder. Açık ayarlarda IdP discovery belgesi doğrulanır; eşlenen roller ayarı yapanın atayabileceği roller olmalıdır
+// @Tags Hospital Settings
+// @Accept json
+// @Produce json
+// @Param body body model.SSOConfigRequest true "SSO ayarları"
+// @Success 200 {object} model.SSOConfigResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/settings/sso [put]
+func (h *SSOHandler) UpdateConfig(c echo.Context) error {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+	}
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+	}
+
+	var req model.SSOConfigRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Geçersiz istek formatı",
+			"details": err.Error(),
+		})
+	}
+
+	ssoConfig, validationErrors, err := h.ssoService.UpdateConfig(&req, hospitalID, userID)
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Veri doğrulama hataları",
+			"validation_errors": validationErrors,
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "SSO ayarları kaydedildi",
+		"data":    ssoConfig,
+	})
+}
+
+// DeleteConfig hastanenin SSO ayarlarını siler
+// @Summary SSO ayarlarını sil
+// @Description SSO ile giriş kapatılır, ayarlar ve rol eşlemeleri silinir
+// @Tags Hospital Settings
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/settings/sso [delete]
+func (h *SSOHandler) DeleteConfig(c echo.Context) error {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+	}
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+	}
+
+	if err := h.ssoService.DeleteConfig(hospitalID, userID); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "SSO ayarları silindi",
+	})
+}
+
+// handleError servis hatalarını uygun HTTP durum kodlarına çevirir
+func (h *SSOHandler) handleError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrSSONotConfigured):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrSSOInvalidState):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrSSOIdentityDenied),
+		errors.Is(err, service.ErrSSOEmailMissing),
+		errors.Is(err, utils.ErrOIDCInvalidToken):
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrSSOUserNotFound),
+		errors.Is(err, service.ErrSSOUserMismatch),
+		errors.Is(err, service.ErrUserInactive),
+		errors.Is(err, service.ErrSSORoleNotMapped),
+		errors.Is(err, service.ErrSSOProvisionFailed),
+		errors.Is(err, service.ErrPermissionEscalation),
+		errors.Is(err, service.ErrHospitalSuspended):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+	case errors.Is(err, utils.ErrOIDCDiscovery), errors.Is(err, utils.ErrOIDCTokenRequest):
+		return c.JSON(http.StatusBadGateway, echo.Map{"error": "Kimlik sağlayıcıya ulaşılamadı veya hatalı yanıt verdi"})
+	default:
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+}
@@ -1,8 +1,11 @@
 package handler
 
 import (
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"time"
 
 	"hospital-platform/model"
 	"hospital-platform/service"
@@ -14,14 +17,18 @@ import (
 // StaffHandler - Personel yönetimi ile ilgili HTTP isteklerini karşılayan controller katmanı
 // API endpoint'lerini handle eder ve servis katmanı ile iletişim kurar
 type StaffHandler struct {
-	staffService *service.StaffService // İş mantığını yöneten servis
+	staffService       *service.StaffService       // İş mantığını yöneten servis
+	staffImportService *service.StaffImportService // CSV/XLSX toplu içe aktarma
+	staffExportService *service.StaffExportService // CSV/XLSX/PDF dışa aktarma
 }
 
 // NewStaffHandler - Personel handler'ının yeni bir instance'ını oluşturur
 // Servis bağımlılığını enjekte eder ve kullanıma hazır hale getirir
 func NewStaffHandler() *StaffHandler {
 	return &StaffHandler{
-		staffService: service.NewStaffService(),
+		staffService:       service.NewStaffService(),
+		staffImportService: service.NewStaffImportService(),
+		staffExportService: service.NewStaffExportService(),
 	}
 }
 
@@ -39,6 +46,7 @@ func NewStaffHandler() *StaffHandler {
 // @Failure 401 {object} map[string]interface{}
 // @Failure 422 {object} map[string]interface{}
 // @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /hospital/staff [post]
 func (h *StaffHandler) CreateStaff(c echo.Context) error {
 	// JWT token'dan hospital ID al
@@ -98,6 +106,7 @@ func (h *StaffHandler) CreateStaff(c echo.Context) error {
 // @Failure 401 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
 // @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /hospital/staff/{id} [get]
 func (h *StaffHandler) GetStaffByID(c echo.Context) error {
 	// JWT token'dan hospital ID al
@@ -142,6 +151,7 @@ func (h *StaffHandler) GetStaffByID(c echo.Context) error {
 // @Failure 401 {object} map[string]interface{}
 // @Failure 422 {object} map[string]interface{}
 // @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /hospital/staff/{id} [put]
 func (h *StaffHandler) UpdateStaff(c echo.Context) error {
 	// JWT token'dan hospital ID al
@@ -202,6 +212,7 @@ func (h *StaffHandler) UpdateStaff(c echo.Context) error {
 // @Failure 400 {object} map[string]interface{}
 // @Failure 401 {object} map[string]interface{}
 // @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /hospital/staff/{id} [delete]
 func (h *StaffHandler) DeleteStaff(c echo.Context) error {
 	// JWT token'dan hospital ID al
@@ -233,6 +244,80 @@ func (h *StaffHandler) DeleteStaff(c echo.Context) error {
 	})
 }
 
+// ==================== TOPLU İÇE AKTARMA ====================
+
+// ImportStaff CSV veya XLSX dosyasından toplu personel ekler
+// @Summary Personel içe aktar (CSV/XLSX)
+// @Description İlk satır başlıktır: ad, soyad, tc, telefon, meslek grubu, unvan, çalışma günleri (zorunlu) ve poliklinik (opsiyonel). Meslek grubu, unvan ve poliklinik adla eşlenir; çalışma günleri "1-5", "1,3,5" veya "Pazartesi-Cuma" yazılabilir. Her satır tekil personel eklemeyle aynı kurallardan geçer. dry_run=true (varsayılan) sadece satır bazında rapor döner; dry_run=false hatasız satırları tek transaction ile ekler, hatalı satırlar atlanır
+// @Tags Staff
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV veya XLSX dosyası (en fazla 5 MB, 1000 satır)"
+// @Param dry_run formData bool false "true: sadece doğrula (varsayılan), false: hatasız satırları ekle"
+// @Success 200 {object} model.StaffImportResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /hospital/staff/import [post]
+func (h *StaffHandler) ImportStaff(c echo.Context) error {
+	// JWT token'dan hospital ID al
+	hospitalID, err := h.getHospitalIDFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	dryRun := true
+	if value := c.FormValue("dry_run"); value != "" {
+		dryRun, err = strconv.ParseBool(value)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, echo.Map{
+				"error": "dry_run true veya false olmalı",
+			})
+		}
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Dosya gerekli (multipart alan adı: file)",
+		})
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Dosya okunamadı",
+		})
+	}
+	defer file.Close()
+
+	// Sınırın bir bayt fazlası okunur - büyük dosyalar belleğe tamamen alınmadan servis tarafından reddedilir
+	data, err := io.ReadAll(io.LimitReader(file, service.MAX_STAFF_IMPORT_SIZE+1))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Dosya okunamadı",
+		})
+	}
+
+	report, validationErrors, err := h.staffImportService.ImportStaff(hospitalID, data, dryRun)
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{
+			"error":             "Veri doğrulama hataları",
+			"validation_errors": validationErrors,
+		})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
 // ==================== LİSTELEME VE FİLTRELEME ====================
 
 // GetStaffList sayfalandırılmış personel listesi getirir
@@ -246,6 +331,7 @@ func (h *StaffHandler) DeleteStaff(c echo.Context) error {
 // @Failure 400 {object} map[string]interface{}
 // @Failure 401 {object} map[string]interface{}
 // @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /hospital/staff/list [post]
 func (h *StaffHandler) GetStaffList(c echo.Context) error {
 	// JWT token'dan hospital ID al
@@ -274,6 +360,71 @@ func (h *StaffHandler) GetStaffList(c echo.Context) error {
 	return c.JSON(http.StatusOK, response)
 }
 
+// ExportStaff filtreye uyan tüm personeli dosya olarak indirir
+// @Summary Personel dışa aktar (CSV/XLSX/PDF)
+// @Description Listeleme ile aynı filtreleri alır; sayfalama alanları yok sayılır ve eşleşen tüm personel aynı sırayla aktarılır. Dosya satırlar okundukça gönderilir. CSV noktalı virgül ayraçlı ve BOM'ludur; CSV/XLSX başlıkları toplu içe aktarmayla uyumludur. PDF her sayfada hastane başlığı taşır
+// @Tags Staff
+// @Accept json
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce application/pdf
+// @Param format query string true "Dosya biçimi" Enums(csv, xlsx, pdf)
+// @Param body body model.StaffListRequest false "Filtreleme verisi (page ve page_size yok sayılır)"
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /hospital/staff/export [post]
+func (h *StaffHandler) ExportStaff(c echo.Context) error {
+	// JWT token'dan hospital ID al
+	hospitalID, err := h.getHospitalIDFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Geçersiz token",
+		})
+	}
+
+	format := c.QueryParam("format")
+	if !service.IsValidStaffExportFormat(format) {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": service.ErrStaffExportFormat.Error(),
+		})
+	}
+
+	var req model.StaffListRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Geçersiz istek formatı",
+			"details": err.Error(),
+		})
+	}
+
+	contentTypes := map[string]string{
+		service.StaffExportCSV:  "text/csv; charset=utf-8",
+		service.StaffExportXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		service.StaffExportPDF:  "application/pdf",
+	}
+	response := c.Response()
+	response.Header().Set(echo.HeaderContentType, contentTypes[format])
+	response.Header().Set(echo.HeaderContentDisposition,
+		fmt.Sprintf("attachment; filename=personel-%s.%s", time.Now().Format("20060102"), format))
+
+	if err := h.staffExportService.ExportStaff(hospitalID, &req, format, response); err != nil {
+		// Dosya gönderilmeye başlandıysa durum kodu değiştirilemez - bağlantı yarım dosyayla kapanır
+		if response.Committed {
+			fmt.Printf("❌ Personel dışa aktarımı yarıda kaldı: Hastane %d - %v\n", hospitalID, err)
+			return nil
+		}
+		response.Header().Del(echo.HeaderContentDisposition)
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"error": err.Error(),
+		})
+	}
+	return nil
+}
+
 // ==================== MASTER DATA ====================
 
 // GetJobGroups meslek gruplarını getirir
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"hospital-platform/model"
+	"hospital-platform/service"
+	"hospital-platform/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// UserStatusHandler kullanıcı hesap durumu (askıya alma, kilitleme, aktif etme) HTTP isteklerini yönetir
+type UserStatusHandler struct {
+	userStatusService *service.UserStatusService
+}
+
+// NewUserStatusHandler yeni bir kullanıcı durumu handler'ı oluşturur
+func NewUserStatusHandler() *UserStatusHandler {
+	return &UserStatusHandler{
+		userStatusService: service.NewUserStatusService(),
+	}
+}
+
+// ChangeStatus hastanedeki bir kullanıcının hesap durumunu değiştirir
+// @Summary Kullanıcı durumunu değiştir
+// @Description Kullanıcı askıya alınır (suspended), kilitlenir (locked), onaya çekilir (pending) veya tekrar aktif edilir (active). active dışındaki durumlar gerekçe ister ve kullanıcının tüm oturumlarını kapatır; kullanıcı giriş yapamaz. Değişiklik durum geçmişine yazılır
+// @Tags User Management
+// @Accept json
+// @Produce json
+// @Param id path int true "Kullanıcı ID"
+// @Param request body model.ChangeUserStatusRequest true "Yeni durum ve gerekçe"
+// @Success 200 {object} model.UserStatusResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/users/{id}/status [put]
+func (h *UserStatusHandler) ChangeStatus(c echo.Context) error {
+	hospitalID, actorID, targetID, ok := h.getTarget(c)
+	if !ok {
+		return nil
+	}
+
+	var req model.ChangeUserStatusRequest
+	if !bindRequest(c, &req) {
+		return nil
+	}
+
+	response, validationErrors, err := h.userStatusService.ChangeStatus(hospitalID, actorID, targetID, &req, utils.GetClientInfo(c))
+	if len(validationErrors) > 0 {
+		return validationFailed(c, validationErrors)
+	}
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// ListStatusHistory hastanedeki bir kullanıcının durum geçmişini listeler
+// @Summary Kullanıcı durum geçmişi
+// @Description Durum değişiklikleri yeniden eskiye; önceki/yeni durum, gerekçe, işlemi yapan (hastane yöneticisi veya platform operatörü) ve IP
+// @Tags User Management
+// @Produce json
+// @Param id path int true "Kullanıcı ID"
+// @Success 200 {array} model.UserStatusChange
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /hospital/users/{id}/status-history [get]
+func (h *UserStatusHandler) ListStatusHistory(c echo.Context) error {
+	hospitalID, actorID, targetID, ok := h.getTarget(c)
+	if !ok {
+		return nil
+	}
+
+	changes, err := h.userStatusService.ListHistory(hospitalID, actorID, targetID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data": changes,
+	})
+}
+
+// ==================== HELPER METHODS ====================
+
+// getTarget token'dan hastane ve yönetici ID'sini, path'ten kullanıcı ID'sini alır
+// Hata durumunda cevabı kendisi yazar ve ok=false döner
+func (h *UserStatusHandler) getTarget(c echo.Context) (uint, uint, uint, bool) {
+	hospitalID, ok := utils.GetHospitalIDFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+		return 0, 0, 0, false
+	}
+	actorID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, echo.Map{"error": "Geçersiz token"})
+		return 0, 0, 0, false
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, echo.Map{"error": "Geçersiz kullanıcı ID"})
+		return 0, 0, 0, false
+	}
+	return hospitalID, actorID, uint(id), true
+}
+
+// handleError servis hatalarını uygun HTTP durum kodlarına çevirir
+func (h *UserStatusHandler) handleError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrStatusUserNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrPermissionEscalation), errors.Is(err, service.ErrUserStatusSelf):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrUserStatusConflict):
+		return c.JSON(http.StatusConflict, echo.Map{"error": err.Error()})
+	default:
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+}
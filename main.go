@@ -6,7 +6,11 @@
 // @securityDefinitions.apikey BearerAuth
 // @in header
 // @name Authorization
-// @description JWT token için "Bearer " prefix'i ile birlikte token'ı girin. Örnek: Bearer eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9...
+// @description JWT token için "Bearer " prefix'i ile birlikte token'ı girin. Örnek: Bearer eyJhbGciOiJSUzI1NiIsImtpZCI6Ii4uLiJ9...
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description Entegrasyonlar için hastane API anahtarı (hpk_...). Sadece anahtara verilen yetkilerin endpoint'lerinde geçerlidir
 
 package main
 
@@ -14,10 +18,13 @@ import (
 	"hospital-platform/config"
 	"hospital-platform/database"
 	"hospital-platform/handler"
+	"hospital-platform/model"
+	"hospital-platform/service"
 	"hospital-platform/utils" // Middleware'ler için
 
 	_ "hospital-platform/docs" // Swagger docs
 
+	"log"
 	"net/http"
 	"time"
 
@@ -40,13 +47,38 @@ func main() {
 	// Ortam değişkenlerini yükle
 	config.LoadEnv() // .env'den verileri çeksin
 
+	// Güvenlik ayarlarını kontrol et - production'da varsayılan secret ile başlama
+	if err := utils.CheckSecretConfig(); err != nil {
+		log.Fatal("Güvenlik ayarları geçersiz: ", err)
+	}
+	if err := utils.CheckHasherConfig(); err != nil {
+		log.Fatal("Şifre hash ayarları geçersiz: ", err)
+	}
+	if err := utils.CheckNotifierConfig(); err != nil {
+		log.Fatal("Bildirim ayarları geçersiz: ", err)
+	}
+
+	// JWT imza anahtarlarını yükle ve periyodik rotasyonu başlat
+	if err := utils.InitSigningKeys(); err != nil {
+		log.Fatal("JWT imza anahtarları yüklenemedi: ", err)
+	}
+	utils.StartKeyRotation()
+
 	// Veritabanına bağlan
 	database.ConnectDB()
 	database.ConnectRedis()
 
+	// Platform operatörü - PLATFORM_ADMIN_EMAIL/PASSWORD tanımlıysa ve hesap yoksa oluşturulur
+	if err := service.NewPlatformService().EnsureBootstrapAdmin(); err != nil {
+		log.Printf("⚠️ Platform operatörü oluşturulamadı: %v", err)
+	}
+
 	// Echo başlat
 	e := echo.New()
 
+	// İstemci IP'si - X-Forwarded-For sadece güvenilen proxy'lerden kabul edilir
+	e.IPExtractor = utils.ClientIPExtractor()
+
 	// Validator middleware'i kur
 	e.Validator = &CustomValidator{validator: validator.New()}
 
@@ -63,20 +95,57 @@ func main() {
 	})
 
 	// Tüm handler'ları initialize et - dependency injection
-	hospitalHandler := handler.NewHospitalHandler()           // Hastane yönetimi
-	locationHandler := handler.NewLocationHandler()           // İl/İlçe dropdown'ları
-	polyclinicNewHandler := handler.NewPolyclinicNewHandler() // Poliklinik yönetimi
-	staffHandler := handler.NewStaffHandler()                 // Personel yönetimi
+	hospitalHandler := handler.NewHospitalHandler()             // Hastane yönetimi
+	locationHandler := handler.NewLocationHandler()             // İl/İlçe dropdown'ları
+	polyclinicNewHandler := handler.NewPolyclinicNewHandler()   // Poliklinik yönetimi
+	staffHandler := handler.NewStaffHandler()                   // Personel yönetimi
+	mfaHandler := handler.NewMFAHandler()                       // İki adımlı doğrulama
+	roleHandler := handler.NewRoleHandler()                     // Rol ve yetki yönetimi
+	passwordPolicyHandler := handler.NewPasswordPolicyHandler() // Şifre politikası
+	profileHandler := handler.NewProfileHandler()               // Profil ve şifre değiştirme
+	userStatusHandler := handler.NewUserStatusHandler()         // Kullanıcı hesap durumu
+	invitationHandler := handler.NewInvitationHandler()         // Alt kullanıcı davetleri
+	signupHandler := handler.NewSignupHandler()                 // Kayıt başvuruları ve katılım kodları
+	sessionHandler := handler.NewSessionHandler()               // Oturum (cihaz) yönetimi
+	apiKeyHandler := handler.NewAPIKeyHandler()                 // Entegrasyon API anahtarları
+	ssoHandler := handler.NewSSOHandler()                       // OIDC tek oturum açma
+	membershipHandler := handler.NewMembershipHandler()         // Çoklu hastane üyelikleri
+	passkeyHandler := handler.NewPasskeyHandler()               // WebAuthn passkey'ler
+	scheduleHandler := handler.NewScheduleHandler()             // Vardiya ve çalışma programları
+	leaveHandler := handler.NewLeaveHandler()                   // İzin ve devamsızlık yönetimi
+	dutyRosterHandler := handler.NewDutyRosterHandler()         // Nöbet listeleri
+	platformHandler := handler.NewPlatformHandler()             // Platform operatörü (süper admin)
 
 	// ========== 🌍 AÇIK ERİŞİM ROTALARİ (Middleware Yok) ==========
 
+	// Token doğrulama anahtarları - diğer servisler token'ları bununla doğrular
+	e.GET("/.well-known/jwks.json", handler.JWKS)
+
 	// Kimlik doğrulama - herkes erişebilir
 	e.POST("/login", handler.Login)
-	e.POST("/register", handler.Register)
+	e.POST("/login/mfa", mfaHandler.VerifyLogin)
+	e.POST("/login/password-change", handler.LoginPasswordChange)
+	e.POST("/login/passkey/options", passkeyHandler.BeginLogin)
+	e.POST("/login/passkey", passkeyHandler.FinishLogin)
+	e.POST("/token/refresh", handler.RefreshToken)
 	e.POST("/reset-password/request", handler.ResetPasswordRequestHandler)
 	e.POST("/reset-password/confirm", handler.ResetPasswordConfirm)
 
-	// Hastane kaydı - herkes erişebilir
+	// SSO (OIDC) ile giriş - hastanenin kimlik sağlayıcısı üzerinden
+	e.POST("/sso/start", ssoHandler.Start)
+	e.GET("/sso/callback", ssoHandler.Callback)
+	e.POST("/sso/callback", ssoHandler.Callback)
+
+	// Kendi kendine kayıt - hastane ve rol katılım kodu/e-posta alan adından belirlenir, yetkili onayı gerekir
+	e.POST("/signup/email-code", signupHandler.SendEmailCode)
+	e.POST("/signup", signupHandler.Signup)
+
+	// Davet kabulü - link sahibi erişebilir (token imzalı ve tek kullanımlık)
+	e.POST("/invitations/lookup", invitationHandler.LookupInvitation)
+	e.POST("/invitations/phone-code", invitationHandler.SendInvitationPhoneCode)
+	e.POST("/invitations/accept", invitationHandler.AcceptInvitation)
+
+	// Hastane kayıt başvurusu - herkes erişebilir (platform onayına kadar hastane "pending" durumundadır)
 	e.POST("/hospital/register", hospitalHandler.RegisterHospital)
 
 	// Master data - herkes erişebilir (dropdown'lar için)
@@ -88,47 +157,224 @@ func main() {
 
 	// ========== 🔐 KORUNMUŞ ERİŞİM ROTALARİ (JWT Gerekli) ==========
 
+	// Oturum sonlandırma - başvurusu onay bekleyen hastaneler dahil login olan herkes
+	e.POST("/logout", handler.Logout, utils.ApplicationAuthMiddleware())
+
+	// Hastane kayıt başvurusu - onay bekleyen veya reddedilen hastanelerin token'ları sadece burada geçerlidir
+	application := e.Group("/hospital/application")
+	application.Use(utils.ApplicationAuthMiddleware())
+	applicationManage := utils.RequirePermission(model.PermHospitalSettings)
+	application.GET("", hospitalHandler.GetApplication, applicationManage)
+	application.PUT("", hospitalHandler.UpdateApplication, applicationManage, utils.RequireMFA())
+	application.POST("/documents", hospitalHandler.UploadDocument, applicationManage, utils.RequireMFA())
+	application.GET("/documents/:id", hospitalHandler.DownloadDocument, applicationManage)
+	application.DELETE("/documents/:id", hospitalHandler.DeleteDocument, applicationManage, utils.RequireMFA())
+	application.POST("/resubmit", hospitalHandler.ResubmitApplication, applicationManage, utils.RequireMFA())
+
 	// JWT middleware'i olan grup oluştur
 	protected := e.Group("")
 	protected.Use(utils.JWTAuthMiddleware())
 
+	// Profil - login olan herkes kendi bilgileri ve şifresi için
+	protected.GET("/me", profileHandler.GetProfile)
+	protected.PUT("/me", profileHandler.UpdateProfile)
+	protected.POST("/me/contact/verify", profileHandler.SendContactCode)
+	protected.POST("/me/password", profileHandler.ChangePassword)
+
+	// Oturum (cihaz) yönetimi - login olan herkes kendi oturumları için
+	protected.GET("/me/sessions", sessionHandler.ListMySessions)
+	protected.DELETE("/me/sessions", sessionHandler.RevokeMyOtherSessions)
+	protected.DELETE("/me/sessions/:id", sessionHandler.RevokeMySession)
+
+	// Çoklu hastane - kullanıcının üye olduğu hastaneler ve aktif hastane değişimi
+	protected.GET("/me/memberships", membershipHandler.ListMyMemberships)
+	protected.POST("/auth/switch-hospital", membershipHandler.SwitchHospital)
+
+	// İki adımlı doğrulama (TOTP) - login olan herkes kendi hesabı için
+	protected.POST("/me/mfa/totp/enroll", mfaHandler.StartEnrollment)
+	protected.POST("/me/mfa/totp/verify", mfaHandler.ConfirmEnrollment)
+	protected.POST("/me/mfa/recovery-codes", mfaHandler.RegenerateRecoveryCodes)
+	protected.POST("/me/mfa/disable", mfaHandler.Disable)
+
+	// Passkey / güvenlik anahtarı (WebAuthn) - passkey girişi MFA sayıldığı için kayıt MFA politikasına tabi
+	protected.GET("/me/passkeys", passkeyHandler.ListPasskeys)
+	protected.POST("/me/passkeys/options", passkeyHandler.BeginRegistration, utils.RequireMFA())
+	protected.POST("/me/passkeys", passkeyHandler.FinishRegistration, utils.RequireMFA())
+	protected.DELETE("/me/passkeys/:id", passkeyHandler.RevokePasskey)
+
 	// Hastane bilgileri - login olan herkes görebilir
 	protected.GET("/hospital/:id", hospitalHandler.GetHospitalByID)
 
-	// ========== 👀 OKUMA İZNİ GEREKLİ (Hem Yetkili Hem Çalışan) ==========
-
-	// Okuma izni olan grup oluştur
-	readAccess := protected.Group("")
-	readAccess.Use(utils.RequirePermission(utils.READ))
-
-	// Poliklinik görüntüleme - hem yetkili hem çalışan
-	readAccess.GET("/hospital/polyclinics", polyclinicNewHandler.GetHospitalPolyclinics)
-
-	// Personel görüntüleme - hem yetkili hem çalışan
-	readAccess.GET("/hospital/staff/:id", staffHandler.GetStaffByID)
-	readAccess.POST("/hospital/staff/list", staffHandler.GetStaffList) // Filtreleme dahil
-
-	// ========== 🔒 YÖNETİCİ İZNİ GEREKLİ (Sadece Yetkili) ==========
-
-	// Admin izni olan grup oluştur
-	adminAccess := protected.Group("")
-	adminAccess.Use(utils.RequirePermission(utils.ADMIN))
-
-	// Poliklinik yönetimi - sadece yetkili
-	adminAccess.POST("/hospital/polyclinics", polyclinicNewHandler.AddPolyclinicToHospital)
-	adminAccess.PUT("/hospital/polyclinics/:id", polyclinicNewHandler.UpdateHospitalPolyclinic)
-	adminAccess.DELETE("/hospital/polyclinics/:id", polyclinicNewHandler.DeleteHospitalPolyclinic)
-
-	// Personel yönetimi - sadece yetkili
-	adminAccess.POST("/hospital/staff", staffHandler.CreateStaff)
-	adminAccess.PUT("/hospital/staff/:id", staffHandler.UpdateStaff)
-	adminAccess.DELETE("/hospital/staff/:id", staffHandler.DeleteStaff)
-
-	// Alt kullanıcı yönetimi - sadece yetkili
-	adminAccess.POST("/hospital/users", handler.CreateSubUser)
-	adminAccess.GET("/hospital/users", handler.GetSubUsers)
-	adminAccess.PUT("/hospital/users/:id", handler.UpdateSubUser)
-	adminAccess.DELETE("/hospital/users/:id", handler.DeleteSubUser)
+	// Şifre kuralları - şifre belirleme ekranları için login olan herkes görebilir
+	protected.GET("/hospital/settings/password-policy", passwordPolicyHandler.GetPolicy)
+
+	// ========== 🔑 YETKİ BAZLI ROTALAR (Rol → Yetki) ==========
+	// Her route ihtiyaç duyduğu yetkiyi kendisi belirtir; yetkiler hastanenin rol tanımlarından okunur
+
+	// Görüntüleme
+	protected.GET("/hospital/polyclinics", polyclinicNewHandler.GetHospitalPolyclinics, utils.RequirePermission(model.PermPolyclinicsRead))
+	protected.GET("/hospital/staff/:id", staffHandler.GetStaffByID, utils.RequirePermission(model.PermStaffRead))
+	protected.POST("/hospital/staff/list", staffHandler.GetStaffList, utils.RequirePermission(model.PermStaffRead))  // Filtreleme dahil
+	protected.POST("/hospital/staff/export", staffHandler.ExportStaff, utils.RequirePermission(model.PermStaffRead)) // ?format=csv|xlsx|pdf, sayfalamasız
+	protected.GET("/hospital/shift-templates", scheduleHandler.GetShiftTemplates, utils.RequirePermission(model.PermStaffRead))
+	protected.GET("/hospital/staff/:id/schedules", scheduleHandler.GetStaffSchedules, utils.RequirePermission(model.PermStaffRead))
+	protected.GET("/hospital/staff/:id/shifts", scheduleHandler.GetStaffShifts, utils.RequirePermission(model.PermStaffRead)) // ?from=&to= takvim
+	protected.GET("/hospital/leave-types", leaveHandler.GetLeaveTypes, utils.RequirePermission(model.PermStaffRead))
+	protected.GET("/hospital/leave-requests", leaveHandler.ListLeaveRequests, utils.RequirePermission(model.PermStaffRead))
+	protected.GET("/hospital/staff/:id/leave-balances", leaveHandler.GetLeaveBalances, utils.RequirePermission(model.PermStaffRead))
+	protected.GET("/hospital/polyclinics/:id/absences", leaveHandler.GetAbsenceCalendar, utils.RequirePermission(model.PermStaffRead)) // Devamsızlık takvimi
+	protected.GET("/hospital/duty-rosters", dutyRosterHandler.ListDutyRosters, utils.RequirePermission(model.PermStaffRead))
+	protected.GET("/hospital/duty-rosters/:id", dutyRosterHandler.GetDutyRoster, utils.RequirePermission(model.PermStaffRead)) // Dağılım, boş nöbetler, ihlaller
+
+	// Yazma işlemleri - hastane MFA'yı zorunlu kılmışsa MFA doğrulanmış token gerekir
+	privileged := protected.Group("")
+	privileged.Use(utils.RequireMFA())
+
+	// Hastane güvenlik ayarları
+	privileged.PUT("/hospital/settings/mfa", mfaHandler.UpdateHospitalPolicy, utils.RequirePermission(model.PermHospitalSettings))
+	privileged.PUT("/hospital/settings/password-policy", passwordPolicyHandler.UpdatePolicy, utils.RequirePermission(model.PermHospitalSettings))
+
+	privileged.GET("/hospital/settings/sso", ssoHandler.GetConfig, utils.RequirePermission(model.PermHospitalSettings))
+	privileged.PUT("/hospital/settings/sso", ssoHandler.UpdateConfig, utils.RequirePermission(model.PermHospitalSettings))
+	privileged.DELETE("/hospital/settings/sso", ssoHandler.DeleteConfig, utils.RequirePermission(model.PermHospitalSettings))
+
+	// Entegrasyon API anahtarları (HBYS, İK) - anahtarlar JWT'nin yanında X-API-Key header'ıyla kabul edilir
+	privileged.GET("/hospital/api-keys/scopes", apiKeyHandler.GetScopes, utils.RequirePermission(model.PermHospitalSettings))
+	privileged.GET("/hospital/api-keys", apiKeyHandler.ListAPIKeys, utils.RequirePermission(model.PermHospitalSettings))
+	privileged.POST("/hospital/api-keys", apiKeyHandler.CreateAPIKey, utils.RequirePermission(model.PermHospitalSettings))
+	privileged.DELETE("/hospital/api-keys/:id", apiKeyHandler.RevokeAPIKey, utils.RequirePermission(model.PermHospitalSettings))
+
+	// Poliklinik yönetimi (polyclinics:write:own sadece kendi polikliniğini güncelleyebilir)
+	privileged.POST("/hospital/polyclinics", polyclinicNewHandler.AddPolyclinicToHospital, utils.RequirePermission(model.PermPolyclinicsWrite))
+	privileged.PUT("/hospital/polyclinics/:id", polyclinicNewHandler.UpdateHospitalPolyclinic, utils.RequireAnyPermission(model.PermPolyclinicsWrite, model.PermPolyclinicsWriteOwn))
+	privileged.DELETE("/hospital/polyclinics/:id", polyclinicNewHandler.DeleteHospitalPolyclinic, utils.RequirePermission(model.PermPolyclinicsDelete))
+
+	// Personel yönetimi
+	privileged.POST("/hospital/staff", staffHandler.CreateStaff, utils.RequirePermission(model.PermStaffWrite))
+	privileged.POST("/hospital/staff/import", staffHandler.ImportStaff, utils.RequirePermission(model.PermStaffWrite)) // CSV/XLSX, dry_run varsayılan
+	privileged.PUT("/hospital/staff/:id", staffHandler.UpdateStaff, utils.RequirePermission(model.PermStaffWrite))
+	privileged.DELETE("/hospital/staff/:id", staffHandler.DeleteStaff, utils.RequirePermission(model.PermStaffWrite))
+
+	// Vardiya şablonları ve personel çalışma programları (çakışma ve dinlenme süresi kontrollü)
+	privileged.POST("/hospital/shift-templates", scheduleHandler.CreateShiftTemplate, utils.RequirePermission(model.PermStaffWrite))
+	privileged.PUT("/hospital/shift-templates/:id", scheduleHandler.UpdateShiftTemplate, utils.RequirePermission(model.PermStaffWrite))
+	privileged.DELETE("/hospital/shift-templates/:id", scheduleHandler.DeleteShiftTemplate, utils.RequirePermission(model.PermStaffWrite))
+	privileged.POST("/hospital/staff/:id/schedules", scheduleHandler.CreateStaffSchedule, utils.RequirePermission(model.PermStaffWrite))
+	privileged.PUT("/hospital/staff/:id/schedules/:schedule_id", scheduleHandler.UpdateStaffSchedule, utils.RequirePermission(model.PermStaffWrite))
+	privileged.DELETE("/hospital/staff/:id/schedules/:schedule_id", scheduleHandler.DeleteStaffSchedule, utils.RequirePermission(model.PermStaffWrite))
+
+	// İzin yönetimi - talepler personel adına staff:write ile girilir, leaves:approve yetkisiyle onaylanır
+	leavesApprove := utils.RequirePermission(model.PermLeavesApprove)
+	privileged.POST("/hospital/leave-requests", leaveHandler.CreateLeaveRequest, utils.RequirePermission(model.PermStaffWrite))
+	privileged.POST("/hospital/leave-requests/:id/cancel", leaveHandler.CancelLeaveRequest, utils.RequireAnyPermission(model.PermStaffWrite, model.PermLeavesApprove))
+	privileged.POST("/hospital/leave-requests/:id/approve", leaveHandler.ApproveLeaveRequest, leavesApprove)
+	privileged.POST("/hospital/leave-requests/:id/reject", leaveHandler.RejectLeaveRequest, leavesApprove)
+	privileged.POST("/hospital/leave-types", leaveHandler.CreateLeaveType, leavesApprove)
+	privileged.PUT("/hospital/leave-types/:id", leaveHandler.UpdateLeaveType, leavesApprove)
+	privileged.DELETE("/hospital/leave-types/:id", leaveHandler.DeleteLeaveType, leavesApprove)
+	privileged.PUT("/hospital/staff/:id/leave-balances", leaveHandler.SetLeaveBalance, leavesApprove)
+
+	// Nöbet listeleri - seed ile tekrarlanabilir üretim, taslakta elle düzenleme, yayınlama
+	rostersManage := utils.RequirePermission(model.PermRostersManage)
+	privileged.POST("/hospital/duty-rosters", dutyRosterHandler.CreateDutyRoster, rostersManage)
+	privileged.DELETE("/hospital/duty-rosters/:id", dutyRosterHandler.DeleteDutyRoster, rostersManage)
+	privileged.POST("/hospital/duty-rosters/:id/publish", dutyRosterHandler.PublishDutyRoster, rostersManage)
+	privileged.POST("/hospital/duty-rosters/:id/assignments", dutyRosterHandler.AddDutyAssignment, rostersManage)
+	privileged.PUT("/hospital/duty-rosters/:id/assignments/:assignment_id", dutyRosterHandler.UpdateDutyAssignment, rostersManage)
+	privileged.DELETE("/hospital/duty-rosters/:id/assignments/:assignment_id", dutyRosterHandler.DeleteDutyAssignment, rostersManage)
+
+	// Alt kullanıcı yönetimi
+	usersManage := utils.RequirePermission(model.PermUsersManage)
+	privileged.POST("/hospital/users", handler.CreateSubUser, usersManage)
+	privileged.GET("/hospital/users", handler.GetSubUsers, usersManage)
+	privileged.PUT("/hospital/users/:id", handler.UpdateSubUser, usersManage)
+	privileged.DELETE("/hospital/users/:id", handler.DeleteSubUser, usersManage)
+	privileged.POST("/hospital/users/:id/unlock", handler.UnlockSubUser, usersManage)
+	privileged.POST("/hospital/users/:id/expire-password", handler.ExpireSubUserPassword, usersManage)
+	privileged.PUT("/hospital/users/:id/status", userStatusHandler.ChangeStatus, usersManage)
+	privileged.GET("/hospital/users/:id/status-history", userStatusHandler.ListStatusHistory, usersManage)
+	privileged.GET("/hospital/users/:id/sessions", sessionHandler.ListUserSessions, usersManage)
+	privileged.DELETE("/hospital/users/:id/sessions", sessionHandler.RevokeAllUserSessions, usersManage)
+	privileged.DELETE("/hospital/users/:id/sessions/:sessionId", sessionHandler.RevokeUserSession, usersManage)
+	privileged.GET("/hospital/members", membershipHandler.ListMembers, usersManage)
+	privileged.POST("/hospital/members", membershipHandler.AddMember, usersManage)
+	privileged.PUT("/hospital/members/:id", membershipHandler.UpdateMember, usersManage)
+	privileged.DELETE("/hospital/members/:id", membershipHandler.RemoveMember, usersManage)
+	privileged.POST("/hospital/invitations", invitationHandler.CreateInvitation, usersManage)
+	privileged.GET("/hospital/invitations", invitationHandler.ListInvitations, usersManage)
+	privileged.POST("/hospital/invitations/:id/resend", invitationHandler.ResendInvitation, usersManage)
+	privileged.DELETE("/hospital/invitations/:id", invitationHandler.RevokeInvitation, usersManage)
+	privileged.GET("/hospital/signup-requests", signupHandler.ListSignupRequests, usersManage)
+	privileged.POST("/hospital/signup-requests/:id/approve", signupHandler.ApproveSignupRequest, usersManage)
+	privileged.POST("/hospital/signup-requests/:id/reject", signupHandler.RejectSignupRequest, usersManage)
+	privileged.GET("/hospital/join-codes", signupHandler.ListJoinCodes, usersManage)
+	privileged.POST("/hospital/join-codes", signupHandler.CreateJoinCode, usersManage)
+	privileged.DELETE("/hospital/join-codes/:id", signupHandler.RevokeJoinCode, usersManage)
+	privileged.GET("/hospital/email-domains", signupHandler.ListEmailDomains, usersManage)
+	privileged.POST("/hospital/email-domains", signupHandler.AddEmailDomain, usersManage)
+	privileged.DELETE("/hospital/email-domains/:id", signupHandler.RemoveEmailDomain, usersManage)
+
+	// Rol ve yetki yönetimi
+	rolesManage := utils.RequirePermission(model.PermRolesManage)
+	privileged.GET("/hospital/permissions", roleHandler.GetPermissions, rolesManage)
+	privileged.GET("/hospital/roles", roleHandler.GetRoles, rolesManage)
+	privileged.POST("/hospital/roles", roleHandler.CreateRole, rolesManage)
+	privileged.PUT("/hospital/roles/:id", roleHandler.UpdateRole, rolesManage)
+	privileged.DELETE("/hospital/roles/:id", roleHandler.DeleteRole, rolesManage)
+
+	// ========== 🛡️ PLATFORM OPERATÖRÜ ROTALARİ (Platform Token Gerekli) ==========
+
+	// Operatör girişi - hastane hesaplarından bağımsız
+	e.POST("/platform/login", platformHandler.Login)
+
+	// Platform token middleware'i olan grup - hastane token'ları ve API anahtarları burada geçersiz
+	platform := e.Group("/platform")
+	platform.Use(utils.PlatformAuthMiddleware())
+
+	// TOTP kaydı - kayıt tamamlanmamış operatör sadece buraya erişebilir
+	platform.POST("/me/mfa/enroll", platformHandler.StartMFAEnrollment)
+	platform.POST("/me/mfa/verify", platformHandler.ConfirmMFAEnrollment)
+
+	// Diğer tüm platform işlemleri TOTP ile doğrulanmış token ister
+	platformAdmin := platform.Group("")
+	platformAdmin.Use(utils.RequirePlatformMFA())
+
+	// Hastaneler
+	platformAdmin.POST("/hospitals/list", platformHandler.ListHospitals)
+	platformAdmin.GET("/hospitals/:id", platformHandler.GetHospital)
+	platformAdmin.POST("/hospitals/:id/suspend", platformHandler.SuspendHospital)
+	platformAdmin.POST("/hospitals/:id/reactivate", platformHandler.ReactivateHospital)
+	platformAdmin.POST("/hospitals/:id/approve", platformHandler.ApproveHospital)
+	platformAdmin.POST("/hospitals/:id/reject", platformHandler.RejectHospital)
+	platformAdmin.GET("/hospitals/:id/documents/:documentId", platformHandler.DownloadHospitalDocument)
+
+	// Kullanıcı desteği
+	platformAdmin.POST("/users/search", platformHandler.SearchUsers)
+	platformAdmin.POST("/users/:id/unlock", platformHandler.UnlockUser)
+	platformAdmin.POST("/users/:id/reset-mfa", platformHandler.ResetUserMFA)
+	platformAdmin.POST("/users/:id/revoke-sessions", platformHandler.RevokeUserSessions)
+	platformAdmin.POST("/users/:id/expire-password", platformHandler.ExpireUserPassword)
+	platformAdmin.PUT("/users/:id/status", platformHandler.ChangeUserStatus)
+
+	// Master data yönetimi
+	platformAdmin.POST("/master-data/provinces", platformHandler.CreateProvince)
+	platformAdmin.PUT("/master-data/provinces/:id", platformHandler.UpdateProvince)
+	platformAdmin.DELETE("/master-data/provinces/:id", platformHandler.DeleteProvince)
+	platformAdmin.POST("/master-data/districts", platformHandler.CreateDistrict)
+	platformAdmin.PUT("/master-data/districts/:id", platformHandler.UpdateDistrict)
+	platformAdmin.DELETE("/master-data/districts/:id", platformHandler.DeleteDistrict)
+	platformAdmin.POST("/master-data/job-groups", platformHandler.CreateJobGroup)
+	platformAdmin.PUT("/master-data/job-groups/:id", platformHandler.UpdateJobGroup)
+	platformAdmin.DELETE("/master-data/job-groups/:id", platformHandler.DeleteJobGroup)
+	platformAdmin.POST("/master-data/job-titles", platformHandler.CreateJobTitle)
+	platformAdmin.PUT("/master-data/job-titles/:id", platformHandler.UpdateJobTitle)
+	platformAdmin.DELETE("/master-data/job-titles/:id", platformHandler.DeleteJobTitle)
+	platformAdmin.POST("/master-data/polyclinic-types", platformHandler.CreatePolyclinicType)
+	platformAdmin.PUT("/master-data/polyclinic-types/:id", platformHandler.UpdatePolyclinicType)
+	platformAdmin.DELETE("/master-data/polyclinic-types/:id", platformHandler.DeletePolyclinicType)
+
+	// Denetim kaydı
+	platformAdmin.POST("/audit-logs/list", platformHandler.ListAuditLogs)
 
 	// ========== POLYCLINIC ROUTES (Legacy - Geriye Uyumluluk) ==========
 	// Legacy polyclinic endpoints
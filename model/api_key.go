@@ -0,0 +1,120 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// API anahtarı durumları (hesaplanan)
+const (
+	APIKeyActive  = "active"
+	APIKeyExpired = "expired"
+	APIKeyRevoked = "revoked"
+)
+
+// APIKeyScopes API anahtarlarına verilebilen yetkiler
+// Hesap/hastane yönetimi yetkileri (PrivilegedPermissions) insan kullanıcıda kalır;
+// polyclinics:write:own kullanıcının polikliniğine bağlı olduğu için anahtara verilemez
+var APIKeyScopes = []string{
+	PermStaffRead,
+	PermStaffWrite,
+	PermPolyclinicsRead,
+	PermPolyclinicsWrite,
+	PermPolyclinicsDelete,
+}
+
+// IsAPIKeyScope yetkinin API anahtarına verilebilir olup olmadığını kontrol eder
+func IsAPIKeyScope(name string) bool {
+	for _, scope := range APIKeyScopes {
+		if scope == name {
+			return true
+		}
+	}
+	return false
+}
+
+// @Description Makineler arası entegrasyon (HBYS, İK) için hastaneye ait API anahtarı - anahtar sadece hash olarak saklanır
+type APIKey struct {
+	gorm.Model `swaggerignore:"true"`
+	HospitalID uint          `json:"hospital_id" gorm:"not null;index" example:"1"`
+	Name       string        `json:"name" gorm:"not null" example:"HBYS entegrasyonu"`
+	KeyHash    string        `json:"-" gorm:"not null;uniqueIndex"`              // Anahtarın SHA-256 hash'i
+	KeyHint    string        `json:"key_hint" example:"hpk_Xq3v"`                // Listede tanımak için anahtarın ilk karakterleri
+	Scopes     []APIKeyScope `json:"-" gorm:"foreignKey:APIKeyID"`               // Anahtara verilen yetkiler
+	AllowedIPs string        `json:"-"`                                          // Virgülle ayrılmış IP/CIDR listesi, boşsa her IP
+	ExpiresAt  time.Time     `json:"expires_at"`                                 // Son kullanma zamanı
+	RevokedAt  *time.Time    `json:"revoked_at,omitempty"`                       // İptal zamanı
+	RevokedBy  *uint         `json:"revoked_by,omitempty" example:"1"`           // İptal eden yetkili
+	LastUsedAt *time.Time    `json:"last_used_at,omitempty"`                     // Son kullanım (dakika hassasiyetinde)
+	LastUsedIP string        `json:"last_used_ip,omitempty" example:"10.0.4.12"` // Son kullanılan IP
+	CreatedBy  uint          `json:"created_by" example:"1"`                     // Oluşturan yetkili
+}
+
+// APIKeyScope - API anahtarına verilmiş tek bir yetki
+type APIKeyScope struct {
+	APIKeyID uint   `gorm:"primaryKey"`
+	Scope    string `gorm:"primaryKey"`
+}
+
+// EffectiveStatus anahtarın şu anki durumunu hesaplar
+func (k *APIKey) EffectiveStatus() string {
+	switch {
+	case k.RevokedAt != nil:
+		return APIKeyRevoked
+	case time.Now().After(k.ExpiresAt):
+		return APIKeyExpired
+	default:
+		return APIKeyActive
+	}
+}
+
+// ScopeNames anahtara verilen yetki isimlerini döndürür
+func (k *APIKey) ScopeNames() []string {
+	names := make([]string, 0, len(k.Scopes))
+	for _, scope := range k.Scopes {
+		names = append(names, scope.Scope)
+	}
+	return names
+}
+
+// AllowedIPList izin verilen IP/CIDR listesini döndürür
+func (k *APIKey) AllowedIPList() []string {
+	if k.AllowedIPs == "" {
+		return []string{}
+	}
+	return strings.Split(k.AllowedIPs, ",")
+}
+
+// ==================== API ANAHTARI DTO'ları ====================
+
+// @Description API anahtarı oluşturma isteği
+type CreateAPIKeyRequest struct {
+	Name          string   `json:"name" example:"HBYS entegrasyonu" binding:"required"`   // Anahtarın adı
+	Scopes        []string `json:"scopes" example:"staff:read" binding:"required"`        // Yetkiler (GET /hospital/api-keys/scopes)
+	ExpiresInDays int      `json:"expires_in_days" example:"180" binding:"required"`      // Geçerlilik süresi (gün)
+	AllowedIPs    []string `json:"allowed_ips,omitempty" example:"10.0.4.12,10.0.5.0/24"` // İzin verilen IP/CIDR'lar (boşsa her IP)
+}
+
+// @Description API anahtarı bilgisi
+type APIKeyResponse struct {
+	ID         uint       `json:"id" example:"1"`
+	Name       string     `json:"name" example:"HBYS entegrasyonu"`
+	KeyHint    string     `json:"key_hint" example:"hpk_Xq3v"`
+	Scopes     []string   `json:"scopes" example:"staff:read"`
+	AllowedIPs []string   `json:"allowed_ips" example:"10.0.4.12"`
+	Status     string     `json:"status" example:"active"` // active, expired, revoked
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	LastUsedIP string     `json:"last_used_ip,omitempty" example:"10.0.4.12"`
+	CreatedBy  uint       `json:"created_by" example:"1"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// @Description Yeni oluşturulan API anahtarı - anahtar sadece bu cevapta gösterilir
+type CreateAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key" example:"hpk_Xq3vR8mZ0c2Lk9PwYt4sNa7bUe1fHj6dGi5oKl3QrVw"` // İsteklerde X-API-Key header'ında gönderilir
+}
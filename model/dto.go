@@ -26,10 +26,12 @@ type HospitalRegistrationRequest struct {
 // HospitalRegistrationResponse represents successful registration response
 // @Description Hastane kayıt yanıtı
 type HospitalRegistrationResponse struct {
-	Message   string   `json:"message" example:"Hastane başarıyla kaydedildi"`
-	Hospital  Hospital `json:"hospital"`
-	AdminUser User     `json:"admin_user"`
-	Token     string   `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	Message      string   `json:"message" example:"Hastane başarıyla kaydedildi"`
+	Hospital     Hospital `json:"hospital"`
+	AdminUser    User     `json:"admin_user"`
+	Token        string   `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	RefreshToken string   `json:"refresh_token" example:"q1w2e3r4t5y6..."`
+	ExpiresIn    int      `json:"expires_in" example:"900"`
 }
 
 // ValidationError represents validation error details
@@ -82,27 +84,27 @@ type StaffCountByGroup struct {
 // Hem zorunlu alanlar hem de isteğe bağlı alanlar içerir
 // @Description Hastaneye yeni personel eklerken gönderilecek veriler
 type CreateStaffRequest struct {
-	FirstName    string `json:"first_name" example:"Dr. Mehmet" binding:"required"` // Personelin adı (zorunlu alan)
-	LastName     string `json:"last_name" example:"Özkan" binding:"required"`       // Personelin soyadı (zorunlu alan)
-	TCKN         string `json:"tc" example:"98765432101" binding:"required"`        // TC Kimlik numarası - sistemde benzersiz olmalı
-	Phone        string `json:"phone" example:"05559876543" binding:"required"`     // Telefon numarası - sistemde benzersiz olmalı
-	JobGroupID   uint   `json:"job_group_id" example:"1" binding:"required"`        // Hangi meslek grubuna ait (Doktor, Hemşire vb.)
-	JobTitleID   uint   `json:"job_title_id" example:"1" binding:"required"`        // Unvanı (Başhekim, Uzman Doktor vb.) - bazıları unique
-	PolyclinicID *uint  `json:"polyclinic_id,omitempty" example:"1"`                // Hangi poliklinikte çalışacak (opsiyonel)
-	WorkDays     []int  `json:"work_days" example:"[1,2,3,4,5]" binding:"required"` // Hangi günler çalışacak (1:Pzt, 7:Paz)
+	FirstName       string `json:"first_name" example:"Dr. Mehmet" binding:"required"` // Personelin adı (zorunlu alan)
+	LastName        string `json:"last_name" example:"Özkan" binding:"required"`       // Personelin soyadı (zorunlu alan)
+	TCKN            string `json:"tc" example:"98765432101" binding:"required"`        // TC Kimlik numarası - sistemde benzersiz olmalı
+	Phone           string `json:"phone" example:"05559876543" binding:"required"`     // Telefon numarası - sistemde benzersiz olmalı
+	JobGroupID      uint   `json:"job_group_id" example:"1" binding:"required"`        // Hangi meslek grubuna ait (Doktor, Hemşire vb.)
+	JobTitleID      uint   `json:"job_title_id" example:"1" binding:"required"`        // Unvanı (Başhekim, Uzman Doktor vb.) - bazıları unique
+	PolyclinicID    *uint  `json:"polyclinic_id,omitempty" example:"1"`                // Hangi poliklinikte çalışacak (opsiyonel)
+	WorkDays        []int  `json:"work_days" example:"1,2,3,4,5" binding:"required"`   // Hangi günler çalışacak (1:Pzt, 7:Paz) - bugünden başlayan haftalık program oluşturulur
+	ShiftTemplateID *uint  `json:"shift_template_id,omitempty" example:"2"`            // Bu günlerde çalışılacak vardiya (boşsa hastanenin "Mesai" vardiyası)
 }
 
 // UpdateStaffRequest represents updating staff request
 // @Description Personel güncelleme verisi
 type UpdateStaffRequest struct {
-	FirstName    string `json:"first_name" example:"Dr. Ahmet" binding:"required"`    // Ad
-	LastName     string `json:"last_name" example:"Yılmaz" binding:"required"`        // Soyad
-	Phone        string `json:"phone" example:"05551234567" binding:"required"`       // Telefon
-	JobGroupID   uint   `json:"job_group_id" example:"2" binding:"required"`          // Meslek grubu ID
-	JobTitleID   uint   `json:"job_title_id" example:"3" binding:"required"`          // Unvan ID
-	PolyclinicID *uint  `json:"polyclinic_id,omitempty" example:"2"`                  // Poliklinik ID (nullable)
-	WorkDays     []int  `json:"work_days" example:"[1,2,3,4,5,6]" binding:"required"` // Çalışma günleri
-	IsActive     bool   `json:"is_active" example:"true"`                             // Aktif mi?
+	FirstName    string `json:"first_name" example:"Dr. Ahmet" binding:"required"` // Ad
+	LastName     string `json:"last_name" example:"Yılmaz" binding:"required"`     // Soyad
+	Phone        string `json:"phone" example:"05551234567" binding:"required"`    // Telefon
+	JobGroupID   uint   `json:"job_group_id" example:"2" binding:"required"`       // Meslek grubu ID
+	JobTitleID   uint   `json:"job_title_id" example:"3" binding:"required"`       // Unvan ID
+	PolyclinicID *uint  `json:"polyclinic_id,omitempty" example:"2"`               // Poliklinik ID (nullable)
+	IsActive     bool   `json:"is_active" example:"true"`                          // Aktif mi?
 }
 
 // StaffListRequest represents staff filtering and pagination request
@@ -140,8 +142,9 @@ type StaffSummary struct {
 	JobGroupName       string  `json:"job_group_name" example:"Doktor"`                      // Meslek grubu adı
 	JobTitleName       string  `json:"job_title_name" example:"Uzman Doktor"`                // Unvan adı
 	PolyclinicTypeName *string `json:"polyclinic_type_name,omitempty" example:"Kardiyoloji"` // Poliklinik adı (nullable)
-	WorkDaysText       string  `json:"work_days_text" example:"Pazartesi-Cuma"`              // Çalışma günleri metni
+	WorkDaysText       string  `json:"work_days_text" example:"Pazartesi-Cuma 08:00-17:00"`  // Bugün geçerli çalışma programının metni
 	IsActive           bool    `json:"is_active" example:"true"`                             // Aktif mi?
+	LeaveTypeName      *string `json:"leave_type_name,omitempty" example:"Yıllık İzin"`      // Bugün onaylı izindeyse izin türü
 }
 
 // PaginationInfo represents pagination metadata
@@ -160,22 +163,23 @@ type PaginationInfo struct {
 // CreateSubUserRequest represents creating sub user request
 // @Description Alt kullanıcı ekleme verisi
 type CreateSubUserRequest struct {
-	FirstName string `json:"first_name" example:"Mehmet" binding:"required"`                     // Ad
-	LastName  string `json:"last_name" example:"Yılmaz" binding:"required"`                      // Soyad
-	TCKN      string `json:"tc" example:"12345678901" binding:"required"`                        // TC Kimlik No
-	Email     string `json:"email" example:"mehmet.yilmaz@example.com" binding:"required,email"` // E-posta
-	Phone     string `json:"phone" example:"05551234567" binding:"required"`                     // Telefon
-	Password  string `json:"password" example:"123456" binding:"required,min=6"`                 // Şifre
-	Role      string `json:"role" example:"çalışan" binding:"required,oneof=yetkili çalışan"`    // Rol
+	FirstName    string `json:"first_name" example:"Mehmet" binding:"required"`                     // Ad
+	LastName     string `json:"last_name" example:"Yılmaz" binding:"required"`                      // Soyad
+	TCKN         string `json:"tc" example:"12345678901" binding:"required"`                        // TC Kimlik No
+	Email        string `json:"email" example:"mehmet.yilmaz@example.com" binding:"required,email"` // E-posta
+	Phone        string `json:"phone" example:"05551234567" binding:"required"`                     // Telefon
+	Password     string `json:"password" example:"123456" binding:"required,min=6"`                 // Şifre
+	Role         string `json:"role" example:"çalışan" binding:"required"`                          // Rol adı (hastanede tanımlı)
+	PolyclinicID *uint  `json:"polyclinic_id,omitempty" example:"3"`                                // Bağlı olduğu poliklinik (opsiyonel)
 }
 
 // UpdateSubUserRequest represents updating sub user request
 // @Description Alt kullanıcı güncelleme verisi
 type UpdateSubUserRequest struct {
-	FirstName string `json:"first_name" example:"Ahmet" binding:"required"`                    // Ad
-	LastName  string `json:"last_name" example:"Özkan" binding:"required"`                     // Soyad
-	Email     string `json:"email" example:"ahmet.ozkan@example.com" binding:"required,email"` // E-posta
-	Phone     string `json:"phone" example:"05559876543" binding:"required"`                   // Telefon
-	Role      string `json:"role" example:"yetkili" binding:"required,oneof=yetkili çalışan"`  // Rol
-	IsActive  bool   `json:"is_active" example:"true"`                                         // Aktif mi?
+	FirstName    string `json:"first_name" example:"Ahmet" binding:"required"`                    // Ad
+	LastName     string `json:"last_name" example:"Özkan" binding:"required"`                     // Soyad
+	Email        string `json:"email" example:"ahmet.ozkan@example.com" binding:"required,email"` // E-posta
+	Phone        string `json:"phone" example:"05559876543" binding:"required"`                   // Telefon
+	Role         string `json:"role" example:"yetkili" binding:"required"`                        // Rol adı (hastanede tanımlı)
+	PolyclinicID *uint  `json:"polyclinic_id,omitempty" example:"3"`                              // Bağlı olduğu poliklinik (opsiyonel)
 }
@@ -0,0 +1,172 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Nöbet listesi durumları
+const (
+	DutyRosterDraft     = "draft"     // Oluşturuldu, yöneticiler düzenleyebilir
+	DutyRosterPublished = "published" // Yayınlandı - nöbetler personel takviminde görünür, değiştirilemez
+)
+
+// Nöbet slotunun geçerli olduğu gün türleri
+const (
+	DutyDayAll     = "all"     // Her gün
+	DutyDayWeekday = "weekday" // Hafta içi (resmi tatiller hariç)
+	DutyDayWeekend = "weekend" // Cumartesi, Pazar ve resmi tatiller
+)
+
+// DutyRoster - Poliklinik veya meslek grubu için belirli bir dönemin nöbet listesi
+// Aynı seed ve aynı girdilerle üretici her zaman aynı listeyi oluşturur
+// @Description Nöbet listesi
+type DutyRoster struct {
+	gorm.Model           `swaggerignore:"true"`
+	HospitalID           uint             `json:"hospital_id" gorm:"not null;index" example:"1"`
+	Name                 string           `json:"name" gorm:"not null" example:"Kardiyoloji Kasım 2025 nöbetleri"`
+	PolyclinicID         *uint            `json:"polyclinic_id,omitempty" example:"3"`                                          // Aday personelin polikliniği
+	JobGroupID           *uint            `json:"job_group_id,omitempty" example:"1"`                                           // Aday personelin meslek grubu
+	StartDate            time.Time        `json:"start_date" gorm:"type:date;not null" example:"2025-11-01T00:00:00Z"`          // İlk nöbet günü
+	EndDate              time.Time        `json:"end_date" gorm:"type:date;not null" example:"2025-11-30T00:00:00Z"`            // Son nöbet günü (dahil)
+	Holidays             []string         `json:"holidays" gorm:"type:text;serializer:json" example:"2025-10-29"`               // Hafta sonu gibi sayılan resmi tatiller (YYYY-AA-GG)
+	MaxConsecutiveNights int              `json:"max_consecutive_nights" gorm:"not null" example:"1"`                           // Art arda en fazla gece nöbeti
+	MinRestHours         int              `json:"min_rest_hours" gorm:"not null" example:"24"`                                  // İki nöbet arası en az dinlenme (saat)
+	Seed                 int64            `json:"seed" gorm:"not null" example:"20251101"`                                      // Üreticinin eşitlik bozma tohumu
+	Status               string           `json:"status" gorm:"not null;index" example:"draft"`                                 // draft, published
+	CreatedBy            *uint            `json:"created_by,omitempty" example:"1"`                                             // Oluşturan kullanıcı
+	PublishedBy          *uint            `json:"published_by,omitempty" example:"1"`                                           // Yayınlayan kullanıcı
+	PublishedAt          *time.Time       `json:"published_at,omitempty"`                                                       // Yayın zamanı
+	Slots                []DutyRosterSlot `json:"slots" gorm:"foreignKey:RosterID;constraint:OnDelete:CASCADE"`                 // Günlük nöbet ihtiyacı
+	Assignments          []DutyAssignment `json:"assignments,omitempty" gorm:"foreignKey:RosterID;constraint:OnDelete:CASCADE"` // Atanan nöbetler
+}
+
+// IsHoliday günün listede resmi tatil olarak işaretlenip işaretlenmediğini kontrol eder
+func (r *DutyRoster) IsHoliday(day time.Time) bool {
+	date := day.Format("2006-01-02")
+	for _, holiday := range r.Holidays {
+		if holiday == date {
+			return true
+		}
+	}
+	return false
+}
+
+// IsWeekend günün hafta sonu nöbeti sayılıp sayılmadığını döndürür (Cumartesi, Pazar veya resmi tatil)
+func (r *DutyRoster) IsWeekend(day time.Time) bool {
+	return day.Weekday() == time.Saturday || day.Weekday() == time.Sunday || r.IsHoliday(day)
+}
+
+// DutyRosterSlot - Listede her uygun gün için doldurulacak nöbet (vardiya ve kişi sayısı)
+type DutyRosterSlot struct {
+	ID              uint          `json:"id" gorm:"primaryKey" example:"1"`
+	RosterID        uint          `json:"-" gorm:"not null;index"`
+	ShiftTemplateID uint          `json:"shift_template_id" gorm:"not null" example:"2"`
+	DayType         string        `json:"day_type" gorm:"not null" example:"weekday"` // all, weekday, weekend
+	Headcount       int           `json:"headcount" gorm:"not null" example:"2"`      // Aynı anda nöbette olacak kişi sayısı
+	ShiftTemplate   ShiftTemplate `json:"shift_template" gorm:"foreignKey:ShiftTemplateID"`
+}
+
+// AppliesTo slotun verilen günde doldurulup doldurulmayacağını döndürür
+func (s *DutyRosterSlot) AppliesTo(roster *DutyRoster, day time.Time) bool {
+	switch s.DayType {
+	case DutyDayWeekday:
+		return !roster.IsWeekend(day)
+	case DutyDayWeekend:
+		return roster.IsWeekend(day)
+	default:
+		return true
+	}
+}
+
+// DutyAssignment - Bir personelin bir günkü nöbeti
+// Başlangıç ve bitiş atama anında vardiyadan hesaplanır, vardiya sonradan değişse de yayınlanmış nöbet değişmez
+// @Description Nöbet ataması
+type DutyAssignment struct {
+	ID       uint      `json:"id" gorm:"primaryKey" example:"10"`
+	RosterID uint      `json:"roster_id" gorm:"not null;index" example:"4"`
+	SlotID   uint      `json:"slot_id" gorm:"not null;index" example:"1"`
+	StaffID  uint      `json:"staff_id" gorm:"not null;index" example:"42"`
+	Date     time.Time `json:"date" gorm:"type:date;not null" example:"2025-11-01T00:00:00Z"` // Nöbetin başladığı gün
+	StartsAt time.Time `json:"starts_at" gorm:"not null" example:"2025-11-01T08:00:00+03:00"`
+	EndsAt   time.Time `json:"ends_at" gorm:"not null" example:"2025-11-02T08:00:00+03:00"`
+	Night    bool      `json:"night" gorm:"not null" example:"true"`   // Gece yarısını geçen nöbet
+	Manual   bool      `json:"manual" gorm:"not null" example:"false"` // Yönetici tarafından elle atandı/değiştirildi
+
+	// Listelerde gösterim için
+	StaffName       string `json:"staff_name,omitempty" gorm:"->;-:migration" example:"Dr. Mehmet Özkan"`
+	ShiftName       string `json:"shift_name,omitempty" gorm:"->;-:migration" example:"Gece"`
+	ShiftTemplateID uint   `json:"shift_template_id,omitempty" gorm:"->;-:migration" example:"2"`
+
+	// Üretici henüz kaydedilmemiş slotu işaret eder, SlotID kayıt sırasında buradan doldurulur
+	Slot *DutyRosterSlot `json:"-" gorm:"-"`
+}
+
+// Hours nöbetin süresini saat olarak döndürür
+func (a *DutyAssignment) Hours() float64 {
+	return a.EndsAt.Sub(a.StartsAt).Hours()
+}
+
+// ==================== NÖBET LİSTESİ DTO'ları ====================
+
+// CreateDutyRosterRequest represents duty roster generation request
+// @Description Nöbet listesi oluşturma verisi - polyclinic_id ve/veya job_group_id zorunlu
+type CreateDutyRosterRequest struct {
+	Name                 string                  `json:"name" example:"Kardiyoloji Kasım 2025 nöbetleri" binding:"required"`
+	PolyclinicID         *uint                   `json:"polyclinic_id,omitempty" example:"3"`
+	JobGroupID           *uint                   `json:"job_group_id,omitempty" example:"1"`
+	StartDate            string                  `json:"start_date" example:"2025-11-01" binding:"required"` // YYYY-AA-GG
+	EndDate              string                  `json:"end_date" example:"2025-11-30" binding:"required"`   // YYYY-AA-GG, dahil
+	Holidays             []string                `json:"holidays,omitempty" example:"2025-10-29"`            // Dönem içindeki resmi tatiller
+	Slots                []DutyRosterSlotRequest `json:"slots" binding:"required"`
+	MaxConsecutiveNights int                     `json:"max_consecutive_nights,omitempty" example:"1"` // Varsayılan 1
+	MinRestHours         *int                    `json:"min_rest_hours,omitempty" example:"24"`        // Varsayılan SCHEDULE_MIN_REST
+	Seed                 *int64                  `json:"seed,omitempty" example:"42"`                  // Verilmezse rastgele üretilir ve listede saklanır
+}
+
+// DutyRosterSlotRequest günlük nöbet ihtiyacı
+// @Description Nöbet slotu
+type DutyRosterSlotRequest struct {
+	ShiftTemplateID uint   `json:"shift_template_id" example:"2" binding:"required"`
+	DayType         string `json:"day_type,omitempty" example:"weekend"` // all (varsayılan), weekday, weekend
+	Headcount       int    `json:"headcount" example:"2" binding:"required"`
+}
+
+// DutyAssignmentRequest represents manual duty assignment
+// @Description Elle nöbet ataması - slot_id ve date sadece yeni atamada kullanılır
+type DutyAssignmentRequest struct {
+	SlotID  uint   `json:"slot_id,omitempty" example:"1"`
+	Date    string `json:"date,omitempty" example:"2025-11-01"`
+	StaffID uint   `json:"staff_id" example:"42" binding:"required"`
+}
+
+// DutyRosterResponse nöbet listesi, personel bazında dağılım, boş kalan nöbetler ve kural ihlalleri
+// @Description Nöbet listesi detayı
+type DutyRosterResponse struct {
+	Roster     *DutyRoster       `json:"roster"`
+	Stats      []DutyStaffStats  `json:"stats"`      // Aday personelin nöbet dağılımı
+	Gaps       []DutyRosterGap   `json:"gaps"`       // Kişi sayısı tamamlanamayan nöbetler
+	Violations []ValidationError `json:"violations"` // Kural ihlalleri (ör. sonradan onaylanan izinler) - varken yayınlanamaz
+}
+
+// DutyStaffStats personelin listedeki nöbet sayıları
+// @Description Personel nöbet dağılımı
+type DutyStaffStats struct {
+	StaffID   uint    `json:"staff_id" example:"42"`
+	StaffName string  `json:"staff_name" example:"Dr. Mehmet Özkan"`
+	Total     int     `json:"total" example:"6"`
+	Nights    int     `json:"nights" example:"4"`
+	Weekends  int     `json:"weekends" example:"2"` // Cumartesi/Pazar (tatiller hariç)
+	Holidays  int     `json:"holidays" example:"1"` // Resmi tatiller
+	Hours     float64 `json:"hours" example:"112"`
+}
+
+// DutyRosterGap kişi sayısı tamamlanamayan nöbet
+// @Description Boş nöbet
+type DutyRosterGap struct {
+	Date      string `json:"date" example:"2025-11-01"`
+	SlotID    uint   `json:"slot_id" example:"1"`
+	ShiftName string `json:"shift_name" example:"Gece"`
+	Missing   int    `json:"missing" example:"1"` // Eksik kişi sayısı
+}
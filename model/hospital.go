@@ -1,6 +1,18 @@
 package model
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Hastane hesap durumları
+const (
+	HospitalStatusActive    = "active"    // Normal çalışan hastane
+	HospitalStatusSuspended = "suspended" // Platform operatörü tarafından askıya alınmış - kullanıcıları giriş yapamaz
+	HospitalStatusPending   = "pending"   // Kayıt başvurusu inceleniyor - sadece başvuru görüntülenip düzenlenebilir
+	HospitalStatusRejected  = "rejected"  // Başvuru reddedildi - düzeltilip tekrar gönderilebilir
+)
 
 // Hospital represents a hospital/healthcare facility
 // @Description Hastane bilgileri
@@ -13,6 +25,14 @@ type Hospital struct {
 	ProvinceID    uint   `json:"province_id" gorm:"not null" example:"1" binding:"required"`                          // İl ID
 	DistrictID    uint   `json:"district_id" gorm:"not null" example:"1" binding:"required"`                          // İlçe ID
 	AddressDetail string `json:"address_detail" gorm:"not null" example:"Beşiktaş Caddesi No:123" binding:"required"` // Açık adres
+	RequireMFA    bool   `json:"require_mfa" gorm:"default:false" example:"false"`                                    // Yetkili kullanıcılar için MFA zorunlu mu?
+
+	// Hesap durumu - platform operatörü tarafından yönetilir
+	Status          string     `json:"status" gorm:"not null;default:active;index" example:"active"` // active, suspended, pending, rejected
+	StatusReason    string     `json:"status_reason,omitempty" example:"Ödeme yapılmadı"`            // Son durum değişikliğinin gerekçesi
+	StatusChangedAt *time.Time `json:"status_changed_at,omitempty"`                                  // Son durum değişikliği zamanı
+	StatusChangedBy *uint      `json:"status_changed_by,omitempty" example:"1"`                      // Değişikliği yapan platform operatörü
+	SubmittedAt     *time.Time `json:"submitted_at,omitempty"`                                       // Başvurunun (son) incelemeye gönderilme zamanı
 
 	// İlişkiler
 	Province Province `json:"province,omitempty" gorm:"foreignKey:ProvinceID"` // İl bilgisi
@@ -0,0 +1,55 @@
+package model
+
+import "time"
+
+// Başvuru belge türleri
+const (
+	DocumentTypeLicense        = "license"         // Sağlık Bakanlığı faaliyet izin belgesi / ruhsat - onay için zorunlu
+	DocumentTypeTaxCertificate = "tax_certificate" // Vergi levhası
+	DocumentTypeAuthorization  = "authorization"   // Başvuranın temsil yetkisini gösteren belge (imza sirküleri vb.)
+	DocumentTypeOther          = "other"
+)
+
+// ValidDocumentTypes yüklenebilecek belge türleri
+var ValidDocumentTypes = []string{
+	DocumentTypeLicense,
+	DocumentTypeTaxCertificate,
+	DocumentTypeAuthorization,
+	DocumentTypeOther,
+}
+
+// HospitalDocument hastane kayıt başvurusuna yüklenen belge
+// @Description Başvuru belgesi (dosya içeriği ayrı endpoint'ten indirilir)
+type HospitalDocument struct {
+	ID          uint      `json:"id" gorm:"primarykey" example:"1"`
+	HospitalID  uint      `json:"hospital_id" gorm:"not null;index" example:"1"`
+	Type        string    `json:"type" gorm:"not null" example:"license"`                 // license, tax_certificate, authorization, other
+	FileName    string    `json:"file_name" gorm:"not null" example:"ruhsat.pdf"`         // Yüklenen dosyanın adı
+	ContentType string    `json:"content_type" gorm:"not null" example:"application/pdf"` // İçerikten tespit edilen tür
+	Size        int64     `json:"size" gorm:"not null" example:"245760"`                  // Bayt
+	SHA256      string    `json:"sha256" gorm:"size:64;not null"`                         // Bütünlük kontrolü için içerik özeti
+	Data        []byte    `json:"-" gorm:"type:bytea;not null"`                           // Dosya içeriği - listelerde yüklenmez
+	UploadedBy  uint      `json:"uploaded_by" example:"1"`                                // Yükleyen kullanıcı
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ==================== BAŞVURU DTO'ları ====================
+
+// @Description Hastane kayıt başvurusu ve durumu
+type HospitalApplicationResponse struct {
+	Hospital    Hospital           `json:"hospital"`
+	Documents   []HospitalDocument `json:"documents"`
+	Editable    bool               `json:"editable" example:"true"`      // Bilgiler ve belgeler değiştirilebilir mi? (pending, rejected)
+	CanResubmit bool               `json:"can_resubmit" example:"false"` // Reddedilen başvuru tekrar gönderilebilir mi?
+}
+
+// @Description Başvuru bilgilerini güncelleme (onay beklerken veya red sonrası)
+type UpdateHospitalApplicationRequest struct {
+	HospitalName  string `json:"hospital_name" example:"Acıbadem Hastanesi" binding:"required"`
+	TaxID         string `json:"tax_id" example:"1234567890" binding:"required"`
+	HospitalEmail string `json:"hospital_email" example:"info@acibadem.com" binding:"required,email"`
+	HospitalPhone string `json:"hospital_phone" example:"02121234567" binding:"required"`
+	ProvinceID    uint   `json:"province_id" example:"1" binding:"required"`
+	DistrictID    uint   `json:"district_id" example:"1" binding:"required"`
+	AddressDetail string `json:"address_detail" example:"Beşiktaş Caddesi No:123" binding:"required"`
+}
@@ -0,0 +1,111 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Davet durumları
+const (
+	InvitationPending  = "pending"
+	InvitationAccepted = "accepted"
+	InvitationRevoked  = "revoked"
+	InvitationExpired  = "expired" // Saklanmaz, pending + süresi dolmuş davetler için hesaplanır
+)
+
+// Invitation - Alt kullanıcı daveti
+// Yönetici ad, iletişim ve rol bilgisini girer; şifreyi davetli kişi kendisi belirler
+type Invitation struct {
+	gorm.Model
+	HospitalID     uint       `gorm:"index;not null"`
+	FirstName      string     `gorm:"not null"`
+	LastName       string     `gorm:"not null"`
+	Email          string     `gorm:"index;not null"`
+	Phone          string     `gorm:"index;not null"`
+	Role           string     `gorm:"not null"`
+	PolyclinicID   *uint      // Bağlı olacağı poliklinik (opsiyonel)
+	Lang           string     // Davet mesajlarının dili (tr/en)
+	TokenHash      string     `gorm:"uniqueIndex;not null"` // Geçerli davet linkinin hash'i - yeniden gönderimde değişir
+	Status         string     `gorm:"index;not null"`
+	ExpiresAt      time.Time  `gorm:"not null"`
+	SendCount      int        // Kaç kez gönderildi
+	LastSentAt     *time.Time // Son gönderim
+	InvitedBy      uint       `gorm:"not null"`
+	AcceptedUserID *uint      // Kabul edilince oluşan kullanıcı
+	AcceptedAt     *time.Time
+	RevokedBy      *uint
+	RevokedAt      *time.Time
+
+	// İlişkiler
+	Hospital Hospital `gorm:"foreignKey:HospitalID"`
+}
+
+// EffectiveStatus süresi dolmuş bekleyen davetleri "expired" olarak döndürür
+func (i *Invitation) EffectiveStatus() string {
+	if i.Status == InvitationPending && time.Now().After(i.ExpiresAt) {
+		return InvitationExpired
+	}
+	return i.Status
+}
+
+// ==================== DAVET DTO'ları ====================
+
+// CreateInvitationRequest represents sub-user invitation request
+// @Description Alt kullanıcı daveti - şifreyi davetli kişi belirler
+type CreateInvitationRequest struct {
+	FirstName    string `json:"first_name" example:"Mehmet" binding:"required"`                     // Ad
+	LastName     string `json:"last_name" example:"Yılmaz" binding:"required"`                      // Soyad
+	Email        string `json:"email" example:"mehmet.yilmaz@example.com" binding:"required,email"` // Davet linkinin gönderileceği e-posta
+	Phone        string `json:"phone" example:"05551234567" binding:"required"`                     // Kabulde SMS ile doğrulanacak telefon
+	Role         string `json:"role" example:"çalışan" binding:"required"`                          // Rol adı (hastanede tanımlı)
+	PolyclinicID *uint  `json:"polyclinic_id,omitempty" example:"3"`                                // Bağlı olduğu poliklinik (opsiyonel)
+	Lang         string `json:"lang,omitempty" example:"tr"`                                        // Davet mesajı dili: tr (varsayılan) veya en
+}
+
+// InvitationResponse represents invitation in admin lists
+// @Description Davet bilgisi
+type InvitationResponse struct {
+	ID           uint       `json:"id" example:"1"`
+	FirstName    string     `json:"first_name" example:"Mehmet"`
+	LastName     string     `json:"last_name" example:"Yılmaz"`
+	Email        string     `json:"email" example:"mehmet.yilmaz@example.com"`
+	Phone        string     `json:"phone" example:"05551234567"`
+	Role         string     `json:"role" example:"çalışan"`
+	PolyclinicID *uint      `json:"polyclinic_id,omitempty" example:"3"`
+	Status       string     `json:"status" example:"pending"` // pending, accepted, revoked, expired
+	ExpiresAt    time.Time  `json:"expires_at"`
+	SendCount    int        `json:"send_count" example:"1"`
+	LastSentAt   *time.Time `json:"last_sent_at,omitempty"`
+	InvitedBy    uint       `json:"invited_by" example:"1"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// InvitationTokenRequest represents a request carrying only the invitation token
+// @Description Davet linkindeki token
+type InvitationTokenRequest struct {
+	Token string `json:"token" example:"Zm9vYmFy.c2lnbmF0dXJl" binding:"required"` // Davet linkindeki token
+	Lang  string `json:"lang,omitempty" example:"tr"`                              // SMS dili (boşsa davet dili)
+}
+
+// InvitationDetailsResponse represents invitation details shown to the invitee
+// @Description Davet kabul ekranı için bilgiler
+type InvitationDetailsResponse struct {
+	FirstName    string    `json:"first_name" example:"Mehmet"`
+	LastName     string    `json:"last_name" example:"Yılmaz"`
+	Email        string    `json:"email" example:"mehmet.yilmaz@example.com"`
+	Phone        string    `json:"phone" example:"*******4567"` // Maskelenmiş telefon
+	HospitalName string    `json:"hospital_name" example:"Ankara Şehir Hastanesi"`
+	Role         string    `json:"role" example:"çalışan"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// AcceptInvitationRequest represents invitation acceptance
+// @Description Daveti kabul et - şifre belirle, telefonu doğrula
+type AcceptInvitationRequest struct {
+	Token           string `json:"token" example:"Zm9vYmFy.c2lnbmF0dXJl" binding:"required"`    // Davet linkindeki token
+	TCKN            string `json:"tc" example:"12345678901" binding:"required"`                 // TC Kimlik No
+	Password        string `json:"password" example:"GüçlüŞifre123" binding:"required"`         // Yeni şifre (hastane şifre politikasına uygun)
+	ConfirmPassword string `json:"confirm_password" example:"GüçlüŞifre123" binding:"required"` // Şifre tekrarı
+	PhoneCode       string `json:"phone_code" example:"123456" binding:"required"`              // Telefona gönderilen doğrulama kodu
+}
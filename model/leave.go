@@ -0,0 +1,152 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// İzin talebi durumları
+const (
+	LeavePending   = "pending"   // Yetkili onayı bekliyor
+	LeaveApproved  = "approved"  // Onaylandı - personel bu günlerde müsait sayılmaz
+	LeaveRejected  = "rejected"  // Reddedildi
+	LeaveCancelled = "cancelled" // Talep eden veya yetkili tarafından iptal edildi
+)
+
+// DefaultLeaveTypes hastanede hiç izin türü yoksa oluşturulan türler (yıllık hak 0 ise bakiye takip edilmez)
+var DefaultLeaveTypes = []LeaveType{
+	{Name: "Yıllık İzin", AnnualDays: 20},
+	{Name: "Hastalık İzni"},
+	{Name: "Kongre / Eğitim", AnnualDays: 10},
+	{Name: "Mazeret İzni", AnnualDays: 10},
+}
+
+// LeaveType - Hastanede tanımlı izin türü
+// @Description İzin türü
+type LeaveType struct {
+	gorm.Model `swaggerignore:"true"`
+	HospitalID uint   `json:"hospital_id" gorm:"not null;uniqueIndex:idx_leave_types_hospital_name" example:"1"`    // Hangi hastaneye ait
+	Name       string `json:"name" gorm:"not null;uniqueIndex:idx_leave_types_hospital_name" example:"Yıllık İzin"` // İzin türü adı
+	AnnualDays int    `json:"annual_days" gorm:"not null;default:0" example:"20"`                                   // Yıllık hak (gün) - 0 ise bakiye takip edilmez
+}
+
+// IsTracked izin türünün yıllık bakiyeden düşüp düşmediğini döndürür
+func (t *LeaveType) IsTracked() bool {
+	return t.AnnualDays > 0
+}
+
+// LeaveBalance - Personelin bir yıl için izin türündeki hakkı (türün varsayılan yıllık hakkını geçersiz kılar, devreden izinler dahil)
+// @Description Yıllık izin hakkı
+type LeaveBalance struct {
+	gorm.Model  `swaggerignore:"true"`
+	HospitalID  uint `json:"hospital_id" gorm:"not null;index" example:"1"`
+	StaffID     uint `json:"staff_id" gorm:"not null;uniqueIndex:idx_leave_balances_staff_type_year" example:"42"`
+	LeaveTypeID uint `json:"leave_type_id" gorm:"not null;uniqueIndex:idx_leave_balances_staff_type_year" example:"1"`
+	Year        int  `json:"year" gorm:"not null;uniqueIndex:idx_leave_balances_staff_type_year" example:"2025"`
+	Days        int  `json:"days" gorm:"not null" example:"24"` // O yılki toplam hak (gün)
+}
+
+// LeaveRequest - Personel adına girilen izin talebi
+// Gün sayısı talep anındaki çalışma programından hesaplanır: sadece vardiyası olan günler izinden düşer
+// @Description İzin talebi
+type LeaveRequest struct {
+	gorm.Model   `swaggerignore:"true"`
+	HospitalID   uint       `json:"hospital_id" gorm:"not null;index" example:"1"`
+	StaffID      uint       `json:"staff_id" gorm:"not null;index" example:"42"`
+	LeaveTypeID  uint       `json:"leave_type_id" gorm:"not null;index" example:"1"`
+	StartDate    time.Time  `json:"start_date" gorm:"type:date;not null" example:"2025-07-07T00:00:00Z"` // İlk izin günü
+	EndDate      time.Time  `json:"end_date" gorm:"type:date;not null" example:"2025-07-18T00:00:00Z"`   // Son izin günü (dahil)
+	Days         int        `json:"days" gorm:"not null" example:"10"`                                   // İzinden düşen çalışma günü sayısı
+	Note         string     `json:"note,omitempty" example:"Yaz tatili"`                                 // Talep açıklaması
+	Status       string     `json:"status" gorm:"not null;index" example:"pending"`                      // pending, approved, rejected, cancelled
+	RequestedBy  *uint      `json:"requested_by,omitempty" example:"3"`                                  // Talebi giren kullanıcı (API anahtarıyla girildiyse boş)
+	ReviewedBy   *uint      `json:"reviewed_by,omitempty" example:"1"`                                   // Onaylayan/reddeden yetkili
+	ReviewedAt   *time.Time `json:"reviewed_at,omitempty"`                                               // İnceleme zamanı
+	RejectReason string     `json:"reject_reason,omitempty" example:"Aynı tarihlerde poliklinikte yeterli hekim yok"`
+	CancelledBy  *uint      `json:"cancelled_by,omitempty" example:"3"` // İptal eden kullanıcı
+	CancelledAt  *time.Time `json:"cancelled_at,omitempty"`             // İptal zamanı
+
+	// Listelerde gösterim için
+	StaffName string    `json:"staff_name,omitempty" gorm:"->;-:migration" example:"Dr. Mehmet Özkan"`
+	LeaveType LeaveType `json:"leave_type" gorm:"foreignKey:LeaveTypeID"`
+}
+
+// CoversDate talebin verilen günü kapsayıp kapsamadığını kontrol eder
+func (r *LeaveRequest) CoversDate(day time.Time) bool {
+	day = DateOf(day)
+	return !day.Before(DateOf(r.StartDate)) && !day.After(DateOf(r.EndDate))
+}
+
+// ==================== İZİN DTO'ları ====================
+
+// LeaveTypeRequest represents leave type create/update request
+// @Description İzin türü ekleme/güncelleme verisi
+type LeaveTypeRequest struct {
+	Name       string `json:"name" example:"Yıllık İzin" binding:"required"` // İzin türü adı (hastanede benzersiz)
+	AnnualDays int    `json:"annual_days" example:"20"`                      // Yıllık hak (gün) - 0 ise bakiye takip edilmez
+}
+
+// CreateLeaveRequest represents leave request creation
+// @Description İzin talebi verisi
+type CreateLeaveRequest struct {
+	StaffID     uint   `json:"staff_id" example:"42" binding:"required"`
+	LeaveTypeID uint   `json:"leave_type_id" example:"1" binding:"required"`
+	StartDate   string `json:"start_date" example:"2025-07-07" binding:"required"` // İlk izin günü (YYYY-AA-GG)
+	EndDate     string `json:"end_date" example:"2025-07-18" binding:"required"`   // Son izin günü, dahil (YYYY-AA-GG)
+	Note        string `json:"note,omitempty" example:"Yaz tatili"`
+}
+
+// RejectLeaveRequest represents leave request rejection
+// @Description İzin talebini reddet
+type RejectLeaveRequest struct {
+	Reason string `json:"reason" example:"Aynı tarihlerde poliklinikte yeterli hekim yok" binding:"required"`
+}
+
+// LeaveRequestFilter izin talebi listeleme filtreleri
+type LeaveRequestFilter struct {
+	Status       string     // Boşsa tüm durumlar
+	StaffID      *uint      // Tek personel
+	PolyclinicID *uint      // Poliklinikteki personel
+	From         *time.Time // Bu günden sonra biten talepler
+	To           *time.Time // Bu günden önce başlayan talepler
+}
+
+// LeaveBalanceRequest represents yearly leave entitlement update
+// @Description Yıllık izin hakkı güncelleme verisi
+type LeaveBalanceRequest struct {
+	LeaveTypeID uint `json:"leave_type_id" example:"1" binding:"required"`
+	Year        int  `json:"year" example:"2025" binding:"required"`
+	Days        int  `json:"days" example:"24"` // Devreden izinler dahil toplam hak
+}
+
+// LeaveBalanceSummary personelin bir yıldaki izin türü bakiyesi
+// @Description İzin bakiyesi
+type LeaveBalanceSummary struct {
+	LeaveTypeID   uint   `json:"leave_type_id" example:"1"`
+	LeaveTypeName string `json:"leave_type_name" example:"Yıllık İzin"`
+	Year          int    `json:"year" example:"2025"`
+	Tracked       bool   `json:"tracked" example:"true"`        // false ise hak sınırı yok, sadece kullanım gösterilir
+	Entitled      int    `json:"entitled" example:"20"`         // Yıllık hak
+	Used          int    `json:"used" example:"5"`              // Onaylanmış izin günleri
+	Pending       int    `json:"pending" example:"3"`           // Onay bekleyen izin günleri
+	Remaining     int    `json:"remaining" example:"12"`        // Hak - kullanılan - bekleyen
+	IsOverridden  bool   `json:"is_overridden" example:"false"` // Hak personele özel tanımlanmış mı?
+}
+
+// AbsenceCalendarDay polikliniğin bir günündeki izinli personeller
+// @Description Devamsızlık takvimi günü
+type AbsenceCalendarDay struct {
+	Date     string         `json:"date" example:"2025-07-07"`
+	Absences []AbsenceEntry `json:"absences"`
+}
+
+// AbsenceEntry takvimde izinli personel
+// @Description İzinli personel
+type AbsenceEntry struct {
+	LeaveRequestID uint   `json:"leave_request_id" example:"7"`
+	StaffID        uint   `json:"staff_id" example:"42"`
+	StaffName      string `json:"staff_name" example:"Dr. Mehmet Özkan"`
+	LeaveTypeName  string `json:"leave_type_name" example:"Yıllık İzin"`
+	Status         string `json:"status" example:"approved"` // approved veya (include_pending ile) pending
+}
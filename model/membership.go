@@ -0,0 +1,72 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// HospitalMembership - Kullanıcının birincil hastanesi dışında çalıştığı hastane üyeliği
+// Birincil hastane ve oradaki rol User kaydındadır; kimlik, iletişim ve şifre bilgileri hesaba aittir.
+// Diğer hastanelerde (ör. konsültan hekim) rol ve poliklinik üyelik bazında tutulur
+type HospitalMembership struct {
+	gorm.Model
+	UserID       uint   `gorm:"not null;uniqueIndex:idx_membership_user_hospital"`
+	HospitalID   uint   `gorm:"not null;uniqueIndex:idx_membership_user_hospital;index"`
+	Role         string `gorm:"not null"` // Bu hastanedeki rol (hastanede tanımlı rollerden biri)
+	PolyclinicID *uint  // Bu hastanede bağlı olduğu poliklinik (polyclinics:write:own için)
+	IsActive     bool   `gorm:"default:true"`
+	CreatedBy    uint   `gorm:"not null"`
+
+	// İlişkiler
+	User     User     `gorm:"foreignKey:UserID"`
+	Hospital Hospital `gorm:"foreignKey:HospitalID"`
+}
+
+// ==================== ÜYELİK DTO'ları ====================
+
+// @Description Kullanıcının giriş yapabildiği hastane
+type MembershipResponse struct {
+	HospitalID   uint   `json:"hospital_id" example:"1"`
+	HospitalName string `json:"hospital_name" example:"Acıbadem Hastanesi"`
+	Role         string `json:"role" example:"çalışan"`
+	PolyclinicID *uint  `json:"polyclinic_id,omitempty" example:"3"`
+	Primary      bool   `json:"primary" example:"true"`  // Hesabın ait olduğu (birincil) hastane mi?
+	Current      bool   `json:"current" example:"false"` // Token'ın geçerli olduğu aktif hastane mi?
+}
+
+// @Description Aktif hastaneyi değiştirme isteği
+type SwitchHospitalRequest struct {
+	HospitalID uint `json:"hospital_id" example:"2" binding:"required"` // Üye olunan hastane
+}
+
+// @Description Başka hastanedeki mevcut bir kullanıcıyı bu hastaneye üye ekleme
+type AddMemberRequest struct {
+	TCKN         string `json:"tc" example:"12345678901" binding:"required"`                       // Kullanıcının TC kimlik numarası
+	Email        string `json:"email" example:"ahmet.yilmaz@example.com" binding:"required,email"` // Kullanıcının hesabındaki e-posta (TC ile birlikte eşleşmeli)
+	Role         string `json:"role" example:"çalışan" binding:"required"`                         // Bu hastanedeki rol
+	PolyclinicID *uint  `json:"polyclinic_id,omitempty" example:"3"`                               // Bu hastanedeki poliklinik (opsiyonel)
+}
+
+// @Description Üyelik güncelleme - rol, poliklinik ve durum
+type UpdateMemberRequest struct {
+	Role         string `json:"role" example:"çalışan" binding:"required"`
+	PolyclinicID *uint  `json:"polyclinic_id,omitempty" example:"3"`
+	IsActive     bool   `json:"is_active" example:"true"`
+}
+
+// @Description Hastanenin diğer hastanelerden üye kullanıcısı
+type HospitalMemberResponse struct {
+	ID                uint      `json:"id" example:"1"` // Üyelik ID
+	UserID            uint      `json:"user_id" example:"42"`
+	FirstName         string    `json:"first_name" example:"Ahmet"`
+	LastName          string    `json:"last_name" example:"Yılmaz"`
+	Email             string    `json:"email" example:"ahmet.yilmaz@example.com"`
+	Phone             string    `json:"phone" example:"05551234567"`
+	PrimaryHospitalID uint      `json:"primary_hospital_id" example:"2"` // Hesabın ait olduğu hastane
+	Role              string    `json:"role" example:"çalışan"`
+	PolyclinicID      *uint     `json:"polyclinic_id,omitempty" example:"3"`
+	IsActive          bool      `json:"is_active" example:"true"`
+	CreatedBy         uint      `json:"created_by" example:"1"`
+	CreatedAt         time.Time `json:"created_at"`
+}
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// @Description Tek kullanımlık MFA kurtarma kodu (hash'lenmiş olarak saklanır)
+type MFARecoveryCode struct {
+	gorm.Model `swaggerignore:"true"`
+	UserID     uint       `json:"user_id" gorm:"not null;index" example:"1"` // Kodun sahibi
+	CodeHash   string     `json:"-" gorm:"not null"`                         // SHA-256 hash
+	UsedAt     *time.Time `json:"used_at,omitempty"`                         // Kullanıldığı zaman (nullable)
+
+	// İlişkiler
+	User User `json:"-" gorm:"foreignKey:UserID"`
+}
+
+// ==================== MFA DTO'ları ====================
+
+// @Description TOTP kayıt başlatma yanıtı - authenticator uygulamasına eklenecek bilgiler
+type MFAEnrollResponse struct {
+	Secret     string `json:"secret" example:"JBSWY3DPEHPK3PXP"`                                                 // Manuel giriş için base32 secret
+	OTPAuthURL string `json:"otpauth_url" example:"otpauth://totp/Hastane%20Takip:ahmet@example.com?secret=..."` // Provisioning URI
+	QRCode     string `json:"qr_code" example:"data:image/png;base64,iVBORw0KGgo..."`                            // QR kod (PNG, data URI)
+}
+
+// @Description TOTP kodu doğrulama isteği
+type MFACodeRequest struct {
+	Code string `json:"code" example:"123456" binding:"required"` // Authenticator uygulamasındaki 6 haneli kod
+}
+
+// @Description TOTP kaydı tamamlandı - kurtarma kodları sadece bir kez gösterilir
+type MFAActivationResponse struct {
+	RecoveryCodes []string       `json:"recovery_codes" example:"abcd-efgh,ijkl-mnop"` // Tek kullanımlık kurtarma kodları
+	Tokens        *TokenResponse `json:"tokens"`                                       // MFA doğrulanmış yeni token çifti
+}
+
+// @Description Şifre doğrulandı, ek adım bekleniyor (MFA kodu veya süresi dolan şifrenin değiştirilmesi)
+type LoginChallengeResponse struct {
+	MFARequired            bool   `json:"mfa_required,omitempty" example:"true"`                                 // MFA kodu bekleniyor
+	MFAToken               string `json:"mfa_token,omitempty" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."` // Kısa ömürlü "mfa pending" token
+	PasswordChangeRequired bool   `json:"password_change_required,omitempty" example:"false"`                    // Şifre süresi doldu, yeni şifre belirlenmeli
+	PasswordChangeToken    string `json:"password_change_token,omitempty"`                                       // /login/password-change için token
+	ExpiresIn              int    `json:"expires_in" example:"300"`                                              // Token ömrü (saniye)
+}
+
+// @Description Login ikinci adımı - TOTP kodu veya kurtarma kodu
+type MFALoginRequest struct {
+	MFAToken     string `json:"mfa_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..." binding:"required"` // Login'de dönen mfa_token
+	Code         string `json:"code,omitempty" example:"123456"`                                                // TOTP kodu
+	RecoveryCode string `json:"recovery_code,omitempty" example:"abcd-efgh"`                                    // veya kurtarma kodu
+}
+
+// @Description MFA kapatma isteği
+type DisableMFARequest struct {
+	Password string `json:"password" example:"123456" binding:"required"` // Mevcut şifre
+	Code     string `json:"code" example:"123456" binding:"required"`     // Güncel TOTP kodu
+}
+
+// @Description Hastane MFA politikası güncelleme isteği
+type UpdateMFAPolicyRequest struct {
+	RequireMFA bool `json:"require_mfa" example:"true"` // true: yetkili kullanıcılar için MFA zorunlu
+}
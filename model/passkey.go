@@ -0,0 +1,137 @@
+package model
+
+import "time"
+
+// WebAuthn tören türleri (Redis'te saklanan challenge kaydı için)
+const (
+	PasskeyCeremonyRegistration = "registration"
+	PasskeyCeremonyLogin        = "login"
+)
+
+// @Description Kullanıcının kayıtlı passkey'i veya güvenlik anahtarı (WebAuthn kimlik bilgisi)
+type Passkey struct {
+	ID             uint       `json:"id" gorm:"primaryKey" example:"1"`
+	UserID         uint       `json:"-" gorm:"not null;index"`
+	CredentialID   string     `json:"-" gorm:"size:1400;not null;uniqueIndex"` // Authenticator'ın ürettiği kimlik bilgisi ID'si (base64url)
+	PublicKey      []byte     `json:"-" gorm:"not null"`                       // COSE kodlu açık anahtar
+	Algorithm      int64      `json:"-" gorm:"not null"`                       // COSE algoritması (-7 ES256, -8 EdDSA, -257 RS256)
+	SignCount      int64      `json:"-" gorm:"not null;default:0"`             // Son görülen imza sayacı - klonlanmış anahtar tespiti
+	AAGUID         string     `json:"-" gorm:"size:36"`                        // Authenticator modeli (attestation none ile genelde sıfır)
+	Transports     string     `json:"-" gorm:"size:100"`                       // usb,nfc,ble,internal,hybrid (virgülle)
+	Name           string     `json:"name" gorm:"size:100;not null" example:"İş bilgisayarı"`
+	BackupEligible bool       `json:"-" gorm:"default:false"` // Senkronize edilebilen passkey mi?
+	BackupState    bool       `json:"-" gorm:"default:false"` // Şu an yedeklenmiş mi?
+	LastUsedAt     *time.Time `json:"-"`
+	CreatedAt      time.Time  `json:"-"`
+
+	// İlişkiler
+	User User `json:"-" gorm:"foreignKey:UserID"`
+}
+
+// PasskeyCeremony Redis'te challenge anahtarıyla saklanan tören kaydı
+// Kayıtta ve kullanıcı belirtilen girişte UserID doludur
+type PasskeyCeremony struct {
+	Type   string `json:"type"`
+	UserID uint   `json:"user_id,omitempty"`
+}
+
+// ==================== PASSKEY DTO'ları ====================
+// Tarayıcıya giden seçenekler WebAuthn JSON alan adlarını (camelCase) kullanır;
+// byte alanları base64url (padding'siz) kodlanır
+
+// @Description Relying party (bu uygulama)
+type PasskeyRelyingParty struct {
+	ID   string `json:"id" example:"hbys.hastane.com"`
+	Name string `json:"name" example:"Hastane Yönetim Platformu"`
+}
+
+// @Description Passkey'in bağlanacağı kullanıcı
+type PasskeyUserEntity struct {
+	ID          string `json:"id" example:"AAAAAAAAAAE"` // Kullanıcı handle'ı (base64url)
+	Name        string `json:"name" example:"ahmet@example.com"`
+	DisplayName string `json:"displayName" example:"Ahmet Yılmaz"`
+}
+
+// @Description Kabul edilen açık anahtar algoritması
+type PasskeyCredentialParam struct {
+	Type string `json:"type" example:"public-key"`
+	Alg  int64  `json:"alg" example:"-7"`
+}
+
+// @Description Kimlik bilgisi tanımlayıcısı (hariç tutulan veya izin verilen)
+type PasskeyCredentialDescriptor struct {
+	Type       string   `json:"type" example:"public-key"`
+	ID         string   `json:"id"` // base64url
+	Transports []string `json:"transports,omitempty" example:"internal,hybrid"`
+}
+
+// @Description Authenticator seçim kriterleri
+type PasskeyAuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey" example:"preferred"`
+	UserVerification string `json:"userVerification" example:"required"`
+}
+
+// @Description navigator.credentials.create() için publicKey seçenekleri
+type PasskeyCreationOptions struct {
+	Challenge              string                        `json:"challenge"`
+	RP                     PasskeyRelyingParty           `json:"rp"`
+	User                   PasskeyUserEntity             `json:"user"`
+	PubKeyCredParams       []PasskeyCredentialParam      `json:"pubKeyCredParams"`
+	Timeout                int                           `json:"timeout" example:"300000"` // Milisaniye
+	ExcludeCredentials     []PasskeyCredentialDescriptor `json:"excludeCredentials"`       // Zaten kayıtlı anahtarlar
+	AuthenticatorSelection PasskeyAuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                        `json:"attestation" example:"none"`
+}
+
+// @Description navigator.credentials.get() için publicKey seçenekleri
+type PasskeyRequestOptions struct {
+	Challenge        string                        `json:"challenge"`
+	Timeout          int                           `json:"timeout" example:"300000"` // Milisaniye
+	RPID             string                        `json:"rpId" example:"hbys.hastane.com"`
+	AllowCredentials []PasskeyCredentialDescriptor `json:"allowCredentials"` // Boşsa cihazdaki keşfedilebilir passkey'ler önerilir
+	UserVerification string                        `json:"userVerification" example:"required"`
+}
+
+// @Description Tarayıcının döndürdüğü PublicKeyCredential (byte alanları base64url)
+type PasskeyCredential struct {
+	ID       string                    `json:"id"`
+	RawID    string                    `json:"rawId"`
+	Type     string                    `json:"type" example:"public-key"`
+	Response PasskeyCredentialResponse `json:"response"`
+}
+
+// @Description Authenticator yanıtı - kayıtta attestationObject, girişte authenticatorData + signature dolu
+type PasskeyCredentialResponse struct {
+	ClientDataJSON    string   `json:"clientDataJSON"`
+	AttestationObject string   `json:"attestationObject,omitempty"`
+	AuthenticatorData string   `json:"authenticatorData,omitempty"`
+	Signature         string   `json:"signature,omitempty"`
+	UserHandle        string   `json:"userHandle,omitempty"`
+	Transports        []string `json:"transports,omitempty"`
+}
+
+// @Description Passkey kaydını tamamlama isteği
+type FinishPasskeyRegistrationRequest struct {
+	Name       string            `json:"name" example:"İş bilgisayarı"` // Boşsa "Passkey" adı verilir
+	Credential PasskeyCredential `json:"credential" binding:"required"`
+}
+
+// @Description Passkey ile giriş başlatma - kimlik verilirse sadece o kullanıcının anahtarları önerilir
+type BeginPasskeyLoginRequest struct {
+	EmailOrPhone string `json:"email_or_phone,omitempty" example:"ahmet@example.com"` // Opsiyonel
+}
+
+// @Description Passkey ile giriş tamamlama isteği
+type FinishPasskeyLoginRequest struct {
+	Credential PasskeyCredential `json:"credential" binding:"required"`
+}
+
+// @Description Kayıtlı passkey bilgisi
+type PasskeyResponse struct {
+	ID         uint       `json:"id" example:"1"`
+	Name       string     `json:"name" example:"İş bilgisayarı"`
+	Transports []string   `json:"transports,omitempty" example:"internal,hybrid"`
+	Synced     bool       `json:"synced" example:"true"` // Bulut ile senkronize edilen passkey mi?
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
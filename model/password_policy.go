@@ -0,0 +1,74 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Hastane şifre politikası tanımlamamışsa kullanılan varsayılanlar
+const (
+	DefaultPasswordMinLength    = 8
+	DefaultPasswordHistoryCount = 5
+)
+
+// PasswordPolicy - Hastaneye özel şifre politikası
+// Kayıt, alt kullanıcı ekleme, şifre sıfırlama ve şifre değiştirmede uygulanır
+// Kayıt yoksa DefaultPasswordPolicy kullanılır
+// @Description Hastane şifre politikası
+type PasswordPolicy struct {
+	gorm.Model    `swaggerignore:"true"`
+	HospitalID    uint `json:"hospital_id" gorm:"uniqueIndex;not null" example:"1"` // Hangi hastaneye ait
+	MinLength     int  `json:"min_length" example:"8"`                              // Minimum uzunluk
+	RequireUpper  bool `json:"require_upper" example:"true"`                        // En az bir büyük harf
+	RequireLower  bool `json:"require_lower" example:"true"`                        // En az bir küçük harf
+	RequireDigit  bool `json:"require_digit" example:"true"`                        // En az bir rakam
+	RequireSymbol bool `json:"require_symbol" example:"false"`                      // En az bir özel karakter
+	CheckDenyList bool `json:"check_deny_list" example:"true"`                      // Yaygın/sızdırılmış şifre listesinde olmamalı
+	HistoryCount  int  `json:"history_count" example:"5"`                           // Son N şifre tekrar kullanılamaz (0: kontrol yok)
+	MaxAgeDays    int  `json:"max_age_days" example:"90"`                           // Şifre geçerlilik süresi (gün, 0: süresiz)
+}
+
+// DefaultPasswordPolicy politika tanımlanmamış hastaneler (ve henüz oluşturulmamış hastane kaydı) için varsayılan politika
+func DefaultPasswordPolicy(hospitalID uint) *PasswordPolicy {
+	return &PasswordPolicy{
+		HospitalID:    hospitalID,
+		MinLength:     DefaultPasswordMinLength,
+		RequireUpper:  true,
+		RequireLower:  true,
+		RequireDigit:  true,
+		CheckDenyList: true,
+		HistoryCount:  DefaultPasswordHistoryCount,
+	}
+}
+
+// PasswordHistory - Kullanıcının önceki şifre hash'leri (tekrar kullanım kontrolü için)
+type PasswordHistory struct {
+	ID           uint      `gorm:"primaryKey"`
+	UserID       uint      `gorm:"index;not null"`
+	PasswordHash string    `gorm:"not null"`
+	CreatedAt    time.Time `gorm:"index"`
+}
+
+// ==================== ŞİFRE POLİTİKASI DTO'ları ====================
+
+// UpdatePasswordPolicyRequest represents password policy update request
+// @Description Şifre politikası güncelleme verisi
+type UpdatePasswordPolicyRequest struct {
+	MinLength     int  `json:"min_length" example:"10"`        // Minimum uzunluk (8-128)
+	RequireUpper  bool `json:"require_upper" example:"true"`   // En az bir büyük harf
+	RequireLower  bool `json:"require_lower" example:"true"`   // En az bir küçük harf
+	RequireDigit  bool `json:"require_digit" example:"true"`   // En az bir rakam
+	RequireSymbol bool `json:"require_symbol" example:"true"`  // En az bir özel karakter
+	CheckDenyList bool `json:"check_deny_list" example:"true"` // Yaygın şifre listesi kontrolü
+	HistoryCount  int  `json:"history_count" example:"5"`      // Son N şifre tekrar kullanılamaz (0-24)
+	MaxAgeDays    int  `json:"max_age_days" example:"90"`      // Şifre geçerlilik süresi (gün, 0: süresiz)
+}
+
+// PasswordChangeRequest represents expired password change during login
+// @Description Süresi dolan şifrenin login sırasında değiştirilmesi
+type PasswordChangeRequest struct {
+	ChangeToken     string `json:"password_change_token" example:"eyJhbGciOiJSUzI1NiIs..." binding:"required"` // Login'de dönen password_change_token
+	NewPassword     string `json:"new_password" example:"YeniSifre123" binding:"required"`                     // Yeni şifre
+	ConfirmPassword string `json:"confirm_password" example:"YeniSifre123" binding:"required"`                 // Şifre tekrarı
+}
@@ -0,0 +1,226 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PlatformAdmin - Hastanelerin dışında duran platform operatörü (süper admin)
+// Hiçbir hastaneye ait değildir; hastane kullanıcılarının token'larıyla karıştırılmaması için ayrı tabloda tutulur.
+// Hesaplar uygulama başlarken PLATFORM_ADMIN_EMAIL / PLATFORM_ADMIN_PASSWORD ile oluşturulur, API'den eklenemez
+type PlatformAdmin struct {
+	gorm.Model  `swaggerignore:"true"`
+	Email       string     `json:"email" gorm:"unique;not null" example:"operator@platform.com"` // Giriş e-postası
+	FirstName   string     `json:"first_name" example:"Platform"`                                // Ad
+	LastName    string     `json:"last_name" example:"Operatörü"`                                // Soyad
+	Password    string     `json:"-" gorm:"not null"`                                            // Şifre hash'i (response'da asla gösterilmez)
+	IsActive    bool       `json:"is_active" gorm:"default:true" example:"true"`                 // Aktif mi?
+	MFAEnabled  bool       `json:"mfa_enabled" gorm:"default:false" example:"true"`              // TOTP kaydı tamamlandı mı?
+	TOTPSecret  string     `json:"-" swaggerignore:"true"`                                       // Şifrelenmiş TOTP secret
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`                                      // Son başarılı giriş
+}
+
+// PlatformAuditLog - Platform operatörünün yaptığı her işlemin kaydı
+// Kayıtlar sadece eklenir; güncelleme veya silme endpoint'i yoktur
+type PlatformAuditLog struct {
+	ID         uint      `json:"id" gorm:"primaryKey" example:"1"`
+	AdminID    uint      `json:"admin_id" gorm:"not null;index" example:"1"`                         // İşlemi yapan operatör
+	AdminEmail string    `json:"admin_email" gorm:"not null" example:"operator@platform.com"`        // Operatör silinse de kayıt okunabilsin
+	Action     string    `json:"action" gorm:"not null;index" example:"hospital.suspend"`            // İşlem kodu (bkz. PlatformAction*)
+	TargetType string    `json:"target_type,omitempty" gorm:"index" example:"hospital"`              // Etkilenen kayıt türü
+	TargetID   *uint     `json:"target_id,omitempty" example:"12"`                                   // Etkilenen kayıt ID
+	HospitalID *uint     `json:"hospital_id,omitempty" gorm:"index" example:"12"`                    // İşlemin ilgili olduğu hastane (varsa)
+	Reason     string    `json:"reason,omitempty" example:"Destek talebi #4521"`                     // Operatörün girdiği gerekçe
+	Details    string    `json:"details,omitempty" gorm:"type:json" example:"{\"name\":\"Doktor\"}"` // İşleme özel ek bilgiler (JSON)
+	IP         string    `json:"ip" example:"10.0.0.5"`
+	UserAgent  string    `json:"user_agent,omitempty"`
+	CreatedAt  time.Time `json:"created_at" gorm:"index"`
+}
+
+// Platform denetim kaydı işlem kodları
+const (
+	PlatformActionLogin            = "auth.login"
+	PlatformActionMFAEnroll        = "auth.mfa_enroll"
+	PlatformActionHospitalList     = "hospital.list"
+	PlatformActionHospitalView     = "hospital.view"
+	PlatformActionHospitalSuspend  = "hospital.suspend"
+	PlatformActionHospitalActivate = "hospital.reactivate"
+	PlatformActionHospitalApprove  = "hospital.approve"
+	PlatformActionHospitalReject   = "hospital.reject"
+	PlatformActionDocumentView     = "hospital.document_view"
+	PlatformActionUserSearch       = "user.search"
+	PlatformActionUserUnlock       = "user.unlock"
+	PlatformActionUserResetMFA     = "user.reset_mfa"
+	PlatformActionUserRevoke       = "user.revoke_sessions"
+	PlatformActionUserExpire       = "user.expire_password"
+	PlatformActionUserStatus       = "user.status"
+	PlatformActionMasterCreate     = "master_data.create"
+	PlatformActionMasterUpdate     = "master_data.update"
+	PlatformActionMasterDelete     = "master_data.delete"
+)
+
+// Denetim kaydı hedef türleri
+const (
+	PlatformTargetHospital       = "hospital"
+	PlatformTargetDocument       = "hospital_document"
+	PlatformTargetUser           = "user"
+	PlatformTargetProvince       = "province"
+	PlatformTargetDistrict       = "district"
+	PlatformTargetJobGroup       = "job_group"
+	PlatformTargetJobTitle       = "job_title"
+	PlatformTargetPolyclinicType = "polyclinic_type"
+)
+
+// ==================== PLATFORM GİRİŞ DTO'ları ====================
+
+// @Description Platform operatörü girişi - TOTP kaydı tamamlanmışsa kod zorunludur
+type PlatformLoginRequest struct {
+	Email    string `json:"email" example:"operator@platform.com" binding:"required,email"`
+	Password string `json:"password" example:"GüçlüŞifre123!" binding:"required"`
+	Code     string `json:"code,omitempty" example:"123456"` // Authenticator uygulamasındaki 6 haneli kod
+}
+
+// @Description Platform operatörü token'ı - hastane endpoint'lerinde geçersizdir
+type PlatformTokenResponse struct {
+	Token                 string `json:"token" example:"eyJhbGciOiJSUzI1NiIs..."`
+	TokenType             string `json:"token_type" example:"Bearer"`
+	ExpiresIn             int    `json:"expires_in" example:"3600"`                         // Saniye
+	MFA                   bool   `json:"mfa" example:"true"`                                // Token TOTP ile doğrulandı mı?
+	MFAEnrollmentRequired bool   `json:"mfa_enrollment_required,omitempty" example:"false"` // true ise önce /platform/me/mfa ile TOTP kaydı yapılmalı
+}
+
+// ==================== HASTANE YÖNETİMİ DTO'ları ====================
+
+// @Description Platform hastane listesi filtreleri
+type PlatformHospitalListRequest struct {
+	Page       int    `json:"page" example:"1" binding:"min=1"`
+	PageSize   int    `json:"page_size" example:"20" binding:"min=1,max=100"`
+	Query      string `json:"query,omitempty" example:"acıbadem"` // Ad, vergi no, e-posta veya telefonda arar
+	Status     string `json:"status,omitempty" example:"active"`  // active, suspended
+	ProvinceID *uint  `json:"province_id,omitempty" example:"34"`
+}
+
+// @Description Platform hastane listesi satırı
+type PlatformHospitalSummary struct {
+	ID              uint       `json:"id" example:"1"`
+	Name            string     `json:"name" example:"Acıbadem Hastanesi"`
+	TaxID           string     `json:"tax_id" example:"1234567890"`
+	Email           string     `json:"email" example:"info@acibadem.com"`
+	Phone           string     `json:"phone" example:"02121234567"`
+	ProvinceName    string     `json:"province_name" example:"İstanbul"`
+	DistrictName    string     `json:"district_name" example:"Beşiktaş"`
+	Status          string     `json:"status" example:"active"`
+	StatusReason    string     `json:"status_reason,omitempty" example:""`
+	StatusChangedAt *time.Time `json:"status_changed_at,omitempty"`
+	SubmittedAt     *time.Time `json:"submitted_at,omitempty"`
+	UserCount       int64      `json:"user_count" example:"12"`
+	StaffCount      int64      `json:"staff_count" example:"140"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// @Description Sayfalandırılmış platform hastane listesi
+type PlatformHospitalListResponse struct {
+	Data       []PlatformHospitalSummary `json:"data"`
+	Pagination PaginationInfo            `json:"pagination"`
+}
+
+// @Description Platform hastane detayı
+type PlatformHospitalDetail struct {
+	PlatformHospitalSummary
+	AddressDetail   string `json:"address_detail" example:"Beşiktaş Caddesi No:123"`
+	RequireMFA      bool   `json:"require_mfa" example:"false"`
+	MemberCount     int64  `json:"member_count" example:"3"` // Başka hastaneden üye kullanıcılar
+	PolyclinicCount int64  `json:"polyclinic_count" example:"8"`
+	SSOEnabled      bool   `json:"sso_enabled" example:"false"`
+
+	Documents []HospitalDocument `json:"documents"` // Başvuru belgeleri (içerik ayrı endpoint'ten indirilir)
+}
+
+// @Description Gerekçe zorunlu platform işlemi (askıya alma, destek işlemleri)
+type PlatformActionRequest struct {
+	Reason string `json:"reason" example:"Destek talebi #4521" binding:"required"` // Denetim kaydına yazılır
+}
+
+// ==================== KULLANICI DESTEĞİ DTO'ları ====================
+
+// @Description Hastaneler arası kullanıcı arama
+type PlatformUserSearchRequest struct {
+	Page       int    `json:"page" example:"1" binding:"min=1"`
+	PageSize   int    `json:"page_size" example:"20" binding:"min=1,max=100"`
+	Query      string `json:"query" example:"ahmet.yilmaz@example.com" binding:"required"` // E-posta, telefon, TC veya ad soyad
+	HospitalID *uint  `json:"hospital_id,omitempty" example:"1"`                           // Sadece bu hastanenin kullanıcıları
+}
+
+// @Description Platform kullanıcı arama satırı
+type PlatformUserSummary struct {
+	ID           uint      `json:"id" example:"42"`
+	HospitalID   uint      `json:"hospital_id" example:"1"` // Birincil hastane
+	HospitalName string    `json:"hospital_name" example:"Acıbadem Hastanesi"`
+	FirstName    string    `json:"first_name" example:"Ahmet"`
+	LastName     string    `json:"last_name" example:"Yılmaz"`
+	Email        string    `json:"email" example:"ahmet.yilmaz@example.com"`
+	Phone        string    `json:"phone" example:"05551234567"`
+	Role         string    `json:"role" example:"yetkili"`
+	Status       string    `json:"status" example:"active"` // active, suspended, locked, pending
+	MFAEnabled   bool      `json:"mfa_enabled" example:"true"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// @Description Sayfalandırılmış platform kullanıcı listesi
+type PlatformUserListResponse struct {
+	Data       []PlatformUserSummary `json:"data"`
+	Pagination PaginationInfo        `json:"pagination"`
+}
+
+// ==================== MASTER DATA DTO'ları ====================
+
+// @Description İl ekleme/güncelleme
+type ProvinceRequest struct {
+	Name string `json:"name" example:"İstanbul" binding:"required"`
+}
+
+// @Description İlçe ekleme/güncelleme
+type DistrictRequest struct {
+	ProvinceID uint   `json:"province_id" example:"34" binding:"required"`
+	Name       string `json:"name" example:"Beşiktaş" binding:"required"`
+}
+
+// @Description Meslek grubu ekleme/güncelleme
+type JobGroupRequest struct {
+	Name string `json:"name" example:"Doktor" binding:"required"`
+}
+
+// @Description Unvan ekleme/güncelleme
+type JobTitleRequest struct {
+	JobGroupID uint   `json:"job_group_id" example:"1" binding:"required"`
+	Name       string `json:"name" example:"Uzman Doktor" binding:"required"`
+	IsUnique   bool   `json:"is_unique" example:"false"` // Hastanede bu unvandan sadece bir kişi olabilir mi?
+}
+
+// @Description Poliklinik türü ekleme/güncelleme
+type PolyclinicTypeRequest struct {
+	Name        string `json:"name" example:"Kardiyoloji" binding:"required"`
+	Description string `json:"description" example:"Kalp ve damar hastalıkları"`
+}
+
+// ==================== DENETİM KAYDI DTO'ları ====================
+
+// @Description Denetim kaydı filtreleri
+type PlatformAuditLogListRequest struct {
+	Page       int        `json:"page" example:"1" binding:"min=1"`
+	PageSize   int        `json:"page_size" example:"50" binding:"min=1,max=100"`
+	AdminID    *uint      `json:"admin_id,omitempty" example:"1"`
+	Action     string     `json:"action,omitempty" example:"hospital.suspend"`
+	TargetType string     `json:"target_type,omitempty" example:"hospital"`
+	TargetID   *uint      `json:"target_id,omitempty" example:"12"`
+	HospitalID *uint      `json:"hospital_id,omitempty" example:"12"`
+	From       *time.Time `json:"from,omitempty" example:"2024-01-01T00:00:00Z"`
+	To         *time.Time `json:"to,omitempty" example:"2024-12-31T23:59:59Z"`
+}
+
+// @Description Sayfalandırılmış denetim kayıtları
+type PlatformAuditLogListResponse struct {
+	Data       []PlatformAuditLog `json:"data"`
+	Pagination PaginationInfo     `json:"pagination"`
+}
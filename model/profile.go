@@ -0,0 +1,55 @@
+package model
+
+import "time"
+
+// ==================== PROFİL DTO'ları ====================
+
+// @Description Giriş yapan kullanıcının kendi profili
+type ProfileResponse struct {
+	ID                uint       `json:"id" example:"5"`
+	FirstName         string     `json:"first_name" example:"Ahmet"`
+	LastName          string     `json:"last_name" example:"Yılmaz"`
+	TCKN              string     `json:"tc" example:"123******01"` // Maskelenmiş - değiştirilemez
+	Email             string     `json:"email" example:"ahmet.yilmaz@example.com"`
+	Phone             string     `json:"phone" example:"05551234567"`
+	EmailVerified     bool       `json:"email_verified" example:"true"`
+	PhoneVerified     bool       `json:"phone_verified" example:"false"`
+	HospitalID        uint       `json:"hospital_id" example:"1"` // Token'daki aktif hastane
+	HospitalName      string     `json:"hospital_name" example:"Acıbadem Hastanesi"`
+	Role              string     `json:"role" example:"yetkili"` // Aktif hastanedeki rol
+	MFAEnabled        bool       `json:"mfa_enabled" example:"false"`
+	HasPassword       bool       `json:"has_password" example:"true"` // SSO ile açılan hesaplarda yerel şifre yoktur
+	PasswordChangedAt *time.Time `json:"password_changed_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+// @Description Profil güncelleme - e-posta veya telefon değişiyorsa yeni adrese gönderilen kod gerekir
+type UpdateProfileRequest struct {
+	FirstName string `json:"first_name" example:"Ahmet" binding:"required"`
+	LastName  string `json:"last_name" example:"Yılmaz" binding:"required"`
+	Email     string `json:"email" example:"ahmet.yilmaz@example.com" binding:"required,email"`
+	Phone     string `json:"phone" example:"05551234567" binding:"required"`
+	EmailCode string `json:"email_code,omitempty" example:"482913"` // E-posta değişiyorsa /me/contact/verify ile yeni adrese gönderilen kod
+	PhoneCode string `json:"phone_code,omitempty" example:"205718"` // Telefon değişiyorsa yeni numaraya gönderilen kod
+	Lang      string `json:"lang,omitempty" example:"tr"`           // Eski adrese gidecek değişiklik bildiriminin dili
+}
+
+// @Description Yeni e-posta veya telefon için doğrulama kodu isteği
+type ContactVerificationRequest struct {
+	Channel string `json:"channel" example:"email" binding:"required"`                // email veya sms
+	Value   string `json:"value" example:"ahmet.yeni@example.com" binding:"required"` // Yeni e-posta adresi veya telefon numarası
+	Lang    string `json:"lang,omitempty" example:"tr"`
+}
+
+// @Description Mevcut şifreyle şifre değiştirme
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" example:"EskiSifre!2024" binding:"required"`
+	NewPassword     string `json:"new_password" example:"YeniSifre!2025" binding:"required"`
+	ConfirmPassword string `json:"confirm_password" example:"YeniSifre!2025" binding:"required"`
+}
+
+// @Description Şifre değiştirme sonucu
+type ChangePasswordResponse struct {
+	Message         string `json:"message" example:"Şifreniz değiştirildi"`
+	RevokedSessions int    `json:"revoked_sessions" example:"2"` // Sonlandırılan diğer oturum sayısı
+}
@@ -2,7 +2,9 @@ package model
 
 // @Description Şifre sıfırlama talebi
 type ResetPasswordRequest struct {
-	Phone string `json:"phone" example:"05551234567" binding:"required"` // Telefon numarası
+	Phone   string `json:"phone" example:"05551234567" binding:"required"` // Telefon numarası
+	Channel string `json:"channel,omitempty" example:"sms"`                // Kodun gönderileceği kanal: sms (varsayılan) veya email (kayıtlı e-posta adresine)
+	Lang    string `json:"lang,omitempty" example:"tr"`                    // Mesaj dili: tr veya en (boşsa Accept-Language)
 }
 
 // @Description Şifre sıfırlama onayı
@@ -0,0 +1,117 @@
+package model
+
+import "gorm.io/gorm"
+
+// Yetki (permission) isimleri - route'lar bu isimlerle yetki ister
+const (
+	PermStaffRead           = "staff:read"            // Personel görüntüleme
+	PermStaffWrite          = "staff:write"           // Personel ekleme/güncelleme/silme
+	PermPolyclinicsRead     = "polyclinics:read"      // Poliklinik görüntüleme
+	PermPolyclinicsWrite    = "polyclinics:write"     // Poliklinik ekleme/güncelleme (tüm poliklinikler)
+	PermPolyclinicsWriteOwn = "polyclinics:write:own" // Sadece kullanıcının bağlı olduğu polikliniği güncelleme
+	PermPolyclinicsDelete   = "polyclinics:delete"    // Poliklinik silme
+	PermUsersManage         = "users:manage"          // Alt kullanıcı yönetimi
+	PermRolesManage         = "roles:manage"          // Rol ve yetki yönetimi
+	PermHospitalSettings    = "hospital:settings"     // Hastane güvenlik ayarları (MFA politikası vb.)
+	PermLeavesApprove       = "leaves:approve"        // İzin taleplerini onaylama/reddetme, izin türleri ve hakları
+	PermRostersManage       = "rosters:manage"        // Nöbet listesi oluşturma, düzenleme ve yayınlama
+)
+
+// PermissionInfo - Tanımlı bir yetkinin adı ve açıklaması
+// @Description Yetki bilgisi
+type PermissionInfo struct {
+	Name        string `json:"name" example:"staff:write"`                       // Yetki adı
+	Description string `json:"description" example:"Personel ekleme/güncelleme"` // Açıklama
+}
+
+// Permissions sistemde tanımlı tüm yetkiler
+var Permissions = []PermissionInfo{
+	{Name: PermStaffRead, Description: "Personel görüntüleme"},
+	{Name: PermStaffWrite, Description: "Personel ekleme, güncelleme ve silme"},
+	{Name: PermPolyclinicsRead, Description: "Poliklinik görüntüleme"},
+	{Name: PermPolyclinicsWrite, Description: "Poliklinik ekleme ve güncelleme"},
+	{Name: PermPolyclinicsWriteOwn, Description: "Sadece bağlı olunan polikliniği güncelleme"},
+	{Name: PermPolyclinicsDelete, Description: "Poliklinik silme"},
+	{Name: PermUsersManage, Description: "Alt kullanıcı ekleme, güncelleme, silme ve kilit açma"},
+	{Name: PermRolesManage, Description: "Rol ve yetki yönetimi"},
+	{Name: PermHospitalSettings, Description: "Hastane güvenlik ayarları"},
+	{Name: PermLeavesApprove, Description: "İzin taleplerini onaylama ve reddetme, izin türleri ve yıllık haklar"},
+	{Name: PermRostersManage, Description: "Nöbet listesi oluşturma, düzenleme ve yayınlama"},
+}
+
+// PrivilegedPermissions hesap/hastane yönetimi sağlayan yetkiler - bu yetkilere sahip roller "yönetici" sayılır
+// (hastane MFA zorunluluğu bu rollere uygulanır)
+var PrivilegedPermissions = []string{PermUsersManage, PermRolesManage, PermHospitalSettings}
+
+// DefaultRolePermissions her yeni hastane için oluşturulan sistem rolleri
+var DefaultRolePermissions = map[string][]string{
+	RoleYetkili: AllPermissionNames(),
+	RoleCalisan: {PermStaffRead, PermPolyclinicsRead},
+}
+
+// AllPermissionNames tüm yetki isimlerini döndürür
+func AllPermissionNames() []string {
+	names := make([]string, 0, len(Permissions))
+	for _, permission := range Permissions {
+		names = append(names, permission.Name)
+	}
+	return names
+}
+
+// IsValidPermission yetki adının tanımlı olup olmadığını kontrol eder
+func IsValidPermission(name string) bool {
+	for _, permission := range Permissions {
+		if permission.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Role - Hastaneye özel rol, bir dizi yetkiden oluşur
+// Kullanıcılar rollere adıyla bağlıdır (User.Role), isim hastane içinde benzersizdir
+// @Description Hastane rolü
+type Role struct {
+	gorm.Model  `swaggerignore:"true"`
+	HospitalID  uint             `json:"hospital_id" gorm:"not null;uniqueIndex:idx_roles_hospital_name" example:"1"`     // Hangi hastaneye ait
+	Name        string           `json:"name" gorm:"not null;uniqueIndex:idx_roles_hospital_name" example:"İK Sorumlusu"` // Rol adı
+	Description string           `json:"description" example:"Personel yönetimi yapabilir"`                               // Açıklama
+	IsSystem    bool             `json:"is_system" gorm:"default:false" example:"false"`                                  // Sistem rolü mü? (silinemez)
+	Permissions []RolePermission `json:"-" gorm:"foreignKey:RoleID"`                                                      // Role ait yetkiler
+}
+
+// RolePermission - Role atanmış tek bir yetki
+type RolePermission struct {
+	RoleID     uint   `gorm:"primaryKey"`
+	Permission string `gorm:"primaryKey"`
+}
+
+// PermissionNames role ait yetki isimlerini döndürür
+func (r *Role) PermissionNames() []string {
+	names := make([]string, 0, len(r.Permissions))
+	for _, permission := range r.Permissions {
+		names = append(names, permission.Permission)
+	}
+	return names
+}
+
+// ==================== ROL DTO'ları ====================
+
+// RoleRequest represents role create/update request
+// @Description Rol ekleme/güncelleme verisi
+type RoleRequest struct {
+	Name        string   `json:"name" example:"İK Sorumlusu" binding:"required"`    // Rol adı
+	Description string   `json:"description" example:"Personel yönetimi yapabilir"` // Açıklama
+	Permissions []string `json:"permissions" example:"staff:read,staff:write"`      // Yetki listesi
+}
+
+// RoleResponse represents role with its permissions
+// @Description Rol bilgisi
+type RoleResponse struct {
+	ID          uint     `json:"id" example:"1"`                               // Rol ID
+	Name        string   `json:"name" example:"İK Sorumlusu"`                  // Rol adı
+	Description string   `json:"description" example:"Personel yönetimi"`      // Açıklama
+	IsSystem    bool     `json:"is_system" example:"false"`                    // Sistem rolü mü?
+	Permissions []string `json:"permissions" example:"staff:read,staff:write"` // Yetkiler
+	UserCount   int64    `json:"user_count" example:"3"`                       // Bu role sahip kullanıcı sayısı
+}
@@ -0,0 +1,166 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Çalışma programı desenleri
+const (
+	SchedulePatternWeekly   = "weekly"   // Haftalık: gün 1=Pazartesi ... 7=Pazar
+	SchedulePatternRotating = "rotating" // Dönüşümlü: başlangıç tarihinden itibaren N günlük döngü (ör. gündüz, gece, izin, izin)
+)
+
+// Personel eklenirken vardiya seçilmezse kullanılan hastane vardiyası (yoksa oluşturulur)
+const (
+	DefaultShiftTemplateName  = "Mesai"
+	DefaultShiftTemplateStart = "08:00"
+	DefaultShiftTemplateEnd   = "17:00"
+)
+
+// ShiftTemplate - Hastanede tanımlı vardiya (başlangıç/bitiş saati)
+// Bitiş saati başlangıçtan küçük veya eşitse vardiya ertesi güne taşar (gece vardiyası, 24 saatlik nöbet)
+// @Description Vardiya şablonu
+type ShiftTemplate struct {
+	gorm.Model `swaggerignore:"true"`
+	HospitalID uint   `json:"hospital_id" gorm:"not null;uniqueIndex:idx_shift_templates_hospital_name" example:"1"` // Hangi hastaneye ait
+	Name       string `json:"name" gorm:"not null;uniqueIndex:idx_shift_templates_hospital_name" example:"Gece"`     // Vardiya adı
+	StartTime  string `json:"start_time" gorm:"type:varchar(5);not null" example:"20:00"`                            // Başlangıç (SS:DD)
+	EndTime    string `json:"end_time" gorm:"type:varchar(5);not null" example:"08:00"`                              // Bitiş (SS:DD)
+}
+
+// Span vardiyanın gün başından itibaren başlangıç ve bitiş süresini döndürür (gece vardiyasında bitiş 24 saati aşar)
+func (t *ShiftTemplate) Span() (time.Duration, time.Duration) {
+	start := parseClock(t.StartTime)
+	end := parseClock(t.EndTime)
+	if end <= start {
+		end += 24 * time.Hour
+	}
+	return start, end
+}
+
+// Overnight vardiyanın gece yarısını geçip geçmediğini döndürür (gece vardiyası, 24 saatlik nöbet)
+func (t *ShiftTemplate) Overnight() bool {
+	_, end := t.Span()
+	return end > 24*time.Hour
+}
+
+// Label vardiyayı "08:00-17:00" biçiminde döndürür
+func (t *ShiftTemplate) Label() string {
+	return t.StartTime + "-" + t.EndTime
+}
+
+// parseClock "SS:DD" saatini gün başından itibaren süreye çevirir (doğrulanmış değerler için)
+func parseClock(clock string) time.Duration {
+	parsed, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0
+	}
+	return time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute
+}
+
+// StaffSchedule - Personelin belirli bir tarih aralığında geçerli çalışma programı
+// Bir personelin aynı güne denk gelen birden fazla programı olamaz
+// @Description Personel çalışma programı
+type StaffSchedule struct {
+	gorm.Model    `swaggerignore:"true"`
+	HospitalID    uint                 `json:"hospital_id" gorm:"not null;index" example:"1"`
+	StaffID       uint                 `json:"staff_id" gorm:"not null;index" example:"42"`
+	Pattern       string               `json:"pattern" gorm:"not null" example:"weekly"`                                // weekly, rotating
+	CycleDays     int                  `json:"cycle_days" gorm:"not null" example:"7"`                                  // Döngü uzunluğu (haftalıkta 7)
+	EffectiveFrom time.Time            `json:"effective_from" gorm:"type:date;not null" example:"2025-01-01T00:00:00Z"` // Geçerlilik başlangıcı (dönüşümlü döngünün 1. günü)
+	EffectiveTo   *time.Time           `json:"effective_to,omitempty" gorm:"type:date" example:"2025-12-31T00:00:00Z"`  // Geçerlilik bitişi (dahil, boşsa süresiz)
+	Entries       []StaffScheduleEntry `json:"entries" gorm:"foreignKey:ScheduleID;constraint:OnDelete:CASCADE"`        // Döngü günlerindeki vardiyalar
+}
+
+// CoversDate programın verilen günde geçerli olup olmadığını kontrol eder
+func (s *StaffSchedule) CoversDate(day time.Time) bool {
+	day = DateOf(day)
+	if day.Before(DateOf(s.EffectiveFrom)) {
+		return false
+	}
+	return s.EffectiveTo == nil || !day.After(DateOf(*s.EffectiveTo))
+}
+
+// CycleDay verilen günün döngüdeki sırasını döndürür (haftalıkta ISO gün numarası, dönüşümlüde 1..CycleDays)
+func (s *StaffSchedule) CycleDay(day time.Time) int {
+	if s.Pattern == SchedulePatternWeekly {
+		weekday := int(day.Weekday())
+		if weekday == 0 {
+			weekday = 7
+		}
+		return weekday
+	}
+	days := DaysBetween(DateOf(s.EffectiveFrom), DateOf(day))
+	index := days % s.CycleDays
+	if index < 0 {
+		index += s.CycleDays
+	}
+	return index + 1
+}
+
+// DateOf zamanın yerel saatteki gün başını döndürür (veritabanından gelen date alanları UTC olabilir)
+func DateOf(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
+
+// DaysBetween iki gün arasındaki takvim günü farkını döndürür (yaz saati farklarından etkilenmez)
+func DaysBetween(from, to time.Time) int {
+	fromYear, fromMonth, fromDay := from.Date()
+	toYear, toMonth, toDay := to.Date()
+	start := time.Date(fromYear, fromMonth, fromDay, 12, 0, 0, 0, time.UTC)
+	end := time.Date(toYear, toMonth, toDay, 12, 0, 0, 0, time.UTC)
+	return int(end.Sub(start).Hours() / 24)
+}
+
+// StaffScheduleEntry - Döngünün bir gününde çalışılan vardiya (aynı güne birden fazla vardiya eklenebilir)
+type StaffScheduleEntry struct {
+	ID              uint          `json:"id" gorm:"primaryKey" example:"1"`
+	ScheduleID      uint          `json:"-" gorm:"not null;index"`
+	Day             int           `json:"day" gorm:"not null" example:"1"` // Haftalıkta 1-7, dönüşümlüde 1..cycle_days
+	ShiftTemplateID uint          `json:"shift_template_id" gorm:"not null" example:"2"`
+	ShiftTemplate   ShiftTemplate `json:"shift_template" gorm:"foreignKey:ShiftTemplateID"`
+}
+
+// ==================== ÇALIŞMA PROGRAMI DTO'ları ====================
+
+// ShiftTemplateRequest represents shift template create/update request
+// @Description Vardiya şablonu ekleme/güncelleme verisi
+type ShiftTemplateRequest struct {
+	Name      string `json:"name" example:"Gece" binding:"required"`        // Vardiya adı (hastanede benzersiz)
+	StartTime string `json:"start_time" example:"20:00" binding:"required"` // Başlangıç (SS:DD)
+	EndTime   string `json:"end_time" example:"08:00" binding:"required"`   // Bitiş (SS:DD) - başlangıçtan küçükse ertesi gün biter
+}
+
+// StaffScheduleRequest represents staff schedule create/update request
+// @Description Personel çalışma programı verisi
+type StaffScheduleRequest struct {
+	Pattern       string                      `json:"pattern" example:"rotating" binding:"required"`          // weekly veya rotating
+	CycleDays     int                         `json:"cycle_days,omitempty" example:"4"`                       // Dönüşümlü döngü uzunluğu (2-56 gün)
+	EffectiveFrom string                      `json:"effective_from" example:"2025-01-06" binding:"required"` // Başlangıç tarihi (YYYY-AA-GG)
+	EffectiveTo   *string                     `json:"effective_to,omitempty" example:"2025-06-30"`            // Bitiş tarihi (dahil, boşsa süresiz)
+	Entries       []StaffScheduleEntryRequest `json:"entries" binding:"required"`                             // Çalışılan günler ve vardiyaları
+}
+
+// StaffScheduleEntryRequest döngü gününe atanan vardiya
+// @Description Program günü
+type StaffScheduleEntryRequest struct {
+	Day             int  `json:"day" example:"1" binding:"required"`               // Haftalıkta 1=Pazartesi..7=Pazar, dönüşümlüde döngünün günü
+	ShiftTemplateID uint `json:"shift_template_id" example:"2" binding:"required"` // Vardiya şablonu
+}
+
+// ShiftOccurrence programdan hesaplanan somut bir vardiya
+// @Description Takvimdeki vardiya
+type ShiftOccurrence struct {
+	Date            string    `json:"date" example:"2025-01-06"`            // Vardiyanın başladığı gün
+	ScheduleID      uint      `json:"schedule_id,omitempty" example:"3"`    // Vardiyanın geldiği çalışma programı
+	DutyRosterID    *uint     `json:"duty_roster_id,omitempty" example:"4"` // Yayınlanmış nöbet listesinden geliyorsa liste
+	ShiftTemplateID uint      `json:"shift_template_id" example:"2"`
+	ShiftName       string    `json:"shift_name" example:"Gece"`
+	Start           time.Time `json:"start" example:"2025-01-06T20:00:00+03:00"`
+	End             time.Time `json:"end" example:"2025-01-07T08:00:00+03:00"`
+	LeaveRequestID  *uint     `json:"leave_request_id,omitempty" example:"7"`          // Vardiya günü onaylı izne denk geliyorsa izin talebi
+	LeaveTypeName   string    `json:"leave_type_name,omitempty" example:"Yıllık İzin"` // İzin türü - personel bu vardiyada müsait değil
+}
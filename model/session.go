@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// ClientInfo oturumu açan/kullanan istemcinin bilgileri
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
+
+// SessionRecord Redis'te saklanan oturum kaydı
+// Her login bir token ailesi başlatır; oturum ID'si token ailesi ID'sidir
+type SessionRecord struct {
+	UserID     uint
+	IP         string // Oturumun açıldığı IP
+	LastIP     string // Son istek yapılan IP
+	UserAgent  string
+	MFA        bool // Oturum MFA doğrulanmış bir login ile mi açıldı?
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+}
+
+// @Description Aktif oturum (cihaz)
+type SessionResponse struct {
+	ID         string    `json:"id" example:"m3Jr0c8yQ2a1Zb9xW4vTnA"`                            // Oturum ID (iptal için)
+	Device     string    `json:"device" example:"Chrome / Windows"`                              // User-Agent'tan çıkarılan cihaz özeti
+	UserAgent  string    `json:"user_agent" example:"Mozilla/5.0 (Windows NT 10.0; Win64; x64)"` // Ham User-Agent
+	IP         string    `json:"ip" example:"85.105.12.34"`                                      // Girişin yapıldığı IP
+	LastIP     string    `json:"last_ip" example:"85.105.12.34"`                                 // Son görülen IP
+	MFA        bool      `json:"mfa" example:"true"`                                             // İki adımlı doğrulamayla açıldı mı?
+	CreatedAt  time.Time `json:"created_at"`                                                     // Giriş zamanı
+	LastSeenAt time.Time `json:"last_seen_at"`                                                   // Son istek zamanı (yaklaşık, dakika hassasiyetinde)
+	Current    bool      `json:"current" example:"false"`                                        // İsteği yapan oturum mu?
+}
@@ -0,0 +1,159 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Kayıt başvurusu durumları
+const (
+	SignupPending  = "pending"  // Yetkili onayı bekliyor
+	SignupApproved = "approved" // Onaylandı, kullanıcı oluşturuldu
+	SignupRejected = "rejected" // Reddedildi
+)
+
+// Kayıt başvurusunun hastaneyle eşleştiği yöntem
+const (
+	SignupViaJoinCode    = "join_code"    // Hastanenin verdiği katılım kodu
+	SignupViaEmailDomain = "email_domain" // Hastanenin izin verdiği e-posta alan adı
+)
+
+// Katılım kodu durumları (hesaplanan)
+const (
+	JoinCodeActive    = "active"
+	JoinCodeExpired   = "expired"
+	JoinCodeExhausted = "exhausted"
+	JoinCodeRevoked   = "revoked"
+)
+
+// @Description Hastaneye kendi kendine kayıt başvurusu - onaylanana kadar kullanıcı oluşturulmaz
+type SignupRequest struct {
+	gorm.Model   `swaggerignore:"true"`
+	HospitalID   uint       `json:"hospital_id" gorm:"not null;index" example:"1"`                    // Başvurulan hastane (katılım kodu veya e-posta alan adından belirlenir)
+	FirstName    string     `json:"first_name" gorm:"not null" example:"Zeynep"`                      // Ad
+	LastName     string     `json:"last_name" gorm:"not null" example:"Kaya"`                         // Soyad
+	TCKN         string     `json:"tc" gorm:"not null" example:"12345678901"`                         // TC Kimlik No
+	Email        string     `json:"email" gorm:"not null;index" example:"zeynep.kaya@hastane.gov.tr"` // E-posta (başvuruda kodla doğrulandı)
+	Phone        string     `json:"phone" gorm:"not null" example:"05551234567"`                      // Telefon
+	PasswordHash string     `json:"-" gorm:"not null"`                                                // Başvuruda belirlenen şifrenin hash'i (onayda kullanıcıya aktarılır)
+	Role         string     `json:"role" gorm:"not null" example:"çalışan"`                           // Sunucunun atadığı rol (kod/alan adından, onaylayan değiştirebilir)
+	PolyclinicID *uint      `json:"polyclinic_id,omitempty" example:"3"`                              // Bağlı olacağı poliklinik
+	Source       string     `json:"source" gorm:"not null" example:"join_code"`                       // join_code veya email_domain
+	JoinCodeID   *uint      `json:"join_code_id,omitempty" example:"2"`                               // Kullanılan katılım kodu
+	Lang         string     `json:"-"`                                                                // Bildirim dili
+	Status       string     `json:"status" gorm:"not null;index" example:"pending"`                   // pending, approved, rejected
+	ReviewedBy   *uint      `json:"reviewed_by,omitempty" example:"1"`                                // Onaylayan/reddeden yetkili
+	ReviewedAt   *time.Time `json:"reviewed_at,omitempty"`                                            // İnceleme zamanı
+	RejectReason string     `json:"reject_reason,omitempty" example:"Kadromuzda bulunmuyor"`          // Red gerekçesi
+	UserID       *uint      `json:"user_id,omitempty" example:"15"`                                   // Onayda oluşturulan kullanıcı
+}
+
+// @Description Hastanenin kayıt için dağıttığı katılım kodu - kod sadece hash olarak saklanır
+type JoinCode struct {
+	gorm.Model   `swaggerignore:"true"`
+	HospitalID   uint       `json:"hospital_id" gorm:"not null;index" example:"1"`
+	CodeHash     string     `json:"-" gorm:"not null;uniqueIndex"` // Normalize edilmiş kodun hash'i
+	CodeHint     string     `json:"code_hint" example:"M9PD"`      // Listede tanımak için kodun son 4 karakteri
+	Role         string     `json:"role" gorm:"not null" example:"çalışan"`
+	PolyclinicID *uint      `json:"polyclinic_id,omitempty" example:"3"`
+	MaxUses      int        `json:"max_uses" example:"20"` // 0 = sınırsız
+	UseCount     int        `json:"use_count" example:"4"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`
+	CreatedBy    uint       `json:"created_by" example:"1"`
+}
+
+// EffectiveStatus kodun şu anki durumunu hesaplar
+func (j *JoinCode) EffectiveStatus() string {
+	switch {
+	case j.RevokedAt != nil:
+		return JoinCodeRevoked
+	case time.Now().After(j.ExpiresAt):
+		return JoinCodeExpired
+	case j.MaxUses > 0 && j.UseCount >= j.MaxUses:
+		return JoinCodeExhausted
+	default:
+		return JoinCodeActive
+	}
+}
+
+// @Description Bu alan adındaki e-posta adresleri hastaneye katılım kodu olmadan başvurabilir
+type HospitalEmailDomain struct {
+	gorm.Model   `swaggerignore:"true"`
+	HospitalID   uint   `json:"hospital_id" gorm:"not null;index" example:"1"`
+	Domain       string `json:"domain" gorm:"not null;uniqueIndex" example:"ankarasehir.gov.tr"` // Bir alan adı tek hastaneye ait olabilir
+	Role         string `json:"role" gorm:"not null" example:"çalışan"`                          // Başvurulara atanacak rol
+	PolyclinicID *uint  `json:"polyclinic_id,omitempty" example:"3"`
+	CreatedBy    uint   `json:"created_by" example:"1"`
+}
+
+// ==================== KAYIT DTO'ları ====================
+
+// SignupEmailCodeRequest represents signup email verification code request
+// @Description Kayıt için e-posta doğrulama kodu iste
+type SignupEmailCodeRequest struct {
+	Email string `json:"email" example:"zeynep.kaya@hastane.gov.tr" binding:"required,email"`
+	Lang  string `json:"lang,omitempty" example:"tr"` // Mesaj dili: tr (varsayılan) veya en
+}
+
+// SelfSignupRequest represents self-signup request
+// @Description Kendi kendine kayıt başvurusu - hastane ve rol katılım kodundan veya e-posta alan adından sunucuda belirlenir
+type SelfSignupRequest struct {
+	FirstName       string `json:"first_name" example:"Zeynep" binding:"required"`
+	LastName        string `json:"last_name" example:"Kaya" binding:"required"`
+	TCKN            string `json:"tc" example:"12345678901" binding:"required"`
+	Email           string `json:"email" example:"zeynep.kaya@hastane.gov.tr" binding:"required,email"`
+	Phone           string `json:"phone" example:"05551234567" binding:"required"`
+	Password        string `json:"password" example:"GüçlüŞifre123" binding:"required"`
+	ConfirmPassword string `json:"confirm_password" example:"GüçlüŞifre123" binding:"required"`
+	EmailCode       string `json:"email_code" example:"123456" binding:"required"` // E-postaya gönderilen doğrulama kodu
+	JoinCode        string `json:"join_code,omitempty" example:"ANK7-QX2M-9PDR"`   // Boşsa e-posta alan adıyla eşleştirilir
+	Lang            string `json:"lang,omitempty" example:"tr"`                    // Onay/red bildiriminin dili
+}
+
+// SignupSubmittedResponse represents accepted signup request
+// @Description Başvuru alındı - yetkili onayı bekleniyor
+type SignupSubmittedResponse struct {
+	ID           uint   `json:"id" example:"7"`
+	Status       string `json:"status" example:"pending"`
+	HospitalName string `json:"hospital_name" example:"Ankara Şehir Hastanesi"`
+}
+
+// ApproveSignupRequest represents signup approval
+// @Description Başvuruyu onayla - rol ve poliklinik boşsa başvurudaki değerler kullanılır
+type ApproveSignupRequest struct {
+	Role         string `json:"role,omitempty" example:"hemşire"`
+	PolyclinicID *uint  `json:"polyclinic_id,omitempty" example:"3"`
+}
+
+// RejectSignupRequest represents signup rejection
+// @Description Başvuruyu reddet
+type RejectSignupRequest struct {
+	Reason string `json:"reason" example:"Kadromuzda bulunmuyor" binding:"required"`
+}
+
+// CreateJoinCodeRequest represents join code creation
+// @Description Katılım kodu oluştur - kod sadece bu yanıtta gösterilir
+type CreateJoinCodeRequest struct {
+	Role          string `json:"role" example:"çalışan" binding:"required"` // Kodla başvuranlara atanacak rol
+	PolyclinicID  *uint  `json:"polyclinic_id,omitempty" example:"3"`
+	MaxUses       int    `json:"max_uses" example:"20"`        // 0 = sınırsız
+	ExpiresInDays int    `json:"expires_in_days" example:"30"` // Varsayılan 30, en fazla 365
+}
+
+// JoinCodeResponse represents a join code
+// @Description Katılım kodu bilgisi - code alanı sadece oluşturulurken döner
+type JoinCodeResponse struct {
+	JoinCode
+	Code   string `json:"code,omitempty" example:"ANK7-QX2M-9PDR"`
+	Status string `json:"status" example:"active"` // active, expired, exhausted, revoked
+}
+
+// CreateEmailDomainRequest represents allowed email domain creation
+// @Description Kayıt için izinli e-posta alan adı ekle
+type CreateEmailDomainRequest struct {
+	Domain       string `json:"domain" example:"ankarasehir.gov.tr" binding:"required"`
+	Role         string `json:"role,omitempty" example:"çalışan"` // Boşsa çalışan
+	PolyclinicID *uint  `json:"polyclinic_id,omitempty" example:"3"`
+}
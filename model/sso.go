@@ -0,0 +1,98 @@
+package model
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// SSODefaultScopes yeni SSO ayarlarında istenen OIDC scope'ları
+var SSODefaultScopes = []string{"openid", "email", "profile"}
+
+// @Description Hastanenin OpenID Connect tek oturum açma (SSO) ayarları
+type HospitalSSOConfig struct {
+	gorm.Model      `swaggerignore:"true"`
+	HospitalID      uint             `json:"hospital_id" gorm:"not null;uniqueIndex" example:"1"`
+	Enabled         bool             `json:"enabled" gorm:"default:false" example:"true"`                      // SSO ile giriş açık mı?
+	Issuer          string           `json:"issuer" gorm:"not null" example:"https://idp.hastanegrubu.com.tr"` // IdP issuer (discovery bu adresten okunur)
+	ClientID        string           `json:"client_id" gorm:"not null" example:"hospital-platform"`
+	ClientSecret    string           `json:"-"`                                                    // Şifrelenmiş client secret (boşsa public client, sadece PKCE)
+	Scopes          string           `json:"scopes" example:"openid email profile groups"`         // Boşlukla ayrılmış scope'lar
+	RoleClaim       string           `json:"role_claim" example:"groups"`                          // Rol eşlemesinde bakılan claim (string veya liste)
+	DefaultRole     string           `json:"default_role,omitempty" example:"çalışan"`             // Hiçbir eşleme tutmazsa JIT'te atanacak rol (boşsa giriş reddedilir)
+	JITProvisioning bool             `json:"jit_provisioning" gorm:"default:false" example:"true"` // E-postayla eşleşen kullanıcı yoksa oluşturulsun mu?
+	SyncRole        bool             `json:"sync_role" gorm:"default:false" example:"false"`       // Her girişte rol claim'den güncellensin mi?
+	TCKNClaim       string           `json:"tckn_claim" example:"tckn"`                            // JIT'te TC kimlik numarasının okunduğu claim
+	RoleMappings    []SSORoleMapping `json:"role_mappings" gorm:"foreignKey:ConfigID"`             // Claim değeri → rol (öncelik sırasıyla)
+	UpdatedBy       uint             `json:"updated_by" example:"1"`
+}
+
+// ScopeList scope'ları liste olarak döndürür
+func (c *HospitalSSOConfig) ScopeList() []string {
+	return strings.Fields(c.Scopes)
+}
+
+// @Description IdP claim değerinden platform rolüne eşleme
+type SSORoleMapping struct {
+	ID         uint   `json:"-" gorm:"primaryKey"`
+	ConfigID   uint   `json:"-" gorm:"not null;index"`
+	HospitalID uint   `json:"-" gorm:"not null;index"`                          // Rol adı değişikliklerinin yansıtılması için
+	ClaimValue string `json:"claim_value" gorm:"not null" example:"HBYS-Hekim"` // Role claim'inde aranan değer
+	Role       string `json:"role" gorm:"not null" example:"Poliklinik Sorumlusu"`
+	Priority   int    `json:"-" gorm:"not null"` // Küçük olan önce denenir (istekteki sıra)
+}
+
+// SSOState - IdP'ye yönlendirilen giriş denemesi (Redis'te, tek kullanımlık)
+type SSOState struct {
+	HospitalID   uint   `json:"hospital_id"`
+	CodeVerifier string `json:"code_verifier"`
+	Nonce        string `json:"nonce"`
+}
+
+// ==================== SSO DTO'ları ====================
+
+// @Description Claim → rol eşlemesi
+type SSORoleMappingRequest struct {
+	ClaimValue string `json:"claim_value" example:"HBYS-Hekim" binding:"required"`
+	Role       string `json:"role" example:"Poliklinik Sorumlusu" binding:"required"`
+}
+
+// @Description SSO ayarlarını kaydetme isteği
+type SSOConfigRequest struct {
+	Enabled         bool                    `json:"enabled" example:"true"`
+	Issuer          string                  `json:"issuer" example:"https://idp.hastanegrubu.com.tr" binding:"required"`
+	ClientID        string                  `json:"client_id" example:"hospital-platform" binding:"required"`
+	ClientSecret    string                  `json:"client_secret,omitempty" example:"s3cr3t"`        // Boş bırakılırsa mevcut secret korunur
+	Scopes          []string                `json:"scopes,omitempty" example:"openid,email,profile"` // Varsayılan: openid email profile
+	RoleClaim       string                  `json:"role_claim,omitempty" example:"groups"`
+	RoleMappings    []SSORoleMappingRequest `json:"role_mappings,omitempty"` // Sıra öncelik belirtir
+	DefaultRole     string                  `json:"default_role,omitempty" example:"çalışan"`
+	JITProvisioning bool                    `json:"jit_provisioning" example:"true"`
+	SyncRole        bool                    `json:"sync_role" example:"false"`
+	TCKNClaim       string                  `json:"tckn_claim,omitempty" example:"tckn"` // Varsayılan: tckn
+}
+
+// @Description Hastanenin SSO ayarları - client secret gösterilmez
+type SSOConfigResponse struct {
+	HospitalSSOConfig
+	HasClientSecret bool   `json:"has_client_secret" example:"true"`
+	RedirectURI     string `json:"redirect_uri" example:"http://localhost:3000/sso/callback"` // IdP'de kayıtlı olması gereken dönüş adresi
+}
+
+// @Description SSO girişini başlatma - hastane ID'si veya hastanenin izinli alan adındaki e-posta
+type SSOStartRequest struct {
+	HospitalID uint   `json:"hospital_id,omitempty" example:"1"`
+	Email      string `json:"email,omitempty" example:"ahmet.yilmaz@hastane.gov.tr"`
+}
+
+// @Description Kullanıcının yönlendirileceği IdP giriş adresi
+type SSOStartResponse struct {
+	AuthorizationURL string `json:"authorization_url" example:"https://idp.hastanegrubu.com.tr/authorize?response_type=code&client_id=..."`
+}
+
+// @Description IdP'nin dönüş adresine eklediği code ve state
+type SSOCallbackRequest struct {
+	Code  string `json:"code" query:"code" example:"SplxlOBeZQQYbYS6WxSbIA"`
+	State string `json:"state" query:"state" example:"af0ifjsldkj"`
+	Error string `json:"error,omitempty" query:"error" example:"access_denied"` // IdP hata döndürdüyse
+}
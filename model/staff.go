@@ -13,7 +13,6 @@ type Staff struct {
 	Phone        string `json:"phone" gorm:"unique;not null" example:"05559876543" binding:"required"` // Telefon
 	JobGroupID   uint   `json:"job_group_id" gorm:"not null" example:"1" binding:"required"`           // Meslek grubu
 	JobTitleID   uint   `json:"job_title_id" gorm:"not null" example:"1" binding:"required"`           // Unvan
-	WorkDays     string `json:"work_days" gorm:"type:json" example:"[1,2,3,4,5]" binding:"required"`   // Çalışma günleri (JSON array: 1=Pazartesi, 7=Pazar)
 	IsActive     bool   `json:"is_active" gorm:"default:true" example:"true"`                          // Aktif mi?
 
 	// İlişkiler
@@ -21,4 +20,5 @@ type Staff struct {
 	Polyclinic *HospitalPolyclinic `json:"polyclinic,omitempty" gorm:"foreignKey:PolyclinicID"`
 	JobGroup   JobGroup            `json:"job_group,omitempty" gorm:"foreignKey:JobGroupID"`
 	JobTitle   JobTitle            `json:"job_title,omitempty" gorm:"foreignKey:JobTitleID"`
+	Schedules  []StaffSchedule     `json:"schedules,omitempty" gorm:"foreignKey:StaffID"` // Çalışma programları (vardiyalar ve geçerlilik tarihleri)
 }
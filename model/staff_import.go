@@ -0,0 +1,29 @@
+package model
+
+// Toplu personel içe aktarma satır durumları
+const (
+	StaffImportRowValid   = "valid"   // Doğrulandı (dry-run) - commit ile eklenecek
+	StaffImportRowInvalid = "invalid" // Hatalı, eklenmez
+	StaffImportRowCreated = "created" // Commit ile eklendi
+)
+
+// @Description İçe aktarılan dosyanın tek bir satırının sonucu
+type StaffImportRow struct {
+	Row              int                `json:"row" example:"2"`                 // Dosyadaki satır numarası (başlık 1. satır)
+	Status           string             `json:"status" example:"invalid"`        // valid, invalid, created
+	FirstName        string             `json:"first_name" example:"Mehmet"`     // Satırdaki ad (tanıma kolaylığı için)
+	LastName         string             `json:"last_name" example:"Özkan"`       // Satırdaki soyad
+	StaffID          *uint              `json:"staff_id,omitempty" example:"42"` // Eklenen personelin ID'si (commit)
+	ValidationErrors []ValidationError  `json:"validation_errors,omitempty"`     // Satırın hataları
+	Request          CreateStaffRequest `json:"-"`                               // Adlardan çözülen istek
+}
+
+// @Description Toplu personel içe aktarma raporu
+type StaffImportResponse struct {
+	DryRun       bool             `json:"dry_run" example:"true"`    // true ise hiçbir kayıt eklenmedi
+	TotalRows    int              `json:"total_rows" example:"120"`  // Boş olmayan veri satırı sayısı
+	ValidRows    int              `json:"valid_rows" example:"117"`  // Hatasız satırlar
+	InvalidRows  int              `json:"invalid_rows" example:"3"`  // Hatalı satırlar
+	CreatedCount int              `json:"created_count" example:"0"` // Eklenen personel sayısı (commit)
+	Rows         []StaffImportRow `json:"rows"`                      // Satır bazında sonuçlar
+}
@@ -0,0 +1,32 @@
+package model
+
+// @Description Refresh token ile yeni token çifti alma isteği
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" example:"q1w2e3r4t5y6..." binding:"required"` // Login'de dönen refresh token
+}
+
+// @Description Access + refresh token çifti
+type TokenResponse struct {
+	Token        string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."` // Access token (geriye uyumluluk için "token" adıyla)
+	RefreshToken string `json:"refresh_token" example:"q1w2e3r4t5y6..."`                 // Tek kullanımlık refresh token
+	TokenType    string `json:"token_type" example:"Bearer"`                             // Token tipi
+	ExpiresIn    int    `json:"expires_in" example:"900"`                                // Access token ömrü (saniye)
+
+	MFAEnrollmentRequired bool `json:"mfa_enrollment_required,omitempty" example:"false"` // Hastane MFA zorunlu kılmış ama kullanıcı henüz kayıt olmamış
+
+	HospitalID  uint                 `json:"hospital_id" example:"1"` // Token'ın geçerli olduğu aktif hastane
+	Memberships []MembershipResponse `json:"memberships"`             // Kullanıcının giriş yapabildiği hastaneler (/auth/switch-hospital ile geçilir)
+}
+
+// RefreshTokenRecord Redis'te saklanan refresh token kaydı
+type RefreshTokenRecord struct {
+	UserID     uint   `json:"user_id"`
+	FamilyID   string `json:"family_id"`
+	MFA        bool   `json:"mfa"`                   // Aile MFA doğrulanmış bir login ile mi başladı?
+	HospitalID uint   `json:"hospital_id,omitempty"` // Token'ların geçerli olduğu aktif hastane (boşsa birincil hastane)
+}
+
+// @Description Çıkış isteği
+type LogoutRequest struct {
+	AllSessions bool `json:"all_sessions" example:"false"` // true ise tüm cihazlardaki oturumlar sonlandırılır
+}
@@ -1,27 +1,57 @@
 package model
 
-import "gorm.io/gorm"
+import (
+	"time"
 
+	"gorm.io/gorm"
+)
+
+const (
+	RoleYetkili = "yetkili" // Varsayılan sistem rolü - tüm yetkiler
+	RoleCalisan = "çalışan" // Varsayılan sistem rolü - sadece görüntüleme
+)
+
+// Kullanıcı hesap durumları - active dışındaki durumlarda giriş yapılamaz ve açık token'lar reddedilir
 const (
-	RoleYetkili = "yetkili" // Yetkililer - tüm işlemleri yapabilir
-	RoleCalisan = "çalışan" // Çalışanlar - sadece görüntüleme
+	UserStatusActive    = "active"    // Normal çalışan hesap
+	UserStatusSuspended = "suspended" // Yönetici tarafından askıya alınmış (ör. işten ayrılma, uzun izin)
+	UserStatusLocked    = "locked"    // Güvenlik nedeniyle kilitlenmiş (ör. hesabın ele geçirildiği şüphesi)
+	UserStatusPending   = "pending"   // Hesap açıldı, yönetici etkinleştirmesi bekleniyor
 )
 
+// ValidUserStatuses yöneticinin atayabileceği kullanıcı durumları
+var ValidUserStatuses = []string{UserStatusActive, UserStatusSuspended, UserStatusLocked, UserStatusPending}
+
 // @Description Hastane kullanıcı bilgileri
 type User struct {
-	gorm.Model `swaggerignore:"true"`
-	HospitalID uint   `json:"hospital_id" gorm:"not null" example:"1" binding:"required"`                               // Hangi hastaneye ait
-	FirstName  string `json:"first_name" gorm:"not null" example:"Ahmet" binding:"required"`                            // Ad
-	LastName   string `json:"last_name" gorm:"not null" example:"Yılmaz" binding:"required"`                            // Soyad
-	TCKN       string `json:"tc" gorm:"unique;not null" example:"12345678901" binding:"required"`                       // Türkiye Cumhuriyeti Kimlik Numarası
-	Email      string `json:"email" gorm:"unique;not null" example:"ahmet.yilmaz@example.com" binding:"required,email"` // E-posta adresi
-	Phone      string `json:"phone" gorm:"unique;not null" example:"05551234567" binding:"required"`                    // Telefon numarası
-	Password   string `json:"password,omitempty" example:"123456" binding:"required,min=6"`                             // Şifre (JSON'dan okuyabilir ama response'da göstermez)
-	Role       string `json:"role" gorm:"default:çalışan" example:"çalışan" binding:"required,oneof=yetkili çalışan"`   // Rol: "yetkili" veya "çalışan"
-	CreatedBy  *uint  `json:"created_by,omitempty" example:"1"`                                                         // Kim tarafından eklendi (nullable - ilk user için)
-	IsActive   bool   `json:"is_active" gorm:"default:true" example:"true"`                                             // Aktif mi?
+	gorm.Model         `swaggerignore:"true"`
+	HospitalID         uint       `json:"hospital_id" gorm:"not null" example:"1" binding:"required"`                               // Hangi hastaneye ait
+	FirstName          string     `json:"first_name" gorm:"not null" example:"Ahmet" binding:"required"`                            // Ad
+	LastName           string     `json:"last_name" gorm:"not null" example:"Yılmaz" binding:"required"`                            // Soyad
+	TCKN               string     `json:"tc" gorm:"unique;not null" example:"12345678901" binding:"required"`                       // Türkiye Cumhuriyeti Kimlik Numarası
+	Email              string     `json:"email" gorm:"unique;not null" example:"ahmet.yilmaz@example.com" binding:"required,email"` // E-posta adresi
+	Phone              string     `json:"phone" gorm:"unique;not null" example:"05551234567" binding:"required"`                    // Telefon numarası
+	Password           string     `json:"password,omitempty" example:"123456" binding:"required,min=6"`                             // Şifre (JSON'dan okuyabilir ama response'da göstermez)
+	Role               string     `json:"role" gorm:"default:çalışan" example:"çalışan" binding:"required"`                         // Rol adı (hastanede tanımlı rollerden biri)
+	PolyclinicID       *uint      `json:"polyclinic_id,omitempty" example:"3"`                                                      // Bağlı olduğu poliklinik (polyclinics:write:own için)
+	CreatedBy          *uint      `json:"created_by,omitempty" example:"1"`                                                         // Kim tarafından eklendi (nullable - ilk user için)
+	Status             string     `json:"status" gorm:"not null;default:active;index" example:"active"`                             // active, suspended, locked, pending
+	StatusReason       string     `json:"status_reason,omitempty" example:"İşten ayrıldı"`                                          // Son durum değişikliğinin gerekçesi
+	StatusChangedAt    *time.Time `json:"status_changed_at,omitempty"`                                                              // Son durum değişikliği zamanı
+	StatusChangedBy    *uint      `json:"status_changed_by,omitempty" example:"1"`                                                  // Değişikliği yapan hastane kullanıcısı (platform operatörü ise boş)
+	MFAEnabled         bool       `json:"mfa_enabled" gorm:"default:false" example:"false"`                                         // TOTP iki adımlı doğrulama aktif mi?
+	TOTPSecret         string     `json:"-" swaggerignore:"true"`                                                                   // Şifrelenmiş TOTP secret (response'da asla gösterilmez)
+	PasswordChangedAt  *time.Time `json:"-" swaggerignore:"true"`                                                                   // Son şifre değişikliği (şifre süresi buna göre hesaplanır, boşsa CreatedAt)
+	MustChangePassword bool       `json:"-" swaggerignore:"true"`                                                                   // Bir sonraki girişte şifre değiştirilmeli mi?
+	EmailVerifiedAt    *time.Time `json:"-" swaggerignore:"true"`                                                                   // E-posta adresi doğrulandı mı? (davet linki vb.)
+	PhoneVerifiedAt    *time.Time `json:"-" swaggerignore:"true"`                                                                   // Telefon SMS koduyla doğrulandı mı?
 
 	// İlişkiler
 	Hospital Hospital `json:"hospital,omitempty" gorm:"foreignKey:HospitalID"` // Hastane bilgisi
 	Creator  *User    `json:"creator,omitempty" gorm:"foreignKey:CreatedBy"`   // Oluşturan kullanıcı
 }
+
+// IsActive kullanıcının giriş yapabilir durumda olup olmadığını döndürür
+func (u *User) IsActive() bool {
+	return u.Status == UserStatusActive
+}
@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+// Durum değişikliğini yapan taraf
+const (
+	StatusActorUser     = "user"     // Hastane yöneticisi (users:manage)
+	StatusActorPlatform = "platform" // Platform operatörü
+)
+
+// @Description Kullanıcı durum değişikliği denetim kaydı
+type UserStatusChange struct {
+	ID         uint      `json:"id" gorm:"primaryKey" example:"1"`
+	UserID     uint      `json:"user_id" gorm:"not null;index" example:"42"`
+	HospitalID uint      `json:"hospital_id" gorm:"not null;index" example:"1"` // Kullanıcının birincil hastanesi
+	FromStatus string    `json:"from_status" gorm:"not null" example:"active"`
+	ToStatus   string    `json:"to_status" gorm:"not null" example:"suspended"`
+	Reason     string    `json:"reason" example:"İşten ayrıldı"`
+	ActorType  string    `json:"actor_type" gorm:"not null" example:"user"` // user veya platform
+	ActorID    uint      `json:"actor_id" example:"1"`                      // Hastane kullanıcısı veya platform operatörü ID
+	IP         string    `json:"ip" example:"10.0.0.5"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ==================== KULLANICI DURUMU DTO'ları ====================
+
+// @Description Kullanıcı durumu değiştirme - active dışındaki durumlar için gerekçe zorunludur
+type ChangeUserStatusRequest struct {
+	Status string `json:"status" example:"suspended" binding:"required"` // active, suspended, locked, pending
+	Reason string `json:"reason" example:"İşten ayrıldı"`
+}
+
+// @Description Kullanıcının güncel durumu
+type UserStatusResponse struct {
+	UserID          uint       `json:"user_id" example:"42"`
+	Status          string     `json:"status" example:"suspended"`
+	StatusReason    string     `json:"status_reason,omitempty" example:"İşten ayrıldı"`
+	StatusChangedAt *time.Time `json:"status_changed_at,omitempty"`
+	StatusChangedBy *uint      `json:"status_changed_by,omitempty" example:"1"`
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"errors"
+	"hospital-platform/database"
+	"hospital-platform/model"
+	"time"
+)
+
+// ErrAPIKeyAlreadyRevoked anahtar eşzamanlı bir istekle zaten iptal edildi
+var ErrAPIKeyAlreadyRevoked = errors.New("API anahtarı zaten iptal edilmiş")
+
+// APIKeyRepository API anahtarlarının veritabanı işlemlerini yönetir
+type APIKeyRepository struct{}
+
+// NewAPIKeyRepository yeni bir API anahtarı repository'si oluşturur
+func NewAPIKeyRepository() *APIKeyRepository {
+	return &APIKeyRepository{}
+}
+
+// Create yeni API anahtarını yetkileriyle birlikte oluşturur
+func (r *APIKeyRepository) Create(apiKey *model.APIKey) error {
+	return database.DB.Create(apiKey).Error
+}
+
+// GetByID ID'ye göre API anahtarını yetkileriyle getirir
+func (r *APIKeyRepository) GetByID(id uint) (*model.APIKey, error) {
+	var apiKey model.APIKey
+	if err := database.DB.Preload("Scopes").First(&apiKey, id).Error; err != nil {
+		return nil, err
+	}
+	return &apiKey, nil
+}
+
+// GetByHospitalID hastanenin API anahtarlarını listeler
+func (r *APIKeyRepository) GetByHospitalID(hospitalID uint) ([]model.APIKey, error) {
+	var apiKeys []model.APIKey
+	err := database.DB.Preload("Scopes").
+		Where("hospital_id = ?", hospitalID).
+		Order("created_at DESC").
+		Find(&apiKeys).Error
+	return apiKeys, err
+}
+
+// Revoke anahtarı iptal eder - sadece henüz iptal edilmemişse
+func (r *APIKeyRepository) Revoke(apiKey *model.APIKey, revokedBy uint) error {
+	now := time.Now()
+	result := database.DB.Model(&model.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", apiKey.ID).
+		Updates(map[string]interface{}{"revoked_at": now, "revoked_by": revokedBy})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected != 1 {
+		return ErrAPIKeyAlreadyRevoked
+	}
+
+	apiKey.RevokedAt = &now
+	apiKey.RevokedBy = &revokedBy
+	return nil
+}
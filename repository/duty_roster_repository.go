@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"errors"
+	"hospital-platform/database"
+	"hospital-platform/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrDutyRosterNotDraft nöbet listesi okunduktan sonra başka bir yönetici tarafından yayınlanmışsa döner
+var ErrDutyRosterNotDraft = errors.New("nöbet listesi taslak değil")
+
+// DutyRosterRepository nöbet listeleri, slotları ve atamaları veritabanı işlemlerini yönetir
+type DutyRosterRepository struct{}
+
+// NewDutyRosterRepository yeni bir nöbet listesi repository'si oluşturur
+func NewDutyRosterRepository() *DutyRosterRepository {
+	return &DutyRosterRepository{}
+}
+
+// Create listeyi slotları ve üretilen atamalarıyla birlikte tek transaction'da ekler
+// Atamaların SlotID'si, slotlar eklendikten sonra Slot işaretçisinden doldurulur
+func (r *DutyRosterRepository) Create(roster *model.DutyRoster) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(roster).Error; err != nil {
+			return err
+		}
+
+		for i := range roster.Slots {
+			roster.Slots[i].RosterID = roster.ID
+		}
+		if err := tx.Omit(clause.Associations).Create(&roster.Slots).Error; err != nil {
+			return err
+		}
+
+		if len(roster.Assignments) == 0 {
+			return nil
+		}
+		for i := range roster.Assignments {
+			roster.Assignments[i].RosterID = roster.ID
+			if roster.Assignments[i].Slot != nil {
+				roster.Assignments[i].SlotID = roster.Assignments[i].Slot.ID
+			}
+		}
+		return tx.Omit(clause.Associations).CreateInBatches(roster.Assignments, 200).Error
+	})
+}
+
+// GetByID listeyi slotları (vardiyalarıyla) ve atamalarıyla getirir
+func (r *DutyRosterRepository) GetByID(id uint) (*model.DutyRoster, error) {
+	var roster model.DutyRoster
+	err := database.DB.
+		Preload("Slots", func(db *gorm.DB) *gorm.DB {
+			return db.Order("duty_roster_slots.id ASC")
+		}).
+		Preload("Slots.ShiftTemplate", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped()
+		}).
+		Preload("Assignments", func(db *gorm.DB) *gorm.DB {
+			return r.withDetails(db).Order("duty_assignments.starts_at ASC, duty_assignments.id ASC")
+		}).
+		First(&roster, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &roster, nil
+}
+
+// GetByHospital hastanenin nöbet listelerini (atamalar olmadan) en yeni dönem başta olacak şekilde getirir
+func (r *DutyRosterRepository) GetByHospital(hospitalID uint) ([]model.DutyRoster, error) {
+	var rosters []model.DutyRoster
+	err := database.DB.
+		Preload("Slots", func(db *gorm.DB) *gorm.DB {
+			return db.Order("duty_roster_slots.id ASC")
+		}).
+		Preload("Slots.ShiftTemplate", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped()
+		}).
+		Where("hospital_id = ?", hospitalID).
+		Order("start_date DESC, id DESC").
+		Find(&rosters).Error
+	return rosters, err
+}
+
+// Delete listeyi slotları ve atamalarıyla birlikte siler
+func (r *DutyRosterRepository) Delete(roster *model.DutyRoster) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("roster_id = ?", roster.ID).Delete(&model.DutyAssignment{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("roster_id = ?", roster.ID).Delete(&model.DutyRosterSlot{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(roster).Error
+	})
+}
+
+// Publish taslak listeyi yayınlar - liste okunduktan sonra yayınlandıysa ErrDutyRosterNotDraft döner
+func (r *DutyRosterRepository) Publish(roster *model.DutyRoster, publishedBy *uint) error {
+	now := time.Now()
+	result := database.DB.Model(&model.DutyRoster{}).
+		Where("id = ? AND status = ?", roster.ID, model.DutyRosterDraft).
+		Updates(map[string]interface{}{
+			"status":       model.DutyRosterPublished,
+			"published_by": publishedBy,
+			"published_at": now,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrDutyRosterNotDraft
+	}
+
+	roster.Status = model.DutyRosterPublished
+	roster.PublishedBy = publishedBy
+	roster.PublishedAt = &now
+	return nil
+}
+
+// ==================== ATAMALAR ====================
+
+// CreateAssignment taslak listeye atama ekler
+func (r *DutyRosterRepository) CreateAssignment(assignment *model.DutyAssignment) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := lockDraftRoster(tx, assignment.RosterID); err != nil {
+			return err
+		}
+		return tx.Omit(clause.Associations).Create(assignment).Error
+	})
+}
+
+// UpdateAssignment taslak listedeki atamanın personelini değiştirir
+func (r *DutyRosterRepository) UpdateAssignment(assignment *model.DutyAssignment) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := lockDraftRoster(tx, assignment.RosterID); err != nil {
+			return err
+		}
+		return tx.Model(assignment).Updates(map[string]interface{}{
+			"staff_id": assignment.StaffID,
+			"manual":   true,
+		}).Error
+	})
+}
+
+// DeleteAssignment taslak listeden atamayı kaldırır
+func (r *DutyRosterRepository) DeleteAssignment(assignment *model.DutyAssignment) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := lockDraftRoster(tx, assignment.RosterID); err != nil {
+			return err
+		}
+		return tx.Delete(assignment).Error
+	})
+}
+
+// GetPublishedAssignments verilen personellerin [from, to] aralığında başlayan, yayınlanmış listelerdeki nöbetlerini getirir
+// excludeRosterID > 0 ise o listenin atamaları hariç tutulur (liste kendi atamalarıyla çakışmaz)
+func (r *DutyRosterRepository) GetPublishedAssignments(staffIDs []uint, from, to time.Time, excludeRosterID uint) ([]model.DutyAssignment, error) {
+	var assignments []model.DutyAssignment
+	if len(staffIDs) == 0 {
+		return assignments, nil
+	}
+	err := r.withDetails(database.DB).
+		Joins("JOIN duty_rosters r ON r.id = duty_assignments.roster_id AND r.deleted_at IS NULL").
+		Where("r.status = ? AND r.id <> ?", model.DutyRosterPublished, excludeRosterID).
+		Where("duty_assignments.staff_id IN ?", staffIDs).
+		Where("duty_assignments.date BETWEEN ? AND ?", formatDate(from), formatDate(to)).
+		Order("duty_assignments.starts_at ASC").
+		Find(&assignments).Error
+	return assignments, err
+}
+
+// withDetails atamaları personel ve vardiya adıyla yükler (silinmiş vardiyalar da gösterilir)
+func (r *DutyRosterRepository) withDetails(db *gorm.DB) *gorm.DB {
+	return db.Model(&model.DutyAssignment{}).
+		Select("duty_assignments.*, s.first_name || ' ' || s.last_name AS staff_name, st.name AS shift_name, ds.shift_template_id").
+		Joins("JOIN staffs s ON s.id = duty_assignments.staff_id").
+		Joins("JOIN duty_roster_slots ds ON ds.id = duty_assignments.slot_id").
+		Joins("JOIN shift_templates st ON st.id = ds.shift_template_id")
+}
+
+// lockDraftRoster listenin satırını kilitler ve hâlâ taslak olduğunu doğrular
+// Böylece yayınlama ile eşzamanlı yapılan düzenleme yayınlanmış listeye yazılamaz
+func lockDraftRoster(tx *gorm.DB, rosterID uint) error {
+	var roster model.DutyRoster
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "status").First(&roster, rosterID).Error
+	if err != nil {
+		return err
+	}
+	if roster.Status != model.DutyRosterDraft {
+		return ErrDutyRosterNotDraft
+	}
+	return nil
+}
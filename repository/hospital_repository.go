@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"hospital-platform/database"
 	"hospital-platform/model"
-	"hospital-platform/utils"
+	"time"
+
+	"gorm.io/gorm"
 )
 
 // HospitalRepository hastane veritabanı işlemlerini yönetir
@@ -67,6 +69,12 @@ func (r *HospitalRepository) Update(hospital *model.Hospital) error {
 	return result.Error
 }
 
+// UpdateRequireMFA hastanenin MFA zorunluluk politikasını günceller
+func (r *HospitalRepository) UpdateRequireMFA(hospitalID uint, requireMFA bool) error {
+	result := database.DB.Model(&model.Hospital{}).Where("id = ?", hospitalID).Update("require_mfa", requireMFA)
+	return result.Error
+}
+
 // CreateHospitalWithAdmin hastane ve admin kullanıcıyı transaction ile oluşturur
 func (r *HospitalRepository) CreateHospitalWithAdmin(req *model.HospitalRegistrationRequest, hashedPassword string) (*model.HospitalRegistrationResponse, error) {
 	// Transaction başlat
@@ -81,7 +89,8 @@ func (r *HospitalRepository) CreateHospitalWithAdmin(req *model.HospitalRegistra
 		}
 	}()
 
-	// 1. Hastaneyi oluştur
+	// 1. Hastaneyi oluştur - platform onayına kadar başvuru durumunda kalır
+	now := time.Now()
 	hospital := &model.Hospital{
 		Name:          req.HospitalName,
 		TaxID:         req.TaxID,
@@ -90,6 +99,8 @@ func (r *HospitalRepository) CreateHospitalWithAdmin(req *model.HospitalRegistra
 		ProvinceID:    req.ProvinceID,
 		DistrictID:    req.DistrictID,
 		AddressDetail: req.AddressDetail,
+		Status:        model.HospitalStatusPending,
+		SubmittedAt:   &now,
 	}
 
 	if err := tx.Create(hospital).Error; err != nil {
@@ -97,7 +108,13 @@ func (r *HospitalRepository) CreateHospitalWithAdmin(req *model.HospitalRegistra
 		return nil, fmt.Errorf("hastane oluşturulamadı: %v", err)
 	}
 
-	// 2. Admin kullanıcıyı oluştur
+	// 2. Varsayılan rolleri oluştur (yetkili, çalışan)
+	if err := database.CreateDefaultRoles(tx, hospital.ID); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("varsayılan roller oluşturulamadı: %v", err)
+	}
+
+	// 3. Admin kullanıcıyı oluştur
 	adminUser := &model.User{
 		HospitalID: hospital.ID,
 		FirstName:  req.AdminFirstName,
@@ -108,7 +125,7 @@ func (r *HospitalRepository) CreateHospitalWithAdmin(req *model.HospitalRegistra
 		Password:   hashedPassword,
 		Role:       model.RoleYetkili, // İlk kullanıcı yetkili
 		CreatedBy:  nil,               // İlk kullanıcı için nil
-		IsActive:   true,
+		Status:     model.UserStatusActive,
 	}
 
 	if err := tx.Create(adminUser).Error; err != nil {
@@ -116,26 +133,19 @@ func (r *HospitalRepository) CreateHospitalWithAdmin(req *model.HospitalRegistra
 		return nil, fmt.Errorf("admin kullanıcı oluşturulamadı: %v", err)
 	}
 
-	// 3. Transaction'ı commit et
+	// 4. Transaction'ı commit et
 	if err := tx.Commit().Error; err != nil {
 		return nil, fmt.Errorf("transaction commit edilemedi: %v", err)
 	}
 
-	// 4. JWT token oluştur
-	token, err := utils.GenerateJWT(adminUser.ID, adminUser.Email, adminUser.Role, adminUser.HospitalID, adminUser.Email)
-	if err != nil {
-		return nil, fmt.Errorf("token oluşturulamadı: %v", err)
-	}
-
-	// 5. Response için ilişkileri yükle
+	// 5. Response için ilişkileri yükle (token'lar servis katmanında eklenir)
 	hospitalWithRelations, _ := r.GetByID(hospital.ID)
 	adminUser.Password = "" // Şifreyi response'dan kaldır
 
 	response := &model.HospitalRegistrationResponse{
-		Message:   "Hastane ve admin kullanıcı başarıyla oluşturuldu",
+		Message:   "Hastane başvurusu alındı. Platform onayına kadar sadece başvuru bilgileri ve belgeleri yönetilebilir",
 		Hospital:  *hospitalWithRelations,
 		AdminUser: *adminUser,
-		Token:     token,
 	}
 
 	return response, nil
@@ -146,3 +156,96 @@ func (r *HospitalRepository) Delete(id uint) error {
 	result := database.DB.Delete(&model.Hospital{}, id)
 	return result.Error
 }
+
+// ==================== KAYIT BAŞVURUSU ====================
+
+// UpdateApplication başvurudaki hastane bilgilerini günceller
+// Sadece başvuru durumundaki (pending, rejected) hastanelerde çalışır; aksi halde gorm.ErrRecordNotFound döner
+func (r *HospitalRepository) UpdateApplication(hospitalID uint, req *model.UpdateHospitalApplicationRequest) error {
+	result := database.DB.Model(&model.Hospital{}).
+		Where("id = ? AND status IN ?", hospitalID, []string{model.HospitalStatusPending, model.HospitalStatusRejected}).
+		Updates(map[string]interface{}{
+			"name":           req.HospitalName,
+			"tax_id":         req.TaxID,
+			"email":          req.HospitalEmail,
+			"phone":          req.HospitalPhone,
+			"province_id":    req.ProvinceID,
+			"district_id":    req.DistrictID,
+			"address_detail": req.AddressDetail,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ResubmitApplication reddedilen başvuruyu tekrar incelemeye gönderir
+// Başvuru bu arada başka bir duruma geçtiyse gorm.ErrRecordNotFound döner
+func (r *HospitalRepository) ResubmitApplication(hospitalID uint) error {
+	now := time.Now()
+	result := database.DB.Model(&model.Hospital{}).
+		Where("id = ? AND status = ?", hospitalID, model.HospitalStatusRejected).
+		Updates(map[string]interface{}{
+			"status":            model.HospitalStatusPending,
+			"submitted_at":      &now,
+			"status_changed_at": &now,
+			"status_changed_by": nil,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// CreateDocument başvuru belgesi ekler
+func (r *HospitalRepository) CreateDocument(document *model.HospitalDocument) error {
+	return database.DB.Create(document).Error
+}
+
+// ListDocuments hastanenin başvuru belgelerini dosya içerikleri olmadan getirir
+func (r *HospitalRepository) ListDocuments(hospitalID uint) ([]model.HospitalDocument, error) {
+	var documents []model.HospitalDocument
+	err := database.DB.Omit("data").
+		Where("hospital_id = ?", hospitalID).
+		Order("created_at ASC").
+		Find(&documents).Error
+	return documents, err
+}
+
+// GetDocument hastaneye ait belgeyi içeriğiyle birlikte getirir
+func (r *HospitalRepository) GetDocument(hospitalID, documentID uint) (*model.HospitalDocument, error) {
+	var document model.HospitalDocument
+	if err := database.DB.Where("id = ? AND hospital_id = ?", documentID, hospitalID).First(&document).Error; err != nil {
+		return nil, err
+	}
+	return &document, nil
+}
+
+// DeleteDocument hastaneye ait belgeyi kalıcı olarak siler
+func (r *HospitalRepository) DeleteDocument(hospitalID, documentID uint) error {
+	result := database.DB.Where("id = ? AND hospital_id = ?", documentID, hospitalID).Delete(&model.HospitalDocument{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// CountDocuments hastanenin başvuru belgelerini sayar (docType boşsa tüm türler)
+func (r *HospitalRepository) CountDocuments(hospitalID uint, docType string) (int64, error) {
+	query := database.DB.Model(&model.HospitalDocument{}).Where("hospital_id = ?", hospitalID)
+	if docType != "" {
+		query = query.Where("type = ?", docType)
+	}
+	var count int64
+	err := query.Count(&count).Error
+	return count, err
+}
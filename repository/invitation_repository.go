@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"errors"
+	"hospital-platform/database"
+	"hospital-platform/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvitationAlreadyUsed davet kabul edilirken başka bir istek tarafından kullanılmış/iptal edilmişse döner
+var ErrInvitationAlreadyUsed = errors.New("davet artık geçerli değil")
+
+// InvitationRepository alt kullanıcı davetlerinin veritabanı işlemlerini yönetir
+type InvitationRepository struct{}
+
+// NewInvitationRepository yeni bir davet repository'si oluşturur
+func NewInvitationRepository() *InvitationRepository {
+	return &InvitationRepository{}
+}
+
+// Create yeni davet oluşturur
+func (r *InvitationRepository) Create(invitation *model.Invitation) error {
+	return database.DB.Create(invitation).Error
+}
+
+// Save daveti günceller
+func (r *InvitationRepository) Save(invitation *model.Invitation) error {
+	return database.DB.Save(invitation).Error
+}
+
+// Delete daveti kalıcı olarak siler - sadece hiç gönderilemeyen yeni davetler için kullanılır
+func (r *InvitationRepository) Delete(invitation *model.Invitation) error {
+	return database.DB.Unscoped().Delete(invitation).Error
+}
+
+// GetByID ID'ye göre daveti getirir
+func (r *InvitationRepository) GetByID(id uint) (*model.Invitation, error) {
+	var invitation model.Invitation
+	if err := database.DB.First(&invitation, id).Error; err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// GetByTokenHash link token'ının hash'ine göre daveti hastane bilgisiyle getirir
+func (r *InvitationRepository) GetByTokenHash(tokenHash string) (*model.Invitation, error) {
+	var invitation model.Invitation
+	if err := database.DB.Preload("Hospital").Where("token_hash = ?", tokenHash).First(&invitation).Error; err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// GetByHospitalID hastanenin davetlerini listeler
+// status: pending (süresi dolmamış), expired (bekleyen ama süresi dolmuş), accepted, revoked veya boş (hepsi)
+func (r *InvitationRepository) GetByHospitalID(hospitalID uint, status string) ([]model.Invitation, error) {
+	query := database.DB.Where("hospital_id = ?", hospitalID)
+
+	now := time.Now()
+	switch status {
+	case "":
+	case model.InvitationPending:
+		query = query.Where("status = ? AND expires_at > ?", model.InvitationPending, now)
+	case model.InvitationExpired:
+		query = query.Where("status = ? AND expires_at <= ?", model.InvitationPending, now)
+	default:
+		query = query.Where("status = ?", status)
+	}
+
+	var invitations []model.Invitation
+	err := query.Order("created_at DESC").Find(&invitations).Error
+	return invitations, err
+}
+
+// HasActiveForContact e-posta veya telefon için süresi dolmamış bekleyen davet olup olmadığını kontrol eder
+func (r *InvitationRepository) HasActiveForContact(email, phone string, excludeID uint) (bool, error) {
+	var count int64
+	err := database.DB.Model(&model.Invitation{}).
+		Where("status = ? AND expires_at > ? AND id != ?", model.InvitationPending, time.Now(), excludeID).
+		Where("email = ? OR phone = ?", email, phone).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Accept daveti kabul edilmiş işaretler ve kullanıcıyı aynı transaction içinde oluşturur
+// Koşullu güncelleme sayesinde aynı link iki kez (eşzamanlı da olsa) kullanılamaz
+func (r *InvitationRepository) Accept(invitation *model.Invitation, user *model.User) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		result := tx.Model(&model.Invitation{}).
+			Where("id = ? AND token_hash = ? AND status = ? AND expires_at > ?",
+				invitation.ID, invitation.TokenHash, model.InvitationPending, now).
+			Updates(map[string]interface{}{
+				"status":      model.InvitationAccepted,
+				"accepted_at": now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return ErrInvitationAlreadyUsed
+		}
+
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&model.Invitation{}).
+			Where("id = ?", invitation.ID).
+			Update("accepted_user_id", user.ID).Error
+	})
+}
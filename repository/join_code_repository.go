@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"hospital-platform/database"
+	"hospital-platform/model"
+)
+
+// JoinCodeRepository katılım kodları ve izinli e-posta alan adlarının veritabanı işlemlerini yönetir
+type JoinCodeRepository struct{}
+
+// NewJoinCodeRepository yeni bir katılım kodu repository'si oluşturur
+func NewJoinCodeRepository() *JoinCodeRepository {
+	return &JoinCodeRepository{}
+}
+
+// ==================== KATILIM KODLARI ====================
+
+// Create yeni katılım kodu oluşturur
+func (r *JoinCodeRepository) Create(code *model.JoinCode) error {
+	return database.DB.Create(code).Error
+}
+
+// Save katılım kodunu günceller
+func (r *JoinCodeRepository) Save(code *model.JoinCode) error {
+	return database.DB.Save(code).Error
+}
+
+// GetByID ID'ye göre katılım kodunu getirir
+func (r *JoinCodeRepository) GetByID(id uint) (*model.JoinCode, error) {
+	var code model.JoinCode
+	if err := database.DB.First(&code, id).Error; err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+// GetByCodeHash kodun hash'ine göre katılım kodunu getirir
+func (r *JoinCodeRepository) GetByCodeHash(codeHash string) (*model.JoinCode, error) {
+	var code model.JoinCode
+	if err := database.DB.Where("code_hash = ?", codeHash).First(&code).Error; err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+// GetByHospitalID hastanenin katılım kodlarını listeler
+func (r *JoinCodeRepository) GetByHospitalID(hospitalID uint) ([]model.JoinCode, error) {
+	var codes []model.JoinCode
+	err := database.DB.Where("hospital_id = ?", hospitalID).Order("created_at DESC").Find(&codes).Error
+	return codes, err
+}
+
+// ==================== İZİNLİ E-POSTA ALAN ADLARI ====================
+
+// CreateEmailDomain izinli alan adı ekler
+func (r *JoinCodeRepository) CreateEmailDomain(domain *model.HospitalEmailDomain) error {
+	return database.DB.Create(domain).Error
+}
+
+// GetEmailDomain alan adına göre kaydı getirir
+func (r *JoinCodeRepository) GetEmailDomain(domain string) (*model.HospitalEmailDomain, error) {
+	var emailDomain model.HospitalEmailDomain
+	if err := database.DB.Where("domain = ?", domain).First(&emailDomain).Error; err != nil {
+		return nil, err
+	}
+	return &emailDomain, nil
+}
+
+// GetEmailDomainByID ID'ye göre alan adı kaydını getirir
+func (r *JoinCodeRepository) GetEmailDomainByID(id uint) (*model.HospitalEmailDomain, error) {
+	var emailDomain model.HospitalEmailDomain
+	if err := database.DB.First(&emailDomain, id).Error; err != nil {
+		return nil, err
+	}
+	return &emailDomain, nil
+}
+
+// GetEmailDomainsByHospitalID hastanenin izinli alan adlarını listeler
+func (r *JoinCodeRepository) GetEmailDomainsByHospitalID(hospitalID uint) ([]model.HospitalEmailDomain, error) {
+	var domains []model.HospitalEmailDomain
+	err := database.DB.Where("hospital_id = ?", hospitalID).Order("domain").Find(&domains).Error
+	return domains, err
+}
+
+// DeleteEmailDomain alan adını kalıcı olarak siler (başka bir hastane tarafından tekrar eklenebilsin diye)
+func (r *JoinCodeRepository) DeleteEmailDomain(domain *model.HospitalEmailDomain) error {
+	return database.DB.Unscoped().Delete(domain).Error
+}
@@ -0,0 +1,320 @@
+package repository
+
+import (
+	"errors"
+	"hospital-platform/database"
+	"hospital-platform/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrLeaveAlreadyReviewed izin talebi başka bir yetkili tarafından zaten incelenmiş veya iptal edilmişse döner
+	ErrLeaveAlreadyReviewed = errors.New("izin talebi zaten incelenmiş")
+	// ErrLeaveBalanceExceeded onay anında yıllık hak aşılıyorsa döner
+	ErrLeaveBalanceExceeded = errors.New("yıllık izin hakkı aşılıyor")
+)
+
+// LeaveRepository izin türleri, yıllık haklar ve izin talepleri veritabanı işlemlerini yönetir
+// Tarih karşılaştırmaları program repository'sindeki gibi "YYYY-AA-GG" metniyle yapılır
+type LeaveRepository struct{}
+
+// NewLeaveRepository yeni bir izin repository'si oluşturur
+func NewLeaveRepository() *LeaveRepository {
+	return &LeaveRepository{}
+}
+
+// ==================== İZİN TÜRLERİ ====================
+
+// EnsureDefaultTypes hastanede hiç izin türü tanımlanmamışsa varsayılan türleri oluşturur
+// Silinmiş türler de sayılır, böylece hastanenin kaldırdığı türler geri gelmez
+func (r *LeaveRepository) EnsureDefaultTypes(hospitalID uint) error {
+	var count int64
+	if err := database.DB.Unscoped().Model(&model.LeaveType{}).Where("hospital_id = ?", hospitalID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	types := make([]model.LeaveType, len(model.DefaultLeaveTypes))
+	for i, leaveType := range model.DefaultLeaveTypes {
+		types[i] = model.LeaveType{HospitalID: hospitalID, Name: leaveType.Name, AnnualDays: leaveType.AnnualDays}
+	}
+	// Eşzamanlı ilk istekler aynı türleri oluşturmaya çalışabilir
+	return database.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&types).Error
+}
+
+// GetTypesByHospital hastanenin izin türlerini adına göre getirir
+func (r *LeaveRepository) GetTypesByHospital(hospitalID uint) ([]model.LeaveType, error) {
+	var types []model.LeaveType
+	err := database.DB.Where("hospital_id = ?", hospitalID).Order("name ASC").Find(&types).Error
+	return types, err
+}
+
+// GetTypeByID ID'ye göre izin türünü getirir
+func (r *LeaveRepository) GetTypeByID(id uint) (*model.LeaveType, error) {
+	var leaveType model.LeaveType
+	if err := database.DB.First(&leaveType, id).Error; err != nil {
+		return nil, err
+	}
+	return &leaveType, nil
+}
+
+// GetTypeByName hastanedeki izin türünü adına göre getirir
+func (r *LeaveRepository) GetTypeByName(hospitalID uint, name string) (*model.LeaveType, error) {
+	var leaveType model.LeaveType
+	if err := database.DB.Where("hospital_id = ? AND name = ?", hospitalID, name).First(&leaveType).Error; err != nil {
+		return nil, err
+	}
+	return &leaveType, nil
+}
+
+// CreateType izin türü ekler
+func (r *LeaveRepository) CreateType(leaveType *model.LeaveType) error {
+	return database.DB.Create(leaveType).Error
+}
+
+// UpdateType izin türünü günceller
+func (r *LeaveRepository) UpdateType(leaveType *model.LeaveType) error {
+	return database.DB.Save(leaveType).Error
+}
+
+// DeleteType izin türünü siler
+func (r *LeaveRepository) DeleteType(id uint) error {
+	return database.DB.Delete(&model.LeaveType{}, id).Error
+}
+
+// IsTypeInUse türde bekleyen veya onaylanmış talep olup olmadığını kontrol eder
+func (r *LeaveRepository) IsTypeInUse(id uint) (bool, error) {
+	var count int64
+	err := database.DB.Model(&model.LeaveRequest{}).
+		Where("leave_type_id = ? AND status IN ?", id, []string{model.LeavePending, model.LeaveApproved}).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// ==================== YILLIK HAKLAR ====================
+
+// GetBalances personelin bir yıl için tanımlanmış özel haklarını getirir
+func (r *LeaveRepository) GetBalances(staffID uint, year int) ([]model.LeaveBalance, error) {
+	var balances []model.LeaveBalance
+	err := database.DB.Where("staff_id = ? AND year = ?", staffID, year).Find(&balances).Error
+	return balances, err
+}
+
+// GetBalance personelin izin türü ve yıl için özel hakkını getirir
+func (r *LeaveRepository) GetBalance(staffID, leaveTypeID uint, year int) (*model.LeaveBalance, error) {
+	var balance model.LeaveBalance
+	err := database.DB.Where("staff_id = ? AND leave_type_id = ? AND year = ?", staffID, leaveTypeID, year).First(&balance).Error
+	if err != nil {
+		return nil, err
+	}
+	return &balance, nil
+}
+
+// UpsertBalance personelin yıllık hakkını ekler veya günceller
+func (r *LeaveRepository) UpsertBalance(balance *model.LeaveBalance) error {
+	return database.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "staff_id"}, {Name: "leave_type_id"}, {Name: "year"}},
+		DoUpdates: clause.AssignmentColumns([]string{"days", "updated_at", "deleted_at"}),
+	}).Create(balance).Error
+}
+
+// SumDays personelin yıl içindeki talep günlerini türe ve duruma göre toplar (bekleyen ve onaylanmış)
+// Dönen map: izin türü ID → durum → gün
+func (r *LeaveRepository) SumDays(staffID uint, year int) (map[uint]map[string]int, error) {
+	var rows []struct {
+		LeaveTypeID uint
+		Status      string
+		Days        int
+	}
+	err := database.DB.Model(&model.LeaveRequest{}).
+		Select("leave_type_id, status, SUM(days) AS days").
+		Where("staff_id = ? AND EXTRACT(YEAR FROM start_date) = ?", staffID, year).
+		Where("status IN ?", []string{model.LeavePending, model.LeaveApproved}).
+		Group("leave_type_id, status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	sums := make(map[uint]map[string]int)
+	for _, row := range rows {
+		if sums[row.LeaveTypeID] == nil {
+			sums[row.LeaveTypeID] = make(map[string]int)
+		}
+		sums[row.LeaveTypeID][row.Status] = row.Days
+	}
+	return sums, nil
+}
+
+// ==================== İZİN TALEPLERİ ====================
+
+// CreateRequest izin talebini ekler
+func (r *LeaveRepository) CreateRequest(request *model.LeaveRequest) error {
+	return database.DB.Omit(clause.Associations).Create(request).Error
+}
+
+// GetRequestByID izin talebini personel adı ve türüyle getirir
+func (r *LeaveRepository) GetRequestByID(id uint) (*model.LeaveRequest, error) {
+	var request model.LeaveRequest
+	if err := r.withDetails(database.DB).First(&request, "leave_requests.id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// ListRequests hastanenin izin taleplerini filtreye göre başlangıç tarihi sırasıyla getirir
+func (r *LeaveRepository) ListRequests(hospitalID uint, filter *model.LeaveRequestFilter) ([]model.LeaveRequest, error) {
+	query := r.withDetails(database.DB).
+		Where("leave_requests.hospital_id = ? AND s.deleted_at IS NULL", hospitalID)
+	if filter.Status != "" {
+		query = query.Where("leave_requests.status = ?", filter.Status)
+	}
+	if filter.StaffID != nil {
+		query = query.Where("leave_requests.staff_id = ?", *filter.StaffID)
+	}
+	if filter.PolyclinicID != nil {
+		query = query.Where("s.polyclinic_id = ?", *filter.PolyclinicID)
+	}
+	if filter.From != nil {
+		query = query.Where("leave_requests.end_date >= ?", formatDate(*filter.From))
+	}
+	if filter.To != nil {
+		query = query.Where("leave_requests.start_date <= ?", formatDate(*filter.To))
+	}
+
+	var requests []model.LeaveRequest
+	err := query.Order("leave_requests.start_date ASC, leave_requests.id ASC").Find(&requests).Error
+	return requests, err
+}
+
+// GetActiveRequestsByStaff personelin [from, to] aralığına denk gelen bekleyen ve onaylanmış taleplerini getirir
+func (r *LeaveRepository) GetActiveRequestsByStaff(staffID uint, from, to time.Time) ([]model.LeaveRequest, error) {
+	var requests []model.LeaveRequest
+	err := r.withDetails(database.DB).
+		Where("leave_requests.staff_id = ? AND leave_requests.status IN ?", staffID, []string{model.LeavePending, model.LeaveApproved}).
+		Where("leave_requests.start_date <= ? AND leave_requests.end_date >= ?", formatDate(to), formatDate(from)).
+		Order("leave_requests.start_date ASC").
+		Find(&requests).Error
+	return requests, err
+}
+
+// GetApprovedByStaffIDs verilen personellerin [from, to] aralığına denk gelen onaylı izinlerini getirir
+// Müsaitlik hesaplayan her yer (vardiya takvimi, personel listesi) bu sorguyu kullanır
+func (r *LeaveRepository) GetApprovedByStaffIDs(staffIDs []uint, from, to time.Time) ([]model.LeaveRequest, error) {
+	var requests []model.LeaveRequest
+	if len(staffIDs) == 0 {
+		return requests, nil
+	}
+	err := r.withDetails(database.DB).
+		Where("leave_requests.staff_id IN ? AND leave_requests.status = ?", staffIDs, model.LeaveApproved).
+		Where("leave_requests.start_date <= ? AND leave_requests.end_date >= ?", formatDate(to), formatDate(from)).
+		Order("leave_requests.staff_id ASC, leave_requests.start_date ASC").
+		Find(&requests).Error
+	return requests, err
+}
+
+// Approve bekleyen talebi onaylar
+// entitled >= 0 ise personelin satırı kilitlenip yıl içindeki onaylı günlerle birlikte hak aşılmadığı kontrol edilir,
+// böylece aynı personelin iki talebi eşzamanlı onaylanarak hak aşılamaz
+func (r *LeaveRepository) Approve(request *model.LeaveRequest, reviewedBy uint, entitled int) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if entitled >= 0 {
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Select("id").First(&model.Staff{}, request.StaffID).Error; err != nil {
+				return err
+			}
+
+			var used int64
+			err := tx.Model(&model.LeaveRequest{}).
+				Select("COALESCE(SUM(days), 0)").
+				Where("staff_id = ? AND leave_type_id = ? AND status = ?", request.StaffID, request.LeaveTypeID, model.LeaveApproved).
+				Where("EXTRACT(YEAR FROM start_date) = ?", request.StartDate.Year()).
+				Scan(&used).Error
+			if err != nil {
+				return err
+			}
+			if int(used)+request.Days > entitled {
+				return ErrLeaveBalanceExceeded
+			}
+		}
+
+		now := time.Now()
+		if err := markLeaveStatus(tx, request.ID, []string{model.LeavePending}, map[string]interface{}{
+			"status":      model.LeaveApproved,
+			"reviewed_by": reviewedBy,
+			"reviewed_at": now,
+		}); err != nil {
+			return err
+		}
+
+		request.Status = model.LeaveApproved
+		request.ReviewedBy = &reviewedBy
+		request.ReviewedAt = &now
+		return nil
+	})
+}
+
+// Reject bekleyen talebi gerekçesiyle reddeder
+func (r *LeaveRepository) Reject(request *model.LeaveRequest, reviewedBy uint, reason string) error {
+	now := time.Now()
+	if err := markLeaveStatus(database.DB, request.ID, []string{model.LeavePending}, map[string]interface{}{
+		"status":        model.LeaveRejected,
+		"reviewed_by":   reviewedBy,
+		"reviewed_at":   now,
+		"reject_reason": reason,
+	}); err != nil {
+		return err
+	}
+
+	request.Status = model.LeaveRejected
+	request.ReviewedBy = &reviewedBy
+	request.ReviewedAt = &now
+	request.RejectReason = reason
+	return nil
+}
+
+// Cancel talebi iptal eder - talep okunduktan sonra durumu değiştiyse ErrLeaveAlreadyReviewed döner
+func (r *LeaveRepository) Cancel(request *model.LeaveRequest, cancelledBy *uint) error {
+	now := time.Now()
+	if err := markLeaveStatus(database.DB, request.ID, []string{request.Status}, map[string]interface{}{
+		"status":       model.LeaveCancelled,
+		"cancelled_by": cancelledBy,
+		"cancelled_at": now,
+	}); err != nil {
+		return err
+	}
+
+	request.Status = model.LeaveCancelled
+	request.CancelledBy = cancelledBy
+	request.CancelledAt = &now
+	return nil
+}
+
+// markLeaveStatus talebin durumunu sadece beklenen durumlardaysa günceller - aynı talep iki kez incelenemez
+func markLeaveStatus(tx *gorm.DB, id uint, expected []string, updates map[string]interface{}) error {
+	result := tx.Model(&model.LeaveRequest{}).
+		Where("id = ? AND status IN ?", id, expected).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrLeaveAlreadyReviewed
+	}
+	return nil
+}
+
+// withDetails talepleri personel adı ve izin türüyle yükler (silinmiş türler de gösterilir)
+func (r *LeaveRepository) withDetails(db *gorm.DB) *gorm.DB {
+	return db.Model(&model.LeaveRequest{}).
+		Select("leave_requests.*, s.first_name || ' ' || s.last_name AS staff_name").
+		Joins("JOIN staffs s ON s.id = leave_requests.staff_id").
+		Preload("LeaveType", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped()
+		})
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"hospital-platform/database"
+	"hospital-platform/model"
+
+	"gorm.io/gorm/clause"
+)
+
+// MasterDataRepository il/ilçe, meslek grubu/unvan ve poliklinik türü gibi tüm hastanelerin ortak kullandığı
+// master data kayıtlarının yazma işlemlerini yönetir (okuma işlemleri ilgili repository'lerde ve cache'tedir)
+type MasterDataRepository struct{}
+
+// NewMasterDataRepository yeni bir master data repository'si oluşturur
+func NewMasterDataRepository() *MasterDataRepository {
+	return &MasterDataRepository{}
+}
+
+// Create yeni master data kaydı oluşturur
+func (r *MasterDataRepository) Create(record interface{}) error {
+	return database.DB.Create(record).Error
+}
+
+// Update master data kaydını günceller (ilişkiler güncellenmez)
+func (r *MasterDataRepository) Update(record interface{}) error {
+	return database.DB.Omit(clause.Associations).Save(record).Error
+}
+
+// Delete kaydı kalıcı olarak siler - aynı adla tekrar eklenebilmesi için soft delete kullanılmaz
+// Çağıran, kaydın hiçbir yerde kullanılmadığını önceden kontrol etmelidir
+func (r *MasterDataRepository) Delete(record interface{}) error {
+	return database.DB.Unscoped().Delete(record).Error
+}
+
+// GetByID verilen tipteki kaydı ID ile getirir (record: &model.Province{} vb.)
+func (r *MasterDataRepository) GetByID(record interface{}, id uint) error {
+	return database.DB.First(record, id).Error
+}
+
+// NameExists aynı kapsamda aynı adlı başka kayıt olup olmadığını kontrol eder (büyük/küçük harf duyarsız)
+// scopeColumn boşsa tüm tablo, doluysa (örn: province_id) sadece aynı üst kaydın altı aranır
+func (r *MasterDataRepository) NameExists(table interface{}, name, scopeColumn string, scopeID, excludeID uint) (bool, error) {
+	query := database.DB.Model(table).Where("LOWER(name) = LOWER(?)", name)
+	if scopeColumn != "" {
+		query = query.Where(scopeColumn+" = ?", scopeID)
+	}
+	if excludeID != 0 {
+		query = query.Where("id <> ?", excludeID)
+	}
+
+	var count int64
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+// ==================== KULLANIM KONTROLLERİ ====================
+
+// masterDataUsage silinmek istenen kaydı kullanan tablolar
+var masterDataUsage = map[string][]struct {
+	table  interface{}
+	column string
+}{
+	model.PlatformTargetProvince: {
+		{&model.District{}, "province_id"},
+		{&model.Hospital{}, "province_id"},
+	},
+	model.PlatformTargetDistrict: {
+		{&model.Hospital{}, "district_id"},
+	},
+	model.PlatformTargetJobGroup: {
+		{&model.JobTitle{}, "job_group_id"},
+		{&model.Staff{}, "job_group_id"},
+	},
+	model.PlatformTargetJobTitle: {
+		{&model.Staff{}, "job_title_id"},
+	},
+	model.PlatformTargetPolyclinicType: {
+		{&model.HospitalPolyclinic{}, "polyclinic_type_id"},
+	},
+}
+
+// IsInUse kaydın alt kayıtlar, hastaneler veya personel tarafından kullanılıp kullanılmadığını kontrol eder
+// Soft delete edilmiş kayıtlar da foreign key'i tuttuğu için sayılır
+func (r *MasterDataRepository) IsInUse(targetType string, id uint) (bool, error) {
+	for _, usage := range masterDataUsage[targetType] {
+		var count int64
+		if err := database.DB.Unscoped().Model(usage.table).Where(usage.column+" = ?", id).Count(&count).Error; err != nil {
+			return false, err
+		}
+		if count > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"hospital-platform/database"
+	"hospital-platform/model"
+)
+
+// MembershipRepository kullanıcıların ek hastane üyeliklerinin veritabanı işlemlerini yönetir
+type MembershipRepository struct{}
+
+// NewMembershipRepository yeni bir üyelik repository'si oluşturur
+func NewMembershipRepository() *MembershipRepository {
+	return &MembershipRepository{}
+}
+
+// Create yeni üyelik oluşturur
+func (r *MembershipRepository) Create(membership *model.HospitalMembership) error {
+	return database.DB.Create(membership).Error
+}
+
+// GetByID ID'ye göre üyeliği kullanıcı bilgisiyle getirir
+func (r *MembershipRepository) GetByID(id uint) (*model.HospitalMembership, error) {
+	var membership model.HospitalMembership
+	if err := database.DB.Preload("User").First(&membership, id).Error; err != nil {
+		return nil, err
+	}
+	return &membership, nil
+}
+
+// GetActive kullanıcının verilen hastanedeki aktif üyeliğini hastane bilgisiyle getirir
+func (r *MembershipRepository) GetActive(userID, hospitalID uint) (*model.HospitalMembership, error) {
+	var membership model.HospitalMembership
+	err := database.DB.Preload("Hospital").
+		Where("user_id = ? AND hospital_id = ? AND is_active = ?", userID, hospitalID, true).
+		First(&membership).Error
+	if err != nil {
+		return nil, err
+	}
+	return &membership, nil
+}
+
+// Exists kullanıcının hastanede (aktif veya pasif) üyeliği olup olmadığını kontrol eder
+func (r *MembershipRepository) Exists(userID, hospitalID uint) (bool, error) {
+	var count int64
+	err := database.DB.Model(&model.HospitalMembership{}).
+		Where("user_id = ? AND hospital_id = ?", userID, hospitalID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// GetActiveByUserID kullanıcının aktif üyeliklerini hastane bilgisiyle getirir
+func (r *MembershipRepository) GetActiveByUserID(userID uint) ([]model.HospitalMembership, error) {
+	var memberships []model.HospitalMembership
+	err := database.DB.Preload("Hospital").
+		Where("user_id = ? AND is_active = ?", userID, true).
+		Order("hospital_id ASC").
+		Find(&memberships).Error
+	return memberships, err
+}
+
+// GetByHospitalID hastanenin diğer hastanelerden üyelerini kullanıcı bilgisiyle listeler
+func (r *MembershipRepository) GetByHospitalID(hospitalID uint) ([]model.HospitalMembership, error) {
+	var memberships []model.HospitalMembership
+	err := database.DB.Preload("User").
+		Where("hospital_id = ?", hospitalID).
+		Order("created_at DESC").
+		Find(&memberships).Error
+	return memberships, err
+}
+
+// Update üyeliği günceller
+func (r *MembershipRepository) Update(membership *model.HospitalMembership) error {
+	return database.DB.Omit("User", "Hospital").Save(membership).Error
+}
+
+// Delete üyeliği kalıcı olarak siler (kullanıcı daha sonra yeniden eklenebilsin)
+func (r *MembershipRepository) Delete(membership *model.HospitalMembership) error {
+	return database.DB.Unscoped().Delete(membership).Error
+}
+
+// DeleteByUserID kullanıcının tüm üyeliklerini kalıcı olarak siler (kullanıcı silindiğinde)
+func (r *MembershipRepository) DeleteByUserID(userID uint) error {
+	return database.DB.Unscoped().Where("user_id = ?", userID).Delete(&model.HospitalMembership{}).Error
+}
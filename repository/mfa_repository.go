@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"hospital-platform/database"
+	"hospital-platform/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MFARepository MFA kurtarma kodu veritabanı işlemlerini yönetir
+type MFARepository struct{}
+
+// NewMFARepository yeni bir MFA repository'si oluşturur
+func NewMFARepository() *MFARepository {
+	return &MFARepository{}
+}
+
+// ReplaceRecoveryCodes kullanıcının eski kurtarma kodlarını siler ve yenilerini transaction ile ekler
+func (r *MFARepository) ReplaceRecoveryCodes(userID uint, codeHashes []string) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&model.MFARecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		codes := make([]model.MFARecoveryCode, 0, len(codeHashes))
+		for _, hash := range codeHashes {
+			codes = append(codes, model.MFARecoveryCode{UserID: userID, CodeHash: hash})
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+// UseRecoveryCode kullanılmamış kurtarma kodunu atomik olarak kullanılmış işaretler
+// Kod bulunamazsa veya daha önce kullanıldıysa false döner
+func (r *MFARepository) UseRecoveryCode(userID uint, codeHash string) (bool, error) {
+	result := database.DB.Model(&model.MFARecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	return result.RowsAffected == 1, result.Error
+}
+
+// CountUnusedRecoveryCodes kullanıcının kalan kurtarma kodu sayısını döndürür
+func (r *MFARepository) CountUnusedRecoveryCodes(userID uint) (int64, error) {
+	var count int64
+	result := database.DB.Model(&model.MFARecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count)
+	return count, result.Error
+}
+
+// DeleteRecoveryCodes kullanıcının tüm kurtarma kodlarını siler (MFA kapatıldığında)
+func (r *MFARepository) DeleteRecoveryCodes(userID uint) error {
+	result := database.DB.Unscoped().Where("user_id = ?", userID).Delete(&model.MFARecoveryCode{})
+	return result.Error
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"hospital-platform/database"
+	"hospital-platform/model"
+	"time"
+)
+
+// PasskeyRepository WebAuthn kimlik bilgisi veritabanı işlemlerini yönetir
+type PasskeyRepository struct{}
+
+// NewPasskeyRepository yeni bir passkey repository'si oluşturur
+func NewPasskeyRepository() *PasskeyRepository {
+	return &PasskeyRepository{}
+}
+
+// Create yeni passkey kaydeder
+func (r *PasskeyRepository) Create(passkey *model.Passkey) error {
+	return database.DB.Create(passkey).Error
+}
+
+// ListByUser kullanıcının passkey'lerini kayıt sırasına göre getirir
+func (r *PasskeyRepository) ListByUser(userID uint) ([]model.Passkey, error) {
+	var passkeys []model.Passkey
+	result := database.DB.Where("user_id = ?", userID).Order("created_at ASC").Find(&passkeys)
+	return passkeys, result.Error
+}
+
+// CountByUser kullanıcının passkey sayısını döndürür
+func (r *PasskeyRepository) CountByUser(userID uint) (int64, error) {
+	var count int64
+	result := database.DB.Model(&model.Passkey{}).Where("user_id = ?", userID).Count(&count)
+	return count, result.Error
+}
+
+// GetByCredentialID authenticator'ın gönderdiği kimlik bilgisi ID'siyle passkey'i getirir
+func (r *PasskeyRepository) GetByCredentialID(credentialID string) (*model.Passkey, error) {
+	var passkey model.Passkey
+	result := database.DB.Where("credential_id = ?", credentialID).First(&passkey)
+	return &passkey, result.Error
+}
+
+// ExistsCredentialID kimlik bilgisi ID'sinin herhangi bir hesapta kayıtlı olup olmadığını kontrol eder
+func (r *PasskeyRepository) ExistsCredentialID(credentialID string) (bool, error) {
+	var count int64
+	result := database.DB.Model(&model.Passkey{}).Where("credential_id = ?", credentialID).Count(&count)
+	return count > 0, result.Error
+}
+
+// RecordUsage imza sayacını ve yedekleme durumunu günceller
+// Sayaç koşulu aynı imzanın eşzamanlı iki girişte kullanılmasını engeller; güncelleme olmazsa false döner
+func (r *PasskeyRepository) RecordUsage(id uint, previousCount, signCount int64, backupState bool) (bool, error) {
+	result := database.DB.Model(&model.Passkey{}).
+		Where("id = ? AND sign_count = ?", id, previousCount).
+		Updates(map[string]interface{}{
+			"sign_count":   signCount,
+			"backup_state": backupState,
+			"last_used_at": time.Now(),
+		})
+	return result.RowsAffected == 1, result.Error
+}
+
+// Delete kullanıcının passkey'ini siler, başka kullanıcının kaydına dokunmaz
+func (r *PasskeyRepository) Delete(userID, id uint) (bool, error) {
+	result := database.DB.Where("id = ? AND user_id = ?", id, userID).Delete(&model.Passkey{})
+	return result.RowsAffected == 1, result.Error
+}
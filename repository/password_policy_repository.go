@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"hospital-platform/database"
+	"hospital-platform/model"
+
+	"gorm.io/gorm"
+)
+
+// PasswordPolicyRepository şifre politikası ve şifre geçmişi veritabanı işlemlerini yönetir
+type PasswordPolicyRepository struct{}
+
+// NewPasswordPolicyRepository yeni bir şifre politikası repository'si oluşturur
+func NewPasswordPolicyRepository() *PasswordPolicyRepository {
+	return &PasswordPolicyRepository{}
+}
+
+// GetByHospitalID hastanenin şifre politikasını getirir
+func (r *PasswordPolicyRepository) GetByHospitalID(hospitalID uint) (*model.PasswordPolicy, error) {
+	var policy model.PasswordPolicy
+	if err := database.DB.Where("hospital_id = ?", hospitalID).First(&policy).Error; err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// Save politikayı oluşturur veya günceller
+func (r *PasswordPolicyRepository) Save(policy *model.PasswordPolicy) error {
+	return database.DB.Save(policy).Error
+}
+
+// GetRecentHistory kullanıcının en son kullandığı limit adet şifre hash'ini getirir
+func (r *PasswordPolicyRepository) GetRecentHistory(userID uint, limit int) ([]model.PasswordHistory, error) {
+	var history []model.PasswordHistory
+	err := database.DB.Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&history).Error
+	return history, err
+}
+
+// AddHistory şifre hash'ini geçmişe ekler ve en yeni keep adet kayıt dışındakileri siler
+func (r *PasswordPolicyRepository) AddHistory(userID uint, passwordHash string, keep int) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&model.PasswordHistory{UserID: userID, PasswordHash: passwordHash}).Error; err != nil {
+			return err
+		}
+
+		keepIDs := tx.Model(&model.PasswordHistory{}).
+			Select("id").
+			Where("user_id = ?", userID).
+			Order("created_at DESC, id DESC").
+			Limit(keep)
+		return tx.Where("user_id = ? AND id NOT IN (?)", userID, keepIDs).
+			Delete(&model.PasswordHistory{}).Error
+	})
+}
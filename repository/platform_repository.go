@@ -0,0 +1,243 @@
+package repository
+
+import (
+	"hospital-platform/database"
+	"hospital-platform/model"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PlatformRepository platform operatörü, denetim kaydı ve hastaneler arası sorguların veritabanı işlemlerini yönetir
+type PlatformRepository struct{}
+
+// NewPlatformRepository yeni bir platform repository'si oluşturur
+func NewPlatformRepository() *PlatformRepository {
+	return &PlatformRepository{}
+}
+
+// ==================== OPERATÖR ====================
+
+// GetAdminByEmail e-postaya göre operatörü getirir (büyük/küçük harf duyarsız)
+func (r *PlatformRepository) GetAdminByEmail(email string) (*model.PlatformAdmin, error) {
+	var admin model.PlatformAdmin
+	if err := database.DB.Where("LOWER(email) = ?", strings.ToLower(email)).First(&admin).Error; err != nil {
+		return nil, err
+	}
+	return &admin, nil
+}
+
+// GetAdminByID ID'ye göre operatörü getirir
+func (r *PlatformRepository) GetAdminByID(id uint) (*model.PlatformAdmin, error) {
+	var admin model.PlatformAdmin
+	if err := database.DB.First(&admin, id).Error; err != nil {
+		return nil, err
+	}
+	return &admin, nil
+}
+
+// CreateAdmin yeni operatör oluşturur
+func (r *PlatformRepository) CreateAdmin(admin *model.PlatformAdmin) error {
+	return database.DB.Create(admin).Error
+}
+
+// UpdateAdmin operatörü günceller
+func (r *PlatformRepository) UpdateAdmin(admin *model.PlatformAdmin) error {
+	return database.DB.Save(admin).Error
+}
+
+// ==================== DENETİM KAYDI ====================
+
+// CreateAuditLog denetim kaydı ekler
+func (r *PlatformRepository) CreateAuditLog(log *model.PlatformAuditLog) error {
+	return database.DB.Create(log).Error
+}
+
+// ListAuditLogs filtreli ve sayfalı denetim kayıtlarını yeniden eskiye getirir
+func (r *PlatformRepository) ListAuditLogs(req *model.PlatformAuditLogListRequest) ([]model.PlatformAuditLog, int64, error) {
+	query := database.DB.Model(&model.PlatformAuditLog{})
+	if req.AdminID != nil {
+		query = query.Where("admin_id = ?", *req.AdminID)
+	}
+	if req.Action != "" {
+		query = query.Where("action = ?", req.Action)
+	}
+	if req.TargetType != "" {
+		query = query.Where("target_type = ?", req.TargetType)
+	}
+	if req.TargetID != nil {
+		query = query.Where("target_id = ?", *req.TargetID)
+	}
+	if req.HospitalID != nil {
+		query = query.Where("hospital_id = ?", *req.HospitalID)
+	}
+	if req.From != nil {
+		query = query.Where("created_at >= ?", *req.From)
+	}
+	if req.To != nil {
+		query = query.Where("created_at <= ?", *req.To)
+	}
+
+	// Sayım ve sayfa sorgusu aynı filtrelerle ayrı ayrı çalışabilsin
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var logs []model.PlatformAuditLog
+	err := query.Order("created_at DESC, id DESC").
+		Offset((req.Page - 1) * req.PageSize).
+		Limit(req.PageSize).
+		Find(&logs).Error
+	return logs, total, err
+}
+
+// ==================== HASTANELER ====================
+
+// ListHospitals filtreli ve sayfalı hastane listesini kullanıcı ve personel sayılarıyla getirir
+func (r *PlatformRepository) ListHospitals(req *model.PlatformHospitalListRequest) ([]model.PlatformHospitalSummary, int64, error) {
+	query := database.DB.Table("hospitals h").
+		Joins("LEFT JOIN provinces p ON p.id = h.province_id").
+		Joins("LEFT JOIN districts d ON d.id = h.district_id").
+		Where("h.deleted_at IS NULL")
+
+	if q := strings.TrimSpace(req.Query); q != "" {
+		like := "%" + strings.ToLower(q) + "%"
+		query = query.Where("LOWER(h.name) LIKE ? OR h.tax_id LIKE ? OR LOWER(h.email) LIKE ? OR h.phone LIKE ?", like, like, like, like)
+	}
+	if req.Status != "" {
+		query = query.Where("h.status = ?", req.Status)
+	}
+	if req.ProvinceID != nil {
+		query = query.Where("h.province_id = ?", *req.ProvinceID)
+	}
+
+	// Sayım ve sayfa sorgusu aynı filtrelerle ayrı ayrı çalışabilsin
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// İnceleme kuyruğunda en eski başvuru önce gelir
+	order := "h.name ASC"
+	if req.Status == model.HospitalStatusPending {
+		order = "h.submitted_at ASC, h.id ASC"
+	}
+
+	var hospitals []model.PlatformHospitalSummary
+	err := query.Select(`h.id, h.name, h.tax_id, h.email, h.phone,
+			p.name AS province_name, d.name AS district_name,
+			h.status, h.status_reason, h.status_changed_at, h.submitted_at, h.created_at,
+			(SELECT COUNT(*) FROM users u WHERE u.hospital_id = h.id AND u.deleted_at IS NULL) AS user_count,
+			(SELECT COUNT(*) FROM staffs s WHERE s.hospital_id = h.id AND s.deleted_at IS NULL) AS staff_count`).
+		Order(order).
+		Offset((req.Page - 1) * req.PageSize).
+		Limit(req.PageSize).
+		Scan(&hospitals).Error
+	return hospitals, total, err
+}
+
+// GetHospitalDetail hastanenin platform detayını getirir
+func (r *PlatformRepository) GetHospitalDetail(hospitalID uint) (*model.PlatformHospitalDetail, error) {
+	var hospital model.Hospital
+	if err := database.DB.Preload("Province").Preload("District").First(&hospital, hospitalID).Error; err != nil {
+		return nil, err
+	}
+
+	detail := &model.PlatformHospitalDetail{
+		PlatformHospitalSummary: model.PlatformHospitalSummary{
+			ID:              hospital.ID,
+			Name:            hospital.Name,
+			TaxID:           hospital.TaxID,
+			Email:           hospital.Email,
+			Phone:           hospital.Phone,
+			ProvinceName:    hospital.Province.Name,
+			DistrictName:    hospital.District.Name,
+			Status:          hospital.Status,
+			StatusReason:    hospital.StatusReason,
+			StatusChangedAt: hospital.StatusChangedAt,
+			SubmittedAt:     hospital.SubmittedAt,
+			CreatedAt:       hospital.CreatedAt,
+		},
+		AddressDetail: hospital.AddressDetail,
+		RequireMFA:    hospital.RequireMFA,
+	}
+
+	counts := map[*int64]interface{}{
+		&detail.UserCount:       &model.User{},
+		&detail.StaffCount:      &model.Staff{},
+		&detail.MemberCount:     &model.HospitalMembership{},
+		&detail.PolyclinicCount: &model.HospitalPolyclinic{},
+	}
+	for dest, table := range counts {
+		if err := database.DB.Model(table).Where("hospital_id = ?", hospitalID).Count(dest).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	var ssoCount int64
+	if err := database.DB.Model(&model.HospitalSSOConfig{}).
+		Where("hospital_id = ? AND enabled = ?", hospitalID, true).
+		Count(&ssoCount).Error; err != nil {
+		return nil, err
+	}
+	detail.SSOEnabled = ssoCount > 0
+
+	return detail, nil
+}
+
+// UpdateHospitalStatus hastanenin hesap durumunu gerekçe ve operatör bilgisiyle günceller
+// Hastane bu arada from durumundan çıktıysa gorm.ErrRecordNotFound döner
+func (r *PlatformRepository) UpdateHospitalStatus(hospitalID uint, from, status, reason string, adminID uint) error {
+	now := time.Now()
+	result := database.DB.Model(&model.Hospital{}).Where("id = ? AND status = ?", hospitalID, from).Updates(map[string]interface{}{
+		"status":            status,
+		"status_reason":     reason,
+		"status_changed_at": &now,
+		"status_changed_by": adminID,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ==================== KULLANICILAR ====================
+
+// SearchUsers tüm hastanelerde e-posta, telefon, TC veya ad soyad ile kullanıcı arar
+func (r *PlatformRepository) SearchUsers(req *model.PlatformUserSearchRequest) ([]model.PlatformUserSummary, int64, error) {
+	like := "%" + strings.ToLower(strings.TrimSpace(req.Query)) + "%"
+	query := database.DB.Table("users u").
+		Joins("JOIN hospitals h ON h.id = u.hospital_id").
+		Where("u.deleted_at IS NULL").
+		Where(`LOWER(u.email) LIKE ? OR u.phone LIKE ? OR u.tckn LIKE ?
+			OR LOWER(u.first_name || ' ' || u.last_name) LIKE ?`, like, like, like, like)
+	if req.HospitalID != nil {
+		query = query.Where("u.hospital_id = ?", *req.HospitalID)
+	}
+
+	// Sayım ve sayfa sorgusu aynı filtrelerle ayrı ayrı çalışabilsin
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var users []model.PlatformUserSummary
+	err := query.Select(`u.id, u.hospital_id, h.name AS hospital_name, u.first_name, u.last_name,
+			u.email, u.phone, u.role, u.status, u.mfa_enabled, u.created_at`).
+		Order("u.first_name ASC, u.last_name ASC").
+		Offset((req.Page - 1) * req.PageSize).
+		Limit(req.PageSize).
+		Scan(&users).Error
+	return users, total, err
+}
@@ -50,6 +50,13 @@ func (r *PolyclinicRepository) GetHospitalPolyclinicByID(id uint) (*model.Hospit
 	return &hospitalPolyclinic, nil
 }
 
+// GetHospitalPolyclinicsWithType hastanenin tüm polikliniklerini tür adlarıyla getirir
+func (r *PolyclinicRepository) GetHospitalPolyclinicsWithType(hospitalID uint) ([]model.HospitalPolyclinic, error) {
+	var polyclinics []model.HospitalPolyclinic
+	result := database.DB.Preload("PolyclinicType").Where("hospital_id = ?", hospitalID).Find(&polyclinics)
+	return polyclinics, result.Error
+}
+
 // CheckHospitalPolyclinicExists hastanede aynı poliklinik türü var mı kontrol eder
 func (r *PolyclinicRepository) CheckHospitalPolyclinicExists(hospitalID, polyclinicTypeID uint) (bool, error) {
 	var count int64
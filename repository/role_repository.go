@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"hospital-platform/database"
+	"hospital-platform/model"
+
+	"gorm.io/gorm"
+)
+
+// RoleRepository rol ve yetki veritabanı işlemlerini yönetir
+type RoleRepository struct{}
+
+// NewRoleRepository yeni bir rol repository'si oluşturur
+func NewRoleRepository() *RoleRepository {
+	return &RoleRepository{}
+}
+
+// Create rolü yetkileriyle birlikte oluşturur
+func (r *RoleRepository) Create(role *model.Role) error {
+	return database.DB.Create(role).Error
+}
+
+// GetByID ID'ye göre rolü yetkileriyle birlikte getirir
+func (r *RoleRepository) GetByID(id uint) (*model.Role, error) {
+	var role model.Role
+	if err := database.DB.Preload("Permissions").First(&role, id).Error; err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+// GetByName hastanedeki rolü adına göre getirir
+func (r *RoleRepository) GetByName(hospitalID uint, name string) (*model.Role, error) {
+	var role model.Role
+	err := database.DB.Preload("Permissions").
+		Where("hospital_id = ? AND name = ?", hospitalID, name).
+		First(&role).Error
+	if err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+// GetByHospitalID hastanenin tüm rollerini getirir
+func (r *RoleRepository) GetByHospitalID(hospitalID uint) ([]model.Role, error) {
+	var roles []model.Role
+	err := database.DB.Preload("Permissions").
+		Where("hospital_id = ?", hospitalID).
+		Order("is_system DESC, name ASC").
+		Find(&roles).Error
+	return roles, err
+}
+
+// Update rol bilgilerini ve yetkilerini günceller
+// Rol adı değiştiyse bu role bağlı kullanıcıların, bekleyen davet/başvuruların ve kayıt yöntemlerinin rol adı da aynı transaction içinde güncellenir
+func (r *RoleRepository) Update(role *model.Role, oldName string) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Permissions").Save(role).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("role_id = ?", role.ID).Delete(&model.RolePermission{}).Error; err != nil {
+			return err
+		}
+		if len(role.Permissions) > 0 {
+			for i := range role.Permissions {
+				role.Permissions[i].RoleID = role.ID
+			}
+			if err := tx.Create(&role.Permissions).Error; err != nil {
+				return err
+			}
+		}
+
+		if oldName != role.Name {
+			if err := tx.Model(&model.User{}).
+				Where("hospital_id = ? AND role = ?", role.HospitalID, oldName).
+				Update("role", role.Name).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&model.HospitalMembership{}).
+				Where("hospital_id = ? AND role = ?", role.HospitalID, oldName).
+				Update("role", role.Name).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&model.Invitation{}).
+				Where("hospital_id = ? AND role = ? AND status = ?", role.HospitalID, oldName, model.InvitationPending).
+				Update("role", role.Name).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&model.SignupRequest{}).
+				Where("hospital_id = ? AND role = ? AND status = ?", role.HospitalID, oldName, model.SignupPending).
+				Update("role", role.Name).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&model.JoinCode{}).
+				Where("hospital_id = ? AND role = ?", role.HospitalID, oldName).
+				Update("role", role.Name).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&model.HospitalEmailDomain{}).
+				Where("hospital_id = ? AND role = ?", role.HospitalID, oldName).
+				Update("role", role.Name).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&model.SSORoleMapping{}).
+				Where("hospital_id = ? AND role = ?", role.HospitalID, oldName).
+				Update("role", role.Name).Error; err != nil {
+				return err
+			}
+			return tx.Model(&model.HospitalSSOConfig{}).
+				Where("hospital_id = ? AND default_role = ?", role.HospitalID, oldName).
+				Update("default_role", role.Name).Error
+		}
+		return nil
+	})
+}
+
+// Delete rolü ve yetkilerini kalıcı olarak siler (aynı isimle yeniden oluşturulabilsin diye)
+func (r *RoleRepository) Delete(role *model.Role) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("role_id = ?", role.ID).Delete(&model.RolePermission{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(role).Error
+	})
+}
+
+// CountUsers role sahip kullanıcı sayısını döndürür (diğer hastanelerden üyeler dahil)
+func (r *RoleRepository) CountUsers(hospitalID uint, name string) (int64, error) {
+	var users, members int64
+	if err := database.DB.Model(&model.User{}).
+		Where("hospital_id = ? AND role = ?", hospitalID, name).
+		Count(&users).Error; err != nil {
+		return 0, err
+	}
+	err := database.DB.Model(&model.HospitalMembership{}).
+		Where("hospital_id = ? AND role = ?", hospitalID, name).
+		Count(&members).Error
+	return users + members, err
+}
+
+// GetUserIDsByRole role sahip kullanıcıların ID'lerini döndürür (token geçersiz kılma için, üyeler dahil)
+func (r *RoleRepository) GetUserIDsByRole(hospitalID uint, name string) ([]uint, error) {
+	var ids, memberIDs []uint
+	if err := database.DB.Model(&model.User{}).
+		Where("hospital_id = ? AND role = ?", hospitalID, name).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	err := database.DB.Model(&model.HospitalMembership{}).
+		Where("hospital_id = ? AND role = ?", hospitalID, name).
+		Pluck("user_id", &memberIDs).Error
+	return append(ids, memberIDs...), err
+}
@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"hospital-platform/database"
+	"hospital-platform/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ScheduleRepository vardiya şablonu ve personel çalışma programı veritabanı işlemlerini yönetir
+// Tarih karşılaştırmaları "YYYY-AA-GG" metniyle yapılır, böylece date kolonları oturum saat diliminden etkilenmez
+type ScheduleRepository struct{}
+
+// NewScheduleRepository yeni bir program repository'si oluşturur
+func NewScheduleRepository() *ScheduleRepository {
+	return &ScheduleRepository{}
+}
+
+// ==================== VARDİYA ŞABLONLARI ====================
+
+// CreateTemplate vardiya şablonu ekler
+func (r *ScheduleRepository) CreateTemplate(template *model.ShiftTemplate) error {
+	return database.DB.Create(template).Error
+}
+
+// GetTemplateByID ID'ye göre vardiya şablonunu getirir
+func (r *ScheduleRepository) GetTemplateByID(id uint) (*model.ShiftTemplate, error) {
+	var template model.ShiftTemplate
+	if err := database.DB.First(&template, id).Error; err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// GetTemplateByName hastanedeki vardiya şablonunu adına göre getirir
+func (r *ScheduleRepository) GetTemplateByName(hospitalID uint, name string) (*model.ShiftTemplate, error) {
+	var template model.ShiftTemplate
+	if err := database.DB.Where("hospital_id = ? AND name = ?", hospitalID, name).First(&template).Error; err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// GetTemplatesByHospital hastanenin vardiya şablonlarını başlangıç saatine göre getirir
+func (r *ScheduleRepository) GetTemplatesByHospital(hospitalID uint) ([]model.ShiftTemplate, error) {
+	var templates []model.ShiftTemplate
+	err := database.DB.Where("hospital_id = ?", hospitalID).Order("start_time ASC, name ASC").Find(&templates).Error
+	return templates, err
+}
+
+// getOrCreateDefaultTemplate hastanenin varsayılan vardiyasını verilen transaction içinde getirir, yoksa oluşturur
+func getOrCreateDefaultTemplate(tx *gorm.DB, hospitalID uint) (*model.ShiftTemplate, error) {
+	template := model.ShiftTemplate{HospitalID: hospitalID, Name: model.DefaultShiftTemplateName}
+	err := tx.Where(template).
+		Attrs(model.ShiftTemplate{StartTime: model.DefaultShiftTemplateStart, EndTime: model.DefaultShiftTemplateEnd}).
+		FirstOrCreate(&template).Error
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// UpdateTemplate vardiya şablonunu günceller
+func (r *ScheduleRepository) UpdateTemplate(template *model.ShiftTemplate) error {
+	return database.DB.Save(template).Error
+}
+
+// DeleteTemplate vardiya şablonunu siler
+func (r *ScheduleRepository) DeleteTemplate(id uint) error {
+	return database.DB.Delete(&model.ShiftTemplate{}, id).Error
+}
+
+// IsTemplateInUse şablonun silinmemiş bir programda veya nöbet listesinde kullanılıp kullanılmadığını kontrol eder
+func (r *ScheduleRepository) IsTemplateInUse(id uint) (bool, error) {
+	var count int64
+	err := database.DB.Model(&model.StaffScheduleEntry{}).
+		Joins("JOIN staff_schedules ss ON ss.id = staff_schedule_entries.schedule_id AND ss.deleted_at IS NULL").
+		Where("staff_schedule_entries.shift_template_id = ?", id).
+		Count(&count).Error
+	if err != nil || count > 0 {
+		return count > 0, err
+	}
+
+	err = database.DB.Model(&model.DutyRosterSlot{}).
+		Joins("JOIN duty_rosters dr ON dr.id = duty_roster_slots.roster_id AND dr.deleted_at IS NULL").
+		Where("duty_roster_slots.shift_template_id = ?", id).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// ==================== PERSONEL PROGRAMLARI ====================
+
+// CreateSchedule programı günleriyle birlikte ekler
+func (r *ScheduleRepository) CreateSchedule(schedule *model.StaffSchedule) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		return createSchedules(tx, []*model.StaffSchedule{schedule})
+	})
+}
+
+// createSchedules programları ve günlerini verilen transaction içinde toplu ekler
+// Günlere yüklenmiş vardiya şablonları yeniden kaydedilmez
+func createSchedules(tx *gorm.DB, schedules []*model.StaffSchedule) error {
+	if len(schedules) == 0 {
+		return nil
+	}
+
+	// Vardiyası seçilmemiş günler varsayılan vardiyaya yazılır; vardiya yoksa aynı transaction içinde oluşturulur,
+	// kayıt başarısız olursa varsayılan vardiya da geri alınır
+	defaults := make(map[uint]*model.ShiftTemplate)
+	for _, schedule := range schedules {
+		for i := range schedule.Entries {
+			if schedule.Entries[i].ShiftTemplateID != 0 {
+				continue
+			}
+			if defaults[schedule.HospitalID] == nil {
+				template, err := getOrCreateDefaultTemplate(tx, schedule.HospitalID)
+				if err != nil {
+					return err
+				}
+				defaults[schedule.HospitalID] = template
+			}
+			schedule.Entries[i].ShiftTemplateID = defaults[schedule.HospitalID].ID
+			schedule.Entries[i].ShiftTemplate = *defaults[schedule.HospitalID]
+		}
+	}
+
+	if err := tx.Omit(clause.Associations).CreateInBatches(schedules, 100).Error; err != nil {
+		return err
+	}
+
+	var entries []*model.StaffScheduleEntry
+	for _, schedule := range schedules {
+		for i := range schedule.Entries {
+			schedule.Entries[i].ScheduleID = schedule.ID
+			entries = append(entries, &schedule.Entries[i])
+		}
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	return tx.Omit(clause.Associations).CreateInBatches(entries, 100).Error
+}
+
+// UpdateSchedule programı ve günlerini tek transaction ile değiştirir
+func (r *ScheduleRepository) UpdateSchedule(schedule *model.StaffSchedule) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Entries").Save(schedule).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("schedule_id = ?", schedule.ID).Delete(&model.StaffScheduleEntry{}).Error; err != nil {
+			return err
+		}
+		for i := range schedule.Entries {
+			schedule.Entries[i].ID = 0
+			schedule.Entries[i].ScheduleID = schedule.ID
+		}
+		if len(schedule.Entries) == 0 {
+			return nil
+		}
+		return tx.Omit(clause.Associations).Create(&schedule.Entries).Error
+	})
+}
+
+// DeleteSchedule programı ve günlerini siler
+func (r *ScheduleRepository) DeleteSchedule(schedule *model.StaffSchedule) error {
+	return database.DB.Select(clause.Associations).Delete(schedule).Error
+}
+
+// GetScheduleByID programı günleri ve vardiyalarıyla getirir
+func (r *ScheduleRepository) GetScheduleByID(id uint) (*model.StaffSchedule, error) {
+	var schedule model.StaffSchedule
+	if err := r.withEntries(database.DB).First(&schedule, id).Error; err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// GetSchedulesByStaff personelin tüm programlarını başlangıç tarihine göre getirir
+func (r *ScheduleRepository) GetSchedulesByStaff(staffID uint) ([]model.StaffSchedule, error) {
+	var schedules []model.StaffSchedule
+	err := r.withEntries(database.DB).
+		Where("staff_id = ?", staffID).
+		Order("effective_from ASC").
+		Find(&schedules).Error
+	return schedules, err
+}
+
+// GetSchedulesByStaffIDs verilen personellerin [from, to] aralığına denk gelen programlarını getirir
+func (r *ScheduleRepository) GetSchedulesByStaffIDs(staffIDs []uint, from, to time.Time) ([]model.StaffSchedule, error) {
+	var schedules []model.StaffSchedule
+	if len(staffIDs) == 0 {
+		return schedules, nil
+	}
+	err := r.withEntries(database.DB).
+		Where("staff_id IN ?", staffIDs).
+		Where("effective_from <= ? AND (effective_to IS NULL OR effective_to >= ?)", formatDate(to), formatDate(from)).
+		Order("staff_id ASC, effective_from ASC").
+		Find(&schedules).Error
+	return schedules, err
+}
+
+// GetSchedulesUsingTemplate şablonu kullanan ve verilen günden sonra hâlâ geçerli olan programları getirir
+func (r *ScheduleRepository) GetSchedulesUsingTemplate(templateID uint, from time.Time) ([]model.StaffSchedule, error) {
+	var schedules []model.StaffSchedule
+	err := r.withEntries(database.DB).
+		Where("id IN (?)", database.DB.Model(&model.StaffScheduleEntry{}).Select("schedule_id").Where("shift_template_id = ?", templateID)).
+		Where("effective_to IS NULL OR effective_to >= ?", formatDate(from)).
+		Find(&schedules).Error
+	return schedules, err
+}
+
+// withEntries program günlerini vardiya bilgileriyle yükler
+func (r *ScheduleRepository) withEntries(db *gorm.DB) *gorm.DB {
+	return db.Preload("Entries", func(db *gorm.DB) *gorm.DB {
+		return db.Order("day ASC, id ASC")
+	}).Preload("Entries.ShiftTemplate", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	})
+}
+
+// formatDate tarihi date kolonlarıyla karşılaştırılacak metne çevirir
+func formatDate(t time.Time) string {
+	return t.Format("2006-01-02")
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"errors"
+	"hospital-platform/database"
+	"hospital-platform/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrJoinCodeUnavailable katılım kodu başvuru sırasında iptal edilmiş, süresi dolmuş veya kullanım hakkı bitmişse döner
+	ErrJoinCodeUnavailable = errors.New("katılım kodu artık geçerli değil")
+	// ErrSignupAlreadyReviewed başvuru başka bir yetkili tarafından zaten incelenmişse döner
+	ErrSignupAlreadyReviewed = errors.New("başvuru zaten incelenmiş")
+)
+
+// SignupRepository kayıt başvurularının veritabanı işlemlerini yönetir
+type SignupRepository struct{}
+
+// NewSignupRepository yeni bir kayıt başvurusu repository'si oluşturur
+func NewSignupRepository() *SignupRepository {
+	return &SignupRepository{}
+}
+
+// Create başvuruyu kaydeder; katılım koduyla yapıldıysa kodun kullanım sayısı aynı transaction içinde artırılır
+// Koşullu güncelleme sayesinde kullanım limiti eşzamanlı başvurularda da aşılmaz
+func (r *SignupRepository) Create(request *model.SignupRequest) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if request.JoinCodeID != nil {
+			result := tx.Model(&model.JoinCode{}).
+				Where("id = ? AND revoked_at IS NULL AND expires_at > ?", *request.JoinCodeID, time.Now()).
+				Where("max_uses = 0 OR use_count < max_uses").
+				Update("use_count", gorm.Expr("use_count + 1"))
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected != 1 {
+				return ErrJoinCodeUnavailable
+			}
+		}
+
+		return tx.Create(request).Error
+	})
+}
+
+// GetByID ID'ye göre başvuruyu getirir
+func (r *SignupRepository) GetByID(id uint) (*model.SignupRequest, error) {
+	var request model.SignupRequest
+	if err := database.DB.First(&request, id).Error; err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// GetByHospitalID hastanenin başvurularını listeler (status boşsa hepsi)
+func (r *SignupRepository) GetByHospitalID(hospitalID uint, status string) ([]model.SignupRequest, error) {
+	query := database.DB.Where("hospital_id = ?", hospitalID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var requests []model.SignupRequest
+	err := query.Order("created_at DESC").Find(&requests).Error
+	return requests, err
+}
+
+// GetPendingByIdentity TC, e-posta veya telefonu eşleşen bekleyen başvuruları getirir
+func (r *SignupRepository) GetPendingByIdentity(tckn, email, phone string) ([]model.SignupRequest, error) {
+	var requests []model.SignupRequest
+	err := database.DB.
+		Where("status = ?", model.SignupPending).
+		Where("tckn = ? OR email = ? OR phone = ?", tckn, email, phone).
+		Find(&requests).Error
+	return requests, err
+}
+
+// Approve başvuruyu onaylar ve kullanıcıyı aynı transaction içinde oluşturur
+func (r *SignupRepository) Approve(request *model.SignupRequest, user *model.User, reviewedBy uint) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		if err := markReviewed(tx, request.ID, map[string]interface{}{
+			"status":      model.SignupApproved,
+			"reviewed_by": reviewedBy,
+			"reviewed_at": now,
+		}); err != nil {
+			return err
+		}
+
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+
+		request.Status = model.SignupApproved
+		request.ReviewedBy = &reviewedBy
+		request.ReviewedAt = &now
+		request.UserID = &user.ID
+		return tx.Model(&model.SignupRequest{}).
+			Where("id = ?", request.ID).
+			Update("user_id", user.ID).Error
+	})
+}
+
+// Reject başvuruyu gerekçesiyle reddeder
+func (r *SignupRepository) Reject(request *model.SignupRequest, reviewedBy uint, reason string) error {
+	now := time.Now()
+
+	if err := markReviewed(database.DB, request.ID, map[string]interface{}{
+		"status":        model.SignupRejected,
+		"reviewed_by":   reviewedBy,
+		"reviewed_at":   now,
+		"reject_reason": reason,
+	}); err != nil {
+		return err
+	}
+
+	request.Status = model.SignupRejected
+	request.ReviewedBy = &reviewedBy
+	request.ReviewedAt = &now
+	request.RejectReason = reason
+	return nil
+}
+
+// markReviewed bekleyen başvurunun durumunu koşullu olarak günceller - aynı başvuru iki kez incelenemez
+func markReviewed(tx *gorm.DB, id uint, updates map[string]interface{}) error {
+	result := tx.Model(&model.SignupRequest{}).
+		Where("id = ? AND status = ?", id, model.SignupPending).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected != 1 {
+		return ErrSignupAlreadyReviewed
+	}
+	return nil
+}
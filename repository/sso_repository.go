@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"hospital-platform/database"
+	"hospital-platform/model"
+
+	"gorm.io/gorm"
+)
+
+// SSORepository hastane SSO ayarlarının veritabanı işlemlerini yönetir
+type SSORepository struct{}
+
+// NewSSORepository yeni bir SSO repository'si oluşturur
+func NewSSORepository() *SSORepository {
+	return &SSORepository{}
+}
+
+// GetByHospitalID hastanenin SSO ayarlarını rol eşlemeleriyle (öncelik sırasıyla) getirir
+func (r *SSORepository) GetByHospitalID(hospitalID uint) (*model.HospitalSSOConfig, error) {
+	var config model.HospitalSSOConfig
+	err := database.DB.
+		Preload("RoleMappings", func(db *gorm.DB) *gorm.DB { return db.Order("priority ASC") }).
+		Where("hospital_id = ?", hospitalID).
+		First(&config).Error
+	if err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// Save ayarları kaydeder ve rol eşlemelerini verilen listeyle değiştirir
+func (r *SSORepository) Save(config *model.HospitalSSOConfig) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("RoleMappings").Save(config).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("config_id = ?", config.ID).Delete(&model.SSORoleMapping{}).Error; err != nil {
+			return err
+		}
+		if len(config.RoleMappings) > 0 {
+			for i := range config.RoleMappings {
+				config.RoleMappings[i].ID = 0
+				config.RoleMappings[i].ConfigID = config.ID
+				config.RoleMappings[i].HospitalID = config.HospitalID
+				config.RoleMappings[i].Priority = i
+			}
+			if err := tx.Create(&config.RoleMappings).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Delete ayarları ve rol eşlemelerini kalıcı olarak siler (hastane daha sonra yeniden ayarlayabilsin)
+func (r *SSORepository) Delete(config *model.HospitalSSOConfig) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("config_id = ?", config.ID).Delete(&model.SSORoleMapping{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(config).Error
+	})
+}
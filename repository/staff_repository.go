@@ -1,53 +1,81 @@
 package repository
 
 import (
-	"encoding/json"
 	"fmt"
 	"hospital-platform/database"
 	"hospital-platform/model"
 	"strings"
+	"time"
+
+	"gorm.io/gorm"
 )
 
 // StaffRepository - Personel verilerine erişim katmanı
 // Veritabanı ile personel CRUD işlemlerini gerçekleştiren ana sınıf
-type StaffRepository struct{}
+type StaffRepository struct {
+	scheduleRepo *ScheduleRepository // Liste metinleri için çalışma programları
+	leaveRepo    *LeaveRepository    // Listede bugün izinli personeli göstermek için
+}
 
 // NewStaffRepository - Yeni bir personel repository nesnesi oluşturur
 // Her çağrıldığında temiz bir instance döner
 func NewStaffRepository() *StaffRepository {
-	return &StaffRepository{}
+	return &StaffRepository{
+		scheduleRepo: NewScheduleRepository(),
+		leaveRepo:    NewLeaveRepository(),
+	}
 }
 
 // ==================== TEMEL VERİTABANI İŞLEMLERİ ====================
 
 // Create - Yeni bir personel kaydını veritabanına ekler
-// Çalışma günlerini JSON formatında saklar ve gerekli validasyonları yapar
+// Personelle birlikte gelen çalışma programları aynı transaction içinde kaydedilir
 func (r *StaffRepository) Create(staff *model.Staff) error {
-	// Çalışma günleri dizisini JSON string formatına çevir (örn: [1,2,3,4,5])
-	workDaysJSON, err := json.Marshal(staff.WorkDays)
-	if err != nil {
-		return fmt.Errorf("çalışma günleri JSON formatına çevrilemedi: %v", err)
+	staffs := []model.Staff{*staff}
+	if err := r.CreateBatch(staffs); err != nil {
+		return err
 	}
-	staff.WorkDays = string(workDaysJSON)
+	*staff = staffs[0]
+	return nil
+}
 
-	result := database.DB.Create(staff)
-	return result.Error
+// CreateBatch birden fazla personeli çalışma programlarıyla tek transaction ile ekler (toplu içe aktarma)
+// Bir kayıt bile eklenemezse hiçbiri eklenmez; ID'ler verilen dilimdeki kayıtlara yazılır
+func (r *StaffRepository) CreateBatch(staffs []model.Staff) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Schedules").CreateInBatches(&staffs, 100).Error; err != nil {
+			return err
+		}
+
+		var schedules []*model.StaffSchedule
+		for i := range staffs {
+			for j := range staffs[i].Schedules {
+				staffs[i].Schedules[j].StaffID = staffs[i].ID
+				schedules = append(schedules, &staffs[i].Schedules[j])
+			}
+		}
+		return createSchedules(tx, schedules)
+	})
 }
 
 // GetByID - Verilen ID'ye sahip personeli tüm ilişkili verilerle beraber getirir
 // Hastane, poliklinik, meslek grubu ve unvan bilgilerini de yükler
 func (r *StaffRepository) GetByID(id uint) (*model.Staff, error) {
 	var staff model.Staff
-	result := database.DB.Preload("Hospital").Preload("Polyclinic").Preload("JobGroup").Preload("JobTitle").First(&staff, id)
+	result := database.DB.Preload("Hospital").Preload("Polyclinic").Preload("JobGroup").Preload("JobTitle").
+		Preload("Schedules", func(db *gorm.DB) *gorm.DB {
+			return r.scheduleRepo.withEntries(db).Order("effective_from ASC")
+		}).
+		First(&staff, id)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 	return &staff, nil
 }
 
-// Update personel bilgilerini günceller
+// Update personel bilgilerini günceller (çalışma programları ayrı yönetilir)
 func (r *StaffRepository) Update(staff *model.Staff) error {
-	result := database.DB.Save(staff)
+	result := database.DB.Omit("Schedules").Save(staff)
 	return result.Error
 }
 
@@ -57,6 +85,21 @@ func (r *StaffRepository) Delete(id uint) error {
 	return result.Error
 }
 
+// GetActiveByGroup hastanenin aktif personelini poliklinik ve/veya meslek grubuna göre ID sırasıyla getirir (nöbet adayları)
+func (r *StaffRepository) GetActiveByGroup(hospitalID uint, polyclinicID, jobGroupID *uint) ([]model.Staff, error) {
+	query := database.DB.Where("hospital_id = ? AND is_active = ?", hospitalID, true)
+	if polyclinicID != nil {
+		query = query.Where("polyclinic_id = ?", *polyclinicID)
+	}
+	if jobGroupID != nil {
+		query = query.Where("job_group_id = ?", *jobGroupID)
+	}
+
+	var staffs []model.Staff
+	err := query.Order("id ASC").Find(&staffs).Error
+	return staffs, err
+}
+
 // ==================== VERİFİCATİON METHODS ====================
 
 // CheckTCKNExists TC kimlik numarası var mı kontrol eder
@@ -142,9 +185,9 @@ func (r *StaffRepository) GetPaginatedStaff(hospitalID uint, req *model.StaffLis
 		return nil, fmt.Errorf("personel listesi getirilemedi: %v", err)
 	}
 
-	// WorkDays metinlerini düzenle
-	for i := range staffList {
-		staffList[i].WorkDaysText = r.formatWorkDays(staffList[i].WorkDaysText)
+	// Çalışma programı metinlerini ve bugünkü izinleri ekle
+	if err := r.fillAvailability(staffList); err != nil {
+		return nil, err
 	}
 
 	// Pagination bilgileri
@@ -163,6 +206,52 @@ func (r *StaffRepository) GetPaginatedStaff(hospitalID uint, req *model.StaffLis
 	}, nil
 }
 
+// StreamStaff filtreye uyan tüm personeli sayfalama olmadan, listeyle aynı sırada tek tek fn'e iletir
+// Satırlar veritabanı imlecinden okunur; çalışma programı metinleri için en fazla streamChunkSize satır bellekte tutulur
+func (r *StaffRepository) StreamStaff(hospitalID uint, req *model.StaffListRequest, fn func(model.StaffSummary) error) error {
+	query := r.buildStaffQuery(hospitalID, req) + " ORDER BY s.first_name ASC, s.last_name ASC"
+
+	rows, err := database.DB.Raw(query, r.buildQueryParams(hospitalID, req)...).Rows()
+	if err != nil {
+		return fmt.Errorf("personel listesi getirilemedi: %v", err)
+	}
+	defer rows.Close()
+
+	chunk := make([]model.StaffSummary, 0, streamChunkSize)
+	flush := func() error {
+		if err := r.fillAvailability(chunk); err != nil {
+			return err
+		}
+		for _, staff := range chunk {
+			if err := fn(staff); err != nil {
+				return err
+			}
+		}
+		chunk = chunk[:0]
+		return nil
+	}
+
+	for rows.Next() {
+		var staff model.StaffSummary
+		if err := database.DB.ScanRows(rows, &staff); err != nil {
+			return fmt.Errorf("personel satırı okunamadı: %v", err)
+		}
+		chunk = append(chunk, staff)
+		if len(chunk) == streamChunkSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	return flush()
+}
+
+// streamChunkSize dışa aktarmada çalışma programlarının tek sorguda yüklendiği satır sayısı
+const streamChunkSize = 200
+
 // buildStaffQuery personel sorgusu oluşturur
 func (r *StaffRepository) buildStaffQuery(hospitalID uint, req *model.StaffListRequest) string {
 	query := `
@@ -175,7 +264,6 @@ func (r *StaffRepository) buildStaffQuery(hospitalID uint, req *model.StaffListR
 			jg.name as job_group_name,
 			jt.name as job_title_name,
 			pt.name as polyclinic_type_name,
-			s.work_days as work_days_text,
 			s.is_active
 		FROM staffs s
 		LEFT JOIN job_groups jg ON s.job_group_id = jg.id
@@ -240,11 +328,74 @@ func (r *StaffRepository) buildQueryParams(hospitalID uint, req *model.StaffList
 	return params
 }
 
-// formatWorkDays çalışma günlerini okunabilir metne çevirir
-func (r *StaffRepository) formatWorkDays(workDaysJSON string) string {
-	var days []int
-	if err := json.Unmarshal([]byte(workDaysJSON), &days); err != nil {
-		return "Bilinmiyor"
+// fillAvailability personellerin bugün geçerli çalışma programlarını ve onaylı izinlerini birer sorguda yükler
+// Program metne çevrilir, izinli personelde izin türü doldurulur
+func (r *StaffRepository) fillAvailability(staffList []model.StaffSummary) error {
+	if len(staffList) == 0 {
+		return nil
+	}
+	ids := make([]uint, len(staffList))
+	for i, staff := range staffList {
+		ids[i] = staff.ID
+	}
+
+	today := model.DateOf(time.Now())
+	schedules, err := r.scheduleRepo.GetSchedulesByStaffIDs(ids, today, today)
+	if err != nil {
+		return fmt.Errorf("çalışma programları getirilemedi: %v", err)
+	}
+	current := make(map[uint]*model.StaffSchedule, len(schedules))
+	for i := range schedules {
+		current[schedules[i].StaffID] = &schedules[i]
+	}
+
+	leaves, err := r.leaveRepo.GetApprovedByStaffIDs(ids, today, today)
+	if err != nil {
+		return fmt.Errorf("izinler getirilemedi: %v", err)
+	}
+	onLeave := make(map[uint]string, len(leaves))
+	for _, leave := range leaves {
+		onLeave[leave.StaffID] = leave.LeaveType.Name
+	}
+
+	for i := range staffList {
+		staffList[i].WorkDaysText = r.formatSchedule(current[staffList[i].ID])
+		if leaveType, ok := onLeave[staffList[i].ID]; ok {
+			staffList[i].LeaveTypeName = &leaveType
+		}
+	}
+	return nil
+}
+
+// formatSchedule çalışma programını okunabilir metne çevirir
+// Haftalık: "Pazartesi-Cuma 08:00-17:00, Cumartesi 08:00-13:00"
+// Dönüşümlü: "4 günlük döngü: 08:00-20:00, 20:00-08:00, izin, izin"
+func (r *StaffRepository) formatSchedule(schedule *model.StaffSchedule) string {
+	if schedule == nil {
+		return "Çalışma programı yok"
+	}
+
+	// Döngü günü → o gün çalışılan vardiya saatleri
+	shifts := make([]string, schedule.CycleDays+1)
+	for _, entry := range schedule.Entries {
+		if entry.Day < 1 || entry.Day > schedule.CycleDays {
+			continue
+		}
+		if shifts[entry.Day] != "" {
+			shifts[entry.Day] += " + "
+		}
+		shifts[entry.Day] += entry.ShiftTemplate.Label()
+	}
+
+	if schedule.Pattern == model.SchedulePatternRotating {
+		days := make([]string, schedule.CycleDays)
+		for day := 1; day <= schedule.CycleDays; day++ {
+			days[day-1] = shifts[day]
+			if days[day-1] == "" {
+				days[day-1] = "izin"
+			}
+		}
+		return fmt.Sprintf("%d günlük döngü: %s", schedule.CycleDays, strings.Join(days, ", "))
 	}
 
 	dayNames := map[int]string{
@@ -252,18 +403,28 @@ func (r *StaffRepository) formatWorkDays(workDaysJSON string) string {
 		5: "Cuma", 6: "Cumartesi", 7: "Pazar",
 	}
 
-	var dayTexts []string
-	for _, day := range days {
-		if name, exists := dayNames[day]; exists {
-			dayTexts = append(dayTexts, name)
+	// Aynı saatlerde çalışılan ardışık günler aralık olarak yazılır
+	var parts []string
+	for day := 1; day <= 7; day++ {
+		if shifts[day] == "" {
+			continue
 		}
+		last := day
+		for last < 7 && shifts[last+1] == shifts[day] {
+			last++
+		}
+		if last == day {
+			parts = append(parts, dayNames[day]+" "+shifts[day])
+		} else {
+			parts = append(parts, dayNames[day]+"-"+dayNames[last]+" "+shifts[day])
+		}
+		day = last
 	}
 
-	if len(dayTexts) == 0 {
+	if len(parts) == 0 {
 		return "Çalışma günü yok"
 	}
-
-	return strings.Join(dayTexts, ", ")
+	return strings.Join(parts, ", ")
 }
 
 // ==================== MASTER DATA HELPERS ====================
@@ -39,6 +39,16 @@ func (r *UserRepository) GetByEmail(email string) (*model.User, error) {
 	return &user, nil
 }
 
+// GetByEmailFold e-posta adresine büyük/küçük harf duyarsız göre kullanıcıyı getirir (harici kimlik sağlayıcı eşleştirmesi için)
+func (r *UserRepository) GetByEmailFold(email string) (*model.User, error) {
+	var user model.User
+	result := database.DB.Where("LOWER(email) = LOWER(?)", email).First(&user)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &user, nil
+}
+
 // GetByPhone telefon numarasına göre kullanıcı olup olmadığını kontrol eder
 func (r *UserRepository) GetByPhone(phone string) (*model.User, error) {
 	var user model.User
@@ -65,6 +75,11 @@ func (r *UserRepository) Update(user *model.User) error {
 	return result.Error
 }
 
+// UpdateFields kullanıcının sadece verilen kolonlarını günceller (ilişkiler kaydedilmez)
+func (r *UserRepository) UpdateFields(id uint, fields map[string]interface{}) error {
+	return database.DB.Model(&model.User{}).Where("id = ?", id).Updates(fields).Error
+}
+
 // Delete kullanıcıyı soft delete yapar
 func (r *UserRepository) Delete(id uint) error {
 	result := database.DB.Delete(&model.User{}, id)
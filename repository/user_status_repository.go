@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"hospital-platform/database"
+	"hospital-platform/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// UserStatusRepository kullanıcı hesap durumu ve durum geçmişi veritabanı işlemlerini yönetir
+type UserStatusRepository struct{}
+
+// NewUserStatusRepository yeni bir kullanıcı durumu repository'si oluşturur
+func NewUserStatusRepository() *UserStatusRepository {
+	return &UserStatusRepository{}
+}
+
+// ChangeStatus kullanıcının durumunu günceller ve denetim kaydını aynı transaction'da yazar
+// Kullanıcının durumu bu arada değiştiyse (from ile eşleşmiyorsa) gorm.ErrRecordNotFound döner
+func (r *UserStatusRepository) ChangeStatus(change *model.UserStatusChange, changedBy *uint) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&model.User{}).
+			Where("id = ? AND status = ?", change.UserID, change.FromStatus).
+			Updates(map[string]interface{}{
+				"status":            change.ToStatus,
+				"status_reason":     change.Reason,
+				"status_changed_at": &now,
+				"status_changed_by": changedBy,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		change.CreatedAt = now
+		return tx.Create(change).Error
+	})
+}
+
+// ListChanges kullanıcının durum geçmişini yeniden eskiye getirir
+func (r *UserStatusRepository) ListChanges(userID uint) ([]model.UserStatusChange, error) {
+	var changes []model.UserStatusChange
+	err := database.DB.Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&changes).Error
+	return changes, err
+}
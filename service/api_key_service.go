@@ -0,0 +1,238 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"hospital-platform/model"
+	"hospital-platform/repository"
+	"hospital-platform/utils"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	apiKeyMaxTTLDays     = 730 // En uzun geçerlilik (2 yıl)
+	apiKeyNameMaxLength  = 100
+	apiKeyMaxAllowedIPs  = 20
+	apiKeyNameMinLength  = 2
+	apiKeyMaxActiveCount = 50 // Hastane başına aynı anda aktif anahtar sayısı
+)
+
+var ErrAPIKeyNotFound = errors.New("API anahtarı bulunamadı")
+
+// APIKeyService - Makineler arası entegrasyonlar için hastane API anahtarları iş mantığı
+// Anahtar sadece oluşturulurken bir kez gösterilir, veritabanında hash'i saklanır
+type APIKeyService struct {
+	apiKeyRepo *repository.APIKeyRepository
+}
+
+// NewAPIKeyService yeni bir API anahtarı servisi oluşturur
+func NewAPIKeyService() *APIKeyService {
+	return &APIKeyService{
+		apiKeyRepo: repository.NewAPIKeyRepository(),
+	}
+}
+
+// GetScopes anahtarlara verilebilecek yetkileri açıklamalarıyla döndürür
+func (s *APIKeyService) GetScopes() []model.PermissionInfo {
+	scopes := make([]model.PermissionInfo, 0, len(model.APIKeyScopes))
+	for _, permission := range model.Permissions {
+		if model.IsAPIKeyScope(permission.Name) {
+			scopes = append(scopes, permission)
+		}
+	}
+	return scopes
+}
+
+// CreateAPIKey yeni API anahtarı üretir; düz anahtar sadece bu yanıtta döner
+func (s *APIKeyService) CreateAPIKey(req *model.CreateAPIKeyRequest, hospitalID, actorID uint) (*model.CreateAPIKeyResponse, []model.ValidationError, error) {
+	actor, err := NewMembershipService().GetUserInHospital(actorID, hospitalID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("kullanıcı bulunamadı: %v", err)
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	validationErrors, allowedIPs := s.validateCreate(actor.HospitalID, req)
+	if len(validationErrors) > 0 {
+		return nil, validationErrors, nil
+	}
+
+	// Anahtar, oluşturan yetkilinin sahip olmadığı bir yetkiyi taşıyamaz
+	if err := NewRoleService().checkEscalation(actor.HospitalID, actor.Role, req.Scopes); err != nil {
+		return nil, nil, err
+	}
+
+	raw, err := utils.GenerateAPIKey()
+	if err != nil {
+		return nil, nil, fmt.Errorf("API anahtarı üretilemedi: %v", err)
+	}
+
+	apiKey := &model.APIKey{
+		HospitalID: actor.HospitalID,
+		Name:       req.Name,
+		KeyHash:    utils.HashToken(raw),
+		KeyHint:    utils.APIKeyHint(raw),
+		AllowedIPs: strings.Join(allowedIPs, ","),
+		ExpiresAt:  time.Now().AddDate(0, 0, req.ExpiresInDays),
+		CreatedBy:  actor.ID,
+	}
+	for _, scope := range uniqueStrings(req.Scopes) {
+		apiKey.Scopes = append(apiKey.Scopes, model.APIKeyScope{Scope: scope})
+	}
+
+	if err := s.apiKeyRepo.Create(apiKey); err != nil {
+		return nil, nil, fmt.Errorf("API anahtarı kaydedilemedi: %v", err)
+	}
+
+	fmt.Printf("🔑 API anahtarı oluşturuldu: hastane=%d anahtar=%d (%s) yetkili=%d\n", apiKey.HospitalID, apiKey.ID, apiKey.Name, actor.ID)
+
+	return &model.CreateAPIKeyResponse{
+		APIKeyResponse: toAPIKeyResponse(apiKey),
+		Key:            raw,
+	}, nil, nil
+}
+
+// ListAPIKeys hastanenin API anahtarlarını listeler (anahtarların kendisi gösterilmez)
+func (s *APIKeyService) ListAPIKeys(hospitalID uint) ([]model.APIKeyResponse, error) {
+	apiKeys, err := s.apiKeyRepo.GetByHospitalID(hospitalID)
+	if err != nil {
+		return nil, fmt.Errorf("API anahtarları getirilemedi: %v", err)
+	}
+
+	responses := make([]model.APIKeyResponse, 0, len(apiKeys))
+	for i := range apiKeys {
+		responses = append(responses, toAPIKeyResponse(&apiKeys[i]))
+	}
+	return responses, nil
+}
+
+// RevokeAPIKey API anahtarını iptal eder - anahtarla yapılan sonraki istekler reddedilir
+func (s *APIKeyService) RevokeAPIKey(hospitalID, apiKeyID, actorID uint) error {
+	apiKey, err := s.apiKeyRepo.GetByID(apiKeyID)
+	if err != nil || apiKey.HospitalID != hospitalID {
+		if err == nil || errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrAPIKeyNotFound
+		}
+		return fmt.Errorf("API anahtarı getirilemedi: %v", err)
+	}
+
+	if apiKey.RevokedAt != nil {
+		return nil
+	}
+
+	if err := s.apiKeyRepo.Revoke(apiKey, actorID); err != nil {
+		if errors.Is(err, repository.ErrAPIKeyAlreadyRevoked) {
+			return nil
+		}
+		return fmt.Errorf("API anahtarı iptal edilemedi: %v", err)
+	}
+
+	fmt.Printf("🔒 API anahtarı iptal edildi: hastane=%d anahtar=%d yetkili=%d\n", hospitalID, apiKeyID, actorID)
+	return nil
+}
+
+// ==================== HELPER METHODS ====================
+
+// validateCreate anahtar isteğini doğrular, normalize edilmiş IP listesini döndürür
+func (s *APIKeyService) validateCreate(hospitalID uint, req *model.CreateAPIKeyRequest) ([]model.ValidationError, []string) {
+	var validationErrors []model.ValidationError
+
+	if length := len([]rune(req.Name)); length < apiKeyNameMinLength || length > apiKeyNameMaxLength {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "name",
+			Message: fmt.Sprintf("Anahtar adı %d-%d karakter arasında olmalıdır", apiKeyNameMinLength, apiKeyNameMaxLength),
+		})
+	}
+
+	if len(req.Scopes) == 0 {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "scopes",
+			Message: "En az bir yetki seçilmelidir",
+		})
+	}
+	for _, scope := range req.Scopes {
+		if !model.IsAPIKeyScope(scope) {
+			validationErrors = append(validationErrors, model.ValidationError{
+				Field:   "scopes",
+				Message: fmt.Sprintf("'%s' API anahtarına verilebilecek bir yetki değil", scope),
+			})
+		}
+	}
+
+	if req.ExpiresInDays < 1 || req.ExpiresInDays > apiKeyMaxTTLDays {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "expires_in_days",
+			Message: fmt.Sprintf("Geçerlilik süresi 1 ile %d gün arasında olmalıdır", apiKeyMaxTTLDays),
+		})
+	}
+
+	var allowedIPs []string
+	if len(req.AllowedIPs) > apiKeyMaxAllowedIPs {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "allowed_ips",
+			Message: fmt.Sprintf("En fazla %d IP/CIDR girilebilir", apiKeyMaxAllowedIPs),
+		})
+	}
+	for _, rule := range req.AllowedIPs {
+		normalized, ok := utils.NormalizeIPRule(rule)
+		if !ok {
+			validationErrors = append(validationErrors, model.ValidationError{
+				Field:   "allowed_ips",
+				Message: fmt.Sprintf("'%s' geçerli bir IP adresi veya CIDR bloğu değil", rule),
+			})
+			continue
+		}
+		allowedIPs = append(allowedIPs, normalized)
+	}
+
+	if apiKeys, err := s.apiKeyRepo.GetByHospitalID(hospitalID); err == nil {
+		active := 0
+		for i := range apiKeys {
+			if apiKeys[i].EffectiveStatus() == model.APIKeyActive {
+				active++
+			}
+		}
+		if active >= apiKeyMaxActiveCount {
+			validationErrors = append(validationErrors, model.ValidationError{
+				Field:   "name",
+				Message: fmt.Sprintf("Hastanenin en fazla %d aktif API anahtarı olabilir, kullanılmayanları iptal edin", apiKeyMaxActiveCount),
+			})
+		}
+	}
+
+	return validationErrors, uniqueStrings(allowedIPs)
+}
+
+// uniqueStrings sırayı koruyarak tekrar eden değerleri çıkarır
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		if seen[value] {
+			continue
+		}
+		seen[value] = true
+		unique = append(unique, value)
+	}
+	return unique
+}
+
+// toAPIKeyResponse API anahtarını API cevabına çevirir
+func toAPIKeyResponse(apiKey *model.APIKey) model.APIKeyResponse {
+	return model.APIKeyResponse{
+		ID:         apiKey.ID,
+		Name:       apiKey.Name,
+		KeyHint:    apiKey.KeyHint,
+		Scopes:     apiKey.ScopeNames(),
+		AllowedIPs: apiKey.AllowedIPList(),
+		Status:     apiKey.EffectiveStatus(),
+		ExpiresAt:  apiKey.ExpiresAt,
+		RevokedAt:  apiKey.RevokedAt,
+		LastUsedAt: apiKey.LastUsedAt,
+		LastUsedIP: apiKey.LastUsedIP,
+		CreatedBy:  apiKey.CreatedBy,
+		CreatedAt:  apiKey.CreatedAt,
+	}
+}
@@ -9,103 +9,224 @@ import (
 	"hospital-platform/utils"
 )
 
-// Kayıt servisi — user şifresi hashlenir, veritabanına gönderilir
-func RegisterUser(user *model.User) error {
-	fmt.Println("=== REGISTER DEBUG ===")
-	fmt.Println("Kayıt için gelen şifre:", user.Password)
+// Login kullanıcı girişi yapar; brute-force koruması için kimlik ve IP bazlı sayaçları kullanır
+// Kullanıcı bulunamadığında ve şifre yanlış olduğunda aynı hata döner (hesap varlığı sızdırılmaz)
+// MFA aktif veya şifresi süresi dolmuş kullanıcılar için token yerine ek adım (challenge) döner
+func Login(emailOrPhone, password string, client model.ClientInfo) (*model.TokenResponse, *model.LoginChallengeResponse, error) {
+	guard := NewLoginGuardService()
+
+	// Kilit veya bekleme süresi varsa şifreyi hiç kontrol etme
+	if err := guard.CheckLogin(emailOrPhone, client.IP); err != nil {
+		fmt.Println("Giriş engellendi:", err)
+		return nil, nil, err
+	}
 
-	// Şifreyi güvenli hale getir
-	hashedPassword, err := utils.HashPassword(user.Password)
-	if err != nil {
-		fmt.Println("Hash hatası:", err)
-		return err
+	var user model.User
+
+	// Email veya telefon ile kullanıcıyı bul
+	if err := database.DB.Where("email = ? OR phone = ?", emailOrPhone, emailOrPhone).First(&user).Error; err != nil {
+		// Şifre sahte hash ile yine de doğrulanır - cevap süresinden hesabın varlığı anlaşılmaz
+		if _, _, err := utils.VerifyPassword(password, utils.DummyPasswordHash()); errors.Is(err, utils.ErrHasherBusy) {
+			return nil, nil, err
+		}
+		if err := guard.RecordLoginFailure(emailOrPhone, client.IP); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, ErrInvalidCredentials
+	}
+
+	// Şifreyi kontrol et
+	isValid, needsRehash, err := utils.VerifyPassword(password, user.Password)
+
+	// Hash havuzu doluysa deneme başarısız sayılmaz, istemci tekrar denemeli
+	if errors.Is(err, utils.ErrHasherBusy) {
+		return nil, nil, err
+	}
+
+	if !isValid {
+		if err := guard.RecordLoginFailure(emailOrPhone, client.IP); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, ErrInvalidCredentials
+	}
+
+	if err := guard.RecordLoginSuccess(emailOrPhone); err != nil {
+		fmt.Println("Giriş sayaçları temizlenemedi:", err)
 	}
 
-	fmt.Println("Hash'lenmiş şifre:", hashedPassword)
-	user.Password = hashedPassword
+	// Hesap durumu şifre doğrulandıktan sonra söylenir - yanlış şifreyle hesabın durumu öğrenilemez
+	if err := utils.CheckUserStatus(user.Status); err != nil {
+		fmt.Printf("Giriş engellendi: kullanıcı %d durumu %s\n", user.ID, user.Status)
+		return nil, nil, err
+	}
 
-	// Veritabanına kaydet
-	err = repository.NewUserRepository().Create(user)
+	// Eski algoritma (bcrypt) veya eski parametrelerle üretilmiş hash'i güncel hasher ile yenile
+	if needsRehash {
+		rehashPassword(&user, password)
+	}
+
+	// Şifre süresi dolduysa veya yönetici işaretlediyse önce şifre değiştirilmeli
+	changeRequired, err := NewPasswordPolicyService().IsChangeRequired(&user)
 	if err != nil {
-		fmt.Println("Veritabanı kayıt hatası:", err)
-	} else {
-		fmt.Println("Kullanıcı başarıyla kaydedildi")
+		return nil, nil, err
+	}
+	if changeRequired {
+		changeToken, err := utils.GeneratePasswordChangeToken(user.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("şifre değiştirme token'ı üretilemedi: %v", err)
+		}
+		fmt.Println("Şifre doğrulandı, şifre değişikliği bekleniyor")
+		return nil, &model.LoginChallengeResponse{
+			PasswordChangeRequired: true,
+			PasswordChangeToken:    changeToken,
+			ExpiresIn:              int(utils.PASSWORD_CHANGE_TOKEN_TTL.Seconds()),
+		}, nil
 	}
-	fmt.Println("=== REGISTER DEBUG END ===")
-	return err
+
+	return completeLogin(&user, client)
 }
 
-func Login(emailOrPhone, password string) (string, error) {
-	fmt.Println("=== LOGIN DEBUG ===")
-	fmt.Println("Login için gelen email/telefon:", emailOrPhone)
-	fmt.Println("Login için gelen şifre:", password)
+// ChangeExpiredPassword login sırasında süresi dolan şifreyi değiştirir ve girişi tamamlar
+// Eski şifreyle açılmış tüm oturumlar sonlandırılır; MFA aktifse ikinci adım challenge'ı döner
+func ChangeExpiredPassword(req *model.PasswordChangeRequest, client model.ClientInfo) (*model.TokenResponse, *model.LoginChallengeResponse, []model.ValidationError, error) {
+	userID, err := utils.ValidatePasswordChangeToken(req.ChangeToken)
+	if err != nil {
+		return nil, nil, nil, ErrInvalidPasswordChangeToken
+	}
 
 	var user model.User
+	if err := database.DB.First(&user, userID).Error; err != nil {
+		return nil, nil, nil, ErrInvalidPasswordChangeToken
+	}
+	if err := utils.CheckUserStatus(user.Status); err != nil {
+		return nil, nil, nil, err
+	}
 
-	// Email veya telefon ile kullanıcıyı bul
-	if err := database.DB.Where("email = ? OR phone = ?", emailOrPhone, emailOrPhone).First(&user).Error; err != nil {
-		fmt.Println("Kullanıcı bulunamadı hatası:", err)
-		return "", errors.New("Kullanıcı bulunamadı")
+	if req.NewPassword != req.ConfirmPassword {
+		return nil, nil, []model.ValidationError{{
+			Field:   "confirm_password",
+			Message: "Şifreler eşleşmiyor",
+		}}, nil
 	}
 
-	fmt.Println("Veritabanından gelen hash:", user.Password)
-	fmt.Println("Kullanıcının girdiği şifre:", password)
+	policyService := NewPasswordPolicyService()
+	validationErrors, err := policyService.ValidatePassword(user.HospitalID, &user, "new_password", req.NewPassword)
+	if len(validationErrors) > 0 || err != nil {
+		return nil, nil, validationErrors, err
+	}
 
-	// Şifreyi kontrol et
-	isValid := utils.CheckPasswordHash(password, user.Password)
-	fmt.Println("Şifre kontrol sonucu:", isValid)
+	if err := policyService.SetPassword(&user, req.NewPassword); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := NewTokenService().RevokeAllForUser(user.ID); err != nil {
+		return nil, nil, nil, err
+	}
 
-	if !isValid {
-		fmt.Println("Şifre yanlış!")
-		return "", errors.New("Şifre yanlış")
+	tokens, challenge, err := completeLogin(&user, client)
+	return tokens, challenge, nil, err
+}
+
+// completeLogin şifre adımı tamamlanan kullanıcı için MFA challenge'ı veya token çiftini üretir
+func completeLogin(user *model.User, client model.ClientInfo) (*model.TokenResponse, *model.LoginChallengeResponse, error) {
+	mfaService := NewMFAService()
+
+	// MFA aktifse ikinci adım için kısa ömürlü token dön
+	if user.MFAEnabled {
+		challenge, err := mfaService.NewChallenge(user)
+		if err != nil {
+			return nil, nil, err
+		}
+		fmt.Println("Şifre doğrulandı, MFA adımı bekleniyor")
+		return nil, challenge, nil
 	}
 
-	// JWT token üret (doğru parametreler ile)
-	token, err := utils.GenerateJWT(user.ID, user.Email, user.Role, user.HospitalID, user.Email)
+	// Access + refresh token çifti üret (yeni token ailesi)
+	tokens, err := NewTokenService().IssueTokenPair(user, false, client)
 	if err != nil {
 		fmt.Println("Token üretme hatası:", err)
-		return "", err
+		return nil, nil, err
 	}
+	tokens.MFAEnrollmentRequired = mfaService.IsEnrollmentRequired(user)
 
 	fmt.Println("Login başarılı, token üretildi")
-	fmt.Println("=== LOGIN DEBUG END ===")
-	return token, nil
+	return tokens, nil, nil
+}
+
+// rehashPassword şifreyi güncel hasher ile yeniden hash'leyip sadece şifre kolonunu günceller
+// Hata girişi engellemez, bir sonraki girişte tekrar denenir
+func rehashPassword(user *model.User, password string) {
+	hashed, err := utils.HashPassword(password)
+	if err != nil {
+		fmt.Println("Şifre yeniden hash'lenemedi:", err)
+		return
+	}
+
+	if err := database.DB.Model(user).Update("password", hashed).Error; err != nil {
+		fmt.Println("Yeniden hash'lenen şifre kaydedilemedi:", err)
+		return
+	}
+	fmt.Println("Şifre hash'i güncel algoritmaya yükseltildi")
+}
+
+// Logout mevcut oturumu veya kullanıcının tüm oturumlarını sonlandırır
+func Logout(userID uint, familyID string, allSessions bool) error {
+	tokenService := NewTokenService()
+	if allSessions {
+		return tokenService.RevokeAllForUser(userID)
+	}
+	return tokenService.Logout(userID, familyID)
 }
 
 // ==================== ALT KULLANICI YÖNETİMİ ====================
 
 // CreateSubUser alt kullanıcı oluşturur
-func CreateSubUser(req *model.CreateSubUserRequest, createdBy uint) (*model.User, []model.ValidationError, error) {
+// hospitalID: işlemi yapanın token'daki aktif hastanesi - kullanıcı bu hastanede oluşturulur
+func CreateSubUser(req *model.CreateSubUserRequest, hospitalID, createdBy uint) (*model.User, []model.ValidationError, error) {
 	// 1. Giriş verilerini doğrula
 	validationErrors := validateSubUserData(req)
 	if len(validationErrors) > 0 {
 		return nil, validationErrors, nil
 	}
 
-	// 2. Oluşturan kullanıcının hastane ID'sini al
-	var creator model.User
-	if err := database.DB.First(&creator, createdBy).Error; err != nil {
+	// 2. Oluşturan kullanıcıyı aktif hastanedeki rolüyle al
+	creator, err := NewMembershipService().GetUserInHospital(createdBy, hospitalID)
+	if err != nil {
 		return nil, nil, fmt.Errorf("oluşturan kullanıcı bulunamadı: %v", err)
 	}
 
-	// 3. Şifreyi hash'le
+	// 3. Rol ve poliklinik kontrolü - kimse kendi yetkilerinden fazlasını veremez
+	validationErrors, err = validateRoleAssignment(creator, req.Role, req.PolyclinicID)
+	if len(validationErrors) > 0 || err != nil {
+		return nil, validationErrors, err
+	}
+
+	// 4. Şifre politikası kontrolü (oluşturanın hastanesinin politikası)
+	validationErrors, err = NewPasswordPolicyService().ValidatePassword(creator.HospitalID, nil, "password", req.Password)
+	if len(validationErrors) > 0 || err != nil {
+		return nil, validationErrors, err
+	}
+
+	// 5. Şifreyi hash'le
 	hashedPassword, err := utils.HashPassword(req.Password)
 	if err != nil {
 		return nil, nil, fmt.Errorf("şifre hash'lenemedi: %v", err)
 	}
 
-	// 4. Alt kullanıcıyı oluştur
+	// 6. Alt kullanıcıyı oluştur
 	subUser := &model.User{
-		HospitalID: creator.HospitalID,
-		FirstName:  req.FirstName,
-		LastName:   req.LastName,
-		TCKN:       req.TCKN,
-		Email:      req.Email,
-		Phone:      req.Phone,
-		Password:   hashedPassword,
-		Role:       req.Role,
-		CreatedBy:  &createdBy,
-		IsActive:   true,
+		HospitalID:   creator.HospitalID,
+		FirstName:    req.FirstName,
+		LastName:     req.LastName,
+		TCKN:         req.TCKN,
+		Email:        req.Email,
+		Phone:        req.Phone,
+		Password:     hashedPassword,
+		Role:         req.Role,
+		PolyclinicID: req.PolyclinicID,
+		CreatedBy:    &createdBy,
+		Status:       model.UserStatusActive,
+		// Şifreyi yönetici belirledi - kullanıcı ilk girişte kendi şifresini belirlemeli
+		MustChangePassword: true,
 	}
 
 	if err := database.DB.Create(subUser).Error; err != nil {
@@ -125,7 +246,8 @@ func GetSubUsers(hospitalID uint) ([]model.User, error) {
 }
 
 // UpdateSubUser alt kullanıcı bilgilerini günceller
-func UpdateSubUser(userID uint, req *model.UpdateSubUserRequest, updatedBy uint) (*model.User, []model.ValidationError, error) {
+// Sadece birincil hastanesi işlemi yapanın aktif hastanesi olan kullanıcılar güncellenebilir
+func UpdateSubUser(userID uint, req *model.UpdateSubUserRequest, hospitalID, updatedBy uint) (*model.User, []model.ValidationError, error) {
 	// 1. Giriş verilerini doğrula
 	validationErrors := validateUpdateSubUserData(req, userID)
 	if len(validationErrors) > 0 {
@@ -139,8 +261,8 @@ func UpdateSubUser(userID uint, req *model.UpdateSubUserRequest, updatedBy uint)
 	}
 
 	// 3. Güncelleme yetkisi kontrolü
-	var updater model.User
-	if err := database.DB.First(&updater, updatedBy).Error; err != nil {
+	updater, err := NewMembershipService().GetUserInHospital(updatedBy, hospitalID)
+	if err != nil {
 		return nil, nil, fmt.Errorf("güncelleyen kullanıcı bulunamadı: %v", err)
 	}
 
@@ -148,23 +270,41 @@ func UpdateSubUser(userID uint, req *model.UpdateSubUserRequest, updatedBy uint)
 		return nil, nil, fmt.Errorf("farklı hastaneye ait kullanıcı güncellenemez")
 	}
 
-	// 4. Kullanıcı bilgilerini güncelle
+	// 4. Rol kontrolü - mevcut ve yeni rol, güncelleyenin yetkilerini aşmamalı
+	if err := NewRoleService().CheckAssignableRole(updater.HospitalID, updater.Role, user.Role); errors.Is(err, ErrPermissionEscalation) {
+		return nil, nil, err
+	}
+	validationErrors, err = validateRoleAssignment(updater, req.Role, req.PolyclinicID)
+	if len(validationErrors) > 0 || err != nil {
+		return nil, validationErrors, err
+	}
+
+	// 5. Kullanıcı bilgilerini güncelle - hesap durumu sadece UserStatusService ile (gerekçe ve denetim kaydıyla) değişir
+	roleChanged := user.Role != req.Role
+
 	user.FirstName = req.FirstName
 	user.LastName = req.LastName
 	user.Email = req.Email
 	user.Phone = req.Phone
 	user.Role = req.Role
-	user.IsActive = req.IsActive
+	user.PolyclinicID = req.PolyclinicID
 
 	if err := database.DB.Save(&user).Error; err != nil {
 		return nil, nil, fmt.Errorf("kullanıcı güncellenemedi: %v", err)
 	}
 
+	// 6. Yetki değişikliklerinin bir sonraki istekte geçerli olması için token'ları geçersiz kıl
+	if roleChanged {
+		if err := NewTokenService().InvalidateUserTokens(user.ID); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	return &user, nil, nil
 }
 
 // DeleteSubUser alt kullanıcıyı siler
-func DeleteSubUser(userID uint, deletedBy uint) error {
+func DeleteSubUser(userID, hospitalID, deletedBy uint) error {
 	// 1. Kullanıcıyı bul
 	var user model.User
 	if err := database.DB.First(&user, userID).Error; err != nil {
@@ -172,8 +312,8 @@ func DeleteSubUser(userID uint, deletedBy uint) error {
 	}
 
 	// 2. Silme yetkisi kontrolü
-	var deleter model.User
-	if err := database.DB.First(&deleter, deletedBy).Error; err != nil {
+	deleter, err := NewMembershipService().GetUserInHospital(deletedBy, hospitalID)
+	if err != nil {
 		return fmt.Errorf("silen kullanıcı bulunamadı: %v", err)
 	}
 
@@ -186,10 +326,75 @@ func DeleteSubUser(userID uint, deletedBy uint) error {
 		return fmt.Errorf("kendinizi silemezsiniz")
 	}
 
-	// 4. Kullanıcıyı soft delete yap
+	// 4. Kendinden yetkili kullanıcıyı silmeye çalışıyorsa engelle
+	if err := NewRoleService().CheckAssignableRole(deleter.HospitalID, deleter.Role, user.Role); errors.Is(err, ErrPermissionEscalation) {
+		return err
+	}
+
+	// 5. Kullanıcıyı soft delete yap, diğer hastanelerdeki üyeliklerini kaldır
 	if err := database.DB.Delete(&user).Error; err != nil {
 		return fmt.Errorf("kullanıcı silinemedi: %v", err)
 	}
+	if err := repository.NewMembershipRepository().DeleteByUserID(user.ID); err != nil {
+		return fmt.Errorf("kullanıcı üyelikleri silinemedi: %v", err)
+	}
+
+	// 6. Silinen kullanıcının tüm oturumlarını sonlandır
+	return NewTokenService().RevokeAllForUser(user.ID)
+}
+
+// UnlockSubUser kilitlenmiş kullanıcının giriş ve şifre sıfırlama kilitlerini kaldırır
+func UnlockSubUser(userID, hospitalID, unlockedBy uint) error {
+	// 1. Kullanıcıyı bul
+	var user model.User
+	if err := database.DB.First(&user, userID).Error; err != nil {
+		return fmt.Errorf("kullanıcı bulunamadı: %v", err)
+	}
+
+	// 2. Yetki kontrolü - sadece aynı hastane
+	unlocker, err := NewMembershipService().GetUserInHospital(unlockedBy, hospitalID)
+	if err != nil {
+		return fmt.Errorf("işlemi yapan kullanıcı bulunamadı: %v", err)
+	}
+
+	if unlocker.HospitalID != user.HospitalID {
+		return fmt.Errorf("farklı hastaneye ait kullanıcının kilidi açılamaz")
+	}
+
+	// 3. Email ve telefon için tüm kilit/sayaçları temizle
+	if err := NewLoginGuardService().UnlockIdentifiers(user.Email, user.Phone); err != nil {
+		return fmt.Errorf("kilit kaldırılamadı: %v", err)
+	}
+
+	return nil
+}
+
+// ExpireSubUserPassword kullanıcının bir sonraki girişte şifresini değiştirmesini zorunlu kılar
+func ExpireSubUserPassword(userID, hospitalID, expiredBy uint) error {
+	// 1. Kullanıcıyı bul
+	var user model.User
+	if err := database.DB.First(&user, userID).Error; err != nil {
+		return fmt.Errorf("kullanıcı bulunamadı: %v", err)
+	}
+
+	// 2. Yetki kontrolü - aynı hastane ve kendinden yetkili olmayan kullanıcı
+	actor, err := NewMembershipService().GetUserInHospital(expiredBy, hospitalID)
+	if err != nil {
+		return fmt.Errorf("işlemi yapan kullanıcı bulunamadı: %v", err)
+	}
+
+	if actor.HospitalID != user.HospitalID {
+		return fmt.Errorf("farklı hastaneye ait kullanıcı için şifre değişikliği istenemez")
+	}
+
+	if err := NewRoleService().CheckAssignableRole(actor.HospitalID, actor.Role, user.Role); errors.Is(err, ErrPermissionEscalation) {
+		return err
+	}
+
+	// 3. Bir sonraki girişte şifre değişikliği iste - mevcut oturumlar etkilenmez
+	if err := database.DB.Model(&user).Update("must_change_password", true).Error; err != nil {
+		return fmt.Errorf("kullanıcı güncellenemedi: %v", err)
+	}
 
 	return nil
 }
@@ -236,6 +441,38 @@ func validateSubUserData(req *model.CreateSubUserRequest) []model.ValidationErro
 	return errors
 }
 
+// validateRoleAssignment atanacak rolün hastanede tanımlı olduğunu, atayanın yetkilerini aşmadığını
+// ve poliklinik seçildiyse aynı hastaneye ait olduğunu kontrol eder
+func validateRoleAssignment(actor *model.User, role string, polyclinicID *uint) ([]model.ValidationError, error) {
+	var validationErrors []model.ValidationError
+
+	err := NewRoleService().CheckAssignableRole(actor.HospitalID, actor.Role, role)
+	switch {
+	case errors.Is(err, ErrRoleNotFound):
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "role",
+			Message: "Hastanede bu isimde bir rol tanımlı değil",
+		})
+	case err != nil:
+		return nil, err
+	}
+
+	if polyclinicID != nil {
+		var count int64
+		database.DB.Model(&model.HospitalPolyclinic{}).
+			Where("id = ? AND hospital_id = ?", *polyclinicID, actor.HospitalID).
+			Count(&count)
+		if count == 0 {
+			validationErrors = append(validationErrors, model.ValidationError{
+				Field:   "polyclinic_id",
+				Message: "Poliklinik bu hastaneye ait değil",
+			})
+		}
+	}
+
+	return validationErrors, nil
+}
+
 // validateUpdateSubUserData güncelleme verilerini doğrular
 func validateUpdateSubUserData(req *model.UpdateSubUserRequest, userID uint) []model.ValidationError {
 	var errors []model.ValidationError
@@ -0,0 +1,948 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"hospital-platform/config"
+	"hospital-platform/model"
+	"hospital-platform/repository"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ErrDutyRosterNotFound     = errors.New("Nöbet listesi bulunamadı")
+	ErrDutyRosterPublished    = errors.New("Yayınlanmış nöbet listesi değiştirilemez")
+	ErrDutyAssignmentNotFound = errors.New("Nöbet ataması bulunamadı")
+)
+
+const (
+	MAX_DUTY_ROSTER_DAYS        = 62 // Tek liste en fazla ~2 ay
+	MAX_DUTY_ROSTER_SLOTS       = 10 // Listede tanımlanabilecek nöbet türü
+	MAX_DUTY_SLOT_HEADCOUNT     = 20 // Aynı nöbette en fazla kişi
+	MAX_DUTY_CONSECUTIVE_NIGHTS = 7
+	MAX_DUTY_MIN_REST_HOURS     = 72
+	MAX_DUTY_VIOLATIONS         = 20 // Tek yanıtta raporlanan kural ihlali
+	dutyRosterNameMaxLength     = 150
+)
+
+// DutyRosterService - Nöbet listesi üretimi, taslak üzerinde elle düzenleme ve yayınlama
+// Liste poliklinik ve/veya meslek grubundaki aktif personelden üretilir, onaylı izindeki personele nöbet yazılmaz
+// Yayınlanan nöbetler personelin vardiya takviminde görünür ve sonraki listelerde dinlenme kontrolüne girer
+type DutyRosterService struct {
+	rosterRepo     *repository.DutyRosterRepository
+	scheduleRepo   *repository.ScheduleRepository
+	staffRepo      *repository.StaffRepository
+	leaveRepo      *repository.LeaveRepository
+	polyclinicRepo *repository.PolyclinicRepository
+	cacheService   *CacheService
+	minRest        time.Duration // Liste oluştururken dinlenme süresi verilmezse kullanılır
+}
+
+// NewDutyRosterService yeni bir nöbet listesi servisi oluşturur
+func NewDutyRosterService() *DutyRosterService {
+	return &DutyRosterService{
+		rosterRepo:     repository.NewDutyRosterRepository(),
+		scheduleRepo:   repository.NewScheduleRepository(),
+		staffRepo:      repository.NewStaffRepository(),
+		leaveRepo:      repository.NewLeaveRepository(),
+		polyclinicRepo: repository.NewPolyclinicRepository(),
+		cacheService:   NewCacheService(),
+		minRest:        config.GetEnvDuration("SCHEDULE_MIN_REST", 11*time.Hour),
+	}
+}
+
+// DutyRosterPlan nöbet üreticisinin ve kural kontrolünün girdileri - veritabanından bağımsızdır
+type DutyRosterPlan struct {
+	Roster     *model.DutyRoster                // Dönem, tatiller, kurallar, seed, vardiyaları yüklenmiş slotlar ve mevcut atamalar
+	Candidates []DutyCandidate                  // Listeye yazılabilecek personel
+	Leaves     []model.LeaveRequest             // Personelin dönem ve çevresindeki onaylı izinleri
+	Existing   []model.DutyAssignment           // Personelin başka yayınlanmış listelerdeki nöbetleri
+	Shifts     map[uint][]model.ShiftOccurrence // Personelin çalışma programından gelen düzenli vardiyaları (izinli günler hariç)
+}
+
+// DutyCandidate nöbet yazılabilecek personel
+type DutyCandidate struct {
+	StaffID uint
+	Name    string
+}
+
+// ==================== LİSTELER ====================
+
+// List hastanenin nöbet listelerini döndürür
+func (s *DutyRosterService) List(hospitalID uint) ([]model.DutyRoster, error) {
+	rosters, err := s.rosterRepo.GetByHospital(hospitalID)
+	if err != nil {
+		return nil, fmt.Errorf("nöbet listeleri getirilemedi: %v", err)
+	}
+	return rosters, nil
+}
+
+// Get listeyi personel dağılımı, boş kalan nöbetler ve güncel kural ihlalleriyle döndürür
+func (s *DutyRosterService) Get(hospitalID, id uint) (*model.DutyRosterResponse, error) {
+	roster, err := s.getRoster(hospitalID, id)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := s.loadPlan(roster)
+	if err != nil {
+		return nil, err
+	}
+	return buildDutyRosterResponse(plan), nil
+}
+
+// Create isteği doğrular, nöbetleri üretir ve listeyi taslak olarak kaydeder
+// Seed verilmezse üretilip listede saklanır; aynı seed ve aynı girdilerle her zaman aynı liste oluşur
+func (s *DutyRosterService) Create(hospitalID uint, createdBy *uint, req *model.CreateDutyRosterRequest) (*model.DutyRosterResponse, []model.ValidationError, error) {
+	roster, validationErrors, err := s.buildRoster(hospitalID, req)
+	if err != nil || len(validationErrors) > 0 {
+		return nil, validationErrors, err
+	}
+	roster.CreatedBy = createdBy
+
+	plan, err := s.loadPlan(roster)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(plan.Candidates) == 0 {
+		return nil, []model.ValidationError{{
+			Field:   "polyclinic_id",
+			Message: "Seçilen poliklinik/meslek grubunda aktif personel yok",
+		}}, nil
+	}
+
+	roster.Assignments = GenerateDutyAssignments(plan)
+	if err := s.rosterRepo.Create(roster); err != nil {
+		return nil, nil, fmt.Errorf("nöbet listesi kaydedilemedi: %v", err)
+	}
+
+	fmt.Printf("🩺 Nöbet listesi oluşturuldu: %d (%d nöbet, %d aday, seed %d)\n",
+		roster.ID, len(roster.Assignments), len(plan.Candidates), roster.Seed)
+	response, err := s.Get(hospitalID, roster.ID)
+	return response, nil, err
+}
+
+// Publish taslak listeyi yayınlar - kural ihlali varken (ör. sonradan onaylanan izin) yayınlanamaz
+// Boş kalan nöbetler yayını engellemez, yanıtta gösterilir
+func (s *DutyRosterService) Publish(hospitalID, id uint, publishedBy *uint) (*model.DutyRosterResponse, []model.ValidationError, error) {
+	roster, err := s.getDraft(hospitalID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	plan, err := s.loadPlan(roster)
+	if err != nil {
+		return nil, nil, err
+	}
+	if violations := CheckDutyRoster(plan); len(violations) > 0 {
+		return nil, violations, nil
+	}
+
+	if err := s.rosterRepo.Publish(roster, publishedBy); err != nil {
+		if errors.Is(err, repository.ErrDutyRosterNotDraft) {
+			return nil, nil, ErrDutyRosterPublished
+		}
+		return nil, nil, fmt.Errorf("nöbet listesi yayınlanamadı: %v", err)
+	}
+
+	fmt.Printf("📢 Nöbet listesi yayınlandı: %d (%d nöbet)\n", roster.ID, len(roster.Assignments))
+	return buildDutyRosterResponse(plan), nil, nil
+}
+
+// Delete listeyi siler - yayınlanmış listenin nöbetleri personel takviminden de kalkar
+func (s *DutyRosterService) Delete(hospitalID, id uint) error {
+	roster, err := s.getRoster(hospitalID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.rosterRepo.Delete(roster); err != nil {
+		return fmt.Errorf("nöbet listesi silinemedi: %v", err)
+	}
+	fmt.Printf("🗑️ Nöbet listesi silindi: %d (%s)\n", roster.ID, roster.Status)
+	return nil
+}
+
+// ==================== ELLE DÜZENLEME ====================
+
+// AddAssignment taslak listede bir nöbete personel ekler
+func (s *DutyRosterService) AddAssignment(hospitalID, rosterID uint, req *model.DutyAssignmentRequest) (*model.DutyRosterResponse, []model.ValidationError, error) {
+	roster, err := s.getDraft(hospitalID, rosterID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var slot *model.DutyRosterSlot
+	for i := range roster.Slots {
+		if roster.Slots[i].ID == req.SlotID {
+			slot = &roster.Slots[i]
+		}
+	}
+	if slot == nil {
+		return nil, []model.ValidationError{{Field: "slot_id", Message: "Nöbet bu listede tanımlı değil"}}, nil
+	}
+	day, err := parseScheduleDate(req.Date)
+	if err != nil {
+		return nil, []model.ValidationError{{Field: "date", Message: "Tarih YYYY-AA-GG biçiminde olmalı"}}, nil
+	}
+	day = model.DateOf(day)
+	if day.Before(model.DateOf(roster.StartDate)) || day.After(model.DateOf(roster.EndDate)) || !slot.AppliesTo(roster, day) {
+		return nil, []model.ValidationError{{
+			Field:   "date",
+			Message: fmt.Sprintf("%s nöbeti %s tarihinde tutulmuyor", slot.ShiftTemplate.Name, day.Format(scheduleDisplayDateLayout)),
+		}}, nil
+	}
+
+	filled := 0
+	for _, assignment := range roster.Assignments {
+		if assignment.SlotID == slot.ID && assignment.Date.Format(scheduleDateLayout) == day.Format(scheduleDateLayout) {
+			filled++
+		}
+	}
+	if filled >= slot.Headcount {
+		return nil, []model.ValidationError{{
+			Field:   "slot_id",
+			Message: fmt.Sprintf("%s %s nöbetinin kişi sayısı dolu (%d)", day.Format(scheduleDisplayDateLayout), slot.ShiftTemplate.Name, slot.Headcount),
+		}}, nil
+	}
+
+	plan, err := s.loadPlan(roster)
+	if err != nil {
+		return nil, nil, err
+	}
+	assignment := newDutyAssignment(roster, slot, day)
+	assignment.StaffID = req.StaffID
+	assignment.Manual = true
+	if validationErrors := checkDutyAssignment(plan, &assignment); len(validationErrors) > 0 {
+		return nil, validationErrors, nil
+	}
+
+	if err := s.rosterRepo.CreateAssignment(&assignment); err != nil {
+		if errors.Is(err, repository.ErrDutyRosterNotDraft) {
+			return nil, nil, ErrDutyRosterPublished
+		}
+		return nil, nil, fmt.Errorf("nöbet ataması eklenemedi: %v", err)
+	}
+	response, err := s.Get(hospitalID, rosterID)
+	return response, nil, err
+}
+
+// UpdateAssignment taslak listedeki nöbeti başka bir personele verir
+func (s *DutyRosterService) UpdateAssignment(hospitalID, rosterID, assignmentID uint, req *model.DutyAssignmentRequest) (*model.DutyRosterResponse, []model.ValidationError, error) {
+	roster, err := s.getDraft(hospitalID, rosterID)
+	if err != nil {
+		return nil, nil, err
+	}
+	assignment := findDutyAssignment(roster, assignmentID)
+	if assignment == nil {
+		return nil, nil, ErrDutyAssignmentNotFound
+	}
+
+	plan, err := s.loadPlan(roster)
+	if err != nil {
+		return nil, nil, err
+	}
+	assignment.StaffID = req.StaffID
+	if validationErrors := checkDutyAssignment(plan, assignment); len(validationErrors) > 0 {
+		return nil, validationErrors, nil
+	}
+
+	if err := s.rosterRepo.UpdateAssignment(assignment); err != nil {
+		if errors.Is(err, repository.ErrDutyRosterNotDraft) {
+			return nil, nil, ErrDutyRosterPublished
+		}
+		return nil, nil, fmt.Errorf("nöbet ataması güncellenemedi: %v", err)
+	}
+	response, err := s.Get(hospitalID, rosterID)
+	return response, nil, err
+}
+
+// DeleteAssignment taslak listeden nöbeti kaldırır (nöbet boş kalır)
+func (s *DutyRosterService) DeleteAssignment(hospitalID, rosterID, assignmentID uint) (*model.DutyRosterResponse, error) {
+	roster, err := s.getDraft(hospitalID, rosterID)
+	if err != nil {
+		return nil, err
+	}
+	assignment := findDutyAssignment(roster, assignmentID)
+	if assignment == nil {
+		return nil, ErrDutyAssignmentNotFound
+	}
+
+	if err := s.rosterRepo.DeleteAssignment(assignment); err != nil {
+		if errors.Is(err, repository.ErrDutyRosterNotDraft) {
+			return nil, ErrDutyRosterPublished
+		}
+		return nil, fmt.Errorf("nöbet ataması silinemedi: %v", err)
+	}
+	return s.Get(hospitalID, rosterID)
+}
+
+// ==================== ÜRETİCİ ====================
+
+// dutyInstance listede doldurulacak tek bir nöbet (slotun bir günü)
+type dutyInstance struct {
+	slot *model.DutyRosterSlot
+	day  time.Time
+}
+
+// GenerateDutyAssignments plandaki nöbetleri başlangıç saatine göre sırayla doldurur
+// Her nöbete kurallara uyan adaylar arasından sırasıyla şu ölçütlere göre en az yüklü olan yazılır:
+// aynı türde (tatil, hafta sonu, hafta içi) tuttuğu nöbet, gece nöbetinde tuttuğu gece sayısı, toplam nöbet saati,
+// en uzun süredir nöbet tutmamış olma. Eşitlik listenin seed'inden üretilen sırayla bozulur,
+// böylece aynı plan ve seed her zaman aynı listeyi verir. Uygun aday bulunamayan nöbetler boş bırakılır.
+func GenerateDutyAssignments(plan *DutyRosterPlan) []model.DutyAssignment {
+	roster := plan.Roster
+	rng := rand.New(rand.NewSource(roster.Seed))
+
+	candidates := append([]DutyCandidate(nil), plan.Candidates...)
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].StaffID < candidates[j].StaffID
+	})
+
+	leaves := groupLeavesByStaff(plan.Leaves)
+	duties := make(map[uint][]model.DutyAssignment, len(candidates))
+	for _, existing := range plan.Existing {
+		duties[existing.StaffID] = append(duties[existing.StaffID], existing)
+	}
+	loads := make(map[uint]*dutyLoad, len(candidates))
+	for _, candidate := range candidates {
+		loads[candidate.StaffID] = &dutyLoad{}
+	}
+
+	assignments := []model.DutyAssignment{}
+	for _, instance := range dutyInstances(roster) {
+		category := dutyCategory(roster, instance.day)
+		tieBreak := rng.Perm(len(candidates))
+		for filled := 0; filled < instance.slot.Headcount; filled++ {
+			assignment := newDutyAssignment(roster, instance.slot, instance.day)
+			best := -1
+			var bestKey [5]int64
+			for i, candidate := range candidates {
+				assignment.StaffID = candidate.StaffID
+				if dutyProblem(roster, leaves[candidate.StaffID], plan.Shifts[candidate.StaffID], duties[candidate.StaffID], &assignment) != "" {
+					continue
+				}
+				key := loads[candidate.StaffID].key(category, assignment.Night, int64(tieBreak[i]))
+				if best < 0 || lessDutyKey(key, bestKey) {
+					best, bestKey = i, key
+				}
+			}
+			if best < 0 {
+				break
+			}
+
+			candidate := candidates[best]
+			assignment.StaffID = candidate.StaffID
+			assignment.StaffName = candidate.Name
+			assignments = append(assignments, assignment)
+			duties[candidate.StaffID] = append(duties[candidate.StaffID], assignment)
+			loads[candidate.StaffID].add(category, &assignment)
+		}
+	}
+	return assignments
+}
+
+// dutyLoad üretim sırasında personelin listede biriken nöbet yükü
+type dutyLoad struct {
+	byCategory [3]int
+	nights     int
+	minutes    int64
+	lastStart  int64 // Son nöbetin başlangıcı (Unix saniye), hiç nöbet yoksa 0
+}
+
+// key adayın sıralama anahtarını döndürür - küçük olan önce seçilir
+func (l *dutyLoad) key(category int, night bool, tieBreak int64) [5]int64 {
+	nights := int64(0)
+	if night {
+		nights = int64(l.nights)
+	}
+	return [5]int64{int64(l.byCategory[category]), nights, l.minutes, l.lastStart, tieBreak}
+}
+
+// add atanan nöbeti yüke ekler
+func (l *dutyLoad) add(category int, assignment *model.DutyAssignment) {
+	l.byCategory[category]++
+	if assignment.Night {
+		l.nights++
+	}
+	l.minutes += int64(assignment.EndsAt.Sub(assignment.StartsAt).Minutes())
+	l.lastStart = assignment.StartsAt.Unix()
+}
+
+// lessDutyKey iki sıralama anahtarını sözlük sırasıyla karşılaştırır
+func lessDutyKey(a, b [5]int64) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+// Nöbet türleri - eşit dağılım her tür için ayrı gözetilir
+const (
+	dutyCategoryWeekday = iota
+	dutyCategoryWeekend
+	dutyCategoryHoliday
+)
+
+// dutyCategory günün nöbet türünü döndürür (resmi tatil hafta sonundan önce gelir)
+func dutyCategory(roster *model.DutyRoster, day time.Time) int {
+	switch {
+	case roster.IsHoliday(day):
+		return dutyCategoryHoliday
+	case roster.IsWeekend(day):
+		return dutyCategoryWeekend
+	default:
+		return dutyCategoryWeekday
+	}
+}
+
+// dutyInstances listenin doldurulacak nöbetlerini başlangıç saatine göre sıralı döndürür
+// Aynı saatte başlayan nöbetler slot sırasını korur
+func dutyInstances(roster *model.DutyRoster) []dutyInstance {
+	var instances []dutyInstance
+	for day := model.DateOf(roster.StartDate); !day.After(model.DateOf(roster.EndDate)); day = day.AddDate(0, 0, 1) {
+		for i := range roster.Slots {
+			if roster.Slots[i].AppliesTo(roster, day) {
+				instances = append(instances, dutyInstance{slot: &roster.Slots[i], day: day})
+			}
+		}
+	}
+	sort.SliceStable(instances, func(i, j int) bool {
+		startI, _ := instances[i].slot.ShiftTemplate.Span()
+		startJ, _ := instances[j].slot.ShiftTemplate.Span()
+		return instances[i].day.Add(startI).Before(instances[j].day.Add(startJ))
+	})
+	return instances
+}
+
+// newDutyAssignment slotun verilen gündeki nöbetini (personel atanmadan) oluşturur
+func newDutyAssignment(roster *model.DutyRoster, slot *model.DutyRosterSlot, day time.Time) model.DutyAssignment {
+	start, end := slot.ShiftTemplate.Span()
+	day = model.DateOf(day)
+	return model.DutyAssignment{
+		RosterID:        roster.ID,
+		SlotID:          slot.ID,
+		Slot:            slot,
+		Date:            storedDate(day),
+		StartsAt:        day.Add(start),
+		EndsAt:          day.Add(end),
+		Night:           slot.ShiftTemplate.Overnight(),
+		ShiftName:       slot.ShiftTemplate.Name,
+		ShiftTemplateID: slot.ShiftTemplateID,
+	}
+}
+
+// ==================== KURAL KONTROLÜ ====================
+
+// CheckDutyRoster listedeki tüm nöbetleri izin, çakışma, dinlenme ve art arda gece kurallarına göre kontrol eder
+// Üretici bu kurallara uyar; ihlaller elle düzenlemeden veya liste oluşturulduktan sonra onaylanan izinlerden doğar
+func CheckDutyRoster(plan *DutyRosterPlan) []model.ValidationError {
+	roster := plan.Roster
+	candidates := make(map[uint]bool, len(plan.Candidates))
+	for _, candidate := range plan.Candidates {
+		candidates[candidate.StaffID] = true
+	}
+	leaves := groupLeavesByStaff(plan.Leaves)
+
+	var validationErrors []model.ValidationError
+	seen := make(map[string]bool)
+	total := 0
+	for i := range roster.Assignments {
+		assignment := &roster.Assignments[i]
+		message := ""
+		if !candidates[assignment.StaffID] {
+			message = fmt.Sprintf("%s: %s nöbeti için listenin personeli değil (pasif veya poliklinik/meslek grubu dışında)",
+				assignment.StaffName, describeDuty(assignment))
+		} else if problem := dutyProblem(roster, leaves[assignment.StaffID], plan.Shifts[assignment.StaffID], staffDuties(plan, assignment), assignment); problem != "" {
+			message = assignment.StaffName + ": " + problem
+		}
+		if message == "" || seen[message] {
+			continue
+		}
+		seen[message] = true
+		total++
+		if total <= MAX_DUTY_VIOLATIONS {
+			validationErrors = append(validationErrors, model.ValidationError{Field: "assignments", Message: message})
+		}
+	}
+	if total > MAX_DUTY_VIOLATIONS {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "assignments",
+			Message: fmt.Sprintf("... ve %d ihlal daha", total-MAX_DUTY_VIOLATIONS),
+		})
+	}
+	return validationErrors
+}
+
+// checkDutyAssignment elle yapılan atamayı kontrol eder: personel listeye uygun olmalı ve nöbet kurallara uymalı
+func checkDutyAssignment(plan *DutyRosterPlan, assignment *model.DutyAssignment) []model.ValidationError {
+	var candidate *DutyCandidate
+	for i := range plan.Candidates {
+		if plan.Candidates[i].StaffID == assignment.StaffID {
+			candidate = &plan.Candidates[i]
+		}
+	}
+	if candidate == nil {
+		return []model.ValidationError{{
+			Field:   "staff_id",
+			Message: "Personel bu listenin poliklinik/meslek grubunda aktif değil",
+		}}
+	}
+	assignment.StaffName = candidate.Name
+
+	leaves := groupLeavesByStaff(plan.Leaves)
+	if problem := dutyProblem(plan.Roster, leaves[assignment.StaffID], plan.Shifts[assignment.StaffID], staffDuties(plan, assignment), assignment); problem != "" {
+		return []model.ValidationError{{Field: "staff_id", Message: candidate.Name + ": " + problem}}
+	}
+	return nil
+}
+
+// staffDuties personelin verilen nöbet dışındaki listedeki ve yayınlanmış diğer listelerdeki nöbetlerini döndürür
+func staffDuties(plan *DutyRosterPlan, assignment *model.DutyAssignment) []model.DutyAssignment {
+	var duties []model.DutyAssignment
+	for _, other := range plan.Roster.Assignments {
+		if other.StaffID == assignment.StaffID && (assignment.ID == 0 || other.ID != assignment.ID) {
+			duties = append(duties, other)
+		}
+	}
+	for _, other := range plan.Existing {
+		if other.StaffID == assignment.StaffID {
+			duties = append(duties, other)
+		}
+	}
+	return duties
+}
+
+// dutyProblem nöbetin personelin izinleri, düzenli vardiyaları ve diğer nöbetleriyle uyumunu kontrol eder, sorun yoksa boş metin döner
+// Kontroller: nöbetin sürdüğü her günde onaylı izin, nöbetlerin yerine geçmediği vardiyalar ve diğer nöbetlerle çakışma,
+// aralarında en az dinlenme, art arda en fazla gece nöbeti
+func dutyProblem(roster *model.DutyRoster, leaves []model.LeaveRequest, shifts []model.ShiftOccurrence, others []model.DutyAssignment, duty *model.DutyAssignment) string {
+	// Gece yarısını geçen nöbet ertesi günü de kapsar; tam gece yarısı biten nöbet kapsamaz
+	lastDay := model.DateOf(duty.EndsAt.Add(-time.Nanosecond))
+	for day := model.DateOf(duty.StartsAt); !day.After(lastDay); day = day.AddDate(0, 0, 1) {
+		for i := range leaves {
+			if leaves[i].CoversDate(day) {
+				return fmt.Sprintf("%s nöbetinde izinde (%s)", describeDuty(duty), leaves[i].LeaveType.Name)
+			}
+		}
+	}
+
+	// Nöbet, başladığı gün ve ertesi günkü (nöbet ertesi) düzenli vardiyaların yerine geçer; kalan vardiyalarla çakışmamalı
+	displaced := make(map[string]bool)
+	addDisplacedShiftDays(displaced, duty)
+	for i := range others {
+		addDisplacedShiftDays(displaced, &others[i])
+	}
+
+	minRest := time.Duration(roster.MinRestHours) * time.Hour
+	for i := range shifts {
+		shift := &shifts[i]
+		if displaced[shift.Date] {
+			continue
+		}
+		if shift.Start.Before(duty.EndsAt) && duty.StartsAt.Before(shift.End) {
+			return fmt.Sprintf("Nöbet düzenli vardiyayla çakışıyor: %s ve %s", describeDuty(duty), describeOccurrence(shift))
+		}
+		if shift.Start.Before(duty.StartsAt) {
+			if rest := duty.StartsAt.Sub(shift.End); rest < minRest {
+				return fmt.Sprintf("Dinlenme süresi yetersiz (en az %s): %s ile %s arasında %s",
+					formatRestDuration(minRest), describeOccurrence(shift), describeDuty(duty), formatRestDuration(rest))
+			}
+		} else if rest := shift.Start.Sub(duty.EndsAt); rest < minRest {
+			return fmt.Sprintf("Dinlenme süresi yetersiz (en az %s): %s ile %s arasında %s",
+				formatRestDuration(minRest), describeDuty(duty), describeOccurrence(shift), formatRestDuration(rest))
+		}
+	}
+
+	for i := range others {
+		first, second := &others[i], duty
+		if dutyBefore(duty, first) {
+			first, second = duty, &others[i]
+		}
+		if second.StartsAt.Before(first.EndsAt) {
+			return fmt.Sprintf("Nöbetler çakışıyor: %s ve %s", describeDuty(first), describeDuty(second))
+		}
+		if rest := second.StartsAt.Sub(first.EndsAt); rest < minRest {
+			return fmt.Sprintf("Dinlenme süresi yetersiz (en az %s): %s ile %s arasında %s",
+				formatRestDuration(minRest), describeDuty(first), describeDuty(second), formatRestDuration(rest))
+		}
+	}
+
+	if duty.Night {
+		start, length := nightRun(others, duty)
+		if length > roster.MaxConsecutiveNights {
+			return fmt.Sprintf("%s tarihinden itibaren art arda %d gece nöbeti (en fazla %d)",
+				start.Format(scheduleDisplayDateLayout), length, roster.MaxConsecutiveNights)
+		}
+	}
+	return ""
+}
+
+// addDisplacedShiftDays nöbetin yerine geçtiği düzenli vardiya günlerini (başladığı gün ve ertesi gün) ekler
+func addDisplacedShiftDays(days map[string]bool, duty *model.DutyAssignment) {
+	start := model.DateOf(duty.StartsAt)
+	days[start.Format(scheduleDateLayout)] = true
+	days[start.AddDate(0, 0, 1).Format(scheduleDateLayout)] = true
+}
+
+// nightRun nöbetin içinde bulunduğu art arda gece nöbeti dizisinin ilk gününü ve uzunluğunu döndürür
+func nightRun(others []model.DutyAssignment, duty *model.DutyAssignment) (time.Time, int) {
+	nights := map[string]bool{duty.Date.Format(scheduleDateLayout): true}
+	for _, other := range others {
+		if other.Night {
+			nights[other.Date.Format(scheduleDateLayout)] = true
+		}
+	}
+
+	start := model.DateOf(duty.Date)
+	for nights[start.AddDate(0, 0, -1).Format(scheduleDateLayout)] {
+		start = start.AddDate(0, 0, -1)
+	}
+	length := 0
+	for day := start; nights[day.Format(scheduleDateLayout)]; day = day.AddDate(0, 0, 1) {
+		length++
+	}
+	return start, length
+}
+
+// dutyBefore a nöbetinin b'den önce başlayıp başlamadığını döndürür (aynı anda başlayanlar ID sırasına göre)
+// Sıra sabit olduğu için aynı ihlal iki nöbetin her birinden aynı metinle raporlanır
+func dutyBefore(a, b *model.DutyAssignment) bool {
+	if !a.StartsAt.Equal(b.StartsAt) {
+		return a.StartsAt.Before(b.StartsAt)
+	}
+	return a.ID < b.ID
+}
+
+// ==================== YANIT ====================
+
+// buildDutyRosterResponse listeye personel dağılımını, boş kalan nöbetleri ve kural ihlallerini ekler
+func buildDutyRosterResponse(plan *DutyRosterPlan) *model.DutyRosterResponse {
+	roster := plan.Roster
+	response := &model.DutyRosterResponse{
+		Roster:     roster,
+		Stats:      []model.DutyStaffStats{},
+		Gaps:       []model.DutyRosterGap{},
+		Violations: CheckDutyRoster(plan),
+	}
+	if response.Violations == nil {
+		response.Violations = []model.ValidationError{}
+	}
+
+	stats := make(map[uint]*model.DutyStaffStats)
+	var order []uint
+	addStats := func(staffID uint, name string) *model.DutyStaffStats {
+		if stats[staffID] == nil {
+			stats[staffID] = &model.DutyStaffStats{StaffID: staffID, StaffName: name}
+			order = append(order, staffID)
+		}
+		return stats[staffID]
+	}
+	for _, candidate := range plan.Candidates {
+		addStats(candidate.StaffID, candidate.Name)
+	}
+
+	filled := make(map[string]int)
+	for i := range roster.Assignments {
+		assignment := &roster.Assignments[i]
+		filled[fmt.Sprintf("%d/%s", assignment.SlotID, assignment.Date.Format(scheduleDateLayout))]++
+
+		staffStats := addStats(assignment.StaffID, assignment.StaffName)
+		staffStats.Total++
+		staffStats.Hours += assignment.Hours()
+		if assignment.Night {
+			staffStats.Nights++
+		}
+		switch dutyCategory(roster, model.DateOf(assignment.Date)) {
+		case dutyCategoryHoliday:
+			staffStats.Holidays++
+		case dutyCategoryWeekend:
+			staffStats.Weekends++
+		}
+	}
+	for _, staffID := range order {
+		response.Stats = append(response.Stats, *stats[staffID])
+	}
+
+	for _, instance := range dutyInstances(roster) {
+		date := instance.day.Format(scheduleDateLayout)
+		if missing := instance.slot.Headcount - filled[fmt.Sprintf("%d/%s", instance.slot.ID, date)]; missing > 0 {
+			response.Gaps = append(response.Gaps, model.DutyRosterGap{
+				Date:      date,
+				SlotID:    instance.slot.ID,
+				ShiftName: instance.slot.ShiftTemplate.Name,
+				Missing:   missing,
+			})
+		}
+	}
+	return response
+}
+
+// ==================== HELPER METHODS ====================
+
+// buildRoster isteği doğrular ve vardiyaları yüklenmiş, varsayılanları doldurulmuş taslak listeye çevirir
+func (s *DutyRosterService) buildRoster(hospitalID uint, req *model.CreateDutyRosterRequest) (*model.DutyRoster, []model.ValidationError, error) {
+	var validationErrors []model.ValidationError
+	roster := &model.DutyRoster{
+		HospitalID:           hospitalID,
+		Name:                 strings.TrimSpace(req.Name),
+		PolyclinicID:         req.PolyclinicID,
+		JobGroupID:           req.JobGroupID,
+		Holidays:             []string{},
+		MaxConsecutiveNights: req.MaxConsecutiveNights,
+		MinRestHours:         int(s.minRest.Hours()),
+		Status:               model.DutyRosterDraft,
+	}
+
+	if roster.Name == "" || len([]rune(roster.Name)) > dutyRosterNameMaxLength {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "name",
+			Message: fmt.Sprintf("Liste adı 1-%d karakter arasında olmalıdır", dutyRosterNameMaxLength),
+		})
+	}
+
+	if req.PolyclinicID == nil && req.JobGroupID == nil {
+		validationErrors = append(validationErrors, model.ValidationError{Field: "polyclinic_id", Message: "Poliklinik veya meslek grubu seçilmelidir"})
+	}
+	if req.PolyclinicID != nil {
+		polyclinic, err := s.polyclinicRepo.GetHospitalPolyclinicByID(*req.PolyclinicID)
+		if err != nil || polyclinic.HospitalID != hospitalID {
+			validationErrors = append(validationErrors, model.ValidationError{Field: "polyclinic_id", Message: "Geçersiz poliklinik seçimi"})
+		}
+	}
+	if req.JobGroupID != nil {
+		jobGroups, err := s.cacheService.GetJobGroups()
+		if err != nil {
+			return nil, nil, fmt.Errorf("meslek grupları getirilemedi: %v", err)
+		}
+		found := false
+		for _, jobGroup := range jobGroups {
+			if jobGroup.ID == *req.JobGroupID {
+				found = true
+			}
+		}
+		if !found {
+			validationErrors = append(validationErrors, model.ValidationError{Field: "job_group_id", Message: "Geçersiz meslek grubu seçimi"})
+		}
+	}
+
+	from, fromErr := parseScheduleDate(req.StartDate)
+	to, toErr := parseScheduleDate(req.EndDate)
+	switch {
+	case fromErr != nil:
+		validationErrors = append(validationErrors, model.ValidationError{Field: "start_date", Message: "Başlangıç tarihi YYYY-AA-GG biçiminde olmalı"})
+	case toErr != nil:
+		validationErrors = append(validationErrors, model.ValidationError{Field: "end_date", Message: "Bitiş tarihi YYYY-AA-GG biçiminde olmalı"})
+	case to.Before(from) || model.DaysBetween(from, to) >= MAX_DUTY_ROSTER_DAYS:
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "end_date",
+			Message: fmt.Sprintf("Bitiş tarihi başlangıçtan önce olamaz ve liste en fazla %d gün olabilir", MAX_DUTY_ROSTER_DAYS),
+		})
+	}
+	roster.StartDate, roster.EndDate = from, to
+
+	seenHolidays := make(map[string]bool)
+	for _, value := range req.Holidays {
+		holiday, err := parseScheduleDate(value)
+		if err != nil {
+			validationErrors = append(validationErrors, model.ValidationError{Field: "holidays", Message: fmt.Sprintf("Geçersiz tatil tarihi: %s (YYYY-AA-GG)", value)})
+			continue
+		}
+		if date := holiday.Format(scheduleDateLayout); !seenHolidays[date] {
+			seenHolidays[date] = true
+			roster.Holidays = append(roster.Holidays, date)
+		}
+	}
+	sort.Strings(roster.Holidays)
+
+	if roster.MaxConsecutiveNights == 0 {
+		roster.MaxConsecutiveNights = 1
+	}
+	if roster.MaxConsecutiveNights < 1 || roster.MaxConsecutiveNights > MAX_DUTY_CONSECUTIVE_NIGHTS {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "max_consecutive_nights",
+			Message: fmt.Sprintf("Art arda gece nöbeti 1-%d arasında olmalı", MAX_DUTY_CONSECUTIVE_NIGHTS),
+		})
+	}
+	if req.MinRestHours != nil {
+		roster.MinRestHours = *req.MinRestHours
+		if roster.MinRestHours < 0 || roster.MinRestHours > MAX_DUTY_MIN_REST_HOURS {
+			validationErrors = append(validationErrors, model.ValidationError{
+				Field:   "min_rest_hours",
+				Message: fmt.Sprintf("Dinlenme süresi 0-%d saat arasında olmalı", MAX_DUTY_MIN_REST_HOURS),
+			})
+		}
+	}
+
+	roster.Seed = time.Now().UnixNano()
+	if req.Seed != nil {
+		roster.Seed = *req.Seed
+	}
+
+	templates, err := s.scheduleRepo.GetTemplatesByHospital(hospitalID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("vardiyalar getirilemedi: %v", err)
+	}
+	templatesByID := make(map[uint]model.ShiftTemplate, len(templates))
+	for _, template := range templates {
+		templatesByID[template.ID] = template
+	}
+
+	if len(req.Slots) == 0 || len(req.Slots) > MAX_DUTY_ROSTER_SLOTS {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "slots",
+			Message: fmt.Sprintf("Listede 1-%d nöbet tanımlanmalıdır", MAX_DUTY_ROSTER_SLOTS),
+		})
+	}
+	seenSlots := make(map[string]bool)
+	for _, slot := range req.Slots {
+		if slot.DayType == "" {
+			slot.DayType = model.DutyDayAll
+		}
+		template, ok := templatesByID[slot.ShiftTemplateID]
+		key := fmt.Sprintf("%d/%s", slot.ShiftTemplateID, slot.DayType)
+		switch {
+		case !ok:
+			validationErrors = append(validationErrors, model.ValidationError{
+				Field:   "slots",
+				Message: fmt.Sprintf("Vardiya bulunamadı: %d", slot.ShiftTemplateID),
+			})
+		case slot.DayType != model.DutyDayAll && slot.DayType != model.DutyDayWeekday && slot.DayType != model.DutyDayWeekend:
+			validationErrors = append(validationErrors, model.ValidationError{
+				Field:   "slots",
+				Message: "Gün türü all, weekday veya weekend olmalı",
+			})
+		case slot.Headcount < 1 || slot.Headcount > MAX_DUTY_SLOT_HEADCOUNT:
+			validationErrors = append(validationErrors, model.ValidationError{
+				Field:   "slots",
+				Message: fmt.Sprintf("%s nöbetinin kişi sayısı 1-%d arasında olmalı", template.Name, MAX_DUTY_SLOT_HEADCOUNT),
+			})
+		case seenSlots[key]:
+			validationErrors = append(validationErrors, model.ValidationError{
+				Field:   "slots",
+				Message: fmt.Sprintf("%s nöbeti aynı gün türüyle birden fazla kez eklenmiş", template.Name),
+			})
+		default:
+			seenSlots[key] = true
+			roster.Slots = append(roster.Slots, model.DutyRosterSlot{
+				ShiftTemplateID: template.ID,
+				DayType:         slot.DayType,
+				Headcount:       slot.Headcount,
+				ShiftTemplate:   template,
+			})
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		return nil, validationErrors, nil
+	}
+	return roster, nil, nil
+}
+
+// loadPlan listenin adaylarını, onaylı izinlerini ve başka yayınlanmış listelerdeki nöbetlerini yükler
+// Listede atanmış ama artık aday olmayan personelin izinleri ve nöbetleri de kural kontrolü için yüklenir
+func (s *DutyRosterService) loadPlan(roster *model.DutyRoster) (*DutyRosterPlan, error) {
+	staffs, err := s.staffRepo.GetActiveByGroup(roster.HospitalID, roster.PolyclinicID, roster.JobGroupID)
+	if err != nil {
+		return nil, fmt.Errorf("personel getirilemedi: %v", err)
+	}
+
+	plan := &DutyRosterPlan{Roster: roster}
+	seen := make(map[uint]bool)
+	var staffIDs []uint
+	for _, staff := range staffs {
+		plan.Candidates = append(plan.Candidates, DutyCandidate{StaffID: staff.ID, Name: staff.FirstName + " " + staff.LastName})
+		seen[staff.ID] = true
+		staffIDs = append(staffIDs, staff.ID)
+	}
+	for _, assignment := range roster.Assignments {
+		if !seen[assignment.StaffID] {
+			seen[assignment.StaffID] = true
+			staffIDs = append(staffIDs, assignment.StaffID)
+		}
+	}
+
+	// Dönem sınırındaki nöbet ve vardiyalar da dinlenme ve art arda gece kuralına girer
+	// İzinler de aynı aralıkta yüklenir: son günün gece nöbeti ertesi güne taşar, sınırdaki vardiyaların izinli olup olmadığı bilinmelidir
+	from, to := model.DateOf(roster.StartDate), model.DateOf(roster.EndDate)
+	window := MAX_DUTY_CONSECUTIVE_NIGHTS + 1
+	windowFrom, windowTo := from.AddDate(0, 0, -window), to.AddDate(0, 0, window)
+	if plan.Leaves, err = s.leaveRepo.GetApprovedByStaffIDs(staffIDs, windowFrom, windowTo); err != nil {
+		return nil, fmt.Errorf("izinler getirilemedi: %v", err)
+	}
+	if plan.Existing, err = s.rosterRepo.GetPublishedAssignments(staffIDs, windowFrom, windowTo, roster.ID); err != nil {
+		return nil, fmt.Errorf("yayınlanmış nöbetler getirilemedi: %v", err)
+	}
+
+	schedules, err := s.scheduleRepo.GetSchedulesByStaffIDs(staffIDs, windowFrom, windowTo)
+	if err != nil {
+		return nil, fmt.Errorf("çalışma programları getirilemedi: %v", err)
+	}
+	plan.Shifts = groupShiftsByStaff(schedules, plan.Leaves, windowFrom, windowTo)
+	return plan, nil
+}
+
+// getRoster hastaneye ait listeyi getirir
+func (s *DutyRosterService) getRoster(hospitalID, id uint) (*model.DutyRoster, error) {
+	roster, err := s.rosterRepo.GetByID(id)
+	if err != nil || roster.HospitalID != hospitalID {
+		return nil, ErrDutyRosterNotFound
+	}
+	return roster, nil
+}
+
+// getDraft hastaneye ait taslak listeyi getirir
+func (s *DutyRosterService) getDraft(hospitalID, id uint) (*model.DutyRoster, error) {
+	roster, err := s.getRoster(hospitalID, id)
+	if err != nil {
+		return nil, err
+	}
+	if roster.Status != model.DutyRosterDraft {
+		return nil, ErrDutyRosterPublished
+	}
+	return roster, nil
+}
+
+// findDutyAssignment listedeki atamayı ID'sine göre bulur
+func findDutyAssignment(roster *model.DutyRoster, id uint) *model.DutyAssignment {
+	for i := range roster.Assignments {
+		if roster.Assignments[i].ID == id {
+			return &roster.Assignments[i]
+		}
+	}
+	return nil
+}
+
+// groupLeavesByStaff izinleri personele göre gruplar
+func groupLeavesByStaff(leaves []model.LeaveRequest) map[uint][]model.LeaveRequest {
+	grouped := make(map[uint][]model.LeaveRequest)
+	for _, leave := range leaves {
+		grouped[leave.StaffID] = append(grouped[leave.StaffID], leave)
+	}
+	return grouped
+}
+
+// groupShiftsByStaff programların [from, to] vardiyalarını personele göre gruplar
+// Onaylı izne denk gelen vardiyalar çalışılmadığı için dahil edilmez
+func groupShiftsByStaff(schedules []model.StaffSchedule, leaves []model.LeaveRequest, from, to time.Time) map[uint][]model.ShiftOccurrence {
+	leavesByStaff := groupLeavesByStaff(leaves)
+	grouped := make(map[uint][]model.ShiftOccurrence)
+	for i := range schedules {
+		occurrences := ExpandSchedules(schedules[i:i+1], from, to)
+		MarkLeaves(occurrences, leavesByStaff[schedules[i].StaffID])
+		for _, occurrence := range occurrences {
+			if occurrence.LeaveRequestID == nil {
+				grouped[schedules[i].StaffID] = append(grouped[schedules[i].StaffID], occurrence)
+			}
+		}
+	}
+	return grouped
+}
+
+// describeDuty nöbeti "06.01.2025 Gece (20:00-08:00)" biçiminde döndürür
+func describeDuty(duty *model.DutyAssignment) string {
+	return fmt.Sprintf("%s %s (%s-%s)", duty.StartsAt.Format(scheduleDisplayDateLayout), duty.ShiftName,
+		duty.StartsAt.Format("15:04"), duty.EndsAt.Format("15:04"))
+}
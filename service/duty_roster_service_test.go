@@ -0,0 +1,371 @@
+package service
+
+import (
+	"fmt"
+	"hospital-platform/model"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testDutyDate verilen günü yerel saatte gün başı olarak döndürür
+func testDutyDate(value string) time.Time {
+	day, err := time.ParseInLocation(scheduleDateLayout, value, time.Local)
+	if err != nil {
+		panic(err)
+	}
+	return day
+}
+
+func testShiftTemplate(id uint, name, start, end string) model.ShiftTemplate {
+	template := model.ShiftTemplate{Name: name, StartTime: start, EndTime: end}
+	template.ID = id
+	return template
+}
+
+// testDutyPlan 3-30 Kasım 2025 (4 hafta, Pazartesi başlar) için gündüz ve gece nöbeti olan, izinsiz ve programsız bir plan kurar
+func testDutyPlan(seed int64, staffCount int) *DutyRosterPlan {
+	roster := &model.DutyRoster{
+		StartDate:            testDutyDate("2025-11-03"),
+		EndDate:              testDutyDate("2025-11-30"),
+		Holidays:             []string{"2025-11-12"},
+		MaxConsecutiveNights: 1,
+		MinRestHours:         24,
+		Seed:                 seed,
+		Slots: []model.DutyRosterSlot{
+			{ID: 1, ShiftTemplateID: 1, DayType: model.DutyDayAll, Headcount: 1, ShiftTemplate: testShiftTemplate(1, "Gündüz", "08:00", "20:00")},
+			{ID: 2, ShiftTemplateID: 2, DayType: model.DutyDayAll, Headcount: 1, ShiftTemplate: testShiftTemplate(2, "Gece", "20:00", "08:00")},
+		},
+	}
+	plan := &DutyRosterPlan{Roster: roster, Shifts: map[uint][]model.ShiftOccurrence{}}
+	for i := 1; i <= staffCount; i++ {
+		plan.Candidates = append(plan.Candidates, DutyCandidate{StaffID: uint(i), Name: fmt.Sprintf("Personel %d", i)})
+	}
+	return plan
+}
+
+// generateTestRoster listeyi üretir ve atamalara ID verir (kayıt sonrası gibi)
+func generateTestRoster(plan *DutyRosterPlan) []model.DutyAssignment {
+	assignments := GenerateDutyAssignments(plan)
+	for i := range assignments {
+		assignments[i].ID = uint(i + 1)
+	}
+	plan.Roster.Assignments = assignments
+	return assignments
+}
+
+// dutyKeys atamaları üretim sırasıyla "tarih/slot/personel" biçiminde döndürür
+func dutyKeys(assignments []model.DutyAssignment) []string {
+	keys := make([]string, len(assignments))
+	for i, assignment := range assignments {
+		keys[i] = fmt.Sprintf("%s/%d/%d", assignment.Date.Format(scheduleDateLayout), assignment.SlotID, assignment.StaffID)
+	}
+	return keys
+}
+
+func TestGenerateDutyAssignmentsIsDeterministicForSeed(t *testing.T) {
+	first := dutyKeys(GenerateDutyAssignments(testDutyPlan(42, 6)))
+	second := dutyKeys(GenerateDutyAssignments(testDutyPlan(42, 6)))
+	if strings.Join(first, ",") != strings.Join(second, ",") {
+		t.Fatal("aynı seed ve aynı plan farklı liste üretti")
+	}
+
+	// Aday sırası sonucu etkilememeli
+	shuffled := testDutyPlan(42, 6)
+	for i, j := 0, len(shuffled.Candidates)-1; i < j; i, j = i+1, j-1 {
+		shuffled.Candidates[i], shuffled.Candidates[j] = shuffled.Candidates[j], shuffled.Candidates[i]
+	}
+	if strings.Join(dutyKeys(GenerateDutyAssignments(shuffled)), ",") != strings.Join(first, ",") {
+		t.Fatal("adayların sırası değişince liste değişti")
+	}
+
+	// Eşitlik seed ile bozulur; farklı seed'lerden en az biri farklı liste vermeli
+	differs := false
+	for seed := int64(1); seed <= 10 && !differs; seed++ {
+		if seed != 42 && strings.Join(dutyKeys(GenerateDutyAssignments(testDutyPlan(seed, 6))), ",") != strings.Join(first, ",") {
+			differs = true
+		}
+	}
+	if !differs {
+		t.Fatal("farklı seed'ler hep aynı listeyi üretti")
+	}
+}
+
+func TestGenerateDutyAssignmentsFillsRosterWithoutViolations(t *testing.T) {
+	plan := testDutyPlan(7, 6)
+	assignments := generateTestRoster(plan)
+
+	if gaps := buildDutyRosterResponse(plan).Gaps; len(gaps) != 0 {
+		t.Fatalf("yeterli personelle boş nöbet kalmamalı: %+v", gaps)
+	}
+	if len(assignments) != 56 {
+		t.Fatalf("28 gün x 2 nöbet beklenirdi, gelen: %d", len(assignments))
+	}
+	if violations := CheckDutyRoster(plan); len(violations) != 0 {
+		t.Fatalf("üretilen liste kurallara uymuyor: %+v", violations)
+	}
+	assertDutyRest(t, plan)
+}
+
+func TestGenerateDutyAssignmentsIsFair(t *testing.T) {
+	for _, seed := range []int64{1, 2, 3, 20251101} {
+		plan := testDutyPlan(seed, 6)
+		generateTestRoster(plan)
+
+		stats := buildDutyRosterResponse(plan).Stats
+		if len(stats) != 6 {
+			t.Fatalf("seed %d: 6 personelin dağılımı beklenirdi: %+v", seed, stats)
+		}
+		for _, measure := range []struct {
+			name  string
+			value func(model.DutyStaffStats) float64
+			limit float64
+		}{
+			{"toplam nöbet", func(s model.DutyStaffStats) float64 { return float64(s.Total) }, 1},
+			{"gece nöbeti", func(s model.DutyStaffStats) float64 { return float64(s.Nights) }, 1},
+			{"hafta sonu nöbeti", func(s model.DutyStaffStats) float64 { return float64(s.Weekends) }, 1},
+			{"nöbet saati", func(s model.DutyStaffStats) float64 { return s.Hours }, 12},
+		} {
+			low, high := measure.value(stats[0]), measure.value(stats[0])
+			for _, s := range stats[1:] {
+				low, high = min(low, measure.value(s)), max(high, measure.value(s))
+			}
+			if high-low > measure.limit {
+				t.Errorf("seed %d: %s dağılımı dengesiz (%v-%v): %+v", seed, measure.name, low, high, stats)
+			}
+		}
+	}
+}
+
+func TestDutyLeaveCoversEveryDayOfDuty(t *testing.T) {
+	plan := testDutyPlan(3, 6)
+	plan.Leaves = []model.LeaveRequest{{
+		StaffID:   1,
+		Status:    model.LeaveApproved,
+		StartDate: testDutyDate("2025-11-10"),
+		EndDate:   testDutyDate("2025-11-11"),
+		LeaveType: model.LeaveType{Name: "Yıllık İzin"},
+	}}
+	assignments := generateTestRoster(plan)
+
+	for _, assignment := range assignments {
+		if assignment.StaffID != 1 {
+			continue
+		}
+		for day := model.DateOf(assignment.StartsAt); day.Before(assignment.EndsAt); day = day.AddDate(0, 0, 1) {
+			if plan.Leaves[0].CoversDate(day) {
+				t.Fatalf("izinli personele izne taşan nöbet yazıldı: %s", describeDuty(&assignment))
+			}
+		}
+	}
+	if violations := CheckDutyRoster(plan); len(violations) != 0 {
+		t.Fatalf("üretilen liste kurallara uymuyor: %+v", violations)
+	}
+
+	// İzinden önceki gecenin nöbeti izin gününe taşar
+	roster := plan.Roster
+	night := newDutyAssignment(roster, &roster.Slots[1], testDutyDate("2025-11-09"))
+	night.StaffID = 1
+	if problem := dutyProblem(roster, plan.Leaves, nil, nil, &night); !strings.Contains(problem, "izinde") {
+		t.Fatalf("izne taşan gece nöbeti reddedilmeliydi, gelen: %q", problem)
+	}
+
+	// İzinden önceki gün başlayan 24 saatlik nöbet de izin gününe taşar
+	fullDay := model.DutyRosterSlot{ID: 3, ShiftTemplateID: 3, ShiftTemplate: testShiftTemplate(3, "24 Saat", "08:00", "08:00")}
+	long := newDutyAssignment(roster, &fullDay, testDutyDate("2025-11-09"))
+	if problem := dutyProblem(roster, plan.Leaves, nil, nil, &long); !strings.Contains(problem, "izinde") {
+		t.Fatalf("izne taşan 24 saatlik nöbet reddedilmeliydi, gelen: %q", problem)
+	}
+
+	// Tam gece yarısı biten nöbet ertesi günü kapsamaz
+	evening := model.DutyRosterSlot{ID: 4, ShiftTemplateID: 4, ShiftTemplate: testShiftTemplate(4, "Akşam", "16:00", "00:00")}
+	beforeLeave := newDutyAssignment(roster, &evening, testDutyDate("2025-11-09"))
+	if problem := dutyProblem(roster, plan.Leaves, nil, nil, &beforeLeave); problem != "" {
+		t.Fatalf("gece yarısı biten nöbet izin gününe taşmaz, gelen: %q", problem)
+	}
+	afterLeave := newDutyAssignment(roster, &roster.Slots[0], testDutyDate("2025-11-12"))
+	if problem := dutyProblem(roster, plan.Leaves, nil, nil, &afterLeave); problem != "" {
+		t.Fatalf("izin bitince nöbet yazılabilmeli, gelen: %q", problem)
+	}
+}
+
+// testWeeklySchedule hafta içi her gün 08:00-17:00 çalışılan program
+func testWeeklySchedule(staffID uint) model.StaffSchedule {
+	template := testShiftTemplate(10, "Mesai", "08:00", "17:00")
+	schedule := model.StaffSchedule{
+		StaffID:       staffID,
+		Pattern:       model.SchedulePatternWeekly,
+		CycleDays:     7,
+		EffectiveFrom: testDutyDate("2025-01-01"),
+	}
+	schedule.ID = 100 + staffID
+	for day := 1; day <= 5; day++ {
+		schedule.Entries = append(schedule.Entries, model.StaffScheduleEntry{Day: day, ShiftTemplateID: template.ID, ShiftTemplate: template})
+	}
+	return schedule
+}
+
+func TestDutyRespectsRegularShifts(t *testing.T) {
+	plan := testDutyPlan(5, 8)
+	plan.Roster.MinRestHours = 11
+	plan.Leaves = []model.LeaveRequest{{
+		StaffID:   2,
+		Status:    model.LeaveApproved,
+		StartDate: testDutyDate("2025-11-19"),
+		EndDate:   testDutyDate("2025-11-19"),
+		LeaveType: model.LeaveType{Name: "Yıllık İzin"},
+	}}
+	from, to := plan.Roster.StartDate.AddDate(0, 0, -8), plan.Roster.EndDate.AddDate(0, 0, 8)
+	plan.Shifts = groupShiftsByStaff([]model.StaffSchedule{testWeeklySchedule(1), testWeeklySchedule(2)}, plan.Leaves, from, to)
+
+	// İzinli gündeki vardiya çalışılmaz
+	for _, shift := range plan.Shifts[2] {
+		if shift.Date == "2025-11-19" {
+			t.Fatalf("izinli günün vardiyası kontrole girmemeli: %+v", shift)
+		}
+	}
+	if len(plan.Shifts[1]) != len(plan.Shifts[2])+1 {
+		t.Fatalf("izinli gün dışında iki programın vardiyaları aynı olmalı: %d / %d", len(plan.Shifts[1]), len(plan.Shifts[2]))
+	}
+
+	generateTestRoster(plan)
+	if violations := CheckDutyRoster(plan); len(violations) != 0 {
+		t.Fatalf("üretilen liste kurallara uymuyor: %+v", violations)
+	}
+	assertDutyRest(t, plan)
+
+	roster := plan.Roster
+	monday := testDutyDate("2025-11-10")
+	// Nöbet, başladığı gün ve ertesi günkü mesainin yerine geçer
+	day := newDutyAssignment(roster, &roster.Slots[0], monday)
+	day.StaffID = 1
+	night := newDutyAssignment(roster, &roster.Slots[1], monday)
+	friday := newDutyAssignment(roster, &roster.Slots[1], testDutyDate("2025-11-14"))
+	saturday := newDutyAssignment(roster, &roster.Slots[0], testDutyDate("2025-11-15"))
+	sunday := newDutyAssignment(roster, &roster.Slots[1], testDutyDate("2025-11-16"))
+	for name, duty := range map[string]*model.DutyAssignment{
+		"Pazartesi gündüz": &day, "Pazartesi gece": &night, "Cuma gece": &friday, "Cumartesi gündüz": &saturday, "Pazar gece": &sunday,
+	} {
+		if problem := dutyProblem(roster, nil, plan.Shifts[1], nil, duty); problem != "" {
+			t.Fatalf("%s nöbeti o günün ve ertesi günün mesaisinin yerine geçmeliydi, gelen: %q", name, problem)
+		}
+	}
+
+	// Önceki günün mesaisi yerinde kalır: Pazartesi 17:00'de biten mesaiden 7 saat sonra başlayan nöbet
+	earlySlot := model.DutyRosterSlot{ID: 3, ShiftTemplateID: 3, ShiftTemplate: testShiftTemplate(3, "Sabaha Karşı", "00:00", "08:00")}
+	early := newDutyAssignment(roster, &earlySlot, testDutyDate("2025-11-11"))
+	early.StaffID = 1
+	if problem := dutyProblem(roster, nil, plan.Shifts[1], nil, &early); !strings.Contains(problem, "Dinlenme") {
+		t.Fatalf("önceki günün mesaisinden sonra dinlenmesi yetersiz nöbet reddedilmeliydi, gelen: %q", problem)
+	}
+	// Önceki güne ait gece vardiyası nöbetin içine taşıyorsa çakışmadır
+	overnight := []model.ShiftOccurrence{{
+		Date:       "2025-11-10",
+		ScheduleID: 101,
+		ShiftName:  "Gece Mesaisi",
+		Start:      testDutyDate("2025-11-10").Add(22 * time.Hour),
+		End:        testDutyDate("2025-11-11").Add(6 * time.Hour),
+	}}
+	if problem := dutyProblem(roster, nil, overnight, nil, &early); !strings.Contains(problem, "çakışıyor") {
+		t.Fatalf("önceki günün gece vardiyasıyla çakışan nöbet reddedilmeliydi, gelen: %q", problem)
+	}
+
+	// Elle atama da aynı kurala tabidir
+	if validationErrors := checkDutyAssignment(plan, &early); len(validationErrors) != 1 {
+		t.Fatalf("elle dinlenmesi yetersiz atama reddedilmeliydi: %+v", validationErrors)
+	}
+}
+
+// Herkesin hafta içi mesaisi olduğunda hafta içi gece ve hafta sonu nöbetleri boş kalmamalı
+func TestGenerateDutyAssignmentsFillsNightsAroundWeekdaySchedules(t *testing.T) {
+	plan := testDutyPlan(11, 6)
+	plan.Roster.MinRestHours = 11
+	plan.Roster.Slots = []model.DutyRosterSlot{
+		{ID: 1, ShiftTemplateID: 1, DayType: model.DutyDayWeekday, Headcount: 1, ShiftTemplate: testShiftTemplate(1, "Gece", "20:00", "08:00")},
+		{ID: 2, ShiftTemplateID: 2, DayType: model.DutyDayWeekend, Headcount: 1, ShiftTemplate: testShiftTemplate(2, "24 Saat", "08:00", "08:00")},
+	}
+	var schedules []model.StaffSchedule
+	for _, candidate := range plan.Candidates {
+		schedules = append(schedules, testWeeklySchedule(candidate.StaffID))
+	}
+	from, to := plan.Roster.StartDate.AddDate(0, 0, -8), plan.Roster.EndDate.AddDate(0, 0, 8)
+	plan.Shifts = groupShiftsByStaff(schedules, nil, from, to)
+
+	generateTestRoster(plan)
+	if gaps := buildDutyRosterResponse(plan).Gaps; len(gaps) != 0 {
+		t.Fatalf("nöbetler boş kalmamalıydı: %+v", gaps)
+	}
+	if violations := CheckDutyRoster(plan); len(violations) != 0 {
+		t.Fatalf("üretilen liste kurallara uymuyor: %+v", violations)
+	}
+	assertDutyRest(t, plan)
+}
+
+func TestCheckDutyRosterReportsManualRestAndOverlap(t *testing.T) {
+	plan := testDutyPlan(9, 6)
+	roster := plan.Roster
+
+	first := newDutyAssignment(roster, &roster.Slots[0], testDutyDate("2025-11-03"))
+	first.ID, first.StaffID, first.StaffName = 1, 1, "Personel 1"
+	night := newDutyAssignment(roster, &roster.Slots[1], testDutyDate("2025-11-03"))
+	night.ID, night.StaffID, night.StaffName = 2, 1, "Personel 1"
+	roster.Assignments = []model.DutyAssignment{first, night}
+
+	violations := CheckDutyRoster(plan)
+	if len(violations) != 1 || !strings.Contains(violations[0].Message, "Dinlenme") {
+		t.Fatalf("arka arkaya gündüz ve gece nöbeti tek dinlenme ihlali olarak raporlanmalı: %+v", violations)
+	}
+
+	roster.Assignments[1] = first
+	roster.Assignments[1].ID = 2
+	violations = CheckDutyRoster(plan)
+	if len(violations) != 1 || !strings.Contains(violations[0].Message, "çakışıyor") {
+		t.Fatalf("aynı nöbete iki kez yazılan personel çakışma olarak raporlanmalı: %+v", violations)
+	}
+}
+
+// assertDutyRest personelin nöbetlerinin birbiriyle ve düzenli vardiyalarıyla çakışmadığını, aralarında en az dinlenme olduğunu kontrol eder
+func assertDutyRest(t *testing.T, plan *DutyRosterPlan) {
+	t.Helper()
+
+	minRest := time.Duration(plan.Roster.MinRestHours) * time.Hour
+	spans := make(map[uint][]model.ShiftOccurrence)
+	for _, assignment := range plan.Roster.Assignments {
+		spans[assignment.StaffID] = append(spans[assignment.StaffID], model.ShiftOccurrence{
+			ShiftName: "nöbet " + assignment.ShiftName,
+			Start:     assignment.StartsAt,
+			End:       assignment.EndsAt,
+		})
+	}
+	// Nöbetin yerine geçtiği vardiyalar çalışılmaz
+	displaced := make(map[uint]map[string]bool)
+	for i := range plan.Roster.Assignments {
+		assignment := &plan.Roster.Assignments[i]
+		if displaced[assignment.StaffID] == nil {
+			displaced[assignment.StaffID] = make(map[string]bool)
+		}
+		addDisplacedShiftDays(displaced[assignment.StaffID], assignment)
+	}
+	for staffID, shifts := range plan.Shifts {
+		for _, shift := range shifts {
+			if !displaced[staffID][shift.Date] {
+				spans[staffID] = append(spans[staffID], shift)
+			}
+		}
+	}
+
+	for staffID, list := range spans {
+		sort.Slice(list, func(i, j int) bool { return list[i].Start.Before(list[j].Start) })
+		for i := 1; i < len(list); i++ {
+			previous, current := &list[i-1], &list[i]
+			// İki düzenli vardiya arası çalışma programının kuralıdır
+			if previous.ScheduleID != 0 && current.ScheduleID != 0 {
+				continue
+			}
+			if current.Start.Before(previous.End.Add(minRest)) {
+				t.Fatalf("personel %d: %s ile %s arasında dinlenme yetersiz", staffID, describeOccurrence(previous), describeOccurrence(current))
+			}
+		}
+	}
+}
@@ -1,10 +1,40 @@
 package service
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"hospital-platform/database"
 	"hospital-platform/model"
 	"hospital-platform/repository"
 	"hospital-platform/utils"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+// Başvuru belgesi sınırları
+const (
+	MAX_DOCUMENT_SIZE          = 10 << 20 // 10 MB
+	MAX_DOCUMENTS_PER_HOSPITAL = 20
+)
+
+// allowedDocumentContentTypes içerikten tespit edilen kabul edilen dosya türleri
+var allowedDocumentContentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/jpeg":      true,
+	"image/png":       true,
+}
+
+var (
+	ErrHospitalNotFound       = errors.New("Hastane bulunamadı")
+	ErrApplicationNotEditable = errors.New("Başvuru onaylandıktan sonra bilgiler ve belgeler bu ekrandan değiştirilemez")
+	ErrApplicationNotRejected = errors.New("Sadece reddedilen başvurular tekrar gönderilebilir")
+	ErrDocumentNotFound       = errors.New("Belge bulunamadı")
 )
 
 // HospitalService hastane iş mantığını yönetir
@@ -12,6 +42,7 @@ type HospitalService struct {
 	hospitalRepo *repository.HospitalRepository
 	locationRepo *repository.LocationRepository
 	userRepo     *repository.UserRepository
+	tokenService *TokenService
 }
 
 // NewHospitalService yeni bir hastane servisi oluşturur
@@ -20,38 +51,117 @@ func NewHospitalService() *HospitalService {
 		hospitalRepo: repository.NewHospitalRepository(),
 		locationRepo: repository.NewLocationRepository(),
 		userRepo:     repository.NewUserRepository(),
+		tokenService: NewTokenService(),
 	}
 }
 
 // RegisterHospital hastane ve admin kullanıcı kaydı yapar
-func (s *HospitalService) RegisterHospital(req *model.HospitalRegistrationRequest) (*model.HospitalRegistrationResponse, []model.ValidationError, error) {
+func (s *HospitalService) RegisterHospital(req *model.HospitalRegistrationRequest, client model.ClientInfo) (*model.HospitalRegistrationResponse, []model.ValidationError, error) {
 	// 1. Giriş verilerini doğrula
 	validationErrors := s.validateRegistrationData(req)
 	if len(validationErrors) > 0 {
 		return nil, validationErrors, nil
 	}
 
-	// 2. Admin şifresini hash'le
+	// 2. Admin şifresi varsayılan politikaya uymalı (hastanenin henüz kendi politikası yok)
+	validationErrors, err := NewPasswordPolicyService().ValidatePassword(0, nil, "admin_password", req.AdminPassword)
+	if len(validationErrors) > 0 || err != nil {
+		return nil, validationErrors, err
+	}
+
+	// 3. Admin şifresini hash'le
 	hashedPassword, err := utils.HashPassword(req.AdminPassword)
 	if err != nil {
 		return nil, nil, fmt.Errorf("şifre hash'lenemedi: %v", err)
 	}
 
-	// 3. Hastane ve admin kullanıcıyı oluştur (Repository üzerinden)
+	// 4. Hastane ve admin kullanıcıyı oluştur (Repository üzerinden)
 	response, err := s.hospitalRepo.CreateHospitalWithAdmin(req, hashedPassword)
 	if err != nil {
 		return nil, nil, fmt.Errorf("hastane kaydı başarısız: %v", err)
 	}
 
+	// 5. Admin için token çifti üret
+	tokens, err := s.tokenService.IssueTokenPair(&response.AdminUser, false, client)
+	if err != nil {
+		return nil, nil, fmt.Errorf("token oluşturulamadı: %v", err)
+	}
+	response.Token = tokens.Token
+	response.RefreshToken = tokens.RefreshToken
+	response.ExpiresIn = tokens.ExpiresIn
+
 	return response, nil, nil
 }
 
 // validateRegistrationData hastane kayıt verilerini doğrular
 func (s *HospitalService) validateRegistrationData(req *model.HospitalRegistrationRequest) []model.ValidationError {
+	errors := s.validateHospitalInfo(&model.UpdateHospitalApplicationRequest{
+		HospitalName:  req.HospitalName,
+		TaxID:         req.TaxID,
+		HospitalEmail: req.HospitalEmail,
+		HospitalPhone: req.HospitalPhone,
+		ProvinceID:    req.ProvinceID,
+		DistrictID:    req.DistrictID,
+		AddressDetail: req.AddressDetail,
+	}, 0)
+
+	// Admin TC kimlik numarası benzersizlik kontrolü
+	if existingUser, _ := s.userRepo.GetByTCKN(req.AdminTCKN); existingUser != nil {
+		errors = append(errors, model.ValidationError{
+			Field:   "admin_tc",
+			Message: "Bu TC kimlik numarası zaten kullanılıyor",
+		})
+	}
+
+	// Admin e-posta benzersizlik kontrolü
+	if existingUser, _ := s.userRepo.GetByEmail(req.AdminEmail); existingUser != nil {
+		errors = append(errors, model.ValidationError{
+			Field:   "admin_email",
+			Message: "Bu e-posta adresi zaten kullanılıyor",
+		})
+	}
+
+	// Admin telefon benzersizlik kontrolü
+	if existingUser, _ := s.userRepo.GetByPhone(req.AdminPhone); existingUser != nil {
+		errors = append(errors, model.ValidationError{
+			Field:   "admin_phone",
+			Message: "Bu telefon numarası zaten kullanılıyor",
+		})
+	}
+
+	return errors
+}
+
+// GetHospitalByID ID'ye göre hastane bilgilerini getirir
+func (s *HospitalService) GetHospitalByID(id uint) (*model.Hospital, error) {
+	return s.hospitalRepo.GetByID(id)
+}
+
+// validateHospitalInfo kayıt ve başvuru güncellemesinde hastane bilgilerini doğrular
+// excludeID verilirse benzersizlik kontrollerinde hastanenin kendisi sayılmaz
+func (s *HospitalService) validateHospitalInfo(req *model.UpdateHospitalApplicationRequest, excludeID uint) []model.ValidationError {
 	var errors []model.ValidationError
 
-	// Hastane vergi kimlik numarası benzersizlik kontrolü
-	if existingHospital, _ := s.hospitalRepo.GetByTaxID(req.TaxID); existingHospital != nil {
+	if strings.TrimSpace(req.HospitalName) == "" {
+		errors = append(errors, model.ValidationError{
+			Field:   "hospital_name",
+			Message: "Hastane adı zorunludur",
+		})
+	}
+	if strings.TrimSpace(req.AddressDetail) == "" {
+		errors = append(errors, model.ValidationError{
+			Field:   "address_detail",
+			Message: "Açık adres zorunludur",
+		})
+	}
+
+	// Vergi kimlik numarası 10 hanelidir
+	if len(req.TaxID) != 10 || !isDigits(req.TaxID) {
+		errors = append(errors, model.ValidationError{
+			Field:   "tax_id",
+			Message: "Vergi kimlik numarası 10 haneli olmalıdır",
+		})
+	} else if existingHospital, _ := s.hospitalRepo.GetByTaxID(req.TaxID); existingHospital != nil && existingHospital.ID != excludeID {
 		errors = append(errors, model.ValidationError{
 			Field:   "tax_id",
 			Message: "Bu vergi kimlik numarası zaten kullanılıyor",
@@ -59,7 +169,7 @@ func (s *HospitalService) validateRegistrationData(req *model.HospitalRegistrati
 	}
 
 	// Hastane e-posta benzersizlik kontrolü
-	if existingHospital, _ := s.hospitalRepo.GetByEmail(req.HospitalEmail); existingHospital != nil {
+	if existingHospital, _ := s.hospitalRepo.GetByEmail(req.HospitalEmail); existingHospital != nil && existingHospital.ID != excludeID {
 		errors = append(errors, model.ValidationError{
 			Field:   "hospital_email",
 			Message: "Bu e-posta adresi zaten kullanılıyor",
@@ -67,7 +177,7 @@ func (s *HospitalService) validateRegistrationData(req *model.HospitalRegistrati
 	}
 
 	// Hastane telefon benzersizlik kontrolü
-	if existingHospital, _ := s.hospitalRepo.GetByPhone(req.HospitalPhone); existingHospital != nil {
+	if existingHospital, _ := s.hospitalRepo.GetByPhone(req.HospitalPhone); existingHospital != nil && existingHospital.ID != excludeID {
 		errors = append(errors, model.ValidationError{
 			Field:   "hospital_phone",
 			Message: "Bu telefon numarası zaten kullanılıyor",
@@ -83,34 +193,245 @@ func (s *HospitalService) validateRegistrationData(req *model.HospitalRegistrati
 		})
 	}
 
-	// Admin TC kimlik numarası benzersizlik kontrolü
-	if existingUser, _ := s.userRepo.GetByTCKN(req.AdminTCKN); existingUser != nil {
-		errors = append(errors, model.ValidationError{
-			Field:   "admin_tc",
-			Message: "Bu TC kimlik numarası zaten kullanılıyor",
+	return errors
+}
+
+// ==================== KAYIT BAŞVURUSU ====================
+
+// GetApplication hastanenin başvuru bilgilerini, durumunu ve belgelerini getirir
+func (s *HospitalService) GetApplication(hospitalID uint) (*model.HospitalApplicationResponse, error) {
+	hospital, err := s.hospitalRepo.GetByID(hospitalID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrHospitalNotFound
+		}
+		return nil, fmt.Errorf("hastane getirilemedi: %v", err)
+	}
+
+	documents, err := s.hospitalRepo.ListDocuments(hospitalID)
+	if err != nil {
+		return nil, fmt.Errorf("belgeler getirilemedi: %v", err)
+	}
+
+	return &model.HospitalApplicationResponse{
+		Hospital:    *hospital,
+		Documents:   documents,
+		Editable:    isApplicationEditable(hospital.Status),
+		CanResubmit: hospital.Status == model.HospitalStatusRejected,
+	}, nil
+}
+
+// UpdateApplication onay beklerken veya red sonrası başvurudaki hastane bilgilerini günceller
+func (s *HospitalService) UpdateApplication(hospitalID uint, req *model.UpdateHospitalApplicationRequest) (*model.HospitalApplicationResponse, []model.ValidationError, error) {
+	if _, err := s.getEditableApplication(hospitalID); err != nil {
+		return nil, nil, err
+	}
+
+	if validationErrors := s.validateHospitalInfo(req, hospitalID); len(validationErrors) > 0 {
+		return nil, validationErrors, nil
+	}
+
+	if err := s.hospitalRepo.UpdateApplication(hospitalID, req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrApplicationNotEditable
+		}
+		return nil, nil, fmt.Errorf("başvuru güncellenemedi: %v", err)
+	}
+
+	application, err := s.GetApplication(hospitalID)
+	return application, nil, err
+}
+
+// UploadDocument başvuruya belge ekler
+// Dosya türü uzantıya değil içeriğe bakılarak belirlenir; sadece PDF, JPEG ve PNG kabul edilir
+func (s *HospitalService) UploadDocument(hospitalID, userID uint, docType, fileName string, data []byte) (*model.HospitalDocument, []model.ValidationError, error) {
+	if _, err := s.getEditableApplication(hospitalID); err != nil {
+		return nil, nil, err
+	}
+
+	var validationErrors []model.ValidationError
+	if !isValidDocumentType(docType) {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "type",
+			Message: "Geçersiz belge türü (license, tax_certificate, authorization, other)",
 		})
 	}
 
-	// Admin e-posta benzersizlik kontrolü
-	if existingUser, _ := s.userRepo.GetByEmail(req.AdminEmail); existingUser != nil {
-		errors = append(errors, model.ValidationError{
-			Field:   "admin_email",
-			Message: "Bu e-posta adresi zaten kullanılıyor",
+	contentType := http.DetectContentType(data)
+	switch {
+	case len(data) == 0:
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "file",
+			Message: "Dosya boş",
+		})
+	case len(data) > MAX_DOCUMENT_SIZE:
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "file",
+			Message: fmt.Sprintf("Dosya en fazla %d MB olabilir", MAX_DOCUMENT_SIZE>>20),
+		})
+	case !allowedDocumentContentTypes[contentType]:
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "file",
+			Message: "Sadece PDF, JPEG veya PNG dosyası yüklenebilir",
 		})
 	}
 
-	// Admin telefon benzersizlik kontrolü
-	if existingUser, _ := s.userRepo.GetByPhone(req.AdminPhone); existingUser != nil {
-		errors = append(errors, model.ValidationError{
-			Field:   "admin_phone",
-			Message: "Bu telefon numarası zaten kullanılıyor",
+	count, err := s.hospitalRepo.CountDocuments(hospitalID, "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("belgeler sayılamadı: %v", err)
+	}
+	if count >= MAX_DOCUMENTS_PER_HOSPITAL {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "file",
+			Message: fmt.Sprintf("Başvuruya en fazla %d belge yüklenebilir", MAX_DOCUMENTS_PER_HOSPITAL),
 		})
 	}
+	if len(validationErrors) > 0 {
+		return nil, validationErrors, nil
+	}
 
-	return errors
+	sum := sha256.Sum256(data)
+	document := &model.HospitalDocument{
+		HospitalID:  hospitalID,
+		Type:        docType,
+		FileName:    sanitizeFileName(fileName),
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		SHA256:      hex.EncodeToString(sum[:]),
+		Data:        data,
+		UploadedBy:  userID,
+	}
+	if err := s.hospitalRepo.CreateDocument(document); err != nil {
+		return nil, nil, fmt.Errorf("belge kaydedilemedi: %v", err)
+	}
+
+	fmt.Printf("📎 Başvuru belgesi yüklendi: hastane=%d tür=%s boyut=%d\n", hospitalID, docType, document.Size)
+	return document, nil, nil
 }
 
-// GetHospitalByID ID'ye göre hastane bilgilerini getirir
-func (s *HospitalService) GetHospitalByID(id uint) (*model.Hospital, error) {
-	return s.hospitalRepo.GetByID(id)
+// GetDocument hastaneye ait belgeyi içeriğiyle birlikte getirir
+func (s *HospitalService) GetDocument(hospitalID, documentID uint) (*model.HospitalDocument, error) {
+	document, err := s.hospitalRepo.GetDocument(hospitalID, documentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDocumentNotFound
+		}
+		return nil, fmt.Errorf("belge getirilemedi: %v", err)
+	}
+	return document, nil
+}
+
+// DeleteDocument başvuru düzenlenebilir durumdayken belgeyi siler
+func (s *HospitalService) DeleteDocument(hospitalID, documentID uint) error {
+	if _, err := s.getEditableApplication(hospitalID); err != nil {
+		return err
+	}
+
+	if err := s.hospitalRepo.DeleteDocument(hospitalID, documentID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrDocumentNotFound
+		}
+		return fmt.Errorf("belge silinemedi: %v", err)
+	}
+	return nil
+}
+
+// ResubmitApplication reddedilen başvuruyu düzeltmelerden sonra tekrar incelemeye gönderir
+func (s *HospitalService) ResubmitApplication(hospitalID uint) (*model.HospitalApplicationResponse, []model.ValidationError, error) {
+	hospital, err := s.hospitalRepo.GetByID(hospitalID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrHospitalNotFound
+		}
+		return nil, nil, fmt.Errorf("hastane getirilemedi: %v", err)
+	}
+	if hospital.Status != model.HospitalStatusRejected {
+		return nil, nil, ErrApplicationNotRejected
+	}
+
+	// Ruhsat olmadan inceleme yapılamaz
+	validationErrors, err := s.validateRequiredDocuments(hospitalID)
+	if len(validationErrors) > 0 || err != nil {
+		return nil, validationErrors, err
+	}
+
+	if err := s.hospitalRepo.ResubmitApplication(hospitalID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrApplicationNotRejected
+		}
+		return nil, nil, fmt.Errorf("başvuru gönderilemedi: %v", err)
+	}
+
+	// Middleware hastane durumunu önbellekten okur
+	if err := database.SetHospitalStatus(hospitalID, model.HospitalStatusPending); err != nil {
+		fmt.Printf("⚠️ Hastane durumu önbelleğe yazılamadı: %v\n", err)
+	}
+
+	fmt.Printf("📨 Hastane başvurusu tekrar gönderildi: hastane=%d\n", hospitalID)
+
+	application, err := s.GetApplication(hospitalID)
+	return application, nil, err
+}
+
+// validateRequiredDocuments başvuruda onay için zorunlu belgelerin bulunduğunu kontrol eder
+func (s *HospitalService) validateRequiredDocuments(hospitalID uint) ([]model.ValidationError, error) {
+	count, err := s.hospitalRepo.CountDocuments(hospitalID, model.DocumentTypeLicense)
+	if err != nil {
+		return nil, fmt.Errorf("belgeler sayılamadı: %v", err)
+	}
+	if count == 0 {
+		return []model.ValidationError{{
+			Field:   "documents",
+			Message: "Faaliyet izin belgesi (license) yüklenmeden başvuru incelenemez",
+		}}, nil
+	}
+	return nil, nil
+}
+
+// getEditableApplication hastaneyi getirir, başvuru düzenlenebilir durumda değilse hata döner
+func (s *HospitalService) getEditableApplication(hospitalID uint) (*model.Hospital, error) {
+	hospital, err := s.hospitalRepo.GetByID(hospitalID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrHospitalNotFound
+		}
+		return nil, fmt.Errorf("hastane getirilemedi: %v", err)
+	}
+	if !isApplicationEditable(hospital.Status) {
+		return nil, ErrApplicationNotEditable
+	}
+	return hospital, nil
+}
+
+// isApplicationEditable başvuru bilgilerinin ve belgelerinin değiştirilebildiği durumlar
+func isApplicationEditable(status string) bool {
+	return status == model.HospitalStatusPending || status == model.HospitalStatusRejected
+}
+
+// isValidDocumentType belge türünün tanımlı olup olmadığını kontrol eder
+func isValidDocumentType(docType string) bool {
+	for _, valid := range model.ValidDocumentTypes {
+		if docType == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// sanitizeFileName dosya adından dizin kısmını ve kontrol karakterlerini atar
+func sanitizeFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	if name == "" || name == "." || name == "/" {
+		return "belge"
+	}
+	if len(name) > 200 {
+		name = name[:200]
+	}
+	return name
 }
@@ -0,0 +1,479 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"hospital-platform/config"
+	"hospital-platform/model"
+	"hospital-platform/repository"
+	"hospital-platform/utils"
+	"net/url"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// LINK_PURPOSE_INVITATION davet linklerinin imza etiketi
+const LINK_PURPOSE_INVITATION = "invitation"
+
+var (
+	ErrInvitationNotFound   = errors.New("Davet bulunamadı")
+	ErrInvitationNotPending = errors.New("Bu işlem sadece kabul edilmemiş ve iptal edilmemiş davetler için yapılabilir")
+	ErrInvalidInvitation    = errors.New("Davet linki geçersiz, süresi dolmuş veya daha önce kullanılmış")
+	ErrInvitationRoleGone   = errors.New("Davetteki rol artık tanımlı değil, yöneticinizden yeni davet isteyin")
+)
+
+// InvitationService - Davet ile alt kullanıcı ekleme iş mantığı
+// Yönetici şifre belirlemez; davetli kişi imzalı, tek kullanımlık linkle kendi şifresini belirler ve telefonunu doğrular
+type InvitationService struct {
+	invitationRepo      *repository.InvitationRepository
+	userRepo            *repository.UserRepository
+	hospitalRepo        *repository.HospitalRepository
+	roleRepo            *repository.RoleRepository
+	notificationService *NotificationService
+	verificationService *VerificationService
+}
+
+// NewInvitationService yeni bir davet servisi oluşturur
+func NewInvitationService() *InvitationService {
+	return &InvitationService{
+		invitationRepo:      repository.NewInvitationRepository(),
+		userRepo:            repository.NewUserRepository(),
+		hospitalRepo:        repository.NewHospitalRepository(),
+		roleRepo:            repository.NewRoleRepository(),
+		notificationService: NewNotificationService(),
+		verificationService: NewVerificationService(),
+	}
+}
+
+// getInvitationTTL davet linkinin geçerlilik süresini döndürür (varsayılan 72 saat)
+func getInvitationTTL() time.Duration {
+	return config.GetEnvDuration("INVITATION_TTL", 72*time.Hour)
+}
+
+// ==================== YÖNETİCİ İŞLEMLERİ ====================
+
+// CreateInvitation davet oluşturur ve davet linkini e-posta ile gönderir
+func (s *InvitationService) CreateInvitation(req *model.CreateInvitationRequest, hospitalID, invitedBy uint) (*model.InvitationResponse, []model.ValidationError, error) {
+	inviter, err := NewMembershipService().GetUserInHospital(invitedBy, hospitalID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("davet eden kullanıcı bulunamadı: %v", err)
+	}
+
+	req.Email = strings.TrimSpace(req.Email)
+	req.Phone = strings.TrimSpace(req.Phone)
+
+	// 1. İletişim bilgileri kullanımda olmamalı (kullanıcılar ve bekleyen davetler)
+	validationErrors, err := s.validateContact(req.Email, req.Phone, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 2. Rol ve poliklinik kontrolü - kimse kendi yetkilerinden fazlasını veremez
+	roleErrors, err := validateRoleAssignment(inviter, req.Role, req.PolyclinicID)
+	if err != nil {
+		return nil, nil, err
+	}
+	validationErrors = append(validationErrors, roleErrors...)
+	if len(validationErrors) > 0 {
+		return nil, validationErrors, nil
+	}
+
+	// 3. Daveti oluştur ve gönder
+	invitation := &model.Invitation{
+		HospitalID:   inviter.HospitalID,
+		FirstName:    req.FirstName,
+		LastName:     req.LastName,
+		Email:        req.Email,
+		Phone:        req.Phone,
+		Role:         req.Role,
+		PolyclinicID: req.PolyclinicID,
+		Lang:         ResolveLanguage(req.Lang, ""),
+		Status:       model.InvitationPending,
+		InvitedBy:    inviter.ID,
+	}
+
+	token, err := s.renewToken(invitation)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.invitationRepo.Create(invitation); err != nil {
+		return nil, nil, fmt.Errorf("davet oluşturulamadı: %v", err)
+	}
+
+	// Gönderilemeyen davet silinir - kimsenin almadığı link bekleyen davet olarak kalıp tekrar davet etmeyi engellemez
+	// Bildirim gittikten sonraki kayıt hatasında davet silinmez, gönderilen link geçerli kalır
+	if err := s.send(invitation, inviter, token); err != nil {
+		if invitation.SendCount == 0 {
+			if deleteErr := s.invitationRepo.Delete(invitation); deleteErr != nil {
+				fmt.Printf("❌ Gönderilemeyen davet silinemedi: %d: %v\n", invitation.ID, deleteErr)
+			}
+		}
+		return nil, nil, err
+	}
+
+	return toInvitationResponse(invitation), nil, nil
+}
+
+// ListInvitations hastanenin davetlerini listeler (varsayılan: bekleyenler)
+func (s *InvitationService) ListInvitations(hospitalID uint, status string) ([]model.InvitationResponse, error) {
+	invitations, err := s.invitationRepo.GetByHospitalID(hospitalID, status)
+	if err != nil {
+		return nil, fmt.Errorf("davetler getirilemedi: %v", err)
+	}
+
+	responses := make([]model.InvitationResponse, 0, len(invitations))
+	for i := range invitations {
+		responses = append(responses, *toInvitationResponse(&invitations[i]))
+	}
+	return responses, nil
+}
+
+// ResendInvitation yeni link üretip daveti tekrar gönderir; süre yeniden başlar, eski link geçersiz olur
+func (s *InvitationService) ResendInvitation(hospitalID, invitationID, actorID uint) (*model.InvitationResponse, error) {
+	invitation, actor, err := s.getManageableInvitation(hospitalID, invitationID, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bu arada aynı iletişim bilgileriyle kullanıcı oluşmuş olabilir
+	validationErrors, err := s.validateContact(invitation.Email, invitation.Phone, invitation.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(validationErrors) > 0 {
+		return nil, fmt.Errorf("%s", validationErrors[0].Message)
+	}
+
+	token, err := s.renewToken(invitation)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.invitationRepo.Save(invitation); err != nil {
+		return nil, fmt.Errorf("davet güncellenemedi: %v", err)
+	}
+
+	if err := s.send(invitation, actor, token); err != nil {
+		return nil, err
+	}
+	return toInvitationResponse(invitation), nil
+}
+
+// RevokeInvitation bekleyen daveti iptal eder - link artık kullanılamaz
+func (s *InvitationService) RevokeInvitation(hospitalID, invitationID, actorID uint) error {
+	invitation, _, err := s.getManageableInvitation(hospitalID, invitationID, actorID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	invitation.Status = model.InvitationRevoked
+	invitation.RevokedBy = &actorID
+	invitation.RevokedAt = &now
+
+	if err := s.invitationRepo.Save(invitation); err != nil {
+		return fmt.Errorf("davet iptal edilemedi: %v", err)
+	}
+	return nil
+}
+
+// ==================== DAVETLİ İŞLEMLERİ ====================
+
+// GetDetails davet linkini doğrular ve kabul ekranı için bilgileri döndürür
+func (s *InvitationService) GetDetails(token string) (*model.InvitationDetailsResponse, error) {
+	invitation, err := s.getValidInvitation(token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.InvitationDetailsResponse{
+		FirstName:    invitation.FirstName,
+		LastName:     invitation.LastName,
+		Email:        invitation.Email,
+		Phone:        maskPhone(invitation.Phone),
+		HospitalName: invitation.Hospital.Name,
+		Role:         invitation.Role,
+		ExpiresAt:    invitation.ExpiresAt,
+	}, nil
+}
+
+// SendPhoneCode davetteki telefona doğrulama kodu gönderir
+func (s *InvitationService) SendPhoneCode(token, lang string) error {
+	invitation, err := s.getValidInvitation(token)
+	if err != nil {
+		return err
+	}
+
+	if lang == "" {
+		lang = invitation.Lang
+	}
+	return s.verificationService.SendCode(invitationSubject(invitation), utils.CHANNEL_SMS, invitation.Phone, lang)
+}
+
+// AcceptInvitation daveti kabul eder: şifre politikası ve telefon kodu doğrulanır, kullanıcı oluşturulur
+// Link e-postaya gönderildiği için e-posta, SMS koduyla da telefon doğrulanmış sayılır
+// Başarılı olursa kullanıcı doğrudan giriş yapar (MFA gerekiyorsa challenge döner)
+func (s *InvitationService) AcceptInvitation(req *model.AcceptInvitationRequest, client model.ClientInfo) (*model.TokenResponse, *model.LoginChallengeResponse, []model.ValidationError, error) {
+	invitation, err := s.getValidInvitation(req.Token)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// 1. Davetteki rol hâlâ tanımlı olmalı
+	if _, err := s.roleRepo.GetByName(invitation.HospitalID, invitation.Role); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, nil, ErrInvitationRoleGone
+		}
+		return nil, nil, nil, fmt.Errorf("rol getirilemedi: %v", err)
+	}
+
+	// 2. Giriş verilerini doğrula
+	validationErrors, err := s.validateAcceptance(invitation, req)
+	if len(validationErrors) > 0 || err != nil {
+		return nil, nil, validationErrors, err
+	}
+
+	// 3. Telefon doğrulama kodu
+	subject := invitationSubject(invitation)
+	if err := s.verificationService.CheckCode(subject, req.PhoneCode); err != nil {
+		return nil, nil, nil, err
+	}
+
+	// 4. Kullanıcıyı oluştur ve daveti tek seferde kapat
+	hashedPassword, err := utils.HashPassword(req.Password)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	now := time.Now()
+	user := &model.User{
+		HospitalID:        invitation.HospitalID,
+		FirstName:         invitation.FirstName,
+		LastName:          invitation.LastName,
+		TCKN:              req.TCKN,
+		Email:             invitation.Email,
+		Phone:             invitation.Phone,
+		Password:          hashedPassword,
+		Role:              invitation.Role,
+		PolyclinicID:      invitation.PolyclinicID,
+		CreatedBy:         &invitation.InvitedBy,
+		Status:            model.UserStatusActive,
+		PasswordChangedAt: &now,
+		EmailVerifiedAt:   &now,
+		PhoneVerifiedAt:   &now,
+	}
+
+	if err := s.invitationRepo.Accept(invitation, user); err != nil {
+		if errors.Is(err, repository.ErrInvitationAlreadyUsed) {
+			return nil, nil, nil, ErrInvalidInvitation
+		}
+		return nil, nil, nil, fmt.Errorf("kullanıcı oluşturulamadı: %v", err)
+	}
+	s.verificationService.Consume(subject)
+
+	fmt.Printf("✅ Davet kabul edildi: davet %d -> kullanıcı %d\n", invitation.ID, user.ID)
+
+	tokens, challenge, err := completeLogin(user, client)
+	return tokens, challenge, nil, err
+}
+
+// ==================== HELPER METHODS ====================
+
+// renewToken davet için yeni imzalı link token'ı üretir, hash'ini ve süresini davete yazar
+func (s *InvitationService) renewToken(invitation *model.Invitation) (string, error) {
+	nonce, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return "", fmt.Errorf("davet linki üretilemedi: %v", err)
+	}
+
+	token := utils.SignLinkToken(LINK_PURPOSE_INVITATION, nonce)
+	invitation.TokenHash = utils.HashToken(token)
+	invitation.ExpiresAt = time.Now().Add(getInvitationTTL())
+	invitation.Status = model.InvitationPending
+	return token, nil
+}
+
+// send davet linkini davetlinin e-posta adresine gönderir ve gönderim sayacını günceller
+func (s *InvitationService) send(invitation *model.Invitation, inviter *model.User, token string) error {
+	hospital, err := s.hospitalRepo.GetByID(invitation.HospitalID)
+	if err != nil {
+		return fmt.Errorf("hastane bulunamadı: %v", err)
+	}
+
+	link := config.GetEnv("INVITATION_BASE_URL", "http://localhost:3000/invite") + "?token=" + url.QueryEscape(token)
+
+	err = s.notificationService.Send(utils.CHANNEL_EMAIL, invitation.Email, TEMPLATE_INVITATION, invitation.Lang, map[string]interface{}{
+		"FirstName":    invitation.FirstName,
+		"InviterName":  inviter.FirstName + " " + inviter.LastName,
+		"HospitalName": hospital.Name,
+		"Role":         invitation.Role,
+		"Link":         link,
+		"Hours":        int(getInvitationTTL().Hours()),
+	})
+	if err != nil {
+		return fmt.Errorf("davet gönderilemedi: %v", err)
+	}
+
+	now := time.Now()
+	invitation.SendCount++
+	invitation.LastSentAt = &now
+	return s.invitationRepo.Save(invitation)
+}
+
+// getValidInvitation link token'ını doğrular; imza, tek kullanım, iptal ve süre kontrol edilir
+func (s *InvitationService) getValidInvitation(token string) (*model.Invitation, error) {
+	if _, ok := utils.VerifyLinkToken(LINK_PURPOSE_INVITATION, token); !ok {
+		return nil, ErrInvalidInvitation
+	}
+
+	invitation, err := s.invitationRepo.GetByTokenHash(utils.HashToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidInvitation
+		}
+		return nil, fmt.Errorf("davet getirilemedi: %v", err)
+	}
+
+	if invitation.EffectiveStatus() != model.InvitationPending {
+		return nil, ErrInvalidInvitation
+	}
+	return invitation, nil
+}
+
+// getManageableInvitation yönetici işlemleri için daveti getirir
+// Davet aynı hastaneye ait, bekleyen durumda olmalı ve rolü işlemi yapanın yetkilerini aşmamalı
+func (s *InvitationService) getManageableInvitation(hospitalID, invitationID, actorID uint) (*model.Invitation, *model.User, error) {
+	invitation, err := s.invitationRepo.GetByID(invitationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrInvitationNotFound
+		}
+		return nil, nil, fmt.Errorf("davet getirilemedi: %v", err)
+	}
+	if invitation.HospitalID != hospitalID {
+		return nil, nil, ErrInvitationNotFound
+	}
+	if invitation.Status != model.InvitationPending {
+		return nil, nil, ErrInvitationNotPending
+	}
+
+	actor, err := NewMembershipService().GetUserInHospital(actorID, hospitalID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("işlemi yapan kullanıcı bulunamadı: %v", err)
+	}
+	if err := NewRoleService().CheckAssignableRole(hospitalID, actor.Role, invitation.Role); errors.Is(err, ErrPermissionEscalation) {
+		return nil, nil, err
+	}
+
+	return invitation, actor, nil
+}
+
+// validateContact e-posta ve telefonun kullanıcılar ve diğer bekleyen davetler tarafından kullanılmadığını kontrol eder
+func (s *InvitationService) validateContact(email, phone string, excludeInvitationID uint) ([]model.ValidationError, error) {
+	var validationErrors []model.ValidationError
+
+	if existing, _ := s.userRepo.GetByEmail(email); existing != nil {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "email",
+			Message: "Bu e-posta adresi zaten kullanılıyor",
+		})
+	}
+	if existing, _ := s.userRepo.GetByPhone(phone); existing != nil {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "phone",
+			Message: "Bu telefon numarası zaten kullanılıyor",
+		})
+	}
+
+	pending, err := s.invitationRepo.HasActiveForContact(email, phone, excludeInvitationID)
+	if err != nil {
+		return nil, fmt.Errorf("davetler kontrol edilemedi: %v", err)
+	}
+	if pending {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "email",
+			Message: "Bu e-posta adresi veya telefon için bekleyen bir davet var",
+		})
+	}
+
+	return validationErrors, nil
+}
+
+// validateAcceptance kabul verilerini doğrular: TC kimlik, iletişim bilgileri ve şifre politikası
+func (s *InvitationService) validateAcceptance(invitation *model.Invitation, req *model.AcceptInvitationRequest) ([]model.ValidationError, error) {
+	var validationErrors []model.ValidationError
+
+	if len(req.TCKN) != 11 {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "tc",
+			Message: "TC kimlik numarası 11 haneli olmalıdır",
+		})
+	} else if existing, _ := s.userRepo.GetByTCKN(req.TCKN); existing != nil {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "tc",
+			Message: "Bu TC kimlik numarası zaten kullanılıyor",
+		})
+	}
+
+	// Davet gönderildikten sonra aynı bilgilerle kullanıcı oluşmuş olabilir
+	if existing, _ := s.userRepo.GetByEmail(invitation.Email); existing != nil {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "email",
+			Message: "Bu e-posta adresi zaten kullanılıyor",
+		})
+	}
+	if existing, _ := s.userRepo.GetByPhone(invitation.Phone); existing != nil {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "phone",
+			Message: "Bu telefon numarası zaten kullanılıyor",
+		})
+	}
+
+	if req.Password != req.ConfirmPassword {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "confirm_password",
+			Message: "Şifreler eşleşmiyor",
+		})
+		return validationErrors, nil
+	}
+
+	policyErrors, err := NewPasswordPolicyService().ValidatePassword(invitation.HospitalID, nil, "password", req.Password)
+	if err != nil {
+		return nil, err
+	}
+	return append(validationErrors, policyErrors...), nil
+}
+
+// invitationSubject davetin telefon doğrulama kodu için konu anahtarı
+func invitationSubject(invitation *model.Invitation) string {
+	return fmt.Sprintf("invite:%d", invitation.ID)
+}
+
+// maskPhone telefon numarasının sadece son 4 hanesini gösterir
+func maskPhone(phone string) string {
+	runes := []rune(phone)
+	if len(runes) <= 4 {
+		return phone
+	}
+	return strings.Repeat("*", len(runes)-4) + string(runes[len(runes)-4:])
+}
+
+// toInvitationResponse daveti liste formatına çevirir
+func toInvitationResponse(invitation *model.Invitation) *model.InvitationResponse {
+	return &model.InvitationResponse{
+		ID:           invitation.ID,
+		FirstName:    invitation.FirstName,
+		LastName:     invitation.LastName,
+		Email:        invitation.Email,
+		Phone:        invitation.Phone,
+		Role:         invitation.Role,
+		PolyclinicID: invitation.PolyclinicID,
+		Status:       invitation.EffectiveStatus(),
+		ExpiresAt:    invitation.ExpiresAt,
+		SendCount:    invitation.SendCount,
+		LastSentAt:   invitation.LastSentAt,
+		InvitedBy:    invitation.InvitedBy,
+		CreatedAt:    invitation.CreatedAt,
+	}
+}
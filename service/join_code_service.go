@@ -0,0 +1,326 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"hospital-platform/model"
+	"hospital-platform/repository"
+	"hospital-platform/utils"
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	joinCodeLength         = 12  // Gruplanmadan önceki karakter sayısı (XXXX-XXXX-XXXX)
+	joinCodeDefaultTTLDays = 30  // Süre belirtilmezse
+	joinCodeMaxTTLDays     = 365 // En uzun geçerlilik
+	joinCodeMaxUsesLimit   = 10000
+	joinCodeGroupSize      = 4
+	joinCodeHintLength     = 4
+	emailDomainMaxLength   = 253
+	emailDomainDefaultRole = model.RoleCalisan
+)
+
+var (
+	ErrJoinCodeNotFound    = errors.New("Katılım kodu bulunamadı")
+	ErrEmailDomainNotFound = errors.New("Alan adı bulunamadı")
+)
+
+// emailDomainPattern alan adı formatı: harf/rakam/tire etiketleri, en az bir nokta
+var emailDomainPattern = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]*[a-z0-9])?\.)+[a-z]{2,}$`)
+
+// publicEmailDomains herkesin hesap açabildiği servisler - bunlar hastaneye bağlanamaz
+var publicEmailDomains = map[string]bool{
+	"gmail.com":      true,
+	"googlemail.com": true,
+	"hotmail.com":    true,
+	"hotmail.com.tr": true,
+	"outlook.com":    true,
+	"live.com":       true,
+	"msn.com":        true,
+	"yahoo.com":      true,
+	"icloud.com":     true,
+	"me.com":         true,
+	"yandex.com":     true,
+	"yandex.com.tr":  true,
+	"mynet.com":      true,
+	"proton.me":      true,
+	"protonmail.com": true,
+}
+
+// JoinCodeService - Hastanenin kayıt yöntemlerini yönetir: katılım kodları ve izinli e-posta alan adları
+// Başvuranın rolü her zaman buradan (sunucu tarafında) belirlenir
+type JoinCodeService struct {
+	joinCodeRepo *repository.JoinCodeRepository
+}
+
+// NewJoinCodeService yeni bir katılım kodu servisi oluşturur
+func NewJoinCodeService() *JoinCodeService {
+	return &JoinCodeService{
+		joinCodeRepo: repository.NewJoinCodeRepository(),
+	}
+}
+
+// ==================== KATILIM KODLARI ====================
+
+// CreateJoinCode yeni katılım kodu üretir; düz kod sadece bu yanıtta döner, veritabanında hash'i saklanır
+func (s *JoinCodeService) CreateJoinCode(req *model.CreateJoinCodeRequest, hospitalID, actorID uint) (*model.JoinCodeResponse, []model.ValidationError, error) {
+	actor, err := NewMembershipService().GetUserInHospital(actorID, hospitalID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("kullanıcı bulunamadı: %v", err)
+	}
+
+	validationErrors, err := validateRoleAssignment(actor, req.Role, req.PolyclinicID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if req.MaxUses < 0 || req.MaxUses > joinCodeMaxUsesLimit {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "max_uses",
+			Message: fmt.Sprintf("Kullanım limiti 0 (sınırsız) ile %d arasında olmalıdır", joinCodeMaxUsesLimit),
+		})
+	}
+	if req.ExpiresInDays == 0 {
+		req.ExpiresInDays = joinCodeDefaultTTLDays
+	}
+	if req.ExpiresInDays < 1 || req.ExpiresInDays > joinCodeMaxTTLDays {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "expires_in_days",
+			Message: fmt.Sprintf("Geçerlilik süresi 1 ile %d gün arasında olmalıdır", joinCodeMaxTTLDays),
+		})
+	}
+	if len(validationErrors) > 0 {
+		return nil, validationErrors, nil
+	}
+
+	raw, err := utils.GenerateUnambiguousCode(joinCodeLength)
+	if err != nil {
+		return nil, nil, fmt.Errorf("katılım kodu üretilemedi: %v", err)
+	}
+
+	code := &model.JoinCode{
+		HospitalID:   actor.HospitalID,
+		CodeHash:     utils.HashToken(raw),
+		CodeHint:     raw[len(raw)-joinCodeHintLength:],
+		Role:         req.Role,
+		PolyclinicID: req.PolyclinicID,
+		MaxUses:      req.MaxUses,
+		ExpiresAt:    time.Now().AddDate(0, 0, req.ExpiresInDays),
+		CreatedBy:    actor.ID,
+	}
+	if err := s.joinCodeRepo.Create(code); err != nil {
+		return nil, nil, fmt.Errorf("katılım kodu kaydedilemedi: %v", err)
+	}
+
+	response := toJoinCodeResponse(code)
+	response.Code = formatJoinCode(raw)
+	return response, nil, nil
+}
+
+// ListJoinCodes hastanenin katılım kodlarını listeler (kodların kendisi gösterilmez)
+func (s *JoinCodeService) ListJoinCodes(hospitalID uint) ([]model.JoinCodeResponse, error) {
+	codes, err := s.joinCodeRepo.GetByHospitalID(hospitalID)
+	if err != nil {
+		return nil, fmt.Errorf("katılım kodları getirilemedi: %v", err)
+	}
+
+	responses := make([]model.JoinCodeResponse, 0, len(codes))
+	for i := range codes {
+		responses = append(responses, *toJoinCodeResponse(&codes[i]))
+	}
+	return responses, nil
+}
+
+// RevokeJoinCode katılım kodunu iptal eder - bekleyen başvurular etkilenmez
+func (s *JoinCodeService) RevokeJoinCode(hospitalID, codeID, actorID uint) error {
+	code, err := s.joinCodeRepo.GetByID(codeID)
+	if err != nil || code.HospitalID != hospitalID {
+		if err == nil || errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrJoinCodeNotFound
+		}
+		return fmt.Errorf("katılım kodu getirilemedi: %v", err)
+	}
+
+	if err := s.checkActorCanManage(actorID, hospitalID, code.Role); err != nil {
+		return err
+	}
+
+	if code.RevokedAt != nil {
+		return nil
+	}
+	now := time.Now()
+	code.RevokedAt = &now
+	if err := s.joinCodeRepo.Save(code); err != nil {
+		return fmt.Errorf("katılım kodu iptal edilemedi: %v", err)
+	}
+	return nil
+}
+
+// ResolveJoinCode başvuruda girilen kodu bulur; sadece aktif kodlar döner
+func (s *JoinCodeService) ResolveJoinCode(input string) (*model.JoinCode, error) {
+	code, err := s.joinCodeRepo.GetByCodeHash(utils.HashToken(normalizeJoinCode(input)))
+	if err != nil {
+		return nil, err
+	}
+	if code.EffectiveStatus() != model.JoinCodeActive {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return code, nil
+}
+
+// ==================== İZİNLİ E-POSTA ALAN ADLARI ====================
+
+// AddEmailDomain hastaneye izinli e-posta alan adı ekler
+// Bir alan adı tek hastaneye ait olabilir; herkese açık e-posta servisleri eklenemez
+func (s *JoinCodeService) AddEmailDomain(req *model.CreateEmailDomainRequest, hospitalID, actorID uint) (*model.HospitalEmailDomain, []model.ValidationError, error) {
+	actor, err := NewMembershipService().GetUserInHospital(actorID, hospitalID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("kullanıcı bulunamadı: %v", err)
+	}
+
+	domain := normalizeEmailDomain(req.Domain)
+	if req.Role == "" {
+		req.Role = emailDomainDefaultRole
+	}
+
+	validationErrors, err := validateRoleAssignment(actor, req.Role, req.PolyclinicID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch {
+	case len(domain) > emailDomainMaxLength || !emailDomainPattern.MatchString(domain):
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "domain",
+			Message: "Geçerli bir alan adı girin (ör. hastane.gov.tr)",
+		})
+	case publicEmailDomains[domain]:
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "domain",
+			Message: "Herkese açık e-posta servisleri kayıt için kullanılamaz",
+		})
+	default:
+		existing, err := s.joinCodeRepo.GetEmailDomain(domain)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, fmt.Errorf("alan adı kontrol edilemedi: %v", err)
+		}
+		if existing != nil {
+			message := "Bu alan adı başka bir hastane tarafından kullanılıyor"
+			if existing.HospitalID == actor.HospitalID {
+				message = "Bu alan adı zaten ekli"
+			}
+			validationErrors = append(validationErrors, model.ValidationError{
+				Field:   "domain",
+				Message: message,
+			})
+		}
+	}
+	if len(validationErrors) > 0 {
+		return nil, validationErrors, nil
+	}
+
+	emailDomain := &model.HospitalEmailDomain{
+		HospitalID:   actor.HospitalID,
+		Domain:       domain,
+		Role:         req.Role,
+		PolyclinicID: req.PolyclinicID,
+		CreatedBy:    actor.ID,
+	}
+	if err := s.joinCodeRepo.CreateEmailDomain(emailDomain); err != nil {
+		return nil, nil, fmt.Errorf("alan adı eklenemedi: %v", err)
+	}
+	return emailDomain, nil, nil
+}
+
+// ListEmailDomains hastanenin izinli alan adlarını listeler
+func (s *JoinCodeService) ListEmailDomains(hospitalID uint) ([]model.HospitalEmailDomain, error) {
+	domains, err := s.joinCodeRepo.GetEmailDomainsByHospitalID(hospitalID)
+	if err != nil {
+		return nil, fmt.Errorf("alan adları getirilemedi: %v", err)
+	}
+	return domains, nil
+}
+
+// RemoveEmailDomain izinli alan adını kaldırır
+func (s *JoinCodeService) RemoveEmailDomain(hospitalID, domainID, actorID uint) error {
+	emailDomain, err := s.joinCodeRepo.GetEmailDomainByID(domainID)
+	if err != nil || emailDomain.HospitalID != hospitalID {
+		if err == nil || errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrEmailDomainNotFound
+		}
+		return fmt.Errorf("alan adı getirilemedi: %v", err)
+	}
+
+	if err := s.checkActorCanManage(actorID, hospitalID, emailDomain.Role); err != nil {
+		return err
+	}
+
+	if err := s.joinCodeRepo.DeleteEmailDomain(emailDomain); err != nil {
+		return fmt.Errorf("alan adı silinemedi: %v", err)
+	}
+	return nil
+}
+
+// ResolveEmailDomain e-posta adresinin alan adına kayıtlı hastaneyi bulur
+func (s *JoinCodeService) ResolveEmailDomain(email string) (*model.HospitalEmailDomain, error) {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return s.joinCodeRepo.GetEmailDomain(normalizeEmailDomain(email[at+1:]))
+}
+
+// ==================== HELPER METHODS ====================
+
+// checkActorCanManage kodu/alan adını yönetenin, atadığı rolü verebilecek yetkide olduğunu kontrol eder
+func (s *JoinCodeService) checkActorCanManage(actorID, hospitalID uint, role string) error {
+	actor, err := NewMembershipService().GetUserInHospital(actorID, hospitalID)
+	if err != nil {
+		return fmt.Errorf("kullanıcı bulunamadı: %v", err)
+	}
+	if err := NewRoleService().CheckAssignableRole(hospitalID, actor.Role, role); errors.Is(err, ErrPermissionEscalation) {
+		return err
+	}
+	return nil
+}
+
+// normalizeJoinCode kullanıcı girişindeki tire/boşlukları kaldırır ve büyük harfe çevirir
+func normalizeJoinCode(input string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(input) {
+		if !strings.ContainsRune("- ", r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// formatJoinCode kodu okunabilirlik için 4'lü gruplara ayırır
+func formatJoinCode(raw string) string {
+	var groups []string
+	for i := 0; i < len(raw); i += joinCodeGroupSize {
+		end := i + joinCodeGroupSize
+		if end > len(raw) {
+			end = len(raw)
+		}
+		groups = append(groups, raw[i:end])
+	}
+	return strings.Join(groups, "-")
+}
+
+// normalizeEmailDomain alan adını küçük harfe çevirir, baştaki @ ve sondaki noktayı temizler
+func normalizeEmailDomain(domain string) string {
+	return strings.Trim(strings.ToLower(strings.TrimSpace(domain)), "@.")
+}
+
+// toJoinCodeResponse katılım kodunu hesaplanan durumuyla döndürür
+func toJoinCodeResponse(code *model.JoinCode) *model.JoinCodeResponse {
+	return &model.JoinCodeResponse{
+		JoinCode: *code,
+		Status:   code.EffectiveStatus(),
+	}
+}
@@ -0,0 +1,597 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"hospital-platform/model"
+	"hospital-platform/repository"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrLeaveTypeNotFound       = errors.New("İzin türü bulunamadı")
+	ErrLeaveTypeInUse          = errors.New("Bu izin türünde bekleyen veya onaylanmış talepler var")
+	ErrLeaveRequestNotFound    = errors.New("İzin talebi bulunamadı")
+	ErrLeaveNotPending         = errors.New("Bu izin talebi zaten incelenmiş")
+	ErrLeaveNotCancellable     = errors.New("Sadece bekleyen veya onaylanmış izinler iptal edilebilir")
+	ErrLeaveCancelForbidden    = errors.New("Onaylanmış izni sadece izin onay yetkisi olan kullanıcılar iptal edebilir")
+	ErrLeaveStaffNotFound      = errors.New("Personel bulunamadı")
+	ErrLeavePolyclinicNotFound = errors.New("Poliklinik bulunamadı")
+	ErrLeaveRangeInvalid       = errors.New("Geçersiz tarih aralığı")
+)
+
+const (
+	MAX_LEAVE_REQUEST_DAYS  = 180 // Tek talep en fazla ~6 ay (takvim günü)
+	MAX_LEAVE_ANNUAL_DAYS   = 365
+	leaveTypeNameMaxLength  = 100
+	leaveNoteMaxLength      = 500
+	leaveRejectReasonMaxLen = 500
+)
+
+// LeaveService - İzin türleri, yıllık haklar, izin talepleri ve devamsızlık takvimi
+// Talepler personel adına staff:write yetkisiyle girilir, leaves:approve yetkisi olan kullanıcılar onaylar
+// Onaylı izinler vardiya takviminde ve personel listesinde personeli müsait olmayan olarak işaretler
+type LeaveService struct {
+	leaveRepo      *repository.LeaveRepository
+	scheduleRepo   *repository.ScheduleRepository
+	staffRepo      *repository.StaffRepository
+	polyclinicRepo *repository.PolyclinicRepository
+}
+
+// NewLeaveService yeni bir izin servisi oluşturur
+func NewLeaveService() *LeaveService {
+	return &LeaveService{
+		leaveRepo:      repository.NewLeaveRepository(),
+		scheduleRepo:   repository.NewScheduleRepository(),
+		staffRepo:      repository.NewStaffRepository(),
+		polyclinicRepo: repository.NewPolyclinicRepository(),
+	}
+}
+
+// ==================== İZİN TÜRLERİ ====================
+
+// GetTypes hastanenin izin türlerini döndürür; hiç tanımlanmamışsa varsayılan türleri oluşturur
+func (s *LeaveService) GetTypes(hospitalID uint) ([]model.LeaveType, error) {
+	if err := s.leaveRepo.EnsureDefaultTypes(hospitalID); err != nil {
+		return nil, fmt.Errorf("varsayılan izin türleri oluşturulamadı: %v", err)
+	}
+	types, err := s.leaveRepo.GetTypesByHospital(hospitalID)
+	if err != nil {
+		return nil, fmt.Errorf("izin türleri getirilemedi: %v", err)
+	}
+	return types, nil
+}
+
+// CreateType izin türü ekler
+func (s *LeaveService) CreateType(hospitalID uint, req *model.LeaveTypeRequest) (*model.LeaveType, []model.ValidationError, error) {
+	if err := s.leaveRepo.EnsureDefaultTypes(hospitalID); err != nil {
+		return nil, nil, fmt.Errorf("varsayılan izin türleri oluşturulamadı: %v", err)
+	}
+	if validationErrors := s.validateTypeRequest(hospitalID, 0, req); len(validationErrors) > 0 {
+		return nil, validationErrors, nil
+	}
+
+	leaveType := &model.LeaveType{HospitalID: hospitalID, Name: req.Name, AnnualDays: req.AnnualDays}
+	if err := s.leaveRepo.CreateType(leaveType); err != nil {
+		return nil, nil, fmt.Errorf("izin türü eklenemedi: %v", err)
+	}
+	return leaveType, nil, nil
+}
+
+// UpdateType izin türünün adını ve yıllık hakkını değiştirir
+// Yıllık hak değişikliği personele özel tanımlanmamış tüm hakları etkiler, girilmiş talepler değişmez
+func (s *LeaveService) UpdateType(hospitalID, id uint, req *model.LeaveTypeRequest) (*model.LeaveType, []model.ValidationError, error) {
+	leaveType, err := s.getType(hospitalID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if validationErrors := s.validateTypeRequest(hospitalID, id, req); len(validationErrors) > 0 {
+		return nil, validationErrors, nil
+	}
+
+	leaveType.Name = req.Name
+	leaveType.AnnualDays = req.AnnualDays
+	if err := s.leaveRepo.UpdateType(leaveType); err != nil {
+		return nil, nil, fmt.Errorf("izin türü güncellenemedi: %v", err)
+	}
+	return leaveType, nil, nil
+}
+
+// DeleteType bekleyen veya onaylanmış talebi olmayan izin türünü siler
+func (s *LeaveService) DeleteType(hospitalID, id uint) error {
+	if _, err := s.getType(hospitalID, id); err != nil {
+		return err
+	}
+	inUse, err := s.leaveRepo.IsTypeInUse(id)
+	if err != nil {
+		return fmt.Errorf("izin türü kullanımı kontrol edilemedi: %v", err)
+	}
+	if inUse {
+		return ErrLeaveTypeInUse
+	}
+	return s.leaveRepo.DeleteType(id)
+}
+
+// ==================== İZİN TALEPLERİ ====================
+
+// ListRequests hastanenin izin taleplerini filtreye göre döndürür
+func (s *LeaveService) ListRequests(hospitalID uint, filter *model.LeaveRequestFilter) ([]model.LeaveRequest, error) {
+	requests, err := s.leaveRepo.ListRequests(hospitalID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("izin talepleri getirilemedi: %v", err)
+	}
+	return requests, nil
+}
+
+// CreateRequest personel adına izin talebi oluşturur
+// İzinden düşen gün sayısı personelin çalışma programında vardiyası olan günlerden hesaplanır
+func (s *LeaveService) CreateRequest(hospitalID uint, requestedBy *uint, req *model.CreateLeaveRequest) (*model.LeaveRequest, []model.ValidationError, error) {
+	request, validationErrors, err := s.buildRequest(hospitalID, req)
+	if err != nil || len(validationErrors) > 0 {
+		return nil, validationErrors, err
+	}
+	request.RequestedBy = requestedBy
+
+	if err := s.leaveRepo.CreateRequest(request); err != nil {
+		return nil, nil, fmt.Errorf("izin talebi eklenemedi: %v", err)
+	}
+
+	fmt.Printf("🌴 İzin talebi oluşturuldu: %d (personel %d, %d gün)\n", request.ID, request.StaffID, request.Days)
+	return s.reload(request.ID)
+}
+
+// Approve bekleyen izin talebini onaylar - takip edilen türlerde yıllık hak aşılamaz
+func (s *LeaveService) Approve(hospitalID, id, actorID uint) (*model.LeaveRequest, []model.ValidationError, error) {
+	request, err := s.getRequest(hospitalID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if request.Status != model.LeavePending {
+		return nil, nil, ErrLeaveNotPending
+	}
+
+	entitled, tracked, err := s.entitlement(request.StaffID, &request.LeaveType, request.StartDate.Year())
+	if err != nil {
+		return nil, nil, err
+	}
+	if !tracked {
+		entitled = -1
+	}
+
+	if err := s.leaveRepo.Approve(request, actorID, entitled); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrLeaveAlreadyReviewed):
+			return nil, nil, ErrLeaveNotPending
+		case errors.Is(err, repository.ErrLeaveBalanceExceeded):
+			return nil, []model.ValidationError{{
+				Field:   "leave_type_id",
+				Message: fmt.Sprintf("Onaylanırsa %d yılı %s hakkı (%d gün) aşılıyor", request.StartDate.Year(), request.LeaveType.Name, entitled),
+			}}, nil
+		}
+		return nil, nil, fmt.Errorf("izin talebi onaylanamadı: %v", err)
+	}
+
+	fmt.Printf("✅ İzin talebi onaylandı: %d (onaylayan %d)\n", request.ID, actorID)
+	return request, nil, nil
+}
+
+// Reject bekleyen izin talebini gerekçesiyle reddeder
+func (s *LeaveService) Reject(hospitalID, id, actorID uint, reason string) (*model.LeaveRequest, []model.ValidationError, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" || len([]rune(reason)) > leaveRejectReasonMaxLen {
+		return nil, []model.ValidationError{{
+			Field:   "reason",
+			Message: fmt.Sprintf("Red gerekçesi 1 ile %d karakter arasında olmalıdır", leaveRejectReasonMaxLen),
+		}}, nil
+	}
+
+	request, err := s.getRequest(hospitalID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if request.Status != model.LeavePending {
+		return nil, nil, ErrLeaveNotPending
+	}
+
+	if err := s.leaveRepo.Reject(request, actorID, reason); err != nil {
+		if errors.Is(err, repository.ErrLeaveAlreadyReviewed) {
+			return nil, nil, ErrLeaveNotPending
+		}
+		return nil, nil, fmt.Errorf("izin talebi reddedilemedi: %v", err)
+	}
+
+	fmt.Printf("🚫 İzin talebi reddedildi: %d (reddeden %d)\n", request.ID, actorID)
+	return request, nil, nil
+}
+
+// Cancel bekleyen veya onaylanmış izni iptal eder; onaylanmış izni sadece onay yetkisi olanlar iptal edebilir
+func (s *LeaveService) Cancel(hospitalID, id uint, actorID *uint, canApprove bool) (*model.LeaveRequest, error) {
+	request, err := s.getRequest(hospitalID, id)
+	if err != nil {
+		return nil, err
+	}
+	switch request.Status {
+	case model.LeavePending:
+	case model.LeaveApproved:
+		if !canApprove {
+			return nil, ErrLeaveCancelForbidden
+		}
+	default:
+		return nil, ErrLeaveNotCancellable
+	}
+
+	if err := s.leaveRepo.Cancel(request, actorID); err != nil {
+		if errors.Is(err, repository.ErrLeaveAlreadyReviewed) {
+			return nil, ErrLeaveNotCancellable
+		}
+		return nil, fmt.Errorf("izin talebi iptal edilemedi: %v", err)
+	}
+
+	fmt.Printf("↩️ İzin talebi iptal edildi: %d\n", request.ID)
+	return request, nil
+}
+
+// ==================== YILLIK HAKLAR ====================
+
+// GetBalances personelin yıl içindeki tüm izin türlerindeki hak, kullanım ve kalan günlerini döndürür
+func (s *LeaveService) GetBalances(hospitalID, staffID uint, year int) ([]model.LeaveBalanceSummary, error) {
+	if err := s.checkStaff(hospitalID, staffID); err != nil {
+		return nil, err
+	}
+	types, err := s.GetTypes(hospitalID)
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := s.leaveRepo.GetBalances(staffID, year)
+	if err != nil {
+		return nil, fmt.Errorf("izin hakları getirilemedi: %v", err)
+	}
+	sums, err := s.leaveRepo.SumDays(staffID, year)
+	if err != nil {
+		return nil, fmt.Errorf("izin kullanımı hesaplanamadı: %v", err)
+	}
+
+	byType := make(map[uint]*model.LeaveBalance, len(overrides))
+	for i := range overrides {
+		byType[overrides[i].LeaveTypeID] = &overrides[i]
+	}
+
+	summaries := make([]model.LeaveBalanceSummary, 0, len(types))
+	for i := range types {
+		summaries = append(summaries, buildBalanceSummary(&types[i], byType[types[i].ID], sums[types[i].ID], year))
+	}
+	return summaries, nil
+}
+
+// SetBalance personelin bir yıl için izin türündeki hakkını tanımlar (devreden izinler dahil toplam)
+func (s *LeaveService) SetBalance(hospitalID, staffID uint, req *model.LeaveBalanceRequest) (*model.LeaveBalanceSummary, []model.ValidationError, error) {
+	if err := s.checkStaff(hospitalID, staffID); err != nil {
+		return nil, nil, err
+	}
+
+	var validationErrors []model.ValidationError
+	leaveType, err := s.getType(hospitalID, req.LeaveTypeID)
+	if err != nil {
+		validationErrors = append(validationErrors, model.ValidationError{Field: "leave_type_id", Message: "Geçersiz izin türü"})
+	}
+	currentYear := time.Now().Year()
+	if req.Year < currentYear-1 || req.Year > currentYear+1 {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "year",
+			Message: fmt.Sprintf("Yıl %d-%d arasında olmalı", currentYear-1, currentYear+1),
+		})
+	}
+	if req.Days < 0 || req.Days > MAX_LEAVE_ANNUAL_DAYS {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "days",
+			Message: fmt.Sprintf("Yıllık hak 0-%d gün arasında olmalı", MAX_LEAVE_ANNUAL_DAYS),
+		})
+	}
+	if len(validationErrors) > 0 {
+		return nil, validationErrors, nil
+	}
+
+	balance := &model.LeaveBalance{
+		HospitalID:  hospitalID,
+		StaffID:     staffID,
+		LeaveTypeID: leaveType.ID,
+		Year:        req.Year,
+		Days:        req.Days,
+	}
+	if err := s.leaveRepo.UpsertBalance(balance); err != nil {
+		return nil, nil, fmt.Errorf("izin hakkı kaydedilemedi: %v", err)
+	}
+
+	sums, err := s.leaveRepo.SumDays(staffID, req.Year)
+	if err != nil {
+		return nil, nil, fmt.Errorf("izin kullanımı hesaplanamadı: %v", err)
+	}
+	summary := buildBalanceSummary(leaveType, balance, sums[leaveType.ID], req.Year)
+	return &summary, nil, nil
+}
+
+// ==================== DEVAMSIZLIK TAKVİMİ ====================
+
+// GetAbsenceCalendar polikliniğin [from, to] günlerinde izinli personellerini gün gün döndürür
+// includePending ile onay bekleyen talepler de (planlama için) gösterilir
+func (s *LeaveService) GetAbsenceCalendar(hospitalID, polyclinicID uint, from, to time.Time, includePending bool) ([]model.AbsenceCalendarDay, error) {
+	polyclinic, err := s.polyclinicRepo.GetHospitalPolyclinicByID(polyclinicID)
+	if err != nil || polyclinic.HospitalID != hospitalID {
+		return nil, ErrLeavePolyclinicNotFound
+	}
+	from, to = model.DateOf(from), model.DateOf(to)
+	if to.Before(from) || model.DaysBetween(from, to) >= MAX_SHIFT_CALENDAR_DAYS {
+		return nil, fmt.Errorf("%w (en fazla %d gün)", ErrLeaveRangeInvalid, MAX_SHIFT_CALENDAR_DAYS)
+	}
+
+	filter := &model.LeaveRequestFilter{PolyclinicID: &polyclinicID, From: &from, To: &to}
+	requests, err := s.leaveRepo.ListRequests(hospitalID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("izin talepleri getirilemedi: %v", err)
+	}
+
+	days := []model.AbsenceCalendarDay{}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		calendarDay := model.AbsenceCalendarDay{Date: day.Format(scheduleDateLayout), Absences: []model.AbsenceEntry{}}
+		for i := range requests {
+			request := &requests[i]
+			if !request.CoversDate(day) {
+				continue
+			}
+			if request.Status != model.LeaveApproved && !(includePending && request.Status == model.LeavePending) {
+				continue
+			}
+			calendarDay.Absences = append(calendarDay.Absences, model.AbsenceEntry{
+				LeaveRequestID: request.ID,
+				StaffID:        request.StaffID,
+				StaffName:      request.StaffName,
+				LeaveTypeName:  request.LeaveType.Name,
+				Status:         request.Status,
+			})
+		}
+		days = append(days, calendarDay)
+	}
+	return days, nil
+}
+
+// MarkLeaves tek personelin vardiyalarından onaylı izin günlerine denk gelenleri izin bilgisiyle işaretler
+// Vardiya başladığı güne göre değerlendirilir (izin gün sayısı da aynı şekilde hesaplanır)
+func MarkLeaves(occurrences []model.ShiftOccurrence, leaves []model.LeaveRequest) {
+	for i := range occurrences {
+		day, err := time.ParseInLocation(scheduleDateLayout, occurrences[i].Date, time.Local)
+		if err != nil {
+			continue
+		}
+		for j := range leaves {
+			if leaves[j].Status == model.LeaveApproved && leaves[j].CoversDate(day) {
+				occurrences[i].LeaveRequestID = &leaves[j].ID
+				occurrences[i].LeaveTypeName = leaves[j].LeaveType.Name
+				break
+			}
+		}
+	}
+}
+
+// ==================== HELPER METHODS ====================
+
+// validateTypeRequest izin türü adını ve yıllık hakkını doğrular
+func (s *LeaveService) validateTypeRequest(hospitalID, excludeID uint, req *model.LeaveTypeRequest) []model.ValidationError {
+	var validationErrors []model.ValidationError
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" || len([]rune(req.Name)) > leaveTypeNameMaxLength {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "name",
+			Message: fmt.Sprintf("İzin türü adı 1-%d karakter arasında olmalıdır", leaveTypeNameMaxLength),
+		})
+	} else if existing, err := s.leaveRepo.GetTypeByName(hospitalID, req.Name); err == nil && existing.ID != excludeID {
+		validationErrors = append(validationErrors, model.ValidationError{Field: "name", Message: "Bu isimde bir izin türü zaten var"})
+	}
+	if req.AnnualDays < 0 || req.AnnualDays > MAX_LEAVE_ANNUAL_DAYS {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "annual_days",
+			Message: fmt.Sprintf("Yıllık hak 0-%d gün arasında olmalı (0: takip edilmez)", MAX_LEAVE_ANNUAL_DAYS),
+		})
+	}
+	return validationErrors
+}
+
+// buildRequest talebi doğrular; çakışan talepleri, çalışma günü sayısını ve kalan hakkı kontrol eder
+func (s *LeaveService) buildRequest(hospitalID uint, req *model.CreateLeaveRequest) (*model.LeaveRequest, []model.ValidationError, error) {
+	var validationErrors []model.ValidationError
+
+	if err := s.checkStaff(hospitalID, req.StaffID); err != nil {
+		validationErrors = append(validationErrors, model.ValidationError{Field: "staff_id", Message: "Geçersiz personel"})
+	}
+	leaveType, err := s.getType(hospitalID, req.LeaveTypeID)
+	if err != nil {
+		validationErrors = append(validationErrors, model.ValidationError{Field: "leave_type_id", Message: "Geçersiz izin türü"})
+	}
+
+	start, startErr := parseScheduleDate(req.StartDate)
+	if startErr != nil {
+		validationErrors = append(validationErrors, model.ValidationError{Field: "start_date", Message: "Tarih YYYY-AA-GG biçiminde olmalı"})
+	}
+	end, endErr := parseScheduleDate(req.EndDate)
+	if endErr != nil {
+		validationErrors = append(validationErrors, model.ValidationError{Field: "end_date", Message: "Tarih YYYY-AA-GG biçiminde olmalı"})
+	}
+	if startErr == nil && endErr == nil {
+		switch {
+		case end.Before(start):
+			validationErrors = append(validationErrors, model.ValidationError{Field: "end_date", Message: "Bitiş tarihi başlangıçtan önce olamaz"})
+		case end.Year() != start.Year():
+			validationErrors = append(validationErrors, model.ValidationError{Field: "end_date", Message: "Yıl sonunu aşan izinler her yıl için ayrı talep olarak girilmeli"})
+		case model.DaysBetween(start, end) >= MAX_LEAVE_REQUEST_DAYS:
+			validationErrors = append(validationErrors, model.ValidationError{
+				Field:   "end_date",
+				Message: fmt.Sprintf("Tek talep en fazla %d gün olabilir", MAX_LEAVE_REQUEST_DAYS),
+			})
+		}
+	}
+
+	req.Note = strings.TrimSpace(req.Note)
+	if len([]rune(req.Note)) > leaveNoteMaxLength {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "note",
+			Message: fmt.Sprintf("Açıklama en fazla %d karakter olabilir", leaveNoteMaxLength),
+		})
+	}
+	if len(validationErrors) > 0 {
+		return nil, validationErrors, nil
+	}
+
+	// Aynı günlere bekleyen veya onaylanmış başka talep olamaz
+	existing, err := s.leaveRepo.GetActiveRequestsByStaff(req.StaffID, start, end)
+	if err != nil {
+		return nil, nil, fmt.Errorf("mevcut izinler kontrol edilemedi: %v", err)
+	}
+	if len(existing) > 0 {
+		other := &existing[0]
+		return nil, []model.ValidationError{{
+			Field: "start_date",
+			Message: fmt.Sprintf("Bu tarihlerde personelin %s durumunda %s talebi var (%s - %s)",
+				leaveStatusText(other.Status), other.LeaveType.Name,
+				other.StartDate.Format(scheduleDisplayDateLayout), other.EndDate.Format(scheduleDisplayDateLayout)),
+		}}, nil
+	}
+
+	schedules, err := s.scheduleRepo.GetSchedulesByStaffIDs([]uint{req.StaffID}, start, end)
+	if err != nil {
+		return nil, nil, fmt.Errorf("çalışma programları getirilemedi: %v", err)
+	}
+	days := countWorkingDays(ExpandSchedules(schedules, start, end))
+	if days == 0 {
+		return nil, []model.ValidationError{{
+			Field:   "start_date",
+			Message: "Seçilen tarihlerde personelin çalışma programında vardiyası yok",
+		}}, nil
+	}
+
+	entitled, tracked, err := s.entitlement(req.StaffID, leaveType, start.Year())
+	if err != nil {
+		return nil, nil, err
+	}
+	if tracked {
+		sums, err := s.leaveRepo.SumDays(req.StaffID, start.Year())
+		if err != nil {
+			return nil, nil, fmt.Errorf("izin kullanımı hesaplanamadı: %v", err)
+		}
+		remaining := entitled - sums[leaveType.ID][model.LeaveApproved] - sums[leaveType.ID][model.LeavePending]
+		if days > remaining {
+			return nil, []model.ValidationError{{
+				Field: "leave_type_id",
+				Message: fmt.Sprintf("Yetersiz izin hakkı: %d yılı %s için %d gün kaldı (bekleyen talepler dahil), talep %d gün",
+					start.Year(), leaveType.Name, max(remaining, 0), days),
+			}}, nil
+		}
+	}
+
+	return &model.LeaveRequest{
+		HospitalID:  hospitalID,
+		StaffID:     req.StaffID,
+		LeaveTypeID: leaveType.ID,
+		StartDate:   start,
+		EndDate:     end,
+		Days:        days,
+		Note:        req.Note,
+		Status:      model.LeavePending,
+	}, nil, nil
+}
+
+// entitlement personelin yıl için izin türündeki hakkını döndürür; personele özel hak tanımlıysa o kullanılır
+// Tür takip edilmiyor ve özel hak da yoksa tracked=false döner
+func (s *LeaveService) entitlement(staffID uint, leaveType *model.LeaveType, year int) (int, bool, error) {
+	balance, err := s.leaveRepo.GetBalance(staffID, leaveType.ID, year)
+	if err == nil {
+		return balance.Days, true, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, false, fmt.Errorf("izin hakkı getirilemedi: %v", err)
+	}
+	return leaveType.AnnualDays, leaveType.IsTracked(), nil
+}
+
+// checkStaff personelin hastaneye ait olduğunu kontrol eder
+func (s *LeaveService) checkStaff(hospitalID, staffID uint) error {
+	staff, err := s.staffRepo.GetByID(staffID)
+	if err != nil || staff.HospitalID != hospitalID {
+		return ErrLeaveStaffNotFound
+	}
+	return nil
+}
+
+// getType hastaneye ait izin türünü getirir
+func (s *LeaveService) getType(hospitalID, id uint) (*model.LeaveType, error) {
+	leaveType, err := s.leaveRepo.GetTypeByID(id)
+	if err != nil || leaveType.HospitalID != hospitalID {
+		return nil, ErrLeaveTypeNotFound
+	}
+	return leaveType, nil
+}
+
+// getRequest hastaneye ait izin talebini getirir
+func (s *LeaveService) getRequest(hospitalID, id uint) (*model.LeaveRequest, error) {
+	request, err := s.leaveRepo.GetRequestByID(id)
+	if err != nil || request.HospitalID != hospitalID {
+		return nil, ErrLeaveRequestNotFound
+	}
+	return request, nil
+}
+
+// reload kaydedilen talebi personel adı ve türüyle getirir
+func (s *LeaveService) reload(id uint) (*model.LeaveRequest, []model.ValidationError, error) {
+	request, err := s.leaveRepo.GetRequestByID(id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("kaydedilen izin talebi getirilemedi: %v", err)
+	}
+	return request, nil, nil
+}
+
+// buildBalanceSummary izin türü, varsa personele özel hak ve yıl içindeki kullanımdan bakiye özeti oluşturur
+func buildBalanceSummary(leaveType *model.LeaveType, balance *model.LeaveBalance, sums map[string]int, year int) model.LeaveBalanceSummary {
+	summary := model.LeaveBalanceSummary{
+		LeaveTypeID:   leaveType.ID,
+		LeaveTypeName: leaveType.Name,
+		Year:          year,
+		Tracked:       leaveType.IsTracked(),
+		Entitled:      leaveType.AnnualDays,
+		Used:          sums[model.LeaveApproved],
+		Pending:       sums[model.LeavePending],
+	}
+	if balance != nil {
+		summary.Tracked = true
+		summary.Entitled = balance.Days
+		summary.IsOverridden = true
+	}
+	if summary.Tracked {
+		summary.Remaining = summary.Entitled - summary.Used - summary.Pending
+	}
+	return summary
+}
+
+// countWorkingDays vardiyası başlayan farklı günleri sayar (bölünmüş mesai tek gün sayılır)
+func countWorkingDays(occurrences []model.ShiftOccurrence) int {
+	days := make(map[string]bool)
+	for _, occurrence := range occurrences {
+		days[occurrence.Date] = true
+	}
+	return len(days)
+}
+
+// leaveStatusText talep durumunun Türkçe karşılığını döndürür
+func leaveStatusText(status string) string {
+	switch status {
+	case model.LeavePending:
+		return "onay bekleyen"
+	case model.LeaveApproved:
+		return "onaylanmış"
+	case model.LeaveRejected:
+		return "reddedilmiş"
+	default:
+		return "iptal edilmiş"
+	}
+}
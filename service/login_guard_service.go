@@ -0,0 +1,205 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"hospital-platform/config"
+	"hospital-platform/database"
+	"math"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("E-posta/telefon veya şifre hatalı")
+	ErrTooManyResetCodes  = errors.New("Çok fazla kod talebi yapıldı, lütfen daha sonra tekrar deneyin")
+	ErrResetCodeExhausted = errors.New("Çok fazla hatalı deneme yapıldı, lütfen yeni kod isteyin")
+)
+
+// LoginBlockedError - Giriş denemesi kilit veya bekleme süresi nedeniyle reddedildiğinde döner
+type LoginBlockedError struct {
+	RetryAfter time.Duration // Tekrar denenebilecek süre
+	Locked     bool          // true: geçici hesap kilidi, false: artan bekleme süresi
+}
+
+func (e *LoginBlockedError) Error() string {
+	if e.Locked {
+		return fmt.Sprintf("Çok fazla başarısız deneme nedeniyle giriş geçici olarak kilitlendi, %d saniye sonra tekrar deneyin", int(math.Ceil(e.RetryAfter.Seconds())))
+	}
+	return fmt.Sprintf("Lütfen %d saniye sonra tekrar deneyin", int(math.Ceil(e.RetryAfter.Seconds())))
+}
+
+// LoginGuardService - Giriş ve şifre sıfırlama için brute-force korumasını yönetir
+// Sayaçlar Redis'te kimlik (email/telefon) ve IP bazında tutulur
+type LoginGuardService struct {
+	maxAttempts    int64         // Kimlik başına kilit öncesi izin verilen başarısız deneme
+	maxIPAttempts  int64         // IP başına kilit öncesi izin verilen başarısız deneme
+	delayThreshold int64         // Bu sayıdan sonra her hatada bekleme süresi ikiye katlanır
+	maxDelay       time.Duration // Artan bekleme süresinin üst sınırı
+	window         time.Duration // Sayaç penceresi
+	lockout        time.Duration // Kilit süresi
+
+	maxResetRequests int64         // Telefon başına pencere içinde izin verilen kod talebi
+	maxResetFailures int64         // Kod başına izin verilen hatalı deneme
+	resetWindow      time.Duration // Kod talebi sayacı penceresi
+}
+
+// NewLoginGuardService - Limitleri ortam değişkenlerinden okuyarak yeni servis oluşturur
+func NewLoginGuardService() *LoginGuardService {
+	return &LoginGuardService{
+		maxAttempts:    int64(config.GetEnvInt("LOGIN_MAX_ATTEMPTS", 5)),
+		maxIPAttempts:  int64(config.GetEnvInt("LOGIN_IP_MAX_ATTEMPTS", 20)),
+		delayThreshold: 3,
+		maxDelay:       30 * time.Second,
+		window:         config.GetEnvDuration("LOGIN_ATTEMPT_WINDOW", 15*time.Minute),
+		lockout:        config.GetEnvDuration("LOGIN_LOCKOUT_DURATION", 15*time.Minute),
+
+		maxResetRequests: int64(config.GetEnvInt("RESET_CODE_MAX_REQUESTS", 3)),
+		maxResetFailures: int64(config.GetEnvInt("RESET_CODE_MAX_ATTEMPTS", 5)),
+		resetWindow:      time.Hour,
+	}
+}
+
+// NormalizeIdentifier email/telefon bilgisini sayaç anahtarı için normalize eder
+func NormalizeIdentifier(identifier string) string {
+	return strings.ToLower(strings.TrimSpace(identifier))
+}
+
+// ==================== GİRİŞ KORUMASI ====================
+
+// CheckLogin giriş denemesinden önce kilit ve bekleme durumunu kontrol eder
+func (s *LoginGuardService) CheckLogin(identifier, ip string) error {
+	identifier = NormalizeIdentifier(identifier)
+
+	blockKeys := []struct {
+		key    string
+		locked bool
+	}{
+		{database.LOGIN_LOCK_IDENTIFIER_PREFIX + identifier, true},
+		{database.LOGIN_LOCK_IP_PREFIX + ip, true},
+		{database.LOGIN_DELAY_PREFIX + identifier, false},
+	}
+
+	for _, block := range blockKeys {
+		ttl, err := database.GetBlockTTL(block.key)
+		if err != nil {
+			return fmt.Errorf("giriş kilidi kontrol edilemedi: %v", err)
+		}
+		if ttl > 0 {
+			return &LoginBlockedError{RetryAfter: ttl, Locked: block.locked}
+		}
+	}
+
+	return nil
+}
+
+// RecordLoginFailure başarısız denemeyi sayar; eşik aşılırsa bekleme veya kilit uygular
+func (s *LoginGuardService) RecordLoginFailure(identifier, ip string) error {
+	identifier = NormalizeIdentifier(identifier)
+
+	// 1. Kimlik bazlı sayaç
+	count, err := database.IncrementAttempt(database.LOGIN_FAIL_IDENTIFIER_PREFIX+identifier, s.window)
+	if err != nil {
+		return fmt.Errorf("başarısız deneme kaydedilemedi: %v", err)
+	}
+
+	if count >= s.maxAttempts {
+		fmt.Printf("🔒 GUARD: Kimlik kilitlendi: %s (%d deneme)\n", identifier, count)
+		if err := database.SetBlock(database.LOGIN_LOCK_IDENTIFIER_PREFIX+identifier, s.lockout); err != nil {
+			return err
+		}
+	} else if count >= s.delayThreshold {
+		if err := database.SetBlock(database.LOGIN_DELAY_PREFIX+identifier, s.progressiveDelay(count)); err != nil {
+			return err
+		}
+	}
+
+	// 2. IP bazlı sayaç (farklı hesaplara yayılan denemeler için)
+	ipCount, err := database.IncrementAttempt(database.LOGIN_FAIL_IP_PREFIX+ip, s.window)
+	if err != nil {
+		return fmt.Errorf("başarısız deneme kaydedilemedi: %v", err)
+	}
+
+	if ipCount >= s.maxIPAttempts {
+		fmt.Printf("🔒 GUARD: IP kilitlendi: %s (%d deneme)\n", ip, ipCount)
+		return database.SetBlock(database.LOGIN_LOCK_IP_PREFIX+ip, s.lockout)
+	}
+
+	return nil
+}
+
+// RecordLoginSuccess başarılı girişten sonra kimliğe ait sayaçları sıfırlar
+func (s *LoginGuardService) RecordLoginSuccess(identifier string) error {
+	identifier = NormalizeIdentifier(identifier)
+	return database.ClearAttempts(
+		database.LOGIN_FAIL_IDENTIFIER_PREFIX+identifier,
+		database.LOGIN_DELAY_PREFIX+identifier,
+	)
+}
+
+// UnlockIdentifiers verilen kimliklerin kilit ve sayaçlarını temizler (yetkili kilit açma)
+func (s *LoginGuardService) UnlockIdentifiers(identifiers ...string) error {
+	var keys []string
+	for _, identifier := range identifiers {
+		identifier = NormalizeIdentifier(identifier)
+		keys = append(keys,
+			database.LOGIN_FAIL_IDENTIFIER_PREFIX+identifier,
+			database.LOGIN_DELAY_PREFIX+identifier,
+			database.LOGIN_LOCK_IDENTIFIER_PREFIX+identifier,
+			database.RESET_REQUEST_COUNT_PREFIX+identifier,
+			database.RESET_FAIL_COUNT_PREFIX+identifier,
+		)
+	}
+	return database.ClearAttempts(keys...)
+}
+
+// progressiveDelay eşik sonrası her hatada ikiye katlanan bekleme süresini hesaplar (1s, 2s, 4s...)
+func (s *LoginGuardService) progressiveDelay(count int64) time.Duration {
+	delay := time.Second << uint(count-s.delayThreshold)
+	if delay > s.maxDelay || delay <= 0 {
+		return s.maxDelay
+	}
+	return delay
+}
+
+// ==================== ŞİFRE SIFIRLAMA KORUMASI ====================
+
+// CheckResetRequest telefon başına kod talebi limitini kontrol eder ve talebi sayar
+func (s *LoginGuardService) CheckResetRequest(phone string) error {
+	phone = NormalizeIdentifier(phone)
+
+	count, err := database.IncrementAttempt(database.RESET_REQUEST_COUNT_PREFIX+phone, s.resetWindow)
+	if err != nil {
+		return fmt.Errorf("kod talebi kaydedilemedi: %v", err)
+	}
+	if count > s.maxResetRequests {
+		return ErrTooManyResetCodes
+	}
+	return nil
+}
+
+// CheckResetConfirm mevcut kod için hatalı deneme limitinin aşılıp aşılmadığını kontrol eder
+func (s *LoginGuardService) CheckResetConfirm(phone string) error {
+	count, err := database.GetAttemptCount(database.RESET_FAIL_COUNT_PREFIX + NormalizeIdentifier(phone))
+	if err != nil {
+		return fmt.Errorf("kod denemeleri kontrol edilemedi: %v", err)
+	}
+	if count >= s.maxResetFailures {
+		return ErrResetCodeExhausted
+	}
+	return nil
+}
+
+// RecordResetFailure hatalı kod denemesini sayar, limit dolunca true döner (kod iptal edilmeli)
+func (s *LoginGuardService) RecordResetFailure(phone string, codeTTL time.Duration) (bool, error) {
+	count, err := database.IncrementAttempt(database.RESET_FAIL_COUNT_PREFIX+NormalizeIdentifier(phone), codeTTL)
+	if err != nil {
+		return false, fmt.Errorf("hatalı deneme kaydedilemedi: %v", err)
+	}
+	return count >= s.maxResetFailures, nil
+}
+
+// ClearResetFailures yeni kod üretildiğinde veya şifre sıfırlandığında deneme sayacını temizler
+func (s *LoginGuardService) ClearResetFailures(phone string) error {
+	return database.ClearAttempts(database.RESET_FAIL_COUNT_PREFIX + NormalizeIdentifier(phone))
+}
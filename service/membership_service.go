@@ -0,0 +1,294 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"hospital-platform/model"
+	"hospital-platform/repository"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrMembershipNotFound = errors.New("Bu hastanede üyeliğiniz bulunmuyor")
+	ErrMemberNotFound     = errors.New("Üye bulunamadı")
+	ErrMemberSelf         = errors.New("Kendi üyeliğinizi değiştiremezsiniz")
+)
+
+// MembershipService - Kullanıcıların birden fazla hastanede çalışabilmesi için hastane üyelikleri iş mantığı
+// Hesabın birincil hastanesi User kaydındadır; diğer hastanelerdeki rol ve poliklinik üyelik kaydından okunur.
+// Token her zaman tek bir aktif hastane için üretilir, hastane değiştirmek için yeni token alınır
+type MembershipService struct {
+	membershipRepo *repository.MembershipRepository
+	userRepo       *repository.UserRepository
+	hospitalRepo   *repository.HospitalRepository
+	tokenService   *TokenService
+}
+
+// NewMembershipService yeni bir üyelik servisi oluşturur
+func NewMembershipService() *MembershipService {
+	return &MembershipService{
+		membershipRepo: repository.NewMembershipRepository(),
+		userRepo:       repository.NewUserRepository(),
+		hospitalRepo:   repository.NewHospitalRepository(),
+		tokenService:   NewTokenService(),
+	}
+}
+
+// ==================== AKTİF HASTANE ====================
+
+// GetUserInHospital kullanıcıyı verilen hastanedeki rol ve poliklinikle getirir
+// İşlemi yapan kullanıcının yetki kontrolleri token'daki aktif hastaneye göre yapılmalıdır
+func (s *MembershipService) GetUserInHospital(userID, hospitalID uint) (*model.User, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.ResolveHospital(user, hospitalID)
+}
+
+// ResolveHospital kullanıcının verilen hastanedeki halini döndürür
+// Birincil hastane (veya 0) için kullanıcı olduğu gibi döner; diğer hastanelerde aktif üyelik gerekir.
+// Dönen kopyanın HospitalID, Role ve PolyclinicID alanları üyelikten gelir - kaydedilmemelidir
+func (s *MembershipService) ResolveHospital(user *model.User, hospitalID uint) (*model.User, error) {
+	if hospitalID == 0 || hospitalID == user.HospitalID {
+		return user, nil
+	}
+
+	membership, err := s.membershipRepo.GetActive(user.ID, hospitalID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMembershipNotFound
+		}
+		return nil, fmt.Errorf("üyelik getirilemedi: %v", err)
+	}
+
+	member := *user
+	member.HospitalID = membership.HospitalID
+	member.Role = membership.Role
+	member.PolyclinicID = membership.PolyclinicID
+	member.Hospital = membership.Hospital
+	return &member, nil
+}
+
+// ListMemberships kullanıcının giriş yapabildiği hastaneleri döndürür (birincil hastane ilk sırada)
+func (s *MembershipService) ListMemberships(userID, currentHospitalID uint) ([]model.MembershipResponse, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("kullanıcı getirilemedi: %v", err)
+	}
+
+	memberships, err := s.membershipRepo.GetActiveByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("üyelikler getirilemedi: %v", err)
+	}
+
+	responses := make([]model.MembershipResponse, 0, len(memberships)+1)
+	responses = append(responses, model.MembershipResponse{
+		HospitalID:   user.HospitalID,
+		HospitalName: user.Hospital.Name,
+		Role:         user.Role,
+		PolyclinicID: user.PolyclinicID,
+		Primary:      true,
+		Current:      user.HospitalID == currentHospitalID,
+	})
+	for _, membership := range memberships {
+		responses = append(responses, model.MembershipResponse{
+			HospitalID:   membership.HospitalID,
+			HospitalName: membership.Hospital.Name,
+			Role:         membership.Role,
+			PolyclinicID: membership.PolyclinicID,
+			Current:      membership.HospitalID == currentHospitalID,
+		})
+	}
+	return responses, nil
+}
+
+// SwitchHospital mevcut oturumda verilen hastane için yeni token çifti üretir
+func (s *MembershipService) SwitchHospital(userID uint, familyID string, hospitalID uint) (*model.TokenResponse, error) {
+	return s.tokenService.SwitchHospital(userID, familyID, hospitalID)
+}
+
+// ==================== HASTANE ÜYELERİ ====================
+
+// ListMembers hastanenin diğer hastanelerden üye kullanıcılarını listeler
+func (s *MembershipService) ListMembers(hospitalID uint) ([]model.HospitalMemberResponse, error) {
+	memberships, err := s.membershipRepo.GetByHospitalID(hospitalID)
+	if err != nil {
+		return nil, fmt.Errorf("üyeler getirilemedi: %v", err)
+	}
+
+	responses := make([]model.HospitalMemberResponse, 0, len(memberships))
+	for i := range memberships {
+		responses = append(responses, toHospitalMemberResponse(&memberships[i]))
+	}
+	return responses, nil
+}
+
+// AddMember başka bir hastanenin mevcut kullanıcısını bu hastaneye üye ekler
+// Kullanıcı TC kimlik numarası ve hesabındaki e-posta birlikte verilerek bulunur (tek başına TC ile arama yapılamaz)
+func (s *MembershipService) AddMember(hospitalID, actorID uint, req *model.AddMemberRequest) (*model.HospitalMemberResponse, []model.ValidationError, error) {
+	actor, err := s.GetUserInHospital(actorID, hospitalID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("işlemi yapan kullanıcı bulunamadı: %v", err)
+	}
+
+	var validationErrors []model.ValidationError
+
+	user, err := s.userRepo.GetByTCKN(strings.TrimSpace(req.TCKN))
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, fmt.Errorf("kullanıcı getirilemedi: %v", err)
+	}
+	switch {
+	case user == nil || !strings.EqualFold(user.Email, strings.TrimSpace(req.Email)):
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "tc",
+			Message: "TC kimlik numarası ve e-posta ile eşleşen kullanıcı bulunamadı",
+		})
+	case user.HospitalID == hospitalID:
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "tc",
+			Message: "Kullanıcı zaten bu hastanenin kullanıcısı",
+		})
+	case !user.IsActive():
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "tc",
+			Message: "Kullanıcı hesabı aktif değil",
+		})
+	default:
+		exists, err := s.membershipRepo.Exists(user.ID, hospitalID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("üyelik kontrol edilemedi: %v", err)
+		}
+		if exists {
+			validationErrors = append(validationErrors, model.ValidationError{
+				Field:   "tc",
+				Message: "Kullanıcı zaten bu hastanenin üyesi",
+			})
+		}
+	}
+
+	// Rol ve poliklinik kontrolü - kimse kendi yetkilerinden fazlasını veremez
+	roleErrors, err := validateRoleAssignment(actor, req.Role, req.PolyclinicID)
+	if err != nil {
+		return nil, nil, err
+	}
+	validationErrors = append(validationErrors, roleErrors...)
+	if len(validationErrors) > 0 {
+		return nil, validationErrors, nil
+	}
+
+	membership := &model.HospitalMembership{
+		UserID:       user.ID,
+		HospitalID:   hospitalID,
+		Role:         req.Role,
+		PolyclinicID: req.PolyclinicID,
+		IsActive:     true,
+		CreatedBy:    actor.ID,
+		User:         *user,
+	}
+	if err := s.membershipRepo.Create(membership); err != nil {
+		return nil, nil, fmt.Errorf("üyelik oluşturulamadı: %v", err)
+	}
+
+	fmt.Printf("🏥 Üyelik eklendi: hastane=%d kullanıcı=%d rol=%s yetkili=%d\n", hospitalID, user.ID, membership.Role, actor.ID)
+
+	response := toHospitalMemberResponse(membership)
+	return &response, nil, nil
+}
+
+// UpdateMember üyenin bu hastanedeki rolünü, polikliniğini ve durumunu günceller
+func (s *MembershipService) UpdateMember(hospitalID, membershipID, actorID uint, req *model.UpdateMemberRequest) (*model.HospitalMemberResponse, []model.ValidationError, error) {
+	membership, actor, err := s.getManageableMember(hospitalID, membershipID, actorID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	validationErrors, err := validateRoleAssignment(actor, req.Role, req.PolyclinicID)
+	if len(validationErrors) > 0 || err != nil {
+		return nil, validationErrors, err
+	}
+
+	changed := membership.Role != req.Role || membership.IsActive != req.IsActive
+
+	membership.Role = req.Role
+	membership.PolyclinicID = req.PolyclinicID
+	membership.IsActive = req.IsActive
+	if err := s.membershipRepo.Update(membership); err != nil {
+		return nil, nil, fmt.Errorf("üyelik güncellenemedi: %v", err)
+	}
+
+	// Bu hastanede açık token'lar eski rol/durumla kalmasın
+	if changed {
+		if err := s.tokenService.InvalidateUserTokens(membership.UserID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	response := toHospitalMemberResponse(membership)
+	return &response, nil, nil
+}
+
+// RemoveMember üyeliği kaldırır; kullanıcının birincil hastanesindeki hesabı etkilenmez
+func (s *MembershipService) RemoveMember(hospitalID, membershipID, actorID uint) error {
+	membership, _, err := s.getManageableMember(hospitalID, membershipID, actorID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.membershipRepo.Delete(membership); err != nil {
+		return fmt.Errorf("üyelik silinemedi: %v", err)
+	}
+
+	fmt.Printf("🏥 Üyelik kaldırıldı: hastane=%d kullanıcı=%d yetkili=%d\n", hospitalID, membership.UserID, actorID)
+
+	return s.tokenService.InvalidateUserTokens(membership.UserID)
+}
+
+// ==================== HELPER FUNCTIONS ====================
+
+// getManageableMember üyeliğin bu hastaneye ait olduğunu ve rolünün işlemi yapanın yetkilerini aşmadığını kontrol eder
+func (s *MembershipService) getManageableMember(hospitalID, membershipID, actorID uint) (*model.HospitalMembership, *model.User, error) {
+	membership, err := s.membershipRepo.GetByID(membershipID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrMemberNotFound
+		}
+		return nil, nil, fmt.Errorf("üyelik getirilemedi: %v", err)
+	}
+	if membership.HospitalID != hospitalID {
+		return nil, nil, ErrMemberNotFound
+	}
+	if membership.UserID == actorID {
+		return nil, nil, ErrMemberSelf
+	}
+
+	actor, err := s.GetUserInHospital(actorID, hospitalID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("işlemi yapan kullanıcı bulunamadı: %v", err)
+	}
+	if err := NewRoleService().CheckAssignableRole(hospitalID, actor.Role, membership.Role); errors.Is(err, ErrPermissionEscalation) {
+		return nil, nil, err
+	}
+
+	return membership, actor, nil
+}
+
+// toHospitalMemberResponse üyeliği API cevabına çevirir
+func toHospitalMemberResponse(membership *model.HospitalMembership) model.HospitalMemberResponse {
+	return model.HospitalMemberResponse{
+		ID:                membership.ID,
+		UserID:            membership.UserID,
+		FirstName:         membership.User.FirstName,
+		LastName:          membership.User.LastName,
+		Email:             membership.User.Email,
+		Phone:             membership.User.Phone,
+		PrimaryHospitalID: membership.User.HospitalID,
+		Role:              membership.Role,
+		PolyclinicID:      membership.PolyclinicID,
+		IsActive:          membership.IsActive,
+		CreatedBy:         membership.CreatedBy,
+		CreatedAt:         membership.CreatedAt,
+	}
+}
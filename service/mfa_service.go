@@ -0,0 +1,313 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"hospital-platform/database"
+	"hospital-platform/model"
+	"hospital-platform/repository"
+	"hospital-platform/utils"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidMFACode        = errors.New("Doğrulama kodu hatalı")
+	ErrInvalidMFAToken       = errors.New("Geçersiz veya süresi dolmuş MFA oturumu")
+	ErrMFANotEnrolled        = errors.New("İki adımlı doğrulama kaydı başlatılmamış")
+	ErrMFAAlreadyEnabled     = errors.New("İki adımlı doğrulama zaten aktif")
+	ErrMFANotEnabled         = errors.New("İki adımlı doğrulama aktif değil")
+	ErrMFARequiredByHospital = errors.New("Hastaneniz yetkili kullanıcılar için iki adımlı doğrulamayı zorunlu kılıyor")
+)
+
+const (
+	recoveryCodeCount = 10               // Üretilecek kurtarma kodu sayısı
+	totpReplayWindow  = 90 * time.Second // ±1 periyot tolerans dahil kodun geçerli olabileceği süre
+)
+
+// MFAService - TOTP tabanlı iki adımlı doğrulama iş mantığını yönetir
+// Kayıt, login ikinci adımı, kurtarma kodları ve hastane politikası bu servistedir
+type MFAService struct {
+	userRepo     *repository.UserRepository
+	mfaRepo      *repository.MFARepository
+	hospitalRepo *repository.HospitalRepository
+	tokenService *TokenService
+}
+
+// NewMFAService yeni bir MFA servisi oluşturur
+func NewMFAService() *MFAService {
+	return &MFAService{
+		userRepo:     repository.NewUserRepository(),
+		mfaRepo:      repository.NewMFARepository(),
+		hospitalRepo: repository.NewHospitalRepository(),
+		tokenService: NewTokenService(),
+	}
+}
+
+// ==================== KAYIT (ENROLLMENT) ====================
+
+// StartEnrollment yeni bir TOTP secret'ı üretir ve doğrulanana kadar beklemede tutar
+func (s *MFAService) StartEnrollment(userID uint) (*model.MFAEnrollResponse, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("kullanıcı bulunamadı: %v", err)
+	}
+
+	if user.MFAEnabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	enrollment, err := utils.GenerateTOTP(user.Email)
+	if err != nil {
+		return nil, fmt.Errorf("TOTP anahtarı üretilemedi: %v", err)
+	}
+
+	encrypted, err := utils.EncryptString(enrollment.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("TOTP anahtarı şifrelenemedi: %v", err)
+	}
+
+	// Secret kaydedilir ama MFA, kod doğrulanana kadar aktif olmaz
+	user.TOTPSecret = encrypted
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, fmt.Errorf("TOTP anahtarı kaydedilemedi: %v", err)
+	}
+
+	return &model.MFAEnrollResponse{
+		Secret:     enrollment.Secret,
+		OTPAuthURL: enrollment.OTPAuthURL,
+		QRCode:     enrollment.QRCode,
+	}, nil
+}
+
+// ConfirmEnrollment ilk kodu doğrular, MFA'yı aktif eder ve kurtarma kodlarını üretir
+// Mevcut oturum kapatılır ve MFA doğrulanmış yeni bir token çifti döner
+func (s *MFAService) ConfirmEnrollment(userID uint, code string, currentFamilyID string, client model.ClientInfo) (*model.MFAActivationResponse, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("kullanıcı bulunamadı: %v", err)
+	}
+
+	if user.MFAEnabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	if user.TOTPSecret == "" {
+		return nil, ErrMFANotEnrolled
+	}
+
+	if err := s.verifyTOTP(user, code); err != nil {
+		return nil, err
+	}
+
+	user.MFAEnabled = true
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, fmt.Errorf("MFA aktif edilemedi: %v", err)
+	}
+
+	recoveryCodes, err := s.generateRecoveryCodes(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// MFA olmadan açılmış oturumu kapat, yerine MFA doğrulanmış oturum ver
+	if currentFamilyID != "" {
+		if err := s.tokenService.Logout(user.ID, currentFamilyID); err != nil {
+			return nil, err
+		}
+	}
+
+	tokens, err := s.tokenService.IssueTokenPair(user, true, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.MFAActivationResponse{
+		RecoveryCodes: recoveryCodes,
+		Tokens:        tokens,
+	}, nil
+}
+
+// RegenerateRecoveryCodes güncel TOTP kodu ile doğrulayıp yeni kurtarma kodları üretir
+func (s *MFAService) RegenerateRecoveryCodes(userID uint, code string) ([]string, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("kullanıcı bulunamadı: %v", err)
+	}
+
+	if !user.MFAEnabled {
+		return nil, ErrMFANotEnabled
+	}
+
+	if err := s.verifyTOTP(user, code); err != nil {
+		return nil, err
+	}
+
+	return s.generateRecoveryCodes(user.ID)
+}
+
+// Disable şifre ve TOTP kodu ile doğrulayıp MFA'yı kapatır
+// Hastane MFA'yı zorunlu kılmışsa yönetim yetkisine sahip kullanıcılar kapatamaz
+func (s *MFAService) Disable(userID uint, password, code string) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return fmt.Errorf("kullanıcı bulunamadı: %v", err)
+	}
+
+	if !user.MFAEnabled {
+		return ErrMFANotEnabled
+	}
+
+	if user.Hospital.RequireMFA && NewRoleService().IsPrivileged(user.HospitalID, user.Role) {
+		return ErrMFARequiredByHospital
+	}
+
+	if !utils.CheckPasswordHash(password, user.Password) {
+		return ErrInvalidCredentials
+	}
+
+	if err := s.verifyTOTP(user, code); err != nil {
+		return err
+	}
+
+	user.MFAEnabled = false
+	user.TOTPSecret = ""
+	if err := s.userRepo.Update(user); err != nil {
+		return fmt.Errorf("MFA kapatılamadı: %v", err)
+	}
+
+	if err := s.mfaRepo.DeleteRecoveryCodes(user.ID); err != nil {
+		return fmt.Errorf("kurtarma kodları silinemedi: %v", err)
+	}
+
+	// MFA claim'i taşıyan token'lar artık geçerli olmamalı
+	return s.tokenService.RevokeAllForUser(user.ID)
+}
+
+// ==================== LOGIN İKİNCİ ADIM ====================
+
+// NewChallenge şifresi doğrulanan kullanıcı için MFA pending token üretir
+func (s *MFAService) NewChallenge(user *model.User) (*model.LoginChallengeResponse, error) {
+	mfaToken, err := utils.GenerateMFAPendingToken(user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("MFA token üretilemedi: %v", err)
+	}
+
+	return &model.LoginChallengeResponse{
+		MFARequired: true,
+		MFAToken:    mfaToken,
+		ExpiresIn:   int(utils.MFA_PENDING_TOKEN_TTL.Seconds()),
+	}, nil
+}
+
+// VerifyLogin MFA pending token'ı ve TOTP/kurtarma kodunu doğrulayıp normal token çiftini üretir
+func (s *MFAService) VerifyLogin(req *model.MFALoginRequest, client model.ClientInfo) (*model.TokenResponse, error) {
+	userID, err := utils.ValidateMFAPendingToken(req.MFAToken)
+	if err != nil {
+		return nil, ErrInvalidMFAToken
+	}
+
+	// MFA kodu denemeleri de brute-force korumasına tabi
+	guard := NewLoginGuardService()
+	guardKey := fmt.Sprintf("mfa:%d", userID)
+	if err := guard.CheckLogin(guardKey, client.IP); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil || !user.MFAEnabled {
+		return nil, ErrInvalidMFAToken
+	}
+
+	if req.RecoveryCode != "" {
+		used, err := s.mfaRepo.UseRecoveryCode(user.ID, utils.HashToken(normalizeRecoveryCode(req.RecoveryCode)))
+		if err != nil {
+			return nil, fmt.Errorf("kurtarma kodu kontrol edilemedi: %v", err)
+		}
+		if !used {
+			guard.RecordLoginFailure(guardKey, client.IP)
+			return nil, ErrInvalidMFACode
+		}
+	} else if err := s.verifyTOTP(user, req.Code); err != nil {
+		guard.RecordLoginFailure(guardKey, client.IP)
+		return nil, err
+	}
+
+	guard.RecordLoginSuccess(guardKey)
+
+	return s.tokenService.IssueTokenPair(user, true, client)
+}
+
+// ==================== HASTANE POLİTİKASI ====================
+
+// SetHospitalPolicy hastanenin yetkili kullanıcılar için MFA zorunluluğunu ayarlar
+func (s *MFAService) SetHospitalPolicy(hospitalID uint, requireMFA bool) error {
+	if err := s.hospitalRepo.UpdateRequireMFA(hospitalID, requireMFA); err != nil {
+		return fmt.Errorf("MFA politikası güncellenemedi: %v", err)
+	}
+	return nil
+}
+
+// IsEnrollmentRequired hastane MFA'yı zorunlu kılmış ama kullanıcı henüz kayıt olmamışsa true döner
+func (s *MFAService) IsEnrollmentRequired(user *model.User) bool {
+	if user.MFAEnabled {
+		return false
+	}
+
+	hospital, err := s.hospitalRepo.GetByID(user.HospitalID)
+	if err != nil || !hospital.RequireMFA {
+		return false
+	}
+	return NewRoleService().IsPrivileged(user.HospitalID, user.Role)
+}
+
+// ==================== HELPER METHODS ====================
+
+// verifyTOTP kodu kullanıcının secret'ı ile doğrular ve aynı kodun tekrar kullanılmasını engeller
+func (s *MFAService) verifyTOTP(user *model.User, code string) error {
+	secret, err := utils.DecryptString(user.TOTPSecret)
+	if err != nil {
+		return fmt.Errorf("TOTP anahtarı çözülemedi: %v", err)
+	}
+
+	code = strings.TrimSpace(code)
+	if !utils.ValidateTOTP(code, secret) {
+		return ErrInvalidMFACode
+	}
+
+	firstUse, err := database.MarkTOTPCodeUsed(user.ID, code, totpReplayWindow)
+	if err != nil {
+		return fmt.Errorf("TOTP kodu işaretlenemedi: %v", err)
+	}
+	if !firstUse {
+		return ErrInvalidMFACode
+	}
+
+	return nil
+}
+
+// generateRecoveryCodes yeni kurtarma kodları üretir, hash'lerini kaydeder ve düz hallerini döndürür
+func (s *MFAService) generateRecoveryCodes(userID uint) ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+
+	for i := 0; i < recoveryCodeCount; i++ {
+		raw, err := utils.GenerateReadableCode(5)
+		if err != nil {
+			return nil, fmt.Errorf("kurtarma kodu üretilemedi: %v", err)
+		}
+		code := normalizeRecoveryCode(raw[:4] + "-" + raw[4:8])
+		codes = append(codes, code)
+		hashes = append(hashes, utils.HashToken(code))
+	}
+
+	if err := s.mfaRepo.ReplaceRecoveryCodes(userID, hashes); err != nil {
+		return nil, fmt.Errorf("kurtarma kodları kaydedilemedi: %v", err)
+	}
+
+	return codes, nil
+}
+
+// normalizeRecoveryCode kullanıcının girdiği kurtarma kodunu karşılaştırma için normalize eder
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.TrimSpace(code))
+}
@@ -0,0 +1,285 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"hospital-platform/config"
+	"hospital-platform/utils"
+	"strings"
+	"text/template"
+)
+
+// Bildirim şablonları
+const (
+	TEMPLATE_RESET_CODE         = "reset_code"
+	TEMPLATE_PHONE_VERIFICATION = "phone_verification"
+	TEMPLATE_INVITATION         = "invitation"
+	TEMPLATE_SIGNUP_APPROVED    = "signup_approved"
+	TEMPLATE_SIGNUP_REJECTED    = "signup_rejected"
+	TEMPLATE_HOSPITAL_APPROVED  = "hospital_approved"
+	TEMPLATE_HOSPITAL_REJECTED  = "hospital_rejected"
+	TEMPLATE_CONTACT_CHANGED    = "contact_changed"
+)
+
+// Desteklenen bildirim dilleri
+const (
+	LANG_TR = "tr"
+	LANG_EN = "en"
+)
+
+// messageTemplate - Tek bir dil için bildirim metinleri
+// SMS kısa tutulur, e-posta konu ve daha açıklayıcı gövde içerir
+type messageTemplate struct {
+	Subject string
+	SMS     string
+	Email   string
+}
+
+// templates şablon adı → dil → metinler
+var templates = map[string]map[string]messageTemplate{
+	TEMPLATE_RESET_CODE: {
+		LANG_TR: {
+			Subject: "Şifre sıfırlama kodunuz",
+			SMS:     "Şifre sıfırlama kodunuz: {{.Code}}. Kod {{.Minutes}} dakika geçerlidir, kimseyle paylaşmayın.",
+			Email: `Merhaba {{.FirstName}},
+
+Şifre sıfırlama kodunuz: {{.Code}}
+
+Kod {{.Minutes}} dakika geçerlidir. Bu talebi siz yapmadıysanız bu mesajı dikkate almayın ve kodu kimseyle paylaşmayın.`,
+		},
+		LANG_EN: {
+			Subject: "Your password reset code",
+			SMS:     "Your password reset code is {{.Code}}. It expires in {{.Minutes}} minutes, do not share it with anyone.",
+			Email: `Hello {{.FirstName}},
+
+Your password reset code is: {{.Code}}
+
+The code expires in {{.Minutes}} minutes. If you did not request this, ignore this message and do not share the code with anyone.`,
+		},
+	},
+	TEMPLATE_PHONE_VERIFICATION: {
+		LANG_TR: {
+			Subject: "Doğrulama kodunuz",
+			SMS:     "Telefon doğrulama kodunuz: {{.Code}}. Kod {{.Minutes}} dakika geçerlidir, kimseyle paylaşmayın.",
+			Email:   "Doğrulama kodunuz: {{.Code}}\n\nKod {{.Minutes}} dakika geçerlidir, kimseyle paylaşmayın.",
+		},
+		LANG_EN: {
+			Subject: "Your verification code",
+			SMS:     "Your phone verification code is {{.Code}}. It expires in {{.Minutes}} minutes, do not share it with anyone.",
+			Email:   "Your verification code is: {{.Code}}\n\nThe code expires in {{.Minutes}} minutes, do not share it with anyone.",
+		},
+	},
+	TEMPLATE_INVITATION: {
+		LANG_TR: {
+			Subject: "{{.HospitalName}} sizi davet ediyor",
+			SMS:     "{{.HospitalName}} sizi hastane platformuna davet etti: {{.Link}} ({{.Hours}} saat geçerli)",
+			Email: `Merhaba {{.FirstName}},
+
+{{.InviterName}} sizi {{.HospitalName}} hesabına "{{.Role}}" rolüyle davet etti.
+
+Daveti kabul etmek, şifrenizi belirlemek ve telefonunuzu doğrulamak için aşağıdaki bağlantıyı kullanın:
+{{.Link}}
+
+Bağlantı {{.Hours}} saat geçerlidir ve yalnızca bir kez kullanılabilir. Bu daveti beklemiyorsanız bu mesajı dikkate almayın.`,
+		},
+		LANG_EN: {
+			Subject: "{{.HospitalName}} has invited you",
+			SMS:     "{{.HospitalName}} has invited you to the hospital platform: {{.Link}} (valid for {{.Hours}} hours)",
+			Email: `Hello {{.FirstName}},
+
+{{.InviterName}} has invited you to join {{.HospitalName}} with the "{{.Role}}" role.
+
+Use the link below to accept the invitation, set your password and verify your phone number:
+{{.Link}}
+
+The link is valid for {{.Hours}} hours and can only be used once. If you were not expecting this invitation, ignore this message.`,
+		},
+	},
+	TEMPLATE_SIGNUP_APPROVED: {
+		LANG_TR: {
+			Subject: "{{.HospitalName}} kayıt başvurunuz onaylandı",
+			SMS:     "{{.HospitalName}} kayıt başvurunuz onaylandı. Başvuruda belirlediğiniz şifreyle giriş yapabilirsiniz.",
+			Email: `Merhaba {{.FirstName}},
+
+{{.HospitalName}} hesabına kayıt başvurunuz "{{.Role}}" rolüyle onaylandı.
+
+E-posta adresiniz veya telefon numaranız ve başvuruda belirlediğiniz şifreyle giriş yapabilirsiniz.`,
+		},
+		LANG_EN: {
+			Subject: "Your {{.HospitalName}} signup has been approved",
+			SMS:     "Your {{.HospitalName}} signup has been approved. You can log in with the password you chose.",
+			Email: `Hello {{.FirstName}},
+
+Your request to join {{.HospitalName}} has been approved with the "{{.Role}}" role.
+
+You can log in with your email address or phone number and the password you chose when signing up.`,
+		},
+	},
+	TEMPLATE_SIGNUP_REJECTED: {
+		LANG_TR: {
+			Subject: "{{.HospitalName}} kayıt başvurunuz hakkında",
+			SMS:     "{{.HospitalName}} kayıt başvurunuz onaylanmadı. Gerekçe: {{.Reason}}",
+			Email: `Merhaba {{.FirstName}},
+
+{{.HospitalName}} hesabına kayıt başvurunuz onaylanmadı.
+
+Gerekçe: {{.Reason}}
+
+Bir hata olduğunu düşünüyorsanız hastanenizin yetkilisiyle iletişime geçin.`,
+		},
+		LANG_EN: {
+			Subject: "About your {{.HospitalName}} signup",
+			SMS:     "Your {{.HospitalName}} signup was not approved. Reason: {{.Reason}}",
+			Email: `Hello {{.FirstName}},
+
+Your request to join {{.HospitalName}} was not approved.
+
+Reason: {{.Reason}}
+
+If you believe this is a mistake, please contact your hospital administrator.`,
+		},
+	},
+	TEMPLATE_HOSPITAL_APPROVED: {
+		LANG_TR: {
+			Subject: "{{.HospitalName}} başvurunuz onaylandı",
+			SMS:     "{{.HospitalName}} hastane başvurunuz onaylandı. Platformun tüm özelliklerini kullanabilirsiniz.",
+			Email: `Merhaba,
+
+{{.HospitalName}} için yaptığınız hastane kayıt başvurusu onaylandı.
+
+Yetkili hesabınızla giriş yaparak poliklinik, personel ve kullanıcı yönetimini kullanmaya başlayabilirsiniz.`,
+		},
+		LANG_EN: {
+			Subject: "Your {{.HospitalName}} application has been approved",
+			SMS:     "Your {{.HospitalName}} hospital application has been approved. All platform features are now available.",
+			Email: `Hello,
+
+The hospital registration application for {{.HospitalName}} has been approved.
+
+Log in with your administrator account to start managing polyclinics, staff and users.`,
+		},
+	},
+	TEMPLATE_HOSPITAL_REJECTED: {
+		LANG_TR: {
+			Subject: "{{.HospitalName}} başvurunuz hakkında",
+			SMS:     "{{.HospitalName}} hastane başvurunuz onaylanmadı. Gerekçe: {{.Reason}}",
+			Email: `Merhaba,
+
+{{.HospitalName}} için yaptığınız hastane kayıt başvurusu onaylanmadı.
+
+Gerekçe: {{.Reason}}
+
+Yetkili hesabınızla giriş yaparak başvuru bilgilerini ve belgeleri düzeltip başvurunuzu tekrar gönderebilirsiniz.`,
+		},
+		LANG_EN: {
+			Subject: "About your {{.HospitalName}} application",
+			SMS:     "Your {{.HospitalName}} hospital application was not approved. Reason: {{.Reason}}",
+			Email: `Hello,
+
+The hospital registration application for {{.HospitalName}} was not approved.
+
+Reason: {{.Reason}}
+
+Log in with your administrator account to correct the application details and documents, then resubmit your application.`,
+		},
+	},
+	TEMPLATE_CONTACT_CHANGED: {
+		LANG_TR: {
+			Subject: "Hesap bilgileriniz değiştirildi",
+			SMS:     "Hesabınızın {{if eq .ContactType \"email\"}}e-posta adresi{{else}}telefon numarası{{end}} değiştirildi. Bu işlemi siz yapmadıysanız hastane yöneticinize başvurun.",
+			Email: `Merhaba {{.FirstName}},
+
+Hesabınızın {{if eq .ContactType "email"}}e-posta adresi{{else}}telefon numarası{{end}} değiştirildi. Bu adrese artık bildirim gönderilmeyecek.
+
+Bu işlemi siz yapmadıysanız hemen hastane yöneticinize başvurun.`,
+		},
+		LANG_EN: {
+			Subject: "Your account details have been changed",
+			SMS:     "The {{if eq .ContactType \"email\"}}email address{{else}}phone number{{end}} on your account has been changed. If this was not you, contact your hospital administrator.",
+			Email: `Hello {{.FirstName}},
+
+The {{if eq .ContactType "email"}}email address{{else}}phone number{{end}} on your account has been changed. Notifications will no longer be sent to this address.
+
+If you did not make this change, contact your hospital administrator immediately.`,
+		},
+	},
+}
+
+// NotificationService - Şablonlu SMS ve e-posta bildirimleri
+type NotificationService struct{}
+
+// NewNotificationService yeni bir bildirim servisi oluşturur
+func NewNotificationService() *NotificationService {
+	return &NotificationService{}
+}
+
+// Send şablonu istenen dilde işleyip kanalın sağlayıcısıyla gönderir
+func (s *NotificationService) Send(channel, to, templateName, lang string, data map[string]interface{}) error {
+	notifier, err := utils.GetNotifier(channel)
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := getTemplate(templateName, lang)
+	if err != nil {
+		return err
+	}
+
+	notification := utils.Notification{To: to}
+	if channel == utils.CHANNEL_EMAIL {
+		if notification.Subject, err = renderTemplate(tmpl.Subject, data); err != nil {
+			return err
+		}
+		notification.Body, err = renderTemplate(tmpl.Email, data)
+	} else {
+		notification.Body, err = renderTemplate(tmpl.SMS, data)
+	}
+	if err != nil {
+		return err
+	}
+
+	return notifier.Send(notification)
+}
+
+// ResolveLanguage istekte belirtilen dili veya Accept-Language başlığını desteklenen dillerden birine çevirir
+// Hiçbiri desteklenmiyorsa NOTIFY_DEFAULT_LANG (varsayılan tr) döner
+func ResolveLanguage(requested, acceptLanguage string) string {
+	candidates := []string{requested}
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		candidates = append(candidates, strings.SplitN(strings.TrimSpace(part), ";", 2)[0])
+	}
+
+	for _, candidate := range candidates {
+		lang := strings.ToLower(strings.SplitN(candidate, "-", 2)[0])
+		if lang == LANG_TR || lang == LANG_EN {
+			return lang
+		}
+	}
+	return config.GetEnv("NOTIFY_DEFAULT_LANG", LANG_TR)
+}
+
+// getTemplate şablonu dile göre bulur; dil yoksa Türkçe şablona düşer
+func getTemplate(name, lang string) (messageTemplate, error) {
+	byLang, ok := templates[name]
+	if !ok {
+		return messageTemplate{}, fmt.Errorf("bildirim şablonu bulunamadı: %s", name)
+	}
+	if tmpl, ok := byLang[lang]; ok {
+		return tmpl, nil
+	}
+	return byLang[LANG_TR], nil
+}
+
+// renderTemplate metin şablonunu verilerle işler
+func renderTemplate(text string, data map[string]interface{}) (string, error) {
+	tmpl, err := template.New("notification").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("bildirim şablonu işlenemedi: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("bildirim şablonu işlenemedi: %v", err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,427 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hospital-platform/database"
+	"hospital-platform/model"
+	"hospital-platform/repository"
+	"hospital-platform/utils"
+	"strings"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+const (
+	MAX_PASSKEYS_PER_USER   = 10 // Kullanıcı başına kayıtlı passkey/güvenlik anahtarı sınırı
+	PASSKEY_NAME_MAX_LENGTH = 100
+	PASSKEY_DEFAULT_NAME    = "Passkey"
+)
+
+var (
+	ErrPasskeyNotFound          = errors.New("Passkey bulunamadı")
+	ErrPasskeyAlreadyRegistered = errors.New("Bu passkey zaten kayıtlı")
+	ErrPasskeyLimitReached      = errors.New("En fazla 10 passkey kaydedilebilir, yeni eklemek için birini silin")
+	ErrPasskeyCeremonyExpired   = errors.New("Passkey işlemi zaman aşımına uğradı, lütfen tekrar deneyin")
+	ErrPasskeyInvalid           = errors.New("Passkey doğrulanamadı")
+	ErrPasskeyCloned            = errors.New("Passkey imza sayacı geriye gitti, anahtar kopyalanmış olabilir")
+)
+
+// PasskeyService - WebAuthn ile passkey/güvenlik anahtarı kaydı ve şifresiz giriş
+// Passkey girişi sahip olunan cihaz + PIN/biyometri gerektirdiği için MFA doğrulanmış sayılır
+type PasskeyService struct {
+	passkeyRepo  *repository.PasskeyRepository
+	userRepo     *repository.UserRepository
+	tokenService *TokenService
+}
+
+// NewPasskeyService yeni bir passkey servisi oluşturur
+func NewPasskeyService() *PasskeyService {
+	return &PasskeyService{
+		passkeyRepo:  repository.NewPasskeyRepository(),
+		userRepo:     repository.NewUserRepository(),
+		tokenService: NewTokenService(),
+	}
+}
+
+// ==================== KAYIT ====================
+
+// BeginRegistration navigator.credentials.create() seçeneklerini üretir
+// Kullanıcının mevcut anahtarları hariç tutulur, aynı authenticator iki kez kaydedilmez
+func (s *PasskeyService) BeginRegistration(userID uint) (*model.PasskeyCreationOptions, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProfileNotFound
+		}
+		return nil, fmt.Errorf("kullanıcı getirilemedi: %v", err)
+	}
+
+	passkeys, err := s.passkeyRepo.ListByUser(userID)
+	if err != nil {
+		return nil, fmt.Errorf("passkey'ler getirilemedi: %v", err)
+	}
+	if len(passkeys) >= MAX_PASSKEYS_PER_USER {
+		return nil, ErrPasskeyLimitReached
+	}
+
+	challenge, err := s.startCeremony(model.PasskeyCeremony{Type: model.PasskeyCeremonyRegistration, UserID: userID})
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := utils.GetWebAuthnConfig()
+	params := make([]model.PasskeyCredentialParam, 0, len(utils.WebAuthnAlgorithms))
+	for _, alg := range utils.WebAuthnAlgorithms {
+		params = append(params, model.PasskeyCredentialParam{Type: "public-key", Alg: alg})
+	}
+
+	return &model.PasskeyCreationOptions{
+		Challenge: challenge,
+		RP:        model.PasskeyRelyingParty{ID: cfg.RPID, Name: cfg.RPName},
+		User: model.PasskeyUserEntity{
+			ID:          passkeyUserHandle(user.ID),
+			Name:        user.Email,
+			DisplayName: strings.TrimSpace(user.FirstName + " " + user.LastName),
+		},
+		PubKeyCredParams:   params,
+		Timeout:            int(utils.WEBAUTHN_CEREMONY_TTL.Milliseconds()),
+		ExcludeCredentials: toCredentialDescriptors(passkeys),
+		AuthenticatorSelection: model.PasskeyAuthenticatorSelection{
+			ResidentKey:      "preferred",
+			UserVerification: "required",
+		},
+		Attestation: "none",
+	}, nil
+}
+
+// FinishRegistration authenticator yanıtını doğrular ve açık anahtarı kaydeder
+func (s *PasskeyService) FinishRegistration(userID uint, req *model.FinishPasskeyRegistrationRequest) (*model.PasskeyResponse, []model.ValidationError, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = PASSKEY_DEFAULT_NAME
+	}
+	if utf8.RuneCountInString(name) > PASSKEY_NAME_MAX_LENGTH {
+		return nil, []model.ValidationError{{
+			Field:   "name",
+			Message: fmt.Sprintf("Passkey adı en fazla %d karakter olabilir", PASSKEY_NAME_MAX_LENGTH),
+		}}, nil
+	}
+
+	clientDataJSON, err := decodeBase64URL(req.Credential.Response.ClientDataJSON)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: clientDataJSON base64url değil", ErrPasskeyInvalid)
+	}
+	attestationObject, err := decodeBase64URL(req.Credential.Response.AttestationObject)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: attestationObject base64url değil", ErrPasskeyInvalid)
+	}
+
+	challenge, ceremony, err := s.consumeCeremony(clientDataJSON)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Başka bir kullanıcının başlattığı tören bu hesaba anahtar ekleyemez
+	if ceremony.Type != model.PasskeyCeremonyRegistration || ceremony.UserID != userID {
+		return nil, nil, ErrPasskeyCeremonyExpired
+	}
+
+	credential, err := utils.VerifyWebAuthnRegistration(utils.GetWebAuthnConfig(), challenge, clientDataJSON, attestationObject)
+	if err != nil {
+		fmt.Printf("⚠️ Passkey kaydı reddedildi (kullanıcı %d): %v\n", userID, err)
+		return nil, nil, fmt.Errorf("%w: %v", ErrPasskeyInvalid, err)
+	}
+
+	credentialID := base64.RawURLEncoding.EncodeToString(credential.ID)
+	exists, err := s.passkeyRepo.ExistsCredentialID(credentialID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("passkey kontrol edilemedi: %v", err)
+	}
+	if exists {
+		return nil, nil, ErrPasskeyAlreadyRegistered
+	}
+
+	count, err := s.passkeyRepo.CountByUser(userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("passkey'ler sayılamadı: %v", err)
+	}
+	if count >= MAX_PASSKEYS_PER_USER {
+		return nil, nil, ErrPasskeyLimitReached
+	}
+
+	passkey := &model.Passkey{
+		UserID:         userID,
+		CredentialID:   credentialID,
+		PublicKey:      credential.PublicKey,
+		Algorithm:      credential.Algorithm,
+		SignCount:      int64(credential.SignCount),
+		AAGUID:         credential.AAGUID,
+		Transports:     strings.Join(filterTransports(req.Credential.Response.Transports), ","),
+		Name:           name,
+		BackupEligible: credential.BackupEligible,
+		BackupState:    credential.BackupState,
+	}
+	if err := s.passkeyRepo.Create(passkey); err != nil {
+		return nil, nil, fmt.Errorf("passkey kaydedilemedi: %v", err)
+	}
+
+	fmt.Printf("🔑 Passkey kaydedildi: kullanıcı %d, passkey %d (%s)\n", userID, passkey.ID, passkey.Name)
+	return toPasskeyResponse(passkey), nil, nil
+}
+
+// ==================== PROFİL ====================
+
+// ListPasskeys kullanıcının kayıtlı passkey'lerini listeler
+func (s *PasskeyService) ListPasskeys(userID uint) ([]model.PasskeyResponse, error) {
+	passkeys, err := s.passkeyRepo.ListByUser(userID)
+	if err != nil {
+		return nil, fmt.Errorf("passkey'ler getirilemedi: %v", err)
+	}
+
+	responses := make([]model.PasskeyResponse, 0, len(passkeys))
+	for i := range passkeys {
+		responses = append(responses, *toPasskeyResponse(&passkeys[i]))
+	}
+	return responses, nil
+}
+
+// RevokePasskey kullanıcının passkey'ini siler; o anahtarla artık giriş yapılamaz
+func (s *PasskeyService) RevokePasskey(userID, passkeyID uint) error {
+	deleted, err := s.passkeyRepo.Delete(userID, passkeyID)
+	if err != nil {
+		return fmt.Errorf("passkey silinemedi: %v", err)
+	}
+	if !deleted {
+		return ErrPasskeyNotFound
+	}
+
+	fmt.Printf("🗑️ Passkey silindi: kullanıcı %d, passkey %d\n", userID, passkeyID)
+	return nil
+}
+
+// ==================== GİRİŞ ====================
+
+// BeginLogin navigator.credentials.get() seçeneklerini üretir
+// Kimlik verilmezse cihazdaki keşfedilebilir passkey'ler önerilir. Kimlik verilip kullanıcı
+// bulunamazsa veya passkey'i yoksa da aynı keşfedilebilir giriş seçenekleri döner
+func (s *PasskeyService) BeginLogin(req *model.BeginPasskeyLoginRequest) (*model.PasskeyRequestOptions, error) {
+	ceremony := model.PasskeyCeremony{Type: model.PasskeyCeremonyLogin}
+	allowCredentials := []model.PasskeyCredentialDescriptor{}
+
+	if identifier := strings.TrimSpace(req.EmailOrPhone); identifier != "" {
+		var user model.User
+		if err := database.DB.Where("email = ? OR phone = ?", identifier, identifier).First(&user).Error; err == nil {
+			passkeys, err := s.passkeyRepo.ListByUser(user.ID)
+			if err != nil {
+				return nil, fmt.Errorf("passkey'ler getirilemedi: %v", err)
+			}
+			if len(passkeys) > 0 {
+				ceremony.UserID = user.ID
+				allowCredentials = toCredentialDescriptors(passkeys)
+			}
+		}
+	}
+
+	challenge, err := s.startCeremony(ceremony)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.PasskeyRequestOptions{
+		Challenge:        challenge,
+		Timeout:          int(utils.WEBAUTHN_CEREMONY_TTL.Milliseconds()),
+		RPID:             utils.GetWebAuthnConfig().RPID,
+		AllowCredentials: allowCredentials,
+		UserVerification: "required",
+	}, nil
+}
+
+// FinishLogin authenticator imzasını doğrular ve MFA doğrulanmış token çifti üretir
+// Şifre kullanılmadığı için şifre süresi kontrolü yapılmaz; hesap ve hastane durumu token üretiminde kontrol edilir
+func (s *PasskeyService) FinishLogin(req *model.FinishPasskeyLoginRequest, client model.ClientInfo) (*model.TokenResponse, error) {
+	clientDataJSON, err := decodeBase64URL(req.Credential.Response.ClientDataJSON)
+	if err != nil {
+		return nil, fmt.Errorf("%w: clientDataJSON base64url değil", ErrPasskeyInvalid)
+	}
+	authenticatorData, err := decodeBase64URL(req.Credential.Response.AuthenticatorData)
+	if err != nil {
+		return nil, fmt.Errorf("%w: authenticatorData base64url değil", ErrPasskeyInvalid)
+	}
+	signature, err := decodeBase64URL(req.Credential.Response.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature base64url değil", ErrPasskeyInvalid)
+	}
+	rawID, err := decodeBase64URL(req.Credential.RawID)
+	if err != nil || len(rawID) == 0 {
+		return nil, fmt.Errorf("%w: rawId base64url değil", ErrPasskeyInvalid)
+	}
+
+	challenge, ceremony, err := s.consumeCeremony(clientDataJSON)
+	if err != nil {
+		return nil, err
+	}
+	if ceremony.Type != model.PasskeyCeremonyLogin {
+		return nil, ErrPasskeyCeremonyExpired
+	}
+
+	passkey, err := s.passkeyRepo.GetByCredentialID(base64.RawURLEncoding.EncodeToString(rawID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPasskeyNotFound
+		}
+		return nil, fmt.Errorf("passkey getirilemedi: %v", err)
+	}
+	// Kimlikle başlatılan girişte sadece o kullanıcının anahtarı kabul edilir
+	if ceremony.UserID != 0 && ceremony.UserID != passkey.UserID {
+		return nil, ErrPasskeyNotFound
+	}
+
+	// Doğrulama hataları brute-force korumasına tabi
+	guard := NewLoginGuardService()
+	guardKey := fmt.Sprintf("passkey:%d", passkey.UserID)
+	if err := guard.CheckLogin(guardKey, client.IP); err != nil {
+		return nil, err
+	}
+
+	authData, err := utils.VerifyWebAuthnAssertion(utils.GetWebAuthnConfig(), challenge, passkey.PublicKey, clientDataJSON, authenticatorData, signature)
+	if err == nil && req.Credential.Response.UserHandle != "" {
+		// Keşfedilebilir girişte authenticator'ın döndürdüğü kullanıcı, anahtarın sahibi olmalı
+		if userHandle, decodeErr := decodeBase64URL(req.Credential.Response.UserHandle); decodeErr != nil ||
+			base64.RawURLEncoding.EncodeToString(userHandle) != passkeyUserHandle(passkey.UserID) {
+			err = fmt.Errorf("%w: userHandle eşleşmiyor", utils.ErrWebAuthnInvalid)
+		}
+	}
+	if err != nil {
+		fmt.Printf("⚠️ Passkey girişi reddedildi (passkey %d): %v\n", passkey.ID, err)
+		guard.RecordLoginFailure(guardKey, client.IP)
+		return nil, fmt.Errorf("%w: %v", ErrPasskeyInvalid, err)
+	}
+
+	// İmza sayacı destekleyen authenticator'larda sayaç her girişte artmalı
+	signCount := int64(authData.SignCount)
+	if !utils.WebAuthnSignCountValid(passkey.SignCount, signCount) {
+		fmt.Printf("🚨 Passkey %d imza sayacı geriye gitti (%d <= %d)\n", passkey.ID, signCount, passkey.SignCount)
+		return nil, ErrPasskeyCloned
+	}
+	updated, err := s.passkeyRepo.RecordUsage(passkey.ID, passkey.SignCount, signCount, authData.BackupState)
+	if err != nil {
+		return nil, fmt.Errorf("passkey kullanımı kaydedilemedi: %v", err)
+	}
+	if !updated {
+		return nil, ErrPasskeyCloned
+	}
+
+	guard.RecordLoginSuccess(guardKey)
+
+	user, err := s.userRepo.GetByID(passkey.UserID)
+	if err != nil {
+		return nil, ErrPasskeyNotFound
+	}
+
+	fmt.Printf("✅ Passkey ile giriş: kullanıcı %d, passkey %d\n", user.ID, passkey.ID)
+	return s.tokenService.IssueTokenPair(user, true, client)
+}
+
+// ==================== HELPER METHODS ====================
+
+// startCeremony rastgele challenge üretir ve tören kaydını Redis'e yazar
+func (s *PasskeyService) startCeremony(ceremony model.PasskeyCeremony) (string, error) {
+	challenge, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return "", fmt.Errorf("challenge üretilemedi: %v", err)
+	}
+
+	data, err := json.Marshal(ceremony)
+	if err != nil {
+		return "", err
+	}
+	if err := database.SavePasskeyCeremony(challenge, data, utils.WEBAUTHN_CEREMONY_TTL); err != nil {
+		return "", fmt.Errorf("passkey işlemi kaydedilemedi: %v", err)
+	}
+	return challenge, nil
+}
+
+// consumeCeremony clientDataJSON'daki challenge'a ait tören kaydını tek kullanımlık olarak alır
+func (s *PasskeyService) consumeCeremony(clientDataJSON []byte) (string, *model.PasskeyCeremony, error) {
+	clientData, err := utils.ParseWebAuthnClientData(clientDataJSON)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %v", ErrPasskeyInvalid, err)
+	}
+	if clientData.Challenge == "" {
+		return "", nil, ErrPasskeyCeremonyExpired
+	}
+
+	data, err := database.ConsumePasskeyCeremony(clientData.Challenge)
+	if err != nil {
+		return "", nil, ErrPasskeyCeremonyExpired
+	}
+
+	var ceremony model.PasskeyCeremony
+	if err := json.Unmarshal(data, &ceremony); err != nil {
+		return "", nil, ErrPasskeyCeremonyExpired
+	}
+	return clientData.Challenge, &ceremony, nil
+}
+
+// passkeyUserHandle kullanıcı ID'sini WebAuthn user handle'ına çevirir (8 byte, base64url)
+// Handle kişisel veri içermez; keşfedilebilir girişte authenticator bunu geri döndürür
+func passkeyUserHandle(userID uint) string {
+	handle := make([]byte, 8)
+	binary.BigEndian.PutUint64(handle, uint64(userID))
+	return base64.RawURLEncoding.EncodeToString(handle)
+}
+
+// decodeBase64URL tarayıcıdan gelen base64url değeri (padding'li veya padding'siz) çözer
+func decodeBase64URL(value string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+}
+
+// toCredentialDescriptors kayıtlı passkey'leri tarayıcıya gönderilecek tanımlayıcılara çevirir
+func toCredentialDescriptors(passkeys []model.Passkey) []model.PasskeyCredentialDescriptor {
+	descriptors := make([]model.PasskeyCredentialDescriptor, 0, len(passkeys))
+	for _, passkey := range passkeys {
+		descriptors = append(descriptors, model.PasskeyCredentialDescriptor{
+			Type:       "public-key",
+			ID:         passkey.CredentialID,
+			Transports: splitTransports(passkey.Transports),
+		})
+	}
+	return descriptors
+}
+
+// splitTransports virgülle saklanan transport listesini diziye çevirir
+func splitTransports(transports string) []string {
+	if transports == "" {
+		return nil
+	}
+	return strings.Split(transports, ",")
+}
+
+// filterTransports tarayıcının bildirdiği transport'lardan bilinenleri tekrarsız olarak alır
+func filterTransports(transports []string) []string {
+	known := map[string]bool{"usb": true, "nfc": true, "ble": true, "internal": true, "hybrid": true, "smart-card": true}
+	var filtered []string
+	for _, transport := range transports {
+		if known[transport] {
+			filtered = append(filtered, transport)
+			known[transport] = false
+		}
+	}
+	return filtered
+}
+
+// toPasskeyResponse passkey kaydını API yanıtına çevirir
+func toPasskeyResponse(passkey *model.Passkey) *model.PasskeyResponse {
+	return &model.PasskeyResponse{
+		ID:         passkey.ID,
+		Name:       passkey.Name,
+		Transports: splitTransports(passkey.Transports),
+		Synced:     passkey.BackupState,
+		CreatedAt:  passkey.CreatedAt,
+		LastUsedAt: passkey.LastUsedAt,
+	}
+}
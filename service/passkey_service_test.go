@@ -0,0 +1,37 @@
+package service
+
+import (
+	"errors"
+	"hospital-platform/model"
+	"hospital-platform/utils"
+	"testing"
+)
+
+// Tören kaydı tek kullanımlıktır: aynı challenge ile imzalanmış yanıt ikinci kez gönderilirse reddedilir
+func TestPasskeyCeremonyIsSingleUse(t *testing.T) {
+	startTestRedis(t)
+	service := NewPasskeyService()
+
+	challenge, err := service.startCeremony(model.PasskeyCeremony{Type: utils.WEBAUTHN_TYPE_GET})
+	if err != nil {
+		t.Fatalf("tören başlatılamadı: %v", err)
+	}
+
+	authenticator := &utils.SoftAuthenticator{Origin: "http://localhost:3000"}
+	_, clientDataJSON, _, err := authenticator.Register("localhost", challenge)
+	if err != nil {
+		t.Fatalf("yanıt üretilemedi: %v", err)
+	}
+
+	got, ceremony, err := service.consumeCeremony(clientDataJSON)
+	if err != nil {
+		t.Fatalf("tören alınamadı: %v", err)
+	}
+	if got != challenge || ceremony.Type != utils.WEBAUTHN_TYPE_GET {
+		t.Fatalf("beklenmeyen tören: %s %+v", got, ceremony)
+	}
+
+	if _, _, err := service.consumeCeremony(clientDataJSON); !errors.Is(err, ErrPasskeyCeremonyExpired) {
+		t.Fatalf("tekrar oynatılan challenge için ErrPasskeyCeremonyExpired beklenirdi, gelen: %v", err)
+	}
+}
@@ -0,0 +1,317 @@
+package service
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"hospital-platform/config"
+	"hospital-platform/database"
+	"hospital-platform/model"
+	"hospital-platform/repository"
+	"hospital-platform/utils"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+// Politika sınırları - hastane bunların dışına çıkamaz
+const (
+	passwordMaxLength       = 128 // Hash işlemini uzun girdilerle yormamak için üst sınır
+	passwordPolicyMinLength = 8
+	passwordHistoryMaxCount = 24 // Saklanan en fazla eski şifre sayısı
+	passwordMaxAgeLimitDays = 3650
+	defaultPasswordDenyList = "config/common_passwords.txt"
+)
+
+var ErrInvalidPasswordChangeToken = errors.New("Geçersiz veya süresi dolmuş şifre değiştirme oturumu")
+
+var (
+	denyListOnce sync.Once
+	denyList     map[string]bool
+)
+
+// PasswordPolicyService - Hastane şifre politikası, şifre geçmişi ve şifre süresi iş mantığı
+type PasswordPolicyService struct {
+	policyRepo *repository.PasswordPolicyRepository
+}
+
+// NewPasswordPolicyService yeni bir şifre politikası servisi oluşturur
+func NewPasswordPolicyService() *PasswordPolicyService {
+	return &PasswordPolicyService{
+		policyRepo: repository.NewPasswordPolicyRepository(),
+	}
+}
+
+// ==================== POLİTİKA YÖNETİMİ ====================
+
+// GetPolicy hastanenin şifre politikasını döndürür; tanımlanmamışsa varsayılan politika döner
+func (s *PasswordPolicyService) GetPolicy(hospitalID uint) (*model.PasswordPolicy, error) {
+	if hospitalID == 0 {
+		return model.DefaultPasswordPolicy(0), nil
+	}
+
+	policy, err := s.policyRepo.GetByHospitalID(hospitalID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.DefaultPasswordPolicy(hospitalID), nil
+		}
+		return nil, fmt.Errorf("şifre politikası getirilemedi: %v", err)
+	}
+	return policy, nil
+}
+
+// UpdatePolicy hastanenin şifre politikasını günceller
+// Yeni kurallar sonraki şifre belirlemelerinde, süre değişikliği bir sonraki girişte geçerli olur
+func (s *PasswordPolicyService) UpdatePolicy(hospitalID uint, req *model.UpdatePasswordPolicyRequest) (*model.PasswordPolicy, []model.ValidationError, error) {
+	var validationErrors []model.ValidationError
+
+	if req.MinLength < passwordPolicyMinLength || req.MinLength > passwordMaxLength {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "min_length",
+			Message: fmt.Sprintf("Minimum uzunluk %d-%d arasında olmalıdır", passwordPolicyMinLength, passwordMaxLength),
+		})
+	}
+	if req.HistoryCount < 0 || req.HistoryCount > passwordHistoryMaxCount {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "history_count",
+			Message: fmt.Sprintf("Şifre geçmişi 0-%d arasında olmalıdır", passwordHistoryMaxCount),
+		})
+	}
+	if req.MaxAgeDays < 0 || req.MaxAgeDays > passwordMaxAgeLimitDays {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "max_age_days",
+			Message: fmt.Sprintf("Şifre geçerlilik süresi 0-%d gün arasında olmalıdır", passwordMaxAgeLimitDays),
+		})
+	}
+	if len(validationErrors) > 0 {
+		return nil, validationErrors, nil
+	}
+
+	policy, err := s.GetPolicy(hospitalID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	policy.MinLength = req.MinLength
+	policy.RequireUpper = req.RequireUpper
+	policy.RequireLower = req.RequireLower
+	policy.RequireDigit = req.RequireDigit
+	policy.RequireSymbol = req.RequireSymbol
+	policy.CheckDenyList = req.CheckDenyList
+	policy.HistoryCount = req.HistoryCount
+	policy.MaxAgeDays = req.MaxAgeDays
+
+	if err := s.policyRepo.Save(policy); err != nil {
+		return nil, nil, fmt.Errorf("şifre politikası kaydedilemedi: %v", err)
+	}
+	return policy, nil, nil
+}
+
+// ==================== ŞİFRE DOĞRULAMA ====================
+
+// ValidatePassword şifreyi hastanenin politikasına göre doğrular
+// user: şifresi değişen mevcut kullanıcı (geçmiş kontrolü için), yeni kullanıcı için nil
+// field: hata mesajlarında kullanılacak alan adı (ör. "password", "new_password")
+func (s *PasswordPolicyService) ValidatePassword(hospitalID uint, user *model.User, field, password string) ([]model.ValidationError, error) {
+	policy, err := s.GetPolicy(hospitalID)
+	if err != nil {
+		return nil, err
+	}
+
+	validationErrors := checkPasswordRules(policy, field, password)
+	if len(validationErrors) > 0 || user == nil || policy.HistoryCount == 0 {
+		return validationErrors, nil
+	}
+
+	reused, err := s.isRecentlyUsed(user, password, policy.HistoryCount)
+	if err != nil {
+		return nil, err
+	}
+	if reused {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   field,
+			Message: fmt.Sprintf("Son %d şifrenizden biri tekrar kullanılamaz", policy.HistoryCount),
+		})
+	}
+	return validationErrors, nil
+}
+
+// checkPasswordRules uzunluk, karakter sınıfı ve yaygın şifre kurallarını kontrol eder
+func checkPasswordRules(policy *model.PasswordPolicy, field, password string) []model.ValidationError {
+	var validationErrors []model.ValidationError
+
+	length := len([]rune(password))
+	if length < policy.MinLength {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   field,
+			Message: fmt.Sprintf("Şifre en az %d karakter olmalıdır", policy.MinLength),
+		})
+	}
+	if length > passwordMaxLength {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   field,
+			Message: fmt.Sprintf("Şifre en fazla %d karakter olabilir", passwordMaxLength),
+		})
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+
+	if policy.RequireUpper && !hasUpper {
+		validationErrors = append(validationErrors, model.ValidationError{Field: field, Message: "Şifre en az bir büyük harf içermelidir"})
+	}
+	if policy.RequireLower && !hasLower {
+		validationErrors = append(validationErrors, model.ValidationError{Field: field, Message: "Şifre en az bir küçük harf içermelidir"})
+	}
+	if policy.RequireDigit && !hasDigit {
+		validationErrors = append(validationErrors, model.ValidationError{Field: field, Message: "Şifre en az bir rakam içermelidir"})
+	}
+	if policy.RequireSymbol && !hasSymbol {
+		validationErrors = append(validationErrors, model.ValidationError{Field: field, Message: "Şifre en az bir özel karakter içermelidir"})
+	}
+
+	if policy.CheckDenyList && isDeniedPassword(password) {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   field,
+			Message: "Bu şifre çok yaygın veya sızdırılmış şifreler listesinde, başka bir şifre seçin",
+		})
+	}
+
+	return validationErrors
+}
+
+// isRecentlyUsed şifrenin mevcut şifre veya son (count-1) eski şifreden biri olup olmadığını kontrol eder
+func (s *PasswordPolicyService) isRecentlyUsed(user *model.User, password string, count int) (bool, error) {
+	hashes := []string{user.Password}
+
+	if count > 1 {
+		history, err := s.policyRepo.GetRecentHistory(user.ID, count-1)
+		if err != nil {
+			return false, fmt.Errorf("şifre geçmişi getirilemedi: %v", err)
+		}
+		for _, entry := range history {
+			hashes = append(hashes, entry.PasswordHash)
+		}
+	}
+
+	for _, hash := range hashes {
+		if hash == "" {
+			continue
+		}
+		ok, _, err := utils.VerifyPassword(password, hash)
+		if errors.Is(err, utils.ErrHasherBusy) {
+			return false, err
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ==================== ŞİFRE DEĞİŞTİRME VE SÜRE ====================
+
+// SetPassword doğrulanmış yeni şifreyi hash'leyip kaydeder, eski hash'i geçmişe ekler
+// Şifre değişiklik zamanı güncellenir ve "şifre değiştirilmeli" işareti kalkar
+func (s *PasswordPolicyService) SetPassword(user *model.User, password string) error {
+	hashed, err := utils.HashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	previousHash := user.Password
+	now := time.Now()
+
+	err = database.DB.Model(user).Updates(map[string]interface{}{
+		"password":             hashed,
+		"password_changed_at":  now,
+		"must_change_password": false,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("şifre kaydedilemedi: %v", err)
+	}
+
+	user.Password = hashed
+	user.PasswordChangedAt = &now
+	user.MustChangePassword = false
+
+	if previousHash != "" {
+		if err := s.policyRepo.AddHistory(user.ID, previousHash, passwordHistoryMaxCount); err != nil {
+			return fmt.Errorf("şifre geçmişi kaydedilemedi: %v", err)
+		}
+	}
+	return nil
+}
+
+// IsChangeRequired kullanıcının bir sonraki girişte şifresini değiştirmesi gerekip gerekmediğini döndürür
+// Yönetici işaretlediyse veya politikadaki geçerlilik süresi dolduysa true döner
+func (s *PasswordPolicyService) IsChangeRequired(user *model.User) (bool, error) {
+	if user.MustChangePassword {
+		return true, nil
+	}
+
+	policy, err := s.GetPolicy(user.HospitalID)
+	if err != nil {
+		return false, err
+	}
+	if policy.MaxAgeDays == 0 {
+		return false, nil
+	}
+
+	changedAt := user.CreatedAt
+	if user.PasswordChangedAt != nil {
+		changedAt = *user.PasswordChangedAt
+	}
+	return time.Now().After(changedAt.AddDate(0, 0, policy.MaxAgeDays)), nil
+}
+
+// ==================== YAYGIN ŞİFRE LİSTESİ ====================
+
+// isDeniedPassword şifrenin yaygın şifre listesinde olup olmadığını kontrol eder (büyük/küçük harf duyarsız)
+func isDeniedPassword(password string) bool {
+	denyListOnce.Do(loadDenyList)
+	return denyList[strings.ToLower(password)]
+}
+
+// loadDenyList PASSWORD_DENYLIST_FILE dosyasını bir kez belleğe yükler
+// Dosya okunamazsa uyarı verilir ve sadece diğer kurallar uygulanır
+func loadDenyList() {
+	denyList = make(map[string]bool)
+
+	path := config.GetEnv("PASSWORD_DENYLIST_FILE", defaultPasswordDenyList)
+	file, err := os.Open(path)
+	if err != nil {
+		fmt.Printf("⚠️  Yaygın şifre listesi yüklenemedi (%s): %v\n", path, err)
+		return
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		denyList[strings.ToLower(line)] = true
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Printf("⚠️  Yaygın şifre listesi okunurken hata (%s): %v\n", path, err)
+	}
+
+	fmt.Printf("🔒 Yaygın şifre listesi yüklendi: %d kayıt\n", len(denyList))
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"hospital-platform/database"
+	"hospital-platform/repository"
+	"hospital-platform/utils"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// RESET_CODE_DIGITS şifre sıfırlama kodunun hane sayısı
+const RESET_CODE_DIGITS = 6
+
+var (
+	ErrResetCodeNotFound = errors.New("Kod süresi dolmuş veya bulunamadı")
+	ErrResetCodeMismatch = errors.New("Kod eşleşmiyor")
+)
+
+// PasswordResetService - Şifre sıfırlama kodlarının üretimi, gönderimi ve doğrulanması
+// Kod yanıtta asla dönmez; sadece kullanıcının kayıtlı telefonuna veya e-postasına gönderilir
+type PasswordResetService struct {
+	userRepo            *repository.UserRepository
+	guard               *LoginGuardService
+	notificationService *NotificationService
+}
+
+// NewPasswordResetService yeni bir şifre sıfırlama servisi oluşturur
+func NewPasswordResetService() *PasswordResetService {
+	return &PasswordResetService{
+		userRepo:            repository.NewUserRepository(),
+		guard:               NewLoginGuardService(),
+		notificationService: NewNotificationService(),
+	}
+}
+
+// SendCode telefon numarasına kayıtlı kullanıcı için kod üretir ve seçilen kanaldan gönderir
+// Numara kayıtlı değilse hata dönmez - hesap varlığı yanıttan anlaşılmamalı
+func (s *PasswordResetService) SendCode(phone, channel, lang string) error {
+	if channel != utils.CHANNEL_SMS && channel != utils.CHANNEL_EMAIL {
+		return utils.ErrUnknownChannel
+	}
+
+	// Telefon başına kod talebi limiti
+	if err := s.guard.CheckResetRequest(phone); err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.GetByPhone(phone)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			fmt.Println("Şifre sıfırlama: telefon numarası sistemde kayıtlı değil")
+			return nil
+		}
+		return fmt.Errorf("kullanıcı sorgulanamadı: %v", err)
+	}
+
+	// Kriptografik olarak güvenli kod - Redis'te sadece HMAC hash'i tutulur
+	code, err := utils.GenerateNumericCode(RESET_CODE_DIGITS)
+	if err != nil {
+		return fmt.Errorf("kod üretilemedi: %v", err)
+	}
+
+	key := NormalizeIdentifier(phone)
+	if err := database.SetResetCode(key, utils.HashCode(key, code)); err != nil {
+		return fmt.Errorf("kod kaydedilemedi: %v", err)
+	}
+
+	// Yeni kod için hatalı deneme sayacını sıfırla
+	if err := s.guard.ClearResetFailures(phone); err != nil {
+		fmt.Println("Deneme sayacı temizlenemedi:", err)
+	}
+
+	to := user.Phone
+	if channel == utils.CHANNEL_EMAIL {
+		to = user.Email
+	}
+
+	err = s.notificationService.Send(channel, to, TEMPLATE_RESET_CODE, lang, map[string]interface{}{
+		"Code":      code,
+		"Minutes":   int(database.RESET_CODE_TTL.Minutes()),
+		"FirstName": user.FirstName,
+	})
+	if err != nil {
+		// Kullanıcıya ulaşmayan kod geçerli kalmamalı
+		database.DeleteResetCode(key)
+		return fmt.Errorf("kod gönderilemedi: %v", err)
+	}
+
+	fmt.Printf("🔐 Şifre sıfırlama kodu gönderildi (kanal: %s, kullanıcı: %d)\n", channel, user.ID)
+	return nil
+}
+
+// VerifyCode kodu saklanan hash ile karşılaştırır; hatalı denemeleri sayar ve limit dolunca kodu iptal eder
+func (s *PasswordResetService) VerifyCode(phone, code string) error {
+	// Bu kod için deneme hakkı kaldı mı?
+	if err := s.guard.CheckResetConfirm(phone); err != nil {
+		return err
+	}
+
+	key := NormalizeIdentifier(phone)
+	storedHash, err := database.GetResetCode(key)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return ErrResetCodeNotFound
+		}
+		return fmt.Errorf("kod okunamadı: %v", err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(utils.HashCode(key, code)), []byte(storedHash)) == 1 {
+		return nil
+	}
+
+	exhausted, err := s.guard.RecordResetFailure(phone, database.RESET_CODE_TTL)
+	if err != nil {
+		fmt.Println("Hatalı deneme kaydedilemedi:", err)
+	}
+	if exhausted {
+		// Deneme hakkı bitti - kodu iptal et, yeni kod istenmeli
+		database.DeleteResetCode(key)
+		return ErrResetCodeExhausted
+	}
+	return ErrResetCodeMismatch
+}
+
+// ConsumeCode şifre değiştikten sonra kodu ve deneme sayacını siler (kod tek kullanımlık)
+func (s *PasswordResetService) ConsumeCode(phone string) {
+	database.DeleteResetCode(NormalizeIdentifier(phone))
+	s.guard.ClearResetFailures(phone)
+}
@@ -0,0 +1,903 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hospital-platform/config"
+	"hospital-platform/database"
+	"hospital-platform/model"
+	"hospital-platform/repository"
+	"hospital-platform/utils"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPlatformInvalidCredentials = errors.New("E-posta veya şifre hatalı")
+	ErrPlatformMFACodeRequired    = errors.New("Doğrulama kodu gerekli")
+	ErrPlatformHospitalNotFound   = errors.New("Hastane bulunamadı")
+	ErrPlatformUserNotFound       = errors.New("Kullanıcı bulunamadı")
+	ErrHospitalStatusUnchanged    = errors.New("Hastane zaten bu durumda")
+	ErrHospitalStatusTransition   = errors.New("Hastanenin mevcut durumunda bu işlem yapılamaz")
+	ErrMasterDataNotFound         = errors.New("Kayıt bulunamadı")
+	ErrMasterDataInUse            = errors.New("Kayıt hastaneler veya personel tarafından kullanılıyor, silinemez")
+)
+
+// platformLoginPrefix operatör giriş denemelerini hastane kullanıcılarınınkinden ayırır
+const platformLoginPrefix = "platform:"
+
+// PlatformActor işlemi yapan platform operatörü ve istemci bilgisi - denetim kaydına yazılır
+type PlatformActor struct {
+	AdminID uint
+	Email   string
+	Client  model.ClientInfo
+}
+
+// PlatformService - Hastanelerin dışındaki platform operatörünün iş mantığı
+// Hastane listeleme/askıya alma, hastaneler arası kullanıcı desteği ve master data yönetimi buradadır.
+// Operatörün yaptığı her işlem PlatformAuditLog'a yazılır
+type PlatformService struct {
+	platformRepo   *repository.PlatformRepository
+	hospitalRepo   *repository.HospitalRepository
+	masterDataRepo *repository.MasterDataRepository
+	userRepo       *repository.UserRepository
+	mfaRepo        *repository.MFARepository
+	tokenService   *TokenService
+	cacheService   *CacheService
+
+	notificationService *NotificationService
+	userStatusService   *UserStatusService
+}
+
+// NewPlatformService yeni bir platform servisi oluşturur
+func NewPlatformService() *PlatformService {
+	return &PlatformService{
+		platformRepo:   repository.NewPlatformRepository(),
+		hospitalRepo:   repository.NewHospitalRepository(),
+		masterDataRepo: repository.NewMasterDataRepository(),
+		userRepo:       repository.NewUserRepository(),
+		mfaRepo:        repository.NewMFARepository(),
+		tokenService:   NewTokenService(),
+		cacheService:   NewCacheService(),
+
+		notificationService: NewNotificationService(),
+		userStatusService:   NewUserStatusService(),
+	}
+}
+
+// ==================== OPERATÖR HESABI ====================
+
+// EnsureBootstrapAdmin PLATFORM_ADMIN_EMAIL / PLATFORM_ADMIN_PASSWORD tanımlıysa ve bu e-postayla operatör yoksa oluşturur
+// Uygulama başlarken çağrılır; mevcut operatörün şifresi değiştirilmez. İlk girişten sonra TOTP kaydı zorunludur
+func (s *PlatformService) EnsureBootstrapAdmin() error {
+	email := strings.ToLower(strings.TrimSpace(config.GetEnv("PLATFORM_ADMIN_EMAIL", "")))
+	password := config.GetEnv("PLATFORM_ADMIN_PASSWORD", "")
+	if email == "" || password == "" {
+		return nil
+	}
+
+	if _, err := s.platformRepo.GetAdminByEmail(email); err == nil {
+		return nil
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("platform operatörü kontrol edilemedi: %v", err)
+	}
+
+	// Operatör şifresi de varsayılan şifre politikasına uymalı
+	validationErrors, err := NewPasswordPolicyService().ValidatePassword(0, nil, "PLATFORM_ADMIN_PASSWORD", password)
+	if err != nil {
+		return err
+	}
+	if len(validationErrors) > 0 {
+		return fmt.Errorf("PLATFORM_ADMIN_PASSWORD şifre politikasına uymuyor: %s", validationErrors[0].Message)
+	}
+
+	hashedPassword, err := utils.HashPassword(password)
+	if err != nil {
+		return fmt.Errorf("şifre hash'lenemedi: %v", err)
+	}
+
+	admin := &model.PlatformAdmin{
+		Email:     email,
+		FirstName: "Platform",
+		LastName:  "Operatörü",
+		Password:  hashedPassword,
+		IsActive:  true,
+	}
+	if err := s.platformRepo.CreateAdmin(admin); err != nil {
+		return fmt.Errorf("platform operatörü oluşturulamadı: %v", err)
+	}
+
+	fmt.Printf("🛡️ Platform operatörü oluşturuldu: %s\n", email)
+	return nil
+}
+
+// Login operatör girişi yapar - TOTP kaydı tamamlanmışsa kod aynı istekte gönderilmelidir
+// Kayıt yapılmamışsa sadece TOTP kaydı endpoint'lerinde geçerli (mfa=false) token döner
+func (s *PlatformService) Login(req *model.PlatformLoginRequest, client model.ClientInfo) (*model.PlatformTokenResponse, error) {
+	identifier := platformLoginPrefix + NormalizeIdentifier(req.Email)
+	guard := NewLoginGuardService()
+
+	if err := guard.CheckLogin(identifier, client.IP); err != nil {
+		return nil, err
+	}
+
+	admin, err := s.platformRepo.GetAdminByEmail(strings.TrimSpace(req.Email))
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("operatör getirilemedi: %v", err)
+	}
+
+	valid := false
+	if admin != nil && admin.IsActive {
+		valid, _, err = utils.VerifyPassword(req.Password, admin.Password)
+		if errors.Is(err, utils.ErrHasherBusy) {
+			return nil, err
+		}
+	}
+	if !valid {
+		if err := guard.RecordLoginFailure(identifier, client.IP); err != nil {
+			return nil, err
+		}
+		return nil, ErrPlatformInvalidCredentials
+	}
+
+	if admin.MFAEnabled {
+		if strings.TrimSpace(req.Code) == "" {
+			return nil, ErrPlatformMFACodeRequired
+		}
+		if err := s.verifyTOTP(admin, req.Code); err != nil {
+			if errors.Is(err, ErrInvalidMFACode) {
+				if err := guard.RecordLoginFailure(identifier, client.IP); err != nil {
+					return nil, err
+				}
+			}
+			return nil, err
+		}
+	}
+
+	if err := guard.RecordLoginSuccess(identifier); err != nil {
+		fmt.Println("Giriş sayaçları temizlenemedi:", err)
+	}
+
+	now := time.Now()
+	admin.LastLoginAt = &now
+	if err := s.platformRepo.UpdateAdmin(admin); err != nil {
+		return nil, fmt.Errorf("operatör güncellenemedi: %v", err)
+	}
+
+	actor := &PlatformActor{AdminID: admin.ID, Email: admin.Email, Client: client}
+	if err := s.audit(actor, model.PlatformActionLogin, "", 0, nil, "", map[string]interface{}{"mfa": admin.MFAEnabled}); err != nil {
+		return nil, err
+	}
+
+	return s.issueToken(admin, admin.MFAEnabled)
+}
+
+// StartMFAEnrollment operatör için yeni TOTP secret'ı üretir, kod doğrulanana kadar aktif olmaz
+func (s *PlatformService) StartMFAEnrollment(adminID uint) (*model.MFAEnrollResponse, error) {
+	admin, err := s.platformRepo.GetAdminByID(adminID)
+	if err != nil {
+		return nil, fmt.Errorf("operatör bulunamadı: %v", err)
+	}
+	if admin.MFAEnabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	enrollment, err := utils.GenerateTOTP(admin.Email)
+	if err != nil {
+		return nil, fmt.Errorf("TOTP anahtarı üretilemedi: %v", err)
+	}
+	encrypted, err := utils.EncryptString(enrollment.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("TOTP anahtarı şifrelenemedi: %v", err)
+	}
+
+	admin.TOTPSecret = encrypted
+	if err := s.platformRepo.UpdateAdmin(admin); err != nil {
+		return nil, fmt.Errorf("TOTP anahtarı kaydedilemedi: %v", err)
+	}
+
+	return &model.MFAEnrollResponse{
+		Secret:     enrollment.Secret,
+		OTPAuthURL: enrollment.OTPAuthURL,
+		QRCode:     enrollment.QRCode,
+	}, nil
+}
+
+// ConfirmMFAEnrollment ilk kodu doğrular, TOTP'yi aktif eder ve MFA doğrulanmış yeni token döndürür
+func (s *PlatformService) ConfirmMFAEnrollment(actor *PlatformActor, code string) (*model.PlatformTokenResponse, error) {
+	admin, err := s.platformRepo.GetAdminByID(actor.AdminID)
+	if err != nil {
+		return nil, fmt.Errorf("operatör bulunamadı: %v", err)
+	}
+	if admin.MFAEnabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	if admin.TOTPSecret == "" {
+		return nil, ErrMFANotEnrolled
+	}
+
+	if err := s.verifyTOTP(admin, code); err != nil {
+		return nil, err
+	}
+
+	admin.MFAEnabled = true
+	if err := s.platformRepo.UpdateAdmin(admin); err != nil {
+		return nil, fmt.Errorf("TOTP aktif edilemedi: %v", err)
+	}
+
+	if err := s.audit(actor, model.PlatformActionMFAEnroll, "", 0, nil, "", nil); err != nil {
+		return nil, err
+	}
+
+	return s.issueToken(admin, true)
+}
+
+// ==================== HASTANELER ====================
+
+// ListHospitals hastaneleri arama, durum ve il filtresiyle sayfalı listeler
+func (s *PlatformService) ListHospitals(actor *PlatformActor, req *model.PlatformHospitalListRequest) (*model.PlatformHospitalListResponse, []model.ValidationError, error) {
+	validationErrors := validatePage(req.Page, req.PageSize)
+	switch req.Status {
+	case "", model.HospitalStatusActive, model.HospitalStatusSuspended, model.HospitalStatusPending, model.HospitalStatusRejected:
+	default:
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "status",
+			Message: "Durum active, suspended, pending veya rejected olmalıdır",
+		})
+	}
+	if len(validationErrors) > 0 {
+		return nil, validationErrors, nil
+	}
+
+	hospitals, total, err := s.platformRepo.ListHospitals(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("hastaneler getirilemedi: %v", err)
+	}
+
+	if err := s.audit(actor, model.PlatformActionHospitalList, model.PlatformTargetHospital, 0, nil, "", map[string]interface{}{
+		"filters": req,
+		"results": total,
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	return &model.PlatformHospitalListResponse{
+		Data:       hospitals,
+		Pagination: newPaginationInfo(req.Page, req.PageSize, total),
+	}, nil, nil
+}
+
+// GetHospital hastanenin detayını sayılarla getirir
+func (s *PlatformService) GetHospital(actor *PlatformActor, hospitalID uint) (*model.PlatformHospitalDetail, error) {
+	detail, err := s.platformRepo.GetHospitalDetail(hospitalID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPlatformHospitalNotFound
+		}
+		return nil, fmt.Errorf("hastane getirilemedi: %v", err)
+	}
+	if detail.Documents, err = s.hospitalRepo.ListDocuments(hospitalID); err != nil {
+		return nil, fmt.Errorf("belgeler getirilemedi: %v", err)
+	}
+
+	// Hastane verisine erişim de kayıt altına alınır
+	if err := s.audit(actor, model.PlatformActionHospitalView, model.PlatformTargetHospital, hospitalID, &hospitalID, "", nil); err != nil {
+		return nil, err
+	}
+	return detail, nil
+}
+
+// hospitalStatusTransition operatörün hastane durumu üzerindeki bir işlemi
+type hospitalStatusTransition struct {
+	action         string
+	from           string // İşlemin yapılabildiği tek durum - örn. askıdan çıkarma onay bekleyen başvuruyu aktif edemez
+	to             string
+	reasonRequired bool
+}
+
+var (
+	hospitalSuspend    = hospitalStatusTransition{model.PlatformActionHospitalSuspend, model.HospitalStatusActive, model.HospitalStatusSuspended, true}
+	hospitalReactivate = hospitalStatusTransition{model.PlatformActionHospitalActivate, model.HospitalStatusSuspended, model.HospitalStatusActive, true}
+	hospitalApprove    = hospitalStatusTransition{model.PlatformActionHospitalApprove, model.HospitalStatusPending, model.HospitalStatusActive, false}
+	hospitalReject     = hospitalStatusTransition{model.PlatformActionHospitalReject, model.HospitalStatusPending, model.HospitalStatusRejected, true}
+)
+
+// SuspendHospital hastaneyi askıya alır - kullanıcıları ve API anahtarları bir sonraki istekte reddedilir
+func (s *PlatformService) SuspendHospital(actor *PlatformActor, hospitalID uint, reason string) ([]model.ValidationError, error) {
+	_, validationErrors, err := s.changeHospitalStatus(actor, hospitalID, hospitalSuspend, reason)
+	return validationErrors, err
+}
+
+// ReactivateHospital askıya alınmış hastaneyi tekrar aktif eder
+func (s *PlatformService) ReactivateHospital(actor *PlatformActor, hospitalID uint, reason string) ([]model.ValidationError, error) {
+	_, validationErrors, err := s.changeHospitalStatus(actor, hospitalID, hospitalReactivate, reason)
+	return validationErrors, err
+}
+
+// ApproveHospital onay bekleyen kayıt başvurusunu onaylar - hastane tüm özellikleriyle kullanılabilir hale gelir
+// Faaliyet izin belgesi yüklenmemiş başvuru onaylanamaz
+func (s *PlatformService) ApproveHospital(actor *PlatformActor, hospitalID uint, reason string) ([]model.ValidationError, error) {
+	count, err := s.hospitalRepo.CountDocuments(hospitalID, model.DocumentTypeLicense)
+	if err != nil {
+		return nil, fmt.Errorf("belgeler sayılamadı: %v", err)
+	}
+	if count == 0 {
+		return []model.ValidationError{{
+			Field:   "documents",
+			Message: "Başvuruda faaliyet izin belgesi (license) yok",
+		}}, nil
+	}
+
+	hospital, validationErrors, err := s.changeHospitalStatus(actor, hospitalID, hospitalApprove, reason)
+	if hospital != nil {
+		s.notifyApplicationDecision(hospital, TEMPLATE_HOSPITAL_APPROVED, reason)
+	}
+	return validationErrors, err
+}
+
+// RejectHospital onay bekleyen başvuruyu gerekçesiyle reddeder
+// Başvuran gerekçeyi başvuru ekranında görür, düzeltip tekrar gönderebilir
+func (s *PlatformService) RejectHospital(actor *PlatformActor, hospitalID uint, reason string) ([]model.ValidationError, error) {
+	hospital, validationErrors, err := s.changeHospitalStatus(actor, hospitalID, hospitalReject, reason)
+	if hospital != nil {
+		s.notifyApplicationDecision(hospital, TEMPLATE_HOSPITAL_REJECTED, reason)
+	}
+	return validationErrors, err
+}
+
+// GetHospitalDocument başvuru belgesini içeriğiyle getirir - belge görüntüleme denetim kaydına yazılır
+func (s *PlatformService) GetHospitalDocument(actor *PlatformActor, hospitalID, documentID uint) (*model.HospitalDocument, error) {
+	document, err := s.hospitalRepo.GetDocument(hospitalID, documentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDocumentNotFound
+		}
+		return nil, fmt.Errorf("belge getirilemedi: %v", err)
+	}
+
+	if err := s.audit(actor, model.PlatformActionDocumentView, model.PlatformTargetDocument, documentID, &hospitalID, "", map[string]interface{}{
+		"type":   document.Type,
+		"sha256": document.SHA256,
+	}); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// changeHospitalStatus hastane durumunu değiştirir, önbelleği günceller ve denetim kaydı yazar
+// Başarılı olursa hastanenin değişiklik öncesi kaydını döndürür (bildirimler için)
+func (s *PlatformService) changeHospitalStatus(actor *PlatformActor, hospitalID uint, transition hospitalStatusTransition, reason string) (*model.Hospital, []model.ValidationError, error) {
+	reason = strings.TrimSpace(reason)
+	if transition.reasonRequired && reason == "" {
+		return nil, []model.ValidationError{{Field: "reason", Message: "Gerekçe zorunludur"}}, nil
+	}
+
+	hospital, err := s.hospitalRepo.GetByID(hospitalID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrPlatformHospitalNotFound
+		}
+		return nil, nil, fmt.Errorf("hastane getirilemedi: %v", err)
+	}
+	current := hospital.Status
+	if current == transition.to {
+		return nil, nil, ErrHospitalStatusUnchanged
+	}
+	if current != transition.from {
+		return nil, nil, ErrHospitalStatusTransition
+	}
+
+	if err := s.platformRepo.UpdateHospitalStatus(hospitalID, transition.from, transition.to, reason, actor.AdminID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Kontrol ile güncelleme arasında başka bir operatör durumu değiştirdi
+			return nil, nil, ErrHospitalStatusTransition
+		}
+		return nil, nil, fmt.Errorf("hastane durumu güncellenemedi: %v", err)
+	}
+
+	// Middleware önbellekten okur - değişiklik anında geçerli olsun
+	if err := database.SetHospitalStatus(hospitalID, transition.to); err != nil {
+		return nil, nil, fmt.Errorf("hastane durumu önbelleğe yazılamadı: %v", err)
+	}
+
+	fmt.Printf("🛡️ Hastane durumu değişti: hastane=%d durum=%s operatör=%d\n", hospitalID, transition.to, actor.AdminID)
+
+	s.auditChange(actor, transition.action, model.PlatformTargetHospital, hospitalID, &hospitalID, reason, map[string]interface{}{
+		"from": current,
+		"to":   transition.to,
+	})
+	return hospital, nil, nil
+}
+
+// notifyApplicationDecision başvuru sonucunu hastanenin başvuruda verdiği e-posta adresine bildirir
+// Bildirim gönderilemezse işlem geri alınmaz; başvuran durumu başvuru ekranında da görür
+func (s *PlatformService) notifyApplicationDecision(hospital *model.Hospital, templateName, reason string) {
+	err := s.notificationService.Send(utils.CHANNEL_EMAIL, hospital.Email, templateName, ResolveLanguage("", ""), map[string]interface{}{
+		"HospitalName": hospital.Name,
+		"Reason":       reason,
+	})
+	if err != nil {
+		fmt.Printf("⚠️ Başvuru sonucu bildirilemedi (hastane %d): %v\n", hospital.ID, err)
+	}
+}
+
+// ==================== KULLANICI DESTEĞİ ====================
+
+// SearchUsers tüm hastanelerde kullanıcı arar
+func (s *PlatformService) SearchUsers(actor *PlatformActor, req *model.PlatformUserSearchRequest) (*model.PlatformUserListResponse, []model.ValidationError, error) {
+	validationErrors := validatePage(req.Page, req.PageSize)
+	if len(strings.TrimSpace(req.Query)) < 3 {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "query",
+			Message: "Arama en az 3 karakter olmalıdır",
+		})
+	}
+	if len(validationErrors) > 0 {
+		return nil, validationErrors, nil
+	}
+
+	users, total, err := s.platformRepo.SearchUsers(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("kullanıcılar aranamadı: %v", err)
+	}
+
+	// Kişisel veri araması kayıt altına alınır
+	if err := s.audit(actor, model.PlatformActionUserSearch, model.PlatformTargetUser, 0, req.HospitalID, "", map[string]interface{}{
+		"query":   req.Query,
+		"results": total,
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	return &model.PlatformUserListResponse{
+		Data:       users,
+		Pagination: newPaginationInfo(req.Page, req.PageSize, total),
+	}, nil, nil
+}
+
+// UnlockUser kullanıcının giriş ve şifre sıfırlama kilitlerini kaldırır
+func (s *PlatformService) UnlockUser(actor *PlatformActor, userID uint, reason string) ([]model.ValidationError, error) {
+	return s.supportAction(actor, userID, reason, model.PlatformActionUserUnlock, func(user *model.User) error {
+		return NewLoginGuardService().UnlockIdentifiers(user.Email, user.Phone)
+	})
+}
+
+// ResetUserMFA kullanıcının TOTP kaydını ve kurtarma kodlarını siler, tüm oturumlarını kapatır
+// Telefonunu kaybeden ve kurtarma kodu kalmayan kullanıcı için; kullanıcı bir sonraki girişte yeniden kayıt yapar
+func (s *PlatformService) ResetUserMFA(actor *PlatformActor, userID uint, reason string) ([]model.ValidationError, error) {
+	return s.supportAction(actor, userID, reason, model.PlatformActionUserResetMFA, func(user *model.User) error {
+		user.MFAEnabled = false
+		user.TOTPSecret = ""
+		if err := s.userRepo.Update(user); err != nil {
+			return fmt.Errorf("MFA sıfırlanamadı: %v", err)
+		}
+		if err := s.mfaRepo.DeleteRecoveryCodes(user.ID); err != nil {
+			return fmt.Errorf("kurtarma kodları silinemedi: %v", err)
+		}
+		return s.tokenService.RevokeAllForUser(user.ID)
+	})
+}
+
+// RevokeUserSessions kullanıcının tüm cihazlardaki oturumlarını sonlandırır
+func (s *PlatformService) RevokeUserSessions(actor *PlatformActor, userID uint, reason string) ([]model.ValidationError, error) {
+	return s.supportAction(actor, userID, reason, model.PlatformActionUserRevoke, func(user *model.User) error {
+		return s.tokenService.RevokeAllForUser(user.ID)
+	})
+}
+
+// ExpireUserPassword kullanıcının bir sonraki girişte şifresini değiştirmesini zorunlu kılar
+func (s *PlatformService) ExpireUserPassword(actor *PlatformActor, userID uint, reason string) ([]model.ValidationError, error) {
+	return s.supportAction(actor, userID, reason, model.PlatformActionUserExpire, func(user *model.User) error {
+		user.MustChangePassword = true
+		if err := s.userRepo.Update(user); err != nil {
+			return fmt.Errorf("kullanıcı güncellenemedi: %v", err)
+		}
+		return nil
+	})
+}
+
+// ChangeUserStatus kullanıcının hesap durumunu değiştirir (askıya alma, kilitleme, tekrar aktif etme)
+// Platform işlemlerinde aktif etme dahil her geçiş gerekçe ister
+func (s *PlatformService) ChangeUserStatus(actor *PlatformActor, userID uint, req *model.ChangeUserStatusRequest) (*model.UserStatusResponse, []model.ValidationError, error) {
+	if strings.TrimSpace(req.Reason) == "" {
+		return nil, []model.ValidationError{{Field: "reason", Message: "Gerekçe zorunludur"}}, nil
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrPlatformUserNotFound
+		}
+		return nil, nil, fmt.Errorf("kullanıcı getirilemedi: %v", err)
+	}
+
+	fromStatus := user.Status
+	response, validationErrors, err := s.userStatusService.ChangeStatusByPlatform(actor.AdminID, user, req, actor.Client)
+	if len(validationErrors) > 0 || err != nil {
+		return nil, validationErrors, err
+	}
+
+	s.auditChange(actor, model.PlatformActionUserStatus, model.PlatformTargetUser, user.ID, &user.HospitalID, response.StatusReason, map[string]interface{}{
+		"from": fromStatus,
+		"to":   response.Status,
+	})
+	return response, nil, nil
+}
+
+// supportAction gerekçeyi doğrular, kullanıcıyı bulur, işlemi uygular ve denetim kaydı yazar
+func (s *PlatformService) supportAction(actor *PlatformActor, userID uint, reason, action string, apply func(user *model.User) error) ([]model.ValidationError, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return []model.ValidationError{{Field: "reason", Message: "Gerekçe zorunludur"}}, nil
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPlatformUserNotFound
+		}
+		return nil, fmt.Errorf("kullanıcı getirilemedi: %v", err)
+	}
+
+	if err := apply(user); err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("🛡️ Platform destek işlemi: %s kullanıcı=%d operatör=%d\n", action, user.ID, actor.AdminID)
+
+	s.auditChange(actor, action, model.PlatformTargetUser, user.ID, &user.HospitalID, reason, nil)
+	return nil, nil
+}
+
+// ==================== MASTER DATA ====================
+
+// CreateProvince yeni il ekler
+func (s *PlatformService) CreateProvince(actor *PlatformActor, req *model.ProvinceRequest) (*model.Province, []model.ValidationError, error) {
+	province := &model.Province{Name: strings.TrimSpace(req.Name)}
+	validationErrors, err := s.validateName(&model.Province{}, province.Name, "", 0, 0)
+	if len(validationErrors) > 0 || err != nil {
+		return nil, validationErrors, err
+	}
+	return province, nil, s.saveMasterData(actor, model.PlatformActionMasterCreate, model.PlatformTargetProvince, province, &province.ID, req)
+}
+
+// UpdateProvince ilin adını günceller
+func (s *PlatformService) UpdateProvince(actor *PlatformActor, id uint, req *model.ProvinceRequest) (*model.Province, []model.ValidationError, error) {
+	var province model.Province
+	if err := s.getMasterData(&province, id); err != nil {
+		return nil, nil, err
+	}
+	province.Name = strings.TrimSpace(req.Name)
+	validationErrors, err := s.validateName(&model.Province{}, province.Name, "", 0, id)
+	if len(validationErrors) > 0 || err != nil {
+		return nil, validationErrors, err
+	}
+	return &province, nil, s.saveMasterData(actor, model.PlatformActionMasterUpdate, model.PlatformTargetProvince, &province, &province.ID, req)
+}
+
+// CreateDistrict ile yeni ilçe ekler
+func (s *PlatformService) CreateDistrict(actor *PlatformActor, req *model.DistrictRequest) (*model.District, []model.ValidationError, error) {
+	district := &model.District{ProvinceID: req.ProvinceID, Name: strings.TrimSpace(req.Name)}
+	validationErrors, err := s.validateChild(&model.Province{}, "province_id", req.ProvinceID, &model.District{}, district.Name, 0)
+	if len(validationErrors) > 0 || err != nil {
+		return nil, validationErrors, err
+	}
+	return district, nil, s.saveMasterData(actor, model.PlatformActionMasterCreate, model.PlatformTargetDistrict, district, &district.ID, req)
+}
+
+// UpdateDistrict ilçenin adını veya bağlı olduğu ili günceller
+func (s *PlatformService) UpdateDistrict(actor *PlatformActor, id uint, req *model.DistrictRequest) (*model.District, []model.ValidationError, error) {
+	var district model.District
+	if err := s.getMasterData(&district, id); err != nil {
+		return nil, nil, err
+	}
+	district.ProvinceID = req.ProvinceID
+	district.Name = strings.TrimSpace(req.Name)
+	validationErrors, err := s.validateChild(&model.Province{}, "province_id", req.ProvinceID, &model.District{}, district.Name, id)
+	if len(validationErrors) > 0 || err != nil {
+		return nil, validationErrors, err
+	}
+	return &district, nil, s.saveMasterData(actor, model.PlatformActionMasterUpdate, model.PlatformTargetDistrict, &district, &district.ID, req)
+}
+
+// CreateJobGroup yeni meslek grubu ekler
+func (s *PlatformService) CreateJobGroup(actor *PlatformActor, req *model.JobGroupRequest) (*model.JobGroup, []model.ValidationError, error) {
+	jobGroup := &model.JobGroup{Name: strings.TrimSpace(req.Name)}
+	validationErrors, err := s.validateName(&model.JobGroup{}, jobGroup.Name, "", 0, 0)
+	if len(validationErrors) > 0 || err != nil {
+		return nil, validationErrors, err
+	}
+	return jobGroup, nil, s.saveMasterData(actor, model.PlatformActionMasterCreate, model.PlatformTargetJobGroup, jobGroup, &jobGroup.ID, req)
+}
+
+// UpdateJobGroup meslek grubunun adını günceller
+func (s *PlatformService) UpdateJobGroup(actor *PlatformActor, id uint, req *model.JobGroupRequest) (*model.JobGroup, []model.ValidationError, error) {
+	var jobGroup model.JobGroup
+	if err := s.getMasterData(&jobGroup, id); err != nil {
+		return nil, nil, err
+	}
+	jobGroup.Name = strings.TrimSpace(req.Name)
+	validationErrors, err := s.validateName(&model.JobGroup{}, jobGroup.Name, "", 0, id)
+	if len(validationErrors) > 0 || err != nil {
+		return nil, validationErrors, err
+	}
+	return &jobGroup, nil, s.saveMasterData(actor, model.PlatformActionMasterUpdate, model.PlatformTargetJobGroup, &jobGroup, &jobGroup.ID, req)
+}
+
+// CreateJobTitle meslek grubuna yeni unvan ekler
+func (s *PlatformService) CreateJobTitle(actor *PlatformActor, req *model.JobTitleRequest) (*model.JobTitle, []model.ValidationError, error) {
+	jobTitle := &model.JobTitle{JobGroupID: req.JobGroupID, Name: strings.TrimSpace(req.Name), IsUnique: req.IsUnique}
+	validationErrors, err := s.validateChild(&model.JobGroup{}, "job_group_id", req.JobGroupID, &model.JobTitle{}, jobTitle.Name, 0)
+	if len(validationErrors) > 0 || err != nil {
+		return nil, validationErrors, err
+	}
+	return jobTitle, nil, s.saveMasterData(actor, model.PlatformActionMasterCreate, model.PlatformTargetJobTitle, jobTitle, &jobTitle.ID, req)
+}
+
+// UpdateJobTitle unvanın adını, grubunu veya tekillik kuralını günceller
+// Tekillik sonradan açılırsa mevcut personel etkilenmez, kural yeni kayıt ve güncellemelerde uygulanır
+func (s *PlatformService) UpdateJobTitle(actor *PlatformActor, id uint, req *model.JobTitleRequest) (*model.JobTitle, []model.ValidationError, error) {
+	var jobTitle model.JobTitle
+	if err := s.getMasterData(&jobTitle, id); err != nil {
+		return nil, nil, err
+	}
+	jobTitle.JobGroupID = req.JobGroupID
+	jobTitle.Name = strings.TrimSpace(req.Name)
+	jobTitle.IsUnique = req.IsUnique
+	validationErrors, err := s.validateChild(&model.JobGroup{}, "job_group_id", req.JobGroupID, &model.JobTitle{}, jobTitle.Name, id)
+	if len(validationErrors) > 0 || err != nil {
+		return nil, validationErrors, err
+	}
+	return &jobTitle, nil, s.saveMasterData(actor, model.PlatformActionMasterUpdate, model.PlatformTargetJobTitle, &jobTitle, &jobTitle.ID, req)
+}
+
+// CreatePolyclinicType yeni poliklinik türü ekler
+func (s *PlatformService) CreatePolyclinicType(actor *PlatformActor, req *model.PolyclinicTypeRequest) (*model.PolyclinicType, []model.ValidationError, error) {
+	polyclinicType := &model.PolyclinicType{Name: strings.TrimSpace(req.Name), Description: strings.TrimSpace(req.Description)}
+	validationErrors, err := s.validateName(&model.PolyclinicType{}, polyclinicType.Name, "", 0, 0)
+	if len(validationErrors) > 0 || err != nil {
+		return nil, validationErrors, err
+	}
+	return polyclinicType, nil, s.saveMasterData(actor, model.PlatformActionMasterCreate, model.PlatformTargetPolyclinicType, polyclinicType, &polyclinicType.ID, req)
+}
+
+// UpdatePolyclinicType poliklinik türünün adını ve açıklamasını günceller
+func (s *PlatformService) UpdatePolyclinicType(actor *PlatformActor, id uint, req *model.PolyclinicTypeRequest) (*model.PolyclinicType, []model.ValidationError, error) {
+	var polyclinicType model.PolyclinicType
+	if err := s.getMasterData(&polyclinicType, id); err != nil {
+		return nil, nil, err
+	}
+	polyclinicType.Name = strings.TrimSpace(req.Name)
+	polyclinicType.Description = strings.TrimSpace(req.Description)
+	validationErrors, err := s.validateName(&model.PolyclinicType{}, polyclinicType.Name, "", 0, id)
+	if len(validationErrors) > 0 || err != nil {
+		return nil, validationErrors, err
+	}
+	return &polyclinicType, nil, s.saveMasterData(actor, model.PlatformActionMasterUpdate, model.PlatformTargetPolyclinicType, &polyclinicType, &polyclinicType.ID, req)
+}
+
+// DeleteMasterData kullanılmayan master data kaydını siler
+// targetType: province, district, job_group, job_title, polyclinic_type
+func (s *PlatformService) DeleteMasterData(actor *PlatformActor, targetType string, id uint) error {
+	var record interface{}
+	switch targetType {
+	case model.PlatformTargetProvince:
+		record = &model.Province{}
+	case model.PlatformTargetDistrict:
+		record = &model.District{}
+	case model.PlatformTargetJobGroup:
+		record = &model.JobGroup{}
+	case model.PlatformTargetJobTitle:
+		record = &model.JobTitle{}
+	case model.PlatformTargetPolyclinicType:
+		record = &model.PolyclinicType{}
+	default:
+		return ErrMasterDataNotFound
+	}
+
+	if err := s.getMasterData(record, id); err != nil {
+		return err
+	}
+
+	inUse, err := s.masterDataRepo.IsInUse(targetType, id)
+	if err != nil {
+		return fmt.Errorf("kullanım kontrol edilemedi: %v", err)
+	}
+	if inUse {
+		return ErrMasterDataInUse
+	}
+
+	if err := s.masterDataRepo.Delete(record); err != nil {
+		return fmt.Errorf("kayıt silinemedi: %v", err)
+	}
+	s.invalidateMasterData()
+
+	s.auditChange(actor, model.PlatformActionMasterDelete, targetType, id, nil, "", map[string]interface{}{"record": record})
+	return nil
+}
+
+// ==================== DENETİM KAYDI ====================
+
+// ListAuditLogs denetim kayıtlarını filtreli ve sayfalı listeler
+func (s *PlatformService) ListAuditLogs(req *model.PlatformAuditLogListRequest) (*model.PlatformAuditLogListResponse, []model.ValidationError, error) {
+	if validationErrors := validatePage(req.Page, req.PageSize); len(validationErrors) > 0 {
+		return nil, validationErrors, nil
+	}
+
+	logs, total, err := s.platformRepo.ListAuditLogs(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("denetim kayıtları getirilemedi: %v", err)
+	}
+
+	return &model.PlatformAuditLogListResponse{
+		Data:       logs,
+		Pagination: newPaginationInfo(req.Page, req.PageSize, total),
+	}, nil, nil
+}
+
+// ==================== HELPER METHODS ====================
+
+// issueToken operatör için platform token'ı üretir
+func (s *PlatformService) issueToken(admin *model.PlatformAdmin, mfa bool) (*model.PlatformTokenResponse, error) {
+	token, err := utils.GeneratePlatformToken(admin.ID, admin.Email, mfa)
+	if err != nil {
+		return nil, fmt.Errorf("platform token'ı üretilemedi: %v", err)
+	}
+
+	return &model.PlatformTokenResponse{
+		Token:                 token,
+		TokenType:             "Bearer",
+		ExpiresIn:             int(utils.GetPlatformTokenTTL().Seconds()),
+		MFA:                   mfa,
+		MFAEnrollmentRequired: !admin.MFAEnabled,
+	}, nil
+}
+
+// verifyTOTP operatörün kodunu doğrular ve aynı kodun tekrar kullanılmasını engeller
+func (s *PlatformService) verifyTOTP(admin *model.PlatformAdmin, code string) error {
+	secret, err := utils.DecryptString(admin.TOTPSecret)
+	if err != nil {
+		return fmt.Errorf("TOTP anahtarı çözülemedi: %v", err)
+	}
+
+	code = strings.TrimSpace(code)
+	if !utils.ValidateTOTP(code, secret) {
+		return ErrInvalidMFACode
+	}
+
+	firstUse, err := database.MarkPlatformTOTPCodeUsed(admin.ID, code, totpReplayWindow)
+	if err != nil {
+		return fmt.Errorf("TOTP kodu işaretlenemedi: %v", err)
+	}
+	if !firstUse {
+		return ErrInvalidMFACode
+	}
+	return nil
+}
+
+// audit denetim kaydı yazar - okuma işlemlerinde kayıt yazılamazsa veri döndürülmez
+func (s *PlatformService) audit(actor *PlatformActor, action, targetType string, targetID uint, hospitalID *uint, reason string, details map[string]interface{}) error {
+	log := &model.PlatformAuditLog{
+		AdminID:    actor.AdminID,
+		AdminEmail: actor.Email,
+		Action:     action,
+		TargetType: targetType,
+		HospitalID: hospitalID,
+		Reason:     reason,
+		IP:         actor.Client.IP,
+		UserAgent:  actor.Client.UserAgent,
+	}
+	if targetID != 0 {
+		log.TargetID = &targetID
+	}
+	if details != nil {
+		encoded, err := json.Marshal(details)
+		if err != nil {
+			return fmt.Errorf("denetim kaydı hazırlanamadı: %v", err)
+		}
+		log.Details = string(encoded)
+	}
+
+	if err := s.platformRepo.CreateAuditLog(log); err != nil {
+		return fmt.Errorf("denetim kaydı yazılamadı: %v", err)
+	}
+	return nil
+}
+
+// auditChange tamamlanmış bir değişikliğin denetim kaydını yazar
+// Değişiklik geri alınamayacağı için kayıt hatası isteği başarısız yapmaz, loglanır
+func (s *PlatformService) auditChange(actor *PlatformActor, action, targetType string, targetID uint, hospitalID *uint, reason string, details map[string]interface{}) {
+	if err := s.audit(actor, action, targetType, targetID, hospitalID, reason, details); err != nil {
+		fmt.Printf("⚠️ PLATFORM AUDIT: %s işlemi kaydedilemedi (operatör=%d hedef=%s/%d): %v\n", action, actor.AdminID, targetType, targetID, err)
+	}
+}
+
+// getMasterData master data kaydını ID ile getirir
+func (s *PlatformService) getMasterData(record interface{}, id uint) error {
+	if err := s.masterDataRepo.GetByID(record, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrMasterDataNotFound
+		}
+		return fmt.Errorf("kayıt getirilemedi: %v", err)
+	}
+	return nil
+}
+
+// saveMasterData kaydı oluşturur veya günceller, master data önbelleğini temizler ve denetim kaydı yazar
+func (s *PlatformService) saveMasterData(actor *PlatformActor, action, targetType string, record interface{}, id *uint, req interface{}) error {
+	var err error
+	if action == model.PlatformActionMasterCreate {
+		err = s.masterDataRepo.Create(record)
+	} else {
+		err = s.masterDataRepo.Update(record)
+	}
+	if err != nil {
+		return fmt.Errorf("kayıt kaydedilemedi: %v", err)
+	}
+	s.invalidateMasterData()
+
+	s.auditChange(actor, action, targetType, *id, nil, "", map[string]interface{}{"request": req})
+	return nil
+}
+
+// invalidateMasterData dropdown önbelleklerini temizler - değişiklik hastanelere hemen yansısın
+func (s *PlatformService) invalidateMasterData() {
+	if err := s.cacheService.InvalidateAllMasterData(); err != nil {
+		fmt.Printf("⚠️ Master data önbelleği temizlenemedi: %v\n", err)
+	}
+}
+
+// validateName adın dolu ve kapsamı içinde benzersiz olduğunu kontrol eder
+func (s *PlatformService) validateName(table interface{}, name, scopeColumn string, scopeID, excludeID uint) ([]model.ValidationError, error) {
+	if name == "" {
+		return []model.ValidationError{{Field: "name", Message: "Ad zorunludur"}}, nil
+	}
+
+	exists, err := s.masterDataRepo.NameExists(table, name, scopeColumn, scopeID, excludeID)
+	if err != nil {
+		return nil, fmt.Errorf("ad kontrol edilemedi: %v", err)
+	}
+	if exists {
+		return []model.ValidationError{{Field: "name", Message: "Bu adla kayıt zaten var"}}, nil
+	}
+	return nil, nil
+}
+
+// validateChild üst kaydın (il, meslek grubu) var olduğunu ve adın üst kayıt altında benzersiz olduğunu kontrol eder
+func (s *PlatformService) validateChild(parent interface{}, parentColumn string, parentID uint, table interface{}, name string, excludeID uint) ([]model.ValidationError, error) {
+	if err := s.masterDataRepo.GetByID(parent, parentID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return []model.ValidationError{{Field: parentColumn, Message: "Üst kayıt bulunamadı"}}, nil
+		}
+		return nil, fmt.Errorf("üst kayıt getirilemedi: %v", err)
+	}
+	return s.validateName(table, name, parentColumn, parentID, excludeID)
+}
+
+// validatePage sayfalama parametrelerini kontrol eder
+func validatePage(page, pageSize int) []model.ValidationError {
+	var validationErrors []model.ValidationError
+	if page < 1 {
+		validationErrors = append(validationErrors, model.ValidationError{Field: "page", Message: "Sayfa numarası en az 1 olmalıdır"})
+	}
+	if pageSize < 1 || pageSize > 100 {
+		validationErrors = append(validationErrors, model.ValidationError{Field: "page_size", Message: "Sayfa boyutu 1-100 arasında olmalıdır"})
+	}
+	return validationErrors
+}
+
+// newPaginationInfo sayfalama bilgisini hesaplar
+func newPaginationInfo(page, pageSize int, total int64) model.PaginationInfo {
+	totalPages := int((total + int64(pageSize) - 1) / int64(pageSize))
+	return model.PaginationInfo{
+		CurrentPage:  page,
+		PageSize:     pageSize,
+		TotalRecords: total,
+		TotalPages:   totalPages,
+		HasNext:      page < totalPages,
+		HasPrev:      page > 1,
+	}
+}
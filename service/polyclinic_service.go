@@ -95,6 +95,16 @@ func (s *PolyclinicService) UpdateHospitalPolyclinic(id uint, req *model.UpdateP
 	return polyclinic, nil
 }
 
+// IsUserPolyclinic - Kullanıcının aktif hastanede verilen polikliniğe bağlı olup olmadığını kontrol eder
+// polyclinics:write:own yetkisinin kapsam kontrolü için kullanılır
+func (s *PolyclinicService) IsUserPolyclinic(userID, hospitalID, polyclinicID uint) bool {
+	user, err := NewMembershipService().GetUserInHospital(userID, hospitalID)
+	if err != nil || user.PolyclinicID == nil {
+		return false
+	}
+	return *user.PolyclinicID == polyclinicID
+}
+
 // DeleteHospitalPolyclinic hastane poliklinik siler
 func (s *PolyclinicService) DeleteHospitalPolyclinic(id uint, hospitalID uint) error {
 	// 1. Poliklinik hastaneye ait mi kontrol et
@@ -0,0 +1,335 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"hospital-platform/database"
+	"hospital-platform/model"
+	"hospital-platform/repository"
+	"hospital-platform/utils"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Mevcut şifre denemesi sınırları - çalınan bir token ile şifre tahmin edilmesini engeller
+const (
+	PASSWORD_CHANGE_MAX_FAILURES = 5
+	PASSWORD_CHANGE_FAIL_WINDOW  = 15 * time.Minute
+)
+
+var (
+	ErrProfileNotFound         = errors.New("Kullanıcı bulunamadı")
+	ErrCurrentPasswordInvalid  = errors.New("Mevcut şifre hatalı")
+	ErrTooManyPasswordAttempts = errors.New("Çok fazla hatalı şifre denemesi yapıldı, lütfen daha sonra tekrar deneyin")
+	ErrNoLocalPassword         = errors.New("Hesabınız SSO ile açıldığı için yerel şifresi yok, şifrenizi kimlik sağlayıcınızdan değiştirin")
+)
+
+// ProfileService - Giriş yapmış kullanıcının kendi profil ve şifre işlemleri
+// E-posta/telefon değişikliği yeni adrese gönderilen kodla doğrulanır, eski adrese bildirim gider
+type ProfileService struct {
+	userRepo            *repository.UserRepository
+	membershipService   *MembershipService
+	policyService       *PasswordPolicyService
+	verificationService *VerificationService
+	notificationService *NotificationService
+	sessionService      *SessionService
+}
+
+// NewProfileService yeni bir profil servisi oluşturur
+func NewProfileService() *ProfileService {
+	return &ProfileService{
+		userRepo:            repository.NewUserRepository(),
+		membershipService:   NewMembershipService(),
+		policyService:       NewPasswordPolicyService(),
+		verificationService: NewVerificationService(),
+		notificationService: NewNotificationService(),
+		sessionService:      NewSessionService(),
+	}
+}
+
+// GetProfile kullanıcının profilini token'daki aktif hastanedeki rolüyle getirir
+func (s *ProfileService) GetProfile(userID, hospitalID uint) (*model.ProfileResponse, error) {
+	user, err := s.membershipService.GetUserInHospital(userID, hospitalID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProfileNotFound
+		}
+		return nil, err
+	}
+	return toProfileResponse(user), nil
+}
+
+// SendContactCode yeni e-posta adresine veya telefon numarasına doğrulama kodu gönderir
+// Kod kullanıcıya ve adrese bağlıdır; başka bir adres için kullanılamaz
+func (s *ProfileService) SendContactCode(userID uint, req *model.ContactVerificationRequest, lang string) ([]model.ValidationError, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProfileNotFound
+		}
+		return nil, fmt.Errorf("kullanıcı getirilemedi: %v", err)
+	}
+
+	var field, value string
+	switch req.Channel {
+	case utils.CHANNEL_EMAIL:
+		field, value = "email", normalizeProfileEmail(req.Value)
+	case utils.CHANNEL_SMS:
+		field, value = "phone", strings.TrimSpace(req.Value)
+	default:
+		return []model.ValidationError{{Field: "channel", Message: "Kanal email veya sms olmalıdır"}}, nil
+	}
+
+	if value == "" {
+		return []model.ValidationError{{Field: "value", Message: "Yeni adres zorunludur"}}, nil
+	}
+	if validationErrors := s.validateContactChange(user, field, value); len(validationErrors) > 0 {
+		return validationErrors, nil
+	}
+
+	return nil, s.verificationService.SendCode(contactSubject(userID, field, value), req.Channel, value, lang)
+}
+
+// UpdateProfile ad, soyad, e-posta ve telefonu günceller
+// Değişen e-posta/telefon için yeni adrese gönderilen kod gerekir; değişiklik eski adrese bildirilir
+func (s *ProfileService) UpdateProfile(userID, hospitalID uint, req *model.UpdateProfileRequest) (*model.ProfileResponse, []model.ValidationError, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrProfileNotFound
+		}
+		return nil, nil, fmt.Errorf("kullanıcı getirilemedi: %v", err)
+	}
+
+	req.FirstName = strings.TrimSpace(req.FirstName)
+	req.LastName = strings.TrimSpace(req.LastName)
+	req.Email = normalizeProfileEmail(req.Email)
+	req.Phone = strings.TrimSpace(req.Phone)
+
+	var validationErrors []model.ValidationError
+	if req.FirstName == "" {
+		validationErrors = append(validationErrors, model.ValidationError{Field: "first_name", Message: "Ad zorunludur"})
+	}
+	if req.LastName == "" {
+		validationErrors = append(validationErrors, model.ValidationError{Field: "last_name", Message: "Soyad zorunludur"})
+	}
+	if req.Email == "" {
+		validationErrors = append(validationErrors, model.ValidationError{Field: "email", Message: "E-posta zorunludur"})
+	}
+	if req.Phone == "" {
+		validationErrors = append(validationErrors, model.ValidationError{Field: "phone", Message: "Telefon zorunludur"})
+	}
+	if len(validationErrors) > 0 {
+		return nil, validationErrors, nil
+	}
+
+	emailChanged := !strings.EqualFold(req.Email, user.Email)
+	phoneChanged := req.Phone != user.Phone
+
+	// Yeni adresler başka hesapta kullanılmamalı ve kodla doğrulanmalı
+	var subjects []string
+	now := time.Now()
+	fields := map[string]interface{}{
+		"first_name": req.FirstName,
+		"last_name":  req.LastName,
+	}
+	if emailChanged {
+		validationErrors = append(validationErrors, s.validateContactChange(user, "email", req.Email)...)
+		if req.EmailCode == "" {
+			validationErrors = append(validationErrors, model.ValidationError{Field: "email_code", Message: "Yeni e-posta adresine gönderilen doğrulama kodu gerekli"})
+		}
+		fields["email"] = req.Email
+		fields["email_verified_at"] = now
+	}
+	if phoneChanged {
+		validationErrors = append(validationErrors, s.validateContactChange(user, "phone", req.Phone)...)
+		if req.PhoneCode == "" {
+			validationErrors = append(validationErrors, model.ValidationError{Field: "phone_code", Message: "Yeni telefon numarasına gönderilen doğrulama kodu gerekli"})
+		}
+		fields["phone"] = req.Phone
+		fields["phone_verified_at"] = now
+	}
+	if len(validationErrors) > 0 {
+		return nil, validationErrors, nil
+	}
+
+	if emailChanged {
+		subject := contactSubject(userID, "email", req.Email)
+		if err := s.verificationService.CheckCode(subject, req.EmailCode); err != nil {
+			return nil, nil, err
+		}
+		subjects = append(subjects, subject)
+	}
+	if phoneChanged {
+		subject := contactSubject(userID, "phone", req.Phone)
+		if err := s.verificationService.CheckCode(subject, req.PhoneCode); err != nil {
+			return nil, nil, err
+		}
+		subjects = append(subjects, subject)
+	}
+
+	if err := s.userRepo.UpdateFields(userID, fields); err != nil {
+		return nil, nil, fmt.Errorf("profil güncellenemedi: %v", err)
+	}
+	for _, subject := range subjects {
+		s.verificationService.Consume(subject)
+	}
+
+	// Hesap ele geçirilmişse asıl sahibi haberdar olsun - bildirim eski adreslere gider
+	lang := ResolveLanguage(req.Lang, "")
+	if emailChanged {
+		s.notifyContactChanged(utils.CHANNEL_EMAIL, user.Email, lang, user.FirstName, "email")
+		fmt.Printf("📧 E-posta değiştirildi: kullanıcı=%d\n", userID)
+	}
+	if phoneChanged {
+		s.notifyContactChanged(utils.CHANNEL_SMS, user.Phone, lang, user.FirstName, "phone")
+		fmt.Printf("📱 Telefon değiştirildi: kullanıcı=%d\n", userID)
+	}
+
+	profile, err := s.GetProfile(userID, hospitalID)
+	return profile, nil, err
+}
+
+// ChangePassword mevcut şifreyi doğrulayıp yeni şifreyi kaydeder ve diğer oturumları sonlandırır
+// İstek yapan oturum açık kalır; diğer cihazlardaki token'lar bir sonraki istekte reddedilir
+func (s *ProfileService) ChangePassword(userID uint, currentFamilyID string, req *model.ChangePasswordRequest) (int, []model.ValidationError, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, nil, ErrProfileNotFound
+		}
+		return 0, nil, fmt.Errorf("kullanıcı getirilemedi: %v", err)
+	}
+	if user.Password == "" {
+		return 0, nil, ErrNoLocalPassword
+	}
+
+	failKey := fmt.Sprintf("%s%d", database.PASSWORD_CHANGE_FAIL_PREFIX, userID)
+	failures, err := database.GetAttemptCount(failKey)
+	if err != nil {
+		return 0, nil, fmt.Errorf("şifre denemeleri kontrol edilemedi: %v", err)
+	}
+	if failures >= PASSWORD_CHANGE_MAX_FAILURES {
+		return 0, nil, ErrTooManyPasswordAttempts
+	}
+
+	valid, _, err := utils.VerifyPassword(req.CurrentPassword, user.Password)
+	if errors.Is(err, utils.ErrHasherBusy) {
+		return 0, nil, err
+	}
+	if !valid {
+		if _, err := database.IncrementAttempt(failKey, PASSWORD_CHANGE_FAIL_WINDOW); err != nil {
+			fmt.Println("Hatalı şifre denemesi kaydedilemedi:", err)
+		}
+		return 0, nil, ErrCurrentPasswordInvalid
+	}
+	if err := database.ClearAttempts(failKey); err != nil {
+		fmt.Println("Şifre deneme sayacı temizlenemedi:", err)
+	}
+
+	if req.NewPassword != req.ConfirmPassword {
+		return 0, []model.ValidationError{{
+			Field:   "confirm_password",
+			Message: "Şifreler eşleşmiyor",
+		}}, nil
+	}
+
+	// Politika kullanıcının birincil hastanesinden okunur (şifre hesaba aittir)
+	validationErrors, err := s.policyService.ValidatePassword(user.HospitalID, user, "new_password", req.NewPassword)
+	if len(validationErrors) > 0 || err != nil {
+		return 0, validationErrors, err
+	}
+
+	if err := s.policyService.SetPassword(user, req.NewPassword); err != nil {
+		return 0, nil, err
+	}
+
+	revoked, err := s.sessionService.RevokeOtherSessions(userID, currentFamilyID)
+	if err != nil {
+		return revoked, nil, fmt.Errorf("şifre değişti ancak diğer oturumlar sonlandırılamadı: %v", err)
+	}
+
+	fmt.Printf("🔑 Şifre değiştirildi: kullanıcı=%d, sonlandırılan oturum=%d\n", userID, revoked)
+	return revoked, nil, nil
+}
+
+// validateContactChange yeni e-posta/telefonun başka bir kullanıcıda olmadığını kontrol eder
+func (s *ProfileService) validateContactChange(user *model.User, field, value string) []model.ValidationError {
+	var existing *model.User
+	var err error
+	if field == "email" {
+		if strings.EqualFold(value, user.Email) {
+			return []model.ValidationError{{Field: field, Message: "Yeni e-posta adresi mevcut adresinizle aynı"}}
+		}
+		existing, err = s.userRepo.GetByEmailFold(value)
+	} else {
+		if value == user.Phone {
+			return []model.ValidationError{{Field: field, Message: "Yeni telefon numarası mevcut numaranızla aynı"}}
+		}
+		existing, err = s.userRepo.GetByPhone(value)
+	}
+
+	if err == nil && existing != nil && existing.ID != user.ID {
+		message := "Bu e-posta adresi zaten kullanılıyor"
+		if field == "phone" {
+			message = "Bu telefon numarası zaten kullanılıyor"
+		}
+		return []model.ValidationError{{Field: field, Message: message}}
+	}
+	return nil
+}
+
+// notifyContactChanged iletişim bilgisi değişikliğini eski adrese bildirir, hata işlemi engellemez
+func (s *ProfileService) notifyContactChanged(channel, to, lang, firstName, contactType string) {
+	if to == "" {
+		return
+	}
+	err := s.notificationService.Send(channel, to, TEMPLATE_CONTACT_CHANGED, lang, map[string]interface{}{
+		"FirstName":   firstName,
+		"ContactType": contactType,
+	})
+	if err != nil {
+		fmt.Println("İletişim bilgisi değişikliği bildirilemedi:", err)
+	}
+}
+
+// toProfileResponse kullanıcıyı profil cevabına çevirir
+func toProfileResponse(user *model.User) *model.ProfileResponse {
+	return &model.ProfileResponse{
+		ID:                user.ID,
+		FirstName:         user.FirstName,
+		LastName:          user.LastName,
+		TCKN:              maskTCKN(user.TCKN),
+		Email:             user.Email,
+		Phone:             user.Phone,
+		EmailVerified:     user.EmailVerifiedAt != nil,
+		PhoneVerified:     user.PhoneVerifiedAt != nil,
+		HospitalID:        user.HospitalID,
+		HospitalName:      user.Hospital.Name,
+		Role:              user.Role,
+		MFAEnabled:        user.MFAEnabled,
+		HasPassword:       user.Password != "",
+		PasswordChangedAt: user.PasswordChangedAt,
+		CreatedAt:         user.CreatedAt,
+	}
+}
+
+// contactSubject iletişim bilgisi doğrulama kodu için konu anahtarı - kod kullanıcıya ve yeni adrese bağlıdır
+func contactSubject(userID uint, field, value string) string {
+	return fmt.Sprintf("contact:%d:%s:%s", userID, field, value)
+}
+
+// normalizeProfileEmail e-postayı boşluklardan arındırıp küçük harfe çevirir
+func normalizeProfileEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// maskTCKN TC kimlik numarasının ilk 3 ve son 2 hanesini gösterir
+func maskTCKN(tckn string) string {
+	if len(tckn) != 11 {
+		return "***********"
+	}
+	return tckn[:3] + "******" + tckn[9:]
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"hospital-platform/database"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// startTestRedis bellek içi bir Redis (miniredis) başlatır ve database.RedisClient'ı ona yönlendirir, test bitince eski istemciyi geri koyar
+// Saat FastForward ile ilerletilir, böylece TTL davranışı beklemeden test edilir
+func startTestRedis(t *testing.T) *miniredis.Miniredis {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	previous := database.RedisClient
+	database.RedisClient = redis.NewClient(&redis.Options{
+		Addr:            server.Addr(),
+		DisableIdentity: true,
+	})
+	t.Cleanup(func() {
+		database.RedisClient.Close()
+		database.RedisClient = previous
+	})
+
+	return server
+}
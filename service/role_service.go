@@ -0,0 +1,296 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"hospital-platform/model"
+	"hospital-platform/repository"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrRoleNotFound         = errors.New("Rol bulunamadı")
+	ErrSystemRoleLocked     = errors.New("Sistem rolü silinemez veya yeniden adlandırılamaz")
+	ErrAdminRoleLocked      = errors.New("Yetkili rolünün yetkileri değiştirilemez")
+	ErrRoleInUse            = errors.New("Bu role sahip kullanıcılar var, önce kullanıcıların rolünü değiştirin")
+	ErrPermissionEscalation = errors.New("Sahip olmadığınız yetkileri veremezsiniz")
+)
+
+// RoleService - Hastaneye özel rol ve yetki yönetimi iş mantığı
+type RoleService struct {
+	roleRepo     *repository.RoleRepository
+	tokenService *TokenService
+}
+
+// NewRoleService yeni bir rol servisi oluşturur
+func NewRoleService() *RoleService {
+	return &RoleService{
+		roleRepo:     repository.NewRoleRepository(),
+		tokenService: NewTokenService(),
+	}
+}
+
+// ==================== ROL YÖNETİMİ ====================
+
+// GetPermissions sistemde tanımlı tüm yetkileri döndürür
+func (s *RoleService) GetPermissions() []model.PermissionInfo {
+	return model.Permissions
+}
+
+// GetRoles hastanenin rollerini kullanıcı sayılarıyla birlikte döndürür
+func (s *RoleService) GetRoles(hospitalID uint) ([]model.RoleResponse, error) {
+	roles, err := s.roleRepo.GetByHospitalID(hospitalID)
+	if err != nil {
+		return nil, fmt.Errorf("roller getirilemedi: %v", err)
+	}
+
+	responses := make([]model.RoleResponse, 0, len(roles))
+	for i := range roles {
+		response, err := s.toResponse(&roles[i])
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, *response)
+	}
+	return responses, nil
+}
+
+// CreateRole yeni rol oluşturur - kullanıcı sadece kendi sahip olduğu yetkileri verebilir
+func (s *RoleService) CreateRole(hospitalID uint, actorRole string, req *model.RoleRequest) (*model.RoleResponse, []model.ValidationError, error) {
+	req.Name = strings.TrimSpace(req.Name)
+
+	validationErrors := s.validateRoleRequest(hospitalID, 0, req)
+	if len(validationErrors) > 0 {
+		return nil, validationErrors, nil
+	}
+
+	if err := s.checkEscalation(hospitalID, actorRole, req.Permissions); err != nil {
+		return nil, nil, err
+	}
+
+	role := &model.Role{
+		HospitalID:  hospitalID,
+		Name:        req.Name,
+		Description: req.Description,
+		Permissions: toRolePermissions(req.Permissions),
+	}
+
+	if err := s.roleRepo.Create(role); err != nil {
+		return nil, nil, fmt.Errorf("rol oluşturulamadı: %v", err)
+	}
+
+	response, err := s.toResponse(role)
+	return response, nil, err
+}
+
+// UpdateRole rolün adını, açıklamasını ve yetkilerini günceller
+// Yetki değişiklikleri bir sonraki istekte geçerli olur; ad değişirse rolün kullanıcılarının token'ları yenilenmelidir
+func (s *RoleService) UpdateRole(hospitalID, roleID uint, actorRole string, req *model.RoleRequest) (*model.RoleResponse, []model.ValidationError, error) {
+	role, err := s.getHospitalRole(hospitalID, roleID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+
+	validationErrors := s.validateRoleRequest(hospitalID, role.ID, req)
+	if len(validationErrors) > 0 {
+		return nil, validationErrors, nil
+	}
+
+	if role.IsSystem && req.Name != role.Name {
+		return nil, nil, ErrSystemRoleLocked
+	}
+	if role.Name == model.RoleYetkili {
+		return nil, nil, ErrAdminRoleLocked
+	}
+
+	// Hem rolün mevcut hem de yeni yetkileri, işlemi yapanın yetkileri içinde olmalı
+	if err := s.checkEscalation(hospitalID, actorRole, append(role.PermissionNames(), req.Permissions...)); err != nil {
+		return nil, nil, err
+	}
+
+	oldName := role.Name
+	role.Name = req.Name
+	role.Description = req.Description
+	role.Permissions = toRolePermissions(req.Permissions)
+
+	if err := s.roleRepo.Update(role, oldName); err != nil {
+		return nil, nil, fmt.Errorf("rol güncellenemedi: %v", err)
+	}
+
+	// Token'lar rol adını taşır - ad değiştiyse kullanıcılar yeni adla token almalı
+	if oldName != role.Name {
+		userIDs, err := s.roleRepo.GetUserIDsByRole(hospitalID, role.Name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("rol kullanıcıları getirilemedi: %v", err)
+		}
+		for _, userID := range userIDs {
+			if err := s.tokenService.InvalidateUserTokens(userID); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	response, err := s.toResponse(role)
+	return response, nil, err
+}
+
+// DeleteRole kullanıcısı olmayan, sistem rolü olmayan rolü siler
+func (s *RoleService) DeleteRole(hospitalID, roleID uint) error {
+	role, err := s.getHospitalRole(hospitalID, roleID)
+	if err != nil {
+		return err
+	}
+
+	if role.IsSystem {
+		return ErrSystemRoleLocked
+	}
+
+	count, err := s.roleRepo.CountUsers(hospitalID, role.Name)
+	if err != nil {
+		return fmt.Errorf("rol kullanıcıları sayılamadı: %v", err)
+	}
+	if count > 0 {
+		return ErrRoleInUse
+	}
+
+	if err := s.roleRepo.Delete(role); err != nil {
+		return fmt.Errorf("rol silinemedi: %v", err)
+	}
+	return nil
+}
+
+// ==================== ROL ATAMA ====================
+
+// CheckAssignableRole hedef rolün hastanede tanımlı olduğunu ve atayan kişinin yetkilerini aşmadığını kontrol eder
+func (s *RoleService) CheckAssignableRole(hospitalID uint, actorRole, targetRole string) error {
+	role, err := s.roleRepo.GetByName(hospitalID, targetRole)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrRoleNotFound
+		}
+		return fmt.Errorf("rol getirilemedi: %v", err)
+	}
+
+	return s.checkEscalation(hospitalID, actorRole, role.PermissionNames())
+}
+
+// IsPrivileged rolün yönetim yetkilerinden birine sahip olup olmadığını döndürür
+func (s *RoleService) IsPrivileged(hospitalID uint, roleName string) bool {
+	role, err := s.roleRepo.GetByName(hospitalID, roleName)
+	if err != nil {
+		return false
+	}
+
+	for _, permission := range role.PermissionNames() {
+		for _, privileged := range model.PrivilegedPermissions {
+			if permission == privileged {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ==================== HELPER METHODS ====================
+
+// getHospitalRole rolü getirir ve hastaneye ait olduğunu doğrular
+func (s *RoleService) getHospitalRole(hospitalID, roleID uint) (*model.Role, error) {
+	role, err := s.roleRepo.GetByID(roleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoleNotFound
+		}
+		return nil, fmt.Errorf("rol getirilemedi: %v", err)
+	}
+
+	if role.HospitalID != hospitalID {
+		return nil, ErrRoleNotFound
+	}
+	return role, nil
+}
+
+// checkEscalation verilen yetkilerin tamamının işlemi yapan kullanıcının rolünde olduğunu doğrular
+func (s *RoleService) checkEscalation(hospitalID uint, actorRole string, permissions []string) error {
+	actor, err := s.roleRepo.GetByName(hospitalID, actorRole)
+	if err != nil {
+		return ErrPermissionEscalation
+	}
+
+	owned := make(map[string]bool)
+	for _, permission := range actor.PermissionNames() {
+		owned[permission] = true
+	}
+
+	for _, permission := range permissions {
+		if !owned[permission] {
+			return ErrPermissionEscalation
+		}
+	}
+	return nil
+}
+
+// validateRoleRequest rol adını ve yetki listesini doğrular
+func (s *RoleService) validateRoleRequest(hospitalID, roleID uint, req *model.RoleRequest) []model.ValidationError {
+	var validationErrors []model.ValidationError
+
+	if len([]rune(req.Name)) < 2 || len([]rune(req.Name)) > 50 {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "name",
+			Message: "Rol adı 2-50 karakter arasında olmalıdır",
+		})
+	} else if existing, err := s.roleRepo.GetByName(hospitalID, req.Name); err == nil && existing.ID != roleID {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "name",
+			Message: "Bu isimde bir rol zaten var",
+		})
+	}
+
+	seen := make(map[string]bool)
+	for _, permission := range req.Permissions {
+		if !model.IsValidPermission(permission) {
+			validationErrors = append(validationErrors, model.ValidationError{
+				Field:   "permissions",
+				Message: fmt.Sprintf("Geçersiz yetki: %s", permission),
+			})
+		}
+		if seen[permission] {
+			validationErrors = append(validationErrors, model.ValidationError{
+				Field:   "permissions",
+				Message: fmt.Sprintf("Yetki birden fazla kez verilmiş: %s", permission),
+			})
+		}
+		seen[permission] = true
+	}
+
+	return validationErrors
+}
+
+// toResponse rolü kullanıcı sayısıyla birlikte response formatına çevirir
+func (s *RoleService) toResponse(role *model.Role) (*model.RoleResponse, error) {
+	count, err := s.roleRepo.CountUsers(role.HospitalID, role.Name)
+	if err != nil {
+		return nil, fmt.Errorf("rol kullanıcıları sayılamadı: %v", err)
+	}
+
+	return &model.RoleResponse{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		IsSystem:    role.IsSystem,
+		Permissions: role.PermissionNames(),
+		UserCount:   count,
+	}, nil
+}
+
+// toRolePermissions yetki isimlerini RolePermission kayıtlarına çevirir
+func toRolePermissions(permissions []string) []model.RolePermission {
+	result := make([]model.RolePermission, 0, len(permissions))
+	for _, permission := range permissions {
+		result = append(result, model.RolePermission{Permission: permission})
+	}
+	return result
+}
@@ -0,0 +1,657 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"hospital-platform/config"
+	"hospital-platform/model"
+	"hospital-platform/repository"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ErrShiftTemplateNotFound = errors.New("Vardiya şablonu bulunamadı")
+	ErrShiftTemplateInUse    = errors.New("Bu vardiya bir çalışma programında veya nöbet listesinde kullanılıyor, önce onları değiştirin")
+	ErrScheduleNotFound      = errors.New("Çalışma programı bulunamadı")
+	ErrScheduleStaffNotFound = errors.New("Personel bulunamadı")
+	ErrShiftRangeInvalid     = errors.New("Geçersiz tarih aralığı")
+)
+
+const (
+	MAX_SCHEDULE_CYCLE_DAYS   = 56 // Dönüşümlü döngü en fazla 8 hafta
+	MAX_SHIFT_CALENDAR_DAYS   = 92 // Takvim sorgusu en fazla ~3 ay
+	MAX_SCHEDULE_CONFLICTS    = 5  // Tek yanıtta raporlanan çakışma sayısı
+	scheduleDateLayout        = "2006-01-02"
+	scheduleDisplayDateLayout = "02.01.2006"
+)
+
+// ScheduleService - Vardiya şablonları ve personel çalışma programları
+// Programlar kaydedilmeden önce çakışan vardiyalar ve iki vardiya arasındaki asgari dinlenme süresi kontrol edilir
+type ScheduleService struct {
+	scheduleRepo *repository.ScheduleRepository
+	staffRepo    *repository.StaffRepository
+	leaveRepo    *repository.LeaveRepository
+	rosterRepo   *repository.DutyRosterRepository
+	minRest      time.Duration // İki vardiya arasında olması gereken en az dinlenme süresi
+}
+
+// NewScheduleService yeni bir program servisi oluşturur
+func NewScheduleService() *ScheduleService {
+	return &ScheduleService{
+		scheduleRepo: repository.NewScheduleRepository(),
+		staffRepo:    repository.NewStaffRepository(),
+		leaveRepo:    repository.NewLeaveRepository(),
+		rosterRepo:   repository.NewDutyRosterRepository(),
+		minRest:      config.GetEnvDuration("SCHEDULE_MIN_REST", 11*time.Hour),
+	}
+}
+
+// ==================== VARDİYA ŞABLONLARI ====================
+
+// GetTemplates hastanenin vardiya şablonlarını döndürür
+func (s *ScheduleService) GetTemplates(hospitalID uint) ([]model.ShiftTemplate, error) {
+	templates, err := s.scheduleRepo.GetTemplatesByHospital(hospitalID)
+	if err != nil {
+		return nil, fmt.Errorf("vardiyalar getirilemedi: %v", err)
+	}
+	return templates, nil
+}
+
+// CreateTemplate hastaneye vardiya şablonu ekler
+func (s *ScheduleService) CreateTemplate(hospitalID uint, req *model.ShiftTemplateRequest) (*model.ShiftTemplate, []model.ValidationError, error) {
+	validationErrors := s.validateTemplateRequest(hospitalID, 0, req)
+	if len(validationErrors) > 0 {
+		return nil, validationErrors, nil
+	}
+
+	template := &model.ShiftTemplate{
+		HospitalID: hospitalID,
+		Name:       req.Name,
+		StartTime:  req.StartTime,
+		EndTime:    req.EndTime,
+	}
+	if err := s.scheduleRepo.CreateTemplate(template); err != nil {
+		return nil, nil, fmt.Errorf("vardiya eklenemedi: %v", err)
+	}
+	return template, nil, nil
+}
+
+// UpdateTemplate vardiya şablonunu günceller
+// Saat değişikliği şablonu kullanan güncel ve gelecek programlarda çakışma veya dinlenme ihlali doğuruyorsa reddedilir
+func (s *ScheduleService) UpdateTemplate(hospitalID, id uint, req *model.ShiftTemplateRequest) (*model.ShiftTemplate, []model.ValidationError, error) {
+	template, err := s.getTemplate(hospitalID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	validationErrors := s.validateTemplateRequest(hospitalID, id, req)
+	if len(validationErrors) > 0 {
+		return nil, validationErrors, nil
+	}
+
+	template.Name = req.Name
+	if template.StartTime != req.StartTime || template.EndTime != req.EndTime {
+		template.StartTime = req.StartTime
+		template.EndTime = req.EndTime
+
+		validationErrors, err = s.checkTemplateChange(template)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(validationErrors) > 0 {
+			return nil, validationErrors, nil
+		}
+	}
+
+	if err := s.scheduleRepo.UpdateTemplate(template); err != nil {
+		return nil, nil, fmt.Errorf("vardiya güncellenemedi: %v", err)
+	}
+	return template, nil, nil
+}
+
+// DeleteTemplate kullanılmayan vardiya şablonunu siler
+func (s *ScheduleService) DeleteTemplate(hospitalID, id uint) error {
+	if _, err := s.getTemplate(hospitalID, id); err != nil {
+		return err
+	}
+
+	inUse, err := s.scheduleRepo.IsTemplateInUse(id)
+	if err != nil {
+		return fmt.Errorf("vardiya kullanımı kontrol edilemedi: %v", err)
+	}
+	if inUse {
+		return ErrShiftTemplateInUse
+	}
+	return s.scheduleRepo.DeleteTemplate(id)
+}
+
+// ==================== PERSONEL PROGRAMLARI ====================
+
+// GetSchedules personelin tüm çalışma programlarını döndürür
+func (s *ScheduleService) GetSchedules(hospitalID, staffID uint) ([]model.StaffSchedule, error) {
+	if err := s.checkStaff(hospitalID, staffID); err != nil {
+		return nil, err
+	}
+	schedules, err := s.scheduleRepo.GetSchedulesByStaff(staffID)
+	if err != nil {
+		return nil, fmt.Errorf("çalışma programları getirilemedi: %v", err)
+	}
+	return schedules, nil
+}
+
+// CreateSchedule personele yeni çalışma programı ekler
+func (s *ScheduleService) CreateSchedule(hospitalID, staffID uint, req *model.StaffScheduleRequest) (*model.StaffSchedule, []model.ValidationError, error) {
+	if err := s.checkStaff(hospitalID, staffID); err != nil {
+		return nil, nil, err
+	}
+
+	schedule, validationErrors, err := s.buildSchedule(hospitalID, staffID, req)
+	if err != nil || len(validationErrors) > 0 {
+		return nil, validationErrors, err
+	}
+
+	validationErrors, err = s.checkStaffSchedule(schedule)
+	if err != nil || len(validationErrors) > 0 {
+		return nil, validationErrors, err
+	}
+
+	if err := s.scheduleRepo.CreateSchedule(schedule); err != nil {
+		return nil, nil, fmt.Errorf("çalışma programı eklenemedi: %v", err)
+	}
+	return s.reload(schedule.ID)
+}
+
+// UpdateSchedule personelin çalışma programını değiştirir
+func (s *ScheduleService) UpdateSchedule(hospitalID, staffID, scheduleID uint, req *model.StaffScheduleRequest) (*model.StaffSchedule, []model.ValidationError, error) {
+	existing, err := s.getSchedule(hospitalID, staffID, scheduleID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	schedule, validationErrors, err := s.buildSchedule(hospitalID, staffID, req)
+	if err != nil || len(validationErrors) > 0 {
+		return nil, validationErrors, err
+	}
+	schedule.Model = existing.Model
+
+	validationErrors, err = s.checkStaffSchedule(schedule)
+	if err != nil || len(validationErrors) > 0 {
+		return nil, validationErrors, err
+	}
+
+	if err := s.scheduleRepo.UpdateSchedule(schedule); err != nil {
+		return nil, nil, fmt.Errorf("çalışma programı güncellenemedi: %v", err)
+	}
+	return s.reload(schedule.ID)
+}
+
+// DeleteSchedule personelin çalışma programını siler
+func (s *ScheduleService) DeleteSchedule(hospitalID, staffID, scheduleID uint) error {
+	schedule, err := s.getSchedule(hospitalID, staffID, scheduleID)
+	if err != nil {
+		return err
+	}
+	return s.scheduleRepo.DeleteSchedule(schedule)
+}
+
+// GetShifts personelin [from, to] günleri arasındaki somut vardiyalarını ve yayınlanmış nöbetlerini hesaplar
+// Onaylı izne denk gelen vardiyalar izin bilgisiyle işaretlenir (personel o vardiyada müsait değildir)
+func (s *ScheduleService) GetShifts(hospitalID, staffID uint, from, to time.Time) ([]model.ShiftOccurrence, error) {
+	if err := s.checkStaff(hospitalID, staffID); err != nil {
+		return nil, err
+	}
+	from, to = model.DateOf(from), model.DateOf(to)
+	if to.Before(from) || model.DaysBetween(from, to) >= MAX_SHIFT_CALENDAR_DAYS {
+		return nil, fmt.Errorf("%w (en fazla %d gün)", ErrShiftRangeInvalid, MAX_SHIFT_CALENDAR_DAYS)
+	}
+
+	schedules, err := s.scheduleRepo.GetSchedulesByStaffIDs([]uint{staffID}, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("çalışma programları getirilemedi: %v", err)
+	}
+	leaves, err := s.leaveRepo.GetApprovedByStaffIDs([]uint{staffID}, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("izinler getirilemedi: %v", err)
+	}
+
+	duties, err := s.rosterRepo.GetPublishedAssignments([]uint{staffID}, from, to, 0)
+	if err != nil {
+		return nil, fmt.Errorf("nöbetler getirilemedi: %v", err)
+	}
+
+	occurrences := ExpandSchedules(schedules, from, to)
+	for i := range duties {
+		occurrences = append(occurrences, model.ShiftOccurrence{
+			Date:            duties[i].Date.Format(scheduleDateLayout),
+			DutyRosterID:    &duties[i].RosterID,
+			ShiftTemplateID: duties[i].ShiftTemplateID,
+			ShiftName:       duties[i].ShiftName,
+			Start:           duties[i].StartsAt,
+			End:             duties[i].EndsAt,
+		})
+	}
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].Start.Before(occurrences[j].Start)
+	})
+	MarkLeaves(occurrences, leaves)
+	return occurrences, nil
+}
+
+// ExpandSchedules programların [from, to] günlerinde başlayan vardiyalarını başlangıç saatine göre sıralı döndürür
+func ExpandSchedules(schedules []model.StaffSchedule, from, to time.Time) []model.ShiftOccurrence {
+	occurrences := []model.ShiftOccurrence{}
+	for day := model.DateOf(from); !day.After(model.DateOf(to)); day = day.AddDate(0, 0, 1) {
+		for i := range schedules {
+			schedule := &schedules[i]
+			if !schedule.CoversDate(day) {
+				continue
+			}
+			cycleDay := schedule.CycleDay(day)
+			for _, entry := range schedule.Entries {
+				if entry.Day != cycleDay {
+					continue
+				}
+				start, end := entry.ShiftTemplate.Span()
+				occurrences = append(occurrences, model.ShiftOccurrence{
+					Date:            day.Format(scheduleDateLayout),
+					ScheduleID:      schedule.ID,
+					ShiftTemplateID: entry.ShiftTemplateID,
+					ShiftName:       entry.ShiftTemplate.Name,
+					Start:           day.Add(start),
+					End:             day.Add(end),
+				})
+			}
+		}
+	}
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].Start.Before(occurrences[j].Start)
+	})
+	return occurrences
+}
+
+// ==================== DOĞRULAMA ====================
+
+// validateTemplateRequest vardiya adı ve saatlerini doğrular, saatleri SS:DD biçimine getirir
+func (s *ScheduleService) validateTemplateRequest(hospitalID, excludeID uint, req *model.ShiftTemplateRequest) []model.ValidationError {
+	var validationErrors []model.ValidationError
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		validationErrors = append(validationErrors, model.ValidationError{Field: "name", Message: "Vardiya adı zorunludur"})
+	} else if existing, err := s.scheduleRepo.GetTemplateByName(hospitalID, req.Name); err == nil && existing.ID != excludeID {
+		validationErrors = append(validationErrors, model.ValidationError{Field: "name", Message: "Bu isimde bir vardiya zaten var"})
+	}
+
+	for _, field := range []struct {
+		name  string
+		value *string
+	}{{"start_time", &req.StartTime}, {"end_time", &req.EndTime}} {
+		parsed, err := time.Parse("15:04", strings.TrimSpace(*field.value))
+		if err != nil {
+			validationErrors = append(validationErrors, model.ValidationError{Field: field.name, Message: "Saat SS:DD biçiminde olmalı (ör. 08:00)"})
+			continue
+		}
+		*field.value = parsed.Format("15:04")
+	}
+	return validationErrors
+}
+
+// buildSchedule isteği doğrular ve vardiyaları yüklenmiş program modeline çevirir
+func (s *ScheduleService) buildSchedule(hospitalID, staffID uint, req *model.StaffScheduleRequest) (*model.StaffSchedule, []model.ValidationError, error) {
+	var validationErrors []model.ValidationError
+	schedule := &model.StaffSchedule{HospitalID: hospitalID, StaffID: staffID, Pattern: req.Pattern}
+
+	switch req.Pattern {
+	case model.SchedulePatternWeekly:
+		schedule.CycleDays = 7
+	case model.SchedulePatternRotating:
+		schedule.CycleDays = req.CycleDays
+		if req.CycleDays < 2 || req.CycleDays > MAX_SCHEDULE_CYCLE_DAYS {
+			validationErrors = append(validationErrors, model.ValidationError{
+				Field:   "cycle_days",
+				Message: fmt.Sprintf("Dönüşümlü programın döngüsü 2-%d gün olmalı", MAX_SCHEDULE_CYCLE_DAYS),
+			})
+		}
+	default:
+		validationErrors = append(validationErrors, model.ValidationError{Field: "pattern", Message: "Program tipi weekly veya rotating olmalı"})
+	}
+
+	from, err := parseScheduleDate(req.EffectiveFrom)
+	if err != nil {
+		validationErrors = append(validationErrors, model.ValidationError{Field: "effective_from", Message: "Başlangıç tarihi YYYY-AA-GG biçiminde olmalı"})
+	}
+	schedule.EffectiveFrom = from
+	if req.EffectiveTo != nil && *req.EffectiveTo != "" {
+		to, err := parseScheduleDate(*req.EffectiveTo)
+		if err != nil {
+			validationErrors = append(validationErrors, model.ValidationError{Field: "effective_to", Message: "Bitiş tarihi YYYY-AA-GG biçiminde olmalı"})
+		} else if to.Before(from) {
+			validationErrors = append(validationErrors, model.ValidationError{Field: "effective_to", Message: "Bitiş tarihi başlangıçtan önce olamaz"})
+		}
+		schedule.EffectiveTo = &to
+	}
+
+	templates, err := s.scheduleRepo.GetTemplatesByHospital(hospitalID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("vardiyalar getirilemedi: %v", err)
+	}
+	templatesByID := make(map[uint]model.ShiftTemplate, len(templates))
+	for _, template := range templates {
+		templatesByID[template.ID] = template
+	}
+
+	if len(req.Entries) == 0 {
+		validationErrors = append(validationErrors, model.ValidationError{Field: "entries", Message: "En az bir çalışma günü seçilmelidir"})
+	}
+	seen := make(map[[2]uint]bool)
+	for _, entry := range req.Entries {
+		template, ok := templatesByID[entry.ShiftTemplateID]
+		switch {
+		case schedule.CycleDays > 0 && (entry.Day < 1 || entry.Day > schedule.CycleDays):
+			validationErrors = append(validationErrors, model.ValidationError{
+				Field:   "entries",
+				Message: fmt.Sprintf("Geçersiz gün değeri: %d (1-%d arasında olmalı)", entry.Day, schedule.CycleDays),
+			})
+		case !ok:
+			validationErrors = append(validationErrors, model.ValidationError{
+				Field:   "entries",
+				Message: fmt.Sprintf("Vardiya bulunamadı: %d", entry.ShiftTemplateID),
+			})
+		case seen[[2]uint{uint(entry.Day), entry.ShiftTemplateID}]:
+			validationErrors = append(validationErrors, model.ValidationError{
+				Field:   "entries",
+				Message: fmt.Sprintf("%d. güne aynı vardiya birden fazla kez eklenmiş", entry.Day),
+			})
+		default:
+			seen[[2]uint{uint(entry.Day), entry.ShiftTemplateID}] = true
+			schedule.Entries = append(schedule.Entries, model.StaffScheduleEntry{
+				Day:             entry.Day,
+				ShiftTemplateID: template.ID,
+				ShiftTemplate:   template,
+			})
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		return nil, validationErrors, nil
+	}
+	return schedule, nil, nil
+}
+
+// checkStaffSchedule programı personelin diğer programlarıyla birlikte kontrol eder:
+// tarih aralıkları örtüşmemeli, vardiyalar çakışmamalı ve aralarında asgari dinlenme süresi olmalı
+func (s *ScheduleService) checkStaffSchedule(schedule *model.StaffSchedule) ([]model.ValidationError, error) {
+	existing, err := s.scheduleRepo.GetSchedulesByStaff(schedule.StaffID)
+	if err != nil {
+		return nil, fmt.Errorf("çalışma programları getirilemedi: %v", err)
+	}
+
+	var others []model.StaffSchedule
+	var validationErrors []model.ValidationError
+	for _, other := range existing {
+		if other.ID == schedule.ID && schedule.ID != 0 {
+			continue
+		}
+		if schedulesOverlap(schedule, &other) {
+			validationErrors = append(validationErrors, model.ValidationError{
+				Field:   "effective_from",
+				Message: fmt.Sprintf("Bu tarihlerde personelin başka bir programı var (%s)", formatScheduleRange(&other)),
+			})
+			continue
+		}
+		others = append(others, other)
+	}
+	if len(validationErrors) > 0 {
+		return validationErrors, nil
+	}
+	return s.checkConflicts(schedule, others), nil
+}
+
+// checkConflicts programın kendi içindeki ve komşu programlarla sınırlarındaki vardiya çakışmalarını ve dinlenme ihlallerini bulur
+// Desen döngüsel olduğu için başlangıçtan itibaren iki döngü ve bitiş tarihinin çevresi incelenir
+func (s *ScheduleService) checkConflicts(schedule *model.StaffSchedule, others []model.StaffSchedule) []model.ValidationError {
+	from := model.DateOf(schedule.EffectiveFrom)
+	windows := [][2]time.Time{{from.AddDate(0, 0, -1), from.AddDate(0, 0, 2*schedule.CycleDays+1)}}
+	if schedule.EffectiveTo != nil {
+		to := model.DateOf(*schedule.EffectiveTo)
+		if to.Before(windows[0][1]) {
+			windows[0][1] = to.AddDate(0, 0, 1)
+		} else {
+			windows = append(windows, [2]time.Time{to.AddDate(0, 0, -1), to.AddDate(0, 0, 1)})
+		}
+	}
+
+	// Yeni programın vardiyaları ID'si olmayabileceği için işaretçi ile ayırt edilir
+	const candidateID = ^uint(0)
+	candidate := *schedule
+	candidate.ID = candidateID
+	schedules := append([]model.StaffSchedule{candidate}, others...)
+
+	var validationErrors []model.ValidationError
+	seen := make(map[string]bool)
+	total := 0
+	for _, window := range windows {
+		occurrences := ExpandSchedules(schedules, window[0], window[1])
+		var last *model.ShiftOccurrence
+		for i := range occurrences {
+			current := &occurrences[i]
+			if last != nil && (last.ScheduleID == candidateID || current.ScheduleID == candidateID) {
+				var message string
+				if current.Start.Before(last.End) {
+					message = fmt.Sprintf("Vardiyalar çakışıyor: %s ve %s", describeOccurrence(last), describeOccurrence(current))
+				} else if rest := current.Start.Sub(last.End); rest < s.minRest && current.Date != last.Date {
+					// Aynı gün başlayan bölünmüş vardiyalar (ör. 08-12 ve 13-17) tek iş günü sayılır
+					message = fmt.Sprintf("Dinlenme süresi yetersiz (en az %s): %s ile %s arasında %s",
+						formatRestDuration(s.minRest), describeOccurrence(last), describeOccurrence(current), formatRestDuration(rest))
+				}
+				if message != "" && !seen[message] {
+					seen[message] = true
+					total++
+					if total <= MAX_SCHEDULE_CONFLICTS {
+						validationErrors = append(validationErrors, model.ValidationError{Field: "entries", Message: message})
+					}
+				}
+			}
+			if last == nil || current.End.After(last.End) {
+				last = current
+			}
+		}
+	}
+	if total > MAX_SCHEDULE_CONFLICTS {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "entries",
+			Message: fmt.Sprintf("... ve %d çakışma daha", total-MAX_SCHEDULE_CONFLICTS),
+		})
+	}
+	return validationErrors
+}
+
+// checkTemplateChange değişen saatlerle şablonu kullanan güncel ve gelecek programları yeniden kontrol eder
+func (s *ScheduleService) checkTemplateChange(template *model.ShiftTemplate) ([]model.ValidationError, error) {
+	affected, err := s.scheduleRepo.GetSchedulesUsingTemplate(template.ID, model.DateOf(time.Now()))
+	if err != nil {
+		return nil, fmt.Errorf("vardiyayı kullanan programlar getirilemedi: %v", err)
+	}
+
+	var validationErrors []model.ValidationError
+	for _, schedule := range affected {
+		staffSchedules, err := s.scheduleRepo.GetSchedulesByStaff(schedule.StaffID)
+		if err != nil {
+			return nil, fmt.Errorf("çalışma programları getirilemedi: %v", err)
+		}
+		var others []model.StaffSchedule
+		for _, other := range staffSchedules {
+			if other.ID != schedule.ID {
+				others = append(others, other)
+			}
+		}
+		applyTemplate(&schedule, template)
+		for i := range others {
+			applyTemplate(&others[i], template)
+		}
+
+		for _, conflict := range s.checkConflicts(&schedule, others) {
+			validationErrors = append(validationErrors, model.ValidationError{
+				Field:   "start_time",
+				Message: fmt.Sprintf("Personel %d programında: %s", schedule.StaffID, conflict.Message),
+			})
+		}
+		if len(validationErrors) >= MAX_SCHEDULE_CONFLICTS {
+			break
+		}
+	}
+	return validationErrors, nil
+}
+
+// ==================== HELPER METHODS ====================
+
+// applyTemplate programdaki şablon bilgisini verilen (henüz kaydedilmemiş) haliyle değiştirir
+func applyTemplate(schedule *model.StaffSchedule, template *model.ShiftTemplate) {
+	for i := range schedule.Entries {
+		if schedule.Entries[i].ShiftTemplateID == template.ID {
+			schedule.Entries[i].ShiftTemplate = *template
+		}
+	}
+}
+
+// checkStaff personelin hastaneye ait olduğunu kontrol eder
+func (s *ScheduleService) checkStaff(hospitalID, staffID uint) error {
+	staff, err := s.staffRepo.GetByID(staffID)
+	if err != nil || staff.HospitalID != hospitalID {
+		return ErrScheduleStaffNotFound
+	}
+	return nil
+}
+
+// getTemplate hastaneye ait vardiya şablonunu getirir
+func (s *ScheduleService) getTemplate(hospitalID, id uint) (*model.ShiftTemplate, error) {
+	template, err := s.scheduleRepo.GetTemplateByID(id)
+	if err != nil || template.HospitalID != hospitalID {
+		return nil, ErrShiftTemplateNotFound
+	}
+	return template, nil
+}
+
+// getSchedule personele ait programı getirir
+func (s *ScheduleService) getSchedule(hospitalID, staffID, scheduleID uint) (*model.StaffSchedule, error) {
+	schedule, err := s.scheduleRepo.GetScheduleByID(scheduleID)
+	if err != nil || schedule.HospitalID != hospitalID || schedule.StaffID != staffID {
+		return nil, ErrScheduleNotFound
+	}
+	return schedule, nil
+}
+
+// reload kaydedilen programı vardiya bilgileriyle birlikte getirir
+func (s *ScheduleService) reload(id uint) (*model.StaffSchedule, []model.ValidationError, error) {
+	schedule, err := s.scheduleRepo.GetScheduleByID(id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("kaydedilen program getirilemedi: %v", err)
+	}
+	return schedule, nil, nil
+}
+
+// defaultTemplate hastanenin varsayılan vardiyasını getirir; henüz oluşturulmamışsa kaydedilmemiş halini döndürür (doğrulama için)
+func (s *ScheduleService) defaultTemplate(hospitalID uint) model.ShiftTemplate {
+	if template, err := s.scheduleRepo.GetTemplateByName(hospitalID, model.DefaultShiftTemplateName); err == nil {
+		return *template
+	}
+	return model.ShiftTemplate{
+		HospitalID: hospitalID,
+		Name:       model.DefaultShiftTemplateName,
+		StartTime:  model.DefaultShiftTemplateStart,
+		EndTime:    model.DefaultShiftTemplateEnd,
+	}
+}
+
+// resolveStaffTemplate personel eklerken seçilen vardiyayı, seçilmediyse varsayılan vardiyayı döndürür
+func (s *ScheduleService) resolveStaffTemplate(hospitalID uint, templateID *uint) (model.ShiftTemplate, error) {
+	if templateID == nil {
+		return s.defaultTemplate(hospitalID), nil
+	}
+	template, err := s.getTemplate(hospitalID, *templateID)
+	if err != nil {
+		return model.ShiftTemplate{}, err
+	}
+	return *template, nil
+}
+
+// newWeeklySchedule seçilen günlerde aynı vardiyayla çalışılan, bugünden itibaren geçerli haftalık program oluşturur
+func newWeeklySchedule(hospitalID uint, days []int, template model.ShiftTemplate) model.StaffSchedule {
+	schedule := model.StaffSchedule{
+		HospitalID:    hospitalID,
+		Pattern:       model.SchedulePatternWeekly,
+		CycleDays:     7,
+		EffectiveFrom: storedDate(time.Now()),
+	}
+	seen := make(map[int]bool, len(days))
+	for _, day := range days {
+		if seen[day] {
+			continue
+		}
+		seen[day] = true
+		schedule.Entries = append(schedule.Entries, model.StaffScheduleEntry{
+			Day:             day,
+			ShiftTemplateID: template.ID,
+			ShiftTemplate:   template,
+		})
+	}
+	return schedule
+}
+
+// schedulesOverlap iki programın geçerlilik aralıklarının kesişip kesişmediğini kontrol eder
+func schedulesOverlap(a, b *model.StaffSchedule) bool {
+	return dateRangesOverlap(a.EffectiveFrom, a.EffectiveTo, b.EffectiveFrom, b.EffectiveTo)
+}
+
+// dateRangesOverlap bitişi boş (süresiz) olabilen iki gün aralığının kesişip kesişmediğini kontrol eder
+func dateRangesOverlap(aFrom time.Time, aTo *time.Time, bFrom time.Time, bTo *time.Time) bool {
+	if aTo != nil && model.DateOf(*aTo).Before(model.DateOf(bFrom)) {
+		return false
+	}
+	if bTo != nil && model.DateOf(*bTo).Before(model.DateOf(aFrom)) {
+		return false
+	}
+	return true
+}
+
+// parseScheduleDate "YYYY-AA-GG" tarihini kaydedilecek biçime çevirir
+func parseScheduleDate(value string) (time.Time, error) {
+	parsed, err := time.Parse(scheduleDateLayout, strings.TrimSpace(value))
+	if err != nil {
+		return time.Time{}, err
+	}
+	return storedDate(parsed), nil
+}
+
+// storedDate günü date kolonuna yazılacak şekilde UTC gece yarısına çevirir
+func storedDate(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// formatScheduleRange programın geçerlilik aralığını "06.01.2025 - süresiz" biçiminde döndürür
+func formatScheduleRange(schedule *model.StaffSchedule) string {
+	to := "süresiz"
+	if schedule.EffectiveTo != nil {
+		to = schedule.EffectiveTo.Format(scheduleDisplayDateLayout)
+	}
+	return schedule.EffectiveFrom.Format(scheduleDisplayDateLayout) + " - " + to
+}
+
+// describeOccurrence vardiyayı "06.01.2025 Gece (20:00-08:00)" biçiminde döndürür
+func describeOccurrence(occurrence *model.ShiftOccurrence) string {
+	return fmt.Sprintf("%s %s (%s-%s)", occurrence.Start.Format(scheduleDisplayDateLayout), occurrence.ShiftName,
+		occurrence.Start.Format("15:04"), occurrence.End.Format("15:04"))
+}
+
+// formatRestDuration süreyi "11 saat" veya "8 saat 30 dakika" biçiminde döndürür
+func formatRestDuration(duration time.Duration) string {
+	hours := int(duration.Hours())
+	minutes := int(duration.Minutes()) % 60
+	if minutes == 0 {
+		return fmt.Sprintf("%d saat", hours)
+	}
+	return fmt.Sprintf("%d saat %d dakika", hours, minutes)
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"hospital-platform/database"
+	"hospital-platform/model"
+	"hospital-platform/utils"
+	"sort"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSessionNotFound      = errors.New("Oturum bulunamadı")
+	ErrSessionUserNotFound  = errors.New("Kullanıcı bulunamadı")
+	ErrCurrentSessionRevoke = errors.New("Mevcut oturum bu işlemle sonlandırılamaz, çıkış yapın")
+)
+
+// SessionService - Kullanıcı oturumları (cihazlar) iş mantığı
+// Her login bir token ailesi başlatır; oturum ID'si token ailesi ID'sidir ve iptal edilince
+// aileye ait access/refresh token'lar middleware ve refresh tarafından reddedilir
+type SessionService struct {
+	membershipService *MembershipService
+	tokenService      *TokenService
+}
+
+// NewSessionService yeni bir oturum servisi oluşturur
+func NewSessionService() *SessionService {
+	return &SessionService{
+		membershipService: NewMembershipService(),
+		tokenService:      NewTokenService(),
+	}
+}
+
+// ==================== KENDİ OTURUMLARI ====================
+
+// ListSessions kullanıcının aktif oturumlarını son görülme zamanına göre listeler
+// currentFamilyID isteği yapan oturumu işaretlemek için kullanılır
+func (s *SessionService) ListSessions(userID uint, currentFamilyID string) ([]model.SessionResponse, error) {
+	familyIDs, err := database.GetUserTokenFamilies(userID)
+	if err != nil {
+		return nil, fmt.Errorf("oturumlar getirilemedi: %v", err)
+	}
+
+	sessions := make([]model.SessionResponse, 0, len(familyIDs))
+	for _, familyID := range familyIDs {
+		record, err := database.GetSession(familyID)
+		if errors.Is(err, redis.Nil) {
+			// Süresi dolmuş oturum - listeden temizle
+			if err := database.RemoveUserTokenFamily(userID, familyID); err != nil {
+				fmt.Printf("⚠️ Süresi dolmuş oturum listeden çıkarılamadı: %v\n", err)
+			}
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("oturum getirilemedi: %v", err)
+		}
+		if record.UserID != userID {
+			continue
+		}
+
+		sessions = append(sessions, toSessionResponse(familyID, record, familyID == currentFamilyID))
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+	})
+
+	return sessions, nil
+}
+
+// RevokeSession kullanıcının kendi oturumlarından birini sonlandırır
+func (s *SessionService) RevokeSession(userID uint, sessionID string) error {
+	if err := s.getOwnedSession(userID, sessionID); err != nil {
+		return err
+	}
+
+	if err := s.tokenService.Logout(userID, sessionID); err != nil {
+		return err
+	}
+
+	fmt.Printf("🔒 Oturum sonlandırıldı: kullanıcı=%d oturum=%s\n", userID, sessionID)
+	return nil
+}
+
+// RevokeOtherSessions isteği yapan oturum dışındaki tüm oturumları sonlandırır
+func (s *SessionService) RevokeOtherSessions(userID uint, currentFamilyID string) (int, error) {
+	familyIDs, err := database.GetUserTokenFamilies(userID)
+	if err != nil {
+		return 0, fmt.Errorf("oturumlar getirilemedi: %v", err)
+	}
+
+	revoked := 0
+	for _, familyID := range familyIDs {
+		if familyID == currentFamilyID {
+			continue
+		}
+		if err := s.tokenService.Logout(userID, familyID); err != nil {
+			return revoked, err
+		}
+		revoked++
+	}
+
+	fmt.Printf("🔒 Diğer oturumlar sonlandırıldı: kullanıcı=%d adet=%d\n", userID, revoked)
+	return revoked, nil
+}
+
+// ==================== YÖNETİCİ İŞLEMLERİ ====================
+
+// ListUserSessions hastanedeki bir kullanıcının aktif oturumlarını listeler
+func (s *SessionService) ListUserSessions(hospitalID, actorID, userID uint) ([]model.SessionResponse, error) {
+	if _, err := s.getManageableUser(hospitalID, actorID, userID); err != nil {
+		return nil, err
+	}
+	return s.ListSessions(userID, "")
+}
+
+// RevokeUserSession hastanedeki bir kullanıcının oturumunu sonlandırır
+// Yöneticinin kendi oturumu /me/sessions üzerinden yönetilir; isteği yapan oturum bu yolla kapatılamaz
+func (s *SessionService) RevokeUserSession(hospitalID, actorID, userID uint, sessionID, currentFamilyID string) error {
+	if _, err := s.getManageableUser(hospitalID, actorID, userID); err != nil {
+		return err
+	}
+	if sessionID == currentFamilyID {
+		return ErrCurrentSessionRevoke
+	}
+	if err := s.getOwnedSession(userID, sessionID); err != nil {
+		return err
+	}
+
+	if err := s.tokenService.Logout(userID, sessionID); err != nil {
+		return err
+	}
+
+	fmt.Printf("🔒 Oturum yönetici tarafından sonlandırıldı: kullanıcı=%d oturum=%s yönetici=%d\n", userID, sessionID, actorID)
+	return nil
+}
+
+// RevokeAllUserSessions hastanedeki bir kullanıcının tüm oturumlarını sonlandırır
+func (s *SessionService) RevokeAllUserSessions(hospitalID, actorID, userID uint) error {
+	if actorID == userID {
+		return ErrCurrentSessionRevoke
+	}
+	if _, err := s.getManageableUser(hospitalID, actorID, userID); err != nil {
+		return err
+	}
+
+	if err := s.tokenService.RevokeAllForUser(userID); err != nil {
+		return err
+	}
+
+	fmt.Printf("🔒 Tüm oturumlar yönetici tarafından sonlandırıldı: kullanıcı=%d yönetici=%d\n", userID, actorID)
+	return nil
+}
+
+// ==================== HELPER METHODS ====================
+
+// getOwnedSession oturumun var olduğunu ve kullanıcıya ait olduğunu kontrol eder
+func (s *SessionService) getOwnedSession(userID uint, sessionID string) error {
+	record, err := database.GetSession(sessionID)
+	if errors.Is(err, redis.Nil) {
+		return ErrSessionNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("oturum getirilemedi: %v", err)
+	}
+	if record.UserID != userID {
+		return ErrSessionNotFound
+	}
+	return nil
+}
+
+// getManageableUser hedef kullanıcının hastanede (birincil hastane veya aktif üyelik) olduğunu ve
+// yöneticinin o hastanedeki rolüyle yetkisini aşmadığını kontrol eder
+func (s *SessionService) getManageableUser(hospitalID, actorID, userID uint) (*model.User, error) {
+	user, err := s.membershipService.GetUserInHospital(userID, hospitalID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, ErrMembershipNotFound) {
+			return nil, ErrSessionUserNotFound
+		}
+		return nil, fmt.Errorf("kullanıcı getirilemedi: %v", err)
+	}
+	if user.HospitalID != hospitalID {
+		return nil, ErrSessionUserNotFound
+	}
+
+	actor, err := s.membershipService.GetUserInHospital(actorID, hospitalID)
+	if err != nil {
+		return nil, fmt.Errorf("işlemi yapan kullanıcı bulunamadı: %v", err)
+	}
+	if actorID != userID {
+		if err := NewRoleService().CheckAssignableRole(hospitalID, actor.Role, user.Role); errors.Is(err, ErrPermissionEscalation) {
+			return nil, err
+		}
+	}
+
+	return user, nil
+}
+
+// toSessionResponse oturum kaydını API cevabına çevirir
+func toSessionResponse(familyID string, record *model.SessionRecord, current bool) model.SessionResponse {
+	return model.SessionResponse{
+		ID:         familyID,
+		Device:     utils.DescribeUserAgent(record.UserAgent),
+		UserAgent:  record.UserAgent,
+		IP:         record.IP,
+		LastIP:     record.LastIP,
+		MFA:        record.MFA,
+		CreatedAt:  record.CreatedAt,
+		LastSeenAt: record.LastSeenAt,
+		Current:    current,
+	}
+}
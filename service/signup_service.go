@@ -0,0 +1,400 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"hospital-platform/model"
+	"hospital-platform/repository"
+	"hospital-platform/utils"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// signupRejectReasonMaxLength red gerekçesinin en fazla uzunluğu
+const signupRejectReasonMaxLength = 500
+
+var (
+	ErrSignupNotFound   = errors.New("Başvuru bulunamadı")
+	ErrSignupNotPending = errors.New("Bu başvuru zaten incelenmiş")
+)
+
+// SignupService - Kendi kendine kayıt başvuruları
+// Başvuran hastane ve rol seçemez: ikisi de katılım kodundan veya e-posta alan adından sunucuda belirlenir
+// Başvuru yetkili onaylayana kadar kullanıcıya dönüşmez
+type SignupService struct {
+	signupRepo          *repository.SignupRepository
+	userRepo            *repository.UserRepository
+	hospitalRepo        *repository.HospitalRepository
+	roleRepo            *repository.RoleRepository
+	invitationRepo      *repository.InvitationRepository
+	joinCodeService     *JoinCodeService
+	verificationService *VerificationService
+	notificationService *NotificationService
+}
+
+// NewSignupService yeni bir kayıt servisi oluşturur
+func NewSignupService() *SignupService {
+	return &SignupService{
+		signupRepo:          repository.NewSignupRepository(),
+		userRepo:            repository.NewUserRepository(),
+		hospitalRepo:        repository.NewHospitalRepository(),
+		roleRepo:            repository.NewRoleRepository(),
+		invitationRepo:      repository.NewInvitationRepository(),
+		joinCodeService:     NewJoinCodeService(),
+		verificationService: NewVerificationService(),
+		notificationService: NewNotificationService(),
+	}
+}
+
+// ==================== BAŞVURAN İŞLEMLERİ ====================
+
+// SendEmailCode başvuru öncesi e-posta sahipliğini doğrulamak için kod gönderir
+func (s *SignupService) SendEmailCode(email, lang string) error {
+	email = normalizeSignupEmail(email)
+	return s.verificationService.SendCode(signupSubject(email), utils.CHANNEL_EMAIL, email, lang)
+}
+
+// Submit kayıt başvurusunu doğrular ve onay bekleyen olarak kaydeder
+func (s *SignupService) Submit(req *model.SelfSignupRequest) (*model.SignupSubmittedResponse, []model.ValidationError, error) {
+	req.Email = normalizeSignupEmail(req.Email)
+	req.Phone = strings.TrimSpace(req.Phone)
+
+	// 1. Hastane ve rol - katılım kodu veya e-posta alan adından
+	request, validationErrors, err := s.resolveTarget(req)
+	if len(validationErrors) > 0 || err != nil {
+		return nil, validationErrors, err
+	}
+
+	// 2. Kimlik, iletişim bilgileri ve şifre politikası
+	validationErrors, err = s.validateSignup(req, request.HospitalID)
+	if len(validationErrors) > 0 || err != nil {
+		return nil, validationErrors, err
+	}
+
+	// 3. E-posta doğrulama kodu
+	subject := signupSubject(req.Email)
+	if err := s.verificationService.CheckCode(subject, req.EmailCode); err != nil {
+		return nil, nil, err
+	}
+
+	// 4. Başvuruyu kaydet
+	hashedPassword, err := utils.HashPassword(req.Password)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	request.FirstName = req.FirstName
+	request.LastName = req.LastName
+	request.TCKN = req.TCKN
+	request.Email = req.Email
+	request.Phone = req.Phone
+	request.PasswordHash = hashedPassword
+	request.Lang = ResolveLanguage(req.Lang, "")
+	request.Status = model.SignupPending
+
+	if err := s.signupRepo.Create(request); err != nil {
+		if errors.Is(err, repository.ErrJoinCodeUnavailable) {
+			return nil, []model.ValidationError{joinCodeError()}, nil
+		}
+		return nil, nil, fmt.Errorf("başvuru kaydedilemedi: %v", err)
+	}
+	s.verificationService.Consume(subject)
+
+	hospitalName := ""
+	if hospital, err := s.hospitalRepo.GetByID(request.HospitalID); err == nil {
+		hospitalName = hospital.Name
+	}
+
+	fmt.Printf("📝 Kayıt başvurusu alındı: %d (hastane %d, %s)\n", request.ID, request.HospitalID, request.Source)
+
+	return &model.SignupSubmittedResponse{
+		ID:           request.ID,
+		Status:       request.Status,
+		HospitalName: hospitalName,
+	}, nil, nil
+}
+
+// ==================== YETKİLİ İŞLEMLERİ ====================
+
+// ListRequests hastanenin kayıt başvurularını listeler
+func (s *SignupService) ListRequests(hospitalID uint, status string) ([]model.SignupRequest, error) {
+	requests, err := s.signupRepo.GetByHospitalID(hospitalID, status)
+	if err != nil {
+		return nil, fmt.Errorf("başvurular getirilemedi: %v", err)
+	}
+	return requests, nil
+}
+
+// Approve başvuruyu onaylar ve kullanıcıyı oluşturur
+// Rol ve poliklinik boşsa başvurudakiler kullanılır; onaylayan kendi yetkilerini aşan bir rol veremez
+func (s *SignupService) Approve(hospitalID, requestID, actorID uint, req *model.ApproveSignupRequest) (*model.SignupRequest, []model.ValidationError, error) {
+	request, actor, err := s.getPendingRequest(hospitalID, requestID, actorID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if req.Role != "" {
+		request.Role = req.Role
+	}
+	if req.PolyclinicID != nil {
+		request.PolyclinicID = req.PolyclinicID
+	}
+
+	validationErrors, err := validateRoleAssignment(actor, request.Role, request.PolyclinicID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Başvurudan sonra aynı bilgilerle kullanıcı oluşmuş olabilir
+	validationErrors = append(validationErrors, s.validateIdentityUnused(request.TCKN, request.Email, request.Phone)...)
+	if len(validationErrors) > 0 {
+		return nil, validationErrors, nil
+	}
+
+	// Şifre ve e-posta başvuru sırasında belirlendi/doğrulandı
+	submittedAt := request.CreatedAt
+	user := &model.User{
+		HospitalID:        request.HospitalID,
+		FirstName:         request.FirstName,
+		LastName:          request.LastName,
+		TCKN:              request.TCKN,
+		Email:             request.Email,
+		Phone:             request.Phone,
+		Password:          request.PasswordHash,
+		Role:              request.Role,
+		PolyclinicID:      request.PolyclinicID,
+		CreatedBy:         &actor.ID,
+		Status:            model.UserStatusActive,
+		PasswordChangedAt: &submittedAt,
+		EmailVerifiedAt:   &submittedAt,
+	}
+
+	if err := s.signupRepo.Approve(request, user, actor.ID); err != nil {
+		if errors.Is(err, repository.ErrSignupAlreadyReviewed) {
+			return nil, nil, ErrSignupNotPending
+		}
+		return nil, nil, fmt.Errorf("kullanıcı oluşturulamadı: %v", err)
+	}
+
+	fmt.Printf("✅ Kayıt başvurusu onaylandı: %d -> kullanıcı %d (onaylayan %d)\n", request.ID, user.ID, actor.ID)
+
+	s.notify(request, TEMPLATE_SIGNUP_APPROVED, map[string]interface{}{
+		"Role": request.Role,
+	})
+	return request, nil, nil
+}
+
+// Reject başvuruyu gerekçesiyle reddeder; başvuran e-postayla bilgilendirilir
+func (s *SignupService) Reject(hospitalID, requestID, actorID uint, reason string) (*model.SignupRequest, []model.ValidationError, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" || len([]rune(reason)) > signupRejectReasonMaxLength {
+		return nil, []model.ValidationError{{
+			Field:   "reason",
+			Message: fmt.Sprintf("Red gerekçesi 1 ile %d karakter arasında olmalıdır", signupRejectReasonMaxLength),
+		}}, nil
+	}
+
+	request, actor, err := s.getPendingRequest(hospitalID, requestID, actorID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.signupRepo.Reject(request, actor.ID, reason); err != nil {
+		if errors.Is(err, repository.ErrSignupAlreadyReviewed) {
+			return nil, nil, ErrSignupNotPending
+		}
+		return nil, nil, fmt.Errorf("başvuru reddedilemedi: %v", err)
+	}
+
+	fmt.Printf("🚫 Kayıt başvurusu reddedildi: %d (reddeden %d)\n", request.ID, actor.ID)
+
+	s.notify(request, TEMPLATE_SIGNUP_REJECTED, map[string]interface{}{
+		"Reason": reason,
+	})
+	return request, nil, nil
+}
+
+// ==================== HELPER METHODS ====================
+
+// resolveTarget başvurunun hastanesini, rolünü ve kaynağını belirler
+// Katılım kodu girildiyse o kullanılır, yoksa e-posta alan adına bakılır
+func (s *SignupService) resolveTarget(req *model.SelfSignupRequest) (*model.SignupRequest, []model.ValidationError, error) {
+	request := &model.SignupRequest{}
+
+	if strings.TrimSpace(req.JoinCode) != "" {
+		code, err := s.joinCodeService.ResolveJoinCode(req.JoinCode)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, []model.ValidationError{joinCodeError()}, nil
+			}
+			return nil, nil, fmt.Errorf("katılım kodu kontrol edilemedi: %v", err)
+		}
+		request.HospitalID = code.HospitalID
+		request.Role = code.Role
+		request.PolyclinicID = code.PolyclinicID
+		request.Source = model.SignupViaJoinCode
+		request.JoinCodeID = &code.ID
+	} else {
+		domain, err := s.joinCodeService.ResolveEmailDomain(req.Email)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, []model.ValidationError{{
+					Field:   "join_code",
+					Message: "Bu e-posta alan adıyla kayıt açık değil, hastanenizden katılım kodu isteyin",
+				}}, nil
+			}
+			return nil, nil, fmt.Errorf("alan adı kontrol edilemedi: %v", err)
+		}
+		request.HospitalID = domain.HospitalID
+		request.Role = domain.Role
+		request.PolyclinicID = domain.PolyclinicID
+		request.Source = model.SignupViaEmailDomain
+	}
+
+	// Kod/alan adı oluşturulduktan sonra rol silinmiş olabilir
+	if _, err := s.roleRepo.GetByName(request.HospitalID, request.Role); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, []model.ValidationError{{
+				Field:   "join_code",
+				Message: "Bu kayıt yöntemi artık kullanılamıyor, hastanenizle iletişime geçin",
+			}}, nil
+		}
+		return nil, nil, fmt.Errorf("rol getirilemedi: %v", err)
+	}
+
+	return request, nil, nil
+}
+
+// validateSignup başvuru verilerini doğrular: TC kimlik, benzersizlik ve şifre politikası
+func (s *SignupService) validateSignup(req *model.SelfSignupRequest, hospitalID uint) ([]model.ValidationError, error) {
+	var validationErrors []model.ValidationError
+
+	if len(req.TCKN) != 11 {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "tc",
+			Message: "TC kimlik numarası 11 haneli olmalıdır",
+		})
+	}
+
+	validationErrors = append(validationErrors, s.validateIdentityUnused(req.TCKN, req.Email, req.Phone)...)
+
+	pending, err := s.signupRepo.GetPendingByIdentity(req.TCKN, req.Email, req.Phone)
+	if err != nil {
+		return nil, fmt.Errorf("başvurular kontrol edilemedi: %v", err)
+	}
+	if len(pending) > 0 {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "email",
+			Message: "Bu bilgilerle onay bekleyen bir başvuru zaten var",
+		})
+	}
+
+	invited, err := s.invitationRepo.HasActiveForContact(req.Email, req.Phone, 0)
+	if err != nil {
+		return nil, fmt.Errorf("davetler kontrol edilemedi: %v", err)
+	}
+	if invited {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "email",
+			Message: "Bu e-posta adresi veya telefon için bekleyen bir davet var, e-postanızdaki davet linkini kullanın",
+		})
+	}
+
+	if req.Password != req.ConfirmPassword {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "confirm_password",
+			Message: "Şifreler eşleşmiyor",
+		})
+		return validationErrors, nil
+	}
+
+	policyErrors, err := NewPasswordPolicyService().ValidatePassword(hospitalID, nil, "password", req.Password)
+	if err != nil {
+		return nil, err
+	}
+	return append(validationErrors, policyErrors...), nil
+}
+
+// validateIdentityUnused TC, e-posta ve telefonun mevcut kullanıcılarda kullanılmadığını kontrol eder
+func (s *SignupService) validateIdentityUnused(tckn, email, phone string) []model.ValidationError {
+	var validationErrors []model.ValidationError
+
+	if existing, _ := s.userRepo.GetByTCKN(tckn); existing != nil {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "tc",
+			Message: "Bu TC kimlik numarası zaten kullanılıyor",
+		})
+	}
+	if existing, _ := s.userRepo.GetByEmail(email); existing != nil {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "email",
+			Message: "Bu e-posta adresi zaten kullanılıyor",
+		})
+	}
+	if existing, _ := s.userRepo.GetByPhone(phone); existing != nil {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "phone",
+			Message: "Bu telefon numarası zaten kullanılıyor",
+		})
+	}
+
+	return validationErrors
+}
+
+// getPendingRequest inceleme için başvuruyu ve işlemi yapan yetkiliyi getirir
+func (s *SignupService) getPendingRequest(hospitalID, requestID, actorID uint) (*model.SignupRequest, *model.User, error) {
+	request, err := s.signupRepo.GetByID(requestID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrSignupNotFound
+		}
+		return nil, nil, fmt.Errorf("başvuru getirilemedi: %v", err)
+	}
+	if request.HospitalID != hospitalID {
+		return nil, nil, ErrSignupNotFound
+	}
+	if request.Status != model.SignupPending {
+		return nil, nil, ErrSignupNotPending
+	}
+
+	actor, err := NewMembershipService().GetUserInHospital(actorID, hospitalID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("işlemi yapan kullanıcı bulunamadı: %v", err)
+	}
+	return request, actor, nil
+}
+
+// notify başvurana sonucu e-postayla bildirir; gönderim hatası işlemi geri almaz
+func (s *SignupService) notify(request *model.SignupRequest, templateName string, data map[string]interface{}) {
+	hospital, err := s.hospitalRepo.GetByID(request.HospitalID)
+	if err != nil {
+		fmt.Println("Başvuru bildirimi için hastane bulunamadı:", err)
+		return
+	}
+
+	data["FirstName"] = request.FirstName
+	data["HospitalName"] = hospital.Name
+
+	if err := s.notificationService.Send(utils.CHANNEL_EMAIL, request.Email, templateName, request.Lang, data); err != nil {
+		fmt.Println("Başvuru bildirimi gönderilemedi:", err)
+	}
+}
+
+// signupSubject başvuru e-posta doğrulama kodu için konu anahtarı
+func signupSubject(email string) string {
+	return "signup:" + email
+}
+
+// normalizeSignupEmail e-postayı boşluklardan arındırıp küçük harfe çevirir
+func normalizeSignupEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// joinCodeError geçersiz katılım kodu hatası
+func joinCodeError() model.ValidationError {
+	return model.ValidationError{
+		Field:   "join_code",
+		Message: "Katılım kodu geçersiz, süresi dolmuş veya kullanım limiti dolmuş",
+	}
+}
@@ -0,0 +1,558 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hospital-platform/config"
+	"hospital-platform/database"
+	"hospital-platform/model"
+	"hospital-platform/repository"
+	"hospital-platform/utils"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+const (
+	ssoMaxRoleMappings  = 50
+	ssoDefaultTCKNClaim = "tckn"
+)
+
+var (
+	ErrSSONotConfigured   = errors.New("Bu hastane için SSO ile giriş tanımlı değil")
+	ErrSSOInvalidState    = errors.New("SSO girişi geçersiz veya süresi dolmuş, girişi yeniden başlatın")
+	ErrSSOIdentityDenied  = errors.New("Kimlik sağlayıcı girişi reddetti")
+	ErrSSOEmailMissing    = errors.New("Kimlik sağlayıcı doğrulanmış bir e-posta adresi göndermedi")
+	ErrSSOUserNotFound    = errors.New("Bu e-posta ile kayıtlı kullanıcı yok, yöneticinizden davet isteyin")
+	ErrSSOUserMismatch    = errors.New("Bu e-posta başka bir hastanedeki kullanıcıya ait")
+	ErrSSORoleNotMapped   = errors.New("Kimlik sağlayıcıdaki rolünüz bu hastanede bir role eşlenmemiş")
+	ErrSSOProvisionFailed = errors.New("Kullanıcı otomatik oluşturulamadı: kimlik sağlayıcı TC kimlik/telefon bilgisi göndermedi veya bu bilgiler başka kullanıcıda kayıtlı")
+)
+
+// getSSORedirectURI IdP'nin kullanıcıyı geri göndereceği adres (IdP'de kayıtlı olmalı)
+func getSSORedirectURI() string {
+	return config.GetEnv("SSO_REDIRECT_URL", "http://localhost:3000/sso/callback")
+}
+
+// getSSOStateTTL IdP'de giriş için tanınan süre
+func getSSOStateTTL() time.Duration {
+	return config.GetEnvDuration("SSO_STATE_TTL", 10*time.Minute)
+}
+
+// SSOService - Hastane bazlı OpenID Connect tek oturum açma iş mantığı
+// Authorization code + PKCE akışı; kullanıcı e-postayla eşleştirilir veya (açıksa) ilk girişte oluşturulur
+type SSOService struct {
+	ssoRepo  *repository.SSORepository
+	userRepo *repository.UserRepository
+}
+
+// NewSSOService yeni bir SSO servisi oluşturur
+func NewSSOService() *SSOService {
+	return &SSOService{
+		ssoRepo:  repository.NewSSORepository(),
+		userRepo: repository.NewUserRepository(),
+	}
+}
+
+// ==================== AYARLAR ====================
+
+// GetConfig hastanenin SSO ayarlarını getirir
+func (s *SSOService) GetConfig(hospitalID uint) (*model.SSOConfigResponse, error) {
+	ssoConfig, err := s.ssoRepo.GetByHospitalID(hospitalID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSSONotConfigured
+		}
+		return nil, fmt.Errorf("SSO ayarları getirilemedi: %v", err)
+	}
+	return toSSOConfigResponse(ssoConfig), nil
+}
+
+// UpdateConfig hastanenin SSO ayarlarını oluşturur veya günceller
+// Eşlenen roller, ayarı yapan yetkilinin atayabileceği roller olmalı; açık ayarlarda IdP discovery'si doğrulanır
+func (s *SSOService) UpdateConfig(req *model.SSOConfigRequest, hospitalID, actorID uint) (*model.SSOConfigResponse, []model.ValidationError, error) {
+	actor, err := NewMembershipService().GetUserInHospital(actorID, hospitalID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("kullanıcı bulunamadı: %v", err)
+	}
+
+	ssoConfig, err := s.ssoRepo.GetByHospitalID(actor.HospitalID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, fmt.Errorf("SSO ayarları getirilemedi: %v", err)
+		}
+		ssoConfig = &model.HospitalSSOConfig{HospitalID: actor.HospitalID}
+	}
+
+	req.Issuer = strings.TrimSpace(req.Issuer)
+	req.ClientID = strings.TrimSpace(req.ClientID)
+	if req.TCKNClaim == "" {
+		req.TCKNClaim = ssoDefaultTCKNClaim
+	}
+	scopes := normalizeSSOScopes(req.Scopes)
+
+	validationErrors, err := s.validateConfig(actor, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(validationErrors) > 0 {
+		return nil, validationErrors, nil
+	}
+
+	ssoConfig.Enabled = req.Enabled
+	ssoConfig.Issuer = req.Issuer
+	ssoConfig.ClientID = req.ClientID
+	ssoConfig.Scopes = strings.Join(scopes, " ")
+	ssoConfig.RoleClaim = strings.TrimSpace(req.RoleClaim)
+	ssoConfig.DefaultRole = req.DefaultRole
+	ssoConfig.JITProvisioning = req.JITProvisioning
+	ssoConfig.SyncRole = req.SyncRole
+	ssoConfig.TCKNClaim = req.TCKNClaim
+	ssoConfig.UpdatedBy = actor.ID
+	if req.ClientSecret != "" {
+		encrypted, err := utils.EncryptString(req.ClientSecret)
+		if err != nil {
+			return nil, nil, fmt.Errorf("client secret şifrelenemedi: %v", err)
+		}
+		ssoConfig.ClientSecret = encrypted
+	}
+	ssoConfig.RoleMappings = make([]model.SSORoleMapping, 0, len(req.RoleMappings))
+	for _, mapping := range req.RoleMappings {
+		ssoConfig.RoleMappings = append(ssoConfig.RoleMappings, model.SSORoleMapping{
+			ClaimValue: strings.TrimSpace(mapping.ClaimValue),
+			Role:       mapping.Role,
+		})
+	}
+
+	if err := s.ssoRepo.Save(ssoConfig); err != nil {
+		return nil, nil, fmt.Errorf("SSO ayarları kaydedilemedi: %v", err)
+	}
+
+	fmt.Printf("🔐 SSO ayarları güncellendi: hastane=%d issuer=%s açık=%t yetkili=%d\n", ssoConfig.HospitalID, ssoConfig.Issuer, ssoConfig.Enabled, actor.ID)
+	return toSSOConfigResponse(ssoConfig), nil, nil
+}
+
+// DeleteConfig hastanenin SSO ayarlarını siler - kullanıcılar şifreyle girişe döner
+func (s *SSOService) DeleteConfig(hospitalID, actorID uint) error {
+	ssoConfig, err := s.ssoRepo.GetByHospitalID(hospitalID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrSSONotConfigured
+		}
+		return fmt.Errorf("SSO ayarları getirilemedi: %v", err)
+	}
+
+	if err := s.ssoRepo.Delete(ssoConfig); err != nil {
+		return fmt.Errorf("SSO ayarları silinemedi: %v", err)
+	}
+
+	fmt.Printf("🔐 SSO ayarları silindi: hastane=%d yetkili=%d\n", hospitalID, actorID)
+	return nil
+}
+
+// ==================== GİRİŞ AKIŞI ====================
+
+// Start SSO girişini başlatır: state, nonce ve PKCE verifier'ı saklar, IdP giriş adresini döndürür
+// Hastane ID'si verilmezse e-postanın alan adı hastanenin izinli alan adlarından çözülür
+func (s *SSOService) Start(req *model.SSOStartRequest) (*model.SSOStartResponse, error) {
+	hospitalID := req.HospitalID
+	if hospitalID == 0 && req.Email != "" {
+		domain, err := NewJoinCodeService().ResolveEmailDomain(strings.ToLower(strings.TrimSpace(req.Email)))
+		if err != nil {
+			return nil, ErrSSONotConfigured
+		}
+		hospitalID = domain.HospitalID
+	}
+
+	ssoConfig, err := s.getEnabledConfig(hospitalID)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("state üretilemedi: %v", err)
+	}
+	nonce, err := utils.GenerateRandomToken(16)
+	if err != nil {
+		return nil, fmt.Errorf("nonce üretilemedi: %v", err)
+	}
+	verifier, challenge, err := utils.GeneratePKCE()
+	if err != nil {
+		return nil, fmt.Errorf("PKCE üretilemedi: %v", err)
+	}
+
+	authorizationURL, err := utils.OIDCAuthorizationURL(s.client(ssoConfig), state, nonce, challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(model.SSOState{HospitalID: hospitalID, CodeVerifier: verifier, Nonce: nonce})
+	if err != nil {
+		return nil, fmt.Errorf("SSO state hazırlanamadı: %v", err)
+	}
+	if err := database.SaveSSOState(state, data, getSSOStateTTL()); err != nil {
+		return nil, fmt.Errorf("SSO state kaydedilemedi: %v", err)
+	}
+
+	return &model.SSOStartResponse{AuthorizationURL: authorizationURL}, nil
+}
+
+// Callback IdP'den dönen code'u token'a çevirir, ID token'ı doğrular, kullanıcıyı bulur/oluşturur ve platform token'larını üretir
+// Kullanıcının platformda MFA'sı açıksa normal girişteki gibi ikinci adım istenir
+func (s *SSOService) Callback(req *model.SSOCallbackRequest, client model.ClientInfo) (*model.TokenResponse, *model.LoginChallengeResponse, error) {
+	// State her durumda tüketilir - aynı dönüş adresi ikinci kez kullanılamaz
+	data, err := database.ConsumeSSOState(req.State)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil, ErrSSOInvalidState
+		}
+		return nil, nil, fmt.Errorf("SSO state okunamadı: %v", err)
+	}
+	if req.Error != "" {
+		fmt.Printf("⚠️ SSO: IdP hata döndürdü: %s\n", req.Error)
+		return nil, nil, ErrSSOIdentityDenied
+	}
+	if req.Code == "" {
+		return nil, nil, ErrSSOInvalidState
+	}
+
+	var state model.SSOState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, nil, fmt.Errorf("SSO state okunamadı: %v", err)
+	}
+
+	ssoConfig, err := s.getEnabledConfig(state.HospitalID)
+	if err != nil {
+		return nil, nil, err
+	}
+	oidcClient := s.client(ssoConfig)
+
+	tokens, err := utils.ExchangeOIDCCode(oidcClient, req.Code, state.CodeVerifier)
+	if err != nil {
+		return nil, nil, err
+	}
+	claims, err := utils.VerifyOIDCIDToken(oidcClient, tokens.IDToken, state.Nonce)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user, err := s.resolveUser(ssoConfig, claims)
+	if err != nil {
+		fmt.Printf("❌ SSO: hastane=%d sub=%v giriş reddedildi: %v\n", ssoConfig.HospitalID, claims["sub"], err)
+		return nil, nil, err
+	}
+
+	fmt.Printf("✅ SSO: hastane=%d kullanıcı=%d IdP ile doğrulandı\n", ssoConfig.HospitalID, user.ID)
+	return completeLogin(user, client)
+}
+
+// ==================== HELPER METHODS ====================
+
+// resolveUser ID token claim'lerinden kullanıcıyı e-postayla bulur, yoksa (JIT açıksa) oluşturur
+func (s *SSOService) resolveUser(ssoConfig *model.HospitalSSOConfig, claims jwt.MapClaims) (*model.User, error) {
+	email, _ := claims["email"].(string)
+	email = strings.ToLower(strings.TrimSpace(email))
+	if verified, present := claims["email_verified"]; email == "" || (present && verified != true) {
+		return nil, ErrSSOEmailMissing
+	}
+
+	role := s.mapRole(ssoConfig, claims)
+
+	user, err := s.userRepo.GetByEmailFold(email)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("kullanıcı getirilemedi: %v", err)
+	}
+
+	if user != nil {
+		if err := utils.CheckUserStatus(user.Status); err != nil {
+			return nil, err
+		}
+		// Kullanıcı bu hastanenin kendi kullanıcısı veya aktif üyesi olmalı; token bu hastane için üretilir
+		primary := user.HospitalID == ssoConfig.HospitalID
+		member, err := NewMembershipService().ResolveHospital(user, ssoConfig.HospitalID)
+		if errors.Is(err, ErrMembershipNotFound) {
+			return nil, ErrSSOUserMismatch
+		}
+		if err != nil {
+			return nil, err
+		}
+		if ssoConfig.SyncRole && role != "" && role != member.Role {
+			if err := s.syncRole(member, role, primary); err != nil {
+				return nil, err
+			}
+		}
+		return member, nil
+	}
+
+	if !ssoConfig.JITProvisioning {
+		return nil, ErrSSOUserNotFound
+	}
+	if role == "" {
+		return nil, ErrSSORoleNotMapped
+	}
+	return s.provisionUser(ssoConfig, claims, email, role)
+}
+
+// mapRole role claim'indeki değerleri eşlemelerle öncelik sırasıyla karşılaştırır, eşleşme yoksa varsayılan rolü döndürür
+func (s *SSOService) mapRole(ssoConfig *model.HospitalSSOConfig, claims jwt.MapClaims) string {
+	values := map[string]bool{}
+	switch claim := claims[ssoConfig.RoleClaim].(type) {
+	case string:
+		for _, value := range strings.Fields(claim) {
+			values[value] = true
+		}
+	case []interface{}:
+		for _, item := range claim {
+			if value, ok := item.(string); ok {
+				values[value] = true
+			}
+		}
+	}
+
+	for _, mapping := range ssoConfig.RoleMappings {
+		if values[mapping.ClaimValue] {
+			return mapping.Role
+		}
+	}
+	return ssoConfig.DefaultRole
+}
+
+// syncRole kullanıcının bu hastanedeki rolünü IdP'deki rolüyle günceller, eski rolle alınmış token'lar geçersiz olur
+// primary değilse rol kullanıcının bu hastanedeki üyeliğinde güncellenir
+func (s *SSOService) syncRole(user *model.User, role string, primary bool) error {
+	oldRole := user.Role
+	user.Role = role
+
+	query := database.DB.Model(&model.User{}).Where("id = ?", user.ID)
+	if !primary {
+		query = database.DB.Model(&model.HospitalMembership{}).Where("user_id = ? AND hospital_id = ?", user.ID, user.HospitalID)
+	}
+	if err := query.Update("role", role).Error; err != nil {
+		return fmt.Errorf("kullanıcı rolü güncellenemedi: %v", err)
+	}
+	if err := NewTokenService().InvalidateUserTokens(user.ID); err != nil {
+		return err
+	}
+
+	fmt.Printf("🔄 SSO: kullanıcı=%d rolü IdP'ye göre güncellendi: %s → %s\n", user.ID, oldRole, role)
+	return nil
+}
+
+// provisionUser IdP bilgileriyle kullanıcı oluşturur (JIT)
+// Platformda zorunlu olan TC kimlik ve telefon IdP'den gelmek zorunda; kullanıcının yerel şifresi olmaz
+func (s *SSOService) provisionUser(ssoConfig *model.HospitalSSOConfig, claims jwt.MapClaims, email, role string) (*model.User, error) {
+	tckn, _ := claims[ssoConfig.TCKNClaim].(string)
+	phoneClaim, _ := claims["phone_number"].(string)
+	phone := normalizeSSOPhone(phoneClaim)
+	if len(tckn) != 11 || !isDigits(tckn) || phone == "" {
+		return nil, ErrSSOProvisionFailed
+	}
+	if existing, _ := s.userRepo.GetByTCKN(tckn); existing != nil {
+		return nil, ErrSSOProvisionFailed
+	}
+	if existing, _ := s.userRepo.GetByPhone(phone); existing != nil {
+		return nil, ErrSSOProvisionFailed
+	}
+
+	firstName, _ := claims["given_name"].(string)
+	lastName, _ := claims["family_name"].(string)
+	if firstName == "" || lastName == "" {
+		name, _ := claims["name"].(string)
+		if idx := strings.LastIndex(strings.TrimSpace(name), " "); idx > 0 {
+			firstName, lastName = strings.TrimSpace(name[:idx]), strings.TrimSpace(name[idx+1:])
+		}
+	}
+	if firstName == "" || lastName == "" {
+		firstName, lastName = email[:strings.Index(email, "@")], "-"
+	}
+
+	now := time.Now()
+	user := &model.User{
+		HospitalID:      ssoConfig.HospitalID,
+		FirstName:       firstName,
+		LastName:        lastName,
+		TCKN:            tckn,
+		Email:           email,
+		Phone:           phone,
+		Role:            role,
+		Status:          model.UserStatusActive,
+		EmailVerifiedAt: &now,
+	}
+	if err := s.userRepo.Create(user); err != nil {
+		fmt.Printf("❌ SSO: kullanıcı oluşturulamadı: %v\n", err)
+		return nil, ErrSSOProvisionFailed
+	}
+
+	fmt.Printf("👤 SSO: kullanıcı ilk girişte oluşturuldu: hastane=%d kullanıcı=%d rol=%s\n", user.HospitalID, user.ID, role)
+	return user, nil
+}
+
+// getEnabledConfig hastanenin açık SSO ayarlarını getirir
+func (s *SSOService) getEnabledConfig(hospitalID uint) (*model.HospitalSSOConfig, error) {
+	if hospitalID == 0 {
+		return nil, ErrSSONotConfigured
+	}
+	ssoConfig, err := s.ssoRepo.GetByHospitalID(hospitalID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSSONotConfigured
+		}
+		return nil, fmt.Errorf("SSO ayarları getirilemedi: %v", err)
+	}
+	if !ssoConfig.Enabled {
+		return nil, ErrSSONotConfigured
+	}
+	return ssoConfig, nil
+}
+
+// client ayarlardan OIDC istemci bilgilerini oluşturur (client secret çözülerek)
+func (s *SSOService) client(ssoConfig *model.HospitalSSOConfig) utils.OIDCClient {
+	secret := ""
+	if ssoConfig.ClientSecret != "" {
+		decrypted, err := utils.DecryptString(ssoConfig.ClientSecret)
+		if err != nil {
+			fmt.Printf("⚠️ SSO: hastane=%d client secret çözülemedi: %v\n", ssoConfig.HospitalID, err)
+		}
+		secret = decrypted
+	}
+
+	return utils.OIDCClient{
+		Issuer:       ssoConfig.Issuer,
+		ClientID:     ssoConfig.ClientID,
+		ClientSecret: secret,
+		RedirectURI:  getSSORedirectURI(),
+		Scopes:       ssoConfig.ScopeList(),
+	}
+}
+
+// validateConfig SSO ayar isteğini doğrular
+func (s *SSOService) validateConfig(actor *model.User, req *model.SSOConfigRequest) ([]model.ValidationError, error) {
+	var validationErrors []model.ValidationError
+
+	if err := utils.ValidateIssuerURL(req.Issuer); err != nil {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "issuer",
+			Message: "Issuer adresi geçersiz: " + err.Error(),
+		})
+	} else if req.Enabled {
+		// Açılmadan önce IdP'ye ulaşılabildiğini ve issuer'ın doğru olduğunu kontrol et
+		if _, err := utils.DiscoverOIDC(req.Issuer); err != nil {
+			validationErrors = append(validationErrors, model.ValidationError{
+				Field:   "issuer",
+				Message: err.Error(),
+			})
+		}
+	}
+
+	if req.ClientID == "" {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "client_id",
+			Message: "Client ID zorunludur",
+		})
+	}
+
+	if len(req.RoleMappings) > 0 && strings.TrimSpace(req.RoleClaim) == "" {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "role_claim",
+			Message: "Rol eşlemesi için role claim adı zorunludur",
+		})
+	}
+	if len(req.RoleMappings) > ssoMaxRoleMappings {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "role_mappings",
+			Message: fmt.Sprintf("En fazla %d rol eşlemesi tanımlanabilir", ssoMaxRoleMappings),
+		})
+	}
+
+	// Eşlenen roller SSO ile atanacağı için ayarı yapan yetkilinin atayabileceği roller olmalı
+	roles := make([]string, 0, len(req.RoleMappings)+1)
+	for i, mapping := range req.RoleMappings {
+		if strings.TrimSpace(mapping.ClaimValue) == "" {
+			validationErrors = append(validationErrors, model.ValidationError{
+				Field:   fmt.Sprintf("role_mappings[%d].claim_value", i),
+				Message: "Claim değeri zorunludur",
+			})
+		}
+		roles = append(roles, mapping.Role)
+	}
+	if req.DefaultRole != "" {
+		roles = append(roles, req.DefaultRole)
+	}
+	for _, role := range uniqueStrings(roles) {
+		err := NewRoleService().CheckAssignableRole(actor.HospitalID, actor.Role, role)
+		switch {
+		case errors.Is(err, ErrRoleNotFound):
+			validationErrors = append(validationErrors, model.ValidationError{
+				Field:   "role_mappings",
+				Message: fmt.Sprintf("'%s' rolü hastanede tanımlı değil", role),
+			})
+		case err != nil:
+			return nil, err
+		}
+	}
+
+	return validationErrors, nil
+}
+
+// normalizeSSOScopes scope listesini temizler, openid'nin her zaman istenmesini sağlar
+func normalizeSSOScopes(scopes []string) []string {
+	if len(scopes) == 0 {
+		scopes = model.SSODefaultScopes
+	}
+
+	normalized := []string{"openid"}
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		if scope != "" && scope != "openid" {
+			normalized = append(normalized, scope)
+		}
+	}
+	return uniqueStrings(normalized)
+}
+
+// normalizeSSOPhone OIDC phone_number claim'ini (E.164, +905551234567) platform formatına (05551234567) çevirir
+func normalizeSSOPhone(phone string) string {
+	var digits strings.Builder
+	for _, r := range phone {
+		if unicode.IsDigit(r) {
+			digits.WriteRune(r)
+		}
+	}
+
+	number := digits.String()
+	switch {
+	case len(number) == 12 && strings.HasPrefix(number, "90"):
+		return "0" + number[2:]
+	case len(number) == 11 && strings.HasPrefix(number, "0"):
+		return number
+	case len(number) == 10 && strings.HasPrefix(number, "5"):
+		return "0" + number
+	default:
+		return ""
+	}
+}
+
+// isDigits metnin sadece rakamlardan oluştuğunu kontrol eder
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return value != ""
+}
+
+// toSSOConfigResponse ayarları API cevabına çevirir (client secret gösterilmez)
+func toSSOConfigResponse(ssoConfig *model.HospitalSSOConfig) *model.SSOConfigResponse {
+	return &model.SSOConfigResponse{
+		HospitalSSOConfig: *ssoConfig,
+		HasClientSecret:   ssoConfig.ClientSecret != "",
+		RedirectURI:       getSSORedirectURI(),
+	}
+}
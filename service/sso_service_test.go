@@ -0,0 +1,66 @@
+package service
+
+import (
+	"encoding/json"
+	"hospital-platform/database"
+	"hospital-platform/model"
+	"testing"
+	"time"
+)
+
+func saveTestSSOState(t *testing.T, state string) {
+	t.Helper()
+
+	data, _ := json.Marshal(model.SSOState{HospitalID: 1, CodeVerifier: "verifier", Nonce: "nonce"})
+	if err := database.SaveSSOState(state, data, 10*time.Minute); err != nil {
+		t.Fatalf("SSO state yazılamadı: %v", err)
+	}
+}
+
+func TestSSOCallbackRejectsUnknownState(t *testing.T) {
+	startTestRedis(t)
+	saveTestSSOState(t, "state-saved")
+
+	_, _, err := NewSSOService().Callback(&model.SSOCallbackRequest{State: "state-other", Code: "code"}, model.ClientInfo{})
+	if err != ErrSSOInvalidState {
+		t.Fatalf("eşleşmeyen state için ErrSSOInvalidState beklenirdi, gelen: %v", err)
+	}
+	if exists, _ := database.RedisClient.Exists(database.Ctx, database.SSO_STATE_PREFIX+"state-saved").Result(); exists != 1 {
+		t.Fatal("başka bir state'in denenmesi kayıtlı state'i silmemeli")
+	}
+}
+
+func TestSSOCallbackStateIsSingleUse(t *testing.T) {
+	startTestRedis(t)
+	saveTestSSOState(t, "state-1")
+	service := NewSSOService()
+
+	_, _, err := service.Callback(&model.SSOCallbackRequest{State: "state-1", Error: "access_denied"}, model.ClientInfo{})
+	if err != ErrSSOIdentityDenied {
+		t.Fatalf("IdP hatası için ErrSSOIdentityDenied beklenirdi, gelen: %v", err)
+	}
+
+	// Reddedilen giriş de state'i tüketir, aynı dönüş adresi tekrar kullanılamaz
+	_, _, err = service.Callback(&model.SSOCallbackRequest{State: "state-1", Code: "code"}, model.ClientInfo{})
+	if err != ErrSSOInvalidState {
+		t.Fatalf("tekrar kullanılan state için ErrSSOInvalidState beklenirdi, gelen: %v", err)
+	}
+}
+
+func TestSSOCallbackRequiresCode(t *testing.T) {
+	server := startTestRedis(t)
+	saveTestSSOState(t, "state-1")
+
+	_, _, err := NewSSOService().Callback(&model.SSOCallbackRequest{State: "state-1"}, model.ClientInfo{})
+	if err != ErrSSOInvalidState {
+		t.Fatalf("kodsuz dönüş için ErrSSOInvalidState beklenirdi, gelen: %v", err)
+	}
+
+	// Süresi dolan state de geçersizdir
+	saveTestSSOState(t, "state-2")
+	server.FastForward(11 * time.Minute)
+	_, _, err = NewSSOService().Callback(&model.SSOCallbackRequest{State: "state-2", Code: "code"}, model.ClientInfo{})
+	if err != ErrSSOInvalidState {
+		t.Fatalf("süresi dolan state için ErrSSOInvalidState beklenirdi, gelen: %v", err)
+	}
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"hospital-platform/model"
+	"hospital-platform/repository"
+	"hospital-platform/utils"
+	"io"
+	"strings"
+)
+
+// Dışa aktarma biçimleri
+const (
+	StaffExportCSV  = "csv"
+	StaffExportXLSX = "xlsx"
+	StaffExportPDF  = "pdf"
+)
+
+var ErrStaffExportFormat = errors.New("geçersiz dışa aktarma biçimi (csv, xlsx veya pdf olmalı)")
+
+// Dışa aktarılan sütunlar - başlıklar toplu içe aktarmanın tanıdığı adlardır, dosya düzenlenip geri yüklenebilir
+var staffExportColumns = []struct {
+	title     string
+	xlsxWidth float64
+	pdfWidth  float64
+}{
+	{"Ad", 16, 12},
+	{"Soyad", 16, 12},
+	{"TC Kimlik No", 14, 11},
+	{"Telefon", 15, 11},
+	{"Meslek Grubu", 18, 13},
+	{"Unvan", 22, 15},
+	{"Poliklinik", 22, 15},
+	{"Çalışma Günleri", 40, 26},
+	{"Durum", 8, 6},
+}
+
+// StaffExportService - Personel listesini filtrelere göre CSV, XLSX veya PDF olarak dışa aktarır
+// Satırlar veritabanından okundukça çıktıya yazılır
+type StaffExportService struct {
+	staffRepo    *repository.StaffRepository
+	hospitalRepo *repository.HospitalRepository
+}
+
+// NewStaffExportService yeni bir dışa aktarma servisi oluşturur
+func NewStaffExportService() *StaffExportService {
+	return &StaffExportService{
+		staffRepo:    repository.NewStaffRepository(),
+		hospitalRepo: repository.NewHospitalRepository(),
+	}
+}
+
+// IsValidStaffExportFormat biçimin desteklenip desteklenmediğini kontrol eder
+func IsValidStaffExportFormat(format string) bool {
+	return format == StaffExportCSV || format == StaffExportXLSX || format == StaffExportPDF
+}
+
+// staffRowWriter biçimden bağımsız satır yazıcı
+type staffRowWriter interface {
+	WriteRow(cells []string) error
+	Close() error
+}
+
+// ExportStaff listeyle aynı filtre ve sıralamadaki tüm personeli w'ye yazar (sayfalama alanları yok sayılır)
+// Çıktıya yazmadan önce oluşan hatalarda w'ye hiçbir şey yazılmaz
+func (s *StaffExportService) ExportStaff(hospitalID uint, req *model.StaffListRequest, format string, w io.Writer) error {
+	if !IsValidStaffExportFormat(format) {
+		return ErrStaffExportFormat
+	}
+
+	hospital, err := s.hospitalRepo.GetByID(hospitalID)
+	if err != nil {
+		return fmt.Errorf("hastane bulunamadı: %v", err)
+	}
+
+	writer, err := s.newRowWriter(format, hospital, w)
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	err = s.staffRepo.StreamStaff(hospitalID, req, func(staff model.StaffSummary) error {
+		count++
+		return writer.WriteRow(staffExportRow(staff))
+	})
+	if err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	fmt.Printf("📤 Personel dışa aktarıldı: Hastane %d, %d kayıt (%s)\n", hospitalID, count, format)
+	return nil
+}
+
+// newRowWriter biçime göre yazıcıyı oluşturur ve başlıkları yazar
+func (s *StaffExportService) newRowWriter(format string, hospital *model.Hospital, w io.Writer) (staffRowWriter, error) {
+	titles := make([]string, len(staffExportColumns))
+	for i, column := range staffExportColumns {
+		titles[i] = column.title
+	}
+
+	switch format {
+	case StaffExportXLSX:
+		columns := make([]utils.XLSXColumn, len(staffExportColumns))
+		for i, column := range staffExportColumns {
+			columns[i] = utils.XLSXColumn{Title: column.title, Width: column.xlsxWidth}
+		}
+		return utils.NewXLSXWriter(w, "Personel", columns)
+
+	case StaffExportPDF:
+		columns := make([]utils.PDFColumn, len(staffExportColumns))
+		for i, column := range staffExportColumns {
+			columns[i] = utils.PDFColumn{Title: column.title, Width: column.pdfWidth}
+		}
+		address := strings.TrimSpace(fmt.Sprintf("%s %s / %s", hospital.AddressDetail, hospital.District.Name, hospital.Province.Name))
+		return utils.NewPDFTableWriter(w, utils.PDFTableOptions{
+			Title:       hospital.Name,
+			HeaderLines: []string{address, "Tel: " + hospital.Phone + "   E-posta: " + hospital.Email},
+			Subtitle:    "Personel Listesi",
+			Columns:     columns,
+		})
+
+	default:
+		// Türkçe Excel'in doğrudan açabilmesi için BOM ve noktalı virgül
+		if _, err := io.WriteString(w, "\xef\xbb\xbf"); err != nil {
+			return nil, err
+		}
+		writer := &csvRowWriter{writer: csv.NewWriter(w)}
+		writer.writer.Comma = ';'
+		if err := writer.WriteRow(titles); err != nil {
+			return nil, err
+		}
+		return writer, nil
+	}
+}
+
+// csvFormulaPrefixes Excel'in formül olarak yorumladığı hücre başlangıçları
+const csvFormulaPrefixes = "=+-@\t\r"
+
+// csvRowWriter csv.Writer'ı staffRowWriter'a uyarlar
+// Formül gibi başlayan hücrelerin başına ' eklenir (CSV formula injection); içe aktarma bu öneki kaldırır
+type csvRowWriter struct {
+	writer *csv.Writer
+}
+
+func (c *csvRowWriter) WriteRow(cells []string) error {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		escaped[i] = escapeCSVFormula(cell)
+	}
+	return c.writer.Write(escaped)
+}
+
+func (c *csvRowWriter) Close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+// escapeCSVFormula formül gibi başlayan hücreyi metin olarak açılması için ' ile başlatır
+func escapeCSVFormula(cell string) string {
+	if cell != "" && strings.ContainsRune(csvFormulaPrefixes, rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
+
+// staffExportRow personel özetini sütun sırasına göre hücrelere çevirir
+func staffExportRow(staff model.StaffSummary) []string {
+	polyclinic := ""
+	if staff.PolyclinicTypeName != nil {
+		polyclinic = *staff.PolyclinicTypeName
+	}
+	status := "Aktif"
+	if !staff.IsActive {
+		status = "Pasif"
+	}
+	if staff.LeaveTypeName != nil {
+		status += " (İzinde: " + *staff.LeaveTypeName + ")"
+	}
+	return []string{
+		staff.FirstName,
+		staff.LastName,
+		staff.TCKN,
+		staff.Phone,
+		staff.JobGroupName,
+		staff.JobTitleName,
+		polyclinic,
+		staff.WorkDaysText,
+		status,
+	}
+}
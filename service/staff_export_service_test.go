@@ -0,0 +1,45 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+)
+
+func TestCSVExportEscapesFormulaCells(t *testing.T) {
+	cells := []string{"=HYPERLINK(\"http://evil\")", "+905551112233", "-2+3", "@SUM(A1)", "Ayşe", "", "Dr. =A1"}
+
+	var buf bytes.Buffer
+	writer := &csvRowWriter{writer: csv.NewWriter(&buf)}
+	writer.writer.Comma = ';'
+	if err := writer.WriteRow(cells); err != nil {
+		t.Fatalf("satır yazılamadı: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("csv kapatılamadı: %v", err)
+	}
+
+	reader := csv.NewReader(&buf)
+	reader.Comma = ';'
+	written, err := reader.Read()
+	if err != nil {
+		t.Fatalf("csv okunamadı: %v", err)
+	}
+	want := []string{"'=HYPERLINK(\"http://evil\")", "'+905551112233", "'-2+3", "'@SUM(A1)", "Ayşe", "", "Dr. =A1"}
+	for i := range want {
+		if written[i] != want[i] {
+			t.Errorf("hücre %d: beklenen %q, gelen %q", i, want[i], written[i])
+		}
+	}
+
+	// İçe aktarma dışa aktarılan dosyadaki öneki kaldırır
+	columns := map[string]int{}
+	for i := range cells {
+		columns[string(rune('a'+i))] = i
+	}
+	for i := range cells {
+		if got := importValue(written, columns, string(rune('a'+i))); got != cells[i] {
+			t.Errorf("hücre %d içe aktarmada geri çevrilemedi: beklenen %q, gelen %q", i, cells[i], got)
+		}
+	}
+}
@@ -0,0 +1,506 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"hospital-platform/model"
+	"hospital-platform/repository"
+	"hospital-platform/utils"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	MAX_STAFF_IMPORT_SIZE = 5 << 20 // 5 MB
+	MAX_STAFF_IMPORT_ROWS = 1000    // Başlık hariç veri satırı sınırı
+)
+
+// İçe aktarma sütunları - başlıklar Türkçe veya İngilizce yazılabilir, büyük/küçük harf ve boşluk önemsizdir
+const (
+	importColFirstName  = "first_name"
+	importColLastName   = "last_name"
+	importColTCKN       = "tc"
+	importColPhone      = "phone"
+	importColJobGroup   = "job_group"
+	importColJobTitle   = "job_title"
+	importColPolyclinic = "polyclinic"
+	importColWorkDays   = "work_days"
+	importColShift      = "shift"
+)
+
+var staffImportHeaders = map[string]string{
+	"firstname": importColFirstName, "ad": importColFirstName, "adı": importColFirstName,
+	"lastname": importColLastName, "soyad": importColLastName, "soyadı": importColLastName,
+	"tc": importColTCKN, "tckn": importColTCKN, "tcno": importColTCKN, "tckimlikno": importColTCKN,
+	"phone": importColPhone, "telefon": importColPhone, "tel": importColPhone,
+	"jobgroup": importColJobGroup, "meslekgrubu": importColJobGroup,
+	"jobtitle": importColJobTitle, "unvan": importColJobTitle, "ünvan": importColJobTitle,
+	"polyclinic": importColPolyclinic, "poliklinik": importColPolyclinic,
+	"workdays": importColWorkDays, "çalışmagünleri": importColWorkDays, "günler": importColWorkDays,
+	"shift": importColShift, "shifttemplate": importColShift, "vardiya": importColShift,
+}
+
+var requiredImportColumns = []string{
+	importColFirstName, importColLastName, importColTCKN, importColPhone,
+	importColJobGroup, importColJobTitle, importColWorkDays,
+}
+
+// Çalışma günü adları ve kısaltmaları (1=Pazartesi, 7=Pazar)
+var importDayNames = map[string]int{
+	"pazartesi": 1, "pzt": 1, "salı": 2, "sal": 2, "çarşamba": 3, "çar": 3, "çrş": 3,
+	"perşembe": 4, "per": 4, "prş": 4, "cuma": 5, "cum": 5, "cumartesi": 6, "cmt": 6, "pazar": 7, "paz": 7,
+}
+
+// StaffImportService - CSV/XLSX dosyasından toplu personel ekleme
+// Her satır tekil personel eklemeyle aynı kurallardan (validateCreateStaff) geçer
+type StaffImportService struct {
+	staffService   *StaffService
+	staffRepo      *repository.StaffRepository
+	polyclinicRepo *repository.PolyclinicRepository
+	scheduleRepo   *repository.ScheduleRepository
+	cacheService   *CacheService
+}
+
+// NewStaffImportService yeni bir içe aktarma servisi oluşturur
+func NewStaffImportService() *StaffImportService {
+	return &StaffImportService{
+		staffService:   NewStaffService(),
+		staffRepo:      repository.NewStaffRepository(),
+		polyclinicRepo: repository.NewPolyclinicRepository(),
+		scheduleRepo:   repository.NewScheduleRepository(),
+		cacheService:   NewCacheService(),
+	}
+}
+
+// staffImportLookup meslek grubu, unvan, poliklinik ve vardiya adlarını ID'ye çeviren tablolar
+type staffImportLookup struct {
+	jobGroups   map[string]uint
+	jobTitles   map[uint]map[string]model.JobTitle // meslek grubu → unvan adı → unvan
+	polyclinics map[string]uint
+	shifts      map[string]model.ShiftTemplate
+}
+
+// ImportStaff dosyayı okur, her satırı doğrular ve dryRun değilse hatasız satırları tek transaction ile ekler
+// Dosya düzeyindeki sorunlar (okunamayan dosya, eksik sütun) "file" alanında doğrulama hatası olarak döner
+func (s *StaffImportService) ImportStaff(hospitalID uint, data []byte, dryRun bool) (*model.StaffImportResponse, []model.ValidationError, error) {
+	if len(data) == 0 {
+		return nil, []model.ValidationError{{Field: "file", Message: "Dosya boş"}}, nil
+	}
+	if len(data) > MAX_STAFF_IMPORT_SIZE {
+		return nil, []model.ValidationError{{
+			Field:   "file",
+			Message: fmt.Sprintf("Dosya en fazla %d MB olabilir", MAX_STAFF_IMPORT_SIZE>>20),
+		}}, nil
+	}
+
+	rows, err := utils.ReadSpreadsheet(data)
+	if err != nil {
+		if errors.Is(err, utils.ErrSpreadsheetInvalid) {
+			return nil, []model.ValidationError{{Field: "file", Message: "Sadece CSV veya XLSX dosyası yüklenebilir: " + err.Error()}}, nil
+		}
+		return nil, nil, err
+	}
+
+	headerIndex := -1
+	for i, row := range rows {
+		if !isBlankImportRow(row) {
+			headerIndex = i
+			break
+		}
+	}
+	if headerIndex < 0 {
+		return nil, []model.ValidationError{{Field: "file", Message: "Dosyada başlık satırı bulunamadı"}}, nil
+	}
+	columns, validationErrors := mapImportColumns(rows[headerIndex])
+	if len(validationErrors) > 0 {
+		return nil, validationErrors, nil
+	}
+	if len(rows)-headerIndex-1 > MAX_STAFF_IMPORT_ROWS {
+		return nil, []model.ValidationError{{
+			Field:   "file",
+			Message: fmt.Sprintf("Tek seferde en fazla %d satır içe aktarılabilir", MAX_STAFF_IMPORT_ROWS),
+		}}, nil
+	}
+
+	lookup, err := s.loadLookup(hospitalID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response := &model.StaffImportResponse{DryRun: dryRun, Rows: []model.StaffImportRow{}}
+	seenTCKN := make(map[string]int)
+	seenPhone := make(map[string]int)
+	seenUniqueTitle := make(map[uint]int)
+
+	for i := headerIndex + 1; i < len(rows); i++ {
+		if isBlankImportRow(rows[i]) {
+			continue
+		}
+		row := s.validateRow(hospitalID, i+1, rows[i], columns, lookup)
+
+		// Dosya içi tekrarlar - veritabanı kontrolü henüz eklenmemiş satırları göremez
+		req := &row.Request
+		if first, ok := seenTCKN[req.TCKN]; ok && req.TCKN != "" {
+			row.ValidationErrors = append(row.ValidationErrors, model.ValidationError{
+				Field:   "tc",
+				Message: fmt.Sprintf("Bu TC kimlik numarası dosyada %d. satırda da var", first),
+			})
+		} else {
+			seenTCKN[req.TCKN] = row.Row
+		}
+		if first, ok := seenPhone[req.Phone]; ok && req.Phone != "" {
+			row.ValidationErrors = append(row.ValidationErrors, model.ValidationError{
+				Field:   "phone",
+				Message: fmt.Sprintf("Bu telefon numarası dosyada %d. satırda da var", first),
+			})
+		} else {
+			seenPhone[req.Phone] = row.Row
+		}
+		if title, ok := lookup.jobTitles[req.JobGroupID][importKey(rows[i], columns, importColJobTitle)]; ok && title.IsUnique {
+			if first, ok := seenUniqueTitle[title.ID]; ok {
+				row.ValidationErrors = append(row.ValidationErrors, model.ValidationError{
+					Field:   "job_title_id",
+					Message: fmt.Sprintf("Bu unvandan hastanede sadece bir tane olabilir (dosyada %d. satırda da var)", first),
+				})
+			} else {
+				seenUniqueTitle[title.ID] = row.Row
+			}
+		}
+
+		if len(row.ValidationErrors) > 0 {
+			row.Status = model.StaffImportRowInvalid
+			response.InvalidRows++
+		} else {
+			row.Status = model.StaffImportRowValid
+			response.ValidRows++
+		}
+		response.Rows = append(response.Rows, row)
+	}
+	response.TotalRows = len(response.Rows)
+
+	if dryRun || response.ValidRows == 0 {
+		return response, nil, nil
+	}
+
+	if err := s.commit(hospitalID, response, lookup); err != nil {
+		return nil, nil, err
+	}
+	fmt.Printf("📥 Hastane %d için %d personel içe aktarıldı (%d hatalı satır atlandı)\n", hospitalID, response.CreatedCount, response.InvalidRows)
+	return response, nil, nil
+}
+
+// commit hatasız satırları tek transaction ile ekler ve satırlara personel ID'lerini yazar
+// Her personel için çalışma günlerinden bugünden başlayan haftalık program oluşturulur
+func (s *StaffImportService) commit(hospitalID uint, response *model.StaffImportResponse, lookup *staffImportLookup) error {
+	var staffs []model.Staff
+	var rowIndexes []int
+	for i, row := range response.Rows {
+		if row.Status != model.StaffImportRowValid {
+			continue
+		}
+
+		// Vardiya seçilmediyse ID'siz şablon verilir, varsayılan vardiya personellerle aynı transaction içinde bulunur/oluşturulur
+		var template model.ShiftTemplate
+		if row.Request.ShiftTemplateID != nil {
+			template = lookup.shiftsByID(*row.Request.ShiftTemplateID)
+		}
+
+		staffs = append(staffs, model.Staff{
+			HospitalID:   hospitalID,
+			PolyclinicID: row.Request.PolyclinicID,
+			FirstName:    row.Request.FirstName,
+			LastName:     row.Request.LastName,
+			TCKN:         row.Request.TCKN,
+			Phone:        row.Request.Phone,
+			JobGroupID:   row.Request.JobGroupID,
+			JobTitleID:   row.Request.JobTitleID,
+			IsActive:     true,
+			Schedules:    []model.StaffSchedule{newWeeklySchedule(hospitalID, row.Request.WorkDays, template)},
+		})
+		rowIndexes = append(rowIndexes, i)
+	}
+
+	if err := s.staffRepo.CreateBatch(staffs); err != nil {
+		return fmt.Errorf("personeller eklenemedi, hiçbir satır kaydedilmedi: %v", err)
+	}
+
+	for i, index := range rowIndexes {
+		id := staffs[i].ID
+		response.Rows[index].StaffID = &id
+		response.Rows[index].Status = model.StaffImportRowCreated
+	}
+	response.CreatedCount = len(staffs)
+	return nil
+}
+
+// validateRow satırı CreateStaffRequest'e çevirir, adları ID'lere çözer ve personel ekleme kurallarını uygular
+func (s *StaffImportService) validateRow(hospitalID uint, rowNumber int, cells []string, columns map[string]int, lookup *staffImportLookup) model.StaffImportRow {
+	value := func(column string) string {
+		return importValue(cells, columns, column)
+	}
+
+	req := model.CreateStaffRequest{
+		FirstName: value(importColFirstName),
+		LastName:  value(importColLastName),
+		TCKN:      value(importColTCKN),
+		Phone:     normalizeImportPhone(value(importColPhone)),
+	}
+	row := model.StaffImportRow{Row: rowNumber, FirstName: req.FirstName, LastName: req.LastName}
+
+	// Zorunlu alanlar - tekil eklemede binding etiketleriyle kontrol edilenler
+	for _, field := range []struct{ name, value, label string }{
+		{"first_name", req.FirstName, "Ad"},
+		{"last_name", req.LastName, "Soyad"},
+		{"tc", req.TCKN, "TC kimlik numarası"},
+		{"phone", req.Phone, "Telefon"},
+	} {
+		if field.value == "" {
+			row.ValidationErrors = append(row.ValidationErrors, model.ValidationError{Field: field.name, Message: field.label + " zorunludur"})
+		}
+	}
+
+	// Meslek grubu ve unvan adla çözülür; unvan seçilen gruba ait olmalı
+	if name := value(importColJobGroup); name == "" {
+		row.ValidationErrors = append(row.ValidationErrors, model.ValidationError{Field: "job_group_id", Message: "Meslek grubu zorunludur"})
+	} else if id, ok := lookup.jobGroups[normalizeImportName(name)]; ok {
+		req.JobGroupID = id
+	} else {
+		row.ValidationErrors = append(row.ValidationErrors, model.ValidationError{Field: "job_group_id", Message: fmt.Sprintf("Meslek grubu bulunamadı: %s", name)})
+	}
+
+	if name := value(importColJobTitle); name == "" {
+		row.ValidationErrors = append(row.ValidationErrors, model.ValidationError{Field: "job_title_id", Message: "Unvan zorunludur"})
+	} else if req.JobGroupID != 0 {
+		if title, ok := lookup.jobTitles[req.JobGroupID][normalizeImportName(name)]; ok {
+			req.JobTitleID = title.ID
+		} else {
+			row.ValidationErrors = append(row.ValidationErrors, model.ValidationError{Field: "job_title_id", Message: fmt.Sprintf("Unvan bu meslek grubunda bulunamadı: %s", name)})
+		}
+	}
+
+	if name := value(importColPolyclinic); name != "" {
+		if id, ok := lookup.polyclinics[normalizeImportName(name)]; ok {
+			req.PolyclinicID = &id
+		} else {
+			row.ValidationErrors = append(row.ValidationErrors, model.ValidationError{Field: "polyclinic_id", Message: fmt.Sprintf("Hastanede bu poliklinik yok: %s", name)})
+		}
+	}
+
+	workDays, err := parseImportWorkDays(value(importColWorkDays))
+	if err != nil {
+		row.ValidationErrors = append(row.ValidationErrors, model.ValidationError{Field: "work_days", Message: err.Error()})
+	}
+	req.WorkDays = workDays
+
+	if name := value(importColShift); name != "" {
+		if template, ok := lookup.shifts[normalizeImportName(name)]; ok {
+			req.ShiftTemplateID = &template.ID
+		} else {
+			row.ValidationErrors = append(row.ValidationErrors, model.ValidationError{Field: "shift_template_id", Message: fmt.Sprintf("Hastanede bu vardiya yok: %s", name)})
+		}
+	}
+
+	// Tekil personel eklemeyle aynı kurallar (benzersizlik, tekil unvan, poliklinik, çalışma günleri, dinlenme süresi)
+	// Çözülemeyen alanlar yukarıda raporlandığı için aynı alandaki tekrar hata eklenmez
+	reported := make(map[string]bool, len(row.ValidationErrors))
+	for _, validationError := range row.ValidationErrors {
+		reported[validationError.Field] = true
+	}
+	for _, validationError := range s.staffService.validateCreateStaff(&req, hospitalID) {
+		if !reported[validationError.Field] {
+			row.ValidationErrors = append(row.ValidationErrors, validationError)
+		}
+	}
+
+	row.Request = req
+	return row
+}
+
+// loadLookup ad → ID tablolarını master data cache'inden ve hastanenin polikliniklerinden hazırlar
+func (s *StaffImportService) loadLookup(hospitalID uint) (*staffImportLookup, error) {
+	lookup := &staffImportLookup{
+		jobGroups:   make(map[string]uint),
+		jobTitles:   make(map[uint]map[string]model.JobTitle),
+		polyclinics: make(map[string]uint),
+		shifts:      make(map[string]model.ShiftTemplate),
+	}
+
+	jobGroups, err := s.cacheService.GetJobGroups()
+	if err != nil {
+		return nil, fmt.Errorf("meslek grupları getirilemedi: %v", err)
+	}
+	for _, group := range jobGroups {
+		lookup.jobGroups[normalizeImportName(group.Name)] = group.ID
+
+		titles, err := s.cacheService.GetJobTitlesByGroupID(group.ID)
+		if err != nil {
+			return nil, fmt.Errorf("unvanlar getirilemedi: %v", err)
+		}
+		lookup.jobTitles[group.ID] = make(map[string]model.JobTitle, len(titles))
+		for _, title := range titles {
+			lookup.jobTitles[group.ID][normalizeImportName(title.Name)] = title
+		}
+	}
+
+	polyclinics, err := s.polyclinicRepo.GetHospitalPolyclinicsWithType(hospitalID)
+	if err != nil {
+		return nil, fmt.Errorf("poliklinikler getirilemedi: %v", err)
+	}
+	for _, polyclinic := range polyclinics {
+		key := normalizeImportName(polyclinic.PolyclinicType.Name)
+		// Aynı türde pasif ve aktif poliklinik varsa aktif olan seçilir
+		if _, exists := lookup.polyclinics[key]; !exists || polyclinic.IsActive {
+			lookup.polyclinics[key] = polyclinic.ID
+		}
+	}
+
+	templates, err := s.scheduleRepo.GetTemplatesByHospital(hospitalID)
+	if err != nil {
+		return nil, fmt.Errorf("vardiyalar getirilemedi: %v", err)
+	}
+	for _, template := range templates {
+		lookup.shifts[normalizeImportName(template.Name)] = template
+	}
+	return lookup, nil
+}
+
+// shiftsByID vardiyayı ID'sine göre bulur (satırlar doğrulanırken adla çözülmüştür)
+func (l *staffImportLookup) shiftsByID(id uint) model.ShiftTemplate {
+	for _, template := range l.shifts {
+		if template.ID == id {
+			return template
+		}
+	}
+	return model.ShiftTemplate{}
+}
+
+// ==================== HELPER FUNCTIONS ====================
+
+// mapImportColumns başlık satırındaki sütunları alan adlarına eşler
+func mapImportColumns(header []string) (map[string]int, []model.ValidationError) {
+	columns := make(map[string]int)
+	var validationErrors []model.ValidationError
+	for i, cell := range header {
+		key := strings.NewReplacer(" ", "", "_", "", "-", "", ".", "").Replace(normalizeImportName(cell))
+		column, ok := staffImportHeaders[key]
+		if !ok {
+			continue
+		}
+		if _, exists := columns[column]; exists {
+			validationErrors = append(validationErrors, model.ValidationError{
+				Field:   "file",
+				Message: fmt.Sprintf("Sütun birden fazla kez var: %s", strings.TrimSpace(cell)),
+			})
+			continue
+		}
+		columns[column] = i
+	}
+
+	for _, column := range requiredImportColumns {
+		if _, ok := columns[column]; !ok {
+			validationErrors = append(validationErrors, model.ValidationError{
+				Field:   "file",
+				Message: fmt.Sprintf("Zorunlu sütun eksik: %s", column),
+			})
+		}
+	}
+	return columns, validationErrors
+}
+
+// importValue satırdaki sütunun kırpılmış değerini döndürür (sütun yoksa boş)
+func importValue(cells []string, columns map[string]int, column string) string {
+	index, ok := columns[column]
+	if !ok || index >= len(cells) {
+		return ""
+	}
+	value := strings.TrimSpace(cells[index])
+	// Dışa aktarmada formül gibi başlayan hücrelere eklenen ' öneki kaldırılır
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune(csvFormulaPrefixes, rune(value[1])) {
+		value = strings.TrimSpace(value[1:])
+	}
+	return value
+}
+
+// importKey sütun değerinin karşılaştırma anahtarını döndürür
+func importKey(cells []string, columns map[string]int, column string) string {
+	return normalizeImportName(importValue(cells, columns, column))
+}
+
+// normalizeImportName adları Türkçe kurallarla küçük harfe çevirir ve fazla boşlukları atar
+// "KARDİYOLOJİ" ve "Kardiyoloji" aynı anahtarı verir
+func normalizeImportName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLowerSpecial(unicode.TurkishCase, name)), " ")
+}
+
+// normalizeImportPhone boşluk ve ayraçları atar; Excel'in sayı olarak kaydedip baştaki sıfırı
+// düşürdüğü 10 haneli cep telefonlarına sıfırı geri ekler
+func normalizeImportPhone(phone string) string {
+	phone = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "").Replace(phone)
+	if len(phone) == 10 && strings.HasPrefix(phone, "5") {
+		phone = "0" + phone
+	}
+	return phone
+}
+
+// importShiftHours dışa aktarılan program metnindeki "08:00-17:00" saatleri (vardiya ayrı sütundan seçilir)
+var importShiftHours = regexp.MustCompile(`\d{1,2}[:.]\d{2}\s*-\s*\d{1,2}[:.]\d{2}(\s*\+)?`)
+
+// parseImportWorkDays "1,2,3", "1-5", "Pazartesi-Cuma" veya "Pzt;Çar;Cum" biçimlerini gün numaralarına çevirir
+// Dışa aktarmadaki "Pazartesi-Cuma 08:00-17:00" biçimi de kabul edilir, saatler yok sayılır
+func parseImportWorkDays(text string) ([]int, error) {
+	text = importShiftHours.ReplaceAllString(text, " ")
+	text = strings.NewReplacer(" -", "-", "- ", "-").Replace(normalizeImportName(text))
+	if text == "" {
+		return nil, errors.New("En az bir çalışma günü seçilmelidir")
+	}
+
+	seen := make(map[int]bool)
+	var days []int
+	for _, part := range strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ';' || r == '/' || r == ' ' }) {
+		bounds := strings.SplitN(part, "-", 2)
+		start, err := parseImportDay(bounds[0])
+		if err != nil {
+			return nil, err
+		}
+		end := start
+		if len(bounds) == 2 {
+			if end, err = parseImportDay(bounds[1]); err != nil {
+				return nil, err
+			}
+			if end < start {
+				return nil, fmt.Errorf("Geçersiz gün aralığı: %s", part)
+			}
+		}
+		for day := start; day <= end; day++ {
+			if !seen[day] {
+				seen[day] = true
+				days = append(days, day)
+			}
+		}
+	}
+	return days, nil
+}
+
+// parseImportDay gün numarasını (1-7) veya Türkçe gün adını/kısaltmasını çözer
+func parseImportDay(token string) (int, error) {
+	if day, ok := importDayNames[token]; ok {
+		return day, nil
+	}
+	day, err := strconv.Atoi(token)
+	if err != nil || day < 1 || day > 7 {
+		return 0, fmt.Errorf("Geçersiz gün değeri: %s (1-7 veya gün adı olmalı)", token)
+	}
+	return day, nil
+}
+
+// isBlankImportRow tüm hücreleri boş olan satırları ayırt eder
+func isBlankImportRow(cells []string) bool {
+	for _, cell := range cells {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
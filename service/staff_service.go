@@ -1,7 +1,6 @@
 package service
 
 import (
-	"encoding/json"
 	"fmt"
 	"hospital-platform/model"
 	"hospital-platform/repository"
@@ -10,18 +9,20 @@ import (
 // StaffService - Personel işlemlerinin iş mantığını koordine eden servis katmanı
 // Validasyon kuralları, iş kuralları ve repository'ler arası ilişkileri yönetir
 type StaffService struct {
-	staffRepo      *repository.StaffRepository      // Personel veritabanı işlemleri
-	polyclinicRepo *repository.PolyclinicRepository // Poliklinik doğrulama işlemleri
-	cacheService   *CacheService                    // Master data cache işlemleri
+	staffRepo       *repository.StaffRepository      // Personel veritabanı işlemleri
+	polyclinicRepo  *repository.PolyclinicRepository // Poliklinik doğrulama işlemleri
+	cacheService    *CacheService                    // Master data cache işlemleri
+	scheduleService *ScheduleService                 // Çalışma programı ve vardiya kontrolleri
 }
 
 // NewStaffService - Bağımlılıkları enjekte ederek yeni servis instance'ı oluşturur
 // Repository'leri ve cache service'i initialize eder
 func NewStaffService() *StaffService {
 	return &StaffService{
-		staffRepo:      repository.NewStaffRepository(),
-		polyclinicRepo: repository.NewPolyclinicRepository(),
-		cacheService:   NewCacheService(),
+		staffRepo:       repository.NewStaffRepository(),
+		polyclinicRepo:  repository.NewPolyclinicRepository(),
+		cacheService:    NewCacheService(),
+		scheduleService: NewScheduleService(),
 	}
 }
 
@@ -36,10 +37,10 @@ func (s *StaffService) CreateStaff(req *model.CreateStaffRequest, hospitalID uin
 		return nil, validationErrors, nil
 	}
 
-	// 2. WorkDays'i JSON string'e çevir
-	workDaysJSON, err := json.Marshal(req.WorkDays)
+	// 2. Çalışma günlerinden haftalık program oluştur
+	schedule, err := s.newStaffSchedule(req, hospitalID)
 	if err != nil {
-		return nil, nil, fmt.Errorf("çalışma günleri işlenemedi: %v", err)
+		return nil, nil, err
 	}
 
 	// 3. Staff model oluştur
@@ -52,8 +53,8 @@ func (s *StaffService) CreateStaff(req *model.CreateStaffRequest, hospitalID uin
 		Phone:        req.Phone,
 		JobGroupID:   req.JobGroupID,
 		JobTitleID:   req.JobTitleID,
-		WorkDays:     string(workDaysJSON),
 		IsActive:     true,
+		Schedules:    []model.StaffSchedule{*schedule},
 	}
 
 	// 4. Veritabanına kaydet
@@ -99,20 +100,13 @@ func (s *StaffService) UpdateStaff(id uint, req *model.UpdateStaffRequest, hospi
 		return nil, validationErrors, nil
 	}
 
-	// 3. WorkDays'i JSON string'e çevir
-	workDaysJSON, err := json.Marshal(req.WorkDays)
-	if err != nil {
-		return nil, nil, fmt.Errorf("çalışma günleri işlenemedi: %v", err)
-	}
-
-	// 4. Güncelle
+	// 3. Güncelle - çalışma programları /hospital/staff/:id/schedules ile yönetilir
 	staff.FirstName = req.FirstName
 	staff.LastName = req.LastName
 	staff.Phone = req.Phone
 	staff.JobGroupID = req.JobGroupID
 	staff.JobTitleID = req.JobTitleID
 	staff.PolyclinicID = req.PolyclinicID
-	staff.WorkDays = string(workDaysJSON)
 	staff.IsActive = req.IsActive
 
 	err = s.staffRepo.Update(staff)
@@ -221,14 +215,40 @@ func (s *StaffService) validateCreateStaff(req *model.CreateStaffRequest, hospit
 					Field:   "work_days",
 					Message: "Geçersiz gün değeri (1-7 arasında olmalı)",
 				})
-				break
+				return errors
 			}
 		}
 	}
 
+	// Vardiya kontrolü - seçilen günlerde vardiyalar arasında yeterli dinlenme olmalı
+	template, err := s.scheduleService.resolveStaffTemplate(hospitalID, req.ShiftTemplateID)
+	if err != nil {
+		errors = append(errors, model.ValidationError{
+			Field:   "shift_template_id",
+			Message: "Geçersiz vardiya seçimi",
+		})
+	} else if len(req.WorkDays) > 0 {
+		schedule := newWeeklySchedule(hospitalID, req.WorkDays, template)
+		for _, conflict := range s.scheduleService.checkConflicts(&schedule, nil) {
+			conflict.Field = "work_days"
+			errors = append(errors, conflict)
+		}
+	}
+
 	return errors
 }
 
+// newStaffSchedule doğrulanmış istekteki günlerden bugünden itibaren geçerli haftalık program oluşturur
+// Vardiya seçilmediyse hastanenin varsayılan vardiyası kullanılır; henüz yoksa personelle aynı transaction içinde oluşturulur
+func (s *StaffService) newStaffSchedule(req *model.CreateStaffRequest, hospitalID uint) (*model.StaffSchedule, error) {
+	template, err := s.scheduleService.resolveStaffTemplate(hospitalID, req.ShiftTemplateID)
+	if err != nil {
+		return nil, err
+	}
+	schedule := newWeeklySchedule(hospitalID, req.WorkDays, template)
+	return &schedule, nil
+}
+
 // validateUpdateStaff personel güncelleme validasyonu
 func (s *StaffService) validateUpdateStaff(req *model.UpdateStaffRequest, hospitalID uint, excludeID *uint) []model.ValidationError {
 	var errors []model.ValidationError
@@ -262,23 +282,5 @@ func (s *StaffService) validateUpdateStaff(req *model.UpdateStaffRequest, hospit
 		}
 	}
 
-	// Çalışma günleri kontrolü
-	if len(req.WorkDays) == 0 {
-		errors = append(errors, model.ValidationError{
-			Field:   "work_days",
-			Message: "En az bir çalışma günü seçilmelidir",
-		})
-	} else {
-		for _, day := range req.WorkDays {
-			if day < 1 || day > 7 {
-				errors = append(errors, model.ValidationError{
-					Field:   "work_days",
-					Message: "Geçersiz gün değeri (1-7 arasında olmalı)",
-				})
-				break
-			}
-		}
-	}
-
 	return errors
 }
@@ -0,0 +1,273 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hospital-platform/database"
+	"hospital-platform/model"
+	"hospital-platform/repository"
+	"hospital-platform/utils"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("Geçersiz veya süresi dolmuş refresh token")
+	ErrRefreshTokenReused  = errors.New("Refresh token yeniden kullanıldı, oturum iptal edildi")
+	ErrHospitalSuspended   = utils.ErrHospitalSuspended
+	ErrUserInactive        = utils.ErrUserInactive
+)
+
+// TokenService - Access/refresh token çiftlerinin üretimini ve rotasyonunu yönetir
+// Refresh token'lar Redis'te hash'lenmiş olarak saklanır, her kullanımda yenisiyle değiştirilir
+type TokenService struct {
+	userRepo *repository.UserRepository
+}
+
+// NewTokenService yeni bir token servisi oluşturur
+func NewTokenService() *TokenService {
+	return &TokenService{
+		userRepo: repository.NewUserRepository(),
+	}
+}
+
+// IssueTokenPair kullanıcı için yeni bir token ailesi (oturum) başlatır (login, hastane kaydı)
+// mfa: login sırasında ikinci adım doğrulandıysa true - ailenin tüm token'larına taşınır
+// client: oturum listesinde gösterilecek cihaz ve IP bilgisi
+func (s *TokenService) IssueTokenPair(user *model.User, mfa bool, client model.ClientInfo) (*model.TokenResponse, error) {
+	// Askıya alınmış hastane veya aktif olmayan kullanıcı için oturum açılmaz
+	if err := utils.CheckHospitalActive(user.HospitalID); err != nil {
+		return nil, err
+	}
+	if err := utils.CheckUserStatus(user.Status); err != nil {
+		return nil, err
+	}
+
+	familyID, err := utils.GenerateRandomToken(16)
+	if err != nil {
+		return nil, fmt.Errorf("token ailesi oluşturulamadı: %v", err)
+	}
+
+	if err := database.AddUserTokenFamily(user.ID, familyID, utils.GetRefreshTokenTTL()); err != nil {
+		return nil, fmt.Errorf("token ailesi kaydedilemedi: %v", err)
+	}
+
+	now := time.Now()
+	if err := database.CreateSession(familyID, &model.SessionRecord{
+		UserID:     user.ID,
+		IP:         client.IP,
+		LastIP:     client.IP,
+		UserAgent:  client.UserAgent,
+		MFA:        mfa,
+		CreatedAt:  now,
+		LastSeenAt: now,
+	}, utils.GetRefreshTokenTTL()); err != nil {
+		return nil, fmt.Errorf("oturum kaydedilemedi: %v", err)
+	}
+
+	return s.issueForFamily(user, familyID, mfa)
+}
+
+// Refresh refresh token'ı tüketir ve aynı aileden yeni bir token çifti üretir
+// Daha önce kullanılmış bir token gelirse tüm aile iptal edilir (çalınmış token tespiti)
+func (s *TokenService) Refresh(refreshToken string, client model.ClientInfo) (*model.TokenResponse, error) {
+	tokenHash := utils.HashToken(refreshToken)
+
+	// 1. Token kaydını bul
+	data, err := database.GetRefreshToken(tokenHash)
+	if err == redis.Nil {
+		return nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("refresh token okunamadı: %v", err)
+	}
+
+	var record model.RefreshTokenRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// 2. Aile iptal edilmiş mi?
+	revoked, err := database.IsTokenFamilyRevoked(record.FamilyID)
+	if err != nil {
+		return nil, fmt.Errorf("token ailesi kontrol edilemedi: %v", err)
+	}
+	if revoked {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// Oturum kaydı yoksa (süresi dolmuş veya sonlandırılmış) token kullanılamaz
+	exists, err := database.SessionExists(record.FamilyID)
+	if err != nil {
+		return nil, fmt.Errorf("oturum kontrol edilemedi: %v", err)
+	}
+	if !exists {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// 3. Token'ı kullanılmış olarak işaretle - ikinci kullanım yeniden kullanım demektir
+	firstUse, err := database.MarkRefreshTokenUsed(tokenHash, utils.GetRefreshTokenTTL())
+	if err != nil {
+		return nil, fmt.Errorf("refresh token işaretlenemedi: %v", err)
+	}
+	if !firstUse {
+		fmt.Printf("⚠️ TOKEN: Refresh token yeniden kullanımı tespit edildi, aile iptal ediliyor: %s\n", record.FamilyID)
+		if err := s.RevokeFamily(record.FamilyID); err != nil {
+			return nil, fmt.Errorf("token ailesi iptal edilemedi: %v", err)
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	// 4. Kullanıcıyı aktif hastanedeki güncel haliyle yükle (rol/üyelik değişmiş olabilir)
+	user, err := s.userRepo.GetByID(record.UserID)
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+	user, err = NewMembershipService().ResolveHospital(user, record.HospitalID)
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	if err := database.TouchSession(record.FamilyID, client.IP, true); err != nil {
+		fmt.Println("Oturum son görülme bilgisi güncellenemedi:", err)
+	}
+
+	return s.issueForFamily(user, record.FamilyID, record.MFA)
+}
+
+// SwitchHospital oturumu (token ailesini) koruyarak verilen hastane için yeni token çifti üretir
+// Kullanıcının o hastanede aktif üyeliği olmalıdır; oturumun MFA durumu yeni token'lara taşınır
+func (s *TokenService) SwitchHospital(userID uint, familyID string, hospitalID uint) (*model.TokenResponse, error) {
+	session, err := database.GetSession(familyID)
+	if err == redis.Nil || (err == nil && session.UserID != userID) {
+		return nil, ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("oturum getirilemedi: %v", err)
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, ErrSessionNotFound
+	}
+	member, err := NewMembershipService().ResolveHospital(user, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.issueForFamily(member, familyID, session.MFA)
+	if err != nil {
+		return nil, err
+	}
+	tokens.MFAEnrollmentRequired = NewMFAService().IsEnrollmentRequired(member)
+
+	fmt.Printf("🏥 TOKEN: Aktif hastane değiştirildi - kullanıcı=%d hastane=%d\n", userID, hospitalID)
+	return tokens, nil
+}
+
+// RevokeFamily token ailesini iptal eder - aileye ait access token'lar da middleware'de reddedilir
+func (s *TokenService) RevokeFamily(familyID string) error {
+	return database.RevokeTokenFamily(familyID, utils.GetRefreshTokenTTL())
+}
+
+// Logout kullanıcının mevcut oturumunu (token ailesini) sonlandırır
+func (s *TokenService) Logout(userID uint, familyID string) error {
+	if err := s.RevokeFamily(familyID); err != nil {
+		return fmt.Errorf("oturum sonlandırılamadı: %v", err)
+	}
+	return database.RemoveUserTokenFamily(userID, familyID)
+}
+
+// InvalidateUserTokens kullanıcının token versiyonunu artırır
+// Mevcut access token'lar bir sonraki istekte reddedilir, refresh ile güncel rol/yetkiyle yeni token alınabilir
+func (s *TokenService) InvalidateUserTokens(userID uint) error {
+	if _, err := database.IncrementUserTokenVersion(userID); err != nil {
+		return fmt.Errorf("kullanıcı token versiyonu artırılamadı: %v", err)
+	}
+	return nil
+}
+
+// RevokeAllForUser kullanıcının tüm oturumlarını sonlandırır (tüm cihazlardan çıkış, silme, pasifleştirme)
+func (s *TokenService) RevokeAllForUser(userID uint) error {
+	familyIDs, err := database.GetUserTokenFamilies(userID)
+	if err != nil {
+		return fmt.Errorf("kullanıcı oturumları getirilemedi: %v", err)
+	}
+
+	for _, familyID := range familyIDs {
+		if err := s.Logout(userID, familyID); err != nil {
+			return err
+		}
+	}
+
+	return s.InvalidateUserTokens(userID)
+}
+
+// issueForFamily verilen aile için access + refresh token üretir
+// Token kullanıcının HospitalID alanındaki hastane için geçerlidir (üyelikle çözülmüş kullanıcı verilebilir)
+func (s *TokenService) issueForFamily(user *model.User, familyID string, mfa bool) (*model.TokenResponse, error) {
+	// Yenileme ve hastane değişiminde de hastanenin askıda ve kullanıcının pasif olmadığı kontrol edilir
+	if err := utils.CheckHospitalActive(user.HospitalID); err != nil {
+		return nil, err
+	}
+	if err := utils.CheckUserStatus(user.Status); err != nil {
+		return nil, err
+	}
+
+	version, err := database.GetUserTokenVersion(user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("kullanıcı token versiyonu okunamadı: %v", err)
+	}
+
+	accessToken, err := utils.GenerateAccessToken(&utils.Claims{
+		UserID:     user.ID,
+		Email:      user.Email,
+		Role:       user.Role,
+		HospitalID: user.HospitalID,
+		Username:   user.Email,
+		FamilyID:   familyID,
+		Version:    version,
+		MFA:        mfa,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("access token üretilemedi: %v", err)
+	}
+
+	refreshToken, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("refresh token üretilemedi: %v", err)
+	}
+
+	record, err := json.Marshal(model.RefreshTokenRecord{
+		UserID:     user.ID,
+		FamilyID:   familyID,
+		MFA:        mfa,
+		HospitalID: user.HospitalID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("refresh token kaydı hazırlanamadı: %v", err)
+	}
+
+	if err := database.SaveRefreshToken(utils.HashToken(refreshToken), familyID, record, utils.GetRefreshTokenTTL()); err != nil {
+		return nil, fmt.Errorf("refresh token kaydedilemedi: %v", err)
+	}
+	if err := database.ExtendSession(user.ID, familyID, utils.GetRefreshTokenTTL()); err != nil {
+		return nil, fmt.Errorf("oturum süresi uzatılamadı: %v", err)
+	}
+
+	memberships, err := NewMembershipService().ListMemberships(user.ID, user.HospitalID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.TokenResponse{
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(utils.GetAccessTokenTTL().Seconds()),
+		HospitalID:   user.HospitalID,
+		Memberships:  memberships,
+	}, nil
+}
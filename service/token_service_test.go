@@ -0,0 +1,109 @@
+package service
+
+import (
+	"hospital-platform/database"
+	"hospital-platform/model"
+	"hospital-platform/utils"
+	"testing"
+	"time"
+)
+
+// startTestSession IssueTokenPair'in yaptığı gibi aileyi kullanıcı listesine ekler ve oturum kaydını yazar
+func startTestSession(t *testing.T, userID uint, familyID string) {
+	t.Helper()
+
+	ttl := utils.GetRefreshTokenTTL()
+	if err := database.AddUserTokenFamily(userID, familyID, ttl); err != nil {
+		t.Fatalf("aile eklenemedi: %v", err)
+	}
+	now := time.Now()
+	if err := database.CreateSession(familyID, &model.SessionRecord{
+		UserID:     userID,
+		IP:         "10.0.0.1",
+		LastIP:     "10.0.0.1",
+		UserAgent:  "test",
+		CreatedAt:  now,
+		LastSeenAt: now,
+	}, ttl); err != nil {
+		t.Fatalf("oturum yazılamadı: %v", err)
+	}
+}
+
+func TestRefreshedSessionOlderThanRefreshTTLIsListedAndRevocable(t *testing.T) {
+	server := startTestRedis(t)
+	ttl := utils.GetRefreshTokenTTL()
+	const userID = 7
+
+	startTestSession(t, userID, "family-a")
+
+	// Oturum, refresh TTL'in iki katı boyunca düzenli yenilenir (issueForFamily her yenilemede ExtendSession çağırır)
+	for i := 0; i < 4; i++ {
+		server.FastForward(ttl / 2)
+		if err := database.ExtendSession(userID, "family-a", ttl); err != nil {
+			t.Fatalf("oturum uzatılamadı: %v", err)
+		}
+	}
+
+	sessions, err := NewSessionService().ListSessions(userID, "family-a")
+	if err != nil {
+		t.Fatalf("oturumlar listelenemedi: %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].ID != "family-a" || !sessions[0].Current {
+		t.Fatalf("yenilenen oturum listede yok: %+v", sessions)
+	}
+
+	if err := NewTokenService().RevokeAllForUser(userID); err != nil {
+		t.Fatalf("oturumlar iptal edilemedi: %v", err)
+	}
+
+	revoked, err := database.IsTokenFamilyRevoked("family-a")
+	if err != nil {
+		t.Fatalf("iptal durumu okunamadı: %v", err)
+	}
+	if !revoked {
+		t.Fatal("yenilenen oturum toplu iptalde atlandı")
+	}
+	if exists, _ := database.SessionExists("family-a"); exists {
+		t.Fatal("iptal edilen oturumun kaydı silinmedi")
+	}
+}
+
+func TestRefreshedSessionCanBeRevokedIndividually(t *testing.T) {
+	server := startTestRedis(t)
+	ttl := utils.GetRefreshTokenTTL()
+	const userID = 8
+
+	startTestSession(t, userID, "family-old")
+	startTestSession(t, userID, "family-new")
+
+	// Sadece family-new yenilenir; family-old süresi dolunca listeden temizlenmelidir
+	server.FastForward(ttl - time.Minute)
+	if err := database.ExtendSession(userID, "family-new", ttl); err != nil {
+		t.Fatalf("oturum uzatılamadı: %v", err)
+	}
+	server.FastForward(2 * time.Minute)
+
+	service := NewSessionService()
+	sessions, err := service.ListSessions(userID, "")
+	if err != nil {
+		t.Fatalf("oturumlar listelenemedi: %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].ID != "family-new" {
+		t.Fatalf("beklenen sadece family-new, gelen: %+v", sessions)
+	}
+
+	if err := service.RevokeSession(userID, "family-new"); err != nil {
+		t.Fatalf("oturum sonlandırılamadı: %v", err)
+	}
+	if err := service.RevokeSession(userID, "family-new"); err != ErrSessionNotFound {
+		t.Fatalf("sonlandırılan oturum için ErrSessionNotFound beklenirdi, gelen: %v", err)
+	}
+
+	sessions, err = service.ListSessions(userID, "")
+	if err != nil {
+		t.Fatalf("oturumlar listelenemedi: %v", err)
+	}
+	if len(sessions) != 0 {
+		t.Fatalf("oturum listesi boş olmalı: %+v", sessions)
+	}
+}
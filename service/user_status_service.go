@@ -0,0 +1,176 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"hospital-platform/database"
+	"hospital-platform/model"
+	"hospital-platform/repository"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrStatusUserNotFound = errors.New("Kullanıcı bulunamadı")
+	ErrUserStatusSelf     = errors.New("Kendi hesabınızın durumunu değiştiremezsiniz")
+	ErrUserStatusConflict = errors.New("Kullanıcının durumu bu arada değişti, güncel durumu kontrol edip tekrar deneyin")
+)
+
+// UserStatusService - Kullanıcı hesap durumu (active, suspended, locked, pending) geçişlerini yönetir
+// Her değişiklik gerekçe, işlemi yapan ve IP ile user_status_changes tablosuna yazılır.
+// active dışına alınan kullanıcının tüm oturumları kapatılır; giriş ve token doğrulaması durumu kontrol eder
+type UserStatusService struct {
+	userRepo     *repository.UserRepository
+	statusRepo   *repository.UserStatusRepository
+	tokenService *TokenService
+}
+
+// NewUserStatusService yeni bir kullanıcı durumu servisi oluşturur
+func NewUserStatusService() *UserStatusService {
+	return &UserStatusService{
+		userRepo:     repository.NewUserRepository(),
+		statusRepo:   repository.NewUserStatusRepository(),
+		tokenService: NewTokenService(),
+	}
+}
+
+// ChangeStatus hastane yöneticisinin, hastanesindeki bir kullanıcının durumunu değiştirmesi
+// Hesap durumu tüm hastaneler için geçerli olduğundan sadece birincil hastanenin yöneticisi değiştirebilir
+func (s *UserStatusService) ChangeStatus(hospitalID, actorID, userID uint, req *model.ChangeUserStatusRequest, client model.ClientInfo) (*model.UserStatusResponse, []model.ValidationError, error) {
+	if actorID == userID {
+		return nil, nil, ErrUserStatusSelf
+	}
+
+	user, err := s.getManageableUser(hospitalID, actorID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.apply(user, req, model.StatusActorUser, actorID, &actorID, client.IP)
+}
+
+// ChangeStatusByPlatform platform operatörünün bir kullanıcının durumunu değiştirmesi (hastane kısıtı yoktur)
+// Kullanıcıyı PlatformService bulur; platform denetim kaydı da orada ayrıca yazılır
+func (s *UserStatusService) ChangeStatusByPlatform(adminID uint, user *model.User, req *model.ChangeUserStatusRequest, client model.ClientInfo) (*model.UserStatusResponse, []model.ValidationError, error) {
+	return s.apply(user, req, model.StatusActorPlatform, adminID, nil, client.IP)
+}
+
+// ListHistory hastanedeki bir kullanıcının durum geçmişini getirir
+func (s *UserStatusService) ListHistory(hospitalID, actorID, userID uint) ([]model.UserStatusChange, error) {
+	if _, err := s.getManageableUser(hospitalID, actorID, userID); err != nil {
+		return nil, err
+	}
+
+	changes, err := s.statusRepo.ListChanges(userID)
+	if err != nil {
+		return nil, fmt.Errorf("durum geçmişi getirilemedi: %v", err)
+	}
+	return changes, nil
+}
+
+// apply durumu doğrular, değişikliği denetim kaydıyla yazar, önbelleği günceller ve gerekiyorsa oturumları kapatır
+func (s *UserStatusService) apply(user *model.User, req *model.ChangeUserStatusRequest, actorType string, actorID uint, changedBy *uint, ip string) (*model.UserStatusResponse, []model.ValidationError, error) {
+	status := strings.TrimSpace(req.Status)
+	reason := strings.TrimSpace(req.Reason)
+
+	if validationErrors := validateStatusChange(user, status, reason); len(validationErrors) > 0 {
+		return nil, validationErrors, nil
+	}
+
+	change := &model.UserStatusChange{
+		UserID:     user.ID,
+		HospitalID: user.HospitalID,
+		FromStatus: user.Status,
+		ToStatus:   status,
+		Reason:     reason,
+		ActorType:  actorType,
+		ActorID:    actorID,
+		IP:         ip,
+	}
+	if err := s.statusRepo.ChangeStatus(change, changedBy); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrUserStatusConflict
+		}
+		return nil, nil, fmt.Errorf("kullanıcı durumu güncellenemedi: %v", err)
+	}
+
+	// Middleware durumu önbellekten okur - eski değer TTL boyunca kalmasın
+	if err := database.SetUserStatus(user.ID, status); err != nil {
+		fmt.Printf("⚠️ Kullanıcı durumu önbelleğe yazılamadı: %v\n", err)
+	}
+
+	if status == model.UserStatusActive {
+		// Tekrar aktif edilen kullanıcı eski hatalı denemeler yüzünden kilitli kalmasın
+		if err := NewLoginGuardService().UnlockIdentifiers(user.Email, user.Phone); err != nil {
+			fmt.Println("Giriş kilitleri temizlenemedi:", err)
+		}
+	} else if err := s.tokenService.RevokeAllForUser(user.ID); err != nil {
+		return nil, nil, fmt.Errorf("durum değişti ancak oturumlar sonlandırılamadı: %v", err)
+	}
+
+	fmt.Printf("👤 Kullanıcı durumu değişti: kullanıcı=%d %s → %s (%s=%d)\n", user.ID, change.FromStatus, status, actorType, actorID)
+
+	return &model.UserStatusResponse{
+		UserID:          user.ID,
+		Status:          status,
+		StatusReason:    reason,
+		StatusChangedAt: &change.CreatedAt,
+		StatusChangedBy: changedBy,
+	}, nil, nil
+}
+
+// getManageableUser hedef kullanıcının birincil hastanesinin işlemi yapanın aktif hastanesi olduğunu
+// ve yöneticinin yetkisini aşmadığını kontrol eder
+func (s *UserStatusService) getManageableUser(hospitalID, actorID, userID uint) (*model.User, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrStatusUserNotFound
+		}
+		return nil, fmt.Errorf("kullanıcı getirilemedi: %v", err)
+	}
+	if user.HospitalID != hospitalID {
+		return nil, ErrStatusUserNotFound
+	}
+
+	actor, err := NewMembershipService().GetUserInHospital(actorID, hospitalID)
+	if err != nil {
+		return nil, fmt.Errorf("işlemi yapan kullanıcı bulunamadı: %v", err)
+	}
+	if actorID != userID {
+		if err := NewRoleService().CheckAssignableRole(hospitalID, actor.Role, user.Role); errors.Is(err, ErrPermissionEscalation) {
+			return nil, err
+		}
+	}
+
+	return user, nil
+}
+
+// validateStatusChange yeni durumun geçerli olduğunu ve active dışı durumlar için gerekçe verildiğini kontrol eder
+func validateStatusChange(user *model.User, status, reason string) []model.ValidationError {
+	valid := false
+	for _, s := range model.ValidUserStatuses {
+		if s == status {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return []model.ValidationError{{
+			Field:   "status",
+			Message: "Durum active, suspended, locked veya pending olmalıdır",
+		}}
+	}
+
+	if status == user.Status {
+		return []model.ValidationError{{Field: "status", Message: "Kullanıcı zaten bu durumda"}}
+	}
+	if status != model.UserStatusActive && reason == "" {
+		return []model.ValidationError{{Field: "reason", Message: "Hesabı askıya alma, kilitleme veya onaya çekme için gerekçe zorunludur"}}
+	}
+	if len(reason) > 500 {
+		return []model.ValidationError{{Field: "reason", Message: "Gerekçe en fazla 500 karakter olabilir"}}
+	}
+	return nil
+}
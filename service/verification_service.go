@@ -0,0 +1,116 @@
+package service
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"hospital-platform/config"
+	"hospital-platform/database"
+	"hospital-platform/utils"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// VERIFY_CODE_TTL telefon/e-posta doğrulama kodunun geçerlilik süresi
+const VERIFY_CODE_TTL = 10 * time.Minute
+
+var (
+	ErrTooManyVerificationCodes  = errors.New("Çok fazla doğrulama kodu istendi, lütfen daha sonra tekrar deneyin")
+	ErrVerificationCodeInvalid   = errors.New("Doğrulama kodu hatalı veya süresi dolmuş")
+	ErrVerificationCodeExhausted = errors.New("Çok fazla hatalı deneme yapıldı, lütfen yeni kod isteyin")
+)
+
+// VerificationService - Telefon/e-posta sahipliğini tek kullanımlık kodla doğrular
+// Kodlar konu (ör. "invite:12") bazında saklanır; gönderim ve hatalı deneme sayıları sınırlıdır
+type VerificationService struct {
+	notificationService *NotificationService
+	maxSends            int64
+	maxFailures         int64
+	sendWindow          time.Duration
+}
+
+// NewVerificationService yeni bir doğrulama servisi oluşturur
+func NewVerificationService() *VerificationService {
+	return &VerificationService{
+		notificationService: NewNotificationService(),
+		maxSends:            int64(config.GetEnvInt("VERIFY_CODE_MAX_SENDS", 3)),
+		maxFailures:         int64(config.GetEnvInt("VERIFY_CODE_MAX_ATTEMPTS", 5)),
+		sendWindow:          config.GetEnvDuration("VERIFY_CODE_SEND_WINDOW", 15*time.Minute),
+	}
+}
+
+// SendCode konu için yeni kod üretir ve kanal üzerinden alıcıya gönderir
+func (s *VerificationService) SendCode(subject, channel, to, lang string) error {
+	count, err := database.IncrementAttempt(database.VERIFY_SEND_COUNT_PREFIX+subject, s.sendWindow)
+	if err != nil {
+		return fmt.Errorf("kod talebi kaydedilemedi: %v", err)
+	}
+	if count > s.maxSends {
+		return ErrTooManyVerificationCodes
+	}
+
+	code, err := utils.GenerateNumericCode(6)
+	if err != nil {
+		return fmt.Errorf("kod üretilemedi: %v", err)
+	}
+
+	if err := database.SetVerificationCode(subject, utils.HashCode(subject, code), VERIFY_CODE_TTL); err != nil {
+		return fmt.Errorf("kod kaydedilemedi: %v", err)
+	}
+	if err := database.ClearAttempts(database.VERIFY_FAIL_COUNT_PREFIX + subject); err != nil {
+		fmt.Println("Deneme sayacı temizlenemedi:", err)
+	}
+
+	err = s.notificationService.Send(channel, to, TEMPLATE_PHONE_VERIFICATION, lang, map[string]interface{}{
+		"Code":    code,
+		"Minutes": int(VERIFY_CODE_TTL.Minutes()),
+	})
+	if err != nil {
+		database.DeleteVerificationCode(subject)
+		return fmt.Errorf("kod gönderilemedi: %v", err)
+	}
+	return nil
+}
+
+// CheckCode kodu doğrular; hatalı denemeleri sayar ve limit dolunca kodu iptal eder
+// Başarılı doğrulamada kod silinmez - işlem tamamlanınca Consume çağrılmalı
+func (s *VerificationService) CheckCode(subject, code string) error {
+	failKey := database.VERIFY_FAIL_COUNT_PREFIX + subject
+
+	failures, err := database.GetAttemptCount(failKey)
+	if err != nil {
+		return fmt.Errorf("kod denemeleri kontrol edilemedi: %v", err)
+	}
+	if failures >= s.maxFailures {
+		return ErrVerificationCodeExhausted
+	}
+
+	storedHash, err := database.GetVerificationCode(subject)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return ErrVerificationCodeInvalid
+		}
+		return fmt.Errorf("kod okunamadı: %v", err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(utils.HashCode(subject, code)), []byte(storedHash)) == 1 {
+		return nil
+	}
+
+	failures, err = database.IncrementAttempt(failKey, VERIFY_CODE_TTL)
+	if err != nil {
+		fmt.Println("Hatalı deneme kaydedilemedi:", err)
+	}
+	if failures >= s.maxFailures {
+		database.DeleteVerificationCode(subject)
+		return ErrVerificationCodeExhausted
+	}
+	return ErrVerificationCodeInvalid
+}
+
+// Consume kodu ve sayaçları siler (kod tek kullanımlık)
+func (s *VerificationService) Consume(subject string) {
+	database.DeleteVerificationCode(subject)
+	database.ClearAttempts(database.VERIFY_FAIL_COUNT_PREFIX + subject)
+}
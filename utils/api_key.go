@@ -0,0 +1,162 @@
+package utils
+
+import (
+	"fmt"
+	"hospital-platform/config"
+	"hospital-platform/database"
+	"hospital-platform/model"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	API_KEY_PREFIX    = "hpk_"      // API anahtarlarının ön eki - Bearer JWT'den ayırt etmek için
+	API_KEY_HEADER    = "X-API-Key" // API anahtarının gönderildiği header (Authorization: Bearer hpk_... de kabul edilir)
+	API_KEY_HINT_SIZE = 8           // Listede gösterilen ön ek dahil karakter sayısı
+
+	// API anahtarının son kullanım bilgisi en fazla bu sıklıkta yazılır
+	API_KEY_TOUCH_INTERVAL = time.Minute
+)
+
+// GenerateAPIKey yeni bir API anahtarı üretir: "hpk_" + 32 byte rastgele değer
+func GenerateAPIKey() (string, error) {
+	secret, err := GenerateRandomToken(32)
+	if err != nil {
+		return "", err
+	}
+	return API_KEY_PREFIX + secret, nil
+}
+
+// APIKeyHint anahtarın listede gösterilecek ilk karakterlerini döndürür
+func APIKeyHint(key string) string {
+	if len(key) <= API_KEY_HINT_SIZE {
+		return key
+	}
+	return key[:API_KEY_HINT_SIZE]
+}
+
+// NormalizeIPRule IP veya CIDR değerini doğrular ve standart yazımını döndürür
+func NormalizeIPRule(rule string) (string, bool) {
+	rule = strings.TrimSpace(rule)
+	if ip := net.ParseIP(rule); ip != nil {
+		return ip.String(), true
+	}
+	if _, network, err := net.ParseCIDR(rule); err == nil {
+		return network.String(), true
+	}
+	return "", false
+}
+
+// IsIPAllowed IP'nin izin listesinde olup olmadığını kontrol eder - liste boşsa her IP kabul edilir
+func IsIPAllowed(rules []string, ip string) bool {
+	if len(rules) == 0 {
+		return true
+	}
+
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+
+	for _, rule := range rules {
+		if _, network, err := net.ParseCIDR(rule); err == nil {
+			if network.Contains(parsed) {
+				return true
+			}
+			continue
+		}
+		if allowed := net.ParseIP(rule); allowed != nil && allowed.Equal(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIPExtractor istemci IP'sini belirler
+// X-Forwarded-For sadece güvenilen proxy'lerden (loopback, özel ağlar ve TRUSTED_PROXIES) kabul edilir;
+// böylece IP izin listesi ve brute-force sayaçları header ile atlatılamaz
+func ClientIPExtractor() echo.IPExtractor {
+	var options []echo.TrustOption
+	for _, proxy := range strings.Split(config.GetEnv("TRUSTED_PROXIES", ""), ",") {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		if !strings.Contains(proxy, "/") {
+			if ip := net.ParseIP(proxy); ip != nil && ip.To4() != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			fmt.Printf("⚠️ TRUSTED_PROXIES içinde geçersiz değer atlandı: %s\n", proxy)
+			continue
+		}
+		options = append(options, echo.TrustIPRange(network))
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
+
+// extractAPIKey istekte API anahtarı varsa döndürür (X-API-Key veya Authorization: Bearer hpk_...)
+func extractAPIKey(c echo.Context) (string, bool) {
+	if key := c.Request().Header.Get(API_KEY_HEADER); key != "" {
+		return key, true
+	}
+	authHeader := c.Request().Header.Get("Authorization")
+	if strings.HasPrefix(authHeader, "Bearer "+API_KEY_PREFIX) {
+		return authHeader[7:], true
+	}
+	return "", false
+}
+
+// authenticateAPIKey API anahtarını doğrular ve context'e hastane ve yetki bilgilerini ekler
+// Anahtarın yetkileri (scope) rol yerine doğrudan context'teki yetki listesine yazılır
+func authenticateAPIKey(c echo.Context, key string) error {
+	var apiKey model.APIKey
+	if err := database.DB.Preload("Scopes").
+		Where("key_hash = ?", HashToken(key)).
+		First(&apiKey).Error; err != nil {
+		return fmt.Errorf("API anahtarı bulunamadı")
+	}
+
+	if status := apiKey.EffectiveStatus(); status != model.APIKeyActive {
+		return fmt.Errorf("API anahtarı kullanılamaz (durum: %s)", status)
+	}
+
+	ip := c.RealIP()
+	if !IsIPAllowed(apiKey.AllowedIPList(), ip) {
+		return fmt.Errorf("IP izin listesinde değil: %s", ip)
+	}
+
+	permissions := make(map[string]bool, len(apiKey.Scopes))
+	for _, scope := range apiKey.ScopeNames() {
+		permissions[scope] = true
+	}
+
+	// JWT ile aynı tipte (float64) yazılır - GetHospitalIDFromContext her iki yolda da aynı çalışır
+	c.Set("hospital_id", float64(apiKey.HospitalID))
+	c.Set("username", "api-key:"+apiKey.Name)
+	c.Set("api_key_id", apiKey.ID)
+	c.Set("permissions", permissions)
+
+	// Son kullanım bilgisi isteği engellemez ve her istekte yazılmaz
+	cutoff := time.Now().Add(-API_KEY_TOUCH_INTERVAL)
+	if err := database.DB.Model(&model.APIKey{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", apiKey.ID, cutoff).
+		Updates(map[string]interface{}{"last_used_at": time.Now(), "last_used_ip": ip}).Error; err != nil {
+		fmt.Printf("⚠️ AUTH: API anahtarı son kullanım bilgisi güncellenemedi: %v\n", err)
+	}
+
+	return nil
+}
+
+// IsAPIKeyRequest isteğin API anahtarıyla doğrulanıp doğrulanmadığını döndürür
+func IsAPIKeyRequest(c echo.Context) bool {
+	_, ok := c.Get("api_key_id").(uint)
+	return ok
+}
@@ -0,0 +1,263 @@
+package utils
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// CBOR (RFC 8949) - WebAuthn attestation nesnesi ve COSE anahtarları için minimal çözücü
+// Sadece CTAP2'nin kullandığı alt küme desteklenir: tam sayılar, byte/metin dizileri, dizi, map,
+// etiketler, true/false/null ve kayan noktalı sayılar. Belirsiz uzunluklu (indefinite) kodlama reddedilir.
+
+const CBOR_MAX_DEPTH = 16 // İç içe dizi/map sınırı - kötü niyetli girdide yığın taşmasını engeller
+
+var ErrCBORInvalid = errors.New("geçersiz CBOR verisi")
+
+// DecodeCBOR tek bir CBOR değerini çözer ve kullanılan byte sayısını döndürür
+// Tam sayılar int64, byte dizileri []byte, metinler string, diziler []interface{},
+// map'ler map[interface{}]interface{} olarak döner
+func DecodeCBOR(data []byte) (interface{}, int, error) {
+	d := &cborDecoder{data: data}
+	value, err := d.decode(0)
+	if err != nil {
+		return nil, 0, err
+	}
+	return value, d.pos, nil
+}
+
+type cborDecoder struct {
+	data []byte
+	pos  int
+}
+
+func (d *cborDecoder) decode(depth int) (interface{}, error) {
+	if depth > CBOR_MAX_DEPTH {
+		return nil, fmt.Errorf("%w: çok derin iç içe yapı", ErrCBORInvalid)
+	}
+	if d.pos >= len(d.data) {
+		return nil, fmt.Errorf("%w: beklenmeyen veri sonu", ErrCBORInvalid)
+	}
+
+	initial := d.data[d.pos]
+	d.pos++
+	major := initial >> 5
+	info := initial & 0x1f
+
+	// Kayan noktalı sayılar ve basit değerler argümanı farklı yorumlar
+	if major == 7 {
+		return d.decodeSimple(info)
+	}
+
+	arg, err := d.readArgument(info)
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case 0: // İşaretsiz tam sayı
+		if arg > math.MaxInt64 {
+			return nil, fmt.Errorf("%w: tam sayı çok büyük", ErrCBORInvalid)
+		}
+		return int64(arg), nil
+	case 1: // Negatif tam sayı: -1 - arg
+		if arg > math.MaxInt64 {
+			return nil, fmt.Errorf("%w: tam sayı çok küçük", ErrCBORInvalid)
+		}
+		return -1 - int64(arg), nil
+	case 2: // Byte dizisi
+		raw, err := d.readBytes(arg)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(nil), raw...), nil
+	case 3: // UTF-8 metin
+		raw, err := d.readBytes(arg)
+		if err != nil {
+			return nil, err
+		}
+		return string(raw), nil
+	case 4: // Dizi
+		if arg > uint64(len(d.data)-d.pos) {
+			return nil, fmt.Errorf("%w: dizi uzunluğu veriden büyük", ErrCBORInvalid)
+		}
+		items := make([]interface{}, 0, arg)
+		for i := uint64(0); i < arg; i++ {
+			item, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case 5: // Map - anahtarlar tam sayı veya metin olmalı
+		if arg > uint64(len(d.data)-d.pos)/2 {
+			return nil, fmt.Errorf("%w: map uzunluğu veriden büyük", ErrCBORInvalid)
+		}
+		entries := make(map[interface{}]interface{}, arg)
+		for i := uint64(0); i < arg; i++ {
+			key, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			switch key.(type) {
+			case int64, string:
+			default:
+				return nil, fmt.Errorf("%w: desteklenmeyen map anahtarı", ErrCBORInvalid)
+			}
+			if _, exists := entries[key]; exists {
+				return nil, fmt.Errorf("%w: tekrarlanan map anahtarı", ErrCBORInvalid)
+			}
+			value, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			entries[key] = value
+		}
+		return entries, nil
+	case 6: // Etiket - içerik değeri döndürülür
+		return d.decode(depth + 1)
+	}
+	return nil, fmt.Errorf("%w: bilinmeyen tür %d", ErrCBORInvalid, major)
+}
+
+// readArgument başlık byte'ındaki ek bilgiye göre uzunluk/değer argümanını okur
+func (d *cborDecoder) readArgument(info byte) (uint64, error) {
+	switch {
+	case info < 24:
+		return uint64(info), nil
+	case info == 24:
+		raw, err := d.readBytes(1)
+		if err != nil {
+			return 0, err
+		}
+		return uint64(raw[0]), nil
+	case info == 25:
+		raw, err := d.readBytes(2)
+		if err != nil {
+			return 0, err
+		}
+		return uint64(binary.BigEndian.Uint16(raw)), nil
+	case info == 26:
+		raw, err := d.readBytes(4)
+		if err != nil {
+			return 0, err
+		}
+		return uint64(binary.BigEndian.Uint32(raw)), nil
+	case info == 27:
+		raw, err := d.readBytes(8)
+		if err != nil {
+			return 0, err
+		}
+		return binary.BigEndian.Uint64(raw), nil
+	}
+	return 0, fmt.Errorf("%w: belirsiz uzunluklu kodlama desteklenmiyor", ErrCBORInvalid)
+}
+
+// decodeSimple major type 7 değerlerini (false, true, null, undefined, float) çözer
+func (d *cborDecoder) decodeSimple(info byte) (interface{}, error) {
+	switch info {
+	case 20:
+		return false, nil
+	case 21:
+		return true, nil
+	case 22, 23:
+		return nil, nil
+	case 25:
+		raw, err := d.readBytes(2)
+		if err != nil {
+			return nil, err
+		}
+		return halfToFloat(binary.BigEndian.Uint16(raw)), nil
+	case 26:
+		raw, err := d.readBytes(4)
+		if err != nil {
+			return nil, err
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(raw))), nil
+	case 27:
+		raw, err := d.readBytes(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(raw)), nil
+	}
+	return nil, fmt.Errorf("%w: desteklenmeyen basit değer %d", ErrCBORInvalid, info)
+}
+
+// readBytes sıradaki n byte'ı döndürür, veri yetmiyorsa hata verir
+func (d *cborDecoder) readBytes(n uint64) ([]byte, error) {
+	if n > uint64(len(d.data)-d.pos) {
+		return nil, fmt.Errorf("%w: beklenmeyen veri sonu", ErrCBORInvalid)
+	}
+	start := d.pos
+	d.pos += int(n)
+	return d.data[start:d.pos], nil
+}
+
+// halfToFloat IEEE 754 yarım hassasiyetli sayıyı float64'e çevirir
+func halfToFloat(half uint16) float64 {
+	exponent := int((half >> 10) & 0x1f)
+	mantissa := float64(half & 0x3ff)
+	var value float64
+	switch exponent {
+	case 0:
+		value = math.Ldexp(mantissa, -24)
+	case 31:
+		if mantissa == 0 {
+			value = math.Inf(1)
+		} else {
+			value = math.NaN()
+		}
+	default:
+		value = math.Ldexp(mantissa+1024, exponent-25)
+	}
+	if half&0x8000 != 0 {
+		return -value
+	}
+	return value
+}
+
+// ==================== KODLAYICI ====================
+
+// Yazılım authenticator'ının attestation nesnesi ve COSE anahtarı üretmek için kullandığı minimal kodlayıcı
+// Map girdileri verilen sırayla yazılır (CTAP2 kanonik sırası çağıranın sorumluluğundadır)
+
+func cborHead(major byte, n uint64) []byte {
+	switch {
+	case n < 24:
+		return []byte{major<<5 | byte(n)}
+	case n <= 0xff:
+		return []byte{major<<5 | 24, byte(n)}
+	case n <= 0xffff:
+		return binary.BigEndian.AppendUint16([]byte{major<<5 | 25}, uint16(n))
+	case n <= 0xffffffff:
+		return binary.BigEndian.AppendUint32([]byte{major<<5 | 26}, uint32(n))
+	}
+	return binary.BigEndian.AppendUint64([]byte{major<<5 | 27}, n)
+}
+
+func cborInt(n int64) []byte {
+	if n < 0 {
+		return cborHead(1, uint64(-1-n))
+	}
+	return cborHead(0, uint64(n))
+}
+
+func cborBytes(b []byte) []byte {
+	return append(cborHead(2, uint64(len(b))), b...)
+}
+
+func cborText(s string) []byte {
+	return append(cborHead(3, uint64(len(s))), s...)
+}
+
+func cborMap(entries [][2][]byte) []byte {
+	buf := cborHead(5, uint64(len(entries)))
+	for _, entry := range entries {
+		buf = append(buf, entry[0]...)
+		buf = append(buf, entry[1]...)
+	}
+	return buf
+}
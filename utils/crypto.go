@@ -0,0 +1,128 @@
+package utils
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"hospital-platform/config"
+	"strings"
+)
+
+// getEncryptionKey veritabanında saklanan hassas alanlar için AES-256 anahtarı üretir
+// DATA_ENCRYPTION_KEY tanımlı değilse JWT secret'ından türetilir
+func getEncryptionKey() []byte {
+	secret := config.GetEnv("DATA_ENCRYPTION_KEY", string(getJWTSecret()))
+	key := sha256.Sum256([]byte(secret))
+	return key[:]
+}
+
+// EncryptString metni AES-GCM ile şifreler ve base64 olarak döndürür (TOTP secret vb. için)
+func EncryptString(plainText string) (string, error) {
+	block, err := aes.NewCipher(getEncryptionKey())
+	if err != nil {
+		return "", err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := gcm.Seal(nonce, nonce, []byte(plainText), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptString EncryptString ile şifrelenmiş metni çözer
+func DecryptString(cipherText string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(cipherText)
+	if err != nil {
+		return "", err
+	}
+
+	block, err := aes.NewCipher(getEncryptionKey())
+	if err != nil {
+		return "", err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+
+	if len(data) < gcm.NonceSize() {
+		return "", errors.New("şifreli veri geçersiz")
+	}
+
+	nonce, sealed := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+// ==================== İMZALI LİNK TOKEN'LARI ====================
+
+// getLinkSigningKey e-posta/SMS ile gönderilen linklerin HMAC anahtarını üretir
+// Şifreleme anahtarıyla aynı secret'tan, farklı etiketle türetilir
+func getLinkSigningKey() []byte {
+	secret := config.GetEnv("DATA_ENCRYPTION_KEY", string(getJWTSecret()))
+	key := sha256.Sum256([]byte("link-signing:" + secret))
+	return key[:]
+}
+
+// SignLinkToken payload'ı amaç etiketiyle HMAC-SHA256 imzalar ve "payload.imza" döndürür
+// Payload nokta içerebilir; imza her zaman son noktadan sonra gelir
+func SignLinkToken(purpose, payload string) string {
+	return payload + "." + linkSignature(purpose, payload)
+}
+
+// VerifyLinkToken imzayı doğrular ve payload'ı döndürür
+// İmza sadece token'ın bu sistem tarafından üretildiğini gösterir; tek kullanımlık/iptal kontrolü çağıranın işidir
+func VerifyLinkToken(purpose, token string) (string, bool) {
+	idx := strings.LastIndex(token, ".")
+	if idx <= 0 {
+		return "", false
+	}
+
+	payload, signature := token[:idx], token[idx+1:]
+	if !hmac.Equal([]byte(signature), []byte(linkSignature(purpose, payload))) {
+		return "", false
+	}
+	return payload, true
+}
+
+// linkSignature amaç ve payload için base64url HMAC üretir - farklı amaçların token'ları birbirinin yerine kullanılamaz
+func linkSignature(purpose, payload string) string {
+	mac := hmac.New(sha256.New, getLinkSigningKey())
+	mac.Write([]byte(purpose + ":" + payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// ==================== KISA KOD HASH'LERİ ====================
+
+// getCodeHashKey SMS/e-posta ile gönderilen kısa kodların HMAC anahtarını üretir
+// Şifreleme anahtarıyla aynı secret'tan, farklı etiketle türetilir
+func getCodeHashKey() []byte {
+	secret := config.GetEnv("DATA_ENCRYPTION_KEY", string(getJWTSecret()))
+	key := sha256.Sum256([]byte("code-hash:" + secret))
+	return key[:]
+}
+
+// HashCode kısa kodun konuya bağlı HMAC-SHA256 hash'ini hex olarak döndürür
+// 6 haneli kodun düz SHA-256'sı Redis'e erişen biri tarafından anında çözülebilir; secret bilinmeden hash'ten kod bulunamaz
+func HashCode(subject, code string) string {
+	mac := hmac.New(sha256.New, getCodeHashKey())
+	mac.Write([]byte(subject + ":" + code))
+	return hex.EncodeToString(mac.Sum(nil))
+}
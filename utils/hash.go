@@ -1,17 +1,327 @@
 package utils
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hospital-platform/config"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
 )
 
-// Şifreyi hashle
-func HashPassword(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), 14)
+// Desteklenen şifre hash algoritmaları
+const (
+	HASHER_ARGON2ID = "argon2id"
+	HASHER_BCRYPT   = "bcrypt"
+)
+
+var (
+	ErrHasherBusy         = errors.New("şifre işlemleri yoğun, lütfen tekrar deneyin")
+	ErrUnknownHashFormat  = errors.New("bilinmeyen şifre hash formatı")
+	ErrInvalidHashEncoded = errors.New("şifre hash'i çözümlenemedi")
+)
+
+// PasswordHasher - Şifre hash algoritması arayüzü
+// Yeni algoritma eklemek için bu arayüzü implement edip initHashers içindeki listeye eklemek yeterli
+type PasswordHasher interface {
+	Name() string                                  // Algoritma adı (config için)
+	Hash(password string) (string, error)          // Şifreyi kendi formatında hash'ler
+	Verify(password, encoded string) (bool, error) // Şifre hash ile eşleşiyor mu?
+	Identifies(encoded string) bool                // Hash bu algoritmaya mı ait?
+	NeedsRehash(encoded string) bool               // Hash güncel parametrelerle mi üretilmiş?
+}
+
+// ==================== ARGON2ID ====================
+
+// Argon2idHasher - Varsayılan hasher, PHC formatında ($argon2id$v=19$m=...,t=...,p=...$salt$hash) saklar
+type Argon2idHasher struct {
+	Memory      uint32 // KiB
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+func (h *Argon2idHasher) Name() string { return HASHER_ARGON2ID }
+
+func (h *Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.Iterations, h.Memory, h.Parallelism, h.KeyLength)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, h.Memory, h.Iterations, h.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (h *Argon2idHasher) Verify(password, encoded string) (bool, error) {
+	params, salt, key, err := decodeArgon2id(encoded)
+	if err != nil {
+		return false, err
+	}
+
+	candidate := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, uint32(len(key)))
+	return subtle.ConstantTimeCompare(key, candidate) == 1, nil
+}
+
+func (h *Argon2idHasher) Identifies(encoded string) bool {
+	return strings.HasPrefix(encoded, "$argon2id$")
+}
+
+func (h *Argon2idHasher) NeedsRehash(encoded string) bool {
+	params, salt, key, err := decodeArgon2id(encoded)
+	if err != nil {
+		return true
+	}
+	return params.Memory != h.Memory || params.Iterations != h.Iterations || params.Parallelism != h.Parallelism ||
+		uint32(len(salt)) != h.SaltLength || uint32(len(key)) != h.KeyLength
+}
+
+// decodeArgon2id PHC formatındaki hash'ten parametreleri, salt'ı ve anahtarı çıkarır
+func decodeArgon2id(encoded string) (*Argon2idHasher, []byte, []byte, error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != HASHER_ARGON2ID {
+		return nil, nil, nil, ErrInvalidHashEncoded
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, nil, nil, ErrInvalidHashEncoded
+	}
+
+	params := &Argon2idHasher{}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return nil, nil, nil, ErrInvalidHashEncoded
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, nil, nil, ErrInvalidHashEncoded
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return nil, nil, nil, ErrInvalidHashEncoded
+	}
+
+	return params, salt, key, nil
+}
+
+// ==================== BCRYPT ====================
+
+// BcryptHasher - Eski kayıtlar için; yeni hash'ler varsayılan olarak argon2id ile üretilir
+type BcryptHasher struct {
+	Cost int
+}
+
+func (h *BcryptHasher) Name() string { return HASHER_BCRYPT }
+
+func (h *BcryptHasher) Hash(password string) (string, error) {
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), h.Cost)
 	return string(bytes), err
 }
 
-// Girilen şifre ile hash aynı mı kontrol et
+func (h *BcryptHasher) Verify(password, encoded string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (h *BcryptHasher) Identifies(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") || strings.HasPrefix(encoded, "$2b$") || strings.HasPrefix(encoded, "$2y$")
+}
+
+func (h *BcryptHasher) NeedsRehash(encoded string) bool {
+	cost, err := bcrypt.Cost([]byte(encoded))
+	return err != nil || cost != h.Cost
+}
+
+// ==================== HASHER SEÇİMİ VE WORKER HAVUZU ====================
+
+var (
+	hasherOnce    sync.Once
+	defaultHasher PasswordHasher
+	hashers       []PasswordHasher
+	hashSlots     chan struct{} // Aynı anda çalışabilecek hash işlemi sayısını sınırlar
+	hashWaitLimit time.Duration
+)
+
+// Argon2id parametre sınırları - bu aralığın dışındaki değerlerle uygulama başlatılmaz
+const (
+	ARGON2_MIN_MEMORY     = 8 * 1024        // KiB - daha azı brute-force'a karşı koruma sağlamaz
+	ARGON2_MAX_MEMORY     = 4 * 1024 * 1024 // KiB - her hash işlemi bu kadar bellek ayırır
+	ARGON2_MAX_ITERATIONS = 100
+	ARGON2_MAX_PARALLEL   = 255 // argon2 paralellik değeri uint8'dir
+	ARGON2_MIN_KEY_LENGTH = 16
+	ARGON2_MAX_KEY_LENGTH = 64
+)
+
+// CheckHasherConfig şifre hash ayarlarını doğrular
+// Uygulama başlarken çağrılmalıdır; sıfır/taşan parametreler ilk login'de panik veya zayıf hash yerine başlangıçta yakalanır
+func CheckHasherConfig() error {
+	_, _, err := loadHashers()
+	return err
+}
+
+// loadHashers hasher'ları config'den okur ve parametrelerini doğrular
+func loadHashers() (*Argon2idHasher, *BcryptHasher, error) {
+	memory := config.GetEnvInt("ARGON2_MEMORY", 64*1024)
+	iterations := config.GetEnvInt("ARGON2_ITERATIONS", 3)
+	parallelism := config.GetEnvInt("ARGON2_PARALLELISM", 2)
+	keyLength := config.GetEnvInt("ARGON2_KEY_LENGTH", 32)
+	cost := config.GetEnvInt("BCRYPT_COST", bcrypt.DefaultCost)
+
+	switch {
+	case memory < ARGON2_MIN_MEMORY || memory > ARGON2_MAX_MEMORY:
+		return nil, nil, fmt.Errorf("ARGON2_MEMORY %d KiB ile %d KiB arasında olmalı, gelen: %d", ARGON2_MIN_MEMORY, ARGON2_MAX_MEMORY, memory)
+	case iterations < 1 || iterations > ARGON2_MAX_ITERATIONS:
+		return nil, nil, fmt.Errorf("ARGON2_ITERATIONS 1 ile %d arasında olmalı, gelen: %d", ARGON2_MAX_ITERATIONS, iterations)
+	case parallelism < 1 || parallelism > ARGON2_MAX_PARALLEL:
+		return nil, nil, fmt.Errorf("ARGON2_PARALLELISM 1 ile %d arasında olmalı, gelen: %d", ARGON2_MAX_PARALLEL, parallelism)
+	case keyLength < ARGON2_MIN_KEY_LENGTH || keyLength > ARGON2_MAX_KEY_LENGTH:
+		return nil, nil, fmt.Errorf("ARGON2_KEY_LENGTH %d ile %d byte arasında olmalı, gelen: %d", ARGON2_MIN_KEY_LENGTH, ARGON2_MAX_KEY_LENGTH, keyLength)
+	case cost < bcrypt.MinCost || cost > bcrypt.MaxCost:
+		return nil, nil, fmt.Errorf("BCRYPT_COST %d ile %d arasında olmalı, gelen: %d", bcrypt.MinCost, bcrypt.MaxCost, cost)
+	}
+
+	if name := config.GetEnv("PASSWORD_HASHER", HASHER_ARGON2ID); name != HASHER_ARGON2ID && name != HASHER_BCRYPT {
+		return nil, nil, fmt.Errorf("PASSWORD_HASHER %s veya %s olmalı, gelen: %s", HASHER_ARGON2ID, HASHER_BCRYPT, name)
+	}
+
+	argon := &Argon2idHasher{
+		Memory:      uint32(memory),
+		Iterations:  uint32(iterations),
+		Parallelism: uint8(parallelism),
+		SaltLength:  16,
+		KeyLength:   uint32(keyLength),
+	}
+	return argon, &BcryptHasher{Cost: cost}, nil
+}
+
+// initHashers hasher'ları ve worker havuzunu config'den bir kez oluşturur
+// Argon2 her işlemde ARGON2_MEMORY kadar bellek kullandığından havuz boyutu bellek kullanımını da sınırlar
+func initHashers() {
+	hasherOnce.Do(func() {
+		argon, bcryptHasher, err := loadHashers()
+		if err != nil {
+			// main başlangıçta CheckHasherConfig çağırır; buraya ancak ayar çalışma sırasında bozulursa gelinir
+			panic(fmt.Sprintf("şifre hash ayarları geçersiz: %v", err))
+		}
+		hashers = []PasswordHasher{argon, bcryptHasher}
+
+		defaultHasher = argon
+		if config.GetEnv("PASSWORD_HASHER", HASHER_ARGON2ID) == HASHER_BCRYPT {
+			defaultHasher = bcryptHasher
+		}
+
+		workers := config.GetEnvInt("PASSWORD_HASH_WORKERS", runtime.NumCPU())
+		if workers < 1 {
+			workers = 1
+		}
+		hashSlots = make(chan struct{}, workers)
+		hashWaitLimit = config.GetEnvDuration("PASSWORD_HASH_QUEUE_TIMEOUT", 5*time.Second)
+	})
+}
+
+// withHashSlot işlemi havuzdan bir slot alarak çalıştırır; bekleme süresi aşılırsa ErrHasherBusy döner
+func withHashSlot(fn func() error) error {
+	timer := time.NewTimer(hashWaitLimit)
+	defer timer.Stop()
+
+	select {
+	case hashSlots <- struct{}{}:
+		defer func() { <-hashSlots }()
+		return fn()
+	case <-timer.C:
+		return ErrHasherBusy
+	}
+}
+
+// findHasher hash formatına göre ilgili hasher'ı bulur
+func findHasher(encoded string) (PasswordHasher, error) {
+	for _, hasher := range hashers {
+		if hasher.Identifies(encoded) {
+			return hasher, nil
+		}
+	}
+	return nil, ErrUnknownHashFormat
+}
+
+// HashPassword şifreyi varsayılan hasher (argon2id) ile hash'ler
+func HashPassword(password string) (string, error) {
+	initHashers()
+
+	var encoded string
+	err := withHashSlot(func() error {
+		var err error
+		encoded, err = defaultHasher.Hash(password)
+		return err
+	})
+	return encoded, err
+}
+
+// VerifyPassword şifreyi hash ile karşılaştırır
+// needsRehash: hash eski algoritma/parametrelerle üretilmişse true - başarılı girişte yeniden hash'lenmeli
+func VerifyPassword(password, encoded string) (ok bool, needsRehash bool, err error) {
+	initHashers()
+
+	hasher, err := findHasher(encoded)
+	if err != nil {
+		return false, false, err
+	}
+
+	err = withHashSlot(func() error {
+		var err error
+		ok, err = hasher.Verify(password, encoded)
+		return err
+	})
+	if err != nil || !ok {
+		return false, false, err
+	}
+
+	needsRehash = hasher.Name() != defaultHasher.Name() || hasher.NeedsRehash(encoded)
+	return true, needsRehash, nil
+}
+
+var (
+	dummyHashOnce sync.Once
+	dummyHash     string
+)
+
+// DummyPasswordHash kullanıcı bulunamadığında şifrenin doğrulanacağı sabit hash'i döndürür
+// Varsayılan hasher'ın güncel parametreleriyle bir kez üretilir; olmayan hesapla yapılan giriş gerçek hesapla aynı sürede reddedilir
+func DummyPasswordHash() string {
+	initHashers()
+
+	dummyHashOnce.Do(func() {
+		password, err := GenerateRandomToken(32)
+		if err != nil {
+			panic(fmt.Sprintf("sahte şifre üretilemedi: %v", err))
+		}
+		encoded, err := defaultHasher.Hash(password)
+		if err != nil {
+			panic(fmt.Sprintf("sahte şifre hash'i üretilemedi: %v", err))
+		}
+		dummyHash = encoded
+	})
+	return dummyHash
+}
+
+// CheckPasswordHash girilen şifre ile hash aynı mı kontrol et
 func CheckPasswordHash(password, hash string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
-	return err == nil
+	ok, _, _ := VerifyPassword(password, hash)
+	return ok
 }
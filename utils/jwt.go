@@ -1,46 +1,162 @@
 package utils
 
 import (
+	"errors"
 	"hospital-platform/config"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
 )
 
+// DEFAULT_JWT_SECRET sadece development ortamında kabul edilen varsayılan secret
+const DEFAULT_JWT_SECRET = "super_secret_jwt_key_for_hospital_platform_2024"
+
 // getJWTSecret JWT secret'ını environment variable'dan al
+// Token'lar artık asimetrik anahtarlarla imzalanıyor, secret sadece veri şifreleme anahtarına fallback olarak kullanılır
 func getJWTSecret() []byte {
-	secret := config.GetEnv("JWT_SECRET", "super_secret_jwt_key_for_hospital_platform_2024")
+	secret := config.GetEnv("JWT_SECRET", DEFAULT_JWT_SECRET)
 	return []byte(secret)
 }
 
+// CheckSecretConfig development dışında varsayılan secret ile çalışılmasını engeller
+// Uygulama başlarken çağrılmalıdır, hata dönerse uygulama başlatılmamalıdır
+func CheckSecretConfig() error {
+	if config.IsDevelopment() {
+		return nil
+	}
+	if string(getJWTSecret()) == DEFAULT_JWT_SECRET {
+		return errors.New("JWT_SECRET varsayılan değerde, development dışında güçlü bir secret tanımlanmalı")
+	}
+	return nil
+}
+
 type Claims struct {
 	UserID     uint   `json:"user_id"`
 	Email      string `json:"email"`
-	Role       string `json:"role"`        // yetkili veya çalışan
-	HospitalID uint   `json:"hospital_id"` // Kullanıcının bağlı olduğu hastane
-	Username   string `json:"username"`    // Kullanıcı adı
+	Role       string `json:"role"`              // Aktif hastanedeki rol
+	HospitalID uint   `json:"hospital_id"`       // Token'ın geçerli olduğu aktif hastane (birincil hastane veya üyelik)
+	Username   string `json:"username"`          // Kullanıcı adı
+	FamilyID   string `json:"fid"`               // Token ailesi - aynı login'den rotate edilen tüm token'lar
+	Version    int64  `json:"ver"`               // Kullanıcı token versiyonu - rol/durum değişince artar
+	MFA        bool   `json:"mfa"`               // Login sırasında MFA doğrulandı mı?
+	Purpose    string `json:"purpose,omitempty"` // Boş: access token, "mfa_pending"/"password_change": sadece ilgili login adımı için, "platform": platform operatörü
 	jwt.RegisteredClaims
 }
 
-// GenerateJWT - Kullanıcı için JWT token oluşturur (hospital_id dahil)
-// Artık hospital bilgisi de token'da taşınır
-func GenerateJWT(userID uint, email, role string, hospitalID uint, username string) (string, error) {
-	expirationTime := time.Now().Add(24 * time.Hour) // 24 saat geçerli
+const (
+	TOKEN_PURPOSE_MFA_PENDING     = "mfa_pending"
+	TOKEN_PURPOSE_PASSWORD_CHANGE = "password_change"
+	TOKEN_PURPOSE_PLATFORM        = "platform"
+	MFA_PENDING_TOKEN_TTL         = 5 * time.Minute
+	PASSWORD_CHANGE_TOKEN_TTL     = 10 * time.Minute
+)
+
+// GetAccessTokenTTL access token geçerlilik süresini döndürür (varsayılan 15 dakika)
+func GetAccessTokenTTL() time.Duration {
+	return config.GetEnvDuration("ACCESS_TOKEN_TTL", 15*time.Minute)
+}
+
+// GetRefreshTokenTTL refresh token geçerlilik süresini döndürür (varsayılan 24 saat - bir vardiya)
+func GetRefreshTokenTTL() time.Duration {
+	return config.GetEnvDuration("REFRESH_TOKEN_TTL", 24*time.Hour)
+}
+
+// GenerateAccessToken - Kısa ömürlü access token oluşturur
+// Süre ve oluşturulma zamanı burada atanır, kalan alanları çağıran doldurur
+func GenerateAccessToken(claims *Claims) (string, error) {
+	now := time.Now()
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(now.Add(GetAccessTokenTTL())),
+		IssuedAt:  jwt.NewNumericDate(now),
+	}
 
+	return signToken(claims)
+}
+
+// GetPlatformTokenTTL platform operatörü token'ının geçerlilik süresini döndürür (varsayılan 1 saat)
+// Operatör token'ı yenilenemez, süre dolunca tekrar giriş yapılır
+func GetPlatformTokenTTL() time.Duration {
+	return config.GetEnvDuration("PLATFORM_TOKEN_TTL", time.Hour)
+}
+
+// GeneratePlatformToken - Platform operatörü için token üretir
+// UserID alanı operatör ID'sidir; purpose "platform" olduğu için hastane endpoint'lerinde kabul edilmez
+func GeneratePlatformToken(adminID uint, email string, mfa bool) (string, error) {
+	now := time.Now()
 	claims := &Claims{
-		UserID:     userID,
-		Email:      email,
-		Role:       role,
-		HospitalID: hospitalID,
-		Username:   username,
+		UserID:   adminID,
+		Email:    email,
+		Username: email,
+		MFA:      mfa,
+		Purpose:  TOKEN_PURPOSE_PLATFORM,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(now.Add(GetPlatformTokenTTL())),
+			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(getJWTSecret())
+	return signToken(claims)
+}
+
+// ValidatePlatformToken - Platform operatörü token'ını doğrular ve claims'leri döndürür
+func ValidatePlatformToken(tokenString string) (*Claims, error) {
+	claims, err := ValidateJWTWithClaims(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Purpose != TOKEN_PURPOSE_PLATFORM {
+		return nil, jwt.ErrTokenInvalidClaims
+	}
+	return claims, nil
+}
+
+// GenerateMFAPendingToken - Şifresi doğrulanmış ama MFA adımı bekleyen kullanıcı için kısa ömürlü token üretir
+// Bu token korumalı endpoint'lerde access token olarak kabul edilmez
+func GenerateMFAPendingToken(userID uint) (string, error) {
+	return generatePurposeToken(userID, TOKEN_PURPOSE_MFA_PENDING, MFA_PENDING_TOKEN_TTL)
+}
+
+// ValidateMFAPendingToken - MFA pending token'ını doğrular ve kullanıcı ID'sini döndürür
+func ValidateMFAPendingToken(tokenString string) (uint, error) {
+	return validatePurposeToken(tokenString, TOKEN_PURPOSE_MFA_PENDING)
+}
+
+// GeneratePasswordChangeToken - Şifresinin süresi dolmuş kullanıcının login sırasında şifre değiştirmesi için token üretir
+// Bu token sadece /login/password-change endpoint'inde kullanılabilir
+func GeneratePasswordChangeToken(userID uint) (string, error) {
+	return generatePurposeToken(userID, TOKEN_PURPOSE_PASSWORD_CHANGE, PASSWORD_CHANGE_TOKEN_TTL)
+}
+
+// ValidatePasswordChangeToken - Şifre değiştirme token'ını doğrular ve kullanıcı ID'sini döndürür
+func ValidatePasswordChangeToken(tokenString string) (uint, error) {
+	return validatePurposeToken(tokenString, TOKEN_PURPOSE_PASSWORD_CHANGE)
+}
+
+// generatePurposeToken - Sadece belirli bir login adımında geçerli, kısa ömürlü token üretir
+func generatePurposeToken(userID uint, purpose string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := &Claims{
+		UserID:  userID,
+		Purpose: purpose,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+
+	return signToken(claims)
+}
+
+// validatePurposeToken - Token'ı doğrular ve beklenen amaç için üretildiğini kontrol eder
+func validatePurposeToken(tokenString, purpose string) (uint, error) {
+	claims, err := ValidateJWTWithClaims(tokenString)
+	if err != nil {
+		return 0, err
+	}
+	if claims.Purpose != purpose {
+		return 0, jwt.ErrTokenInvalidClaims
+	}
+	return claims.UserID, nil
 }
 
 // ValidateJWT - JWT token'ını doğrular ve claims'leri map olarak döndürür
@@ -48,9 +164,7 @@ func GenerateJWT(userID uint, email, role string, hospitalID uint, username stri
 func ValidateJWT(tokenString string) (map[string]interface{}, error) {
 	claims := &Claims{}
 
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		return getJWTSecret(), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, claims, verificationKey)
 
 	if err != nil {
 		return nil, err
@@ -60,6 +174,11 @@ func ValidateJWT(tokenString string) (map[string]interface{}, error) {
 		return nil, jwt.ErrSignatureInvalid
 	}
 
+	// Özel amaçlı token'lar (mfa_pending, password_change, platform) access token yerine kullanılamaz
+	if claims.Purpose != "" {
+		return nil, jwt.ErrTokenInvalidClaims
+	}
+
 	// Claims'leri map formatına çevir
 	claimsMap := map[string]interface{}{
 		"user_id":     float64(claims.UserID), // JSON'da number float64 olur
@@ -67,6 +186,9 @@ func ValidateJWT(tokenString string) (map[string]interface{}, error) {
 		"role":        claims.Role,
 		"hospital_id": float64(claims.HospitalID), // JSON'da number float64 olur
 		"username":    claims.Username,
+		"fid":         claims.FamilyID,
+		"ver":         float64(claims.Version),
+		"mfa":         claims.MFA,
 	}
 
 	return claimsMap, nil
@@ -77,9 +199,7 @@ func ValidateJWT(tokenString string) (map[string]interface{}, error) {
 func ValidateJWTWithClaims(tokenString string) (*Claims, error) {
 	claims := &Claims{}
 
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		return getJWTSecret(), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, claims, verificationKey)
 
 	if err != nil {
 		return nil, err
@@ -2,6 +2,8 @@ package utils
 
 import (
 	"fmt"
+	"hospital-platform/database"
+	"hospital-platform/model"
 	"net/http"
 	"strings"
 
@@ -16,23 +18,44 @@ type JWTClaims struct {
 	Username   string `json:"username"`
 }
 
-// PermissionLevel - Yetki seviyeleri enum'u
-type PermissionLevel int
-
-const (
-	READ  PermissionLevel = iota // Sadece okuma (çalışan)
-	WRITE                        // Okuma + yazma (çalışan+)
-	ADMIN                        // Her şey (yetkili)
-)
-
 // JWTAuthMiddleware - JWT token'ı doğrular ve context'e kullanıcı bilgilerini ekler
 // Her korumalı endpoint'te bu middleware çalışır
+// Entegrasyonlar için hastane API anahtarı da kabul edilir (X-API-Key veya Authorization: Bearer hpk_...)
+// Kayıt başvurusu onaylanmamış hastanelerin token'ları burada reddedilir
 func JWTAuthMiddleware() echo.MiddlewareFunc {
+	return jwtAuth(false)
+}
+
+// ApplicationAuthMiddleware - JWTAuthMiddleware ile aynı doğrulamayı yapar, ek olarak onay bekleyen
+// veya reddedilen hastanelerin token'larını da kabul eder. Sadece başvuru ve oturum kapatma rotalarında kullanılır
+func ApplicationAuthMiddleware() echo.MiddlewareFunc {
+	return jwtAuth(true)
+}
+
+// jwtAuth - JWTAuthMiddleware ve ApplicationAuthMiddleware ortak implementasyonu
+func jwtAuth(allowPending bool) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
+			// API anahtarı - kullanıcı yerine anahtarın hastanesi ve yetkileri context'e yazılır
+			if key, ok := extractAPIKey(c); ok {
+				if err := authenticateAPIKey(c, key); err != nil {
+					fmt.Printf("❌ AUTH: API anahtarı reddedildi: %v\n", err)
+					return c.JSON(http.StatusUnauthorized, echo.Map{
+						"error":   "Yetkilendirme hatası",
+						"message": "Geçersiz, süresi dolmuş veya iptal edilmiş API anahtarı",
+					})
+				}
+
+				if hospitalID, _ := GetHospitalIDFromContext(c); !checkHospitalAccess(c, hospitalID, allowPending) {
+					return nil
+				}
+
+				fmt.Printf("✅ AUTH: API anahtarı ile başarılı - Hospital ID: %v, Key ID: %v\n", c.Get("hospital_id"), c.Get("api_key_id"))
+				return next(c)
+			}
+
 			// Authorization header'ını kontrol et
 			authHeader := c.Request().Header.Get("Authorization")
-			fmt.Printf("🔍 AUTH DEBUG: Header = '%s'\n", authHeader)
 
 			if authHeader == "" {
 				fmt.Println("❌ AUTH: Header eksik")
@@ -44,7 +67,7 @@ func JWTAuthMiddleware() echo.MiddlewareFunc {
 
 			// "Bearer " prefix'ini kontrol et
 			if !strings.HasPrefix(authHeader, "Bearer ") {
-				fmt.Printf("❌ AUTH: Bearer prefix eksik, gelen: '%s'\n", authHeader)
+				fmt.Println("❌ AUTH: Bearer prefix eksik")
 				return c.JSON(http.StatusUnauthorized, echo.Map{
 					"error":   "Yetkilendirme hatası",
 					"message": "Geçersiz token formatı",
@@ -52,8 +75,7 @@ func JWTAuthMiddleware() echo.MiddlewareFunc {
 			}
 
 			// Token'ı çıkar
-			tokenString := authHeader[7:] // "Bearer " kısmını kes
-			fmt.Printf("🔍 AUTH: Token = '%s'\n", tokenString[:20]+"...")
+			tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 
 			// Token'ı doğrula ve parse et
 			claims, err := ValidateJWT(tokenString)
@@ -65,11 +87,44 @@ func JWTAuthMiddleware() echo.MiddlewareFunc {
 				})
 			}
 
+			// Token iptal edildiyse (logout, rol/durum değişikliği, yeniden kullanım tespiti) reddet
+			if err := checkTokenRevocation(claims); err != nil {
+				fmt.Printf("❌ AUTH: Token iptal kontrolü başarısız: %v\n", err)
+				return c.JSON(http.StatusUnauthorized, echo.Map{
+					"error":   "Yetkilendirme hatası",
+					"message": "Token iptal edilmiş",
+				})
+			}
+
+			// Oturum (token ailesi) kullanıcı veya yetkili tarafından sonlandırıldıysa reddet
+			familyID, _ := claims["fid"].(string)
+			if err := checkSession(familyID, c.RealIP()); err != nil {
+				fmt.Printf("❌ AUTH: Oturum kontrolü başarısız: %v\n", err)
+				return c.JSON(http.StatusUnauthorized, echo.Map{
+					"error":   "Yetkilendirme hatası",
+					"message": "Oturum sonlandırılmış",
+				})
+			}
+
+			// Askıya alınan hastanenin açık token'ları da anında geçersiz olur
+			hospitalID, _ := claims["hospital_id"].(float64)
+			if !checkHospitalAccess(c, uint(hospitalID), allowPending) {
+				return nil
+			}
+
+			// Askıya alınan, kilitlenen veya onay bekleyen kullanıcının açık token'ları da reddedilir
+			userID, _ := claims["user_id"].(float64)
+			if !checkUserAccess(c, uint(userID)) {
+				return nil
+			}
+
 			// Claims'i context'e ekle - diğer handler'lar kullanabilsin
 			c.Set("user_id", claims["user_id"])
 			c.Set("hospital_id", claims["hospital_id"])
 			c.Set("role", claims["role"])
 			c.Set("username", claims["username"])
+			c.Set("token_family", claims["fid"])
+			c.Set("mfa", claims["mfa"])
 
 			fmt.Printf("✅ AUTH: Başarılı - Hospital ID: %v, User ID: %v\n", claims["hospital_id"], claims["user_id"])
 
@@ -78,40 +133,101 @@ func JWTAuthMiddleware() echo.MiddlewareFunc {
 	}
 }
 
-// RequirePermission - Belirli yetki seviyesi gerektiren endpoint'ler için middleware
-// Örnek: RequirePermission(ADMIN) - sadece yetkili kullanıcılar erişebilir
-func RequirePermission(requiredLevel PermissionLevel) echo.MiddlewareFunc {
+// RequirePermission - Route'un ihtiyaç duyduğu yetkilerin tamamını gerektirir
+// Yetkiler kullanıcının hastanedeki rolünden okunur, böylece rol değişiklikleri anında geçerli olur
+// Örnek: RequirePermission(model.PermStaffWrite)
+func RequirePermission(permissions ...string) echo.MiddlewareFunc {
+	return requirePermissions(permissions, true)
+}
+
+// RequireAnyPermission - Verilen yetkilerden en az birini gerektirir
+// Kapsamlı yetkilerde kullanılır, örn: polyclinics:write veya polyclinics:write:own
+// Kapsam kontrolü (sadece kendi polikliniği vb.) handler/servis katmanında HasPermission ile yapılır
+func RequireAnyPermission(permissions ...string) echo.MiddlewareFunc {
+	return requirePermissions(permissions, false)
+}
+
+// requirePermissions - RequirePermission ve RequireAnyPermission ortak implementasyonu
+func requirePermissions(required []string, requireAll bool) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			// JWT middleware'den role bilgisini al
-			roleInterface := c.Get("role")
-			if roleInterface == nil {
+			granted, err := loadPermissions(c)
+			if err != nil {
+				fmt.Printf("❌ AUTH: Yetkiler yüklenemedi: %v\n", err)
+				return c.JSON(http.StatusInternalServerError, echo.Map{
+					"error":   "Sistem hatası",
+					"message": "Yetki bilgisi işlenemedi",
+				})
+			}
+
+			var missing []string
+			for _, permission := range required {
+				if !granted[permission] {
+					missing = append(missing, permission)
+				}
+			}
+
+			allowed := len(missing) == 0
+			if !requireAll {
+				allowed = len(missing) < len(required)
+			}
+
+			if !allowed {
+				return c.JSON(http.StatusForbidden, echo.Map{
+					"error":   "Yetkisiz erişim",
+					"message": "Bu işlem için yeterli yetkiniz yok",
+					"details": map[string]interface{}{
+						"required": required,
+						"missing":  missing,
+					},
+				})
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// RequireMFA - Hastane MFA'yı zorunlu kılmışsa, MFA ile doğrulanmamış token'ları reddeder
+// Yazma/yönetim yetkisi isteyen endpoint'lerde RequirePermission ile birlikte kullanılır
+func RequireMFA() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			// Token MFA ile alınmışsa kontrol gereksiz
+			if mfa, _ := c.Get("mfa").(bool); mfa {
+				return next(c)
+			}
+
+			// MFA insan girişleri içindir; API anahtarları zaten yönetim yetkisi taşıyamaz
+			if IsAPIKeyRequest(c) {
+				return next(c)
+			}
+
+			hospitalID, ok := GetHospitalIDFromContext(c)
+			if !ok {
 				return c.JSON(http.StatusUnauthorized, echo.Map{
 					"error":   "Yetkilendirme hatası",
-					"message": "Rol bilgisi bulunamadı",
+					"message": "Hastane bilgisi bulunamadı",
 				})
 			}
 
-			role, ok := roleInterface.(string)
-			if !ok {
+			var requireMFA bool
+			err := database.DB.Model(&model.Hospital{}).
+				Select("require_mfa").
+				Where("id = ?", hospitalID).
+				Scan(&requireMFA).Error
+			if err != nil {
 				return c.JSON(http.StatusInternalServerError, echo.Map{
 					"error":   "Sistem hatası",
-					"message": "Rol bilgisi işlenemedi",
+					"message": "MFA politikası kontrol edilemedi",
 				})
 			}
 
-			// Kullanıcının yetki seviyesini belirle
-			userLevel := getUserPermissionLevel(role)
-
-			// Gerekli yetki seviyesi ile karşılaştır
-			if userLevel < requiredLevel {
+			if requireMFA {
 				return c.JSON(http.StatusForbidden, echo.Map{
-					"error":   "Yetkisiz erişim",
-					"message": "Bu işlem için yeterli yetkiniz yok",
-					"details": map[string]string{
-						"required": getPermissionName(requiredLevel),
-						"current":  getPermissionName(userLevel),
-					},
+					"error":        "Yetkisiz erişim",
+					"message":      "Bu işlem için iki adımlı doğrulama (MFA) gerekli",
+					"mfa_required": true,
 				})
 			}
 
@@ -159,30 +275,144 @@ func RequireRole(requiredRole string) echo.MiddlewareFunc {
 	}
 }
 
-// getUserPermissionLevel - Kullanıcı rolünden yetki seviyesini belirler
-func getUserPermissionLevel(role string) PermissionLevel {
-	switch role {
-	case "yetkili":
-		return ADMIN // Yetkili kullanıcı - her şeyi yapabilir
-	case "çalışan":
-		return READ // Çalışan - sadece okuma
-	default:
-		return READ // Bilinmeyen rol - güvenlik için sadece okuma
+// checkTokenRevocation - Token ailesinin ve kullanıcı token versiyonunun geçerliliğini Redis'ten kontrol eder
+// Redis'e ulaşılamazsa güvenlik için token reddedilir
+func checkTokenRevocation(claims map[string]interface{}) error {
+	if familyID, _ := claims["fid"].(string); familyID != "" {
+		revoked, err := database.IsTokenFamilyRevoked(familyID)
+		if err != nil {
+			return err
+		}
+		if revoked {
+			return fmt.Errorf("token ailesi iptal edilmiş: %s", familyID)
+		}
 	}
+
+	userID, _ := claims["user_id"].(float64)
+	tokenVersion, _ := claims["ver"].(float64)
+
+	currentVersion, err := database.GetUserTokenVersion(uint(userID))
+	if err != nil {
+		return err
+	}
+	if int64(tokenVersion) < currentVersion {
+		return fmt.Errorf("token versiyonu eski (token: %d, güncel: %d)", int64(tokenVersion), currentVersion)
+	}
+
+	return nil
+}
+
+// checkHospitalAccess - Hastane askıya alınmışsa veya (allowPending değilse) başvurusu onaylanmamışsa 403 cevabını yazar ve false döner
+func checkHospitalAccess(c echo.Context, hospitalID uint, allowPending bool) bool {
+	status, err := GetHospitalStatus(hospitalID)
+	if err != nil {
+		fmt.Printf("❌ AUTH: Hastane durumu okunamadı (hastane %d): %v\n", hospitalID, err)
+		c.JSON(http.StatusInternalServerError, echo.Map{
+			"error":   "Sistem hatası",
+			"message": "Hastane durumu kontrol edilemedi",
+		})
+		return false
+	}
+
+	switch status {
+	case model.HospitalStatusSuspended:
+		fmt.Printf("❌ AUTH: Hastane askıda (hastane %d)\n", hospitalID)
+		c.JSON(http.StatusForbidden, echo.Map{
+			"error":   "Hesap askıda",
+			"message": ErrHospitalSuspended.Error(),
+		})
+		return false
+	case model.HospitalStatusPending, model.HospitalStatusRejected:
+		if allowPending {
+			return true
+		}
+		fmt.Printf("❌ AUTH: Hastane başvurusu onaylanmamış (hastane %d, durum %s)\n", hospitalID, status)
+		c.JSON(http.StatusForbidden, echo.Map{
+			"error":              "Başvuru onaylanmadı",
+			"message":            ErrHospitalNotApproved.Error(),
+			"application_status": status,
+		})
+		return false
+	}
+	return true
 }
 
-// getPermissionName - Yetki seviyesinin ismini döndürür (hata mesajları için)
-func getPermissionName(level PermissionLevel) string {
-	switch level {
-	case READ:
-		return "okuma"
-	case WRITE:
-		return "yazma"
-	case ADMIN:
-		return "yönetici"
-	default:
-		return "bilinmeyen"
+// checkUserAccess - Kullanıcı hesabı aktif değilse 403 cevabını yazar ve false döner
+func checkUserAccess(c echo.Context, userID uint) bool {
+	status, err := GetUserStatus(userID)
+	if err != nil {
+		fmt.Printf("❌ AUTH: Kullanıcı durumu okunamadı (kullanıcı %d): %v\n", userID, err)
+		c.JSON(http.StatusUnauthorized, echo.Map{
+			"error":   "Yetkilendirme hatası",
+			"message": "Kullanıcı hesabı bulunamadı",
+		})
+		return false
+	}
+
+	if err := CheckUserStatus(status); err != nil {
+		fmt.Printf("❌ AUTH: Kullanıcı aktif değil (kullanıcı %d, durum %s)\n", userID, status)
+		c.JSON(http.StatusForbidden, echo.Map{
+			"error":       "Hesap aktif değil",
+			"message":     err.Error(),
+			"user_status": status,
+		})
+		return false
 	}
+	return true
+}
+
+// checkSession - Token'ın bağlı olduğu oturumun hâlâ aktif olduğunu kontrol eder ve son görülme bilgisini günceller
+func checkSession(familyID, ip string) error {
+	if familyID == "" {
+		return fmt.Errorf("token oturum bilgisi içermiyor")
+	}
+
+	exists, err := database.SessionExists(familyID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("oturum bulunamadı: %s", familyID)
+	}
+
+	// Son görülme bilgisi isteği engellemez
+	if err := database.TouchSession(familyID, ip, false); err != nil {
+		fmt.Printf("⚠️ AUTH: Oturum son görülme bilgisi güncellenemedi: %v\n", err)
+	}
+	return nil
+}
+
+// loadPermissions - Kullanıcının rolüne ait yetkileri veritabanından okur ve istek boyunca context'te saklar
+func loadPermissions(c echo.Context) (map[string]bool, error) {
+	if permissions, ok := c.Get("permissions").(map[string]bool); ok {
+		return permissions, nil
+	}
+
+	hospitalID, ok := GetHospitalIDFromContext(c)
+	if !ok {
+		return nil, fmt.Errorf("hastane bilgisi bulunamadı")
+	}
+	role, ok := GetRoleFromContext(c)
+	if !ok {
+		return nil, fmt.Errorf("rol bilgisi bulunamadı")
+	}
+
+	var names []string
+	err := database.DB.Model(&model.RolePermission{}).
+		Joins("JOIN roles ON roles.id = role_permissions.role_id").
+		Where("roles.hospital_id = ? AND roles.name = ? AND roles.deleted_at IS NULL", hospitalID, role).
+		Pluck("role_permissions.permission", &names).Error
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := make(map[string]bool, len(names))
+	for _, name := range names {
+		permissions[name] = true
+	}
+
+	c.Set("permissions", permissions)
+	return permissions, nil
 }
 
 // =============== HELPER FUNCTIONS ===============
@@ -204,6 +434,7 @@ func GetUserIDFromContext(c echo.Context) (uint, bool) {
 }
 
 // GetHospitalIDFromContext - Context'ten hospital ID'yi çıkarır
+// Birden fazla hastaneye üye kullanıcılarda token'ın geçerli olduğu tek aktif hastaneyi döndürür (/auth/switch-hospital ile değişir)
 func GetHospitalIDFromContext(c echo.Context) (uint, bool) {
 	hospitalIDInterface := c.Get("hospital_id")
 	fmt.Printf("🔍 CONTEXT DEBUG: hospital_id = %+v (type: %T)\n", hospitalIDInterface, hospitalIDInterface)
@@ -232,6 +463,15 @@ func GetHospitalIDFromContext(c echo.Context) (uint, bool) {
 	return uint(hospitalIDFloat), true
 }
 
+// GetTokenFamilyFromContext - Context'ten token ailesi ID'sini çıkarır (logout için)
+func GetTokenFamilyFromContext(c echo.Context) (string, bool) {
+	familyID, ok := c.Get("token_family").(string)
+	if !ok || familyID == "" {
+		return "", false
+	}
+	return familyID, true
+}
+
 // GetRoleFromContext - Context'ten rol bilgisini çıkarır
 func GetRoleFromContext(c echo.Context) (string, bool) {
 	roleInterface := c.Get("role")
@@ -253,3 +493,21 @@ func GetUsernameFromContext(c echo.Context) (string, bool) {
 	username, ok := usernameInterface.(string)
 	return username, ok
 }
+
+// HasPermission - Kullanıcının rolünde verilen yetkinin olup olmadığını kontrol eder
+// Kapsam kontrolü için handler'larda kullanılır (örn: polyclinics:write yoksa sadece kendi polikliniği)
+func HasPermission(c echo.Context, permission string) bool {
+	permissions, err := loadPermissions(c)
+	if err != nil {
+		return false
+	}
+	return permissions[permission]
+}
+
+// GetClientInfo - İstemcinin IP ve User-Agent bilgisini oturum kaydı için döndürür
+func GetClientInfo(c echo.Context) model.ClientInfo {
+	return model.ClientInfo{
+		IP:        c.RealIP(),
+		UserAgent: TruncateUserAgent(c.Request().UserAgent()),
+	}
+}
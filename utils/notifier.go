@@ -0,0 +1,250 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hospital-platform/config"
+	"io"
+	"mime"
+	"net/http"
+	"net/smtp"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Bildirim kanalları
+const (
+	CHANNEL_SMS   = "sms"
+	CHANNEL_EMAIL = "email"
+)
+
+// Desteklenen bildirim sağlayıcıları
+const (
+	NOTIFIER_HTTP_SMS = "http"    // JSON kabul eden SMS gateway
+	NOTIFIER_SMTP     = "smtp"    // SMTP sunucusu üzerinden e-posta
+	NOTIFIER_FILE     = "file"    // Bildirimleri dosyaya yazar (geliştirme/test)
+	NOTIFIER_CONSOLE  = "console" // Bildirimleri stdout'a yazar (geliştirme)
+)
+
+var ErrUnknownChannel = errors.New("bilinmeyen bildirim kanalı")
+
+// Notification - Gönderilecek tek bir bildirim
+type Notification struct {
+	To      string // Telefon numarası veya e-posta adresi
+	Subject string // Sadece e-posta için
+	Body    string
+}
+
+// Notifier - Bildirim sağlayıcı arayüzü
+// Yeni sağlayıcı eklemek için bu arayüzü implement edip newNotifier içine eklemek yeterli
+type Notifier interface {
+	Name() string                         // Sağlayıcı adı (config için)
+	Send(notification Notification) error // Bildirimi gönderir
+}
+
+// ==================== HTTP SMS GATEWAY ====================
+
+// HTTPSMSNotifier - SMS'i {"to","from","message"} JSON gövdesiyle bir gateway'e POST eder
+// Gateway'e özel format gerekiyorsa araya küçük bir adaptör servis konulabilir
+type HTTPSMSNotifier struct {
+	URL    string
+	APIKey string
+	Sender string
+	Client *http.Client
+}
+
+func (n *HTTPSMSNotifier) Name() string { return NOTIFIER_HTTP_SMS }
+
+func (n *HTTPSMSNotifier) Send(notification Notification) error {
+	payload, err := json.Marshal(map[string]string{
+		"to":      notification.To,
+		"from":    n.Sender,
+		"message": notification.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, n.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if n.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+n.APIKey)
+	}
+
+	resp, err := n.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("SMS gateway'e ulaşılamadı: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("SMS gönderilemedi (HTTP %d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// ==================== SMTP E-POSTA ====================
+
+// SMTPNotifier - E-postayı SMTP sunucusu üzerinden gönderir (587 portunda STARTTLS sunucu destekliyorsa otomatik)
+type SMTPNotifier struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+func (n *SMTPNotifier) Name() string { return NOTIFIER_SMTP }
+
+func (n *SMTPNotifier) Send(notification Notification) error {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", stripHeaderNewlines(n.From))
+	fmt.Fprintf(&msg, "To: %s\r\n", stripHeaderNewlines(notification.To))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", stripHeaderNewlines(notification.Subject)))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(notification.Body, "\n", "\r\n"))
+
+	var auth smtp.Auth
+	if n.Username != "" {
+		auth = smtp.PlainAuth("", n.Username, n.Password, n.Host)
+	}
+
+	if err := smtp.SendMail(n.Host+":"+n.Port, auth, n.From, []string{notification.To}, msg.Bytes()); err != nil {
+		return fmt.Errorf("e-posta gönderilemedi: %v", err)
+	}
+	return nil
+}
+
+// stripHeaderNewlines başlık değerlerindeki satır sonlarını siler (header injection koruması)
+func stripHeaderNewlines(value string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+}
+
+// ==================== DOSYA / KONSOL ====================
+
+// FileNotifier - Bildirimleri gerçekten göndermek yerine dosyaya veya stdout'a yazar
+// Geliştirme ortamında SMS/e-posta sağlayıcısı olmadan akışları denemek için kullanılır
+type FileNotifier struct {
+	Channel string
+	Path    string // Boşsa stdout'a yazar
+	mu      sync.Mutex
+}
+
+func (n *FileNotifier) Name() string {
+	if n.Path == "" {
+		return NOTIFIER_CONSOLE
+	}
+	return NOTIFIER_FILE
+}
+
+func (n *FileNotifier) Send(notification Notification) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	var out io.Writer = os.Stdout
+	if n.Path != "" {
+		file, err := os.OpenFile(n.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			return fmt.Errorf("bildirim dosyası açılamadı: %v", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	_, err := fmt.Fprintf(out, "📨 [%s] %s -> %s\n", time.Now().Format(time.RFC3339), n.Channel, notification.To)
+	if err == nil && notification.Subject != "" {
+		_, err = fmt.Fprintf(out, "Konu: %s\n", notification.Subject)
+	}
+	if err == nil {
+		_, err = fmt.Fprintf(out, "%s\n\n", notification.Body)
+	}
+	return err
+}
+
+// ==================== SAĞLAYICI SEÇİMİ ====================
+
+var (
+	notifierOnce sync.Once
+	notifiers    map[string]Notifier
+)
+
+// initNotifiers kanal başına sağlayıcıyı config'den bir kez oluşturur
+func initNotifiers() {
+	notifierOnce.Do(func() {
+		filePath := config.GetEnv("NOTIFY_FILE_PATH", "notifications.log")
+
+		notifiers = map[string]Notifier{
+			CHANNEL_SMS:   newNotifier(CHANNEL_SMS, config.GetEnv("SMS_PROVIDER", NOTIFIER_CONSOLE), filePath),
+			CHANNEL_EMAIL: newNotifier(CHANNEL_EMAIL, config.GetEnv("EMAIL_PROVIDER", NOTIFIER_CONSOLE), filePath),
+		}
+
+		for channel, notifier := range notifiers {
+			fmt.Printf("📨 Bildirim kanalı %s: %s\n", channel, notifier.Name())
+		}
+	})
+}
+
+// CheckNotifierConfig development dışında bildirimlerin konsola veya dosyaya yazılmasını engeller
+// Şifre sıfırlama kodları, davet linkleri ve doğrulama kodları loglara düşmemelidir
+// Uygulama başlarken çağrılmalıdır, hata dönerse uygulama başlatılmamalıdır
+func CheckNotifierConfig() error {
+	if config.IsDevelopment() {
+		return nil
+	}
+	initNotifiers()
+	for _, channel := range []string{CHANNEL_SMS, CHANNEL_EMAIL} {
+		if name := notifiers[channel].Name(); name == NOTIFIER_CONSOLE || name == NOTIFIER_FILE {
+			return fmt.Errorf("%s bildirimleri %s sağlayıcısına yazılıyor, development dışında SMS_PROVIDER=%s ve EMAIL_PROVIDER=%s tanımlanmalı",
+				channel, name, NOTIFIER_HTTP_SMS, NOTIFIER_SMTP)
+		}
+	}
+	return nil
+}
+
+// newNotifier kanal ve sağlayıcı adına göre notifier oluşturur; bilinmeyen sağlayıcıda konsola düşer
+func newNotifier(channel, provider, filePath string) Notifier {
+	switch {
+	case channel == CHANNEL_SMS && provider == NOTIFIER_HTTP_SMS:
+		return &HTTPSMSNotifier{
+			URL:    config.GetEnv("SMS_API_URL", ""),
+			APIKey: config.GetEnv("SMS_API_KEY", ""),
+			Sender: config.GetEnv("SMS_SENDER", ""),
+			Client: &http.Client{Timeout: config.GetEnvDuration("SMS_API_TIMEOUT", 10*time.Second)},
+		}
+	case channel == CHANNEL_EMAIL && provider == NOTIFIER_SMTP:
+		return &SMTPNotifier{
+			Host:     config.GetEnv("SMTP_HOST", "localhost"),
+			Port:     config.GetEnv("SMTP_PORT", "587"),
+			Username: config.GetEnv("SMTP_USERNAME", ""),
+			Password: config.GetEnv("SMTP_PASSWORD", ""),
+			From:     config.GetEnv("SMTP_FROM", "no-reply@hospital-platform.local"),
+		}
+	case provider == NOTIFIER_FILE:
+		return &FileNotifier{Channel: channel, Path: filePath}
+	case provider != NOTIFIER_CONSOLE:
+		fmt.Printf("⚠️  %s için bilinmeyen bildirim sağlayıcısı: %s, konsol kullanılıyor\n", channel, provider)
+	}
+	return &FileNotifier{Channel: channel}
+}
+
+// GetNotifier kanal için yapılandırılmış sağlayıcıyı döndürür
+func GetNotifier(channel string) (Notifier, error) {
+	initNotifiers()
+
+	notifier, ok := notifiers[channel]
+	if !ok {
+		return nil, ErrUnknownChannel
+	}
+	return notifier, nil
+}
@@ -0,0 +1,354 @@
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hospital-platform/config"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	OIDC_DISCOVERY_PATH = "/.well-known/openid-configuration"
+	OIDC_CACHE_TTL      = 10 * time.Minute // Discovery ve JWKS önbellek süresi
+	OIDC_HTTP_TIMEOUT   = 10 * time.Second
+	OIDC_MAX_BODY_SIZE  = 1 << 20 // IdP yanıtları için üst sınır (1 MB)
+	OIDC_CLOCK_SKEW     = time.Minute
+)
+
+var (
+	ErrOIDCDiscovery    = errors.New("kimlik sağlayıcı (IdP) ayarları alınamadı")
+	ErrOIDCTokenRequest = errors.New("kimlik sağlayıcıdan token alınamadı")
+	ErrOIDCInvalidToken = errors.New("kimlik sağlayıcının ID token'ı doğrulanamadı")
+)
+
+// OIDCProvider - IdP'nin discovery belgesinden kullanılan alanlar
+type OIDCProvider struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// OIDCClient - Hastaneye ait IdP istemci bilgileri
+type OIDCClient struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string // Boşsa public client (sadece PKCE)
+	RedirectURI  string
+	Scopes       []string
+}
+
+// OIDCTokenResponse - Token endpoint yanıtı
+type OIDCTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	IDToken     string `json:"id_token"`
+	TokenType   string `json:"token_type"`
+}
+
+// oidcCacheEntry discovery belgesi ve JWKS için süreli önbellek kaydı
+type oidcCacheEntry struct {
+	provider  *OIDCProvider
+	keys      map[string]interface{}
+	expiresAt time.Time
+}
+
+var (
+	oidcCache   = map[string]*oidcCacheEntry{}
+	oidcCacheMu sync.Mutex
+	oidcHTTP    = &http.Client{Timeout: OIDC_HTTP_TIMEOUT}
+)
+
+// ==================== PKCE ====================
+
+// GeneratePKCE PKCE verifier ve S256 challenge üretir
+func GeneratePKCE() (verifier, challenge string, err error) {
+	verifier, err = GenerateRandomToken(32)
+	if err != nil {
+		return "", "", err
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	return verifier, base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+// ==================== DISCOVERY ====================
+
+// ValidateIssuerURL issuer adresinin https olduğunu kontrol eder
+// Development ortamında yerel test IdP'si için localhost üzerinde http'ye izin verilir
+func ValidateIssuerURL(issuer string) error {
+	parsed, err := url.Parse(issuer)
+	if err != nil || parsed.Host == "" {
+		return fmt.Errorf("geçerli bir URL değil")
+	}
+	if parsed.Scheme == "https" {
+		return nil
+	}
+
+	host := parsed.Hostname()
+	if parsed.Scheme == "http" && config.IsDevelopment() && (host == "localhost" || host == "127.0.0.1" || host == "::1") {
+		return nil
+	}
+	return fmt.Errorf("issuer https olmalıdır")
+}
+
+// DiscoverOIDC issuer'ın discovery belgesini getirir (önbellekli)
+// Belgedeki issuer, ayarlardaki issuer ile birebir eşleşmek zorunda
+func DiscoverOIDC(issuer string) (*OIDCProvider, error) {
+	entry, err := getOIDCEntry(issuer)
+	if err != nil {
+		return nil, err
+	}
+	return entry.provider, nil
+}
+
+// getOIDCEntry önbellekteki kaydı döndürür, yoksa veya süresi dolmuşsa discovery ve JWKS'i yeniden yükler
+func getOIDCEntry(issuer string) (*oidcCacheEntry, error) {
+	oidcCacheMu.Lock()
+	entry, ok := oidcCache[issuer]
+	oidcCacheMu.Unlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry, nil
+	}
+
+	return refreshOIDCEntry(issuer)
+}
+
+// refreshOIDCEntry discovery belgesini ve JWKS'i IdP'den yükleyip önbelleğe yazar
+func refreshOIDCEntry(issuer string) (*oidcCacheEntry, error) {
+	var provider OIDCProvider
+	if err := getJSON(strings.TrimSuffix(issuer, "/")+OIDC_DISCOVERY_PATH, &provider); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCDiscovery, err)
+	}
+	if provider.Issuer != issuer {
+		return nil, fmt.Errorf("%w: issuer eşleşmiyor (beklenen %s, gelen %s)", ErrOIDCDiscovery, issuer, provider.Issuer)
+	}
+	if provider.AuthorizationEndpoint == "" || provider.TokenEndpoint == "" || provider.JWKSURI == "" {
+		return nil, fmt.Errorf("%w: discovery belgesinde zorunlu endpoint'ler eksik", ErrOIDCDiscovery)
+	}
+
+	var set JWKSet
+	if err := getJSON(provider.JWKSURI, &set); err != nil {
+		return nil, fmt.Errorf("%w: JWKS alınamadı: %v", ErrOIDCDiscovery, err)
+	}
+
+	keys := make(map[string]interface{}, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := parseJWK(jwk)
+		if err != nil {
+			fmt.Printf("⚠️ OIDC: %s JWKS anahtarı atlandı (%s): %v\n", issuer, jwk.Kid, err)
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	entry := &oidcCacheEntry{provider: &provider, keys: keys, expiresAt: time.Now().Add(OIDC_CACHE_TTL)}
+	oidcCacheMu.Lock()
+	oidcCache[issuer] = entry
+	oidcCacheMu.Unlock()
+	return entry, nil
+}
+
+// ==================== AUTHORIZATION CODE + PKCE ====================
+
+// OIDCAuthorizationURL kullanıcının yönlendirileceği IdP giriş adresini oluşturur
+func OIDCAuthorizationURL(client OIDCClient, state, nonce, codeChallenge string) (string, error) {
+	provider, err := DiscoverOIDC(client.Issuer)
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := url.Parse(provider.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("%w: authorization_endpoint geçersiz", ErrOIDCDiscovery)
+	}
+
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", client.ClientID)
+	query.Set("redirect_uri", client.RedirectURI)
+	query.Set("scope", strings.Join(client.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+
+	return authURL.String(), nil
+}
+
+// ExchangeOIDCCode authorization code'u PKCE verifier ile token'a çevirir
+// Client secret varsa client_secret_basic ile kimlik doğrulanır
+func ExchangeOIDCCode(client OIDCClient, code, codeVerifier string) (*OIDCTokenResponse, error) {
+	provider, err := DiscoverOIDC(client.Issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", client.RedirectURI)
+	form.Set("code_verifier", codeVerifier)
+	if client.ClientSecret == "" {
+		form.Set("client_id", client.ClientID)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, provider.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCTokenRequest, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if client.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(client.ClientID), url.QueryEscape(client.ClientSecret))
+	}
+
+	resp, err := oidcHTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCTokenRequest, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, OIDC_MAX_BODY_SIZE))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCTokenRequest, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: HTTP %d: %s", ErrOIDCTokenRequest, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var tokens OIDCTokenResponse
+	if err := json.Unmarshal(body, &tokens); err != nil {
+		return nil, fmt.Errorf("%w: yanıt okunamadı: %v", ErrOIDCTokenRequest, err)
+	}
+	if tokens.IDToken == "" {
+		return nil, fmt.Errorf("%w: yanıtta id_token yok", ErrOIDCTokenRequest)
+	}
+	return &tokens, nil
+}
+
+// VerifyOIDCIDToken ID token'ın imzasını (IdP JWKS), issuer, audience, süre ve nonce'unu doğrular, claim'leri döndürür
+// Bilinmeyen kid gelirse IdP anahtar rotasyonu ihtimaline karşı JWKS bir kez yenilenir
+func VerifyOIDCIDToken(client OIDCClient, rawIDToken, nonce string) (jwt.MapClaims, error) {
+	entry, err := getOIDCEntry(client.Issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := jwt.MapClaims{}
+	parser := jwt.Parser{ValidMethods: []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "EdDSA"}, SkipClaimsValidation: true}
+	_, err = parser.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		if key, ok := entry.keys[kid]; ok {
+			return key, nil
+		}
+		refreshed, err := refreshOIDCEntry(client.Issuer)
+		if err != nil {
+			return nil, err
+		}
+		if key, ok := refreshed.keys[kid]; ok {
+			return key, nil
+		}
+		return nil, ErrUnknownKeyID
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCInvalidToken, err)
+	}
+
+	now := time.Now()
+	switch {
+	case claims["iss"] != client.Issuer:
+		return nil, fmt.Errorf("%w: issuer eşleşmiyor", ErrOIDCInvalidToken)
+	case !claims.VerifyAudience(client.ClientID, true):
+		return nil, fmt.Errorf("%w: audience eşleşmiyor", ErrOIDCInvalidToken)
+	case !claims.VerifyExpiresAt(now.Add(-OIDC_CLOCK_SKEW).Unix(), true):
+		return nil, fmt.Errorf("%w: token süresi dolmuş", ErrOIDCInvalidToken)
+	case !claims.VerifyIssuedAt(now.Add(OIDC_CLOCK_SKEW).Unix(), false):
+		return nil, fmt.Errorf("%w: token gelecekte üretilmiş", ErrOIDCInvalidToken)
+	case claims["nonce"] != nonce:
+		return nil, fmt.Errorf("%w: nonce eşleşmiyor", ErrOIDCInvalidToken)
+	}
+
+	return claims, nil
+}
+
+// ==================== HELPER FUNCTIONS ====================
+
+// getJSON IdP'den JSON belge getirir
+func getJSON(rawURL string, target interface{}) error {
+	resp, err := oidcHTTP.Get(rawURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, OIDC_MAX_BODY_SIZE)).Decode(target)
+}
+
+// parseJWK JWKS'teki public key'i Go anahtarına çevirir (RSA, EC P-256/384/521, Ed25519)
+func parseJWK(jwk JWK) (interface{}, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("desteklenmeyen eğri: %s", jwk.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	case "OKP":
+		if jwk.Crv != "Ed25519" {
+			return nil, fmt.Errorf("desteklenmeyen eğri: %s", jwk.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("geçersiz Ed25519 anahtarı")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("desteklenmeyen anahtar tipi: %s", jwk.Kty)
+	}
+}
@@ -0,0 +1,322 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	testOIDCClientID = "hospital-platform"
+	testOIDCSecret   = "test-secret"
+)
+
+// testIdP cmd/mock-idp'nin httptest üzerinde çalışan küçük bir kopyasıdır
+// Discovery, JWKS ve PKCE (S256) doğrulayan token endpoint'i sunar; giriş formu yerine kodlar doğrudan üretilir
+type testIdP struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	kid    string
+
+	mu       sync.Mutex
+	codes    map[string]testAuthCode
+	jwksHits int
+}
+
+type testAuthCode struct {
+	challenge string
+	nonce     string
+}
+
+func newTestIdP(t *testing.T) *testIdP {
+	t.Helper()
+
+	idp := &testIdP{key: newTestRSAKey(t), kid: "idp-1", codes: map[string]testAuthCode{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(OIDC_DISCOVERY_PATH, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(OIDCProvider{
+			Issuer:                idp.issuer(),
+			AuthorizationEndpoint: idp.issuer() + "/authorize",
+			TokenEndpoint:         idp.issuer() + "/token",
+			JWKSURI:               idp.issuer() + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		idp.mu.Lock()
+		idp.jwksHits++
+		key, kid := idp.key, idp.kid
+		idp.mu.Unlock()
+
+		json.NewEncoder(w).Encode(JWKSet{Keys: []JWK{{
+			Kty: "RSA",
+			Kid: kid,
+			Use: "sig",
+			Alg: "RS256",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", idp.token)
+
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+func (idp *testIdP) issuer() string {
+	return idp.server.URL
+}
+
+func (idp *testIdP) client() OIDCClient {
+	return OIDCClient{
+		Issuer:       idp.issuer(),
+		ClientID:     testOIDCClientID,
+		ClientSecret: testOIDCSecret,
+		RedirectURI:  "http://localhost:3000/sso/callback",
+		Scopes:       []string{"openid", "email"},
+	}
+}
+
+// authorize kullanıcının IdP'de giriş yaptığını varsayar ve tek kullanımlık kod üretir
+func (idp *testIdP) authorize(t *testing.T, challenge, nonce string) string {
+	t.Helper()
+
+	code, err := GenerateRandomToken(16)
+	if err != nil {
+		t.Fatalf("kod üretilemedi: %v", err)
+	}
+	idp.mu.Lock()
+	idp.codes[code] = testAuthCode{challenge: challenge, nonce: nonce}
+	idp.mu.Unlock()
+	return code
+}
+
+// token PKCE verifier'ı challenge ile karşılaştırır ve imzalı ID token döndürür
+func (idp *testIdP) token(w http.ResponseWriter, r *http.Request) {
+	clientID, secret, ok := r.BasicAuth()
+	if !ok || clientID != testOIDCClientID || secret != testOIDCSecret {
+		http.Error(w, `{"error":"invalid_client"}`, http.StatusUnauthorized)
+		return
+	}
+
+	r.ParseForm()
+	idp.mu.Lock()
+	code, found := idp.codes[r.PostForm.Get("code")]
+	delete(idp.codes, r.PostForm.Get("code"))
+	idp.mu.Unlock()
+	if !found {
+		http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+		return
+	}
+
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(sum[:]) != code.challenge {
+		http.Error(w, `{"error":"invalid_grant","error_description":"PKCE doğrulanamadı"}`, http.StatusBadRequest)
+		return
+	}
+
+	json.NewEncoder(w).Encode(OIDCTokenResponse{
+		AccessToken: "access",
+		IDToken:     idp.sign(idp.claims(code.nonce), idp.key, idp.kid),
+		TokenType:   "Bearer",
+	})
+}
+
+func (idp *testIdP) claims(nonce string) jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss":            idp.issuer(),
+		"aud":            testOIDCClientID,
+		"sub":            "user-1",
+		"email":          "hekim@hastane.example",
+		"email_verified": true,
+		"nonce":          nonce,
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+	}
+}
+
+func (idp *testIdP) sign(claims jwt.MapClaims, key *rsa.PrivateKey, kid string) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		panic(err)
+	}
+	return signed
+}
+
+func newTestRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("RSA anahtarı üretilemedi: %v", err)
+	}
+	return key
+}
+
+func TestOIDCAuthorizationCodeFlowWithPKCE(t *testing.T) {
+	idp := newTestIdP(t)
+	client := idp.client()
+
+	verifier, challenge, err := GeneratePKCE()
+	if err != nil {
+		t.Fatalf("PKCE üretilemedi: %v", err)
+	}
+	authorizationURL, err := OIDCAuthorizationURL(client, "state-1", "nonce-1", challenge)
+	if err != nil {
+		t.Fatalf("giriş adresi oluşturulamadı: %v", err)
+	}
+	parsed, _ := url.Parse(authorizationURL)
+	query := parsed.Query()
+	if query.Get("code_challenge") != challenge || query.Get("code_challenge_method") != "S256" ||
+		query.Get("state") != "state-1" || query.Get("nonce") != "nonce-1" {
+		t.Fatalf("giriş adresinde PKCE/state/nonce eksik: %s", authorizationURL)
+	}
+
+	code := idp.authorize(t, challenge, "nonce-1")
+	tokens, err := ExchangeOIDCCode(client, code, verifier)
+	if err != nil {
+		t.Fatalf("kod token'a çevrilemedi: %v", err)
+	}
+	claims, err := VerifyOIDCIDToken(client, tokens.IDToken, "nonce-1")
+	if err != nil {
+		t.Fatalf("ID token doğrulanamadı: %v", err)
+	}
+	if claims["email"] != "hekim@hastane.example" {
+		t.Fatalf("beklenmeyen claim'ler: %v", claims)
+	}
+
+	// Kod tek kullanımlık
+	if _, err := ExchangeOIDCCode(client, code, verifier); !errors.Is(err, ErrOIDCTokenRequest) {
+		t.Fatalf("ikinci kez kullanılan kod için ErrOIDCTokenRequest beklenirdi, gelen: %v", err)
+	}
+}
+
+func TestOIDCRejectsWrongPKCEVerifier(t *testing.T) {
+	idp := newTestIdP(t)
+	client := idp.client()
+
+	verifier, challenge, err := GeneratePKCE()
+	if err != nil {
+		t.Fatalf("PKCE üretilemedi: %v", err)
+	}
+	otherVerifier, _, err := GeneratePKCE()
+	if err != nil {
+		t.Fatalf("PKCE üretilemedi: %v", err)
+	}
+
+	for name, candidate := range map[string]string{"eksik verifier": "", "yanlış verifier": otherVerifier, "challenge verifier yerine": challenge} {
+		t.Run(name, func(t *testing.T) {
+			code := idp.authorize(t, challenge, "nonce")
+			if _, err := ExchangeOIDCCode(client, code, candidate); !errors.Is(err, ErrOIDCTokenRequest) {
+				t.Fatalf("ErrOIDCTokenRequest beklenirdi, gelen: %v", err)
+			}
+		})
+	}
+
+	code := idp.authorize(t, challenge, "nonce")
+	if _, err := ExchangeOIDCCode(client, code, verifier); err != nil {
+		t.Fatalf("doğru verifier reddedildi: %v", err)
+	}
+}
+
+func TestVerifyOIDCIDTokenRejectsInvalidTokens(t *testing.T) {
+	idp := newTestIdP(t)
+	client := idp.client()
+	otherKey := newTestRSAKey(t)
+
+	tests := []struct {
+		name   string
+		token  func() string
+		nonce  string
+		client OIDCClient
+	}{
+		{"geçersiz imza", func() string { return idp.sign(idp.claims("n"), otherKey, idp.kid) }, "n", client},
+		{"bilinmeyen kid", func() string { return idp.sign(idp.claims("n"), otherKey, "idp-unknown") }, "n", client},
+		{"yanlış issuer", func() string {
+			claims := idp.claims("n")
+			claims["iss"] = "https://evil.example.com"
+			return idp.sign(claims, idp.key, idp.kid)
+		}, "n", client},
+		{"yanlış audience", func() string {
+			claims := idp.claims("n")
+			claims["aud"] = "another-client"
+			return idp.sign(claims, idp.key, idp.kid)
+		}, "n", client},
+		{"süresi dolmuş", func() string {
+			claims := idp.claims("n")
+			claims["exp"] = time.Now().Add(-OIDC_CLOCK_SKEW - time.Minute).Unix()
+			return idp.sign(claims, idp.key, idp.kid)
+		}, "n", client},
+		{"exp yok", func() string {
+			claims := idp.claims("n")
+			delete(claims, "exp")
+			return idp.sign(claims, idp.key, idp.kid)
+		}, "n", client},
+		{"gelecekte üretilmiş", func() string {
+			claims := idp.claims("n")
+			claims["iat"] = time.Now().Add(OIDC_CLOCK_SKEW + time.Hour).Unix()
+			return idp.sign(claims, idp.key, idp.kid)
+		}, "n", client},
+		{"nonce eşleşmiyor", func() string { return idp.sign(idp.claims("n"), idp.key, idp.kid) }, "başka-nonce", client},
+		{"nonce yok", func() string {
+			claims := idp.claims("n")
+			delete(claims, "nonce")
+			return idp.sign(claims, idp.key, idp.kid)
+		}, "n", client},
+		{"alg none", func() string {
+			token := jwt.NewWithClaims(jwt.SigningMethodNone, idp.claims("n"))
+			token.Header["kid"] = idp.kid
+			signed, _ := token.SignedString(jwt.UnsafeAllowNoneSignatureType)
+			return signed
+		}, "n", client},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := VerifyOIDCIDToken(tt.client, tt.token(), tt.nonce); !errors.Is(err, ErrOIDCInvalidToken) {
+				t.Fatalf("ErrOIDCInvalidToken beklenirdi, gelen: %v", err)
+			}
+		})
+	}
+}
+
+// IdP anahtarını değiştirdiğinde bilinmeyen kid JWKS'i bir kez yeniler, yeni anahtarla imzalı token kabul edilir
+func TestVerifyOIDCIDTokenRefreshesKeysOnRotation(t *testing.T) {
+	idp := newTestIdP(t)
+	client := idp.client()
+
+	if _, err := VerifyOIDCIDToken(client, idp.sign(idp.claims("n"), idp.key, idp.kid), "n"); err != nil {
+		t.Fatalf("ID token doğrulanamadı: %v", err)
+	}
+
+	rotated := newTestRSAKey(t)
+	idp.mu.Lock()
+	idp.key, idp.kid = rotated, "idp-2"
+	hits := idp.jwksHits
+	idp.mu.Unlock()
+
+	if _, err := VerifyOIDCIDToken(client, idp.sign(idp.claims("n"), rotated, "idp-2"), "n"); err != nil {
+		t.Fatalf("rotasyon sonrası ID token doğrulanamadı: %v", err)
+	}
+
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	if idp.jwksHits != hits+1 {
+		t.Fatalf("JWKS bir kez yenilenmeliydi: %d -> %d", hits, idp.jwksHits)
+	}
+}
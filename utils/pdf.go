@@ -0,0 +1,318 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// PDFTableWriter - Başlıklı, sayfalara bölünmüş tablo raporunu doğrudan çıktıya yazan minimal PDF üretici
+// Her sayfa dolduğunda çıktıya yazılır; bellekte sadece o anki sayfa tutulur.
+// Gömülü font kullanılmaz: Helvetica, Türkçe harfler (ğ, ı, İ, ş) eklenmiş Windows-1254 kodlamasıyla kullanılır.
+
+const (
+	pdfPageWidth  = 841.89 // A4 yatay (pt)
+	pdfPageHeight = 595.28
+	pdfMargin     = 36.0
+
+	pdfTitleSize  = 14.0
+	pdfTextSize   = 9.0
+	pdfCellSize   = 8.0
+	pdfRowHeight  = 14.0
+	pdfCellMargin = 3.0
+)
+
+// PDFColumn tablo sütunu - genişlikler sayfa genişliğine oranlanır
+type PDFColumn struct {
+	Title string
+	Width float64
+}
+
+// PDFTableOptions rapor başlığı ve sütunları
+type PDFTableOptions struct {
+	Title       string   // Her sayfanın üstündeki kalın başlık (ör. hastane adı)
+	HeaderLines []string // Başlığın altındaki bilgi satırları (adres, telefon...)
+	Subtitle    string   // Rapor adı (ör. "Personel Listesi")
+	Columns     []PDFColumn
+}
+
+type PDFTableWriter struct {
+	out     *pdfCountingWriter
+	options PDFTableOptions
+	widths  []float64 // Sütun genişlikleri (pt)
+	created time.Time
+
+	offsets []int64 // Nesne numarası → dosyadaki konumu (0: kullanılmıyor)
+	pages   []int   // Sayfa nesne numaraları
+
+	page    *bytes.Buffer // Yazılmakta olan sayfanın içerik akışı
+	cursorY float64
+	rows    int // Sayfadaki satır sayısı (zebra deseni için)
+}
+
+type pdfCountingWriter struct {
+	w *bufio.Writer
+	n int64
+}
+
+func (c *pdfCountingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// Nesne numaraları: 1 katalog, 2 sayfa ağacı, 3-4 fontlar; sayfalar 5'ten başlar
+const (
+	pdfCatalogObject   = 1
+	pdfPagesObject     = 2
+	pdfFontObject      = 3
+	pdfBoldFontObject  = 4
+	pdfFirstPageObject = 5
+)
+
+// Windows-1254'te WinAnsi'den farklı olan Türkçe harfler
+const pdfTurkishDifferences = "[208 /Gbreve 221 /Idotaccent 222 /Scedilla 240 /gbreve 253 /dotlessi 254 /scedilla]"
+
+var pdfTextEncoder = encoding.ReplaceUnsupported(charmap.Windows1254.NewEncoder())
+
+// NewPDFTableWriter PDF başlığını ve font nesnelerini yazar
+func NewPDFTableWriter(w io.Writer, options PDFTableOptions) (*PDFTableWriter, error) {
+	p := &PDFTableWriter{
+		out:     &pdfCountingWriter{w: bufio.NewWriter(w)},
+		options: options,
+		created: time.Now(),
+		offsets: make([]int64, pdfFirstPageObject),
+	}
+
+	total := 0.0
+	for _, column := range options.Columns {
+		total += column.Width
+	}
+	available := pdfPageWidth - 2*pdfMargin
+	for _, column := range options.Columns {
+		p.widths = append(p.widths, column.Width/total*available)
+	}
+
+	if _, err := io.WriteString(p.out, "%PDF-1.4\n%\xe2\xe3\xcf\xd3\n"); err != nil {
+		return nil, err
+	}
+	for _, font := range []struct {
+		object int
+		name   string
+	}{{pdfFontObject, "Helvetica"}, {pdfBoldFontObject, "Helvetica-Bold"}} {
+		p.writeObject(font.object, fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding << /Type /Encoding /BaseEncoding /WinAnsiEncoding /Differences %s >> >>",
+			font.name, pdfTurkishDifferences))
+	}
+	return p, nil
+}
+
+// WriteRow tabloya satır ekler; sayfa dolduysa sayfayı yazıp yenisini açar
+func (p *PDFTableWriter) WriteRow(cells []string) error {
+	if p.page == nil || p.cursorY-pdfRowHeight < pdfMargin+pdfRowHeight {
+		if err := p.flushPage(); err != nil {
+			return err
+		}
+		p.startPage()
+	}
+
+	if p.rows%2 == 1 {
+		fmt.Fprintf(p.page, "0.95 g %.2f %.2f %.2f %.2f re f 0 g\n", pdfMargin, p.cursorY-pdfRowHeight, pdfPageWidth-2*pdfMargin, pdfRowHeight)
+	}
+	p.writeCells(cells, "F1", pdfCellSize)
+	p.rows++
+	return nil
+}
+
+// Close son sayfayı, sayfa ağacını, kataloğu ve çapraz referans tablosunu yazar
+// Hiç satır yoksa başlıklı boş bir sayfa üretilir
+func (p *PDFTableWriter) Close() error {
+	if p.page == nil {
+		p.startPage()
+		p.text(pdfMargin, p.cursorY-pdfRowHeight+4, "F1", pdfTextSize, "Kayıt bulunamadı")
+	}
+	if err := p.flushPage(); err != nil {
+		return err
+	}
+
+	kids := make([]string, len(p.pages))
+	for i, page := range p.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", page)
+	}
+	p.writeObject(pdfPagesObject, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(p.pages)))
+	p.writeObject(pdfCatalogObject, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pdfPagesObject))
+
+	xrefOffset := p.out.n
+	fmt.Fprintf(p.out, "xref\n0 %d\n0000000000 65535 f \n", len(p.offsets))
+	for _, offset := range p.offsets[1:] {
+		fmt.Fprintf(p.out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(p.out, "trailer\n<< /Size %d /Root %d 0 R /Info << /Producer (hospital-platform) /CreationDate (D:%s) >> >>\nstartxref\n%d\n%%%%EOF\n",
+		len(p.offsets), pdfCatalogObject, p.created.Format("20060102150405"), xrefOffset)
+	return p.out.w.Flush()
+}
+
+// startPage yeni sayfa açar: hastane başlığı, rapor bilgisi ve tablo başlığı
+func (p *PDFTableWriter) startPage() {
+	p.page = &bytes.Buffer{}
+	p.rows = 0
+	y := pdfPageHeight - pdfMargin - pdfTitleSize
+
+	p.text(pdfMargin, y, "F2", pdfTitleSize, p.options.Title)
+	y -= pdfTitleSize
+	for _, line := range p.options.HeaderLines {
+		if line == "" {
+			continue
+		}
+		p.text(pdfMargin, y, "F1", pdfTextSize, line)
+		y -= pdfTextSize + 3
+	}
+
+	y -= 4
+	p.text(pdfMargin, y, "F2", pdfTextSize+1, p.options.Subtitle)
+	generated := "Oluşturulma: " + p.created.Format("02.01.2006 15:04")
+	p.text(pdfPageWidth-pdfMargin-pdfTextWidth(generated, pdfTextSize, false), y, "F1", pdfTextSize, generated)
+	y -= 6
+	fmt.Fprintf(p.page, "0.5 w %.2f %.2f m %.2f %.2f l S\n", pdfMargin, y, pdfPageWidth-pdfMargin, y)
+
+	p.cursorY = y - 6
+	fmt.Fprintf(p.page, "0.85 g %.2f %.2f %.2f %.2f re f 0 g\n", pdfMargin, p.cursorY-pdfRowHeight, pdfPageWidth-2*pdfMargin, pdfRowHeight)
+	titles := make([]string, len(p.options.Columns))
+	for i, column := range p.options.Columns {
+		titles[i] = column.Title
+	}
+	p.writeCells(titles, "F2", pdfCellSize)
+
+	footer := fmt.Sprintf("Sayfa %d", len(p.pages)+1)
+	p.text(pdfPageWidth-pdfMargin-pdfTextWidth(footer, pdfCellSize, false), pdfMargin-14, "F1", pdfCellSize, footer)
+}
+
+// writeCells satırdaki hücreleri sütun genişliğine sığacak şekilde yazar ve imleci bir satır aşağı alır
+func (p *PDFTableWriter) writeCells(cells []string, font string, size float64) {
+	x := pdfMargin
+	baseline := p.cursorY - pdfRowHeight + 4
+	for i, width := range p.widths {
+		if i < len(cells) {
+			p.text(x+pdfCellMargin, baseline, font, size, pdfFitText(cells[i], width-2*pdfCellMargin, size, font == "F2"))
+		}
+		x += width
+	}
+	p.cursorY -= pdfRowHeight
+}
+
+// flushPage sayfanın sıkıştırılmış içerik akışını ve sayfa nesnesini çıktıya yazar
+func (p *PDFTableWriter) flushPage() error {
+	if p.page == nil {
+		return nil
+	}
+
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	zw.Write(p.page.Bytes())
+	zw.Close()
+
+	pageObject := len(p.offsets)
+	contentObject := pageObject + 1
+	p.offsets = append(p.offsets, 0, 0)
+	p.pages = append(p.pages, pageObject)
+
+	p.writeObject(pageObject, fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 %d 0 R /F2 %d 0 R >> >> /Contents %d 0 R >>",
+		pdfPagesObject, pdfPageWidth, pdfPageHeight, pdfFontObject, pdfBoldFontObject, contentObject))
+
+	p.offsets[contentObject] = p.out.n
+	fmt.Fprintf(p.out, "%d 0 obj\n<< /Length %d /Filter /FlateDecode >>\nstream\n", contentObject, compressed.Len())
+	p.out.Write(compressed.Bytes())
+	io.WriteString(p.out, "\nendstream\nendobj\n")
+
+	p.page = nil
+	// Sayfa istemciye hemen gönderilir
+	return p.out.w.Flush()
+}
+
+// writeObject nesneyi yazar ve konumunu çapraz referans tablosu için kaydeder
+func (p *PDFTableWriter) writeObject(object int, body string) {
+	p.offsets[object] = p.out.n
+	fmt.Fprintf(p.out, "%d 0 obj\n%s\nendobj\n", object, body)
+}
+
+// text sayfaya tek satır metin yazar
+func (p *PDFTableWriter) text(x, y float64, font string, size float64, text string) {
+	fmt.Fprintf(p.page, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, pdfEscape(text))
+}
+
+// pdfEscape metni Windows-1254'e çevirir ve PDF metin dizisi için kaçışlar
+// Kodlamada olmayan karakterler "?" olur
+func pdfEscape(text string) string {
+	encoded, err := pdfTextEncoder.String(text)
+	if err != nil {
+		encoded = "?"
+	}
+	var b strings.Builder
+	for i := 0; i < len(encoded); i++ {
+		switch c := encoded[i]; c {
+		case '(', ')', '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case '\n', '\r', '\t':
+			b.WriteByte(' ')
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// pdfFitText metni verilen genişliğe sığacak şekilde kısaltır ("..." ile)
+func pdfFitText(text string, width, size float64, bold bool) string {
+	if pdfTextWidth(text, size, bold) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && pdfTextWidth(string(runes)+"...", size, bold) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
+
+// pdfTextWidth metnin Helvetica ile genişliğini (pt) hesaplar
+// Kalın font için genişlikler yaklaşık %8 artırılır (kısaltma güvenli tarafta kalır)
+func pdfTextWidth(text string, size float64, bold bool) float64 {
+	units := 0
+	for _, r := range text {
+		switch {
+		case r >= 32 && r < 127:
+			units += helveticaWidths[r-32]
+		case strings.ContainsRune("ıİ", r):
+			units += 278
+		case strings.ContainsRune("ÇĞÖÜ", r):
+			units += 778
+		case strings.ContainsRune("Ş", r):
+			units += 667
+		case strings.ContainsRune("çş", r):
+			units += 500
+		default:
+			units += 556
+		}
+	}
+	width := float64(units) * size / 1000
+	if bold {
+		width *= 1.08
+	}
+	return width
+}
+
+// Helvetica karakter genişlikleri (1/1000 em), ASCII 32-126
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278, // boşluk - /
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556, // 0 - ?
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778, // @ - O
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556, // P - _
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556, // ` - o
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584, // p - ~
+}
@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"hospital-platform/database"
+	"hospital-platform/model"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	// ErrHospitalSuspended askıya alınmış hastanenin kullanıcıları ve API anahtarları için döner
+	ErrHospitalSuspended = errors.New("Hastane hesabı askıya alınmış, platform yönetimiyle iletişime geçin")
+	// ErrHospitalNotApproved kayıt başvurusu onaylanmamış hastanenin başvuru dışındaki istekleri için döner
+	ErrHospitalNotApproved = errors.New("Hastane başvurusu henüz onaylanmadı, sadece başvuru bilgileri görüntülenip düzenlenebilir")
+)
+
+// PlatformAuthMiddleware - Platform operatörü token'ını doğrular
+// Hastane token'ları ve API anahtarları burada geçersizdir; operatör her istekte aktif olmalıdır
+func PlatformAuthMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			authHeader := c.Request().Header.Get("Authorization")
+			if !strings.HasPrefix(authHeader, "Bearer ") {
+				return c.JSON(http.StatusUnauthorized, echo.Map{
+					"error":   "Yetkilendirme hatası",
+					"message": "Authorization header eksik veya geçersiz",
+				})
+			}
+
+			claims, err := ValidatePlatformToken(authHeader[7:])
+			if err != nil {
+				fmt.Printf("❌ PLATFORM AUTH: Token reddedildi: %v\n", err)
+				return c.JSON(http.StatusUnauthorized, echo.Map{
+					"error":   "Yetkilendirme hatası",
+					"message": "Geçersiz veya süresi dolmuş platform token'ı",
+				})
+			}
+
+			// Pasif edilen operatörün açık token'ı anında geçersiz olsun
+			var admin model.PlatformAdmin
+			if err := database.DB.Select("id", "is_active").First(&admin, claims.UserID).Error; err != nil || !admin.IsActive {
+				fmt.Printf("❌ PLATFORM AUTH: Operatör bulunamadı veya pasif: %d\n", claims.UserID)
+				return c.JSON(http.StatusUnauthorized, echo.Map{
+					"error":   "Yetkilendirme hatası",
+					"message": "Platform hesabı aktif değil",
+				})
+			}
+
+			c.Set("platform_admin_id", claims.UserID)
+			c.Set("platform_admin_email", claims.Email)
+			c.Set("platform_mfa", claims.MFA)
+
+			return next(c)
+		}
+	}
+}
+
+// RequirePlatformMFA - TOTP ile doğrulanmamış operatör token'larını reddeder
+// Sadece TOTP kaydı endpoint'leri bu middleware olmadan çalışır
+func RequirePlatformMFA() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if mfa, _ := c.Get("platform_mfa").(bool); !mfa {
+				return c.JSON(http.StatusForbidden, echo.Map{
+					"error":                   "Yetkisiz erişim",
+					"message":                 "Platform işlemleri için iki adımlı doğrulama (TOTP) gerekli",
+					"mfa_enrollment_required": true,
+				})
+			}
+			return next(c)
+		}
+	}
+}
+
+// GetPlatformAdminFromContext - Context'ten operatör ID ve e-postasını çıkarır
+func GetPlatformAdminFromContext(c echo.Context) (uint, string, bool) {
+	adminID, ok := c.Get("platform_admin_id").(uint)
+	if !ok || adminID == 0 {
+		return 0, "", false
+	}
+	email, _ := c.Get("platform_admin_email").(string)
+	return adminID, email, true
+}
+
+// GetHospitalStatus - Hastanenin hesap durumunu önbellekten, yoksa veritabanından okur
+func GetHospitalStatus(hospitalID uint) (string, error) {
+	status, found, err := database.GetHospitalStatus(hospitalID)
+	if err != nil {
+		return "", err
+	}
+	if found {
+		return status, nil
+	}
+
+	if err := database.DB.Model(&model.Hospital{}).
+		Select("status").
+		Where("id = ?", hospitalID).
+		Scan(&status).Error; err != nil {
+		return "", err
+	}
+	if status == "" {
+		status = model.HospitalStatusActive
+	}
+
+	if err := database.SetHospitalStatus(hospitalID, status); err != nil {
+		fmt.Printf("⚠️ Hastane durumu önbelleğe yazılamadı: %v\n", err)
+	}
+	return status, nil
+}
+
+// CheckHospitalActive - Hastane askıya alınmışsa ErrHospitalSuspended döner
+// Onay bekleyen ve reddedilen hastanelerde token verilir; erişimi middleware başvuru rotalarıyla sınırlar
+func CheckHospitalActive(hospitalID uint) error {
+	status, err := GetHospitalStatus(hospitalID)
+	if err != nil {
+		return fmt.Errorf("hastane durumu kontrol edilemedi: %v", err)
+	}
+	if status == model.HospitalStatusSuspended {
+		return ErrHospitalSuspended
+	}
+	return nil
+}
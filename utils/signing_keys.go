@@ -0,0 +1,457 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hospital-platform/config"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// JWT imza algoritmaları
+const (
+	SIGNING_ALG_RS256 = "RS256"
+	SIGNING_ALG_EDDSA = "EdDSA"
+)
+
+const (
+	privateKeySuffix = ".pem"     // İmzalama + doğrulama anahtarı (PKCS#8 private key)
+	publicKeySuffix  = ".pub.pem" // Sadece doğrulama anahtarı (PKIX public key) - başka servis/instance'ın anahtarı
+	rsaKeyBits       = 2048
+
+	keyCreatedHeader = "Created"         // PEM header'ı - anahtarın oluşturulma zamanı (RFC 3339, UTC)
+	kidTimeLayout    = "20060102T150405" // kid'in başındaki oluşturulma zamanı (header'sız eski dosyalar için)
+)
+
+var (
+	ErrUnknownKeyID       = errors.New("bilinmeyen imza anahtarı (kid)")
+	ErrNoActiveSigningKey = errors.New("aktif imza anahtarı yok")
+)
+
+// signingKey - Diskten yüklenmiş tek bir JWT anahtarı
+type signingKey struct {
+	KID       string
+	Method    jwt.SigningMethod
+	Private   crypto.Signer // nil ise anahtar sadece doğrulama için kullanılır
+	Public    crypto.PublicKey
+	CreatedAt time.Time
+}
+
+// keyRing - Aktif imza anahtarı ve doğrulamada kabul edilen tüm anahtarlar
+// Anahtarlar JWT_KEYS_DIR altındaki dosyalardan okunur, kid dosya adıdır
+type keyRing struct {
+	mu        sync.RWMutex
+	keys      map[string]*signingKey
+	activeKID string
+}
+
+var signingKeys = &keyRing{keys: map[string]*signingKey{}}
+
+// getKeysDir anahtar dizinini döndürür
+func getKeysDir() string {
+	return config.GetEnv("JWT_KEYS_DIR", "keys")
+}
+
+// getSigningAlgorithm yeni üretilecek anahtarların algoritmasını döndürür
+func getSigningAlgorithm() string {
+	return config.GetEnv("JWT_SIGNING_ALG", SIGNING_ALG_RS256)
+}
+
+// getKeyRotationInterval aktif anahtarın ne kadar süre sonra yenisiyle değiştirileceğini döndürür (0: rotasyon kapalı)
+func getKeyRotationInterval() time.Duration {
+	return config.GetEnvDuration("JWT_KEY_ROTATION_INTERVAL", 30*24*time.Hour)
+}
+
+// getKeyRetention emekliye ayrılan anahtarın doğrulama için ne kadar süre daha kabul edileceğini döndürür
+// Access token ömründen kısa olmamalı, aksi halde rotasyon anında verilmiş token'lar reddedilir
+func getKeyRetention() time.Duration {
+	retention := config.GetEnvDuration("JWT_KEY_RETENTION", 24*time.Hour)
+	if minimum := GetAccessTokenTTL() + PASSWORD_CHANGE_TOKEN_TTL + MFA_PENDING_TOKEN_TTL; retention < minimum {
+		return minimum
+	}
+	return retention
+}
+
+// ==================== BAŞLATMA VE ROTASYON ====================
+
+// InitSigningKeys anahtar dizinini yükler; imzalama anahtarı yoksa yenisini üretir
+// Uygulama başlarken çağrılmalıdır
+func InitSigningKeys() error {
+	if err := os.MkdirAll(getKeysDir(), 0700); err != nil {
+		return fmt.Errorf("anahtar dizini oluşturulamadı: %v", err)
+	}
+
+	if err := signingKeys.load(); err != nil {
+		return err
+	}
+
+	if signingKeys.activeKID == "" {
+		fmt.Println("🔑 JWT: İmza anahtarı bulunamadı, yenisi üretiliyor")
+		return RotateSigningKey()
+	}
+
+	fmt.Printf("🔑 JWT: Aktif imza anahtarı: %s (%d doğrulama anahtarı)\n", signingKeys.activeKID, len(signingKeys.keys))
+	return nil
+}
+
+// RotateSigningKey yeni bir anahtar üretip diske yazar ve aktif anahtar yapar
+// Eski anahtarlar retention süresi boyunca doğrulama için JWKS'te yayınlanmaya devam eder
+func RotateSigningKey() error {
+	kid, err := generateKeyFile(getKeysDir(), getSigningAlgorithm())
+	if err != nil {
+		return err
+	}
+
+	if err := signingKeys.load(); err != nil {
+		return err
+	}
+
+	fmt.Printf("🔑 JWT: Yeni imza anahtarı üretildi: %s\n", kid)
+	return nil
+}
+
+// StartKeyRotation anahtar dizinini periyodik olarak yeniden okur ve süresi dolan aktif anahtarı değiştirir
+// Paylaşılan dizini kullanan diğer instance'ların ürettiği anahtarlar da bu sayede yüklenir
+func StartKeyRotation() {
+	interval := config.GetEnvDuration("JWT_KEY_RELOAD_INTERVAL", 5*time.Minute)
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if err := signingKeys.load(); err != nil {
+				fmt.Printf("❌ JWT: Anahtarlar yeniden yüklenemedi: %v\n", err)
+				continue
+			}
+
+			rotation := getKeyRotationInterval()
+			if rotation > 0 && signingKeys.activeAge() >= rotation {
+				if err := RotateSigningKey(); err != nil {
+					fmt.Printf("❌ JWT: Anahtar rotasyonu başarısız: %v\n", err)
+				}
+			}
+		}
+	}()
+}
+
+// ==================== ANAHTAR HALKASI ====================
+
+// load dizindeki anahtarları okur ve aktif anahtarı belirler
+// JWT_ACTIVE_KID tanımlıysa o anahtar, değilse en yeni imzalama anahtarı aktiftir
+func (r *keyRing) load() error {
+	entries, err := os.ReadDir(getKeysDir())
+	if err != nil {
+		return fmt.Errorf("anahtar dizini okunamadı: %v", err)
+	}
+
+	rotation := getKeyRotationInterval()
+	retention := getKeyRetention()
+	keys := map[string]*signingKey{}
+	var signers []*signingKey
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), privateKeySuffix) {
+			continue
+		}
+
+		key, err := readKeyFile(filepath.Join(getKeysDir(), entry.Name()))
+		if err != nil {
+			return fmt.Errorf("%s okunamadı: %v", entry.Name(), err)
+		}
+
+		// Rotasyon + retention süresini aşmış anahtarlar artık kabul edilmez ve dosyası silinir
+		// JWT_ACTIVE_KID ile sabitlenmiş anahtar silinmez, aşağıda yapılandırma hatası olarak bildirilir
+		if rotation > 0 && time.Since(key.CreatedAt) > rotation+retention {
+			if key.KID != config.GetEnv("JWT_ACTIVE_KID", "") {
+				removeExpiredKeyFile(filepath.Join(getKeysDir(), entry.Name()))
+			}
+			continue
+		}
+
+		// Aynı kid için hem private hem public dosya varsa private olan kullanılır
+		if existing, ok := keys[key.KID]; ok && existing.Private != nil {
+			continue
+		}
+
+		keys[key.KID] = key
+		if key.Private != nil {
+			signers = append(signers, key)
+		}
+	}
+
+	activeKID := config.GetEnv("JWT_ACTIVE_KID", "")
+	if activeKID != "" {
+		if key, ok := keys[activeKID]; !ok || key.Private == nil {
+			return fmt.Errorf("JWT_ACTIVE_KID (%s) için imzalama anahtarı bulunamadı", activeKID)
+		}
+	} else if len(signers) > 0 {
+		sort.Slice(signers, func(i, j int) bool {
+			if signers[i].CreatedAt.Equal(signers[j].CreatedAt) {
+				return signers[i].KID > signers[j].KID
+			}
+			return signers[i].CreatedAt.After(signers[j].CreatedAt)
+		})
+		activeKID = signers[0].KID
+	}
+
+	r.mu.Lock()
+	r.keys = keys
+	r.activeKID = activeKID
+	r.mu.Unlock()
+	return nil
+}
+
+// active imzalama için kullanılacak anahtarı döndürür
+func (r *keyRing) active() (*signingKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key, ok := r.keys[r.activeKID]
+	if !ok {
+		return nil, ErrNoActiveSigningKey
+	}
+	return key, nil
+}
+
+// activeAge aktif anahtarın yaşını döndürür
+func (r *keyRing) activeAge() time.Duration {
+	key, err := r.active()
+	if err != nil {
+		return 0
+	}
+	return time.Since(key.CreatedAt)
+}
+
+// get kid ile doğrulama anahtarını bulur
+func (r *keyRing) get(kid string) (*signingKey, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key, ok := r.keys[kid]
+	return key, ok
+}
+
+// ==================== İMZALAMA / DOĞRULAMA ====================
+
+// signToken claims'i aktif anahtarla imzalar ve header'a kid ekler
+func signToken(claims jwt.Claims) (string, error) {
+	key, err := signingKeys.active()
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.KID
+	return token.SignedString(key.Private)
+}
+
+// verificationKey token header'ındaki kid'e göre doğrulama anahtarını döndürür
+// Algoritma anahtarın tipiyle eşleşmek zorunda (alg karışıklığı saldırılarına karşı)
+func verificationKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := signingKeys.get(kid)
+	if !ok {
+		return nil, ErrUnknownKeyID
+	}
+
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, jwt.ErrSignatureInvalid
+	}
+	return key.Public, nil
+}
+
+// ==================== JWKS ====================
+
+// JWK - RFC 7517 formatında tek bir public key
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`   // RSA modulus
+	E   string `json:"e,omitempty"`   // RSA exponent
+	Crv string `json:"crv,omitempty"` // OKP/EC eğrisi (Ed25519, P-256)
+	X   string `json:"x,omitempty"`   // OKP public key / EC x koordinatı
+	Y   string `json:"y,omitempty"`   // EC y koordinatı (harici IdP anahtarları için)
+}
+
+// JWKSet - /.well-known/jwks.json yanıtı
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// GetJWKS doğrulamada kabul edilen tüm anahtarların public kısmını döndürür
+func GetJWKS() JWKSet {
+	signingKeys.mu.RLock()
+	defer signingKeys.mu.RUnlock()
+
+	set := JWKSet{Keys: []JWK{}}
+	for _, key := range signingKeys.keys {
+		jwk := JWK{Kid: key.KID, Use: "sig", Alg: key.Method.Alg()}
+
+		switch public := key.Public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		default:
+			continue
+		}
+
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].Kid < set.Keys[j].Kid })
+	return set
+}
+
+// ==================== DOSYA İŞLEMLERİ ====================
+
+// readKeyFile PEM dosyasını okur - ".pub.pem" dosyaları sadece doğrulama için yüklenir
+// Oluşturulma zamanı dosyanın değiştirilme zamanından değil PEM header'ından (yoksa kid'den) okunur;
+// kopyalama veya yedekten dönme anahtarın yaşını sıfırlamaz
+func readKeyFile(path string) (*signingKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("PEM formatı geçersiz")
+	}
+
+	name := filepath.Base(path)
+	key := &signingKey{}
+
+	if strings.HasSuffix(name, publicKeySuffix) {
+		key.KID = strings.TrimSuffix(name, publicKeySuffix)
+		key.Public, err = x509.ParsePKIXPublicKey(block.Bytes)
+	} else {
+		key.KID = strings.TrimSuffix(name, privateKeySuffix)
+		var parsed interface{}
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err == nil {
+			signer, ok := parsed.(crypto.Signer)
+			if !ok {
+				return nil, errors.New("desteklenmeyen private key tipi")
+			}
+			key.Private = signer
+			key.Public = signer.Public()
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key.CreatedAt, err = keyCreatedAt(key.KID, block)
+	if err != nil {
+		return nil, err
+	}
+
+	switch key.Public.(type) {
+	case *rsa.PublicKey:
+		key.Method = jwt.SigningMethodRS256
+	case ed25519.PublicKey:
+		key.Method = jwt.SigningMethodEdDSA
+	default:
+		return nil, errors.New("desteklenmeyen anahtar tipi (RSA veya Ed25519 olmalı)")
+	}
+
+	return key, nil
+}
+
+// generateKeyFile verilen algoritma için yeni bir private key üretip dizine yazar ve kid'ini döndürür
+func generateKeyFile(dir, alg string) (string, error) {
+	var private crypto.Signer
+	var err error
+
+	switch alg {
+	case SIGNING_ALG_RS256:
+		private, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case SIGNING_ALG_EDDSA:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return "", fmt.Errorf("desteklenmeyen JWT_SIGNING_ALG: %s (RS256 veya EdDSA olmalı)", alg)
+	}
+	if err != nil {
+		return "", fmt.Errorf("anahtar üretilemedi: %v", err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return "", fmt.Errorf("anahtar kodlanamadı: %v", err)
+	}
+
+	suffix, err := GenerateReadableCode(3)
+	if err != nil {
+		return "", err
+	}
+	now := time.Now().UTC()
+	kid := now.Format(kidTimeLayout) + "-" + suffix
+
+	data := pem.EncodeToMemory(&pem.Block{
+		Type:    "PRIVATE KEY",
+		Headers: map[string]string{keyCreatedHeader: now.Format(time.RFC3339)},
+		Bytes:   der,
+	})
+	if err := os.WriteFile(filepath.Join(dir, kid+privateKeySuffix), data, 0600); err != nil {
+		return "", fmt.Errorf("anahtar dosyası yazılamadı: %v", err)
+	}
+
+	return kid, nil
+}
+
+// keyCreatedAt anahtarın oluşturulma zamanını PEM header'ından, yoksa kid'in başındaki zamandan okur
+// Dışarıdan eklenen anahtarın PEM'ine "Created: <RFC 3339>" header'ı eklenmelidir
+func keyCreatedAt(kid string, block *pem.Block) (time.Time, error) {
+	if value, ok := block.Headers[keyCreatedHeader]; ok {
+		createdAt, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%s header'ı geçersiz: %v", keyCreatedHeader, err)
+		}
+		return createdAt, nil
+	}
+
+	if prefix, _, found := strings.Cut(kid, "-"); found {
+		if createdAt, err := time.Parse(kidTimeLayout, prefix); err == nil {
+			return createdAt, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("anahtarın oluşturulma zamanı belirlenemedi, PEM'e %s header'ı eklenmeli", keyCreatedHeader)
+}
+
+// removeExpiredKeyFile doğrulama süresi geçmiş anahtar dosyasını siler
+// Dizini paylaşan başka bir instance dosyayı önce silmiş olabilir, bu hata sayılmaz
+func removeExpiredKeyFile(path string) {
+	if err := os.Remove(path); err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			fmt.Printf("⚠️ JWT: Süresi dolan anahtar dosyası silinemedi: %s: %v\n", filepath.Base(path), err)
+		}
+		return
+	}
+	fmt.Printf("🗑️ JWT: Süresi dolan anahtar silindi: %s\n", filepath.Base(path))
+}
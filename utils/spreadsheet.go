@@ -0,0 +1,287 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// CSV ve XLSX tablolarını satır/hücre metnine çeviren okuyucu (toplu içe aktarma için)
+// XLSX için sadece ilk çalışma sayfası okunur; formüllerin son hesaplanan değeri kullanılır.
+
+const (
+	SPREADSHEET_MAX_PART_SIZE = 50 << 20 // XLSX içindeki tek bir XML parçasının açılmış boyut sınırı (zip bombası koruması)
+	SPREADSHEET_MAX_COLUMNS   = 100
+)
+
+var ErrSpreadsheetInvalid = errors.New("dosya okunamadı")
+
+// ReadSpreadsheet dosya içeriğine göre XLSX veya CSV olarak okur ve satırları döndürür
+// Zip imzası taşıyan dosyalar XLSX, diğerleri CSV kabul edilir
+func ReadSpreadsheet(data []byte) ([][]string, error) {
+	if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		return readXLSX(data)
+	}
+	return readCSV(data)
+}
+
+// ==================== CSV ====================
+
+// readCSV UTF-8 (BOM'lu/BOM'suz) veya Türkçe Excel'in kaydettiği Windows-1254 CSV'yi okur
+// Ayraç ilk satıra göre seçilir: Türkçe Excel noktalı virgül kullanır
+func readCSV(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if !utf8.Valid(data) {
+		decoded, err := charmap.Windows1254.NewDecoder().Bytes(data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: karakter kodlaması tanınmadı", ErrSpreadsheetInvalid)
+		}
+		data = decoded
+	}
+
+	firstLine := data
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		firstLine = data[:i]
+	}
+	reader := csv.NewReader(bytes.NewReader(data))
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		reader.Comma = ';'
+	}
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSpreadsheetInvalid, err)
+	}
+	return rows, nil
+}
+
+// ==================== XLSX ====================
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		RelationshipID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+	var b strings.Builder
+	for _, run := range t.Runs {
+		b.WriteString(run.T)
+	}
+	return b.String()
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxText `xml:"si"`
+}
+
+type xlsxSheet struct {
+	Rows []struct {
+		Index int `xml:"r,attr"`
+		Cells []struct {
+			Ref    string   `xml:"r,attr"`
+			Type   string   `xml:"t,attr"`
+			Value  string   `xml:"v"`
+			Inline xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// readXLSX ilk çalışma sayfasını okur; boş satırlar korunur, böylece satır numaraları Excel ile aynı kalır
+func readXLSX(data []byte) ([][]string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: XLSX arşivi açılamadı", ErrSpreadsheetInvalid)
+	}
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	sheetPath, err := xlsxFirstSheetPath(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var sharedStrings xlsxSharedStrings
+	if file, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := decodeXLSXPart(file, &sharedStrings); err != nil {
+			return nil, err
+		}
+	}
+
+	file, ok := files[sheetPath]
+	if !ok {
+		return nil, fmt.Errorf("%w: çalışma sayfası bulunamadı", ErrSpreadsheetInvalid)
+	}
+	var sheet xlsxSheet
+	if err := decodeXLSXPart(file, &sheet); err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	for _, row := range sheet.Rows {
+		rowIndex := row.Index
+		if rowIndex == 0 {
+			rowIndex = len(rows) + 1
+		}
+		if rowIndex < len(rows)+1 {
+			return nil, fmt.Errorf("%w: satırlar sıralı değil", ErrSpreadsheetInvalid)
+		}
+		for len(rows) < rowIndex {
+			rows = append(rows, nil)
+		}
+
+		var cells []string
+		for _, cell := range row.Cells {
+			column := len(cells)
+			if cell.Ref != "" {
+				if column, err = xlsxColumnIndex(cell.Ref); err != nil {
+					return nil, err
+				}
+			}
+			if column >= SPREADSHEET_MAX_COLUMNS {
+				continue
+			}
+			for len(cells) <= column {
+				cells = append(cells, "")
+			}
+
+			switch cell.Type {
+			case "s":
+				index, err := strconv.Atoi(cell.Value)
+				if err != nil || index < 0 || index >= len(sharedStrings.Items) {
+					return nil, fmt.Errorf("%w: paylaşılan metin bulunamadı (%s)", ErrSpreadsheetInvalid, cell.Ref)
+				}
+				cells[column] = sharedStrings.Items[index].String()
+			case "inlineStr":
+				cells[column] = cell.Inline.String()
+			case "b":
+				cells[column] = map[string]string{"1": "TRUE", "0": "FALSE"}[cell.Value]
+			case "e":
+				cells[column] = ""
+			case "", "n":
+				cells[column] = formatXLSXNumber(cell.Value)
+			default: // str (formül sonucu), d (ISO tarih)
+				cells[column] = cell.Value
+			}
+		}
+		rows[rowIndex-1] = cells
+	}
+	return rows, nil
+}
+
+// xlsxFirstSheetPath workbook ilişkilerinden ilk çalışma sayfasının arşiv içindeki yolunu bulur
+func xlsxFirstSheetPath(files map[string]*zip.File) (string, error) {
+	workbookFile, ok := files["xl/workbook.xml"]
+	if !ok {
+		return "", fmt.Errorf("%w: XLSX çalışma kitabı bulunamadı", ErrSpreadsheetInvalid)
+	}
+	var workbook xlsxWorkbook
+	if err := decodeXLSXPart(workbookFile, &workbook); err != nil {
+		return "", err
+	}
+	if len(workbook.Sheets) == 0 {
+		return "", fmt.Errorf("%w: çalışma kitabında sayfa yok", ErrSpreadsheetInvalid)
+	}
+
+	relsFile, ok := files["xl/_rels/workbook.xml.rels"]
+	if !ok {
+		return "xl/worksheets/sheet1.xml", nil
+	}
+	var rels xlsxRelationships
+	if err := decodeXLSXPart(relsFile, &rels); err != nil {
+		return "", err
+	}
+	for _, rel := range rels.Relationships {
+		if rel.ID == workbook.Sheets[0].RelationshipID {
+			if strings.HasPrefix(rel.Target, "/") {
+				return strings.TrimPrefix(rel.Target, "/"), nil
+			}
+			return path.Join("xl", rel.Target), nil
+		}
+	}
+	return "", fmt.Errorf("%w: çalışma sayfası ilişkisi bulunamadı", ErrSpreadsheetInvalid)
+}
+
+// decodeXLSXPart arşivdeki XML parçasını boyut sınırıyla çözer
+func decodeXLSXPart(file *zip.File, target interface{}) error {
+	reader, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("%w: %s açılamadı", ErrSpreadsheetInvalid, file.Name)
+	}
+	defer reader.Close()
+
+	limited := &io.LimitedReader{R: reader, N: SPREADSHEET_MAX_PART_SIZE + 1}
+	if err := xml.NewDecoder(limited).Decode(target); err != nil {
+		if limited.N <= 0 {
+			return fmt.Errorf("%w: %s çok büyük", ErrSpreadsheetInvalid, file.Name)
+		}
+		return fmt.Errorf("%w: %s çözülemedi", ErrSpreadsheetInvalid, file.Name)
+	}
+	return nil
+}
+
+// xlsxColumnIndex "AB12" gibi hücre referansından sıfır tabanlı sütun indeksini çıkarır
+func xlsxColumnIndex(ref string) (int, error) {
+	column := 0
+	letters := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		column = column*26 + int(r-'A'+1)
+		letters++
+		if letters > 3 {
+			return 0, fmt.Errorf("%w: geçersiz hücre referansı (%s)", ErrSpreadsheetInvalid, ref)
+		}
+	}
+	if letters == 0 {
+		return 0, fmt.Errorf("%w: geçersiz hücre referansı (%s)", ErrSpreadsheetInvalid, ref)
+	}
+	return column - 1, nil
+}
+
+// formatXLSXNumber bilimsel gösterimle kaydedilmiş tam sayıları (ör. TC, telefon) düz rakamlara çevirir
+func formatXLSXNumber(value string) string {
+	if !strings.ContainsAny(value, "eE") {
+		return value
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return value
+	}
+	if number == float64(int64(number)) {
+		return strconv.FormatInt(int64(number), 10)
+	}
+	return strconv.FormatFloat(number, 'f', -1, 64)
+}
@@ -0,0 +1,145 @@
+package utils
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// XLSXWriter - Tek sayfalık XLSX dosyasını satır satır doğrudan çıktıya yazar
+// Satırlar bellekte biriktirilmez; büyük dışa aktarmalarda bellek kullanımı sabit kalır.
+// Tüm hücreler metin (inline string) olarak yazılır - TC ve telefon gibi değerler sayıya dönüşmez.
+type XLSXWriter struct {
+	archive *zip.Writer
+	sheet   *bufio.Writer
+	row     int
+	columns int
+}
+
+// XLSXColumn sütun başlığı ve genişliği (karakter cinsinden)
+type XLSXColumn struct {
+	Title string
+	Width float64
+}
+
+const xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/><Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/></Types>`
+
+const xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+
+const xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/></Relationships>`
+
+// Stil 0: normal, stil 1: kalın ve gri zeminli başlık
+const xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts><fills count="3"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill><fill><patternFill patternType="solid"><fgColor rgb="FFD9D9D9"/></patternFill></fill></fills><borders count="1"><border/></borders><cellStyleXfs count="1"><xf/></cellStyleXfs><cellXfs count="2"><xf/><xf fontId="1" fillId="2" applyFont="1" applyFill="1"/></cellXfs></styleSheet>`
+
+// NewXLSXWriter sabit parçaları yazar ve başlık satırıyla sayfayı açar
+func NewXLSXWriter(w io.Writer, sheetName string, columns []XLSXColumn) (*XLSXWriter, error) {
+	archive := zip.NewWriter(w)
+
+	workbook := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="` + xmlEscape(sheetName) + `" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+	for _, part := range []struct{ name, content string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", workbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", xlsxStyles},
+	} {
+		file, err := archive.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(file, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	file, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	x := &XLSXWriter{archive: archive, sheet: bufio.NewWriter(file), columns: len(columns)}
+
+	// Başlık satırı dondurulur, kaydırırken görünür kalır
+	x.sheet.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews><cols>`)
+	for i, column := range columns {
+		fmt.Fprintf(x.sheet, `<col min="%d" max="%d" width="%.1f" customWidth="1"/>`, i+1, i+1, column.Width)
+	}
+	x.sheet.WriteString(`</cols><sheetData>`)
+
+	titles := make([]string, len(columns))
+	for i, column := range columns {
+		titles[i] = column.Title
+	}
+	if err := x.writeRow(titles, 1); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// WriteRow veri satırı ekler
+func (x *XLSXWriter) WriteRow(cells []string) error {
+	return x.writeRow(cells, 0)
+}
+
+func (x *XLSXWriter) writeRow(cells []string, style int) error {
+	x.row++
+	fmt.Fprintf(x.sheet, `<row r="%d">`, x.row)
+	for i, cell := range cells {
+		if cell == "" {
+			continue
+		}
+		fmt.Fprintf(x.sheet, `<c r="%s%d" t="inlineStr"`, xlsxColumnName(i), x.row)
+		if style != 0 {
+			fmt.Fprintf(x.sheet, ` s="%d"`, style)
+		}
+		x.sheet.WriteString(`><is><t xml:space="preserve">`)
+		x.sheet.WriteString(xmlEscape(cell))
+		x.sheet.WriteString(`</t></is></c>`)
+	}
+	_, err := x.sheet.WriteString(`</row>`)
+	return err
+}
+
+// Close sayfayı kapatır ve arşivi tamamlar (otomatik filtre başlık satırına eklenir)
+func (x *XLSXWriter) Close() error {
+	x.sheet.WriteString(`</sheetData>`)
+	if x.columns > 0 {
+		fmt.Fprintf(x.sheet, `<autoFilter ref="A1:%s%d"/>`, xlsxColumnName(x.columns-1), x.row)
+	}
+	x.sheet.WriteString(`</worksheet>`)
+	if err := x.sheet.Flush(); err != nil {
+		return err
+	}
+	return x.archive.Close()
+}
+
+// xlsxColumnName sıfır tabanlı sütun indeksini harfe çevirir (0 → A, 26 → AA)
+func xlsxColumnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// xmlEscape metni XML içine güvenle yazılacak hale getirir; XML'de geçersiz kontrol karakterleri atılır
+func xmlEscape(text string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(strings.Map(func(r rune) rune {
+		if r < 0x20 && r != '\t' && r != '\n' && r != '\r' {
+			return -1
+		}
+		return r
+	}, text)))
+	return b.String()
+}
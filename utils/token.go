@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// GenerateRandomToken kriptografik olarak güvenli, URL-safe rastgele bir token üretir
+func GenerateRandomToken(byteLength int) (string, error) {
+	buf := make([]byte, byteLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// GenerateReadableCode kullanıcının elle yazabileceği, küçük harf base32 rastgele kod üretir
+// 5 byte = 8 karakter (kurtarma kodları vb. için)
+func GenerateReadableCode(byteLength int) (string, error) {
+	buf := make([]byte, byteLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(buf)), nil
+}
+
+// HashToken token'ın SHA-256 hash'ini hex olarak döndürür
+// Token'lar veritabanında/Redis'te asla düz metin olarak saklanmaz
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// GenerateNumericCode kriptografik olarak güvenli, belirtilen uzunlukta rakamlardan oluşan kod üretir
+// SMS ile gönderilen doğrulama kodları için (başındaki sıfırlar korunur)
+func GenerateNumericCode(digits int) (string, error) {
+	max := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil)
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", digits, n), nil
+}
+
+// unambiguousAlphabet elle yazılan kodlarda karışan karakterler (0/O, 1/I/L) çıkarılmış alfabe
+const unambiguousAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+
+// GenerateUnambiguousCode kriptografik olarak güvenli, büyük harf ve rakamlardan oluşan kod üretir
+// Katılım kodu gibi insanlar arasında paylaşılıp elle girilen kodlar için
+func GenerateUnambiguousCode(length int) (string, error) {
+	max := big.NewInt(int64(len(unambiguousAlphabet)))
+	code := make([]byte, length)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code[i] = unambiguousAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
@@ -0,0 +1,74 @@
+package utils
+
+import "strings"
+
+// USER_AGENT_MAX_LENGTH oturum kaydında saklanan User-Agent'ın en fazla uzunluğu
+const USER_AGENT_MAX_LENGTH = 512
+
+// userAgentBrowsers User-Agent'taki işaret → tarayıcı adı (sıra önemli: Edge ve Opera da "Chrome" içerir)
+var userAgentBrowsers = []struct{ marker, name string }{
+	{"Edg/", "Edge"},
+	{"OPR/", "Opera"},
+	{"YaBrowser/", "Yandex"},
+	{"SamsungBrowser/", "Samsung Internet"},
+	{"Firefox/", "Firefox"},
+	{"FxiOS/", "Firefox"},
+	{"CriOS/", "Chrome"},
+	{"Chrome/", "Chrome"},
+	{"Safari/", "Safari"},
+}
+
+// userAgentSystems User-Agent'taki işaret → işletim sistemi (sıra önemli: Android "Linux" da içerir)
+var userAgentSystems = []struct{ marker, name string }{
+	{"iPhone", "iOS"},
+	{"iPad", "iPadOS"},
+	{"Android", "Android"},
+	{"Windows", "Windows"},
+	{"Mac OS X", "macOS"},
+	{"CrOS", "ChromeOS"},
+	{"Linux", "Linux"},
+}
+
+// DescribeUserAgent User-Agent'tan "Tarayıcı / Sistem" biçiminde kısa cihaz özeti çıkarır
+// Tarayıcı olmayan istemcilerde (mobil uygulama, curl) ilk ürün adı kullanılır
+func DescribeUserAgent(userAgent string) string {
+	if userAgent == "" {
+		return "Bilinmeyen cihaz"
+	}
+
+	browser := ""
+	for _, b := range userAgentBrowsers {
+		if strings.Contains(userAgent, b.marker) {
+			browser = b.name
+			break
+		}
+	}
+
+	system := ""
+	for _, s := range userAgentSystems {
+		if strings.Contains(userAgent, s.marker) {
+			system = s.name
+			break
+		}
+	}
+
+	switch {
+	case browser != "" && system != "":
+		return browser + " / " + system
+	case browser != "":
+		return browser
+	case system != "":
+		return system
+	default:
+		product := strings.SplitN(strings.Fields(userAgent)[0], "/", 2)[0]
+		return product
+	}
+}
+
+// TruncateUserAgent User-Agent'ı saklanabilir uzunluğa indirir
+func TruncateUserAgent(userAgent string) string {
+	if len(userAgent) <= USER_AGENT_MAX_LENGTH {
+		return userAgent
+	}
+	return userAgent[:USER_AGENT_MAX_LENGTH]
+}
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"hospital-platform/database"
+	"hospital-platform/model"
+)
+
+// ErrUserInactive aktif olmayan (askıda, kilitli, onay bekleyen) kullanıcılar için döner
+// Duruma özel mesaj UserStatusError ile verilir; errors.Is(err, ErrUserInactive) tüm durumları yakalar
+var ErrUserInactive = errors.New("Kullanıcı hesabı aktif değil")
+
+// UserStatusError - Kullanıcının hesap durumu girişe veya token kullanımına izin vermediğinde döner
+type UserStatusError struct {
+	Status string
+}
+
+func (e *UserStatusError) Error() string {
+	switch e.Status {
+	case model.UserStatusSuspended:
+		return "Hesabınız askıya alınmış, hastane yöneticinizle iletişime geçin"
+	case model.UserStatusLocked:
+		return "Hesabınız güvenlik nedeniyle kilitlenmiş, hastane yöneticinizle iletişime geçin"
+	case model.UserStatusPending:
+		return "Hesabınız henüz etkinleştirilmedi, hastane yöneticinizin onayını bekleyin"
+	default:
+		return ErrUserInactive.Error()
+	}
+}
+
+// Is errors.Is(err, ErrUserInactive) kontrolünün tüm durumlar için çalışmasını sağlar
+func (e *UserStatusError) Is(target error) bool {
+	return target == ErrUserInactive
+}
+
+// CheckUserStatus - Durum active değilse UserStatusError döner
+func CheckUserStatus(status string) error {
+	if status == model.UserStatusActive {
+		return nil
+	}
+	return &UserStatusError{Status: status}
+}
+
+// GetUserStatus - Kullanıcının hesap durumunu önbellekten, yoksa veritabanından okur
+// Silinmiş kullanıcılar için gorm.ErrRecordNotFound döner
+func GetUserStatus(userID uint) (string, error) {
+	status, found, err := database.GetUserStatus(userID)
+	if err != nil {
+		return "", err
+	}
+	if found {
+		return status, nil
+	}
+
+	var user model.User
+	if err := database.DB.Select("id", "status").First(&user, userID).Error; err != nil {
+		return "", err
+	}
+
+	if err := database.SetUserStatus(userID, user.Status); err != nil {
+		fmt.Printf("⚠️ Kullanıcı durumu önbelleğe yazılamadı: %v\n", err)
+	}
+	return user.Status, nil
+}
@@ -0,0 +1,371 @@
+package utils
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hospital-platform/config"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// WebAuthn (passkey / güvenlik anahtarı) kayıt ve doğrulama törenleri
+// Attestation "none" istenir ve attestation ifadesi doğrulanmaz: kimlik doğrulaması cihazın
+// markasına değil, kayıt sırasında oturum açmış kullanıcıya bağlanan açık anahtara dayanır.
+
+const (
+	WEBAUTHN_ALG_ES256 = -7   // ECDSA P-256 + SHA-256
+	WEBAUTHN_ALG_EDDSA = -8   // Ed25519
+	WEBAUTHN_ALG_RS256 = -257 // RSASSA-PKCS1-v1_5 + SHA-256
+
+	WEBAUTHN_CEREMONY_TTL = 5 * time.Minute // Challenge geçerlilik süresi (tarayıcıya timeout olarak da gönderilir)
+
+	WEBAUTHN_TYPE_CREATE = "webauthn.create"
+	WEBAUTHN_TYPE_GET    = "webauthn.get"
+)
+
+// Authenticator data bayrakları
+const (
+	webauthnFlagUserPresent    = 0x01
+	webauthnFlagUserVerified   = 0x04
+	webauthnFlagBackupEligible = 0x08
+	webauthnFlagBackupState    = 0x10
+	webauthnFlagAttestedData   = 0x40
+	webauthnFlagExtensionData  = 0x80
+)
+
+// WebAuthnAlgorithms kabul edilen COSE algoritmaları (tarayıcıya tercih sırasıyla gönderilir)
+var WebAuthnAlgorithms = []int64{WEBAUTHN_ALG_ES256, WEBAUTHN_ALG_EDDSA, WEBAUTHN_ALG_RS256}
+
+var ErrWebAuthnInvalid = errors.New("passkey yanıtı doğrulanamadı")
+
+// WebAuthnConfig - Relying party (bu uygulama) bilgileri
+type WebAuthnConfig struct {
+	RPID    string   // Kayıtlı alan adı (ör. hbys.hastane.com) - passkey'ler bu alan adına bağlanır
+	RPName  string   // Kullanıcıya gösterilen uygulama adı
+	Origins []string // Tarayıcı yanıtlarında kabul edilen origin'ler
+}
+
+// GetWebAuthnConfig relying party ayarlarını ortam değişkenlerinden okur
+func GetWebAuthnConfig() WebAuthnConfig {
+	var origins []string
+	for _, origin := range strings.Split(config.GetEnv("WEBAUTHN_ORIGINS", "http://localhost:3000"), ",") {
+		if origin = strings.TrimRight(strings.TrimSpace(origin), "/"); origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+	return WebAuthnConfig{
+		RPID:    config.GetEnv("WEBAUTHN_RP_ID", "localhost"),
+		RPName:  config.GetEnv("WEBAUTHN_RP_NAME", "Hastane Yönetim Platformu"),
+		Origins: origins,
+	}
+}
+
+// WebAuthnClientData - Tarayıcının imzalanan isteğe eklediği clientDataJSON
+type WebAuthnClientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin"`
+}
+
+// WebAuthnAuthenticatorData - Authenticator'ın imzaladığı veri
+type WebAuthnAuthenticatorData struct {
+	RPIDHash       []byte
+	UserPresent    bool
+	UserVerified   bool
+	BackupEligible bool
+	BackupState    bool
+	SignCount      uint32
+
+	// Sadece kayıt töreninde dolu
+	AAGUID       []byte
+	CredentialID []byte
+	PublicKey    []byte // COSE kodlu açık anahtar
+}
+
+// WebAuthnCredential - Kayıt töreninden çıkan, saklanacak kimlik bilgisi
+type WebAuthnCredential struct {
+	ID             []byte
+	PublicKey      []byte // COSE kodlu - doğrulamada tekrar çözülür
+	Algorithm      int64
+	SignCount      uint32
+	AAGUID         string
+	BackupEligible bool
+	BackupState    bool
+}
+
+// ParseWebAuthnClientData clientDataJSON'u çözer
+// Challenge'ı okuyup ilgili töreni bulmak için doğrulamadan önce çağrılabilir
+func ParseWebAuthnClientData(clientDataJSON []byte) (*WebAuthnClientData, error) {
+	var clientData WebAuthnClientData
+	if err := json.Unmarshal(clientDataJSON, &clientData); err != nil {
+		return nil, fmt.Errorf("%w: clientDataJSON çözülemedi", ErrWebAuthnInvalid)
+	}
+	return &clientData, nil
+}
+
+// VerifyWebAuthnRegistration navigator.credentials.create() yanıtını doğrular
+// challenge: törende üretilen base64url challenge
+func VerifyWebAuthnRegistration(cfg WebAuthnConfig, challenge string, clientDataJSON, attestationObject []byte) (*WebAuthnCredential, error) {
+	if err := verifyWebAuthnClientData(cfg, clientDataJSON, WEBAUTHN_TYPE_CREATE, challenge); err != nil {
+		return nil, err
+	}
+
+	decoded, _, err := DecodeCBOR(attestationObject)
+	if err != nil {
+		return nil, fmt.Errorf("%w: attestationObject çözülemedi: %v", ErrWebAuthnInvalid, err)
+	}
+	attestation, ok := decoded.(map[interface{}]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: attestationObject map değil", ErrWebAuthnInvalid)
+	}
+	rawAuthData, ok := attestation["authData"].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("%w: authData eksik", ErrWebAuthnInvalid)
+	}
+
+	authData, err := ParseWebAuthnAuthenticatorData(rawAuthData)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyWebAuthnAuthenticatorData(cfg, authData); err != nil {
+		return nil, err
+	}
+	if authData.CredentialID == nil {
+		return nil, fmt.Errorf("%w: yanıtta kimlik bilgisi yok", ErrWebAuthnInvalid)
+	}
+
+	_, algorithm, err := ParseCOSEKey(authData.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebAuthnCredential{
+		ID:             authData.CredentialID,
+		PublicKey:      authData.PublicKey,
+		Algorithm:      algorithm,
+		SignCount:      authData.SignCount,
+		AAGUID:         formatAAGUID(authData.AAGUID),
+		BackupEligible: authData.BackupEligible,
+		BackupState:    authData.BackupState,
+	}, nil
+}
+
+// VerifyWebAuthnAssertion navigator.credentials.get() yanıtının imzasını kayıtlı açık anahtarla doğrular
+// Başarılı olursa authenticator data döner (imza sayacı ve yedekleme durumu güncellenmeli)
+func VerifyWebAuthnAssertion(cfg WebAuthnConfig, challenge string, publicKeyCOSE, clientDataJSON, rawAuthData, signature []byte) (*WebAuthnAuthenticatorData, error) {
+	if err := verifyWebAuthnClientData(cfg, clientDataJSON, WEBAUTHN_TYPE_GET, challenge); err != nil {
+		return nil, err
+	}
+
+	authData, err := ParseWebAuthnAuthenticatorData(rawAuthData)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyWebAuthnAuthenticatorData(cfg, authData); err != nil {
+		return nil, err
+	}
+
+	publicKey, algorithm, err := ParseCOSEKey(publicKeyCOSE)
+	if err != nil {
+		return nil, err
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := append(append([]byte(nil), rawAuthData...), clientDataHash[:]...)
+	if !verifyWebAuthnSignature(publicKey, algorithm, signed, signature) {
+		return nil, fmt.Errorf("%w: imza geçersiz", ErrWebAuthnInvalid)
+	}
+
+	return authData, nil
+}
+
+// WebAuthnSignCountValid yeni imza sayacının kayıtlı sayaçtan büyük olup olmadığını kontrol eder
+// Sayaç tutmayan authenticator'lar hep 0 gönderir ve kabul edilir; sayaç bir kez artmışsa
+// geriye giden veya aynı kalan değer anahtarın kopyalandığını gösterir
+func WebAuthnSignCountValid(stored, received int64) bool {
+	if stored == 0 && received == 0 {
+		return true
+	}
+	return received > stored
+}
+
+// ParseWebAuthnAuthenticatorData authenticator data byte dizisini çözer
+func ParseWebAuthnAuthenticatorData(data []byte) (*WebAuthnAuthenticatorData, error) {
+	if len(data) < 37 {
+		return nil, fmt.Errorf("%w: authenticator data çok kısa", ErrWebAuthnInvalid)
+	}
+
+	flags := data[32]
+	authData := &WebAuthnAuthenticatorData{
+		RPIDHash:       data[:32],
+		UserPresent:    flags&webauthnFlagUserPresent != 0,
+		UserVerified:   flags&webauthnFlagUserVerified != 0,
+		BackupEligible: flags&webauthnFlagBackupEligible != 0,
+		BackupState:    flags&webauthnFlagBackupState != 0,
+		SignCount:      binary.BigEndian.Uint32(data[33:37]),
+	}
+	rest := data[37:]
+
+	if flags&webauthnFlagAttestedData != 0 {
+		if len(rest) < 18 {
+			return nil, fmt.Errorf("%w: kimlik bilgisi verisi eksik", ErrWebAuthnInvalid)
+		}
+		authData.AAGUID = rest[:16]
+		idLength := int(binary.BigEndian.Uint16(rest[16:18]))
+		rest = rest[18:]
+		if idLength == 0 || idLength > 1023 || len(rest) < idLength {
+			return nil, fmt.Errorf("%w: kimlik bilgisi ID uzunluğu geçersiz", ErrWebAuthnInvalid)
+		}
+		authData.CredentialID = rest[:idLength]
+		rest = rest[idLength:]
+
+		_, consumed, err := DecodeCBOR(rest)
+		if err != nil {
+			return nil, fmt.Errorf("%w: açık anahtar çözülemedi: %v", ErrWebAuthnInvalid, err)
+		}
+		authData.PublicKey = rest[:consumed]
+		rest = rest[consumed:]
+	}
+
+	if flags&webauthnFlagExtensionData != 0 {
+		_, consumed, err := DecodeCBOR(rest)
+		if err != nil {
+			return nil, fmt.Errorf("%w: uzantı verisi çözülemedi: %v", ErrWebAuthnInvalid, err)
+		}
+		rest = rest[consumed:]
+	}
+
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("%w: authenticator data sonunda fazla veri", ErrWebAuthnInvalid)
+	}
+	return authData, nil
+}
+
+// ParseCOSEKey COSE_Key yapısını Go açık anahtarına çevirir
+// Desteklenen türler: EC2 P-256 (ES256), OKP Ed25519 (EdDSA), RSA (RS256)
+func ParseCOSEKey(data []byte) (crypto.PublicKey, int64, error) {
+	decoded, _, err := DecodeCBOR(data)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: COSE anahtarı çözülemedi: %v", ErrWebAuthnInvalid, err)
+	}
+	key, ok := decoded.(map[interface{}]interface{})
+	if !ok {
+		return nil, 0, fmt.Errorf("%w: COSE anahtarı map değil", ErrWebAuthnInvalid)
+	}
+
+	keyType, _ := key[int64(1)].(int64)
+	algorithm, _ := key[int64(3)].(int64)
+
+	switch {
+	case keyType == 2 && algorithm == WEBAUTHN_ALG_ES256:
+		curve, _ := key[int64(-1)].(int64)
+		x, _ := key[int64(-2)].([]byte)
+		y, _ := key[int64(-3)].([]byte)
+		if curve != 1 || len(x) != 32 || len(y) != 32 {
+			return nil, 0, fmt.Errorf("%w: P-256 anahtarı geçersiz", ErrWebAuthnInvalid)
+		}
+		publicKey := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !publicKey.Curve.IsOnCurve(publicKey.X, publicKey.Y) {
+			return nil, 0, fmt.Errorf("%w: P-256 noktası eğri üzerinde değil", ErrWebAuthnInvalid)
+		}
+		return publicKey, algorithm, nil
+
+	case keyType == 1 && algorithm == WEBAUTHN_ALG_EDDSA:
+		curve, _ := key[int64(-1)].(int64)
+		x, _ := key[int64(-2)].([]byte)
+		if curve != 6 || len(x) != ed25519.PublicKeySize {
+			return nil, 0, fmt.Errorf("%w: Ed25519 anahtarı geçersiz", ErrWebAuthnInvalid)
+		}
+		return ed25519.PublicKey(x), algorithm, nil
+
+	case keyType == 3 && algorithm == WEBAUTHN_ALG_RS256:
+		n, _ := key[int64(-1)].([]byte)
+		e, _ := key[int64(-2)].([]byte)
+		if len(n) < 256 || len(e) == 0 || len(e) > 4 {
+			return nil, 0, fmt.Errorf("%w: RSA anahtarı geçersiz veya 2048 bitten kısa", ErrWebAuthnInvalid)
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, algorithm, nil
+	}
+
+	return nil, 0, fmt.Errorf("%w: desteklenmeyen anahtar türü (kty=%d, alg=%d)", ErrWebAuthnInvalid, keyType, algorithm)
+}
+
+// verifyWebAuthnClientData tören türünü, challenge'ı ve origin'i kontrol eder
+func verifyWebAuthnClientData(cfg WebAuthnConfig, clientDataJSON []byte, ceremonyType, challenge string) error {
+	clientData, err := ParseWebAuthnClientData(clientDataJSON)
+	if err != nil {
+		return err
+	}
+	if clientData.Type != ceremonyType {
+		return fmt.Errorf("%w: tören türü hatalı (%s)", ErrWebAuthnInvalid, clientData.Type)
+	}
+	if challenge == "" || subtle.ConstantTimeCompare([]byte(clientData.Challenge), []byte(challenge)) != 1 {
+		return fmt.Errorf("%w: challenge eşleşmiyor", ErrWebAuthnInvalid)
+	}
+	if clientData.CrossOrigin {
+		return fmt.Errorf("%w: cross-origin tören kabul edilmez", ErrWebAuthnInvalid)
+	}
+
+	for _, origin := range cfg.Origins {
+		if clientData.Origin == origin {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: origin izinli değil (%s)", ErrWebAuthnInvalid, clientData.Origin)
+}
+
+// verifyWebAuthnAuthenticatorData RP ID hash'ini ve kullanıcı doğrulama bayraklarını kontrol eder
+// Passkey şifre + ikinci adımın yerine geçtiği için kullanıcı doğrulaması (PIN/biyometri) zorunludur
+func verifyWebAuthnAuthenticatorData(cfg WebAuthnConfig, authData *WebAuthnAuthenticatorData) error {
+	rpIDHash := sha256.Sum256([]byte(cfg.RPID))
+	if !bytes.Equal(authData.RPIDHash, rpIDHash[:]) {
+		return fmt.Errorf("%w: RP ID eşleşmiyor", ErrWebAuthnInvalid)
+	}
+	if !authData.UserPresent {
+		return fmt.Errorf("%w: kullanıcı varlığı doğrulanmadı", ErrWebAuthnInvalid)
+	}
+	if !authData.UserVerified {
+		return fmt.Errorf("%w: kullanıcı doğrulaması (PIN/biyometri) yapılmadı", ErrWebAuthnInvalid)
+	}
+	if authData.BackupState && !authData.BackupEligible {
+		return fmt.Errorf("%w: yedekleme bayrakları tutarsız", ErrWebAuthnInvalid)
+	}
+	return nil
+}
+
+// verifyWebAuthnSignature authenticator imzasını algoritmaya göre doğrular
+func verifyWebAuthnSignature(publicKey crypto.PublicKey, algorithm int64, signed, signature []byte) bool {
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(signed)
+		return algorithm == WEBAUTHN_ALG_ES256 && ecdsa.VerifyASN1(key, digest[:], signature)
+	case ed25519.PublicKey:
+		return algorithm == WEBAUTHN_ALG_EDDSA && ed25519.Verify(key, signed, signature)
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(signed)
+		return algorithm == WEBAUTHN_ALG_RS256 && rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) == nil
+	}
+	return false
+}
+
+// formatAAGUID authenticator modelini UUID biçiminde döndürür (attestation none ile genelde sıfırdır)
+func formatAAGUID(aaguid []byte) string {
+	if len(aaguid) != 16 {
+		return ""
+	}
+	h := hex.EncodeToString(aaguid)
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:32]
+}
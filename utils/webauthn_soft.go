@@ -0,0 +1,114 @@
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+)
+
+// ==================== YAZILIM AUTHENTICATOR'I ====================
+
+// SoftAuthenticator - Passkey akışını tarayıcısız denemek için ES256 yazılım authenticator'ı
+// Sadece geliştirme/test içindir (cmd/soft-authenticator ve testler); PIN/biyometri sorulmaz,
+// yanıtlar yine de kullanıcı doğrulandı bayrağıyla imzalanır ve attestation "none" döner.
+type SoftAuthenticator struct {
+	Origin string // clientDataJSON'a yazılan origin
+
+	// Reddedilmesi gereken yanıtları üretmek için (testler)
+	CrossOrigin bool // clientDataJSON'da crossOrigin=true
+	SkipUV      bool // Kullanıcı doğrulaması bayrağı olmadan imzalar
+}
+
+// SoftCredential - Yazılım authenticator'ında tutulan kimlik bilgisi
+type SoftCredential struct {
+	ID         []byte
+	RPID       string
+	PrivateKey *ecdsa.PrivateKey
+	SignCount  uint32
+}
+
+// Register yeni ES256 anahtar üretir ve navigator.credentials.create() yanıtının
+// clientDataJSON ve attestationObject alanlarını döndürür
+func (a *SoftAuthenticator) Register(rpID, challenge string) (*SoftCredential, []byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	credentialID := make([]byte, 16)
+	if _, err := rand.Read(credentialID); err != nil {
+		return nil, nil, nil, err
+	}
+
+	// COSE_Key: kty=EC2, alg=ES256, crv=P-256, x, y
+	coseKey := cborMap([][2][]byte{
+		{cborInt(1), cborInt(2)},
+		{cborInt(3), cborInt(WEBAUTHN_ALG_ES256)},
+		{cborInt(-1), cborInt(1)},
+		{cborInt(-2), cborBytes(key.PublicKey.X.FillBytes(make([]byte, 32)))},
+		{cborInt(-3), cborBytes(key.PublicKey.Y.FillBytes(make([]byte, 32)))},
+	})
+
+	authData := a.authenticatorData(rpID, webauthnFlagAttestedData, 0)
+	authData = append(authData, make([]byte, 16)...) // AAGUID
+	authData = binary.BigEndian.AppendUint16(authData, uint16(len(credentialID)))
+	authData = append(authData, credentialID...)
+	authData = append(authData, coseKey...)
+
+	attestationObject := cborMap([][2][]byte{
+		{cborText("fmt"), cborText("none")},
+		{cborText("attStmt"), cborMap(nil)},
+		{cborText("authData"), cborBytes(authData)},
+	})
+
+	clientDataJSON, err := a.clientData(WEBAUTHN_TYPE_CREATE, challenge)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	credential := &SoftCredential{ID: credentialID, RPID: rpID, PrivateKey: key}
+	return credential, clientDataJSON, attestationObject, nil
+}
+
+// Assert imza sayacını artırır ve navigator.credentials.get() yanıtının
+// clientDataJSON, authenticatorData ve signature alanlarını döndürür
+func (a *SoftAuthenticator) Assert(credential *SoftCredential, challenge string) ([]byte, []byte, []byte, error) {
+	credential.SignCount++
+	authData := a.authenticatorData(credential.RPID, 0, credential.SignCount)
+
+	clientDataJSON, err := a.clientData(WEBAUTHN_TYPE_GET, challenge)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	digest := sha256.Sum256(append(append([]byte(nil), authData...), clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, credential.PrivateKey, digest[:])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return clientDataJSON, authData, signature, nil
+}
+
+// authenticatorData rpIdHash || flags || signCount başlığını üretir
+func (a *SoftAuthenticator) authenticatorData(rpID string, flags byte, signCount uint32) []byte {
+	flags |= webauthnFlagUserPresent
+	if !a.SkipUV {
+		flags |= webauthnFlagUserVerified
+	}
+
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	data := append(rpIDHash[:], flags)
+	return binary.BigEndian.AppendUint32(data, signCount)
+}
+
+func (a *SoftAuthenticator) clientData(ceremonyType, challenge string) ([]byte, error) {
+	return json.Marshal(WebAuthnClientData{
+		Type:        ceremonyType,
+		Challenge:   challenge,
+		Origin:      a.Origin,
+		CrossOrigin: a.CrossOrigin,
+	})
+}
@@ -0,0 +1,285 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+const (
+	testRPID      = "hbys.example.com"
+	testOrigin    = "https://hbys.example.com"
+	testChallenge = "Y2hhbGxlbmdlLWJpcg"
+)
+
+var testWebAuthnConfig = WebAuthnConfig{
+	RPID:    testRPID,
+	RPName:  "Test",
+	Origins: []string{testOrigin},
+}
+
+// registerSoftCredential yazılım authenticator'ı ile kayıt yapar ve sunucunun sakladığı kimlik bilgisini döndürür
+func registerSoftCredential(t *testing.T) (*SoftCredential, *WebAuthnCredential) {
+	t.Helper()
+
+	authenticator := &SoftAuthenticator{Origin: testOrigin}
+	soft, clientDataJSON, attestationObject, err := authenticator.Register(testRPID, testChallenge)
+	if err != nil {
+		t.Fatalf("kayıt yanıtı üretilemedi: %v", err)
+	}
+	credential, err := VerifyWebAuthnRegistration(testWebAuthnConfig, testChallenge, clientDataJSON, attestationObject)
+	if err != nil {
+		t.Fatalf("kayıt doğrulanamadı: %v", err)
+	}
+	return soft, credential
+}
+
+func TestWebAuthnRegistrationAndAssertion(t *testing.T) {
+	soft, credential := registerSoftCredential(t)
+
+	if !bytes.Equal(credential.ID, soft.ID) {
+		t.Fatalf("kimlik bilgisi ID'si farklı: %x != %x", credential.ID, soft.ID)
+	}
+	if credential.Algorithm != WEBAUTHN_ALG_ES256 || credential.SignCount != 0 {
+		t.Fatalf("beklenmeyen kimlik bilgisi: alg=%d sayaç=%d", credential.Algorithm, credential.SignCount)
+	}
+
+	authenticator := &SoftAuthenticator{Origin: testOrigin}
+	clientDataJSON, authData, signature, err := authenticator.Assert(soft, "Z2lyaXMtY2hhbGxlbmdl")
+	if err != nil {
+		t.Fatalf("giriş yanıtı üretilemedi: %v", err)
+	}
+	result, err := VerifyWebAuthnAssertion(testWebAuthnConfig, "Z2lyaXMtY2hhbGxlbmdl", credential.PublicKey, clientDataJSON, authData, signature)
+	if err != nil {
+		t.Fatalf("giriş doğrulanamadı: %v", err)
+	}
+	if !result.UserVerified || result.SignCount != 1 {
+		t.Fatalf("beklenmeyen authenticator verisi: %+v", result)
+	}
+	if !WebAuthnSignCountValid(int64(credential.SignCount), int64(result.SignCount)) {
+		t.Fatal("artan imza sayacı reddedildi")
+	}
+}
+
+func TestWebAuthnRejectsInvalidRegistration(t *testing.T) {
+	tests := []struct {
+		name          string
+		authenticator SoftAuthenticator
+		rpID          string
+		ceremony      string
+	}{
+		{"yanlış RP ID", SoftAuthenticator{Origin: testOrigin}, "evil.example.com", testChallenge},
+		{"yanlış origin", SoftAuthenticator{Origin: "https://evil.example.com"}, testRPID, testChallenge},
+		{"cross-origin", SoftAuthenticator{Origin: testOrigin, CrossOrigin: true}, testRPID, testChallenge},
+		{"kullanıcı doğrulaması yok", SoftAuthenticator{Origin: testOrigin, SkipUV: true}, testRPID, testChallenge},
+		{"başka challenge", SoftAuthenticator{Origin: testOrigin}, testRPID, "YmFza2EtY2hhbGxlbmdl"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, clientDataJSON, attestationObject, err := tt.authenticator.Register(tt.rpID, tt.ceremony)
+			if err != nil {
+				t.Fatalf("kayıt yanıtı üretilemedi: %v", err)
+			}
+			_, err = VerifyWebAuthnRegistration(testWebAuthnConfig, testChallenge, clientDataJSON, attestationObject)
+			if !errors.Is(err, ErrWebAuthnInvalid) {
+				t.Fatalf("ErrWebAuthnInvalid beklenirdi, gelen: %v", err)
+			}
+		})
+	}
+}
+
+func TestWebAuthnRejectsInvalidAssertion(t *testing.T) {
+	soft, credential := registerSoftCredential(t)
+
+	tests := []struct {
+		name          string
+		authenticator SoftAuthenticator
+		rpID          string
+	}{
+		{"yanlış RP ID", SoftAuthenticator{Origin: testOrigin}, "evil.example.com"},
+		{"yanlış origin", SoftAuthenticator{Origin: "https://hbys.example.com.evil.net"}, testRPID},
+		{"cross-origin", SoftAuthenticator{Origin: testOrigin, CrossOrigin: true}, testRPID},
+		{"kullanıcı doğrulaması yok", SoftAuthenticator{Origin: testOrigin, SkipUV: true}, testRPID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer := &SoftCredential{RPID: tt.rpID, PrivateKey: soft.PrivateKey, SignCount: soft.SignCount}
+			clientDataJSON, authData, signature, err := tt.authenticator.Assert(signer, testChallenge)
+			if err != nil {
+				t.Fatalf("giriş yanıtı üretilemedi: %v", err)
+			}
+			_, err = VerifyWebAuthnAssertion(testWebAuthnConfig, testChallenge, credential.PublicKey, clientDataJSON, authData, signature)
+			if !errors.Is(err, ErrWebAuthnInvalid) {
+				t.Fatalf("ErrWebAuthnInvalid beklenirdi, gelen: %v", err)
+			}
+		})
+	}
+
+	t.Run("değiştirilmiş authenticator data", func(t *testing.T) {
+		authenticator := &SoftAuthenticator{Origin: testOrigin}
+		clientDataJSON, authData, signature, err := authenticator.Assert(soft, testChallenge)
+		if err != nil {
+			t.Fatalf("giriş yanıtı üretilemedi: %v", err)
+		}
+		tampered := append([]byte(nil), authData...)
+		binary.BigEndian.PutUint32(tampered[33:37], 1000)
+		_, err = VerifyWebAuthnAssertion(testWebAuthnConfig, testChallenge, credential.PublicKey, clientDataJSON, tampered, signature)
+		if !errors.Is(err, ErrWebAuthnInvalid) {
+			t.Fatalf("ErrWebAuthnInvalid beklenirdi, gelen: %v", err)
+		}
+	})
+
+	t.Run("kayıt yanıtı girişte", func(t *testing.T) {
+		authenticator := &SoftAuthenticator{Origin: testOrigin}
+		_, clientDataJSON, _, err := authenticator.Register(testRPID, testChallenge)
+		if err != nil {
+			t.Fatalf("kayıt yanıtı üretilemedi: %v", err)
+		}
+		_, authData, signature, err := authenticator.Assert(soft, testChallenge)
+		if err != nil {
+			t.Fatalf("giriş yanıtı üretilemedi: %v", err)
+		}
+		_, err = VerifyWebAuthnAssertion(testWebAuthnConfig, testChallenge, credential.PublicKey, clientDataJSON, authData, signature)
+		if !errors.Is(err, ErrWebAuthnInvalid) {
+			t.Fatalf("ErrWebAuthnInvalid beklenirdi, gelen: %v", err)
+		}
+	})
+}
+
+// Sunucu her girişte yeni challenge üretir ve eskisini siler; önceki törenin yanıtı yeni challenge ile doğrulanamaz
+func TestWebAuthnRejectsReplayedChallenge(t *testing.T) {
+	soft, credential := registerSoftCredential(t)
+
+	authenticator := &SoftAuthenticator{Origin: testOrigin}
+	clientDataJSON, authData, signature, err := authenticator.Assert(soft, testChallenge)
+	if err != nil {
+		t.Fatalf("giriş yanıtı üretilemedi: %v", err)
+	}
+	if _, err := VerifyWebAuthnAssertion(testWebAuthnConfig, testChallenge, credential.PublicKey, clientDataJSON, authData, signature); err != nil {
+		t.Fatalf("ilk giriş doğrulanamadı: %v", err)
+	}
+
+	for _, challenge := range []string{"eWVuaS1jaGFsbGVuZ2U", ""} {
+		_, err := VerifyWebAuthnAssertion(testWebAuthnConfig, challenge, credential.PublicKey, clientDataJSON, authData, signature)
+		if !errors.Is(err, ErrWebAuthnInvalid) {
+			t.Fatalf("tekrar oynatılan yanıt (challenge %q) kabul edildi: %v", challenge, err)
+		}
+	}
+}
+
+func TestWebAuthnSignCountValid(t *testing.T) {
+	tests := []struct {
+		stored, received int64
+		valid            bool
+	}{
+		{0, 0, true},  // Sayaç tutmayan authenticator
+		{0, 1, true},  // İlk giriş
+		{5, 6, true},  // Normal artış
+		{5, 5, false}, // Aynı kaldı - kopya anahtar
+		{5, 3, false}, // Geriye gitti
+		{5, 0, false}, // Sayaç sıfırlandı
+	}
+	for _, tt := range tests {
+		if got := WebAuthnSignCountValid(tt.stored, tt.received); got != tt.valid {
+			t.Errorf("WebAuthnSignCountValid(%d, %d) = %v, beklenen %v", tt.stored, tt.received, got, tt.valid)
+		}
+	}
+
+	// Kopyalanmış anahtar: imza geçerli ama sayaç kayıtlı değerin gerisinde
+	soft, credential := registerSoftCredential(t)
+	authenticator := &SoftAuthenticator{Origin: testOrigin}
+	for i := 0; i < 3; i++ {
+		if _, _, _, err := authenticator.Assert(soft, testChallenge); err != nil {
+			t.Fatalf("giriş yanıtı üretilemedi: %v", err)
+		}
+	}
+	clone := &SoftCredential{RPID: soft.RPID, PrivateKey: soft.PrivateKey, SignCount: 1}
+	clientDataJSON, authData, signature, err := authenticator.Assert(clone, testChallenge)
+	if err != nil {
+		t.Fatalf("giriş yanıtı üretilemedi: %v", err)
+	}
+	result, err := VerifyWebAuthnAssertion(testWebAuthnConfig, testChallenge, credential.PublicKey, clientDataJSON, authData, signature)
+	if err != nil {
+		t.Fatalf("imza doğrulanamadı: %v", err)
+	}
+	if WebAuthnSignCountValid(int64(soft.SignCount), int64(result.SignCount)) {
+		t.Fatalf("geriye giden sayaç kabul edildi: %d <= %d", result.SignCount, soft.SignCount)
+	}
+}
+
+func TestWebAuthnRejectsMalformedCBOR(t *testing.T) {
+	authenticator := &SoftAuthenticator{Origin: testOrigin}
+	_, clientDataJSON, attestationObject, err := authenticator.Register(testRPID, testChallenge)
+	if err != nil {
+		t.Fatalf("kayıt yanıtı üretilemedi: %v", err)
+	}
+
+	// Her uzunlukta kesilmiş attestation nesnesi hata vermeli
+	for n := 0; n < len(attestationObject); n++ {
+		_, err := VerifyWebAuthnRegistration(testWebAuthnConfig, testChallenge, clientDataJSON, attestationObject[:n])
+		if !errors.Is(err, ErrWebAuthnInvalid) {
+			t.Fatalf("%d byte'a kesilmiş attestation kabul edildi: %v", n, err)
+		}
+	}
+
+	malicious := map[string][]byte{
+		"dev byte dizisi":          {0x5b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		"dev dizi":                 {0x9b, 0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		"dev map":                  {0xbb, 0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		"belirsiz uzunluk":         {0x5f, 0x41, 0x00, 0xff},
+		"taşan tam sayı":           {0x1b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		"derin iç içe dizi":        bytes.Repeat([]byte{0x81}, 10000),
+		"derin iç içe etiket":      bytes.Repeat([]byte{0xc6}, 10000),
+		"byte anahtarlı map":       {0xa1, 0x41, 0x00, 0x00},
+		"tekrarlanan map anahtarı": {0xa2, 0x01, 0x00, 0x01, 0x00},
+		"authData metin":           cborMap([][2][]byte{{cborText("authData"), cborText("x")}}),
+		"kısa authData":            cborMap([][2][]byte{{cborText("authData"), cborBytes(make([]byte, 36))}}),
+	}
+	for name, data := range malicious {
+		t.Run(name, func(t *testing.T) {
+			_, err := VerifyWebAuthnRegistration(testWebAuthnConfig, testChallenge, clientDataJSON, data)
+			if !errors.Is(err, ErrWebAuthnInvalid) {
+				t.Fatalf("ErrWebAuthnInvalid beklenirdi, gelen: %v", err)
+			}
+			if _, _, err := ParseCOSEKey(data); err == nil {
+				t.Fatal("COSE anahtarı olarak kabul edildi")
+			}
+		})
+	}
+
+	// Rastgele bozulmuş girdiler panik üretmemeli (panik testi düşürür)
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 5000; i++ {
+		corrupted := append([]byte(nil), attestationObject...)
+		for j := 0; j < 1+rng.Intn(4); j++ {
+			corrupted[rng.Intn(len(corrupted))] = byte(rng.Intn(256))
+		}
+		VerifyWebAuthnRegistration(testWebAuthnConfig, testChallenge, clientDataJSON, corrupted)
+		ParseWebAuthnAuthenticatorData(corrupted)
+		ParseCOSEKey(corrupted)
+	}
+}
+
+func FuzzDecodeCBOR(f *testing.F) {
+	authenticator := &SoftAuthenticator{Origin: testOrigin}
+	_, _, attestationObject, err := authenticator.Register(testRPID, testChallenge)
+	if err != nil {
+		f.Fatalf("kayıt yanıtı üretilemedi: %v", err)
+	}
+	f.Add(attestationObject)
+	f.Add([]byte{0x5b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+	f.Add([]byte{0xa1, 0x01, 0xf9, 0x7c, 0x00})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		value, consumed, err := DecodeCBOR(data)
+		if err == nil && (consumed <= 0 || consumed > len(data)) {
+			t.Fatalf("geçersiz tüketilen byte sayısı %d (veri %d byte), değer %v", consumed, len(data), value)
+		}
+		ParseCOSEKey(data)
+		ParseWebAuthnAuthenticatorData(data)
+	})
+}